@@ -0,0 +1,544 @@
+package archive
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/umbracle/fastrlp"
+	"github.com/xgr-network/xgr-node/blockchain"
+	"github.com/xgr-network/xgr-node/helper/common"
+	"github.com/xgr-network/xgr-node/helper/progress"
+	"github.com/xgr-network/xgr-node/types"
+	"github.com/xgr-network/xgr-node/types/buildroot"
+)
+
+// A blocks file starts with blocksFileMagic followed by one record per block:
+//
+//	uint32 payload length | payload | uint32 crc32 of the payload
+//
+// where the payload is the RLP list [block RLP, receipts storage RLP].
+// Records are written in ascending block order without gaps
+const (
+	blocksImport = "import"
+
+	recordPrefixSize   = 4
+	recordChecksumSize = 4
+
+	// maxRecordSize bounds the allocation for a record before its checksum is verified
+	maxRecordSize = 256 * 1024 * 1024
+)
+
+var blocksFileMagic = []byte("XGRBLK01")
+
+var (
+	ErrNotBlocksFile      = errors.New("not a blocks file")
+	ErrCorruptedRecord    = errors.New("corrupted record in blocks file")
+	ErrTruncatedRecord    = errors.New("truncated record at the end of blocks file")
+	ErrParentHashMismatch = errors.New("block parent hash doesn't match the previous block")
+	ErrBlocksFileGap      = errors.New("blocks file doesn't continue the local chain")
+	ErrBlocksFileRange    = errors.New("blocks file doesn't cover the start of the requested range")
+)
+
+// blockRecord is a block with the receipts of its transactions
+type blockRecord struct {
+	Block    *types.Block
+	Receipts types.Receipts
+}
+
+func (r *blockRecord) marshalRLP() []byte {
+	ar := fastrlp.DefaultArenaPool.Get()
+	defer fastrlp.DefaultArenaPool.Put(ar)
+
+	vv := ar.NewArray()
+	vv.Set(ar.NewBytes(r.Block.MarshalRLP()))
+	vv.Set(ar.NewBytes(r.Receipts.MarshalStoreRLPTo(nil)))
+
+	return vv.MarshalTo(nil)
+}
+
+func (r *blockRecord) unmarshalRLP(input []byte) error {
+	pr := fastrlp.DefaultParserPool.Get()
+	defer fastrlp.DefaultParserPool.Put(pr)
+
+	v, err := pr.Parse(input)
+	if err != nil {
+		return err
+	}
+
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) != 2 {
+		return fmt.Errorf("incorrect number of elements to decode block record, expected 2 but found %d", len(elems))
+	}
+
+	rawBlock, err := elems[0].Bytes()
+	if err != nil {
+		return err
+	}
+
+	rawReceipts, err := elems[1].Bytes()
+	if err != nil {
+		return err
+	}
+
+	r.Block = &types.Block{}
+	if err := r.Block.UnmarshalRLP(rawBlock); err != nil {
+		return err
+	}
+
+	r.Receipts = types.Receipts{}
+
+	return r.Receipts.UnmarshalStoreRLP(rawReceipts)
+}
+
+// blockRecordReader reads the records of a blocks file
+type blockRecordReader struct {
+	input io.Reader
+	// offset is the end of the last record read successfully
+	offset int64
+}
+
+func newBlockRecordReader(input io.Reader) (*blockRecordReader, error) {
+	magic := make([]byte, len(blocksFileMagic))
+	if _, err := io.ReadFull(input, magic); err != nil || string(magic) != string(blocksFileMagic) {
+		return nil, ErrNotBlocksFile
+	}
+
+	return &blockRecordReader{
+		input:  input,
+		offset: int64(len(blocksFileMagic)),
+	}, nil
+}
+
+// next returns the next record, or nil at the end of the file
+func (r *blockRecordReader) next() (*blockRecord, error) {
+	var prefix [recordPrefixSize]byte
+
+	if _, err := io.ReadFull(r.input, prefix[:]); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+
+		return nil, r.readError(err)
+	}
+
+	size := binary.BigEndian.Uint32(prefix[:])
+	if size > maxRecordSize {
+		return nil, fmt.Errorf("%w: record at offset %d has size %d", ErrCorruptedRecord, r.offset, size)
+	}
+
+	buf := make([]byte, int(size)+recordChecksumSize)
+	if _, err := io.ReadFull(r.input, buf); err != nil {
+		return nil, r.readError(err)
+	}
+
+	payload, checksum := buf[:size], buf[size:]
+	if crc32.ChecksumIEEE(payload) != binary.BigEndian.Uint32(checksum) {
+		return nil, fmt.Errorf("%w: checksum mismatch of record at offset %d", ErrCorruptedRecord, r.offset)
+	}
+
+	record := &blockRecord{}
+	if err := record.unmarshalRLP(payload); err != nil {
+		return nil, fmt.Errorf("%w: record at offset %d: %w", ErrCorruptedRecord, r.offset, err)
+	}
+
+	r.offset += int64(recordPrefixSize + len(buf))
+
+	return record, nil
+}
+
+func (r *blockRecordReader) readError(err error) error {
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return fmt.Errorf("%w: record at offset %d", ErrTruncatedRecord, r.offset)
+	}
+
+	return err
+}
+
+// writeBlockRecord appends the record of the block to the writer
+func writeBlockRecord(writer io.Writer, record *blockRecord) error {
+	payload := record.marshalRLP()
+
+	buf := make([]byte, 0, recordPrefixSize+len(payload)+recordChecksumSize)
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(payload)))
+	buf = append(buf, payload...)
+	buf = binary.BigEndian.AppendUint32(buf, crc32.ChecksumIEEE(payload))
+
+	_, err := writer.Write(buf)
+
+	return err
+}
+
+// blockReader is the part of the blockchain storage the blocks are exported from
+type blockReader interface {
+	ReadCanonicalHash(n uint64) (types.Hash, bool)
+	ReadHeadNumber() (uint64, bool)
+	ReadHeader(hash types.Hash) (*types.Header, error)
+	ReadBody(hash types.Hash) (*types.Body, error)
+	ReadReceipts(hash types.Hash) ([]*types.Receipt, error)
+}
+
+// ExportBlocks writes the canonical blocks from..to with their receipts to the blocks file at outPath.
+// If to is nil, the export ends at the head block. If the file already exists, it must hold
+// a previous export ending right before from or inside the range; the export resumes after
+// its last complete record and a partially written record is discarded.
+// It returns the range of blocks in the file
+func ExportBlocks(
+	reader blockReader,
+	logger hclog.Logger,
+	from uint64,
+	to *uint64,
+	outPath string,
+) (uint64, uint64, error) {
+	head, ok := reader.ReadHeadNumber()
+	if !ok {
+		return 0, 0, errors.New("blockchain storage has no head block")
+	}
+
+	last := head
+	if to != nil && *to < head {
+		last = *to
+	}
+
+	if from > last {
+		return 0, 0, fmt.Errorf("from %d is after the last block %d", from, last)
+	}
+
+	fs, first, next, err := openBlocksFileForExport(outPath, logger, from)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	writer := bufio.NewWriter(fs)
+
+	next, err = exportBlocks(reader, logger, writer, next, last)
+	if err == nil {
+		err = writer.Flush()
+	}
+
+	if err == nil {
+		err = fs.Sync()
+	}
+
+	if closeErr := fs.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if next == first {
+		return 0, 0, errors.New("no blocks were exported")
+	}
+
+	return first, next - 1, nil
+}
+
+// openBlocksFileForExport opens the blocks file for appending and returns
+// the first block in the file and the next block to export
+func openBlocksFileForExport(outPath string, logger hclog.Logger, from uint64) (*os.File, uint64, uint64, error) {
+	fs, err := os.OpenFile(outPath, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	info, err := fs.Stat()
+	if err != nil {
+		_ = fs.Close()
+
+		return nil, 0, 0, err
+	}
+
+	if info.Size() == 0 {
+		if _, err := fs.Write(blocksFileMagic); err != nil {
+			_ = fs.Close()
+
+			return nil, 0, 0, err
+		}
+
+		return fs, from, from, nil
+	}
+
+	first, last, end, err := scanBlocksFile(fs)
+	if err != nil {
+		_ = fs.Close()
+
+		return nil, 0, 0, err
+	}
+
+	if end < info.Size() {
+		logger.Info("Discarding the partially written record at the end of blocks file", "offset", end)
+	}
+
+	// drop the partially written record, if any, and append after the last complete one
+	if err := fs.Truncate(end); err != nil {
+		_ = fs.Close()
+
+		return nil, 0, 0, err
+	}
+
+	if _, err := fs.Seek(end, io.SeekStart); err != nil {
+		_ = fs.Close()
+
+		return nil, 0, 0, err
+	}
+
+	if last == nil {
+		return fs, from, from, nil
+	}
+
+	if from < first || from > *last+1 {
+		_ = fs.Close()
+
+		return nil, 0, 0, fmt.Errorf("%w: file holds blocks %d..%d, requested from %d",
+			ErrBlocksFileRange, first, *last, from)
+	}
+
+	logger.Info("Resuming export of blocks file", "first", first, "last", *last)
+
+	return fs, first, *last + 1, nil
+}
+
+// scanBlocksFile returns the first and the last block of the complete records in the file
+// and the offset where they end. Only a truncated last record is tolerated
+func scanBlocksFile(input io.Reader) (uint64, *uint64, int64, error) {
+	reader, err := newBlockRecordReader(bufio.NewReader(input))
+	if err != nil {
+		return 0, nil, 0, err
+	}
+
+	var (
+		first uint64
+		last  *uint64
+	)
+
+	for {
+		record, err := reader.next()
+		if errors.Is(err, ErrTruncatedRecord) {
+			return first, last, reader.offset, nil
+		} else if err != nil {
+			return 0, nil, 0, err
+		}
+
+		if record == nil {
+			return first, last, reader.offset, nil
+		}
+
+		number := record.Block.Number()
+		if last == nil {
+			first = number
+		}
+
+		last = &number
+	}
+}
+
+// exportBlocks writes the blocks from..to and returns the next block to export,
+// which is before to+1 if the export was interrupted
+func exportBlocks(reader blockReader, logger hclog.Logger, writer io.Writer, from, to uint64) (uint64, error) {
+	shutdownCh := common.GetTerminationSignalCh()
+
+	for number := from; number <= to; number++ {
+		select {
+		case <-shutdownCh:
+			logger.Info("Caught termination signal, the export can be resumed", "next", number)
+
+			return number, nil
+		default:
+		}
+
+		record, err := readBlockRecord(reader, number)
+		if err != nil {
+			return number, err
+		}
+
+		if err := writeBlockRecord(writer, record); err != nil {
+			return number, err
+		}
+
+		if number%1000 == 0 || number == to {
+			logger.Info("Exported blocks", "current", number, "to", to)
+		}
+	}
+
+	return to + 1, nil
+}
+
+func readBlockRecord(reader blockReader, number uint64) (*blockRecord, error) {
+	hash, ok := reader.ReadCanonicalHash(number)
+	if !ok {
+		return nil, fmt.Errorf("canonical hash of block %d not found", number)
+	}
+
+	header, err := reader.ReadHeader(hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header of block %d: %w", number, err)
+	}
+
+	body, err := reader.ReadBody(hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read body of block %d: %w", number, err)
+	}
+
+	receipts, err := reader.ReadReceipts(hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read receipts of block %d: %w", number, err)
+	}
+
+	return &blockRecord{
+		Block: &types.Block{
+			Header:       header,
+			Transactions: body.Transactions,
+			Uncles:       body.Uncles,
+		},
+		Receipts: receipts,
+	}, nil
+}
+
+// blockImporter is the chain the blocks are imported into
+type blockImporter interface {
+	SubscribeEvents() blockchain.Subscription
+	UnsubscribeEvents(blockchain.Subscription)
+	Header() *types.Header
+	GetHashByNumber(uint64) types.Hash
+	VerifyFinalizedBlock(*types.Block) (*types.FullBlock, error)
+	WriteFullBlock(*types.FullBlock, string) error
+}
+
+// ImportBlocks writes the blocks of the blocks file at filePath to the chain.
+// Blocks the chain already has are skipped, so an interrupted import can be run again.
+// By default every block is executed and verified like a block received from a peer.
+// In fast mode the receipts of the file are trusted: only the transactions and receipts roots
+// are checked against the headers, the blocks are not executed and their state is not built
+func ImportBlocks(
+	chain blockImporter,
+	filePath string,
+	fast bool,
+	progression *progress.ProgressionWrapper,
+) error {
+	fp, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+
+	defer fp.Close()
+
+	reader, err := newBlockRecordReader(bufio.NewReader(fp))
+	if err != nil {
+		return err
+	}
+
+	return importBlockRecords(chain, reader, fast, progression)
+}
+
+func importBlockRecords(
+	chain blockImporter,
+	reader *blockRecordReader,
+	fast bool,
+	progression *progress.ProgressionWrapper,
+) error {
+	shutdownCh := common.GetTerminationSignalCh()
+
+	// Create a blockchain subscription for the import progression, tracked from the first new block
+	subscription := chain.SubscribeEvents()
+	defer func() {
+		progression.StopProgression()
+		chain.UnsubscribeEvents(subscription)
+	}()
+
+	var (
+		parent  *types.Header
+		started bool
+	)
+
+	for {
+		record, err := reader.next()
+		if err != nil {
+			return err
+		}
+
+		if record == nil {
+			return nil
+		}
+
+		block := record.Block
+
+		if parent != nil && block.ParentHash() != parent.Hash {
+			return fmt.Errorf("%w: block %d has parent %s, expected %s",
+				ErrParentHashMismatch, block.Number(), block.ParentHash(), parent.Hash)
+		}
+
+		parent = block.Header
+
+		head := chain.Header()
+
+		if block.Number() <= head.Number {
+			// already imported, the file must hold the same chain
+			if hash := chain.GetHashByNumber(block.Number()); hash != block.Hash() {
+				return fmt.Errorf("%w: block %d is %s in the file but %s in the local chain",
+					ErrBlocksFileGap, block.Number(), block.Hash(), hash)
+			}
+
+			continue
+		}
+
+		if block.Number() != head.Number+1 || block.ParentHash() != head.Hash {
+			return fmt.Errorf("%w: local head is %d (%s), next block in the file is %d with parent %s",
+				ErrBlocksFileGap, head.Number, head.Hash, block.Number(), block.ParentHash())
+		}
+
+		if !started {
+			progression.StartProgression(block.Number(), subscription)
+
+			started = true
+		}
+
+		fullBlock, err := verifyBlockRecord(chain, record, fast)
+		if err != nil {
+			return fmt.Errorf("failed to verify block %d: %w", block.Number(), err)
+		}
+
+		if err := chain.WriteFullBlock(fullBlock, blocksImport); err != nil {
+			return err
+		}
+
+		progression.UpdateCurrentProgression(block.Number())
+
+		select {
+		case <-shutdownCh:
+			return nil
+		default:
+		}
+	}
+}
+
+func verifyBlockRecord(chain blockImporter, record *blockRecord, fast bool) (*types.FullBlock, error) {
+	if !fast {
+		return chain.VerifyFinalizedBlock(record.Block)
+	}
+
+	header := record.Block.Header
+
+	if hash := buildroot.CalculateTransactionsRoot(record.Block.Transactions, header.Number); hash != header.TxRoot {
+		return nil, fmt.Errorf("transactions root %s doesn't match header %s", hash, header.TxRoot)
+	}
+
+	if hash := buildroot.CalculateReceiptsRoot(record.Receipts); hash != header.ReceiptsRoot {
+		return nil, fmt.Errorf("receipts root %s doesn't match header %s", hash, header.ReceiptsRoot)
+	}
+
+	return &types.FullBlock{
+		Block:    record.Block,
+		Receipts: record.Receipts,
+	}, nil
+}
@@ -0,0 +1,347 @@
+package archive
+
+import (
+	"bytes"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+	"github.com/xgr-network/xgr-node/blockchain"
+	"github.com/xgr-network/xgr-node/blockchain/storage"
+	"github.com/xgr-network/xgr-node/blockchain/storage/memory"
+	"github.com/xgr-network/xgr-node/helper/progress"
+	"github.com/xgr-network/xgr-node/types"
+	"github.com/xgr-network/xgr-node/types/buildroot"
+)
+
+// newTestStorageChain writes a chain of the genesis and n blocks with one transaction each
+// to a memory storage and returns the storage and the blocks
+func newTestStorageChain(t *testing.T, n int) (storage.Storage, []*types.FullBlock) {
+	t.Helper()
+
+	db, err := memory.NewMemoryStorage(nil)
+	require.NoError(t, err)
+
+	status := types.ReceiptSuccess
+	parent := &types.Header{Number: 0, ExtraData: []byte("genesis")}
+	parent.ComputeHash()
+
+	chain := []*types.FullBlock{{Block: &types.Block{Header: parent}}}
+
+	for i := 1; i <= n; i++ {
+		to := types.StringToAddress("0x2")
+
+		tx := &types.Transaction{
+			Type:     types.LegacyTx,
+			Nonce:    uint64(i - 1),
+			GasPrice: big.NewInt(1000),
+			Gas:      21000,
+			To:       &to,
+			Value:    big.NewInt(int64(i)),
+			V:        big.NewInt(27),
+			R:        big.NewInt(1),
+			S:        big.NewInt(1),
+		}
+		tx.ComputeHash(uint64(i))
+
+		receipts := []*types.Receipt{{
+			Status:            &status,
+			CumulativeGasUsed: 21000,
+			GasUsed:           21000,
+			TxHash:            tx.Hash,
+		}}
+
+		header := &types.Header{
+			Number:       uint64(i),
+			ParentHash:   parent.Hash,
+			GasLimit:     30000000,
+			GasUsed:      21000,
+			Timestamp:    uint64(i),
+			TxRoot:       buildroot.CalculateTransactionsRoot([]*types.Transaction{tx}, uint64(i)),
+			ReceiptsRoot: buildroot.CalculateReceiptsRoot(receipts),
+		}
+		header.ComputeHash()
+
+		chain = append(chain, &types.FullBlock{
+			Block: &types.Block{
+				Header:       header,
+				Transactions: []*types.Transaction{tx},
+			},
+			Receipts: receipts,
+		})
+
+		parent = header
+	}
+
+	batch := storage.NewBatchWriter(db)
+
+	for _, b := range chain {
+		batch.PutHeader(b.Block.Header)
+		batch.PutBody(b.Block.Hash(), b.Block.Body())
+		batch.PutReceipts(b.Block.Hash(), b.Receipts)
+		batch.PutCanonicalHash(b.Block.Number(), b.Block.Hash())
+	}
+
+	batch.PutHeadHash(parent.Hash)
+	batch.PutHeadNumber(parent.Number)
+
+	require.NoError(t, batch.WriteBatch())
+
+	return db, chain
+}
+
+// mockImportChain is a chain which accepts the blocks on top of its head
+type mockImportChain struct {
+	blocks []*types.FullBlock
+	// verified counts the blocks passed to VerifyFinalizedBlock
+	verified int
+}
+
+func newMockImportChain(blocks []*types.FullBlock) *mockImportChain {
+	return &mockImportChain{
+		blocks: append([]*types.FullBlock{}, blocks...),
+	}
+}
+
+func (m *mockImportChain) SubscribeEvents() blockchain.Subscription {
+	return blockchain.NewMockSubscription()
+}
+
+func (m *mockImportChain) UnsubscribeEvents(blockchain.Subscription) {}
+
+func (m *mockImportChain) Header() *types.Header {
+	return m.blocks[len(m.blocks)-1].Block.Header
+}
+
+func (m *mockImportChain) GetHashByNumber(n uint64) types.Hash {
+	if n >= uint64(len(m.blocks)) {
+		return types.ZeroHash
+	}
+
+	return m.blocks[n].Block.Hash()
+}
+
+func (m *mockImportChain) VerifyFinalizedBlock(block *types.Block) (*types.FullBlock, error) {
+	m.verified++
+
+	return &types.FullBlock{Block: block}, nil
+}
+
+func (m *mockImportChain) WriteFullBlock(block *types.FullBlock, _ string) error {
+	m.blocks = append(m.blocks, block)
+
+	return nil
+}
+
+func exportTestBlocks(t *testing.T, db storage.Storage, from uint64, to *uint64, out string) (uint64, uint64) {
+	t.Helper()
+
+	resFrom, resTo, err := ExportBlocks(db, hclog.NewNullLogger(), from, to, out)
+	require.NoError(t, err)
+
+	return resFrom, resTo
+}
+
+func importTestBlocks(chain *mockImportChain, path string, fast bool) error {
+	return ImportBlocks(chain, path, fast, progress.NewProgressionWrapper(progress.ChainSyncRestore))
+}
+
+func TestBlocksFile_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	db, blocks := newTestStorageChain(t, 5)
+	out := filepath.Join(t.TempDir(), "blocks.rlp")
+
+	from, to := exportTestBlocks(t, db, 1, nil, out)
+	require.Equal(t, uint64(1), from)
+	require.Equal(t, uint64(5), to)
+
+	t.Run("verified import", func(t *testing.T) {
+		t.Parallel()
+
+		chain := newMockImportChain(blocks[:1])
+
+		require.NoError(t, importTestBlocks(chain, out, false))
+		require.Equal(t, 5, chain.verified)
+		require.Len(t, chain.blocks, 6)
+
+		for i, b := range chain.blocks {
+			require.Equal(t, blocks[i].Block.Hash(), b.Block.Hash())
+		}
+	})
+
+	t.Run("fast import", func(t *testing.T) {
+		t.Parallel()
+
+		chain := newMockImportChain(blocks[:1])
+
+		require.NoError(t, importTestBlocks(chain, out, true))
+		require.Zero(t, chain.verified)
+		require.Len(t, chain.blocks, 6)
+
+		for i, b := range chain.blocks[1:] {
+			expected := blocks[i+1]
+
+			require.Equal(t, expected.Block.Hash(), b.Block.Hash())
+			require.Equal(t, expected.Block.Transactions[0].Hash, b.Block.Transactions[0].Hash)
+			require.Len(t, b.Receipts, 1)
+			require.Equal(t, expected.Receipts[0].TxHash, b.Receipts[0].TxHash)
+			require.Equal(t, expected.Receipts[0].GasUsed, b.Receipts[0].GasUsed)
+		}
+	})
+
+	t.Run("import skips known blocks", func(t *testing.T) {
+		t.Parallel()
+
+		chain := newMockImportChain(blocks[:3])
+
+		require.NoError(t, importTestBlocks(chain, out, false))
+		require.Equal(t, 3, chain.verified)
+		require.Len(t, chain.blocks, 6)
+	})
+
+	t.Run("import requires the parent of the first new block", func(t *testing.T) {
+		t.Parallel()
+
+		other := &types.Header{Number: 0, ExtraData: []byte("other genesis")}
+		other.ComputeHash()
+
+		chain := newMockImportChain([]*types.FullBlock{{Block: &types.Block{Header: other}}})
+
+		require.ErrorIs(t, importTestBlocks(chain, out, false), ErrBlocksFileGap)
+	})
+}
+
+func TestBlocksFile_ExportResume(t *testing.T) {
+	t.Parallel()
+
+	db, _ := newTestStorageChain(t, 6)
+	dir := t.TempDir()
+
+	full := filepath.Join(dir, "full.rlp")
+	exportTestBlocks(t, db, 1, nil, full)
+
+	expected, err := os.ReadFile(full)
+	require.NoError(t, err)
+
+	// an export of the first blocks which was interrupted while writing a record
+	partial := filepath.Join(dir, "partial.rlp")
+	to := uint64(3)
+	exportTestBlocks(t, db, 1, &to, partial)
+
+	written, err := os.ReadFile(partial)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(partial, append(written, expected[len(written):len(written)+10]...), 0600))
+
+	from, last := exportTestBlocks(t, db, 1, nil, partial)
+	require.Equal(t, uint64(1), from)
+	require.Equal(t, uint64(6), last)
+
+	resumed, err := os.ReadFile(partial)
+	require.NoError(t, err)
+	require.Equal(t, expected, resumed)
+
+	// resuming requires the file to cover the start of the range
+	_, _, err = ExportBlocks(db, hclog.NewNullLogger(), 0, nil, partial)
+	require.ErrorIs(t, err, ErrBlocksFileRange)
+}
+
+func TestBlocksFile_DetectsInvalidRecords(t *testing.T) {
+	t.Parallel()
+
+	db, blocks := newTestStorageChain(t, 3)
+	dir := t.TempDir()
+
+	out := filepath.Join(dir, "blocks.rlp")
+	exportTestBlocks(t, db, 1, nil, out)
+
+	data, err := os.ReadFile(out)
+	require.NoError(t, err)
+
+	writeFile := func(name string, data []byte) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, data, 0600))
+
+		return path
+	}
+
+	t.Run("corrupted record", func(t *testing.T) {
+		t.Parallel()
+
+		corrupted := append([]byte{}, data...)
+		corrupted[len(corrupted)-10] ^= 0xff
+
+		chain := newMockImportChain(blocks[:1])
+
+		require.ErrorIs(t, importTestBlocks(chain, writeFile("corrupted.rlp", corrupted), false), ErrCorruptedRecord)
+		// the records before the corrupted one are imported
+		require.Len(t, chain.blocks, 3)
+	})
+
+	t.Run("truncated record", func(t *testing.T) {
+		t.Parallel()
+
+		chain := newMockImportChain(blocks[:1])
+
+		require.ErrorIs(t, importTestBlocks(chain, writeFile("truncated.rlp", data[:len(data)-3]), false), ErrTruncatedRecord)
+		require.Len(t, chain.blocks, 3)
+	})
+
+	t.Run("not a blocks file", func(t *testing.T) {
+		t.Parallel()
+
+		chain := newMockImportChain(blocks[:1])
+
+		require.ErrorIs(t, importTestBlocks(chain, writeFile("other.rlp", []byte("not a blocks file")), false), ErrNotBlocksFile)
+	})
+
+	t.Run("broken parent hash continuity", func(t *testing.T) {
+		t.Parallel()
+
+		var file []byte
+
+		file = append(file, blocksFileMagic...)
+
+		for _, b := range []*types.FullBlock{blocks[1], blocks[3]} {
+			file = appendTestRecord(t, file, b)
+		}
+
+		chain := newMockImportChain(blocks[:1])
+
+		require.ErrorIs(t, importTestBlocks(chain, writeFile("gap.rlp", file), false), ErrParentHashMismatch)
+		require.Len(t, chain.blocks, 2)
+	})
+
+	t.Run("fast import checks the receipts", func(t *testing.T) {
+		t.Parallel()
+
+		status := types.ReceiptFailed
+		forged := &types.FullBlock{
+			Block: blocks[1].Block,
+			Receipts: []*types.Receipt{{
+				Status:            &status,
+				CumulativeGasUsed: 21000,
+				GasUsed:           21000,
+				TxHash:            blocks[1].Block.Transactions[0].Hash,
+			}},
+		}
+
+		file := appendTestRecord(t, append([]byte{}, blocksFileMagic...), forged)
+		chain := newMockImportChain(blocks[:1])
+
+		require.ErrorContains(t, importTestBlocks(chain, writeFile("forged.rlp", file), true), "receipts root")
+		require.Len(t, chain.blocks, 1)
+	})
+}
+
+func appendTestRecord(t *testing.T, dst []byte, block *types.FullBlock) []byte {
+	t.Helper()
+
+	buf := bytes.NewBuffer(dst)
+	require.NoError(t, writeBlockRecord(buf, &blockRecord{Block: block.Block, Receipts: block.Receipts}))
+
+	return buf.Bytes()
+}
@@ -8,12 +8,15 @@ import (
 	"sync"
 	"sync/atomic"
 
+	"github.com/armon/go-metrics"
 	"github.com/hashicorp/go-hclog"
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/xgr-network/xgr-node/blockchain/storage"
 	"github.com/xgr-network/xgr-node/chain"
+	"github.com/xgr-network/xgr-node/contracts"
 	"github.com/xgr-network/xgr-node/helper/common"
 	"github.com/xgr-network/xgr-node/state"
+	"github.com/xgr-network/xgr-node/state/runtime/addresslist"
 	"github.com/xgr-network/xgr-node/types"
 	"github.com/xgr-network/xgr-node/types/buildroot"
 )
@@ -24,6 +27,12 @@ const (
 
 	// defaultCacheSize is the default size for Blockchain LRU cache structures
 	defaultCacheSize int = 100
+
+	// maxLazyBlockEconomicsTxs is the largest block whose economics are computed from the
+	// receipts on request, for blocks imported before the aggregates were stored
+	maxLazyBlockEconomicsTxs = 10_000
+
+	blockchainMetrics = "blockchain"
 )
 
 var (
@@ -38,6 +47,7 @@ var (
 	ErrInvalidStateRoot     = errors.New("invalid block state root")
 	ErrInvalidGasUsed       = errors.New("invalid block gas used")
 	ErrInvalidReceiptsRoot  = errors.New("invalid block receipts root")
+	ErrReorgBelowFinalized  = errors.New("reorg below the finalized block")
 )
 
 // Blockchain is a blockchain reference
@@ -66,6 +76,10 @@ type Blockchain struct {
 	// any new fields from being added
 	receiptsCache *lru.Cache // LRU cache for the block receipts
 
+	// addressBloomCache keeps the address activity blooms between verification and insertion,
+	// for the same reasons as the receiptsCache
+	addressBloomCache *lru.Cache
+
 	currentHeader     atomic.Pointer[types.Header] // The current header
 	currentDifficulty atomic.Pointer[big.Int]      // The current difficulty of the chain (total difficulty)
 
@@ -73,6 +87,17 @@ type Blockchain struct {
 
 	gpAverage *gasPriceAverage // A reference to the average gas price
 
+	shadowFork *ShadowFork // Records divergences instead of rejecting blocks (nil if disabled)
+
+	blockObserver BlockObserver // Notified about the committed canonical blocks (nil if disabled)
+
+	maxReorgDepth   uint64        // Maximum number of canonical blocks a reorg may replace (0 if unlimited)
+	finalizedNumber atomic.Uint64 // The latest block that can never be reorged out
+
+	logIndexTail    atomic.Uint64 // The first block of the log index (0 if the index is not started)
+	logIndexCloseCh chan struct{} // Stops the log index backfill (nil if it is not running)
+	logIndexWg      sync.WaitGroup
+
 	writeLock sync.Mutex
 }
 
@@ -84,6 +109,11 @@ type gasPriceAverage struct {
 	count *big.Int // Param used in the avg. gas price calculation
 }
 
+// BlockObserver is notified about the fee split of every block committed to the canonical chain
+type BlockObserver interface {
+	BlockCommitted(header *types.Header, fees *types.BlockEconomics)
+}
+
 type Verifier interface {
 	VerifyHeader(header *types.Header) error
 	ProcessHeaders(headers []*types.Header) error
@@ -104,6 +134,9 @@ type BlockResult struct {
 	Root     types.Hash
 	Receipts []*types.Receipt
 	TotalGas uint64
+
+	// Accounts are the account changes of the block, only collected in shadow-fork mode
+	Accounts []*state.AccountDiff
 }
 
 // updateGasPriceAvg updates the rolling average value of the gas price
@@ -238,6 +271,11 @@ func (b *Blockchain) initCaches(size int) error {
 		return fmt.Errorf("unable to create receipts cache, %w", err)
 	}
 
+	b.addressBloomCache, err = lru.New(size)
+	if err != nil {
+		return fmt.Errorf("unable to create address bloom cache, %w", err)
+	}
+
 	return nil
 }
 
@@ -298,6 +336,61 @@ func (b *Blockchain) SetConsensus(c Verifier) {
 	b.consensus = c
 }
 
+// SetShadowFork enables shadow-fork mode. Blocks whose execution result doesn't match
+// the header are recorded by the shadow fork instead of being rejected.
+func (b *Blockchain) SetShadowFork(s *ShadowFork) {
+	b.shadowFork = s
+}
+
+// SetBlockObserver sets the observer notified about the committed canonical blocks
+func (b *Blockchain) SetBlockObserver(o BlockObserver) {
+	b.blockObserver = o
+}
+
+// SetMaxReorgDepth sets the maximum number of canonical blocks a reorg may replace, 0 disables the limit
+func (b *Blockchain) SetMaxReorgDepth(depth uint64) {
+	b.maxReorgDepth = depth
+}
+
+// SetFinalized marks the block with the given number as finalized (e.g. checkpointed).
+// Reorgs below the finalized block are refused. The finalized block never moves backwards.
+func (b *Blockchain) SetFinalized(number uint64) {
+	for {
+		current := b.finalizedNumber.Load()
+		if number <= current || b.finalizedNumber.CompareAndSwap(current, number) {
+			return
+		}
+	}
+}
+
+// FinalizedNumber returns the number of the latest block that can't be reorged out,
+// either explicitly finalized or further back than the maximum reorg depth
+func (b *Blockchain) FinalizedNumber() uint64 {
+	finalized := b.finalizedNumber.Load()
+
+	if b.maxReorgDepth == 0 {
+		return finalized
+	}
+
+	if head := b.Header(); head != nil && head.Number > b.maxReorgDepth {
+		if depthFinalized := head.Number - b.maxReorgDepth; depthFinalized > finalized {
+			return depthFinalized
+		}
+	}
+
+	return finalized
+}
+
+// ShadowDivergences returns the divergences recorded from the given block on.
+// It returns false if the node doesn't run in shadow-fork mode.
+func (b *Blockchain) ShadowDivergences(from uint64) ([]*Divergence, bool) {
+	if b.shadowFork == nil {
+		return nil, false
+	}
+
+	return b.shadowFork.Divergences(from), true
+}
+
 // setCurrentHeader sets the current header
 func (b *Blockchain) setCurrentHeader(h *types.Header, diff *big.Int) {
 	// Update the header (atomic)
@@ -580,6 +673,10 @@ func (b *Blockchain) WriteHeadersWithBodies(headers []*types.Header) error {
 			return err
 		}
 
+		if isCanonical {
+			b.updateLogIndex(batchWriter, event, header, nil)
+		}
+
 		if err := b.writeBatchAndUpdate(batchWriter, header, newTD, isCanonical); err != nil {
 			return err
 		}
@@ -721,7 +818,15 @@ func (b *Blockchain) verifyBlockBody(block *types.Block) ([]*types.Receipt, erro
 
 	// Verify the local execution result with the proposed block data
 	if err := blockResult.verifyBlockResult(block); err != nil {
-		return nil, fmt.Errorf("unable to verify block execution result, %w", err)
+		if b.shadowFork == nil || errors.Is(err, ErrInvalidReceiptsSize) {
+			return nil, fmt.Errorf("unable to verify block execution result, %w", err)
+		}
+
+		if err := b.shadowFork.record(block, blockResult); err != nil {
+			return nil, err
+		}
+
+		b.logger.Warn("shadow fork divergence", "block", block.Number(), "hash", block.Hash(), "err", err)
 	}
 
 	return blockResult.Receipts, nil
@@ -785,12 +890,19 @@ func (b *Blockchain) executeBlockTransactions(block *types.Block) (*BlockResult,
 
 	// Append the receipts to the receipts cache
 	b.receiptsCache.Add(header.Hash, txn.Receipts())
+	b.addressBloomCache.Add(header.Hash, txn.AddressBloom())
 
-	return &BlockResult{
+	result := &BlockResult{
 		Root:     root,
 		Receipts: txn.Receipts(),
 		TotalGas: txn.TotalGas(),
-	}, nil
+	}
+
+	if b.shadowFork != nil {
+		result.Accounts = txn.AccountDiffs()
+	}
+
+	return result, nil
 }
 
 // WriteFullBlock writes a single block to the local blockchain.
@@ -829,6 +941,13 @@ func (b *Blockchain) WriteFullBlock(fblock *types.FullBlock, source string) erro
 	// Otherwise, a client might ask for a header once the receipt is valid,
 	// but before it is written into the storage
 	batchWriter.PutReceipts(block.Hash(), fblock.Receipts)
+	batchWriter.PutAddressBloom(block.Hash(), b.extractAddressBloom(block))
+	economics := ComputeBlockEconomics(block.Transactions, fblock.Receipts)
+	batchWriter.PutBlockEconomics(block.Hash(), economics)
+
+	if isCanonical {
+		b.updateLogIndex(batchWriter, evnt, header, fblock.Receipts)
+	}
 
 	// update snapshot
 	if err := b.consensus.ProcessHeaders([]*types.Header{header}); err != nil {
@@ -844,6 +963,10 @@ func (b *Blockchain) WriteFullBlock(fblock *types.FullBlock, source string) erro
 
 	b.dispatchEvent(evnt)
 
+	if isCanonical && b.blockObserver != nil {
+		b.blockObserver.BlockCommitted(header, economics)
+	}
+
 	logArgs := []interface{}{
 		"number", header.Number,
 		"txs", len(block.Transactions),
@@ -900,6 +1023,13 @@ func (b *Blockchain) WriteBlock(block *types.Block, source string) error {
 	// Otherwise, a client might ask for a header once the receipt is valid,
 	// but before it is written into the storage
 	batchWriter.PutReceipts(block.Hash(), blockReceipts)
+	batchWriter.PutAddressBloom(block.Hash(), b.extractAddressBloom(block))
+	economics := ComputeBlockEconomics(block.Transactions, blockReceipts)
+	batchWriter.PutBlockEconomics(block.Hash(), economics)
+
+	if isCanonical {
+		b.updateLogIndex(batchWriter, evnt, header, blockReceipts)
+	}
 
 	// update snapshot
 	if err := b.consensus.ProcessHeaders([]*types.Header{header}); err != nil {
@@ -915,6 +1045,10 @@ func (b *Blockchain) WriteBlock(block *types.Block, source string) error {
 
 	b.dispatchEvent(evnt)
 
+	if isCanonical && b.blockObserver != nil {
+		b.blockObserver.BlockCommitted(header, economics)
+	}
+
 	logArgs := []interface{}{
 		"number", header.Number,
 		"txs", len(block.Transactions),
@@ -971,6 +1105,94 @@ func (b *Blockchain) extractBlockReceipts(block *types.Block) ([]*types.Receipt,
 	return extractedReceipts, nil
 }
 
+// extractAddressBloom returns the address activity bloom of the block from the cache.
+// If the block was not executed locally, the bloom falls back to the tx senders and recipients
+func (b *Blockchain) extractAddressBloom(block *types.Block) types.AddressBloom {
+	if cached, ok := b.addressBloomCache.Get(block.Header.Hash); ok {
+		if bloom, ok := cached.(types.AddressBloom); ok {
+			return bloom
+		}
+	}
+
+	var bloom types.AddressBloom
+
+	for _, txn := range block.Transactions {
+		bloom.Add(txn.From)
+
+		if txn.To != nil {
+			bloom.Add(*txn.To)
+		}
+	}
+
+	return bloom
+}
+
+// GetAddressBloom returns the address activity bloom of the block
+func (b *Blockchain) GetAddressBloom(hash types.Hash) (types.AddressBloom, bool) {
+	return b.db.ReadAddressBloom(hash)
+}
+
+// GetBlockEconomics returns the fee split aggregates of the block.
+// Blocks imported before the aggregates were stored are computed from their receipts,
+// unless they hold more than maxLazyBlockEconomicsTxs transactions
+func (b *Blockchain) GetBlockEconomics(hash types.Hash) (*types.BlockEconomics, bool) {
+	if econ, ok := b.db.ReadBlockEconomics(hash); ok {
+		return econ, true
+	}
+
+	body, ok := b.readBody(hash)
+	if !ok || len(body.Transactions) > maxLazyBlockEconomicsTxs {
+		return nil, false
+	}
+
+	if len(body.Transactions) == 0 {
+		return types.NewBlockEconomics(), true
+	}
+
+	receipts, err := b.db.ReadReceipts(hash)
+	if err != nil || len(receipts) != len(body.Transactions) {
+		return nil, false
+	}
+
+	return ComputeBlockEconomics(body.Transactions, receipts), true
+}
+
+// ComputeBlockEconomics sums up the fee splits of the receipts and counts
+// the transactions calling the engine execute precompile. Receipts stored
+// without a fee split fall back to their fee split logs.
+func ComputeBlockEconomics(txs []*types.Transaction, receipts []*types.Receipt) *types.BlockEconomics {
+	econ := types.NewBlockEconomics()
+
+	for _, txn := range txs {
+		if txn.To != nil && *txn.To == contracts.EngineExecutePrecompile {
+			econ.EngineTxCount++
+		}
+	}
+
+	for _, receipt := range receipts {
+		if split := receipt.FeeSplit; split != nil {
+			econ.TotalDonated.Add(econ.TotalDonated, split.Donation)
+			econ.TotalValidatorFees.Add(econ.TotalValidatorFees, split.Validator)
+			econ.TotalBurned.Add(econ.TotalBurned, split.Burned)
+
+			continue
+		}
+
+		for _, log := range receipt.Logs {
+			if log.Address != state.FeeSplitLogAddress || len(log.Topics) == 0 ||
+				log.Topics[0] != state.FeeSplitLogTopic || len(log.Data) != 3*types.HashLength {
+				continue
+			}
+
+			econ.TotalDonated.Add(econ.TotalDonated, new(big.Int).SetBytes(log.Data[:32]))
+			econ.TotalValidatorFees.Add(econ.TotalValidatorFees, new(big.Int).SetBytes(log.Data[32:64]))
+			econ.TotalBurned.Add(econ.TotalBurned, new(big.Int).SetBytes(log.Data[64:]))
+		}
+	}
+
+	return econ
+}
+
 // updateGasPriceAvgWithBlock extracts the gas price information from the
 // block, and updates the average gas price for the chain accordingly
 func (b *Blockchain) updateGasPriceAvgWithBlock(block *types.Block) {
@@ -1269,6 +1491,22 @@ func (b *Blockchain) handleReorg(
 		oldChain = append(oldChain, oldHeader)
 	}
 
+	// oldHeader is the common ancestor now, a heavier branch must not replace finalized blocks
+	if finalized := b.FinalizedNumber(); oldHeader.Number < finalized {
+		metrics.IncrCounter([]string{blockchainMetrics, "rejected_reorgs"}, 1)
+
+		b.logger.Error("CRITICAL: refused reorg below the finalized block",
+			"ancestor", oldHeader.Number,
+			"finalized", finalized,
+			"head", oldChainHead.Number,
+			"new_head", newChainHead.Number,
+			"new_hash", newChainHead.Hash,
+		)
+
+		return fmt.Errorf("%w: common ancestor %d, finalized %d",
+			ErrReorgBelowFinalized, oldHeader.Number, finalized)
+	}
+
 	forks, err := b.getForksToWrite(oldChainHead)
 	if err != nil {
 		return fmt.Errorf("failed to write the old header as fork: %w", err)
@@ -1347,11 +1585,22 @@ func (b *Blockchain) GetBlockByNumber(blockNumber uint64, full bool) (*types.Blo
 	return b.GetBlockByHash(blockHash, full)
 }
 
-// Close closes the DB connection
+// Close stops the log index backfill and closes the DB connection
 func (b *Blockchain) Close() error {
+	if b.logIndexCloseCh != nil {
+		close(b.logIndexCloseCh)
+		b.logIndexWg.Wait()
+	}
+
 	return b.db.Close()
 }
 
+// MinBaseFee returns the minimum base fee configured in the EngineRegistry
+// at the state of the given header, or chain.MinBaseFee if the registry is not deployed
+func (b *Blockchain) MinBaseFee(header *types.Header) uint64 {
+	return b.resolveMinBaseFee(header)
+}
+
 func (b *Blockchain) resolveMinBaseFee(parent *types.Header) uint64 {
 
 	min := chain.MinBaseFee
@@ -1360,18 +1609,10 @@ func (b *Blockchain) resolveMinBaseFee(parent *types.Header) uint64 {
 		return min
 	}
 
-	// Avoid widening the public Executor interface; use a narrow internal assertion.
-	type stateAt interface {
-		StateAt(root types.Hash) (state.Snapshot, error)
-	}
-	sa, ok := b.executor.(stateAt)
+	snap, ok := b.snapshotAt(parent.StateRoot)
 	if !ok {
 		return min
 	}
-	snap, err := sa.StateAt(parent.StateRoot)
-	if err != nil {
-		return min
-	}
 
 	acc, err := snap.GetAccount(reg)
 	if err != nil || acc == nil {
@@ -1390,6 +1631,54 @@ func (b *Blockchain) resolveMinBaseFee(parent *types.Header) uint64 {
 	return v.Uint64() // erlaubt auch 0
 }
 
+// snapshotAt returns the state snapshot at the root, if the executor can provide it
+func (b *Blockchain) snapshotAt(root types.Hash) (state.Snapshot, bool) {
+	// Avoid widening the public Executor interface; use a narrow internal assertion.
+	type stateAt interface {
+		StateAt(root types.Hash) (state.Snapshot, error)
+	}
+
+	sa, ok := b.executor.(stateAt)
+	if !ok {
+		return nil, false
+	}
+
+	snap, err := sa.StateAt(root)
+	if err != nil {
+		return nil, false
+	}
+
+	return snap, true
+}
+
+// BridgeBlocksTransfer reports whether the bridge block list at the state of the header
+// rejects a top-level value transfer to the address, which is the case for blocked accounts
+// without code. It doesn't check the StrictBridgeBlockList fork
+func (b *Blockchain) BridgeBlocksTransfer(header *types.Header, to types.Address) bool {
+	if b.config.Params.BridgeBlockList == nil {
+		return false
+	}
+
+	snap, ok := b.snapshotAt(header.StateRoot)
+	if !ok {
+		return false
+	}
+
+	if acc, err := snap.GetAccount(to); err == nil && acc != nil &&
+		!bytes.Equal(acc.CodeHash, types.EmptyCodeHash.Bytes()) && !bytes.Equal(acc.CodeHash, types.ZeroHash.Bytes()) {
+		return false
+	}
+
+	list, err := snap.GetAccount(contracts.BlockListBridgeAddr)
+	if err != nil || list == nil {
+		return false
+	}
+
+	role := snap.GetStorage(contracts.BlockListBridgeAddr, list.Root, types.BytesToHash(to.Bytes()))
+
+	return addresslist.Role(role) == addresslist.EnabledRole
+}
+
 // mulDivClampU64 computes floor(a*b/(c*d)) using big.Int to avoid overflow and clamps to uint64 max.
 func mulDivClampU64(a, b, c, d uint64) uint64 {
 	if a == 0 || b == 0 {
@@ -9,9 +9,11 @@ import (
 
 	"github.com/hashicorp/go-hclog"
 	lru "github.com/hashicorp/golang-lru"
+	"github.com/xgr-network/xgr-node/contracts"
 	"github.com/xgr-network/xgr-node/helper/common"
 	"github.com/xgr-network/xgr-node/helper/hex"
 	"github.com/xgr-network/xgr-node/state"
+	itrie "github.com/xgr-network/xgr-node/state/immutable-trie"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -551,6 +553,75 @@ func TestForkUnknownParents(t *testing.T) {
 	assert.Error(t, b.WriteHeadersWithBodies([]*types.Header{h1[12]}))
 }
 
+func TestReorgBelowFinalized(t *testing.T) {
+	// newChain writes 10 canonical headers (0..9)
+	newChain := func(t *testing.T) (*Blockchain, []*types.Header) {
+		t.Helper()
+
+		b := NewTestBlockchain(t, nil)
+		headers := NewTestHeaders(10)
+
+		batchWriter := storage.NewBatchWriter(b.db)
+		td := new(big.Int).SetUint64(headers[0].Difficulty)
+
+		batchWriter.PutCanonicalHeader(headers[0], td)
+
+		require.NoError(t, b.writeBatchAndUpdate(batchWriter, headers[0], td, true))
+		require.NoError(t, b.WriteHeadersWithBodies(headers[1:]))
+
+		return b, headers
+	}
+
+	// branch returns a heavier competing branch forking off after ancestor
+	branch := func(headers []*types.Header, ancestor uint64) []*types.Header {
+		return AppendNewTestheadersWithSeed(headers[:ancestor+1], 12, 1)[ancestor+1:]
+	}
+
+	t.Run("explicitly finalized block", func(t *testing.T) {
+		b, headers := newChain(t)
+		b.SetFinalized(5)
+
+		// the finalized block never moves backwards
+		b.SetFinalized(3)
+		assert.Equal(t, uint64(5), b.FinalizedNumber())
+
+		err := b.WriteHeadersWithBodies(branch(headers, 4))
+		assert.ErrorIs(t, err, ErrReorgBelowFinalized)
+		assert.Equal(t, headers[9].Hash, b.Header().Hash)
+
+		// the finalized block itself is kept by a reorg forking off it
+		newBranch := branch(headers, 5)
+		assert.NoError(t, b.WriteHeadersWithBodies(newBranch))
+		assert.Equal(t, newBranch[len(newBranch)-1].Hash, b.Header().Hash)
+	})
+
+	t.Run("max reorg depth", func(t *testing.T) {
+		b, headers := newChain(t)
+		b.SetMaxReorgDepth(3)
+
+		// head is 9, blocks up to 6 can't be reorged out
+		assert.Equal(t, uint64(6), b.FinalizedNumber())
+
+		err := b.WriteHeadersWithBodies(branch(headers, 5))
+		assert.ErrorIs(t, err, ErrReorgBelowFinalized)
+		assert.Equal(t, headers[9].Hash, b.Header().Hash)
+
+		newBranch := branch(headers, 7)
+		assert.NoError(t, b.WriteHeadersWithBodies(newBranch))
+		assert.Equal(t, newBranch[len(newBranch)-1].Hash, b.Header().Hash)
+	})
+
+	t.Run("unlimited", func(t *testing.T) {
+		b, headers := newChain(t)
+
+		assert.Equal(t, uint64(0), b.FinalizedNumber())
+
+		newBranch := branch(headers, 1)
+		assert.NoError(t, b.WriteHeadersWithBodies(newBranch))
+		assert.Equal(t, newBranch[len(newBranch)-1].Hash, b.Header().Hash)
+	})
+}
+
 func TestBlockchainWriteBody(t *testing.T) {
 	t.Parallel()
 
@@ -1418,6 +1489,63 @@ func TestBlockchain_CalculateBaseFee(t *testing.T) {
 	}
 }
 
+// not parallel, it changes chain.EngineRegistryAddress
+func TestBlockchain_MinBaseFee(t *testing.T) {
+	registry := types.StringToAddress("0x1001")
+
+	defer func(addr types.Address) {
+		chain.EngineRegistryAddress = addr
+	}(chain.EngineRegistryAddress)
+
+	st := itrie.NewState(itrie.NewMemoryStorage())
+	blockchain := &Blockchain{
+		executor: state.NewExecutor(&chain.Params{}, st, hclog.NewNullLogger()),
+	}
+
+	// commitRegistry returns the header of a state with the registry deployed
+	// (optionally with the min base fee set)
+	commitRegistry := func(minBaseFee *big.Int) *types.Header {
+		snap := st.NewSnapshot()
+		txn := state.NewTxn(snap)
+
+		txn.SetCode(registry, []byte{0x1})
+
+		if minBaseFee != nil {
+			txn.SetState(registry, chain.EngineRegistrySlotKeyMinBaseFee(), types.BytesToHash(minBaseFee.Bytes()))
+		}
+
+		objs, err := txn.Commit(false)
+		require.NoError(t, err)
+
+		_, root, err := snap.Commit(objs)
+		require.NoError(t, err)
+
+		return &types.Header{StateRoot: types.BytesToHash(root)}
+	}
+
+	withFloor := commitRegistry(big.NewInt(200_000_000_000))
+	withZero := commitRegistry(nil)
+	withInvalid := commitRegistry(new(big.Int).Lsh(big.NewInt(1), 64))
+
+	// registry address not configured
+	chain.EngineRegistryAddress = types.ZeroAddress
+	assert.Equal(t, chain.MinBaseFee, blockchain.MinBaseFee(withFloor))
+
+	chain.EngineRegistryAddress = registry
+
+	// registry not deployed
+	assert.Equal(t, chain.MinBaseFee, blockchain.MinBaseFee(&types.Header{StateRoot: types.EmptyRootHash}))
+
+	// registry deployed with a zero min base fee
+	assert.Equal(t, uint64(0), blockchain.MinBaseFee(withZero))
+
+	// registry deployed with a min base fee
+	assert.Equal(t, uint64(200_000_000_000), blockchain.MinBaseFee(withFloor))
+
+	// values above uint64 fall back to the static floor
+	assert.Equal(t, chain.MinBaseFee, blockchain.MinBaseFee(withInvalid))
+}
+
 func TestBlockchain_WriteFullBlock(t *testing.T) {
 	t.Parallel()
 
@@ -1458,8 +1586,12 @@ func TestBlockchain_WriteFullBlock(t *testing.T) {
 		stream: newEventStream(),
 	}
 
+	observer := &recordingObserver{}
+	bc.SetBlockObserver(observer)
+
 	bc.headersCache, _ = lru.New(10)
 	bc.difficultyCache, _ = lru.New(10)
+	bc.addressBloomCache, _ = lru.New(10)
 
 	existingTD := big.NewInt(1)
 	existingHeader := &types.Header{Number: 1}
@@ -1499,6 +1631,7 @@ func TestBlockchain_WriteFullBlock(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 0, len(db))
 	require.Equal(t, uint64(1), bc.currentHeader.Load().Number)
+	require.Empty(t, observer.headers)
 
 	// already existing block write
 	err = bc.WriteFullBlock(&types.FullBlock{
@@ -1510,7 +1643,7 @@ func TestBlockchain_WriteFullBlock(t *testing.T) {
 	}, "polybft")
 
 	require.NoError(t, err)
-	require.Equal(t, 8, len(db))
+	require.Equal(t, 10, len(db))
 	require.Equal(t, uint64(2), bc.currentHeader.Load().Number)
 	require.NotNil(t, db[hex.EncodeToHex(getKey(storage.BODY, header.Hash.Bytes()))])
 	require.NotNil(t, db[hex.EncodeToHex(getKey(storage.TX_LOOKUP_PREFIX, tx.Hash.Bytes()))])
@@ -1520,4 +1653,152 @@ func TestBlockchain_WriteFullBlock(t *testing.T) {
 	require.NotNil(t, db[hex.EncodeToHex(getKey(storage.DIFFICULTY, header.Hash.Bytes()))])
 	require.NotNil(t, db[hex.EncodeToHex(getKey(storage.CANONICAL, common.EncodeUint64ToBytes(header.Number)))])
 	require.NotNil(t, db[hex.EncodeToHex(getKey(storage.RECEIPTS, header.Hash.Bytes()))])
+	require.NotNil(t, db[hex.EncodeToHex(getKey(storage.ADDRESS_BLOOM, header.Hash.Bytes()))])
+	require.NotNil(t, db[hex.EncodeToHex(getKey(storage.BLOCK_ECONOMICS, header.Hash.Bytes()))])
+
+	// only the committed block is observed
+	require.Equal(t, []*types.Header{header}, observer.headers)
+	require.Len(t, observer.fees, 1)
+}
+
+type recordingObserver struct {
+	headers []*types.Header
+	fees    []*types.BlockEconomics
+}
+
+func (r *recordingObserver) BlockCommitted(header *types.Header, fees *types.BlockEconomics) {
+	r.headers = append(r.headers, header)
+	r.fees = append(r.fees, fees)
+}
+
+func feeSplitReceipt(donated, validator, burned uint64) *types.Receipt {
+	data := make([]byte, 0, 3*types.HashLength)
+	for _, v := range []uint64{donated, validator, burned} {
+		data = append(data, types.BytesToHash(new(big.Int).SetUint64(v).Bytes()).Bytes()...)
+	}
+
+	return &types.Receipt{
+		Logs: []*types.Log{
+			{
+				Address: types.StringToAddress("0x1"),
+				Topics:  []types.Hash{state.FeeSplitLogTopic},
+				Data:    data,
+			},
+			{
+				Address: state.FeeSplitLogAddress,
+				Topics:  []types.Hash{state.FeeSplitLogTopic},
+				Data:    data,
+			},
+		},
+	}
+}
+
+func TestBlockchain_ComputeBlockEconomics(t *testing.T) {
+	t.Parallel()
+
+	engine := contracts.EngineExecutePrecompile
+	plain := types.StringToAddress("0x2")
+
+	txs := []*types.Transaction{
+		{To: &engine},
+		{To: &plain},
+		{To: &engine},
+		{}, // contract creation
+		{To: &plain},
+	}
+	receipts := []*types.Receipt{
+		feeSplitReceipt(10, 200, 1000),
+		feeSplitReceipt(0, 300, 1000),
+		feeSplitReceipt(15, 100, 1000),
+		feeSplitReceipt(5, 50, 1000),
+		feeSplitReceipt(20, 150, 1000),
+	}
+
+	// the fee split of the receipt takes precedence over its log
+	receipts[4].FeeSplit = &types.FeeSplitInfo{
+		Donation:  big.NewInt(20),
+		Validator: big.NewInt(150),
+		Burned:    big.NewInt(1000),
+	}
+
+	econ := ComputeBlockEconomics(txs, receipts)
+
+	// the fee split log of a foreign address is ignored
+	assert.Equal(t, big.NewInt(5000), econ.TotalBurned)
+	assert.Equal(t, big.NewInt(50), econ.TotalDonated)
+	assert.Equal(t, big.NewInt(800), econ.TotalValidatorFees)
+	assert.Equal(t, uint64(2), econ.EngineTxCount)
+}
+
+func TestBlockchain_GetBlockEconomics(t *testing.T) {
+	t.Parallel()
+
+	engine := contracts.EngineExecutePrecompile
+	plain := types.StringToAddress("0x2")
+
+	stored := types.StringToHash("1")
+	lazy := types.StringToHash("2")
+	oversized := types.StringToHash("3")
+
+	oversizedTxs := make([]*types.Transaction, maxLazyBlockEconomicsTxs+1)
+	for i := range oversizedTxs {
+		oversizedTxs[i] = &types.Transaction{From: plain, To: &engine}
+	}
+
+	storedEcon := &types.BlockEconomics{
+		TotalBurned:        big.NewInt(1),
+		TotalDonated:       big.NewInt(2),
+		TotalValidatorFees: big.NewInt(3),
+		EngineTxCount:      4,
+	}
+
+	storageMock := storage.NewMockStorage()
+	storageMock.HookReadBlockEconomics(func(hash types.Hash) (*types.BlockEconomics, bool) {
+		if hash == stored {
+			return storedEcon, true
+		}
+
+		return nil, false
+	})
+	storageMock.HookReadBody(func(hash types.Hash) (*types.Body, error) {
+		switch hash {
+		case lazy:
+			return &types.Body{Transactions: []*types.Transaction{{To: &engine}, {To: &plain}}}, nil
+		case oversized:
+			return &types.Body{Transactions: oversizedTxs}, nil
+		}
+
+		return nil, storage.ErrNotFound
+	})
+	storageMock.HookReadReceipts(func(hash types.Hash) ([]*types.Receipt, error) {
+		if hash == lazy {
+			return []*types.Receipt{feeSplitReceipt(1, 2, 3), feeSplitReceipt(4, 5, 6)}, nil
+		}
+
+		return nil, storage.ErrNotFound
+	})
+
+	bc := &Blockchain{
+		logger:   hclog.NewNullLogger(),
+		db:       storageMock,
+		txSigner: &mockSigner{},
+	}
+
+	econ, ok := bc.GetBlockEconomics(stored)
+	require.True(t, ok)
+	assert.Equal(t, storedEcon, econ)
+
+	// blocks without stored aggregates are computed from the receipts
+	econ, ok = bc.GetBlockEconomics(lazy)
+	require.True(t, ok)
+	assert.Equal(t, big.NewInt(9), econ.TotalBurned)
+	assert.Equal(t, big.NewInt(5), econ.TotalDonated)
+	assert.Equal(t, big.NewInt(7), econ.TotalValidatorFees)
+	assert.Equal(t, uint64(1), econ.EngineTxCount)
+
+	_, ok = bc.GetBlockEconomics(oversized)
+	assert.False(t, ok)
+
+	_, ok = bc.GetBlockEconomics(types.StringToHash("4"))
+	assert.False(t, ok)
 }
@@ -0,0 +1,40 @@
+package blockchain
+
+import (
+	"math/big"
+
+	"github.com/armon/go-metrics"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+// feeMetricsPrefix is the prefix of the fee split metrics
+const feeMetricsPrefix = "fees"
+
+var gwei = big.NewFloat(1e9)
+
+var _ BlockObserver = (*FeeMetrics)(nil)
+
+// FeeMetrics records the fee split totals of the committed blocks as counters.
+// The fees are counted in gwei, as the counters are floats
+type FeeMetrics struct {
+	incrCounter func(key []string, val float32)
+}
+
+// NewFeeMetrics creates the fee split metrics collector reporting to the global metrics sink
+func NewFeeMetrics() *FeeMetrics {
+	return &FeeMetrics{incrCounter: metrics.IncrCounter}
+}
+
+// BlockCommitted implements the BlockObserver interface
+func (f *FeeMetrics) BlockCommitted(_ *types.Header, fees *types.BlockEconomics) {
+	f.incrCounter([]string{feeMetricsPrefix, "donated_gwei"}, toGwei(fees.TotalDonated))
+	f.incrCounter([]string{feeMetricsPrefix, "validator_gwei"}, toGwei(fees.TotalValidatorFees))
+	f.incrCounter([]string{feeMetricsPrefix, "burned_gwei"}, toGwei(fees.TotalBurned))
+	f.incrCounter([]string{feeMetricsPrefix, "engine_txs"}, float32(fees.EngineTxCount))
+}
+
+func toGwei(wei *big.Int) float32 {
+	v, _ := new(big.Float).Quo(new(big.Float).SetInt(wei), gwei).Float32()
+
+	return v
+}
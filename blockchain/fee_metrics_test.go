@@ -0,0 +1,51 @@
+package blockchain
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xgr-network/xgr-node/types"
+)
+
+func TestFeeMetrics_BlockCommitted(t *testing.T) {
+	t.Parallel()
+
+	sink := metrics.NewInmemSink(time.Minute, time.Minute)
+
+	conf := metrics.DefaultConfig("test")
+	conf.EnableHostname = false
+	conf.EnableRuntimeMetrics = false
+
+	m, err := metrics.New(conf, sink)
+	require.NoError(t, err)
+
+	feeMetrics := &FeeMetrics{incrCounter: m.IncrCounter}
+
+	newFees := func(donated, validator, burned int64, engineTxs uint64) *types.BlockEconomics {
+		fees := types.NewBlockEconomics()
+		fees.TotalDonated.Mul(big.NewInt(donated), big.NewInt(1e9))
+		fees.TotalValidatorFees.Mul(big.NewInt(validator), big.NewInt(1e9))
+		fees.TotalBurned.Mul(big.NewInt(burned), big.NewInt(1e9))
+		fees.EngineTxCount = engineTxs
+
+		return fees
+	}
+
+	feeMetrics.BlockCommitted(&types.Header{Number: 1}, newFees(10, 80, 5, 1))
+	feeMetrics.BlockCommitted(&types.Header{Number: 2}, newFees(2, 16, 1, 0))
+
+	data := sink.Data()
+	require.NotEmpty(t, data)
+
+	counters := data[len(data)-1].Counters
+
+	require.Equal(t, float64(12), counters["test.fees.donated_gwei"].Sum)
+	require.Equal(t, float64(96), counters["test.fees.validator_gwei"].Sum)
+	require.Equal(t, float64(6), counters["test.fees.burned_gwei"].Sum)
+	require.Equal(t, float64(1), counters["test.fees.engine_txs"].Sum)
+	require.Equal(t, 2, counters["test.fees.donated_gwei"].Count)
+}
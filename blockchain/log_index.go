@@ -0,0 +1,403 @@
+package blockchain
+
+import (
+	"github.com/xgr-network/xgr-node/blockchain/storage"
+	"github.com/xgr-network/xgr-node/helper/common"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+// logIndexBackfillChunk is the number of blocks indexed by the backfill in one batch
+const logIndexBackfillChunk uint64 = 256
+
+// logIndexEntry is a bitmap of the log index modified in a batch
+type logIndexEntry struct {
+	key     storage.LogIndexKey
+	section uint64
+	bitmap  storage.LogIndexBitmap
+}
+
+// logIndexWriter collects the modifications of the log index bitmaps, so the same
+// bitmap can be modified several times before it is written
+type logIndexWriter struct {
+	db      storage.Storage
+	entries map[string]*logIndexEntry
+}
+
+func newLogIndexWriter(db storage.Storage) *logIndexWriter {
+	return &logIndexWriter{
+		db:      db,
+		entries: map[string]*logIndexEntry{},
+	}
+}
+
+// add marks the block in the bitmaps of the addresses and first topics of its logs
+func (w *logIndexWriter) add(number uint64, receipts []*types.Receipt) {
+	for _, key := range logIndexKeys(receipts) {
+		w.entry(key, number).bitmap.Set(number)
+	}
+}
+
+// remove unmarks the block in the bitmaps of the addresses and first topics of its logs
+func (w *logIndexWriter) remove(number uint64, receipts []*types.Receipt) {
+	for _, key := range logIndexKeys(receipts) {
+		w.entry(key, number).bitmap.Clear(number)
+	}
+}
+
+func (w *logIndexWriter) entry(key storage.LogIndexKey, number uint64) *logIndexEntry {
+	section := storage.LogIndexSection(number)
+	id := string(key) + string(common.EncodeUint64ToBytes(section))
+
+	entry, ok := w.entries[id]
+	if !ok {
+		bitmap, ok := w.db.ReadLogIndex(key, section)
+		if ok {
+			bitmap = append(storage.LogIndexBitmap{}, bitmap...)
+		} else {
+			bitmap = storage.NewLogIndexBitmap()
+		}
+
+		entry = &logIndexEntry{key: key, section: section, bitmap: bitmap}
+		w.entries[id] = entry
+	}
+
+	return entry
+}
+
+// write puts the modified bitmaps into the batch, empty bitmaps are deleted
+func (w *logIndexWriter) write(batchWriter *storage.BatchWriter) {
+	for _, entry := range w.entries {
+		if entry.bitmap.IsEmpty() {
+			batchWriter.DeleteLogIndex(entry.key, entry.section)
+		} else {
+			batchWriter.PutLogIndex(entry.key, entry.section, entry.bitmap)
+		}
+	}
+}
+
+// logIndexKeys returns the distinct log index keys of the logs
+func logIndexKeys(receipts []*types.Receipt) []storage.LogIndexKey {
+	seen := map[string]struct{}{}
+	keys := []storage.LogIndexKey{}
+
+	appendKey := func(key storage.LogIndexKey) {
+		if _, ok := seen[string(key)]; !ok {
+			seen[string(key)] = struct{}{}
+			keys = append(keys, key)
+		}
+	}
+
+	for _, receipt := range receipts {
+		for _, log := range receipt.Logs {
+			appendKey(storage.LogIndexAddressKey(log.Address))
+
+			if len(log.Topics) > 0 {
+				appendKey(storage.LogIndexTopicKey(log.Topics[0]))
+			}
+		}
+	}
+
+	return keys
+}
+
+// updateLogIndex updates the log index with the canonical chain change of the event.
+// The blocks of the old chain of a reorg are removed from the index, the blocks of the
+// new chain are added. The receipts of the written header are passed as they are not stored
+// yet, nil receipts are read from the storage.
+func (b *Blockchain) updateLogIndex(
+	batchWriter *storage.BatchWriter,
+	evnt *Event,
+	header *types.Header,
+	receipts []*types.Receipt,
+) {
+	w := newLogIndexWriter(b.db)
+
+	if evnt.Type == EventReorg {
+		for _, h := range evnt.OldChain {
+			oldReceipts, err := b.db.ReadReceipts(h.Hash)
+			if err != nil {
+				// blocks without receipts were never indexed
+				continue
+			}
+
+			w.remove(h.Number, oldReceipts)
+		}
+	}
+
+	for _, h := range evnt.NewChain {
+		newReceipts := receipts
+
+		if h.Hash != header.Hash || receipts == nil {
+			var err error
+
+			if newReceipts, err = b.db.ReadReceipts(h.Hash); err != nil {
+				continue
+			}
+		}
+
+		w.add(h.Number, newReceipts)
+	}
+
+	w.write(batchWriter)
+}
+
+// StartLogIndex initializes the log index and starts indexing the blocks inserted before it
+// existed in the background. Until the backfill is done, FilterLogBlocks checks the blooms
+// of the blocks which are not indexed yet. Must be called after ComputeGenesis.
+func (b *Blockchain) StartLogIndex() error {
+	b.writeLock.Lock()
+
+	tail, ok := b.db.ReadLogIndexTail()
+	if !ok {
+		// the blocks inserted from now on are indexed
+		tail = b.Header().Number + 1
+
+		batchWriter := storage.NewBatchWriter(b.db)
+		batchWriter.PutLogIndexTail(tail)
+
+		if err := batchWriter.WriteBatch(); err != nil {
+			b.writeLock.Unlock()
+
+			return err
+		}
+	}
+
+	b.logIndexTail.Store(tail)
+	b.writeLock.Unlock()
+
+	if tail > 1 {
+		b.logger.Info("backfilling the log index", "blocks", tail-1)
+
+		b.logIndexCloseCh = make(chan struct{})
+		b.logIndexWg.Add(1)
+
+		go b.backfillLogIndex()
+	}
+
+	return nil
+}
+
+// backfillLogIndex indexes the blocks below the log index tail, from the tail down to the genesis
+func (b *Blockchain) backfillLogIndex() {
+	defer b.logIndexWg.Done()
+
+	for {
+		select {
+		case <-b.logIndexCloseCh:
+			return
+		default:
+		}
+
+		done, err := b.backfillLogIndexChunk()
+		if err != nil {
+			b.logger.Error("failed to backfill the log index", "err", err)
+
+			return
+		}
+
+		if done {
+			b.logger.Info("log index backfilled")
+
+			return
+		}
+	}
+}
+
+// backfillLogIndexChunk indexes the chunk below the tail and moves the tail,
+// it returns true if all blocks are indexed
+func (b *Blockchain) backfillLogIndexChunk() (bool, error) {
+	b.writeLock.Lock()
+	defer b.writeLock.Unlock()
+
+	tail := b.logIndexTail.Load()
+	if tail <= 1 {
+		return true, nil
+	}
+
+	from := uint64(1)
+	if tail-1 > logIndexBackfillChunk {
+		from = tail - logIndexBackfillChunk
+	}
+
+	w := newLogIndexWriter(b.db)
+
+	for n := from; n < tail; n++ {
+		hash, ok := b.db.ReadCanonicalHash(n)
+		if !ok {
+			continue
+		}
+
+		receipts, err := b.db.ReadReceipts(hash)
+		if err != nil {
+			continue
+		}
+
+		w.add(n, receipts)
+	}
+
+	batchWriter := storage.NewBatchWriter(b.db)
+	w.write(batchWriter)
+	batchWriter.PutLogIndexTail(from)
+
+	if err := batchWriter.WriteBatch(); err != nil {
+		return false, err
+	}
+
+	b.logIndexTail.Store(from)
+
+	return from <= 1, nil
+}
+
+// FilterLogBlocks returns the ascending numbers of the blocks in the range which may contain
+// a log emitted by one of the addresses with one of the first topics. An empty list matches
+// any address or first topic. Indexed blocks are looked up in the log index, the blocks the
+// backfill has not reached yet are checked against their blooms. It returns false if the query
+// has neither addresses nor first topics, the index can't narrow the range then.
+func (b *Blockchain) FilterLogBlocks(
+	from, to uint64,
+	addresses []types.Address,
+	topics []types.Hash,
+) ([]uint64, bool) {
+	if len(addresses) == 0 && len(topics) == 0 {
+		return nil, false
+	}
+
+	tail := b.logIndexTail.Load()
+	if tail == 0 {
+		// the log index is not started
+		tail = to + 1
+	}
+
+	matches := []uint64{}
+
+	for n := from; n <= to && n < tail; n++ {
+		header, ok := b.GetHeaderByNumber(n)
+		if !ok {
+			break
+		}
+
+		if bloomMatches(&header.LogsBloom, addresses, topics) {
+			matches = append(matches, n)
+		}
+	}
+
+	start := from
+	if start < tail {
+		start = tail
+	}
+
+	for section := storage.LogIndexSection(start); start <= to && section <= storage.LogIndexSection(to); section++ {
+		bitmap := b.sectionMatches(section, addresses, topics)
+		if bitmap == nil {
+			continue
+		}
+
+		first := section * storage.LogIndexSectionSize
+		last := first + storage.LogIndexSectionSize - 1
+
+		if first < start {
+			first = start
+		}
+
+		if last > to {
+			last = to
+		}
+
+		for n := first; n <= last; n++ {
+			if bitmap.Has(n) {
+				matches = append(matches, n)
+			}
+		}
+	}
+
+	return matches, true
+}
+
+// sectionMatches returns the bitmap of the blocks of the section which may contain a log
+// of the addresses and first topics, or nil if there are none
+func (b *Blockchain) sectionMatches(
+	section uint64,
+	addresses []types.Address,
+	topics []types.Hash,
+) storage.LogIndexBitmap {
+	union := func(keys []storage.LogIndexKey) storage.LogIndexBitmap {
+		var res storage.LogIndexBitmap
+
+		for _, key := range keys {
+			bitmap, ok := b.db.ReadLogIndex(key, section)
+			if !ok {
+				continue
+			}
+
+			if res == nil {
+				res = append(storage.LogIndexBitmap{}, bitmap...)
+			} else {
+				res.Or(bitmap)
+			}
+		}
+
+		return res
+	}
+
+	var res storage.LogIndexBitmap
+
+	if len(addresses) > 0 {
+		keys := make([]storage.LogIndexKey, len(addresses))
+		for i, addr := range addresses {
+			keys[i] = storage.LogIndexAddressKey(addr)
+		}
+
+		if res = union(keys); res == nil {
+			return nil
+		}
+	}
+
+	if len(topics) > 0 {
+		keys := make([]storage.LogIndexKey, len(topics))
+		for i, topic := range topics {
+			keys[i] = storage.LogIndexTopicKey(topic)
+		}
+
+		topicRes := union(keys)
+		if topicRes == nil {
+			return nil
+		}
+
+		if res == nil {
+			res = topicRes
+		} else {
+			res.And(topicRes)
+		}
+	}
+
+	return res
+}
+
+// bloomMatches returns true if the bloom may contain a log of one of the addresses with one of the topics
+func bloomMatches(bloom *types.Bloom, addresses []types.Address, topics []types.Hash) bool {
+	anyAddress := len(addresses) == 0
+
+	for _, addr := range addresses {
+		if bloom.IsAddressInBloom(addr) {
+			anyAddress = true
+
+			break
+		}
+	}
+
+	if !anyAddress {
+		return false
+	}
+
+	if len(topics) == 0 {
+		return true
+	}
+
+	for _, topic := range topics {
+		if bloom.IsTopicInBloom(topic) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,232 @@
+package blockchain
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xgr-network/xgr-node/blockchain/storage"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+var (
+	logIndexTestAddresses = []types.Address{
+		types.StringToAddress("0x1001"),
+		types.StringToAddress("0x1002"),
+		types.StringToAddress("0x1003"),
+	}
+	logIndexTestTopics = []types.Hash{
+		types.StringToHash("0x2001"),
+		types.StringToHash("0x2002"),
+	}
+)
+
+// logIndexTestBlock returns a child block of the parent with one log, the address and topic of
+// the log depend on the block number and the variant. Every fifth block has no logs.
+func logIndexTestBlock(parent *types.Header, variant, difficulty uint64) (*types.Block, []*types.Receipt) {
+	number := parent.Number + 1
+	receipts := []*types.Receipt{}
+
+	if number%5 != 0 {
+		receipts = append(receipts, &types.Receipt{
+			Logs: []*types.Log{{
+				Address: logIndexTestAddresses[(number+variant)%uint64(len(logIndexTestAddresses))],
+				Topics:  []types.Hash{logIndexTestTopics[(number+variant)%uint64(len(logIndexTestTopics))]},
+			}},
+		})
+	}
+
+	header := &types.Header{
+		Number:       number,
+		ParentHash:   parent.Hash,
+		Difficulty:   difficulty,
+		LogsBloom:    types.CreateBloom(receipts),
+		ExtraData:    []byte{byte(variant)},
+		TxRoot:       types.EmptyRootHash,
+		ReceiptsRoot: types.EmptyRootHash,
+		Sha3Uncles:   types.EmptyUncleHash,
+	}
+	header.ComputeHash()
+
+	return &types.Block{Header: header}, receipts
+}
+
+// naiveLogBlocks returns the canonical blocks in the range with a matching log by scanning all receipts
+func naiveLogBlocks(t *testing.T, b *Blockchain, from, to uint64, addresses []types.Address, topics []types.Hash) []uint64 {
+	t.Helper()
+
+	contains := func(list []types.Hash, h types.Hash) bool {
+		for _, v := range list {
+			if v == h {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	matches := []uint64{}
+
+	for n := from; n <= to; n++ {
+		header, ok := b.GetHeaderByNumber(n)
+		require.True(t, ok)
+
+		receipts, err := b.db.ReadReceipts(header.Hash)
+		if err != nil {
+			continue
+		}
+
+	logs:
+		for _, receipt := range receipts {
+			for _, log := range receipt.Logs {
+				addressMatch := len(addresses) == 0
+
+				for _, addr := range addresses {
+					addressMatch = addressMatch || addr == log.Address
+				}
+
+				topicMatch := len(topics) == 0 || (len(log.Topics) > 0 && contains(topics, log.Topics[0]))
+
+				if addressMatch && topicMatch {
+					matches = append(matches, n)
+
+					break logs
+				}
+			}
+		}
+	}
+
+	return matches
+}
+
+func TestBlockchain_FilterLogBlocks(t *testing.T) {
+	t.Parallel()
+
+	const (
+		backfilled = 700
+		inserted   = 4500
+	)
+
+	b := NewTestBlockchain(t, nil)
+	parent := b.Header()
+
+	// the blocks of an existing chain are written before the log index exists
+	batchWriter := storage.NewBatchWriter(b.db)
+	td := big.NewInt(0)
+
+	for i := 0; i < backfilled; i++ {
+		block, receipts := logIndexTestBlock(parent, 0, 1)
+		td.Add(td, big.NewInt(1))
+
+		batchWriter.PutCanonicalHeader(block.Header, new(big.Int).Set(td))
+		batchWriter.PutReceipts(block.Hash(), receipts)
+
+		parent = block.Header
+	}
+
+	require.NoError(t, b.writeBatchAndUpdate(batchWriter, parent, td, true))
+
+	queries := []struct {
+		addresses []types.Address
+		topics    []types.Hash
+	}{
+		{logIndexTestAddresses[:1], nil},
+		{nil, logIndexTestTopics[1:]},
+		{logIndexTestAddresses[1:], logIndexTestTopics[:1]},
+		{[]types.Address{types.StringToAddress("0xdead")}, nil},
+	}
+
+	for _, q := range queries {
+		// without the index, the blooms are checked
+		found, ok := b.FilterLogBlocks(1, backfilled, q.addresses, q.topics)
+		require.True(t, ok)
+		assert.Equal(t, naiveLogBlocks(t, b, 1, backfilled, q.addresses, q.topics), found)
+	}
+
+	// the blocks inserted from now on are indexed on insertion, the backfill indexes the others
+	require.NoError(t, b.StartLogIndex())
+
+	for i := 0; i < inserted; i++ {
+		block, receipts := logIndexTestBlock(parent, 0, 1)
+		require.NoError(t, b.WriteFullBlock(&types.FullBlock{Block: block, Receipts: receipts}, "test"))
+
+		parent = block.Header
+	}
+
+	b.logIndexWg.Wait()
+	require.Equal(t, uint64(1), b.logIndexTail.Load())
+
+	head := b.Header().Number
+	require.Equal(t, uint64(backfilled+inserted), head)
+
+	// the blocks below the tail are checked against their blooms while the backfill is running
+	for _, tail := range []uint64{1, backfilled / 2, backfilled + 1, head + 1} {
+		b.logIndexTail.Store(tail)
+
+		for _, q := range queries {
+			for _, r := range [][2]uint64{{1, head}, {backfilled - 10, backfilled + 10}, {4090, 4100}, {head, head}} {
+				found, ok := b.FilterLogBlocks(r[0], r[1], q.addresses, q.topics)
+				require.True(t, ok)
+				assert.Equal(t, naiveLogBlocks(t, b, r[0], r[1], q.addresses, q.topics), found,
+					"tail %d, range %d-%d", tail, r[0], r[1])
+			}
+		}
+	}
+
+	// the index can't narrow a query without addresses and topics
+	_, ok := b.FilterLogBlocks(1, head, nil, nil)
+	assert.False(t, ok)
+
+	require.NoError(t, b.Close())
+}
+
+func TestBlockchain_LogIndexReorg(t *testing.T) {
+	t.Parallel()
+
+	b := NewTestBlockchain(t, nil)
+	require.NoError(t, b.StartLogIndex())
+
+	// canonical chain 1..6
+	chainA := []*types.Header{b.Header()}
+
+	for i := 0; i < 6; i++ {
+		block, receipts := logIndexTestBlock(chainA[len(chainA)-1], 0, 1)
+		require.NoError(t, b.WriteFullBlock(&types.FullBlock{Block: block, Receipts: receipts}, "test"))
+
+		chainA = append(chainA, block.Header)
+	}
+
+	// a fork from block 3 with other logs, its first block outweighs the blocks 4..6
+	batchWriter := storage.NewBatchWriter(b.db)
+	chainB := []*types.Header{chainA[3]}
+
+	for i := 0; i < 4; i++ {
+		block, receipts := logIndexTestBlock(chainB[len(chainB)-1], 1, 4)
+		batchWriter.PutReceipts(block.Hash(), receipts)
+
+		chainB = append(chainB, block.Header)
+	}
+
+	require.NoError(t, batchWriter.WriteBatch())
+	require.NoError(t, b.WriteHeadersWithBodies(chainB[1:]))
+	require.Equal(t, chainB[4].Hash, b.Header().Hash)
+
+	for _, addr := range logIndexTestAddresses {
+		found, ok := b.FilterLogBlocks(1, 7, []types.Address{addr}, nil)
+		require.True(t, ok)
+		assert.Equal(t, naiveLogBlocks(t, b, 1, 7, []types.Address{addr}, nil), found, "address %s", addr)
+	}
+
+	for _, topic := range logIndexTestTopics {
+		found, ok := b.FilterLogBlocks(1, 7, nil, []types.Hash{topic})
+		require.True(t, ok)
+		assert.Equal(t, naiveLogBlocks(t, b, 1, 7, nil, []types.Hash{topic}), found, "topic %s", topic)
+	}
+
+	// the orphaned block 4 had a log of the second address, the new block 4 has none of it
+	bitmap, ok := b.db.ReadLogIndex(storage.LogIndexAddressKey(logIndexTestAddresses[1]), 0)
+	require.True(t, ok)
+	assert.False(t, bitmap.Has(4))
+	assert.True(t, bitmap.Has(1))
+}
@@ -0,0 +1,183 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/xgr-network/xgr-node/blockchain/storage"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+var (
+	ErrRewindNotBelowHead     = errors.New("rewind target is not below the head block")
+	ErrRewindStateUnavailable = errors.New("state of the rewind target is not available")
+	ErrRewindBelowFinalized   = errors.New("rewind below the finalized block")
+)
+
+// RewindResult describes the data removed by a chain rewind
+type RewindResult struct {
+	OldHead          uint64
+	NewHead          uint64
+	NewHeadHash      types.Hash
+	RemovedBlocks    uint64
+	RemovedReceipts  uint64
+	RemovedTxLookups uint64
+}
+
+// RewindStorage truncates the canonical chain in the storage to the block with the given number.
+// The canonical hash mappings, receipts, transaction lookups and log index entries of the blocks
+// above it are removed and the head is reset to it. Headers, bodies and the state tries are kept,
+// so the node re-executes forward from the new head. hasState reports whether the state with the
+// given root is available, the rewind is refused if the state of the target block is not.
+func RewindStorage(db storage.Storage, number uint64, hasState func(root types.Hash) bool) (*RewindResult, error) {
+	res, _, err := rewindStorage(db, number, hasState)
+
+	return res, err
+}
+
+// rewindStorage rewinds the storage and returns the removed canonical headers, from the old head down
+func rewindStorage(
+	db storage.Storage,
+	number uint64,
+	hasState func(root types.Hash) bool,
+) (*RewindResult, []*types.Header, error) {
+	head, ok := db.ReadHeadNumber()
+	if !ok {
+		return nil, nil, errors.New("head block not found")
+	}
+
+	if number >= head {
+		return nil, nil, fmt.Errorf("%w: target %d, head %d", ErrRewindNotBelowHead, number, head)
+	}
+
+	target, err := readCanonicalHeader(db, number)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !hasState(target.StateRoot) {
+		return nil, nil, fmt.Errorf("%w: block %d, state root %s", ErrRewindStateUnavailable, number, target.StateRoot)
+	}
+
+	res := &RewindResult{
+		OldHead:     head,
+		NewHead:     number,
+		NewHeadHash: target.Hash,
+	}
+
+	removed := make([]*types.Header, 0, head-number)
+	batchWriter := storage.NewBatchWriter(db)
+	logIndex := newLogIndexWriter(db)
+
+	for n := head; n > number; n-- {
+		header, err := readCanonicalHeader(db, n)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		body, err := db.ReadBody(header.Hash)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read the body of block %d: %w", n, err)
+		}
+
+		// a transaction included again in a block which is not rewound keeps its lookup
+		for _, txn := range body.Transactions {
+			if blockHash, ok := db.ReadTxLookup(txn.Hash); ok && blockHash == header.Hash {
+				batchWriter.DeleteTxLookup(txn.Hash)
+
+				res.RemovedTxLookups++
+			}
+		}
+
+		if receipts, err := db.ReadReceipts(header.Hash); err == nil {
+			batchWriter.DeleteReceipts(header.Hash)
+			logIndex.remove(n, receipts)
+
+			res.RemovedReceipts++
+		}
+
+		batchWriter.DeleteCanonicalHash(n)
+
+		res.RemovedBlocks++
+
+		removed = append(removed, header)
+	}
+
+	logIndex.write(batchWriter)
+	batchWriter.PutHeadHash(target.Hash)
+	batchWriter.PutHeadNumber(target.Number)
+
+	if err := batchWriter.WriteBatch(); err != nil {
+		return nil, nil, err
+	}
+
+	return res, removed, nil
+}
+
+func readCanonicalHeader(db storage.Storage, n uint64) (*types.Header, error) {
+	hash, ok := db.ReadCanonicalHash(n)
+	if !ok {
+		return nil, fmt.Errorf("canonical hash of block %d not found", n)
+	}
+
+	header, err := db.ReadHeader(hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the header of block %d: %w", n, err)
+	}
+
+	return header, nil
+}
+
+// SetHead rewinds the canonical chain to the block with the given number, see RewindStorage.
+// The rewind is refused below the finalized block. Subscribers receive the removed blocks
+// as the old chain of a reorg event.
+func (b *Blockchain) SetHead(number uint64) (*RewindResult, error) {
+	b.writeLock.Lock()
+	defer b.writeLock.Unlock()
+
+	if finalized := b.FinalizedNumber(); number < finalized {
+		return nil, fmt.Errorf("%w: target %d, finalized %d", ErrRewindBelowFinalized, number, finalized)
+	}
+
+	res, removed, err := rewindStorage(b.db, number, func(root types.Hash) bool {
+		_, ok := b.snapshotAt(root)
+
+		return ok
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	header, ok := b.readHeader(res.NewHeadHash)
+	if !ok {
+		return nil, fmt.Errorf("header '%s' not found", res.NewHeadHash)
+	}
+
+	td, ok := b.readTotalDifficulty(header.Hash)
+	if !ok {
+		return nil, fmt.Errorf("total difficulty of '%s' not found", header.Hash)
+	}
+
+	// receipts cached by the verification phase must not outlive their blocks
+	b.receiptsCache.Purge()
+	b.setCurrentHeader(header, td)
+
+	evnt := &Event{Source: "rewind"}
+	for _, h := range removed {
+		evnt.AddOldHeader(h)
+	}
+
+	evnt.AddNewHeader(header)
+	evnt.Type = EventReorg
+	evnt.SetDifficulty(td)
+
+	b.dispatchEvent(evnt)
+
+	b.logger.Warn("rewound the canonical chain",
+		"old_head", res.OldHead,
+		"new_head", res.NewHead,
+		"removed_blocks", res.RemovedBlocks,
+	)
+
+	return res, nil
+}
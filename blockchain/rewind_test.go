@@ -0,0 +1,194 @@
+package blockchain
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xgr-network/xgr-node/blockchain/storage"
+	"github.com/xgr-network/xgr-node/blockchain/storage/memory"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+// writeRewindTestChain writes a canonical chain of the genesis and n blocks with one transaction each
+// to the storage. All blocks have the empty state root.
+func writeRewindTestChain(t *testing.T, db storage.Storage, n int) ([]*types.Header, []*types.Transaction) {
+	t.Helper()
+
+	var (
+		headers []*types.Header
+		txs     []*types.Transaction
+		parent  types.Hash
+	)
+
+	batchWriter := storage.NewBatchWriter(db)
+
+	for i := 0; i <= n; i++ {
+		header := &types.Header{
+			Number:       uint64(i),
+			ParentHash:   parent,
+			StateRoot:    types.EmptyRootHash,
+			TxRoot:       types.EmptyRootHash,
+			ReceiptsRoot: types.EmptyRootHash,
+			Sha3Uncles:   types.EmptyUncleHash,
+			Difficulty:   1,
+		}
+		header.ComputeHash()
+
+		body := &types.Body{}
+
+		if i > 0 {
+			tx := &types.Transaction{Nonce: uint64(i), Value: big.NewInt(1), V: big.NewInt(1)}
+			tx.ComputeHash(header.Number)
+
+			body.Transactions = []*types.Transaction{tx}
+			txs = append(txs, tx)
+
+			batchWriter.PutTxLookup(tx.Hash, header.Hash)
+			batchWriter.PutReceipts(header.Hash, []*types.Receipt{{TxHash: tx.Hash, GasUsed: 21000}})
+		}
+
+		batchWriter.PutCanonicalHeader(header, big.NewInt(int64(i+1)))
+		batchWriter.PutBody(header.Hash, body)
+
+		headers = append(headers, header)
+		parent = header.Hash
+	}
+
+	require.NoError(t, batchWriter.WriteBatch())
+
+	return headers, txs
+}
+
+func TestRewindStorage(t *testing.T) {
+	t.Parallel()
+
+	hasState := func(types.Hash) bool { return true }
+
+	t.Run("rewinds the canonical chain", func(t *testing.T) {
+		t.Parallel()
+
+		db, err := memory.NewMemoryStorage(nil)
+		require.NoError(t, err)
+
+		headers, txs := writeRewindTestChain(t, db, 5)
+
+		res, err := RewindStorage(db, 2, hasState)
+		require.NoError(t, err)
+		assert.Equal(t, &RewindResult{
+			OldHead:          5,
+			NewHead:          2,
+			NewHeadHash:      headers[2].Hash,
+			RemovedBlocks:    3,
+			RemovedReceipts:  3,
+			RemovedTxLookups: 3,
+		}, res)
+
+		head, ok := db.ReadHeadNumber()
+		require.True(t, ok)
+		assert.Equal(t, uint64(2), head)
+
+		headHash, ok := db.ReadHeadHash()
+		require.True(t, ok)
+		assert.Equal(t, headers[2].Hash, headHash)
+
+		for i := 1; i <= 5; i++ {
+			_, canonical := db.ReadCanonicalHash(uint64(i))
+			_, lookup := db.ReadTxLookup(txs[i-1].Hash)
+			_, receiptsErr := db.ReadReceipts(headers[i].Hash)
+
+			kept := i <= 2
+			assert.Equal(t, kept, canonical, "canonical hash of block %d", i)
+			assert.Equal(t, kept, lookup, "tx lookup of block %d", i)
+			assert.Equal(t, kept, receiptsErr == nil, "receipts of block %d", i)
+
+			// the headers are kept for re-execution
+			_, err := db.ReadHeader(headers[i].Hash)
+			assert.NoError(t, err)
+		}
+	})
+
+	t.Run("rewinds to the genesis", func(t *testing.T) {
+		t.Parallel()
+
+		db, err := memory.NewMemoryStorage(nil)
+		require.NoError(t, err)
+
+		headers, _ := writeRewindTestChain(t, db, 3)
+
+		res, err := RewindStorage(db, 0, hasState)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(3), res.RemovedBlocks)
+
+		hash, ok := db.ReadCanonicalHash(0)
+		require.True(t, ok)
+		assert.Equal(t, headers[0].Hash, hash)
+	})
+
+	t.Run("refuses a target which is not below the head", func(t *testing.T) {
+		t.Parallel()
+
+		db, err := memory.NewMemoryStorage(nil)
+		require.NoError(t, err)
+
+		writeRewindTestChain(t, db, 3)
+
+		_, err = RewindStorage(db, 3, hasState)
+		assert.ErrorIs(t, err, ErrRewindNotBelowHead)
+
+		_, err = RewindStorage(db, 4, hasState)
+		assert.ErrorIs(t, err, ErrRewindNotBelowHead)
+	})
+
+	t.Run("refuses a target without state", func(t *testing.T) {
+		t.Parallel()
+
+		db, err := memory.NewMemoryStorage(nil)
+		require.NoError(t, err)
+
+		writeRewindTestChain(t, db, 3)
+
+		_, err = RewindStorage(db, 1, func(types.Hash) bool { return false })
+		assert.ErrorIs(t, err, ErrRewindStateUnavailable)
+
+		head, ok := db.ReadHeadNumber()
+		require.True(t, ok)
+		assert.Equal(t, uint64(3), head)
+	})
+}
+
+func TestBlockchain_SetHead(t *testing.T) {
+	t.Parallel()
+
+	b := NewTestBlockchain(t, nil)
+	headers, txs := writeRewindTestChain(t, b.db, 6)
+	b.setCurrentHeader(headers[6], big.NewInt(7))
+
+	b.SetFinalized(3)
+
+	_, err := b.SetHead(2)
+	assert.ErrorIs(t, err, ErrRewindBelowFinalized)
+
+	sub := b.SubscribeEvents()
+	defer b.UnsubscribeEvents(sub)
+
+	res, err := b.SetHead(4)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), res.RemovedBlocks)
+
+	assert.Equal(t, headers[4].Hash, b.Header().Hash)
+	assert.Equal(t, big.NewInt(5), b.CurrentTD())
+
+	_, ok := b.GetHeaderByNumber(5)
+	assert.False(t, ok)
+
+	_, ok = b.ReadTxLookup(txs[4].Hash)
+	assert.False(t, ok)
+
+	evnt := sub.GetEvent()
+	require.NotNil(t, evnt)
+	assert.Equal(t, EventReorg, evnt.Type)
+	assert.Equal(t, []*types.Header{headers[6], headers[5]}, evnt.OldChain)
+	assert.Equal(t, headers[4].Hash, evnt.Header().Hash)
+}
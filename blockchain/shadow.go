@@ -0,0 +1,106 @@
+package blockchain
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/xgr-network/xgr-node/state"
+	"github.com/xgr-network/xgr-node/types"
+	"github.com/xgr-network/xgr-node/types/buildroot"
+)
+
+// maxShadowDivergences is the number of divergences kept by a shadow fork,
+// older ones are dropped first
+const maxShadowDivergences = 1024
+
+// RootMapper translates the state roots of the followed network to the local ones
+type RootMapper interface {
+	MapRoot(remote, local types.Hash) error
+}
+
+// Divergence is a block whose local execution result differs from its header
+type Divergence struct {
+	Number               uint64               `json:"number"`
+	Hash                 types.Hash           `json:"hash"`
+	ExpectedRoot         types.Hash           `json:"expectedRoot"`
+	LocalRoot            types.Hash           `json:"localRoot"`
+	ExpectedGasUsed      uint64               `json:"expectedGasUsed"`
+	LocalGasUsed         uint64               `json:"localGasUsed"`
+	ExpectedReceiptsRoot types.Hash           `json:"expectedReceiptsRoot"`
+	LocalReceiptsRoot    types.Hash           `json:"localReceiptsRoot"`
+	Accounts             []*state.AccountDiff `json:"accounts"`
+}
+
+// ShadowFork lets a node follow a live network while executing its blocks with
+// local code changes. Blocks whose execution result doesn't match the header are
+// recorded as divergences instead of being rejected.
+type ShadowFork struct {
+	roots RootMapper
+
+	lock        sync.RWMutex
+	divergences []*Divergence
+}
+
+// NewShadowFork creates a shadow fork that maps the diverged roots with the given mapper
+func NewShadowFork(roots RootMapper) *ShadowFork {
+	return &ShadowFork{
+		roots: roots,
+	}
+}
+
+// record stores the divergence of the block and maps its state root to the local one
+func (s *ShadowFork) record(block *types.Block, result *BlockResult) error {
+	header := block.Header
+
+	if result.Root != header.StateRoot {
+		if err := s.roots.MapRoot(header.StateRoot, result.Root); err != nil {
+			return fmt.Errorf("failed to map shadow state root: %w", err)
+		}
+	}
+
+	divergence := &Divergence{
+		Number:               header.Number,
+		Hash:                 header.Hash,
+		ExpectedRoot:         header.StateRoot,
+		LocalRoot:            result.Root,
+		ExpectedGasUsed:      header.GasUsed,
+		LocalGasUsed:         result.TotalGas,
+		ExpectedReceiptsRoot: header.ReceiptsRoot,
+		LocalReceiptsRoot:    buildroot.CalculateReceiptsRoot(result.Receipts),
+		Accounts:             result.Accounts,
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// the same block can be verified more than once
+	if n := len(s.divergences); n > 0 && s.divergences[n-1].Hash == header.Hash {
+		s.divergences[n-1] = divergence
+
+		return nil
+	}
+
+	s.divergences = append(s.divergences, divergence)
+
+	if len(s.divergences) > maxShadowDivergences {
+		s.divergences = s.divergences[len(s.divergences)-maxShadowDivergences:]
+	}
+
+	return nil
+}
+
+// Divergences returns the recorded divergences starting at the given block number
+func (s *ShadowFork) Divergences(from uint64) []*Divergence {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	i := sort.Search(len(s.divergences), func(i int) bool {
+		return s.divergences[i].Number >= from
+	})
+
+	res := make([]*Divergence, len(s.divergences)-i)
+	copy(res, s.divergences[i:])
+
+	return res
+}
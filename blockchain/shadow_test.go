@@ -0,0 +1,127 @@
+package blockchain
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xgr-network/xgr-node/blockchain/storage"
+	"github.com/xgr-network/xgr-node/chain"
+	"github.com/xgr-network/xgr-node/state"
+	itrie "github.com/xgr-network/xgr-node/state/immutable-trie"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+func TestBlockchain_ShadowFork(t *testing.T) {
+	t.Parallel()
+
+	trieStorage := itrie.NewMemoryStorage()
+	shadowState := state.NewShadowState(itrie.NewState(trieStorage), trieStorage)
+
+	// without the precompile accounts created in the first block, so an empty block keeps the root
+	executor := state.NewExecutor(&chain.Params{
+		Forks: chain.AllForksEnabled.Copy().RemoveFork(chain.RewardAddress).RemoveFork(chain.DonationVote),
+		BurnContract: map[uint64]types.Address{
+			0: types.ZeroAddress,
+		},
+	}, shadowState, hclog.NewNullLogger())
+
+	account := types.StringToAddress("0x1")
+
+	genesisRoot, err := executor.WriteGenesis(map[types.Address]*chain.GenesisAccount{
+		account: {Balance: big.NewInt(1)},
+	}, types.ZeroHash)
+	require.NoError(t, err)
+
+	executor.GetHash = func(*types.Header) state.GetHashByNumber {
+		return func(uint64) types.Hash {
+			return types.ZeroHash
+		}
+	}
+
+	blockchain, err := NewMockBlockchain(map[TestCallbackType]interface{}{
+		StorageCallback: func(storage *storage.MockStorage) {
+			storage.HookReadHeader(func(types.Hash) (*types.Header, error) {
+				return &types.Header{StateRoot: genesisRoot}, nil
+			})
+		},
+		ExecutorCallback: func(mock *mockExecutor) {
+			mock.HookProcessBlock(executor.ProcessBlock)
+		},
+	})
+	require.NoError(t, err)
+
+	// the followed network computed a different root for the (empty) block
+	remoteRoot := types.StringToHash("0xabcd")
+	block := &types.Block{
+		Header: &types.Header{
+			Number:       1,
+			Hash:         types.StringToHash("0x1234"),
+			Sha3Uncles:   types.EmptyUncleHash,
+			TxRoot:       types.EmptyRootHash,
+			ReceiptsRoot: types.EmptyRootHash,
+			StateRoot:    remoteRoot,
+		},
+	}
+
+	_, err = blockchain.verifyBlockBody(block)
+	assert.ErrorIs(t, err, ErrInvalidStateRoot)
+
+	_, ok := blockchain.ShadowDivergences(0)
+	assert.False(t, ok)
+
+	blockchain.SetShadowFork(NewShadowFork(shadowState))
+
+	// verifying the block twice records a single divergence
+	for i := 0; i < 2; i++ {
+		_, err = blockchain.verifyBlockBody(block)
+		require.NoError(t, err)
+	}
+
+	divergences, ok := blockchain.ShadowDivergences(0)
+	require.True(t, ok)
+	require.Len(t, divergences, 1)
+
+	assert.Equal(t, uint64(1), divergences[0].Number)
+	assert.Equal(t, remoteRoot, divergences[0].ExpectedRoot)
+	assert.Equal(t, genesisRoot, divergences[0].LocalRoot)
+
+	divergences, _ = blockchain.ShadowDivergences(2)
+	assert.Empty(t, divergences)
+
+	// the remote root resolves to the local state, also after a restart
+	for _, st := range []*state.ShadowState{
+		shadowState,
+		state.NewShadowState(itrie.NewState(trieStorage), trieStorage),
+	} {
+		assert.Equal(t, genesisRoot, st.LocalRoot(remoteRoot))
+
+		snap, err := st.NewSnapshotAt(remoteRoot)
+		require.NoError(t, err)
+
+		acct, err := snap.GetAccount(account)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(1), acct.Balance)
+	}
+}
+
+func TestShadowFork_MaxDivergences(t *testing.T) {
+	t.Parallel()
+
+	trieStorage := itrie.NewMemoryStorage()
+	shadow := NewShadowFork(state.NewShadowState(itrie.NewState(trieStorage), trieStorage))
+
+	for i := uint64(1); i <= maxShadowDivergences+10; i++ {
+		header := &types.Header{Number: i, StateRoot: types.StringToHash("0x1")}
+		header.ComputeHash()
+
+		require.NoError(t, shadow.record(&types.Block{Header: header}, &BlockResult{}))
+	}
+
+	divergences := shadow.Divergences(0)
+	require.Len(t, divergences, maxShadowDivergences)
+	assert.Equal(t, uint64(11), divergences[0].Number)
+}
@@ -41,6 +41,30 @@ func (b *BatchWriter) PutTxLookup(hash types.Hash, blockHash types.Hash) {
 	b.putWithPrefix(TX_LOOKUP_PREFIX, hash.Bytes(), vr)
 }
 
+func (b *BatchWriter) PutAddressBloom(hash types.Hash, bloom types.AddressBloom) {
+	b.putWithPrefix(ADDRESS_BLOOM, hash.Bytes(), bloom[:])
+}
+
+func (b *BatchWriter) PutBlockEconomics(hash types.Hash, econ *types.BlockEconomics) {
+	ar := &fastrlp.Arena{}
+	vv := ar.NewArray()
+
+	vv.Set(ar.NewBigInt(econ.TotalBurned))
+	vv.Set(ar.NewBigInt(econ.TotalDonated))
+	vv.Set(ar.NewBigInt(econ.TotalValidatorFees))
+	vv.Set(ar.NewUint(econ.EngineTxCount))
+
+	b.putWithPrefix(BLOCK_ECONOMICS, hash.Bytes(), vv.MarshalTo([]byte{blockEconomicsVersion}))
+}
+
+func (b *BatchWriter) PutLogIndex(key LogIndexKey, section uint64, bitmap LogIndexBitmap) {
+	b.putWithPrefix(LOG_INDEX, logIndexSectionKey(key, section), bitmap)
+}
+
+func (b *BatchWriter) PutLogIndexTail(n uint64) {
+	b.putWithPrefix(LOG_INDEX, TAIL, common.EncodeUint64ToBytes(n))
+}
+
 func (b *BatchWriter) PutHeadNumber(n uint64) {
 	b.putWithPrefix(HEAD, NUMBER, common.EncodeUint64ToBytes(n))
 }
@@ -73,6 +97,22 @@ func (b *BatchWriter) PutForks(forks []types.Hash) {
 	b.putRlp(FORK, EMPTY, &ff)
 }
 
+func (b *BatchWriter) DeleteCanonicalHash(n uint64) {
+	b.deleteWithPrefix(CANONICAL, common.EncodeUint64ToBytes(n))
+}
+
+func (b *BatchWriter) DeleteReceipts(hash types.Hash) {
+	b.deleteWithPrefix(RECEIPTS, hash.Bytes())
+}
+
+func (b *BatchWriter) DeleteTxLookup(hash types.Hash) {
+	b.deleteWithPrefix(TX_LOOKUP_PREFIX, hash.Bytes())
+}
+
+func (b *BatchWriter) DeleteLogIndex(key LogIndexKey, section uint64) {
+	b.deleteWithPrefix(LOG_INDEX, logIndexSectionKey(key, section))
+}
+
 func (b *BatchWriter) putRlp(p, k []byte, raw types.RLPMarshaler) {
 	var data []byte
 
@@ -91,6 +131,12 @@ func (b *BatchWriter) putWithPrefix(p, k, data []byte) {
 	b.batch.Put(fullKey, data)
 }
 
+func (b *BatchWriter) deleteWithPrefix(p, k []byte) {
+	fullKey := append(append(make([]byte, 0, len(p)+len(k)), p...), k...)
+
+	b.batch.Delete(fullKey)
+}
+
 func (b *BatchWriter) WriteBatch() error {
 	return b.batch.Write()
 }
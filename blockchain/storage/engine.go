@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Engines the blockchain storage can be backed with. Both use the same key layout
+const (
+	EngineLevelDB = "leveldb"
+	EnginePebble  = "pebble"
+)
+
+var ErrEngineMismatch = errors.New("storage was created by another database engine")
+
+// DetectEngine returns the engine which created the storage at the given path,
+// or an empty string if the path doesn't exist or holds no database yet
+func DetectEngine(path string) (string, error) {
+	if _, err := os.Stat(filepath.Join(path, "CURRENT")); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+
+		return "", err
+	}
+
+	// both engines keep a CURRENT and MANIFEST file, only pebble writes OPTIONS files
+	options, err := filepath.Glob(filepath.Join(path, "OPTIONS-*"))
+	if err != nil {
+		return "", err
+	}
+
+	if len(options) > 0 {
+		return EnginePebble, nil
+	}
+
+	return EngineLevelDB, nil
+}
+
+// CheckEngine returns an error if the storage at the given path was created by another engine
+func CheckEngine(path string, engine string) error {
+	detected, err := DetectEngine(path)
+	if err != nil {
+		return err
+	}
+
+	if detected != "" && detected != engine {
+		return fmt.Errorf("%w: %s was created by %s, it can't be opened with %s, start the node with --db.engine=%s",
+			ErrEngineMismatch, path, detected, engine, detected)
+	}
+
+	return nil
+}
@@ -39,13 +39,27 @@ var (
 
 	// TX_LOOKUP_PREFIX is the prefix for transaction lookups
 	TX_LOOKUP_PREFIX = []byte("l")
+
+	// ADDRESS_BLOOM is the prefix for the per-block address activity blooms
+	ADDRESS_BLOOM = []byte("a")
+
+	// BLOCK_ECONOMICS is the prefix for the per-block fee split aggregates
+	BLOCK_ECONOMICS = []byte("e")
+
+	// LOG_INDEX is the prefix for the log index bitmaps
+	LOG_INDEX = []byte("i")
 )
 
+// blockEconomicsVersion is the encoding version of the stored block economics.
+// Entries with another version are ignored and recomputed from the receipts
+const blockEconomicsVersion byte = 1
+
 // Sub-prefixes
 var (
 	HASH   = []byte("hash")
 	NUMBER = []byte("number")
 	EMPTY  = []byte("empty")
+	TAIL   = []byte("tail")
 )
 
 // KV is a key value storage interface.
@@ -191,6 +205,86 @@ func (s *KeyValueStorage) ReadTxLookup(hash types.Hash) (types.Hash, bool) {
 	return types.BytesToHash(blockHash), true
 }
 
+// ADDRESS BLOOM //
+
+// ReadAddressBloom reads the address activity bloom of the block
+func (s *KeyValueStorage) ReadAddressBloom(hash types.Hash) (types.AddressBloom, bool) {
+	var bloom types.AddressBloom
+
+	data, ok := s.get(ADDRESS_BLOOM, hash.Bytes())
+	if !ok || len(data) != types.AddressBloomByteLength {
+		return bloom, false
+	}
+
+	copy(bloom[:], data)
+
+	return bloom, true
+}
+
+// BLOCK ECONOMICS //
+
+// ReadBlockEconomics reads the fee split aggregates of the block
+func (s *KeyValueStorage) ReadBlockEconomics(hash types.Hash) (*types.BlockEconomics, bool) {
+	data, ok := s.get(BLOCK_ECONOMICS, hash.Bytes())
+	if !ok || len(data) < 1 || data[0] != blockEconomicsVersion {
+		return nil, false
+	}
+
+	parser := &fastrlp.Parser{}
+
+	v, err := parser.Parse(data[1:])
+	if err != nil {
+		return nil, false
+	}
+
+	elems, err := v.GetElems()
+	if err != nil || len(elems) != 4 {
+		return nil, false
+	}
+
+	econ := types.NewBlockEconomics()
+
+	if err := elems[0].GetBigInt(econ.TotalBurned); err != nil {
+		return nil, false
+	}
+
+	if err := elems[1].GetBigInt(econ.TotalDonated); err != nil {
+		return nil, false
+	}
+
+	if err := elems[2].GetBigInt(econ.TotalValidatorFees); err != nil {
+		return nil, false
+	}
+
+	if econ.EngineTxCount, err = elems[3].GetUint64(); err != nil {
+		return nil, false
+	}
+
+	return econ, true
+}
+
+// LOG INDEX //
+
+// ReadLogIndex reads the log index bitmap of the key in the section
+func (s *KeyValueStorage) ReadLogIndex(key LogIndexKey, section uint64) (LogIndexBitmap, bool) {
+	data, ok := s.get(LOG_INDEX, logIndexSectionKey(key, section))
+	if !ok || uint64(len(data)) != LogIndexSectionSize/8 {
+		return nil, false
+	}
+
+	return data, true
+}
+
+// ReadLogIndexTail reads the first block of the log index, the blocks from it to the head are indexed
+func (s *KeyValueStorage) ReadLogIndexTail() (uint64, bool) {
+	data, ok := s.get(LOG_INDEX, TAIL)
+	if !ok || len(data) != 8 {
+		return 0, false
+	}
+
+	return common.EncodeBytesToUint64(data), true
+}
+
 var ErrNotFound = fmt.Errorf("not found")
 
 func (s *KeyValueStorage) readRLP(p, k []byte, raw types.RLPUnmarshaler) error {
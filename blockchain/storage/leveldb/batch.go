@@ -2,31 +2,93 @@ package leveldb
 
 import (
 	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
 	"github.com/xgr-network/xgr-node/blockchain/storage"
 )
 
+// ImportMaxBatchSize is the size of the pending keys and values which flushes the batches of bulk imports
+const ImportMaxBatchSize = 64 * opt.MiB
+
 var _ storage.Batch = (*batchLevelDB)(nil)
 
 type batchLevelDB struct {
 	db *leveldb.DB
 	b  *leveldb.Batch
+
+	size    int // size of the pending keys and values
+	maxSize int // size which flushes the pending batch, 0 disables the auto-flush
+	err     error
 }
 
+// NewBatchLevelDB creates a batch which is only written by Write, so all of its puts and deletes
+// (e.g. a block with its receipts) are written atomically
 func NewBatchLevelDB(db *leveldb.DB) *batchLevelDB {
+	return NewBatchLevelDBWithLimit(db, 0)
+}
+
+// NewBatchLevelDBWithLimit creates a batch which is written to the db, and started over,
+// as soon as the pending keys and values exceed maxSize bytes. The batch isn't atomic anymore,
+// it is meant for bulk imports only
+func NewBatchLevelDBWithLimit(db *leveldb.DB, maxSize int) *batchLevelDB {
 	return &batchLevelDB{
-		db: db,
-		b:  new(leveldb.Batch),
+		db:      db,
+		b:       new(leveldb.Batch),
+		maxSize: maxSize,
 	}
 }
 
 func (b *batchLevelDB) Delete(key []byte) {
 	b.b.Delete(key)
+	b.size += len(key)
+	b.flushIfFull()
 }
 
 func (b *batchLevelDB) Put(k []byte, v []byte) {
 	b.b.Put(k, v)
+	b.size += len(k) + len(v)
+	b.flushIfFull()
 }
 
+// Write writes the pending puts and deletes. It returns the error of an earlier auto-flush,
+// the batch is empty afterwards so writing it again is a no-op
 func (b *batchLevelDB) Write() error {
-	return b.db.Write(b.b, nil)
+	if b.err != nil {
+		return b.err
+	}
+
+	return b.flush()
+}
+
+// Reset drops the pending puts and deletes and the error of an earlier auto-flush,
+// so the batch can be refilled. Calling Reset after Write is safe,
+// the written puts and deletes are not affected
+func (b *batchLevelDB) Reset() {
+	b.b.Reset()
+	b.size = 0
+	b.err = nil
+}
+
+// flushIfFull writes the pending batch once it exceeds the max size.
+// The first failed flush is kept for Write, the batch isn't flushed anymore after it
+func (b *batchLevelDB) flushIfFull() {
+	if b.maxSize <= 0 || b.size <= b.maxSize || b.err != nil {
+		return
+	}
+
+	b.err = b.flush()
+}
+
+func (b *batchLevelDB) flush() error {
+	if b.b.Len() == 0 {
+		return nil
+	}
+
+	if err := b.db.Write(b.b, nil); err != nil {
+		return err
+	}
+
+	b.b.Reset()
+	b.size = 0
+
+	return nil
 }
@@ -0,0 +1,166 @@
+package leveldb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+func TestBatchLevelDB_AutoFlush(t *testing.T) {
+	t.Parallel()
+
+	db, err := leveldb.OpenFile(t.TempDir(), nil)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+
+	const (
+		count   = 1000
+		maxSize = 1024
+	)
+
+	key := func(i int) []byte {
+		return []byte(fmt.Sprintf("key-%04d", i))
+	}
+
+	batch := NewBatchLevelDBWithLimit(db, maxSize)
+
+	for i := 0; i < count; i++ {
+		batch.Put(key(i), make([]byte, 32))
+	}
+
+	// the first puts were flushed before the batch is written
+	_, err = db.Get(key(0), nil)
+	require.NoError(t, err)
+	require.LessOrEqual(t, batch.size, maxSize)
+
+	batch.Delete(key(0))
+
+	require.NoError(t, batch.Write())
+	require.NoError(t, batch.Write())
+	require.Zero(t, batch.b.Len())
+
+	_, err = db.Get(key(0), nil)
+	require.ErrorIs(t, err, leveldb.ErrNotFound)
+
+	for i := 1; i < count; i++ {
+		value, err := db.Get(key(i), nil)
+		require.NoError(t, err)
+		require.Len(t, value, 32)
+	}
+}
+
+func TestBatchLevelDB_NoAutoFlushByDefault(t *testing.T) {
+	t.Parallel()
+
+	db, err := leveldb.OpenFile(t.TempDir(), nil)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+
+	batch := NewBatchLevelDB(db)
+
+	// far above the import limit, nothing is written before Write
+	value := make([]byte, 1024*1024)
+	for i := 0; i < 2*ImportMaxBatchSize/len(value); i++ {
+		batch.Put([]byte(fmt.Sprintf("key-%04d", i)), value)
+	}
+
+	_, err = db.Get([]byte("key-0000"), nil)
+	require.ErrorIs(t, err, leveldb.ErrNotFound)
+
+	require.NoError(t, batch.Write())
+
+	_, err = db.Get([]byte("key-0000"), nil)
+	require.NoError(t, err)
+}
+
+func TestBatchLevelDB_Reset(t *testing.T) {
+	t.Parallel()
+
+	db, err := leveldb.OpenFile(t.TempDir(), nil)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+
+	batch := NewBatchLevelDB(db)
+
+	batch.Put([]byte("a"), []byte("1"))
+	batch.Put([]byte("b"), []byte("1"))
+	require.NoError(t, batch.Write())
+
+	batch.Reset()
+
+	batch.Put([]byte("b"), []byte("2"))
+	batch.Delete([]byte("a"))
+	require.NoError(t, batch.Write())
+
+	// the pending puts are dropped by Reset
+	batch.Put([]byte("c"), []byte("3"))
+	batch.Reset()
+	require.NoError(t, batch.Write())
+
+	_, err = db.Get([]byte("a"), nil)
+	require.ErrorIs(t, err, leveldb.ErrNotFound)
+
+	value, err := db.Get([]byte("b"), nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte("2"), value)
+
+	_, err = db.Get([]byte("c"), nil)
+	require.ErrorIs(t, err, leveldb.ErrNotFound)
+}
+
+func BenchmarkBatchLevelDB(b *testing.B) {
+	db, err := leveldb.OpenFile(b.TempDir(), nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	defer db.Close()
+
+	key, value := make([]byte, 32), make([]byte, 128)
+
+	fill := func(batch *batchLevelDB) {
+		for i := 0; i < 100; i++ {
+			key[0] = byte(i)
+			batch.Put(key, value)
+		}
+	}
+
+	b.Run("fresh", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			batch := NewBatchLevelDB(db)
+			fill(batch)
+
+			if err := batch.Write(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("reuse", func(b *testing.B) {
+		b.ReportAllocs()
+
+		batch := NewBatchLevelDB(db)
+
+		for i := 0; i < b.N; i++ {
+			batch.Reset()
+			fill(batch)
+
+			if err := batch.Write(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
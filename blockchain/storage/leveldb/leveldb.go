@@ -43,6 +43,10 @@ func NewLevelDBStorage(path string, logger hclog.Logger) (storage.Storage, error
 
 // NewLevelDBStorageWithOpt creates the new storage reference with leveldb with custom options
 func NewLevelDBStorageWithOpt(path string, logger hclog.Logger, opts *opt.Options) (storage.Storage, error) {
+	if err := storage.CheckEngine(path, storage.EngineLevelDB); err != nil {
+		return nil, err
+	}
+
 	db, err := leveldb.OpenFile(path, opts)
 	if err != nil {
 		return nil, err
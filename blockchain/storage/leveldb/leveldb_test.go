@@ -48,6 +48,19 @@ func TestStorage(t *testing.T) {
 	storage.TestStorage(t, newStorage)
 }
 
+func BenchmarkStorage(b *testing.B) {
+	storage.BenchmarkStorage(b, func(b *testing.B) (storage.Storage, func()) {
+		b.Helper()
+
+		s, err := NewLevelDBStorage(b.TempDir(), hclog.NewNullLogger())
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		return s, func() { _ = s.Close() }
+	})
+}
+
 func generateTxs(t *testing.T, startNonce, count int, from types.Address, to *types.Address) []*types.Transaction {
 	t.Helper()
 
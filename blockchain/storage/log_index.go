@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"github.com/xgr-network/xgr-node/helper/common"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+// LogIndexSectionSize is the number of blocks covered by one bitmap of the log index
+const LogIndexSectionSize uint64 = 4096
+
+// Log index key kinds
+var (
+	logIndexAddress = []byte("a")
+	logIndexTopic   = []byte("t")
+)
+
+// LogIndexKey identifies the blocks with logs emitted by an address or with a first topic
+type LogIndexKey []byte
+
+// LogIndexAddressKey returns the log index key of the logs emitted by the address
+func LogIndexAddressKey(addr types.Address) LogIndexKey {
+	return append(append(LogIndexKey{}, logIndexAddress...), addr.Bytes()...)
+}
+
+// LogIndexTopicKey returns the log index key of the logs with the first topic
+func LogIndexTopicKey(topic types.Hash) LogIndexKey {
+	return append(append(LogIndexKey{}, logIndexTopic...), topic.Bytes()...)
+}
+
+// LogIndexSection returns the section of the log index covering the block
+func LogIndexSection(number uint64) uint64 {
+	return number / LogIndexSectionSize
+}
+
+// logIndexSectionKey returns the storage key of the bitmap of a section
+func logIndexSectionKey(key LogIndexKey, section uint64) []byte {
+	return append(append(make([]byte, 0, len(key)+8), key...), common.EncodeUint64ToBytes(section)...)
+}
+
+// LogIndexBitmap marks the blocks of a section which contain a log with the key
+type LogIndexBitmap []byte
+
+// NewLogIndexBitmap returns an empty bitmap of a section
+func NewLogIndexBitmap() LogIndexBitmap {
+	return make(LogIndexBitmap, LogIndexSectionSize/8)
+}
+
+// Set marks the block
+func (b LogIndexBitmap) Set(number uint64) {
+	i := number % LogIndexSectionSize
+	b[i/8] |= 1 << (i % 8)
+}
+
+// Clear unmarks the block
+func (b LogIndexBitmap) Clear(number uint64) {
+	i := number % LogIndexSectionSize
+	b[i/8] &^= 1 << (i % 8)
+}
+
+// Has returns true if the block is marked
+func (b LogIndexBitmap) Has(number uint64) bool {
+	i := number % LogIndexSectionSize
+
+	return b[i/8]&(1<<(i%8)) != 0
+}
+
+// Or marks the blocks marked in the other bitmap
+func (b LogIndexBitmap) Or(other LogIndexBitmap) {
+	for i := range b {
+		b[i] |= other[i]
+	}
+}
+
+// And unmarks the blocks not marked in the other bitmap
+func (b LogIndexBitmap) And(other LogIndexBitmap) {
+	for i := range b {
+		b[i] &= other[i]
+	}
+}
+
+// IsEmpty returns true if no block is marked
+func (b LogIndexBitmap) IsEmpty() bool {
+	for _, v := range b {
+		if v != 0 {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,33 @@
+package pebble
+
+import (
+	"github.com/cockroachdb/pebble"
+	"github.com/xgr-network/xgr-node/blockchain/storage"
+)
+
+var _ storage.Batch = (*batchPebble)(nil)
+
+type batchPebble struct {
+	b *pebble.Batch
+}
+
+func NewBatchPebble(db *pebble.DB) *batchPebble {
+	return &batchPebble{
+		b: db.NewBatch(),
+	}
+}
+
+func (b *batchPebble) Delete(key []byte) {
+	_ = b.b.Delete(key, nil)
+}
+
+func (b *batchPebble) Put(k []byte, v []byte) {
+	_ = b.b.Set(k, v, nil)
+}
+
+// Write commits the batch atomically, the batch can't be reused afterwards
+func (b *batchPebble) Write() error {
+	defer b.b.Close()
+
+	return b.b.Commit(pebble.Sync)
+}
@@ -0,0 +1,38 @@
+package pebble
+
+import (
+	"github.com/cockroachdb/pebble"
+)
+
+// Iterator iterates over the key-value pairs of a key range, in key order.
+// Key and Value are only valid until the next call to Next
+type Iterator struct {
+	iter    *pebble.Iterator
+	started bool
+}
+
+// Next moves the iterator to the next pair, it returns false once the range is exhausted
+func (i *Iterator) Next() bool {
+	if !i.started {
+		i.started = true
+
+		return i.iter.First()
+	}
+
+	return i.iter.Next()
+}
+
+// Key returns the key of the current pair
+func (i *Iterator) Key() []byte {
+	return i.iter.Key()
+}
+
+// Value returns the value of the current pair
+func (i *Iterator) Value() []byte {
+	return i.iter.Value()
+}
+
+// Release releases the iterator, it returns the first error hit while iterating
+func (i *Iterator) Release() error {
+	return i.iter.Close()
+}
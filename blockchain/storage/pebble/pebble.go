@@ -0,0 +1,159 @@
+package pebble
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/hashicorp/go-hclog"
+	"github.com/xgr-network/xgr-node/blockchain/storage"
+)
+
+const (
+	DefaultCache   = int(256)
+	DefaultHandles = int(256)
+
+	mib = 1024 * 1024
+)
+
+// Factory creates a pebble storage
+func Factory(config map[string]interface{}, logger hclog.Logger) (storage.Storage, error) {
+	path, ok := config["path"]
+	if !ok {
+		return nil, fmt.Errorf("path not found")
+	}
+
+	pathStr, ok := path.(string)
+	if !ok {
+		return nil, fmt.Errorf("path is not a string")
+	}
+
+	return NewPebbleStorage(pathStr, logger)
+}
+
+// NewPebbleStorage creates the new storage reference with pebble default options
+func NewPebbleStorage(path string, logger hclog.Logger) (storage.Storage, error) {
+	cache := pebble.NewCache(int64(DefaultCache / 2 * mib))
+	defer cache.Unref()
+
+	// Set default options
+	options := &pebble.Options{
+		Cache:        cache,
+		MaxOpenFiles: DefaultHandles,
+		MemTableSize: uint64(DefaultCache / 4 * mib),
+		Logger:       &pebbleLogger{logger.Named("pebble")},
+	}
+
+	return NewPebbleStorageWithOpt(path, logger, options)
+}
+
+// NewPebbleStorageWithOpt creates the new storage reference with pebble with custom options
+func NewPebbleStorageWithOpt(path string, logger hclog.Logger, opts *pebble.Options) (storage.Storage, error) {
+	kv, err := newPebbleKV(path, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return storage.NewKeyValueStorage(logger.Named("pebble"), kv), nil
+}
+
+func newPebbleKV(path string, opts *pebble.Options) (*pebbleKV, error) {
+	if err := storage.CheckEngine(path, storage.EnginePebble); err != nil {
+		return nil, err
+	}
+
+	db, err := pebble.Open(path, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pebbleKV{db}, nil
+}
+
+// pebbleKV is the pebble implementation of the kv storage
+type pebbleKV struct {
+	db *pebble.DB
+}
+
+// Set sets the key-value pair in pebble storage
+func (p *pebbleKV) Set(k []byte, v []byte) error {
+	return p.db.Set(k, v, pebble.Sync)
+}
+
+// Get retrieves the key-value pair in pebble storage
+func (p *pebbleKV) Get(k []byte) ([]byte, bool, error) {
+	data, closer, err := p.db.Get(k)
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	// the returned slice is only valid until the closer is closed
+	value := make([]byte, len(data))
+	copy(value, data)
+
+	if err := closer.Close(); err != nil {
+		return nil, false, err
+	}
+
+	return value, true, nil
+}
+
+// NewIterator returns an iterator over the keys with the given prefix, in key order
+func (p *pebbleKV) NewIterator(prefix []byte) (*Iterator, error) {
+	iter, err := p.db.NewIter(&pebble.IterOptions{
+		LowerBound: prefix,
+		UpperBound: prefixUpperBound(prefix),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Iterator{iter: iter}, nil
+}
+
+// Close closes the pebble storage instance
+func (p *pebbleKV) Close() error {
+	return p.db.Close()
+}
+
+func (p *pebbleKV) NewBatch() storage.Batch {
+	return NewBatchPebble(p.db)
+}
+
+// prefixUpperBound returns the smallest key greater than all keys with the prefix,
+// nil if there is none
+func prefixUpperBound(prefix []byte) []byte {
+	upper := make([]byte, len(prefix))
+	copy(upper, prefix)
+
+	for i := len(upper) - 1; i >= 0; i-- {
+		upper[i]++
+		if upper[i] != 0 {
+			return upper[:i+1]
+		}
+	}
+
+	return nil
+}
+
+// pebbleLogger routes the pebble logs into the node logger
+type pebbleLogger struct {
+	logger hclog.Logger
+}
+
+func (l *pebbleLogger) Infof(format string, args ...interface{}) {
+	l.logger.Debug(fmt.Sprintf(format, args...))
+}
+
+func (l *pebbleLogger) Errorf(format string, args ...interface{}) {
+	l.logger.Error(fmt.Sprintf(format, args...))
+}
+
+func (l *pebbleLogger) Fatalf(format string, args ...interface{}) {
+	l.logger.Error(fmt.Sprintf(format, args...))
+	panic(fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,161 @@
+package pebble
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+	"github.com/xgr-network/xgr-node/blockchain/storage"
+	"github.com/xgr-network/xgr-node/blockchain/storage/leveldb"
+)
+
+func newStorage(t *testing.T) (storage.Storage, func()) {
+	t.Helper()
+
+	s, err := NewPebbleStorage(t.TempDir(), hclog.NewNullLogger())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	closeFn := func() {
+		if err := s.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return s, closeFn
+}
+
+func TestStorage(t *testing.T) {
+	storage.TestStorage(t, newStorage)
+}
+
+func BenchmarkStorage(b *testing.B) {
+	storage.BenchmarkStorage(b, func(b *testing.B) (storage.Storage, func()) {
+		b.Helper()
+
+		s, err := NewPebbleStorage(b.TempDir(), hclog.NewNullLogger())
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		return s, func() { _ = s.Close() }
+	})
+}
+
+func TestPebbleKV_PutGetBatch(t *testing.T) {
+	t.Parallel()
+
+	path := t.TempDir()
+
+	kv, err := newPebbleKV(path, &pebble.Options{})
+	require.NoError(t, err)
+
+	// missing keys are not an error
+	_, ok, err := kv.Get([]byte("a"))
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	require.NoError(t, kv.Set([]byte("a"), []byte("1")))
+
+	batch := kv.NewBatch()
+	batch.Put([]byte("b"), []byte("2"))
+	batch.Put([]byte("c"), []byte("3"))
+	batch.Delete([]byte("a"))
+
+	// nothing is visible before the batch is written
+	_, ok, err = kv.Get([]byte("b"))
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	require.NoError(t, batch.Write())
+
+	_, ok, err = kv.Get([]byte("a"))
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	value, ok, err := kv.Get([]byte("c"))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, []byte("3"), value)
+
+	// the data survives a reopen
+	require.NoError(t, kv.Close())
+
+	kv, err = newPebbleKV(path, &pebble.Options{})
+	require.NoError(t, err)
+
+	defer kv.Close()
+
+	value, ok, err = kv.Get([]byte("b"))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, []byte("2"), value)
+}
+
+func TestPebbleKV_Iterator(t *testing.T) {
+	t.Parallel()
+
+	kv, err := newPebbleKV(t.TempDir(), &pebble.Options{})
+	require.NoError(t, err)
+
+	defer kv.Close()
+
+	batch := kv.NewBatch()
+	batch.Put([]byte("h2"), []byte("2"))
+	batch.Put([]byte("h1"), []byte("1"))
+	batch.Put([]byte("h\xff"), []byte("3"))
+	batch.Put([]byte("i1"), []byte("other prefix"))
+	batch.Put([]byte("g1"), []byte("other prefix"))
+	require.NoError(t, batch.Write())
+
+	iter, err := kv.NewIterator([]byte("h"))
+	require.NoError(t, err)
+
+	var keys, values []string
+
+	for iter.Next() {
+		keys = append(keys, string(iter.Key()))
+		values = append(values, string(iter.Value()))
+	}
+
+	require.NoError(t, iter.Release())
+	require.Equal(t, []string{"h1", "h2", "h\xff"}, keys)
+	require.Equal(t, []string{"1", "2", "3"}, values)
+}
+
+func TestPebble_EngineMismatch(t *testing.T) {
+	t.Parallel()
+
+	logger := hclog.NewNullLogger()
+
+	// leveldb datadir opened with pebble
+	levelDBPath := t.TempDir()
+
+	s, err := leveldb.NewLevelDBStorage(levelDBPath, logger)
+	require.NoError(t, err)
+	require.NoError(t, s.Close())
+
+	_, err = NewPebbleStorage(levelDBPath, logger)
+	require.ErrorIs(t, err, storage.ErrEngineMismatch)
+
+	// pebble datadir opened with leveldb
+	pebblePath := t.TempDir()
+
+	s, err = NewPebbleStorage(pebblePath, logger)
+	require.NoError(t, err)
+	require.NoError(t, s.Close())
+
+	_, err = leveldb.NewLevelDBStorage(pebblePath, logger)
+	require.ErrorIs(t, err, storage.ErrEngineMismatch)
+
+	// both still open with their own engine
+	s, err = NewPebbleStorage(pebblePath, logger)
+	require.NoError(t, err)
+	require.NoError(t, s.Close())
+
+	s, err = leveldb.NewLevelDBStorage(levelDBPath, logger)
+	require.NoError(t, err)
+	require.NoError(t, s.Close())
+}
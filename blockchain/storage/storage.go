@@ -26,6 +26,13 @@ type Storage interface {
 
 	ReadTxLookup(hash types.Hash) (types.Hash, bool)
 
+	ReadAddressBloom(hash types.Hash) (types.AddressBloom, bool)
+
+	ReadBlockEconomics(hash types.Hash) (*types.BlockEconomics, bool)
+
+	ReadLogIndex(key LogIndexKey, section uint64) (LogIndexBitmap, bool)
+	ReadLogIndexTail() (uint64, bool)
+
 	NewBatch() Batch
 
 	Close() error
@@ -49,6 +49,98 @@ func TestStorage(t *testing.T, m PlaceholderStorage) {
 	t.Run("testReceipts", func(t *testing.T) {
 		testReceipts(t, m)
 	})
+	t.Run("testBlockEconomics", func(t *testing.T) {
+		testBlockEconomics(t, m)
+	})
+	t.Run("testLogIndex", func(t *testing.T) {
+		testLogIndex(t, m)
+	})
+}
+
+// BenchmarkStorage runs a set of benchmarks on a storage, so that the engines can be compared
+func BenchmarkStorage(b *testing.B, m func(b *testing.B) (Storage, func())) {
+	b.Helper()
+
+	b.Run("benchmarkWriteBatch", func(b *testing.B) {
+		benchmarkWriteBatch(b, m)
+	})
+	b.Run("benchmarkReadHeader", func(b *testing.B) {
+		benchmarkReadHeader(b, m)
+	})
+	b.Run("benchmarkReadMissing", func(b *testing.B) {
+		benchmarkReadMissing(b, m)
+	})
+}
+
+// benchmarkWriteBatch writes the header and the canonical hash of one block per batch
+func benchmarkWriteBatch(b *testing.B, m func(b *testing.B) (Storage, func())) {
+	b.Helper()
+
+	s, closeFn := m(b)
+	defer closeFn()
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		header := &types.Header{Number: uint64(i), ExtraData: []byte{}}
+		header.ComputeHash()
+
+		batch := NewBatchWriter(s)
+		batch.PutHeader(header)
+		batch.PutCanonicalHash(header.Number, header.Hash)
+
+		if err := batch.WriteBatch(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func benchmarkReadHeader(b *testing.B, m func(b *testing.B) (Storage, func())) {
+	b.Helper()
+
+	s, closeFn := m(b)
+	defer closeFn()
+
+	const count = 1000
+
+	hashes := make([]types.Hash, count)
+	batch := NewBatchWriter(s)
+
+	for i := range hashes {
+		header := &types.Header{Number: uint64(i), ExtraData: []byte{}}
+		header.ComputeHash()
+
+		batch.PutHeader(header)
+
+		hashes[i] = header.Hash
+	}
+
+	if err := batch.WriteBatch(); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := s.ReadHeader(hashes[i%count]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func benchmarkReadMissing(b *testing.B, m func(b *testing.B) (Storage, func())) {
+	b.Helper()
+
+	s, closeFn := m(b)
+	defer closeFn()
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, ok := s.ReadCanonicalHash(uint64(i)); ok {
+			b.Fatal("unexpected canonical hash")
+		}
+	}
 }
 
 func testCanonicalChain(t *testing.T, m PlaceholderStorage) {
@@ -394,6 +486,90 @@ func testReceipts(t *testing.T, m PlaceholderStorage) {
 	assert.True(t, reflect.DeepEqual(receipts, found))
 }
 
+func testBlockEconomics(t *testing.T, m PlaceholderStorage) {
+	t.Helper()
+
+	s, closeFn := m(t)
+	defer closeFn()
+
+	_, ok := s.ReadBlockEconomics(hash1)
+	assert.False(t, ok)
+
+	econ := &types.BlockEconomics{
+		TotalBurned:        big.NewInt(3000),
+		TotalDonated:       big.NewInt(20),
+		TotalValidatorFees: new(big.Int),
+		EngineTxCount:      2,
+	}
+
+	batch := NewBatchWriter(s)
+	batch.PutBlockEconomics(hash1, econ)
+	require.NoError(t, batch.WriteBatch())
+
+	found, ok := s.ReadBlockEconomics(hash1)
+	require.True(t, ok)
+	assert.Equal(t, econ, found)
+
+	// entries of an unknown encoding version are ignored
+	batch = NewBatchWriter(s)
+	batch.putWithPrefix(BLOCK_ECONOMICS, hash2.Bytes(), []byte{blockEconomicsVersion + 1, 0xc0})
+	require.NoError(t, batch.WriteBatch())
+
+	_, ok = s.ReadBlockEconomics(hash2)
+	assert.False(t, ok)
+}
+
+func testLogIndex(t *testing.T, m PlaceholderStorage) {
+	t.Helper()
+
+	s, closeFn := m(t)
+	defer closeFn()
+
+	addrKey := LogIndexAddressKey(addr1)
+	topicKey := LogIndexTopicKey(hash1)
+
+	_, ok := s.ReadLogIndex(addrKey, 0)
+	assert.False(t, ok)
+
+	_, ok = s.ReadLogIndexTail()
+	assert.False(t, ok)
+
+	bitmap := NewLogIndexBitmap()
+	bitmap.Set(LogIndexSectionSize + 5)
+
+	batch := NewBatchWriter(s)
+	batch.PutLogIndex(addrKey, 1, bitmap)
+	batch.PutLogIndex(topicKey, 1, bitmap)
+	batch.PutLogIndexTail(7)
+	require.NoError(t, batch.WriteBatch())
+
+	found, ok := s.ReadLogIndex(addrKey, 1)
+	require.True(t, ok)
+	assert.True(t, found.Has(5))
+	assert.False(t, found.Has(6))
+
+	// the sections and key kinds are separate
+	_, ok = s.ReadLogIndex(addrKey, 0)
+	assert.False(t, ok)
+
+	_, ok = s.ReadLogIndex(LogIndexTopicKey(types.BytesToHash(addr1.Bytes())), 1)
+	assert.False(t, ok)
+
+	tail, ok := s.ReadLogIndexTail()
+	require.True(t, ok)
+	assert.Equal(t, uint64(7), tail)
+
+	batch = NewBatchWriter(s)
+	batch.DeleteLogIndex(addrKey, 1)
+	require.NoError(t, batch.WriteBatch())
+
+	_, ok = s.ReadLogIndex(addrKey, 1)
+	assert.False(t, ok)
+
+	_, ok = s.ReadLogIndex(topicKey, 1)
+	assert.True(t, ok)
+}
+
 func testWriteCanonicalHeader(t *testing.T, m PlaceholderStorage) {
 	t.Helper()
 
@@ -460,6 +636,10 @@ type readBodyDelegate func(types.Hash) (*types.Body, error)
 type readSnapshotDelegate func(types.Hash) ([]byte, bool)
 type readReceiptsDelegate func(types.Hash) ([]*types.Receipt, error)
 type readTxLookupDelegate func(types.Hash) (types.Hash, bool)
+type readAddressBloomDelegate func(types.Hash) (types.AddressBloom, bool)
+type readBlockEconomicsDelegate func(types.Hash) (*types.BlockEconomics, bool)
+type readLogIndexDelegate func(LogIndexKey, uint64) (LogIndexBitmap, bool)
+type readLogIndexTailDelegate func() (uint64, bool)
 type closeDelegate func() error
 type newBatchDelegate func() Batch
 
@@ -473,6 +653,10 @@ type MockStorage struct {
 	readBodyFn            readBodyDelegate
 	readReceiptsFn        readReceiptsDelegate
 	readTxLookupFn        readTxLookupDelegate
+	readAddressBloomFn    readAddressBloomDelegate
+	readBlockEconomicsFn  readBlockEconomicsDelegate
+	readLogIndexFn        readLogIndexDelegate
+	readLogIndexTailFn    readLogIndexTailDelegate
 	closeFn               closeDelegate
 	newBatchFn            newBatchDelegate
 }
@@ -589,6 +773,54 @@ func (m *MockStorage) HookReadTxLookup(fn readTxLookupDelegate) {
 	m.readTxLookupFn = fn
 }
 
+func (m *MockStorage) ReadAddressBloom(hash types.Hash) (types.AddressBloom, bool) {
+	if m.readAddressBloomFn != nil {
+		return m.readAddressBloomFn(hash)
+	}
+
+	return types.AddressBloom{}, false
+}
+
+func (m *MockStorage) HookReadAddressBloom(fn readAddressBloomDelegate) {
+	m.readAddressBloomFn = fn
+}
+
+func (m *MockStorage) ReadBlockEconomics(hash types.Hash) (*types.BlockEconomics, bool) {
+	if m.readBlockEconomicsFn != nil {
+		return m.readBlockEconomicsFn(hash)
+	}
+
+	return nil, false
+}
+
+func (m *MockStorage) HookReadBlockEconomics(fn readBlockEconomicsDelegate) {
+	m.readBlockEconomicsFn = fn
+}
+
+func (m *MockStorage) ReadLogIndex(key LogIndexKey, section uint64) (LogIndexBitmap, bool) {
+	if m.readLogIndexFn != nil {
+		return m.readLogIndexFn(key, section)
+	}
+
+	return nil, false
+}
+
+func (m *MockStorage) HookReadLogIndex(fn readLogIndexDelegate) {
+	m.readLogIndexFn = fn
+}
+
+func (m *MockStorage) ReadLogIndexTail() (uint64, bool) {
+	if m.readLogIndexTailFn != nil {
+		return m.readLogIndexTailFn()
+	}
+
+	return 0, false
+}
+
+func (m *MockStorage) HookReadLogIndexTail(fn readLogIndexTailDelegate) {
+	m.readLogIndexTailFn = fn
+}
+
 func (m *MockStorage) Close() error {
 	if m.closeFn != nil {
 		return m.closeFn()
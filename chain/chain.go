@@ -409,6 +409,14 @@ func importChain(content []byte) (*Chain, error) {
 		if chain.Params.BootstrapEngineEOA != (types.Address{}) {
 			BootstrapEngineEOA = chain.Params.BootstrapEngineEOA
 		}
+		if pct := chain.Params.DefaultDonationPercent; pct != nil && *pct > 100 {
+			return nil, fmt.Errorf("invalid default donation percent %d", *pct)
+		}
+		if gov := chain.Params.DonationGovernance; gov != nil {
+			if err := gov.validate(); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	return chain, nil
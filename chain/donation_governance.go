@@ -0,0 +1,28 @@
+package chain
+
+import (
+	"errors"
+)
+
+var errDonationGovernanceThreshold = errors.New("invalid donation governance threshold")
+
+// DonationGovernanceConfig configures the on-chain vote on the donation percent.
+// The validators of the block approve the proposals, a proposal is executable DelayBlocks
+// after Threshold validators approved it. The execution writes the percent into the
+// donationPercent slot of the EngineRegistry.
+type DonationGovernanceConfig struct {
+	// Threshold is the number of validator approvals a proposal needs to pass
+	Threshold uint64 `json:"threshold"`
+
+	// DelayBlocks is the number of blocks between passing and executing a proposal
+	DelayBlocks uint64 `json:"delayBlocks"`
+}
+
+// validate checks that the threshold requires at least one approval
+func (c *DonationGovernanceConfig) validate() error {
+	if c.Threshold == 0 {
+		return errDonationGovernanceThreshold
+	}
+
+	return nil
+}
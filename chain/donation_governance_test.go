@@ -0,0 +1,14 @@
+package chain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDonationGovernanceConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, (&DonationGovernanceConfig{Threshold: 2, DelayBlocks: 10}).validate())
+	require.ErrorIs(t, (&DonationGovernanceConfig{DelayBlocks: 10}).validate(), errDonationGovernanceThreshold)
+}
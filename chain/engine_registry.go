@@ -1,6 +1,8 @@
 package chain
 
 import (
+	"math/big"
+
 	"golang.org/x/crypto/sha3"
 
 	"github.com/xgr-network/xgr-node/types"
@@ -23,19 +25,45 @@ var DefaultBurnedAddress = types.StringToAddress("0x0000000000000000000000000000
 var DefaultDonationAddress = DefaultBurnedAddress //types.StringToAddress("0xCfD008a1de815f402aD8E7e6F8461d3a878DEF59")
 
 // DefaultDonationPercent is the fallback donation fee percent (0-100).
+// Genesis can override it with params.defaultDonationPercent (see Params.DonationPercent).
 const DefaultDonationPercent uint64 = 15
 
+// FixedBurnWei is the fixed amount (1000 Gwei) of every transaction fee sent to DefaultBurnedAddress.
+const FixedBurnWei uint64 = 1000 * 1_000_000_000
+
+// Keep in sync with EngineRegistry.sol storage layout.
 const (
+	engineRegistrySlotAdmin             uint64 = 0
+	engineRegistrySlotPendingAdmin      uint64 = 1
 	engineRegistrySlotAuthorizedEngines uint64 = 2
+	engineRegistrySlotEngineList        uint64 = 3
+	engineRegistrySlotEngineIndex       uint64 = 4
 	engineRegistrySlotMinBaseFee        uint64 = 5
 	engineRegistrySlotPaused            uint64 = 6
-	// Keep in sync with EngineRegistry.sol storage layout (appended after `paused`)
-	// Keep in sync with EngineRegistry.sol storage layout.
-	// slot 7: __reserved0 (uint256)
+	engineRegistrySlotReserved0         uint64 = 7
+	// appended after `paused` and `__reserved0`
 	engineRegistrySlotDonationAddress uint64 = 8
 	engineRegistrySlotDonationPercent uint64 = 9
+	// slot 10: requireGrantChainId (bool, offset 0) and refundOnFailurePolicy (bool, offset 1).
+	// solc packs the two adjacent bools into the same slot.
+	engineRegistrySlotGrantFlags uint64 = 10
+
+	engineRegistryOffsetRequireGrantChainID   = 0
+	engineRegistryOffsetRefundOnFailurePolicy = 1
 )
 
+// EngineRegistrySlotKeyAdmin returns the storage slot key for admin.
+func EngineRegistrySlotKeyAdmin() types.Hash { return u256Slot(engineRegistrySlotAdmin) }
+
+// EngineRegistrySlotKeyPendingAdmin returns the storage slot key for pendingAdmin.
+func EngineRegistrySlotKeyPendingAdmin() types.Hash { return u256Slot(engineRegistrySlotPendingAdmin) }
+
+// EngineRegistrySlotKeyEngineList returns the storage slot key for the length of engineList.
+func EngineRegistrySlotKeyEngineList() types.Hash { return u256Slot(engineRegistrySlotEngineList) }
+
+// EngineRegistrySlotKeyReserved0 returns the storage slot key for __reserved0.
+func EngineRegistrySlotKeyReserved0() types.Hash { return u256Slot(engineRegistrySlotReserved0) }
+
 // EngineRegistrySlotKeyMinBaseFee returns the storage slot key for minBaseFee.
 func EngineRegistrySlotKeyMinBaseFee() types.Hash { return u256Slot(engineRegistrySlotMinBaseFee) }
 
@@ -52,12 +80,86 @@ func EngineRegistrySlotKeyDonationPercent() types.Hash {
 	return u256Slot(engineRegistrySlotDonationPercent)
 }
 
+// EngineRegistrySlotKeyRequireGrantChainID returns the storage slot key for requireGrantChainId.
+// The slot is shared with refundOnFailurePolicy, decode it with EngineRegistryRequireGrantChainID.
+func EngineRegistrySlotKeyRequireGrantChainID() types.Hash {
+	return u256Slot(engineRegistrySlotGrantFlags)
+}
+
+// EngineRegistrySlotKeyRefundOnFailurePolicy returns the storage slot key for refundOnFailurePolicy.
+// The slot is shared with requireGrantChainId, decode it with EngineRegistryRefundOnFailurePolicy.
+func EngineRegistrySlotKeyRefundOnFailurePolicy() types.Hash {
+	return u256Slot(engineRegistrySlotGrantFlags)
+}
+
+// EngineRegistryRequireGrantChainID decodes requireGrantChainId from its raw slot value.
+func EngineRegistryRequireGrantChainID(slot types.Hash) bool {
+	return packedBool(slot, engineRegistryOffsetRequireGrantChainID)
+}
+
+// EngineRegistryRefundOnFailurePolicy decodes refundOnFailurePolicy from its raw slot value.
+func EngineRegistryRefundOnFailurePolicy(slot types.Hash) bool {
+	return packedBool(slot, engineRegistryOffsetRefundOnFailurePolicy)
+}
+
+// packedBool returns the bool stored at the given byte offset of a slot. solc fills
+// packed slots from the lower-order end, offset 0 is the last byte of the word.
+func packedBool(slot types.Hash, offset int) bool {
+	return slot[types.HashLength-1-offset] != 0
+}
+
+// ResolveDonation returns the donation recipient and percent configured by the raw donationAddress
+// and donationPercent slots of a deployed EngineRegistry. A percent above 100 falls back to
+// defaultPercent, a zero address disables the donation.
+func ResolveDonation(addrSlot, pctSlot types.Hash, defaultPercent uint64) (types.Address, uint64) {
+	donationAddr, donationPercent := DefaultDonationAddress, defaultPercent
+
+	// donationPercent: uint256 (accept 0..100)
+	if pct := new(big.Int).SetBytes(pctSlot[:]); pct.BitLen() <= 64 && pct.Uint64() <= 100 {
+		donationPercent = pct.Uint64()
+	}
+
+	// donationAddress: address is right-aligned in last 20 bytes of the slot
+	var regAddr types.Address
+
+	copy(regAddr[:], addrSlot[12:32])
+
+	// Safety: if address is zero => donation disabled
+	if regAddr == types.ZeroAddress {
+		return donationAddr, 0
+	}
+
+	return regAddr, donationPercent
+}
+
 // EngineRegistrySlotKeyAuthorizedEngine returns the mapping slot key for authorizedEngines[engine].
 func EngineRegistrySlotKeyAuthorizedEngine(engine types.Address) types.Hash {
-	// keccak256(pad32(engine) || pad32(slot))
+	return addressMappingSlot(engine, engineRegistrySlotAuthorizedEngines)
+}
+
+// EngineRegistrySlotKeyEngineIndex returns the mapping slot key for engineIndex[engine].
+func EngineRegistrySlotKeyEngineIndex(engine types.Address) types.Hash {
+	return addressMappingSlot(engine, engineRegistrySlotEngineIndex)
+}
+
+// EngineRegistrySlotKeyEngineListItem returns the storage slot key for engineList[index].
+func EngineRegistrySlotKeyEngineListItem(index uint64) types.Hash {
+	// keccak256(pad32(slot)) + index
+	slot := u256Slot(engineRegistrySlotEngineList)
+
+	keccak := sha3.NewLegacyKeccak256()
+	keccak.Write(slot[:])
+	base := new(big.Int).SetBytes(keccak.Sum(nil))
+
+	return types.BytesToHash(base.Add(base, new(big.Int).SetUint64(index)).Bytes())
+}
+
+// addressMappingSlot returns the slot key of mapping[key] for a mapping at the given slot
+func addressMappingSlot(key types.Address, mappingSlot uint64) types.Hash {
+	// keccak256(pad32(key) || pad32(slot))
 	var buf [64]byte
-	copy(buf[12:32], engine[:])
-	slot := u256Slot(engineRegistrySlotAuthorizedEngines)
+	copy(buf[12:32], key[:])
+	slot := u256Slot(mappingSlot)
 	copy(buf[32:], slot[:])
 
 	keccak := sha3.NewLegacyKeccak256()
@@ -0,0 +1,117 @@
+package chain
+
+import (
+	"encoding/json"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/xgr-network/xgr-node/types"
+)
+
+// engineRegistryStorage is the solc storage layout of EngineRegistry together with a
+// storage dump of a deployed registry (testdata/engine_registry_storage.json).
+// Regenerate the layout with `solc --storage-layout` whenever EngineRegistry.sol changes.
+type engineRegistryStorage struct {
+	StorageLayout struct {
+		Storage []struct {
+			Label  string `json:"label"`
+			Offset int    `json:"offset"`
+			Slot   string `json:"slot"`
+		} `json:"storage"`
+	} `json:"storageLayout"`
+	Storage map[types.Hash]types.Hash `json:"storage"`
+}
+
+func readEngineRegistryStorage(t *testing.T) *engineRegistryStorage {
+	t.Helper()
+
+	raw, err := os.ReadFile("testdata/engine_registry_storage.json")
+	require.NoError(t, err)
+
+	var dump engineRegistryStorage
+	require.NoError(t, json.Unmarshal(raw, &dump))
+
+	return &dump
+}
+
+func TestEngineRegistry_StorageLayout(t *testing.T) {
+	t.Parallel()
+
+	type slotRef struct {
+		key    types.Hash
+		offset int
+	}
+
+	expected := map[string]slotRef{
+		"admin":                 {EngineRegistrySlotKeyAdmin(), 0},
+		"pendingAdmin":          {EngineRegistrySlotKeyPendingAdmin(), 0},
+		"authorizedEngines":     {u256Slot(engineRegistrySlotAuthorizedEngines), 0},
+		"engineList":            {EngineRegistrySlotKeyEngineList(), 0},
+		"engineIndex":           {u256Slot(engineRegistrySlotEngineIndex), 0},
+		"minBaseFee":            {EngineRegistrySlotKeyMinBaseFee(), 0},
+		"paused":                {EngineRegistrySlotKeyPaused(), 0},
+		"__reserved0":           {EngineRegistrySlotKeyReserved0(), 0},
+		"donationAddress":       {EngineRegistrySlotKeyDonationAddress(), 0},
+		"donationPercent":       {EngineRegistrySlotKeyDonationPercent(), 0},
+		"requireGrantChainId":   {EngineRegistrySlotKeyRequireGrantChainID(), engineRegistryOffsetRequireGrantChainID},
+		"refundOnFailurePolicy": {EngineRegistrySlotKeyRefundOnFailurePolicy(), engineRegistryOffsetRefundOnFailurePolicy},
+	}
+
+	found := 0
+
+	for _, v := range readEngineRegistryStorage(t).StorageLayout.Storage {
+		ref, ok := expected[v.Label]
+		if !ok {
+			continue
+		}
+
+		slot, ok := new(big.Int).SetString(v.Slot, 10)
+		require.True(t, ok)
+
+		require.Equal(t, types.BytesToHash(slot.Bytes()), ref.key, v.Label)
+		require.Equal(t, v.Offset, ref.offset, v.Label)
+
+		found++
+	}
+
+	require.Equal(t, len(expected), found)
+}
+
+func TestEngineRegistry_DecodeStorageDump(t *testing.T) {
+	t.Parallel()
+
+	storage := readEngineRegistryStorage(t).Storage
+	admin := types.StringToAddress("0xa11")
+
+	require.Equal(t, types.BytesToHash(admin.Bytes()), storage[EngineRegistrySlotKeyAdmin()])
+	require.Equal(t, types.ZeroHash, storage[EngineRegistrySlotKeyPaused()])
+	require.Equal(t, uint64(100_000_000_000),
+		new(big.Int).SetBytes(storage[EngineRegistrySlotKeyMinBaseFee()].Bytes()).Uint64())
+
+	donationAddr, donationPercent := ResolveDonation(
+		storage[EngineRegistrySlotKeyDonationAddress()],
+		storage[EngineRegistrySlotKeyDonationPercent()],
+		DefaultDonationPercent,
+	)
+	require.Equal(t, admin, donationAddr)
+	require.Equal(t, uint64(20), donationPercent)
+
+	// refundOnFailurePolicy was set, requireGrantChainId sharing its slot was not
+	require.False(t, EngineRegistryRequireGrantChainID(storage[EngineRegistrySlotKeyRequireGrantChainID()]))
+	require.True(t, EngineRegistryRefundOnFailurePolicy(storage[EngineRegistrySlotKeyRefundOnFailurePolicy()]))
+}
+
+func TestEngineRegistry_EngineListItemSlotKey(t *testing.T) {
+	t.Parallel()
+
+	// keccak256(pad32(3)) + index
+	require.Equal(t,
+		types.StringToHash("0xc2575a0e9e593c00f959f8c92f12db2869c3395a3b0502d05e2516446f71f85b"),
+		EngineRegistrySlotKeyEngineListItem(0))
+	require.Equal(t,
+		types.StringToHash("0xc2575a0e9e593c00f959f8c92f12db2869c3395a3b0502d05e2516446f71f85c"),
+		EngineRegistrySlotKeyEngineListItem(1))
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"sort"
 
+	"golang.org/x/crypto/sha3"
+
 	"github.com/xgr-network/xgr-node/forkmanager"
 	"github.com/xgr-network/xgr-node/types"
 )
@@ -32,10 +34,38 @@ type Params struct {
 	Engine         map[string]interface{} `json:"engine"`
 	BlockGasTarget uint64                 `json:"blockGasTarget"`
 
+	// SystemTxGasReserve is the portion of the block gas pool that only state transactions may draw from
+	SystemTxGasReserve uint64 `json:"systemTxGasReserve,omitempty"`
+
+	// StateTxRecipients are the contracts state transactions may target.
+	// If empty, the default system contracts (state receiver, validator set, reward pool) are allowed
+	StateTxRecipients []types.Address `json:"stateTxRecipients,omitempty"`
+
 	// XGR: On-chain config registry (loaded from genesis.json -> params.*)
 	EngineRegistryAddress types.Address `json:"engineRegistryAddress,omitempty"`
 	BootstrapEngineEOA    types.Address `json:"bootstrapEngineEOA,omitempty"`
 
+	// EngineEnabled registers the ENGINE_EXECUTE precompile and serves the xgr engine RPCs (default true).
+	// It changes the state transition, so it is part of the chain spec hash (see SpecHash)
+	EngineEnabled *bool `json:"engineEnabled,omitempty"`
+
+	// DefaultDonationPercent replaces chain.DefaultDonationPercent (0-100). It applies while the EngineRegistry
+	// is not deployed and when the registry holds a percent above 100
+	DefaultDonationPercent *uint64 `json:"defaultDonationPercent,omitempty"`
+
+	// DonationGovernance configures the vote of the validators on the donation percent
+	// of the registry (DonationVote fork)
+	DonationGovernance *DonationGovernanceConfig `json:"donationGovernance,omitempty"`
+
+	// EmitFeeSplitLog appends the synthetic XGRFeeSplit log to the receipt logs of every transaction.
+	// The log is part of the receipts root, so it is always emitted before the FeeSplitLogOptIn fork.
+	// From the fork on it is off by default, the fees are distributed the same way without the log
+	EmitFeeSplitLog bool `json:"emitFeeSplitLog,omitempty"`
+
+	// StrictTxGasLimit rejects a block containing a transaction with more gas than the block gas limit.
+	// Otherwise such a transaction is skipped while processing the block
+	StrictTxGasLimit bool `json:"strictTxGasLimit,omitempty"`
+
 	// Access control configuration
 	ContractDeployerAllowList *AddressListConfig `json:"contractDeployerAllowList,omitempty"`
 	ContractDeployerBlockList *AddressListConfig `json:"contractDeployerBlockList,omitempty"`
@@ -84,6 +114,48 @@ func (p *Params) CalculateBurnContract(block uint64) (types.Address, error) {
 	return p.BurnContract[blocks[len(blocks)-1]], nil
 }
 
+// IsEngineEnabled returns true unless the engine is explicitly disabled in the genesis
+func (p *Params) IsEngineEnabled() bool {
+	return p.EngineEnabled == nil || *p.EngineEnabled
+}
+
+// DonationPercent returns the donation fee percent used while the registry is missing,
+// the genesis override or DefaultDonationPercent
+func (p *Params) DonationPercent() uint64 {
+	if p.DefaultDonationPercent != nil {
+		return *p.DefaultDonationPercent
+	}
+
+	return DefaultDonationPercent
+}
+
+// Warnings returns the settings of the params which are valid, but likely a mistake
+func (p *Params) Warnings() []string {
+	var warnings []string
+
+	if !p.IsEngineEnabled() && p.EngineRegistryAddress != types.ZeroAddress {
+		warnings = append(warnings, "engineRegistryAddress is set, but the engine is disabled")
+	}
+
+	return warnings
+}
+
+// SpecHash returns the keccak256 hash of the consensus-relevant chain spec: the genesis hash
+// and whether the engine is enabled. Nodes with a different chain spec hash don't peer.
+// Node-local settings are left out, so they never split the network
+func (p *Params) SpecHash(genesis types.Hash) types.Hash {
+	engineEnabled := byte(0)
+	if p.IsEngineEnabled() {
+		engineEnabled = 1
+	}
+
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write(genesis.Bytes())
+	hash.Write([]byte{engineEnabled})
+
+	return types.BytesToHash(hash.Sum(nil))
+}
+
 func (p *Params) GetEngine() string {
 	// We know there is already one
 	for k := range p.Engine {
@@ -95,22 +167,41 @@ func (p *Params) GetEngine() string {
 
 // predefined forks
 const (
-	Homestead           = "homestead"
-	Byzantium           = "byzantium"
-	Constantinople      = "constantinople"
-	Petersburg          = "petersburg"
-	Istanbul            = "istanbul"
-	London              = "london"
-	EIP150              = "EIP150"
-	EIP158              = "EIP158"
-	EIP155              = "EIP155"
-	QuorumCalcAlignment = "quorumcalcalignment"
-	TxHashWithType      = "txHashWithType"
-	LondonFix           = "londonfix"
-	EIP3860             = "EIP3860"
-	EIP2929             = "EIP2929"
-	EIP2930             = "EIP2930"
-	EIP3651             = "EIP3651"
+	Homestead               = "homestead"
+	Byzantium               = "byzantium"
+	Constantinople          = "constantinople"
+	Petersburg              = "petersburg"
+	Istanbul                = "istanbul"
+	London                  = "london"
+	EIP150                  = "EIP150"
+	EIP158                  = "EIP158"
+	EIP155                  = "EIP155"
+	QuorumCalcAlignment     = "quorumcalcalignment"
+	TxHashWithType          = "txHashWithType"
+	LondonFix               = "londonfix"
+	EIP3860                 = "EIP3860"
+	EIP2929                 = "EIP2929"
+	EIP2930                 = "EIP2930"
+	EIP3651                 = "EIP3651"
+	AddressListIndex        = "addressListIndex"
+	RewardAddress           = "rewardAddress"
+	MinBaseFeeFloor         = "minBaseFeeFloor"
+	StrictBridgeBlockList   = "strictBridgeBlockList"
+	DonationVote            = "donationVote"
+	EIP6780                 = "EIP6780"
+	EIP3529                 = "EIP3529"
+	EIP1153                 = "EIP1153"
+	FeeSplitLogOptIn        = "feeSplitLogOptIn"
+	DeploymentRejectedLog   = "deploymentRejectedLog"
+	GrantFeeChargedEvent    = "grantFeeChargedEvent"
+	EngineSessionIteration  = "engineSessionIteration"
+	EngineWarmInnerCall     = "engineWarmInnerCall"
+	BridgeCallAccessLists   = "bridgeCallAccessLists"
+	EIP3607                 = "EIP3607"
+	EngineGrantChainID      = "engineGrantChainID"
+	BridgeListPrecedence    = "bridgeListPrecedence"
+	EngineSessionGasCeiling = "engineSessionGasCeiling"
+	EngineGrantExpiry       = "engineGrantExpiry"
 )
 
 // Forks is map which contains all forks and their starting blocks from genesis
@@ -137,22 +228,41 @@ func (f *Forks) RemoveFork(name string) *Forks {
 // At returns ForksInTime instance that shows which supported forks are enabled for the block
 func (f *Forks) At(block uint64) ForksInTime {
 	return ForksInTime{
-		Homestead:           f.IsActive(Homestead, block),
-		Byzantium:           f.IsActive(Byzantium, block),
-		Constantinople:      f.IsActive(Constantinople, block),
-		Petersburg:          f.IsActive(Petersburg, block),
-		Istanbul:            f.IsActive(Istanbul, block),
-		London:              f.IsActive(London, block),
-		EIP150:              f.IsActive(EIP150, block),
-		EIP158:              f.IsActive(EIP158, block),
-		EIP155:              f.IsActive(EIP155, block),
-		QuorumCalcAlignment: f.IsActive(QuorumCalcAlignment, block),
-		TxHashWithType:      f.IsActive(TxHashWithType, block),
-		LondonFix:           f.IsActive(LondonFix, block),
-		EIP3860:             f.IsActive(EIP3860, block),
-		EIP2929:             f.IsActive(EIP2929, block),
-		EIP2930:             f.IsActive(EIP2930, block),
-		EIP3651:             f.IsActive(EIP3651, block),
+		Homestead:               f.IsActive(Homestead, block),
+		Byzantium:               f.IsActive(Byzantium, block),
+		Constantinople:          f.IsActive(Constantinople, block),
+		Petersburg:              f.IsActive(Petersburg, block),
+		Istanbul:                f.IsActive(Istanbul, block),
+		London:                  f.IsActive(London, block),
+		EIP150:                  f.IsActive(EIP150, block),
+		EIP158:                  f.IsActive(EIP158, block),
+		EIP155:                  f.IsActive(EIP155, block),
+		QuorumCalcAlignment:     f.IsActive(QuorumCalcAlignment, block),
+		TxHashWithType:          f.IsActive(TxHashWithType, block),
+		LondonFix:               f.IsActive(LondonFix, block),
+		EIP3860:                 f.IsActive(EIP3860, block),
+		EIP2929:                 f.IsActive(EIP2929, block),
+		EIP2930:                 f.IsActive(EIP2930, block),
+		EIP3651:                 f.IsActive(EIP3651, block),
+		AddressListIndex:        f.IsActive(AddressListIndex, block),
+		RewardAddress:           f.IsActive(RewardAddress, block),
+		MinBaseFeeFloor:         f.IsActive(MinBaseFeeFloor, block),
+		StrictBridgeBlockList:   f.IsActive(StrictBridgeBlockList, block),
+		DonationVote:            f.IsActive(DonationVote, block),
+		EIP6780:                 f.IsActive(EIP6780, block),
+		EIP3529:                 f.IsActive(EIP3529, block),
+		EIP1153:                 f.IsActive(EIP1153, block),
+		FeeSplitLogOptIn:        f.IsActive(FeeSplitLogOptIn, block),
+		DeploymentRejectedLog:   f.IsActive(DeploymentRejectedLog, block),
+		GrantFeeChargedEvent:    f.IsActive(GrantFeeChargedEvent, block),
+		EngineSessionIteration:  f.IsActive(EngineSessionIteration, block),
+		EngineWarmInnerCall:     f.IsActive(EngineWarmInnerCall, block),
+		BridgeCallAccessLists:   f.IsActive(BridgeCallAccessLists, block),
+		EIP3607:                 f.IsActive(EIP3607, block),
+		EngineGrantChainID:      f.IsActive(EngineGrantChainID, block),
+		BridgeListPrecedence:    f.IsActive(BridgeListPrecedence, block),
+		EngineSessionGasCeiling: f.IsActive(EngineSessionGasCeiling, block),
+		EngineGrantExpiry:       f.IsActive(EngineGrantExpiry, block),
 	}
 }
 
@@ -205,25 +315,63 @@ type ForksInTime struct {
 	EIP155,
 	QuorumCalcAlignment,
 	TxHashWithType,
-	LondonFix, EIP3860, EIP2929, EIP2930, EIP3651 bool
+	LondonFix, EIP3860, EIP2929, EIP2930, EIP3651,
+	AddressListIndex,
+	RewardAddress,
+	MinBaseFeeFloor,
+	StrictBridgeBlockList,
+	DonationVote,
+	EIP6780,
+	EIP3529,
+	EIP1153,
+	FeeSplitLogOptIn,
+	DeploymentRejectedLog,
+	GrantFeeChargedEvent,
+	EngineSessionIteration,
+	EngineWarmInnerCall,
+	BridgeCallAccessLists,
+	EIP3607,
+	EngineGrantChainID,
+	BridgeListPrecedence,
+	EngineSessionGasCeiling,
+	EngineGrantExpiry bool
 }
 
 // AllForksEnabled should contain all supported forks by current edge version
 var AllForksEnabled = &Forks{
-	Homestead:           NewFork(0),
-	EIP150:              NewFork(0),
-	EIP155:              NewFork(0),
-	EIP158:              NewFork(0),
-	Byzantium:           NewFork(0),
-	Constantinople:      NewFork(0),
-	Petersburg:          NewFork(0),
-	Istanbul:            NewFork(0),
-	London:              NewFork(0),
-	QuorumCalcAlignment: NewFork(0),
-	TxHashWithType:      NewFork(0),
-	LondonFix:           NewFork(0),
-	EIP3860:             NewFork(0),
-	EIP2929:             NewFork(0),
-	EIP2930:             NewFork(0),
-	EIP3651:             NewFork(0),
+	Homestead:               NewFork(0),
+	EIP150:                  NewFork(0),
+	EIP155:                  NewFork(0),
+	EIP158:                  NewFork(0),
+	Byzantium:               NewFork(0),
+	Constantinople:          NewFork(0),
+	Petersburg:              NewFork(0),
+	Istanbul:                NewFork(0),
+	London:                  NewFork(0),
+	QuorumCalcAlignment:     NewFork(0),
+	TxHashWithType:          NewFork(0),
+	LondonFix:               NewFork(0),
+	EIP3860:                 NewFork(0),
+	EIP2929:                 NewFork(0),
+	EIP2930:                 NewFork(0),
+	EIP3651:                 NewFork(0),
+	AddressListIndex:        NewFork(0),
+	RewardAddress:           NewFork(0),
+	MinBaseFeeFloor:         NewFork(0),
+	StrictBridgeBlockList:   NewFork(0),
+	DonationVote:            NewFork(0),
+	EIP6780:                 NewFork(0),
+	EIP3529:                 NewFork(0),
+	EIP1153:                 NewFork(0),
+	FeeSplitLogOptIn:        NewFork(0),
+	DeploymentRejectedLog:   NewFork(0),
+	GrantFeeChargedEvent:    NewFork(0),
+	EngineSessionIteration:  NewFork(0),
+	EngineWarmInnerCall:     NewFork(0),
+	BridgeCallAccessLists:   NewFork(0),
+	EIP3607:                 NewFork(0),
+	EngineGrantChainID:      NewFork(0),
+	BridgeListPrecedence:    NewFork(0),
+	EngineSessionGasCeiling: NewFork(0),
+	EngineGrantExpiry:       NewFork(0),
 }
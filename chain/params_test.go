@@ -128,3 +128,37 @@ func TestParams_CalculateBurnContract(t *testing.T) {
 		})
 	}
 }
+
+func TestParams_EngineEnabled(t *testing.T) {
+	t.Parallel()
+
+	var params Params
+
+	require.NoError(t, json.Unmarshal([]byte(`{"chainID": 100}`), &params))
+	require.True(t, params.IsEngineEnabled())
+	require.Empty(t, params.Warnings())
+
+	genesis := types.StringToHash("0x1")
+	enabledHash := params.SpecHash(genesis)
+
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"chainID": 100,
+		"engineEnabled": false,
+		"engineRegistryAddress": "0x0000000000000000000000000000000000001001"
+	}`), &params))
+	require.False(t, params.IsEngineEnabled())
+	require.Len(t, params.Warnings(), 1)
+
+	disabledHash := params.SpecHash(genesis)
+
+	// nodes with and without the engine must not peer
+	require.NotEqual(t, enabledHash, disabledHash)
+
+	// node-local settings don't change the spec hash
+	params.StrictTxGasLimit = true
+	params.EmitFeeSplitLog = true
+	require.Equal(t, disabledHash, params.SpecHash(genesis))
+
+	// another genesis is another chain
+	require.NotEqual(t, disabledHash, params.SpecHash(types.StringToHash("0x2")))
+}
@@ -3,6 +3,8 @@ package backup
 import (
 	"github.com/spf13/cobra"
 	"github.com/xgr-network/xgr-node/command"
+	"github.com/xgr-network/xgr-node/command/backup/exportblocks"
+	"github.com/xgr-network/xgr-node/command/backup/rewind"
 
 	"github.com/xgr-network/xgr-node/command/helper"
 )
@@ -17,12 +19,22 @@ func GetCommand() *cobra.Command {
 
 	helper.RegisterGRPCAddressFlag(backupCmd)
 
+	registerSubcommands(backupCmd)
 	setFlags(backupCmd)
 	helper.SetRequiredFlags(backupCmd, params.getRequiredFlags())
 
 	return backupCmd
 }
 
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// backup export-blocks
+		exportblocks.GetCommand(),
+		// backup rewind
+		rewind.GetCommand(),
+	)
+}
+
 func setFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(
 		&params.out,
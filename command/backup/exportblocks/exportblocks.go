@@ -0,0 +1,80 @@
+package exportblocks
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/xgr-network/xgr-node/blockchain/storage"
+	"github.com/xgr-network/xgr-node/command"
+	"github.com/xgr-network/xgr-node/command/helper"
+)
+
+func GetCommand() *cobra.Command {
+	exportBlocksCmd := &cobra.Command{
+		Use: "export-blocks",
+		Short: "Export blocks with their receipts from the data directory of a stopped node to a blocks file, " +
+			"which can be imported with 'server --import-blocks'",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(exportBlocksCmd)
+	helper.SetRequiredFlags(exportBlocksCmd, params.getRequiredFlags())
+
+	return exportBlocksCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory of the node",
+	)
+
+	cmd.Flags().StringVar(
+		&params.dbEngine,
+		dbEngineFlag,
+		storage.EngineLevelDB,
+		fmt.Sprintf("the database engine of the blockchain storage (%s or %s)",
+			storage.EngineLevelDB, storage.EnginePebble),
+	)
+
+	cmd.Flags().StringVar(
+		&params.out,
+		outFlag,
+		"",
+		"the blocks file to write, an existing file is resumed after its last complete block",
+	)
+
+	cmd.Flags().StringVar(
+		&params.fromRaw,
+		fromFlag,
+		"0",
+		"the first block to export",
+	)
+
+	cmd.Flags().StringVar(
+		&params.toRaw,
+		toFlag,
+		"",
+		"the last block to export, the head block if omitted",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.exportBlocks(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
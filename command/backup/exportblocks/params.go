@@ -0,0 +1,107 @@
+package exportblocks
+
+import (
+	"errors"
+	"path/filepath"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/xgr-network/xgr-node/archive"
+	"github.com/xgr-network/xgr-node/blockchain/storage"
+	"github.com/xgr-network/xgr-node/command"
+	"github.com/xgr-network/xgr-node/helper/common"
+	"github.com/xgr-network/xgr-node/server"
+)
+
+const (
+	dataDirFlag  = "data-dir"
+	dbEngineFlag = "db.engine"
+	outFlag      = "out"
+	fromFlag     = "from"
+	toFlag       = "to"
+)
+
+var (
+	params = &exportBlocksParams{}
+)
+
+var (
+	errDecodeRange     = errors.New("unable to decode range value")
+	errInvalidRange    = errors.New(`invalid "to" value; must be >= "from"`)
+	errInvalidDBEngine = errors.New("db engine must be " + storage.EngineLevelDB + " or " + storage.EnginePebble)
+)
+
+type exportBlocksParams struct {
+	dataDir  string
+	dbEngine string
+	out      string
+
+	fromRaw string
+	toRaw   string
+
+	from uint64
+	to   *uint64
+
+	resFrom uint64
+	resTo   uint64
+}
+
+func (p *exportBlocksParams) validateFlags() error {
+	if p.dbEngine != storage.EngineLevelDB && p.dbEngine != storage.EnginePebble {
+		return errInvalidDBEngine
+	}
+
+	var parseErr error
+
+	if p.from, parseErr = common.ParseUint64orHex(&p.fromRaw); parseErr != nil {
+		return errDecodeRange
+	}
+
+	if p.toRaw != "" {
+		var parsedTo uint64
+
+		if parsedTo, parseErr = common.ParseUint64orHex(&p.toRaw); parseErr != nil {
+			return errDecodeRange
+		}
+
+		if p.from > parsedTo {
+			return errInvalidRange
+		}
+
+		p.to = &parsedTo
+	}
+
+	return nil
+}
+
+func (p *exportBlocksParams) getRequiredFlags() []string {
+	return []string{
+		dataDirFlag,
+		outFlag,
+	}
+}
+
+func (p *exportBlocksParams) exportBlocks() error {
+	logger := hclog.New(&hclog.LoggerOptions{
+		Name:  "export-blocks",
+		Level: hclog.LevelFromString("INFO"),
+	})
+
+	db, err := server.NewBlockchainStorage(p.dbEngine, filepath.Join(p.dataDir, "blockchain"), logger)
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	p.resFrom, p.resTo, err = archive.ExportBlocks(db, logger, p.from, p.to, p.out)
+
+	return err
+}
+
+func (p *exportBlocksParams) getResult() command.CommandResult {
+	return &ExportBlocksResult{
+		From: p.resFrom,
+		To:   p.resTo,
+		Out:  p.out,
+	}
+}
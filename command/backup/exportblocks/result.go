@@ -0,0 +1,28 @@
+package exportblocks
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/xgr-network/xgr-node/command/helper"
+)
+
+type ExportBlocksResult struct {
+	From uint64 `json:"from"`
+	To   uint64 `json:"to"`
+	Out  string `json:"out"`
+}
+
+func (r *ExportBlocksResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[EXPORT BLOCKS]\n")
+	buffer.WriteString("Exported blocks file successfully:\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("File|%s", r.Out),
+		fmt.Sprintf("From|%d", r.From),
+		fmt.Sprintf("To|%d", r.To),
+	}))
+
+	return buffer.String()
+}
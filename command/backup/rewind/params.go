@@ -0,0 +1,104 @@
+package rewind
+
+import (
+	"errors"
+	"path/filepath"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/xgr-network/xgr-node/blockchain"
+	"github.com/xgr-network/xgr-node/blockchain/storage"
+	"github.com/xgr-network/xgr-node/command"
+	"github.com/xgr-network/xgr-node/helper/common"
+	"github.com/xgr-network/xgr-node/server"
+	itrie "github.com/xgr-network/xgr-node/state/immutable-trie"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+const (
+	dataDirFlag  = "data-dir"
+	dbEngineFlag = "db.engine"
+	toFlag       = "to"
+)
+
+var (
+	params = &rewindParams{}
+)
+
+var (
+	errDecodeTo        = errors.New("unable to decode the rewind target")
+	errInvalidDBEngine = errors.New("db engine must be " + storage.EngineLevelDB + " or " + storage.EnginePebble)
+)
+
+type rewindParams struct {
+	dataDir  string
+	dbEngine string
+
+	toRaw string
+	to    uint64
+
+	res *blockchain.RewindResult
+}
+
+func (p *rewindParams) validateFlags() error {
+	if p.dbEngine != storage.EngineLevelDB && p.dbEngine != storage.EnginePebble {
+		return errInvalidDBEngine
+	}
+
+	to, err := common.ParseUint64orHex(&p.toRaw)
+	if err != nil {
+		return errDecodeTo
+	}
+
+	p.to = to
+
+	return nil
+}
+
+func (p *rewindParams) getRequiredFlags() []string {
+	return []string{
+		dataDirFlag,
+		toFlag,
+	}
+}
+
+func (p *rewindParams) rewind() error {
+	logger := hclog.New(&hclog.LoggerOptions{
+		Name:  "rewind",
+		Level: hclog.LevelFromString("INFO"),
+	})
+
+	db, err := server.NewBlockchainStorage(p.dbEngine, filepath.Join(p.dataDir, "blockchain"), logger)
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	stateStorage, err := itrie.NewLevelDBStorage(filepath.Join(p.dataDir, "trie"), logger)
+	if err != nil {
+		return err
+	}
+
+	defer stateStorage.Close()
+
+	st := itrie.NewState(stateStorage)
+
+	p.res, err = blockchain.RewindStorage(db, p.to, func(root types.Hash) bool {
+		_, err := st.NewSnapshotAt(root)
+
+		return err == nil
+	})
+
+	return err
+}
+
+func (p *rewindParams) getResult() command.CommandResult {
+	return &RewindResult{
+		OldHead:          p.res.OldHead,
+		NewHead:          p.res.NewHead,
+		NewHeadHash:      p.res.NewHeadHash.String(),
+		RemovedBlocks:    p.res.RemovedBlocks,
+		RemovedReceipts:  p.res.RemovedReceipts,
+		RemovedTxLookups: p.res.RemovedTxLookups,
+	}
+}
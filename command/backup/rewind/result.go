@@ -0,0 +1,34 @@
+package rewind
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/xgr-network/xgr-node/command/helper"
+)
+
+type RewindResult struct {
+	OldHead          uint64 `json:"oldHead"`
+	NewHead          uint64 `json:"newHead"`
+	NewHeadHash      string `json:"newHeadHash"`
+	RemovedBlocks    uint64 `json:"removedBlocks"`
+	RemovedReceipts  uint64 `json:"removedReceipts"`
+	RemovedTxLookups uint64 `json:"removedTxLookups"`
+}
+
+func (r *RewindResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[REWIND]\n")
+	buffer.WriteString("Rewound the canonical chain successfully:\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Old head|%d", r.OldHead),
+		fmt.Sprintf("New head|%d", r.NewHead),
+		fmt.Sprintf("New head hash|%s", r.NewHeadHash),
+		fmt.Sprintf("Removed canonical blocks|%d", r.RemovedBlocks),
+		fmt.Sprintf("Removed receipts|%d", r.RemovedReceipts),
+		fmt.Sprintf("Removed tx lookups|%d", r.RemovedTxLookups),
+	}))
+
+	return buffer.String()
+}
@@ -0,0 +1,66 @@
+package rewind
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/xgr-network/xgr-node/blockchain/storage"
+	"github.com/xgr-network/xgr-node/command"
+	"github.com/xgr-network/xgr-node/command/helper"
+)
+
+func GetCommand() *cobra.Command {
+	rewindCmd := &cobra.Command{
+		Use: "rewind",
+		Short: "Rewind the canonical chain in the data directory of a stopped node to the given block, " +
+			"the node re-executes the blocks above it when started",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(rewindCmd)
+	helper.SetRequiredFlags(rewindCmd, params.getRequiredFlags())
+
+	return rewindCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory of the node",
+	)
+
+	cmd.Flags().StringVar(
+		&params.dbEngine,
+		dbEngineFlag,
+		storage.EngineLevelDB,
+		fmt.Sprintf("the database engine of the blockchain storage (%s or %s)",
+			storage.EngineLevelDB, storage.EnginePebble),
+	)
+
+	cmd.Flags().StringVar(
+		&params.toRaw,
+		toFlag,
+		"",
+		"the block to rewind to, it becomes the new head block",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.rewind(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,27 @@
+package engine
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/xgr-network/xgr-node/command/engine/registryinfo"
+	"github.com/xgr-network/xgr-node/command/helper"
+)
+
+func GetCommand() *cobra.Command {
+	engineCmd := &cobra.Command{
+		Use:   "engine",
+		Short: "Top level command for inspecting the engine configuration of a chain. Only accepts subcommands.",
+	}
+
+	helper.RegisterJSONRPCFlag(engineCmd)
+
+	registerSubcommands(engineCmd)
+
+	return engineCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// engine registry-info
+		registryinfo.GetCommand(),
+	)
+}
@@ -0,0 +1,62 @@
+package registryinfo
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/xgr-network/xgr-node/command/helper"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+const (
+	registryFlag = "registry"
+	engineFlag   = "engine"
+)
+
+var (
+	errInvalidRegistry = errors.New("invalid registry address provided")
+	errInvalidEngine   = errors.New("invalid engine address provided")
+)
+
+var (
+	params = &registryInfoParams{}
+)
+
+type registryInfoParams struct {
+	jsonRPC     string
+	registryRaw string
+	engineRaw   string
+
+	registryAddr types.Address
+	engineAddr   *types.Address
+}
+
+func (p *registryInfoParams) getRequiredFlags() []string {
+	return []string{
+		registryFlag,
+	}
+}
+
+func (p *registryInfoParams) initRawParams() error {
+	if _, err := helper.ParseJSONRPCAddress(p.jsonRPC); err != nil {
+		return fmt.Errorf("failed to parse json rpc address. Error: %w", err)
+	}
+
+	if err := types.IsValidAddress(p.registryRaw); err != nil {
+		return fmt.Errorf("%w: %w", errInvalidRegistry, err)
+	}
+
+	p.registryAddr = types.StringToAddress(p.registryRaw)
+	p.engineAddr = nil
+
+	if p.engineRaw != "" {
+		if err := types.IsValidAddress(p.engineRaw); err != nil {
+			return fmt.Errorf("%w: %w", errInvalidEngine, err)
+		}
+
+		engine := types.StringToAddress(p.engineRaw)
+		p.engineAddr = &engine
+	}
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package registryinfo
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/spf13/cobra"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/jsonrpc"
+
+	"github.com/xgr-network/xgr-node/chain"
+	"github.com/xgr-network/xgr-node/command"
+	"github.com/xgr-network/xgr-node/command/helper"
+	"github.com/xgr-network/xgr-node/helper/hex"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+/*
+./xgrchain engine registry-info --jsonrpc "http://localhost:10002" --registry 0x... --engine 0x...
+*/
+func GetCommand() *cobra.Command {
+	registryInfoCmd := &cobra.Command{
+		Use:     "registry-info",
+		Short:   "Reads the live EngineRegistry settings from its storage slots",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(registryInfoCmd)
+	helper.SetRequiredFlags(registryInfoCmd, params.getRequiredFlags())
+
+	return registryInfoCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.registryRaw,
+		registryFlag,
+		"",
+		"the EngineRegistry address (params.engineRegistryAddress)",
+	)
+
+	cmd.Flags().StringVar(
+		&params.engineRaw,
+		engineFlag,
+		"",
+		"the engine address to check the authorization of",
+	)
+}
+
+func runPreRun(cmd *cobra.Command, _ []string) error {
+	params.jsonRPC = helper.GetJSONRPCAddress(cmd)
+
+	return params.initRawParams()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	rpcClient, err := jsonrpc.NewClient(params.jsonRPC)
+	if err != nil {
+		outputter.SetError(fmt.Errorf("failed to connect to %s: %w", params.jsonRPC, err))
+
+		return
+	}
+
+	result, err := readRegistryInfo(rpcClient.Eth(), params.registryAddr, params.engineAddr)
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(result)
+}
+
+// storageClient is the part of the eth JSON-RPC client used to read the registry
+type storageClient interface {
+	GetCode(addr ethgo.Address, block ethgo.BlockNumberOrHash) (string, error)
+	GetStorageAt(addr ethgo.Address, slot ethgo.Hash, block ethgo.BlockNumberOrHash) (ethgo.Hash, error)
+}
+
+// readRegistryInfo reads and decodes the registry slots at the latest block.
+// The values are reported as stored, without the fallbacks applied during block execution
+func readRegistryInfo(
+	client storageClient,
+	registry types.Address,
+	engine *types.Address,
+) (*RegistryInfoResult, error) {
+	rawCode, err := client.GetCode(ethgo.Address(registry), ethgo.Latest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get registry code: %w", err)
+	}
+
+	code, err := hex.DecodeHex(rawCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode registry code: %w", err)
+	}
+
+	if len(code) == 0 {
+		return nil, fmt.Errorf("registry %s is not deployed", registry)
+	}
+
+	read := func(slot types.Hash) (types.Hash, error) {
+		value, err := client.GetStorageAt(ethgo.Address(registry), ethgo.Hash(slot), ethgo.Latest)
+		if err != nil {
+			return types.Hash{}, fmt.Errorf("failed to read registry slot %s: %w", slot, err)
+		}
+
+		return types.Hash(value), nil
+	}
+
+	var (
+		slots = []types.Hash{
+			chain.EngineRegistrySlotKeyPaused(),
+			chain.EngineRegistrySlotKeyMinBaseFee(),
+			chain.EngineRegistrySlotKeyDonationAddress(),
+			chain.EngineRegistrySlotKeyDonationPercent(),
+			chain.EngineRegistrySlotKeyRequireGrantChainID(),
+			chain.EngineRegistrySlotKeyRefundOnFailurePolicy(),
+		}
+		values = make([]types.Hash, len(slots))
+	)
+
+	for i, slot := range slots {
+		if values[i], err = read(slot); err != nil {
+			return nil, err
+		}
+	}
+
+	res := &RegistryInfoResult{
+		Registry:              registry.String(),
+		Paused:                values[0] != types.ZeroHash,
+		MinBaseFee:            new(big.Int).SetBytes(values[1][:]).String(),
+		DonationAddress:       types.BytesToAddress(values[2][12:]).String(),
+		DonationPercent:       new(big.Int).SetBytes(values[3][:]).String(),
+		RequireGrantChainID:   chain.EngineRegistryRequireGrantChainID(values[4]),
+		RefundOnFailurePolicy: chain.EngineRegistryRefundOnFailurePolicy(values[5]),
+	}
+
+	if engine != nil {
+		authorized, err := read(chain.EngineRegistrySlotKeyAuthorizedEngine(*engine))
+		if err != nil {
+			return nil, err
+		}
+
+		isAuthorized := authorized != types.ZeroHash
+
+		res.Engine = engine.String()
+		res.EngineAuthorized = &isAuthorized
+	}
+
+	return res, nil
+}
@@ -0,0 +1,107 @@
+package registryinfo
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+
+	"github.com/xgr-network/xgr-node/chain"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+var (
+	registryAddr = types.StringToAddress("0x1001")
+	donationAddr = types.StringToAddress("0xd0")
+	engineA      = types.StringToAddress("0xa1")
+	engineB      = types.StringToAddress("0xb2")
+)
+
+// mockRPC serves the code and the storage of a single EngineRegistry account
+type mockRPC struct {
+	code    string
+	storage map[types.Hash]types.Hash
+}
+
+func (m *mockRPC) GetCode(addr ethgo.Address, _ ethgo.BlockNumberOrHash) (string, error) {
+	if types.Address(addr) != registryAddr {
+		return "0x", nil
+	}
+
+	return m.code, nil
+}
+
+func (m *mockRPC) GetStorageAt(addr ethgo.Address, slot ethgo.Hash, _ ethgo.BlockNumberOrHash) (ethgo.Hash, error) {
+	if types.Address(addr) != registryAddr {
+		return ethgo.Hash{}, nil
+	}
+
+	return ethgo.Hash(m.storage[types.Hash(slot)]), nil
+}
+
+func uintSlot(n uint64) types.Hash {
+	return types.BytesToHash(new(big.Int).SetUint64(n).Bytes())
+}
+
+func TestReadRegistryInfo(t *testing.T) {
+	t.Parallel()
+
+	client := &mockRPC{
+		code: "0x6001",
+		storage: map[types.Hash]types.Hash{
+			chain.EngineRegistrySlotKeyPaused():                  uintSlot(1),
+			chain.EngineRegistrySlotKeyMinBaseFee():              uintSlot(1_000_000_000),
+			chain.EngineRegistrySlotKeyDonationAddress():         types.BytesToHash(donationAddr.Bytes()),
+			chain.EngineRegistrySlotKeyDonationPercent():         uintSlot(20),
+			chain.EngineRegistrySlotKeyRefundOnFailurePolicy():   uintSlot(0x100),
+			chain.EngineRegistrySlotKeyAuthorizedEngine(engineA): uintSlot(1),
+		},
+	}
+
+	t.Run("registry settings", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := readRegistryInfo(client, registryAddr, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, registryAddr.String(), res.Registry)
+		assert.True(t, res.Paused)
+		assert.Equal(t, "1000000000", res.MinBaseFee)
+		assert.Equal(t, donationAddr.String(), res.DonationAddress)
+		assert.Equal(t, "20", res.DonationPercent)
+		assert.False(t, res.RequireGrantChainID)
+		assert.True(t, res.RefundOnFailurePolicy)
+		assert.Empty(t, res.Engine)
+		assert.Nil(t, res.EngineAuthorized)
+	})
+
+	t.Run("authorized engine", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := readRegistryInfo(client, registryAddr, &engineA)
+		require.NoError(t, err)
+
+		require.NotNil(t, res.EngineAuthorized)
+		assert.Equal(t, engineA.String(), res.Engine)
+		assert.True(t, *res.EngineAuthorized)
+	})
+
+	t.Run("unauthorized engine", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := readRegistryInfo(client, registryAddr, &engineB)
+		require.NoError(t, err)
+
+		require.NotNil(t, res.EngineAuthorized)
+		assert.False(t, *res.EngineAuthorized)
+	})
+
+	t.Run("registry not deployed", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := readRegistryInfo(client, types.StringToAddress("0x2002"), nil)
+		require.ErrorContains(t, err, "is not deployed")
+	})
+}
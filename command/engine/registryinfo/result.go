@@ -0,0 +1,45 @@
+package registryinfo
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/xgr-network/xgr-node/command/helper"
+)
+
+type RegistryInfoResult struct {
+	Registry              string `json:"registry"`
+	Paused                bool   `json:"paused"`
+	MinBaseFee            string `json:"minBaseFee"`
+	DonationAddress       string `json:"donationAddress"`
+	DonationPercent       string `json:"donationPercent"`
+	RequireGrantChainID   bool   `json:"requireGrantChainId"`
+	RefundOnFailurePolicy bool   `json:"refundOnFailurePolicy"`
+	Engine                string `json:"engine,omitempty"`
+	EngineAuthorized      *bool  `json:"engineAuthorized,omitempty"`
+}
+
+func (r *RegistryInfoResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[ENGINE REGISTRY]\n")
+
+	outputs := []string{
+		fmt.Sprintf("Registry|%s", r.Registry),
+		fmt.Sprintf("Paused|%t", r.Paused),
+		fmt.Sprintf("Min base fee (wei)|%s", r.MinBaseFee),
+		fmt.Sprintf("Donation address|%s", r.DonationAddress),
+		fmt.Sprintf("Donation percent|%s", r.DonationPercent),
+		fmt.Sprintf("Require grant chain ID|%t", r.RequireGrantChainID),
+		fmt.Sprintf("Refund on failure policy|%t", r.RefundOnFailurePolicy),
+	}
+
+	if r.EngineAuthorized != nil {
+		outputs = append(outputs, fmt.Sprintf("Engine %s authorized|%t", r.Engine, *r.EngineAuthorized))
+	}
+
+	buffer.WriteString(helper.FormatKV(outputs))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -142,7 +142,7 @@ func (p *predeployParams) updateGenesisConfig() error {
 		p.artifactsPath,
 		p.constructorArgs,
 		p.address,
-		p.genesisConfig.Params.ChainID,
+		p.genesisConfig.Params,
 	)
 	if err != nil {
 		return err
@@ -8,18 +8,48 @@ import (
 )
 
 func GetCommand() *cobra.Command {
-	return &cobra.Command{
+	licenseCmd := &cobra.Command{
 		Use:   "license",
 		Short: "Returns XGRChain license and dependency attributions",
 		Args:  cobra.NoArgs,
 		Run:   runCommand,
 	}
+
+	setFlags(licenseCmd)
+
+	return licenseCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(
+		&params.thirdParty,
+		thirdPartyFlag,
+		false,
+		"list the licenses of all third-party modules with their full text",
+	)
 }
 
 func runCommand(cmd *cobra.Command, _ []string) {
 	outputter := command.InitializeOutputter(cmd)
 	defer outputter.WriteOutput()
 
+	if params.thirdParty {
+		thirdParty, err := licenses.GetThirdPartyLicenses()
+		if err != nil {
+			outputter.SetError(err)
+
+			return
+		}
+
+		outputter.SetCommandResult(
+			&ThirdPartyLicenseResult{
+				Licenses: thirdParty,
+			},
+		)
+
+		return
+	}
+
 	bsdLicenses, err := licenses.GetBSDLicenses()
 	if err != nil {
 		outputter.SetError(err)
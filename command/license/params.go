@@ -0,0 +1,13 @@
+package license
+
+const (
+	thirdPartyFlag = "third-party"
+)
+
+var (
+	params = &licenseParams{}
+)
+
+type licenseParams struct {
+	thirdParty bool
+}
@@ -3,6 +3,7 @@ package license
 import (
 	"bytes"
 	"fmt"
+	"strings"
 
 	"github.com/xgr-network/xgr-node/licenses"
 )
@@ -42,3 +43,30 @@ func (r *LicenseResult) GetOutput() string {
 
 	return buffer.String()
 }
+
+type ThirdPartyLicenseResult struct {
+	Licenses []licenses.ThirdPartyLicense `json:"third_party_licenses"`
+}
+
+func (r *ThirdPartyLicenseResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[THIRD-PARTY LICENSES]\n")
+
+	if len(r.Licenses) == 0 {
+		buffer.WriteString("\nNo third-party license manifest embedded in this build.\n")
+
+		return buffer.String()
+	}
+
+	for _, l := range r.Licenses {
+		buffer.WriteString(fmt.Sprintf("\n%s %s (%s)\n\n", l.Module, l.Version, l.Type))
+		buffer.WriteString(l.Text)
+
+		if !strings.HasSuffix(l.Text, "\n") {
+			buffer.WriteString("\n")
+		}
+	}
+
+	return buffer.String()
+}
@@ -21,11 +21,15 @@ var (
 )
 
 const (
-	addrFlag = "addr"
+	addrFlag    = "addr"
+	staticFlag  = "static"
+	trustedFlag = "trusted"
 )
 
 type addParams struct {
 	peerAddresses []string
+	static        bool
+	trusted       bool
 
 	systemClient proto.SystemClient
 
@@ -74,7 +78,9 @@ func (p *addParams) addPeer(peerAddress string) error {
 	if _, err := p.systemClient.PeersAdd(
 		context.Background(),
 		&proto.PeersAddRequest{
-			Id: peerAddress,
+			Id:      peerAddress,
+			Static:  p.static,
+			Trusted: p.trusted,
 		},
 	); err != nil {
 		return err
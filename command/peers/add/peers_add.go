@@ -27,6 +27,20 @@ func setFlags(cmd *cobra.Command) {
 		[]string{},
 		"the libp2p addresses of the peers",
 	)
+
+	cmd.Flags().BoolVar(
+		&params.static,
+		staticFlag,
+		false,
+		"keep the peers connected, reconnecting after disconnects and restarts",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.trusted,
+		trustedFlag,
+		false,
+		"add the peers as static peers exempt from the peer limits and penalties",
+	)
 }
 
 func runPreRun(_ *cobra.Command, _ []string) error {
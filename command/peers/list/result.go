@@ -9,18 +9,50 @@ import (
 )
 
 type PeersListResult struct {
-	Peers []string `json:"peers"`
+	Peers   []string `json:"peers"`
+	Static  []string `json:"static"`
+	Trusted []string `json:"trusted"`
 }
 
 func newPeersListResult(peers []*proto.Peer) *PeersListResult {
-	resultPeers := make([]string, len(peers))
+	result := &PeersListResult{
+		Peers:   make([]string, len(peers)),
+		Static:  []string{},
+		Trusted: []string{},
+	}
+
 	for i, p := range peers {
-		resultPeers[i] = p.Id
+		result.Peers[i] = p.Id
+
+		if p.Static {
+			result.Static = append(result.Static, p.Id)
+		}
+
+		if p.Trusted {
+			result.Trusted = append(result.Trusted, p.Id)
+		}
+	}
+
+	return result
+}
+
+// markers returns the static and trusted markers of the peer
+func (r *PeersListResult) markers(id string) string {
+	var markers string
+
+	for _, static := range r.Static {
+		if static == id {
+			markers += " [static]"
+		}
 	}
 
-	return &PeersListResult{
-		Peers: resultPeers,
+	for _, trusted := range r.Trusted {
+		if trusted == id {
+			markers += " [trusted]"
+		}
 	}
+
+	return markers
 }
 
 func (r *PeersListResult) GetOutput() string {
@@ -35,7 +67,7 @@ func (r *PeersListResult) GetOutput() string {
 
 		rows := make([]string, len(r.Peers))
 		for i, p := range r.Peers {
-			rows[i] = fmt.Sprintf("[%d]|%s", i, p)
+			rows[i] = fmt.Sprintf("[%d]|%s%s", i, p, r.markers(p))
 		}
 		buffer.WriteString(helper.FormatKV(rows))
 	}
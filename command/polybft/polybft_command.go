@@ -9,6 +9,7 @@ import (
 	"github.com/xgr-network/xgr-node/command/rootchain/validators"
 	"github.com/xgr-network/xgr-node/command/rootchain/whitelist"
 	"github.com/xgr-network/xgr-node/command/rootchain/withdraw"
+	"github.com/xgr-network/xgr-node/command/sidechain/rewardaddress"
 	"github.com/xgr-network/xgr-node/command/sidechain/rewards"
 	"github.com/xgr-network/xgr-node/command/sidechain/unstaking"
 	sidechainWithdraw "github.com/xgr-network/xgr-node/command/sidechain/withdraw"
@@ -27,6 +28,8 @@ func GetCommand() *cobra.Command {
 		sidechainWithdraw.GetCommand(),
 		// sidechain (reward pool) command to withdraw pending rewards
 		rewards.GetCommand(),
+		// sidechain (reward address registry) command to register the validator reward address
+		rewardaddress.GetCommand(),
 		// rootchain (stake manager) command to withdraw stake
 		withdraw.GetCommand(),
 		// rootchain (supernet manager) command that queries validator info
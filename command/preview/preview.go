@@ -0,0 +1,47 @@
+package preview
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/xgr-network/xgr-node/command"
+	"github.com/xgr-network/xgr-node/command/helper"
+	"github.com/xgr-network/xgr-node/server/proto"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+func GetCommand() *cobra.Command {
+	previewCmd := &cobra.Command{
+		Use:   "preview",
+		Short: "Builds the block the node would produce next, without sealing or writing it",
+		Args:  cobra.NoArgs,
+		Run:   runCommand,
+	}
+
+	helper.RegisterGRPCAddressFlag(previewCmd)
+
+	return previewCmd
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	preview, err := getBlockPreview(helper.GetGRPCAddress(cmd))
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(newPreviewResult(preview))
+}
+
+func getBlockPreview(grpcAddress string) (*proto.BlockPreviewResponse, error) {
+	client, err := helper.GetSystemClientConnection(grpcAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.BlockPreview(context.Background(), &empty.Empty{})
+}
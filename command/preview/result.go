@@ -0,0 +1,100 @@
+package preview
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/xgr-network/xgr-node/command/helper"
+	"github.com/xgr-network/xgr-node/server/proto"
+)
+
+type PreviewTxResult struct {
+	Hash         string `json:"hash"`
+	From         string `json:"from"`
+	Nonce        uint64 `json:"nonce"`
+	GasUsed      uint64 `json:"gas_used"`
+	Status       uint64 `json:"status"`
+	BurnedFee    string `json:"burned_fee"`
+	DonationFee  string `json:"donation_fee"`
+	ValidatorFee string `json:"validator_fee"`
+}
+
+type PreviewResult struct {
+	Number       uint64             `json:"number"`
+	ParentHash   string             `json:"parent_hash"`
+	StateRoot    string             `json:"state_root"`
+	GasLimit     uint64             `json:"gas_limit"`
+	GasUsed      uint64             `json:"gas_used"`
+	BaseFee      uint64             `json:"base_fee"`
+	BurnedFee    string             `json:"burned_fee"`
+	DonationFee  string             `json:"donation_fee"`
+	ValidatorFee string             `json:"validator_fee"`
+	Transactions []*PreviewTxResult `json:"transactions"`
+}
+
+func newPreviewResult(preview *proto.BlockPreviewResponse) *PreviewResult {
+	result := &PreviewResult{
+		Number:       preview.Number,
+		ParentHash:   preview.ParentHash,
+		StateRoot:    preview.StateRoot,
+		GasLimit:     preview.GasLimit,
+		GasUsed:      preview.GasUsed,
+		BaseFee:      preview.BaseFee,
+		BurnedFee:    preview.BurnedFee,
+		DonationFee:  preview.DonationFee,
+		ValidatorFee: preview.ValidatorFee,
+		Transactions: make([]*PreviewTxResult, len(preview.Transactions)),
+	}
+
+	for i, tx := range preview.Transactions {
+		result.Transactions[i] = &PreviewTxResult{
+			Hash:         tx.Hash,
+			From:         tx.From,
+			Nonce:        tx.Nonce,
+			GasUsed:      tx.GasUsed,
+			Status:       tx.Status,
+			BurnedFee:    tx.BurnedFee,
+			DonationFee:  tx.DonationFee,
+			ValidatorFee: tx.ValidatorFee,
+		}
+	}
+
+	return result
+}
+
+func (r *PreviewResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[BLOCK PREVIEW]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Number|%d", r.Number),
+		fmt.Sprintf("Parent Hash|%s", r.ParentHash),
+		fmt.Sprintf("State Root|%s", r.StateRoot),
+		fmt.Sprintf("Gas Limit|%d", r.GasLimit),
+		fmt.Sprintf("Gas Used|%d", r.GasUsed),
+		fmt.Sprintf("Base Fee|%d", r.BaseFee),
+		fmt.Sprintf("Burned Fee (wei)|%s", r.BurnedFee),
+		fmt.Sprintf("Donation Fee (wei)|%s", r.DonationFee),
+		fmt.Sprintf("Validator Fee (wei)|%s", r.ValidatorFee),
+	}))
+
+	buffer.WriteString("\n\n[TRANSACTIONS]\n")
+
+	if len(r.Transactions) == 0 {
+		buffer.WriteString("No transactions")
+	} else {
+		rows := make([]string, len(r.Transactions)+1)
+		rows[0] = "Hash|From|Nonce|Gas Used|Status|Validator Fee (wei)"
+
+		for i, tx := range r.Transactions {
+			rows[i+1] = fmt.Sprintf("%s|%s|%d|%d|%d|%s",
+				tx.Hash, tx.From, tx.Nonce, tx.GasUsed, tx.Status, tx.ValidatorFee)
+		}
+
+		buffer.WriteString(helper.FormatList(rows))
+	}
+
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -18,6 +18,8 @@ func GetCommand() *cobra.Command {
 	genesisCMD := RegenesisCMD()
 	genesisCMD.AddCommand(GetRootCMD())
 	genesisCMD.AddCommand(HistoryTestCmd())
+	genesisCMD.AddCommand(ExportStateCmd())
+	genesisCMD.AddCommand(ImportStateCmd())
 
 	return genesisCMD
 }
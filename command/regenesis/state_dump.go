@@ -0,0 +1,207 @@
+package regenesis
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/spf13/cobra"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	blockleveldb "github.com/xgr-network/xgr-node/blockchain/storage/leveldb"
+	"github.com/xgr-network/xgr-node/command"
+	"github.com/xgr-network/xgr-node/command/helper"
+	itrie "github.com/xgr-network/xgr-node/state/immutable-trie"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+var (
+	exportSourcePath string
+	exportRoot       string
+	exportOut        string
+
+	importIn      string
+	importDataDir string
+)
+
+/*
+./xgrchain regenesis export-state --source-path ./test-chain-1/trie \
+--root 0xf5ef1a28c82226effb90f4465180ec3469226747818579673f4be929f1cd8663 --out ./state.dump
+*/
+func ExportStateCmd() *cobra.Command {
+	exportCmd := &cobra.Command{
+		Use:   "export-state",
+		Short: "Exports the state at a state root to a snapshot file",
+	}
+
+	exportCmd.Flags().StringVar(&exportSourcePath, "source-path", "", "the directory of trie data of the chain")
+	exportCmd.Flags().StringVar(&exportRoot, "root", "", "the state root to export")
+	exportCmd.Flags().StringVar(&exportOut, "out", "", "the snapshot file to write")
+
+	exportCmd.Run = func(cmd *cobra.Command, args []string) {
+		outputter := command.InitializeOutputter(exportCmd)
+		defer outputter.WriteOutput()
+
+		if exportSourcePath == "" || exportRoot == "" || exportOut == "" {
+			outputter.SetError(fmt.Errorf("not enough arguments"))
+
+			return
+		}
+
+		trieDB, err := leveldb.OpenFile(exportSourcePath, &opt.Options{ReadOnly: true})
+		if err != nil {
+			outputter.SetError(fmt.Errorf("open trie db error:%w", err))
+
+			return
+		}
+		defer trieDB.Close()
+
+		out, err := os.Create(exportOut)
+		if err != nil {
+			outputter.SetError(fmt.Errorf("create snapshot file error:%w", err))
+
+			return
+		}
+		defer out.Close()
+
+		logger := newProgressLogger("export-state")
+		root := types.StringToHash(exportRoot)
+
+		stats, err := itrie.ExportState(root, itrie.NewKV(trieDB), out, func(s itrie.StateDumpStats) {
+			logger.Info("exporting state", "accounts", s.Accounts, "codes", s.Codes, "slots", s.Slots)
+		})
+		if err != nil {
+			outputter.SetError(fmt.Errorf("export state error:%w", err))
+
+			return
+		}
+
+		if err := out.Sync(); err != nil {
+			outputter.SetError(fmt.Errorf("write snapshot file error:%w", err))
+
+			return
+		}
+
+		outputter.WriteCommandResult(&StateDumpResult{
+			Action: "export",
+			Root:   root.String(),
+			File:   exportOut,
+			Stats:  *stats,
+		})
+	}
+
+	return exportCmd
+}
+
+/*
+./xgrchain regenesis import-state --in ./state.dump --data-dir ./test-chain-2
+*/
+func ImportStateCmd() *cobra.Command {
+	importCmd := &cobra.Command{
+		Use:   "import-state",
+		Short: "Imports a state snapshot file into a fresh data directory and verifies its state root",
+	}
+
+	importCmd.Flags().StringVar(&importIn, "in", "", "the snapshot file to import")
+	importCmd.Flags().StringVar(&importDataDir, "data-dir", "", "the fresh data directory to import the state into")
+
+	importCmd.Run = func(cmd *cobra.Command, args []string) {
+		outputter := command.InitializeOutputter(importCmd)
+		defer outputter.WriteOutput()
+
+		if importIn == "" || importDataDir == "" {
+			outputter.SetError(fmt.Errorf("not enough arguments"))
+
+			return
+		}
+
+		triePath := filepath.Join(importDataDir, "trie")
+
+		if entries, err := os.ReadDir(triePath); err == nil && len(entries) > 0 {
+			outputter.SetError(fmt.Errorf("trie directory %s is not empty, the state must be imported into a fresh data directory",
+				triePath))
+
+			return
+		} else if err != nil && !errors.Is(err, os.ErrNotExist) {
+			outputter.SetError(fmt.Errorf("read trie directory error:%w", err))
+
+			return
+		}
+
+		in, err := os.Open(importIn)
+		if err != nil {
+			outputter.SetError(fmt.Errorf("open snapshot file error:%w", err))
+
+			return
+		}
+		defer in.Close()
+
+		trieDB, err := leveldb.OpenFile(triePath, nil)
+		if err != nil {
+			outputter.SetError(fmt.Errorf("open trie db error:%w", err))
+
+			return
+		}
+		defer trieDB.Close()
+
+		logger := newProgressLogger("import-state")
+
+		// the trie directory is fresh, so the batches don't need to be atomic and may flush on their way
+		kv := itrie.NewImportKV(trieDB, func() itrie.Batch {
+			return blockleveldb.NewBatchLevelDBWithLimit(trieDB, blockleveldb.ImportMaxBatchSize)
+		})
+
+		root, stats, err := itrie.ImportState(in, kv, func(s itrie.StateDumpStats) {
+			logger.Info("importing state", "accounts", s.Accounts, "codes", s.Codes, "slots", s.Slots)
+		})
+		if err != nil {
+			outputter.SetError(fmt.Errorf("import state error:%w", err))
+
+			return
+		}
+
+		outputter.WriteCommandResult(&StateDumpResult{
+			Action: "import",
+			Root:   root.String(),
+			File:   importIn,
+			Stats:  *stats,
+		})
+	}
+
+	return importCmd
+}
+
+// newProgressLogger reports the progress of long running state dumps on stderr,
+// so that it doesn't mix with the command output
+func newProgressLogger(name string) hclog.Logger {
+	return hclog.New(&hclog.LoggerOptions{
+		Name:   name,
+		Output: os.Stderr,
+	})
+}
+
+type StateDumpResult struct {
+	Action string               `json:"action"`
+	Root   string               `json:"root"`
+	File   string               `json:"file"`
+	Stats  itrie.StateDumpStats `json:"stats"`
+}
+
+func (r *StateDumpResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString(fmt.Sprintf("\n[State %s SUCCESS]\n", r.Action))
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("State root|%s", r.Root),
+		fmt.Sprintf("Snapshot file|%s", r.File),
+		fmt.Sprintf("Accounts|%d", r.Stats.Accounts),
+		fmt.Sprintf("Codes|%d", r.Stats.Codes),
+		fmt.Sprintf("Storage slots|%d", r.Stats.Slots),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
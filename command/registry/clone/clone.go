@@ -0,0 +1,343 @@
+package clone
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/abi"
+	"github.com/umbracle/ethgo/jsonrpc"
+
+	"github.com/xgr-network/xgr-node/chain"
+	"github.com/xgr-network/xgr-node/command"
+	"github.com/xgr-network/xgr-node/command/helper"
+	"github.com/xgr-network/xgr-node/helper/hex"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+/*
+./xgrchain registry clone --source-rpc "http://localhost:10002" --registry 0x... --out overrides.json
+*/
+func GetCommand() *cobra.Command {
+	registryCloneCmd := &cobra.Command{
+		Use:     "clone",
+		Short:   "Clones the EngineRegistry state (authorized engines and fee settings) of a source chain",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(registryCloneCmd)
+	helper.SetRequiredFlags(registryCloneCmd, params.getRequiredFlags())
+
+	return registryCloneCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.sourceRPC,
+		sourceRPCFlag,
+		"",
+		"the JSON-RPC address of the chain to clone the registry from",
+	)
+
+	cmd.Flags().StringVar(
+		&params.registryRaw,
+		registryFlag,
+		"",
+		"the EngineRegistry address on the source chain (params.engineRegistryAddress)",
+	)
+
+	cmd.Flags().StringVar(
+		&params.outPath,
+		outFlag,
+		"",
+		"the file to write the genesis alloc fragment to",
+	)
+
+	cmd.Flags().StringVar(
+		&params.applyToPath,
+		applyToFlag,
+		"",
+		"the genesis file to merge the cloned registry into",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.enginesRaw,
+		enginesFlag,
+		[]string{},
+		"engine addresses to check in addition to the ones found in the registry events",
+	)
+
+	cmd.Flags().Int64Var(
+		&params.blockNumber,
+		blockFlag,
+		int64(ethgo.Latest),
+		"the block number to clone the registry state at",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.logRange,
+		logRangeFlag,
+		defaultLogRange,
+		"the block range of a single eth_getLogs request when scanning registry events",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.initRawParams()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	rpcClient, err := jsonrpc.NewClient(params.sourceRPC)
+	if err != nil {
+		outputter.SetError(fmt.Errorf("failed to connect to %s: %w", params.sourceRPC, err))
+
+		return
+	}
+
+	params.clone, err = cloneRegistry(
+		rpcClient.Eth(),
+		params.registryAddr,
+		params.engines,
+		params.blockNumber,
+		params.logRange,
+	)
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	if err := params.writeOverrides(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	if err := params.applyToGenesis(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
+
+// maxEngines mirrors EngineRegistry.MAX_ENGINES
+const maxEngines = 200
+
+var (
+	engineAddedEvent   = abi.MustNewEvent("event EngineAdded(address indexed engine, address indexed addedBy)")
+	engineRemovedEvent = abi.MustNewEvent("event EngineRemoved(address indexed engine, address indexed removedBy)")
+)
+
+// registryClient is the part of the eth JSON-RPC client used to read the source registry
+type registryClient interface {
+	BlockNumber() (uint64, error)
+	GetCode(addr ethgo.Address, block ethgo.BlockNumberOrHash) (string, error)
+	GetStorageAt(addr ethgo.Address, slot ethgo.Hash, block ethgo.BlockNumberOrHash) (ethgo.Hash, error)
+	GetLogs(filter *ethgo.LogFilter) ([]*ethgo.Log, error)
+}
+
+// registryClone is the EngineRegistry account as read from the source chain
+type registryClone struct {
+	registry    types.Address
+	blockNumber uint64
+	code        []byte
+	storage     map[types.Hash]types.Hash
+	engines     []types.Address
+}
+
+// cloneRegistry reads all documented registry slots at the given block. The authorizedEngines
+// mapping can't be enumerated from storage, so the engines are collected from the
+// EngineAdded/EngineRemoved events, the engineList array and the explicit engine list.
+func cloneRegistry(
+	client registryClient,
+	registry types.Address,
+	engines []types.Address,
+	blockNumber int64,
+	logRange uint64,
+) (*registryClone, error) {
+	var number uint64
+
+	if blockNumber < 0 {
+		latest, err := client.BlockNumber()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get latest block: %w", err)
+		}
+
+		number = latest
+	} else {
+		number = uint64(blockNumber)
+	}
+
+	block := ethgo.BlockNumber(number)
+
+	rawCode, err := client.GetCode(ethgo.Address(registry), block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get registry code: %w", err)
+	}
+
+	code, err := hex.DecodeHex(rawCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode registry code: %w", err)
+	}
+
+	if len(code) == 0 {
+		return nil, fmt.Errorf("registry %s is not deployed at block %d", registry, number)
+	}
+
+	c := &registryClone{
+		registry:    registry,
+		blockNumber: number,
+		code:        code,
+		storage:     map[types.Hash]types.Hash{},
+	}
+
+	read := func(slot types.Hash) (types.Hash, error) {
+		value, err := client.GetStorageAt(ethgo.Address(registry), ethgo.Hash(slot), block)
+		if err != nil {
+			return types.Hash{}, fmt.Errorf("failed to read registry slot %s: %w", slot, err)
+		}
+
+		if res := types.Hash(value); res != (types.Hash{}) {
+			c.storage[slot] = res
+		}
+
+		return types.Hash(value), nil
+	}
+
+	// requireGrantChainId and refundOnFailurePolicy share a slot
+	for _, slot := range []types.Hash{
+		chain.EngineRegistrySlotKeyAdmin(),
+		chain.EngineRegistrySlotKeyPendingAdmin(),
+		chain.EngineRegistrySlotKeyMinBaseFee(),
+		chain.EngineRegistrySlotKeyPaused(),
+		chain.EngineRegistrySlotKeyReserved0(),
+		chain.EngineRegistrySlotKeyDonationAddress(),
+		chain.EngineRegistrySlotKeyDonationPercent(),
+		chain.EngineRegistrySlotKeyRequireGrantChainID(),
+	} {
+		if _, err := read(slot); err != nil {
+			return nil, err
+		}
+	}
+
+	candidates := make(map[types.Address]struct{}, len(engines))
+	for _, engine := range engines {
+		candidates[engine] = struct{}{}
+	}
+
+	rawLength, err := read(chain.EngineRegistrySlotKeyEngineList())
+	if err != nil {
+		return nil, err
+	}
+
+	length := new(big.Int).SetBytes(rawLength[:])
+	if !length.IsUint64() || length.Uint64() > maxEngines {
+		return nil, fmt.Errorf("invalid engine list length %s", length)
+	}
+
+	for i := uint64(0); i < length.Uint64(); i++ {
+		item, err := read(chain.EngineRegistrySlotKeyEngineListItem(i))
+		if err != nil {
+			return nil, err
+		}
+
+		candidates[types.BytesToAddress(item[:])] = struct{}{}
+	}
+
+	if err := scanEngineEvents(client, registry, number, logRange, candidates); err != nil {
+		return nil, err
+	}
+
+	for engine := range candidates {
+		authorized, err := read(chain.EngineRegistrySlotKeyAuthorizedEngine(engine))
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := read(chain.EngineRegistrySlotKeyEngineIndex(engine)); err != nil {
+			return nil, err
+		}
+
+		if authorized != (types.Hash{}) {
+			c.engines = append(c.engines, engine)
+		}
+	}
+
+	sort.Slice(c.engines, func(i, j int) bool {
+		return c.engines[i].String() < c.engines[j].String()
+	})
+
+	return c, nil
+}
+
+// scanEngineEvents adds every engine that was ever added or removed up to the given block.
+// Missing events (e.g. pruned logs) are tolerated, the explicit engine list covers them.
+func scanEngineEvents(
+	client registryClient,
+	registry types.Address,
+	to uint64,
+	logRange uint64,
+	candidates map[types.Address]struct{},
+) error {
+	added, removed := engineAddedEvent.ID(), engineRemovedEvent.ID()
+
+	for from := uint64(0); from <= to; from += logRange {
+		filter := &ethgo.LogFilter{
+			Address: []ethgo.Address{ethgo.Address(registry)},
+			Topics:  [][]*ethgo.Hash{{&added, &removed}},
+		}
+
+		filter.SetFromUint64(from)
+		filter.SetToUint64(min(from+logRange-1, to))
+
+		logs, err := client.GetLogs(filter)
+		if err != nil {
+			return fmt.Errorf("failed to get registry events: %w", err)
+		}
+
+		for _, log := range logs {
+			if len(log.Topics) < 2 {
+				continue
+			}
+
+			candidates[types.BytesToAddress(log.Topics[1][:])] = struct{}{}
+		}
+	}
+
+	return nil
+}
+
+// account returns the registry as a genesis account
+func (c *registryClone) account() *chain.GenesisAccount {
+	return &chain.GenesisAccount{
+		Code:    c.code,
+		Storage: c.storage,
+		Balance: big.NewInt(0),
+	}
+}
+
+// allocJSON returns the genesis alloc fragment holding the registry account
+func (c *registryClone) allocJSON() ([]byte, error) {
+	return json.MarshalIndent(map[types.Address]*chain.GenesisAccount{
+		c.registry: c.account(),
+	}, "", "    ")
+}
+
+// applyTo puts the registry into the genesis alloc and points the chain params to it
+func (c *registryClone) applyTo(cc *chain.Chain) {
+	if cc.Genesis.Alloc == nil {
+		cc.Genesis.Alloc = map[types.Address]*chain.GenesisAccount{}
+	}
+
+	cc.Genesis.Alloc[c.registry] = c.account()
+	cc.Params.EngineRegistryAddress = c.registry
+}
@@ -0,0 +1,218 @@
+package clone
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+
+	"github.com/xgr-network/xgr-node/chain"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+var (
+	registryAddr = types.StringToAddress("0x1001")
+	adminAddr    = types.StringToAddress("0xad")
+	engineA      = types.StringToAddress("0xa1")
+	engineB      = types.StringToAddress("0xb2")
+	removedAddr  = types.StringToAddress("0xc3")
+	addedByAddr  = types.StringToAddress("0xd4")
+)
+
+// devChain is a minimal source chain holding an EngineRegistry account
+type devChain struct {
+	head    uint64
+	code    string
+	storage map[types.Hash]types.Hash
+	logs    []*ethgo.Log
+
+	getLogsCalls int
+}
+
+func (d *devChain) BlockNumber() (uint64, error) {
+	return d.head, nil
+}
+
+func (d *devChain) GetCode(addr ethgo.Address, _ ethgo.BlockNumberOrHash) (string, error) {
+	if types.Address(addr) != registryAddr {
+		return "0x", nil
+	}
+
+	return d.code, nil
+}
+
+func (d *devChain) GetStorageAt(addr ethgo.Address, slot ethgo.Hash, _ ethgo.BlockNumberOrHash) (ethgo.Hash, error) {
+	if types.Address(addr) != registryAddr {
+		return ethgo.Hash{}, nil
+	}
+
+	return ethgo.Hash(d.storage[types.Hash(slot)]), nil
+}
+
+func (d *devChain) GetLogs(filter *ethgo.LogFilter) ([]*ethgo.Log, error) {
+	d.getLogsCalls++
+
+	res := []*ethgo.Log{}
+
+	for _, log := range d.logs {
+		if log.BlockNumber >= uint64(*filter.From) && log.BlockNumber <= uint64(*filter.To) {
+			res = append(res, log)
+		}
+	}
+
+	return res, nil
+}
+
+func engineLog(event ethgo.Hash, engine types.Address, block uint64) *ethgo.Log {
+	return &ethgo.Log{
+		BlockNumber: block,
+		Address:     ethgo.Address(registryAddr),
+		Topics: []ethgo.Hash{
+			event,
+			ethgo.Hash(types.BytesToHash(engine.Bytes())),
+			ethgo.Hash(types.BytesToHash(addedByAddr.Bytes())),
+		},
+	}
+}
+
+// newDevChain returns a chain with engineA and engineB authorized.
+// removedAddr was authorized once and removed later on.
+func newDevChain() *devChain {
+	word := func(v uint64) types.Hash {
+		return types.BytesToHash(new(big.Int).SetUint64(v).Bytes())
+	}
+
+	storage := map[types.Hash]types.Hash{
+		chain.EngineRegistrySlotKeyAdmin():           types.BytesToHash(adminAddr.Bytes()),
+		chain.EngineRegistrySlotKeyMinBaseFee():      word(100_000_000_000),
+		chain.EngineRegistrySlotKeyDonationAddress(): types.BytesToHash(adminAddr.Bytes()),
+		chain.EngineRegistrySlotKeyDonationPercent(): word(10),
+
+		chain.EngineRegistrySlotKeyEngineList():         word(2),
+		chain.EngineRegistrySlotKeyEngineListItem(0):    types.BytesToHash(engineA.Bytes()),
+		chain.EngineRegistrySlotKeyEngineListItem(1):    types.BytesToHash(engineB.Bytes()),
+		chain.EngineRegistrySlotKeyEngineIndex(engineB): word(1),
+
+		chain.EngineRegistrySlotKeyAuthorizedEngine(engineA): word(1),
+		chain.EngineRegistrySlotKeyAuthorizedEngine(engineB): word(1),
+	}
+
+	added, removed := engineAddedEvent.ID(), engineRemovedEvent.ID()
+
+	return &devChain{
+		head:    2500,
+		code:    "0x6080604052",
+		storage: storage,
+		logs: []*ethgo.Log{
+			engineLog(added, engineA, 1),
+			engineLog(added, removedAddr, 5),
+			engineLog(added, engineB, 1200),
+			engineLog(removed, removedAddr, 2400),
+		},
+	}
+}
+
+func TestCloneRegistry(t *testing.T) {
+	t.Parallel()
+
+	source := newDevChain()
+
+	c, err := cloneRegistry(source, registryAddr, nil, int64(ethgo.Latest), 1000)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(2500), c.blockNumber)
+	assert.Equal(t, []byte{0x60, 0x80, 0x60, 0x40, 0x52}, c.code)
+	assert.Equal(t, []types.Address{engineA, engineB}, c.engines)
+
+	// every non-zero slot of the source registry is cloned
+	assert.Equal(t, source.storage, c.storage)
+
+	// 0..999, 1000..1999, 2000..2500
+	assert.Equal(t, 3, source.getLogsCalls)
+}
+
+func TestCloneRegistry_MissingEvents(t *testing.T) {
+	t.Parallel()
+
+	source := newDevChain()
+	source.logs = nil
+
+	// drop the engine list too, so the mapping is only reachable through the explicit engines
+	delete(source.storage, chain.EngineRegistrySlotKeyEngineList())
+	delete(source.storage, chain.EngineRegistrySlotKeyEngineListItem(0))
+	delete(source.storage, chain.EngineRegistrySlotKeyEngineListItem(1))
+
+	c, err := cloneRegistry(source, registryAddr, nil, int64(ethgo.Latest), 1000)
+	require.NoError(t, err)
+	assert.Empty(t, c.engines)
+
+	c, err = cloneRegistry(source, registryAddr, []types.Address{engineB, engineA}, int64(ethgo.Latest), 1000)
+	require.NoError(t, err)
+
+	assert.Equal(t, []types.Address{engineA, engineB}, c.engines)
+	assert.Equal(t, source.storage, c.storage)
+}
+
+func TestCloneRegistry_NotDeployed(t *testing.T) {
+	t.Parallel()
+
+	source := newDevChain()
+	source.code = "0x"
+
+	_, err := cloneRegistry(source, registryAddr, nil, 10, 1000)
+	assert.ErrorContains(t, err, "not deployed")
+}
+
+func TestCloneRegistry_StorageError(t *testing.T) {
+	t.Parallel()
+
+	errRPC := errors.New("rpc down")
+
+	_, err := cloneRegistry(&failingChain{devChain: newDevChain(), err: errRPC}, registryAddr, nil, 10, 1000)
+	assert.ErrorIs(t, err, errRPC)
+}
+
+type failingChain struct {
+	*devChain
+	err error
+}
+
+func (f *failingChain) GetStorageAt(ethgo.Address, ethgo.Hash, ethgo.BlockNumberOrHash) (ethgo.Hash, error) {
+	return ethgo.Hash{}, f.err
+}
+
+func TestRegistryClone_AllocAndGenesis(t *testing.T) {
+	t.Parallel()
+
+	c, err := cloneRegistry(newDevChain(), registryAddr, nil, int64(ethgo.Latest), 1000)
+	require.NoError(t, err)
+
+	data, err := c.allocJSON()
+	require.NoError(t, err)
+
+	alloc := map[types.Address]*chain.GenesisAccount{}
+	require.NoError(t, json.Unmarshal(data, &alloc))
+	require.Contains(t, alloc, registryAddr)
+	assert.Equal(t, c.code, alloc[registryAddr].Code)
+	assert.Equal(t, c.storage, alloc[registryAddr].Storage)
+
+	other := types.StringToAddress("0x2002")
+	cc := &chain.Chain{
+		Genesis: &chain.Genesis{
+			Alloc: map[types.Address]*chain.GenesisAccount{
+				other: {Balance: big.NewInt(1)},
+			},
+		},
+		Params: &chain.Params{},
+	}
+
+	c.applyTo(cc)
+
+	assert.Equal(t, registryAddr, cc.Params.EngineRegistryAddress)
+	assert.Contains(t, cc.Genesis.Alloc, other)
+	assert.Equal(t, c.storage, cc.Genesis.Alloc[registryAddr].Storage)
+}
@@ -0,0 +1,144 @@
+package clone
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/xgr-network/xgr-node/chain"
+	"github.com/xgr-network/xgr-node/command"
+	"github.com/xgr-network/xgr-node/command/helper"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+const (
+	sourceRPCFlag = "source-rpc"
+	registryFlag  = "registry"
+	outFlag       = "out"
+	applyToFlag   = "apply-to"
+	enginesFlag   = "engines"
+	blockFlag     = "block"
+	logRangeFlag  = "log-range"
+
+	defaultLogRange = uint64(1000)
+)
+
+var (
+	errNoOutput        = fmt.Errorf("at least one of --%s or --%s must be set", outFlag, applyToFlag)
+	errInvalidRegistry = errors.New("invalid registry address provided")
+	errInvalidEngine   = errors.New("invalid engine address provided")
+	errInvalidLogRange = errors.New("log range must be greater than zero")
+)
+
+var (
+	params = &cloneParams{}
+)
+
+type cloneParams struct {
+	sourceRPC    string
+	registryRaw  string
+	outPath      string
+	applyToPath  string
+	enginesRaw   []string
+	blockNumber  int64
+	logRange     uint64
+	registryAddr types.Address
+	engines      []types.Address
+
+	clone *registryClone
+}
+
+func (p *cloneParams) getRequiredFlags() []string {
+	return []string{
+		sourceRPCFlag,
+		registryFlag,
+	}
+}
+
+func (p *cloneParams) initRawParams() error {
+	if p.outPath == "" && p.applyToPath == "" {
+		return errNoOutput
+	}
+
+	if p.logRange == 0 {
+		return errInvalidLogRange
+	}
+
+	if err := types.IsValidAddress(p.registryRaw); err != nil {
+		return fmt.Errorf("%w: %w", errInvalidRegistry, err)
+	}
+
+	p.registryAddr = types.StringToAddress(p.registryRaw)
+
+	p.engines = make([]types.Address, 0, len(p.enginesRaw))
+
+	for _, raw := range p.enginesRaw {
+		if err := types.IsValidAddress(raw); err != nil {
+			return fmt.Errorf("%w: %w", errInvalidEngine, err)
+		}
+
+		p.engines = append(p.engines, types.StringToAddress(raw))
+	}
+
+	return nil
+}
+
+// writeOverrides stores the cloned registry as a genesis alloc fragment
+func (p *cloneParams) writeOverrides() error {
+	if p.outPath == "" {
+		return nil
+	}
+
+	data, err := p.clone.allocJSON()
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(p.outPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", p.outPath, err)
+	}
+
+	return nil
+}
+
+// applyToGenesis merges the cloned registry into an existing genesis file
+func (p *cloneParams) applyToGenesis() error {
+	if p.applyToPath == "" {
+		return nil
+	}
+
+	cc, err := chain.Import(p.applyToPath)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to load chain config from %s: %w",
+			p.applyToPath,
+			err,
+		)
+	}
+
+	p.clone.applyTo(cc)
+
+	// Remove the current genesis configuration from disk
+	if err := os.Remove(p.applyToPath); err != nil {
+		return err
+	}
+
+	// Save the new genesis configuration
+	return helper.WriteGenesisConfigToDisk(cc, p.applyToPath)
+}
+
+func (p *cloneParams) getResult() command.CommandResult {
+	engines := make([]string, 0, len(p.clone.engines))
+	for _, engine := range p.clone.engines {
+		engines = append(engines, engine.String())
+	}
+
+	return &RegistryCloneResult{
+		Registry:    p.registryAddr.String(),
+		BlockNumber: p.clone.blockNumber,
+		Engines:     engines,
+		Slots:       len(p.clone.storage),
+		Out:         p.outPath,
+		AppliedTo:   p.applyToPath,
+	}
+}
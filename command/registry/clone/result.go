@@ -0,0 +1,44 @@
+package clone
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/xgr-network/xgr-node/command/helper"
+)
+
+type RegistryCloneResult struct {
+	Registry    string   `json:"registry"`
+	BlockNumber uint64   `json:"blockNumber"`
+	Engines     []string `json:"engines"`
+	Slots       int      `json:"slots"`
+	Out         string   `json:"out,omitempty"`
+	AppliedTo   string   `json:"appliedTo,omitempty"`
+}
+
+func (r *RegistryCloneResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[ENGINE REGISTRY CLONE]\n")
+
+	outputs := []string{
+		fmt.Sprintf("Registry|%s", r.Registry),
+		fmt.Sprintf("Block|%d", r.BlockNumber),
+		fmt.Sprintf("Authorized engines|%s", strings.Join(r.Engines, ", ")),
+		fmt.Sprintf("Storage slots|%d", r.Slots),
+	}
+
+	if r.Out != "" {
+		outputs = append(outputs, fmt.Sprintf("Overrides|%s", r.Out))
+	}
+
+	if r.AppliedTo != "" {
+		outputs = append(outputs, fmt.Sprintf("Applied to|%s", r.AppliedTo))
+	}
+
+	buffer.WriteString(helper.FormatKV(outputs))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
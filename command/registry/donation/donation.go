@@ -0,0 +1,281 @@
+package donation
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/umbracle/ethgo"
+
+	"github.com/xgr-network/xgr-node/command"
+	"github.com/xgr-network/xgr-node/command/helper"
+	"github.com/xgr-network/xgr-node/command/polybftsecrets"
+	rootHelper "github.com/xgr-network/xgr-node/command/rootchain/helper"
+	sidechainHelper "github.com/xgr-network/xgr-node/command/sidechain"
+	"github.com/xgr-network/xgr-node/contracts"
+	"github.com/xgr-network/xgr-node/contracts/abis"
+	"github.com/xgr-network/xgr-node/helper/hex"
+	"github.com/xgr-network/xgr-node/txrelayer"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+var params donationParams
+
+func GetCommand() *cobra.Command {
+	donationCmd := &cobra.Command{
+		Use:   "donation",
+		Short: "Proposes, votes on and executes changes of the donation percent. Only accepts subcommands.",
+	}
+
+	helper.RegisterJSONRPCFlag(donationCmd)
+
+	donationCmd.AddCommand(
+		proposeCommand(),
+		voteCommand(),
+		executeCommand(),
+		statusCommand(),
+	)
+
+	return donationCmd
+}
+
+func proposeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "propose",
+		Short: "Proposes a new donation percent, the sender has to be a validator or an authorized engine",
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			params.jsonRPC = helper.GetJSONRPCAddress(cmd)
+
+			return params.validateProposeFlags()
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return sendDonationTx(cmd, "PROPOSE", "propose", params.percent)
+		},
+	}
+
+	setAccountFlags(cmd)
+	cmd.Flags().Uint64Var(&params.percent, percentFlag, 0, "proposed donation percent (0-100)")
+	_ = cmd.MarkFlagRequired(percentFlag)
+
+	return cmd
+}
+
+func voteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vote",
+		Short: "Approves a donation percent proposal, the sender has to be a validator",
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			params.jsonRPC = helper.GetJSONRPCAddress(cmd)
+
+			return params.validateIDFlags(true)
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return sendDonationTx(cmd, "VOTE", "approve", params.id)
+		},
+	}
+
+	setAccountFlags(cmd)
+	setIDFlag(cmd)
+
+	return cmd
+}
+
+func executeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "execute",
+		Short: "Writes the percent of a passed proposal into the EngineRegistry once its delay elapsed",
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			params.jsonRPC = helper.GetJSONRPCAddress(cmd)
+
+			return params.validateIDFlags(true)
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return sendDonationTx(cmd, "EXECUTE", "execute", params.id)
+		},
+	}
+
+	setAccountFlags(cmd)
+	setIDFlag(cmd)
+
+	return cmd
+}
+
+func statusCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Shows the approvals and the state of a donation percent proposal",
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			params.jsonRPC = helper.GetJSONRPCAddress(cmd)
+
+			return params.validateIDFlags(false)
+		},
+		RunE: runStatus,
+	}
+
+	setIDFlag(cmd)
+
+	return cmd
+}
+
+func setAccountFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.accountDir,
+		polybftsecrets.AccountDirFlag,
+		"",
+		polybftsecrets.AccountDirFlagDesc,
+	)
+
+	cmd.Flags().StringVar(
+		&params.accountConfig,
+		polybftsecrets.AccountConfigFlag,
+		"",
+		polybftsecrets.AccountConfigFlagDesc,
+	)
+
+	cmd.MarkFlagsMutuallyExclusive(polybftsecrets.AccountDirFlag, polybftsecrets.AccountConfigFlag)
+}
+
+func setIDFlag(cmd *cobra.Command) {
+	cmd.Flags().Uint64Var(&params.id, idFlag, 0, "id of the donation percent proposal")
+	_ = cmd.MarkFlagRequired(idFlag)
+}
+
+// sendDonationTx sends the call of the method with its single argument to the donation vote precompile
+func sendDonationTx(cmd *cobra.Command, action, method string, arg uint64) error {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	account, err := sidechainHelper.GetAccount(params.accountDir, params.accountConfig)
+	if err != nil {
+		return err
+	}
+
+	txRelayer, err := txrelayer.NewTxRelayer(txrelayer.WithIPAddress(params.jsonRPC),
+		txrelayer.WithReceiptTimeout(150*time.Millisecond))
+	if err != nil {
+		return err
+	}
+
+	encoded, err := abis.DonationGovernanceABI.GetMethod(method).Encode(
+		[]interface{}{new(big.Int).SetUint64(arg)})
+	if err != nil {
+		return err
+	}
+
+	receiver := (*ethgo.Address)(&contracts.DonationGovernancePrecompile)
+	txn := rootHelper.CreateTransaction(account.Ecdsa.Address(), receiver, encoded, nil, false)
+
+	receipt, err := txRelayer.SendTransaction(txn, account.Ecdsa)
+	if err != nil {
+		return err
+	}
+
+	if receipt.Status != uint64(types.ReceiptSuccess) {
+		return fmt.Errorf("donation %s transaction failed on block: %d", method, receipt.BlockNumber)
+	}
+
+	result := &donationTxResult{
+		Action:      action,
+		Sender:      account.Ecdsa.Address().String(),
+		ProposalID:  arg,
+		BlockNumber: receipt.BlockNumber,
+	}
+
+	// the id of a new proposal is the first indexed topic of its DonationPercentProposed event
+	if method == "propose" {
+		proposed := abis.DonationGovernanceABI.Events["DonationPercentProposed"]
+
+		result.ProposalID = 0
+		result.Percent = fmt.Sprintf("%d", arg)
+
+		for _, log := range receipt.Logs {
+			if log.Address == *receiver && len(log.Topics) > 1 && log.Topics[0] == proposed.ID() {
+				result.ProposalID = new(big.Int).SetBytes(log.Topics[1].Bytes()).Uint64()
+			}
+		}
+	}
+
+	outputter.WriteCommandResult(result)
+
+	return nil
+}
+
+func runStatus(cmd *cobra.Command, _ []string) error {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	txRelayer, err := txrelayer.NewTxRelayer(txrelayer.WithIPAddress(params.jsonRPC))
+	if err != nil {
+		return err
+	}
+
+	countMethod := abis.DonationGovernanceABI.GetMethod("proposalCount")
+	proposalMethod := abis.DonationGovernanceABI.GetMethod("proposal")
+
+	count, err := callDonationPrecompile(txRelayer, countMethod.ID(), countMethod.Outputs.Decode)
+	if err != nil {
+		return err
+	}
+
+	input, err := proposalMethod.Encode([]interface{}{new(big.Int).SetUint64(params.id)})
+	if err != nil {
+		return err
+	}
+
+	proposal, err := callDonationPrecompile(txRelayer, input, proposalMethod.Outputs.Decode)
+	if err != nil {
+		return fmt.Errorf("failed to read proposal %d: %w", params.id, err)
+	}
+
+	proposer, _ := proposal["proposer"].(ethgo.Address)
+	executed, _ := proposal["executed"].(bool)
+
+	outputter.WriteCommandResult(&donationStatusResult{
+		ProposalID:    params.id,
+		ProposalCount: bigToUint64(count["0"]),
+		Proposer:      proposer.String(),
+		Percent:       bigToUint64(proposal["percent"]),
+		ProposedBlock: bigToUint64(proposal["proposedBlock"]),
+		Approvals:     bigToUint64(proposal["approvals"]),
+		PassedBlock:   bigToUint64(proposal["passedBlock"]),
+		Executed:      executed,
+	})
+
+	return nil
+}
+
+// callDonationPrecompile calls the read method of the donation vote precompile and decodes its outputs
+func callDonationPrecompile(
+	txRelayer txrelayer.TxRelayer, input []byte, decode func([]byte) (interface{}, error),
+) (map[string]interface{}, error) {
+	response, err := txRelayer.Call(ethgo.ZeroAddress, ethgo.Address(contracts.DonationGovernancePrecompile), input)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := hex.DecodeHex(response)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded, err := decode(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs, ok := decoded.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected output of the donation vote precompile")
+	}
+
+	return outputs, nil
+}
+
+func bigToUint64(val interface{}) uint64 {
+	if n, ok := val.(*big.Int); ok && n.IsUint64() {
+		return n.Uint64()
+	}
+
+	return 0
+}
@@ -0,0 +1,63 @@
+package donation
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/xgr-network/xgr-node/command/helper"
+	sidechainHelper "github.com/xgr-network/xgr-node/command/sidechain"
+)
+
+const (
+	percentFlag = "percent"
+	idFlag      = "id"
+)
+
+var (
+	errInvalidPercent = errors.New("donation percent must be between 0 and 100")
+	errInvalidID      = errors.New("proposal id must be greater than zero")
+)
+
+type donationParams struct {
+	accountDir    string
+	accountConfig string
+	jsonRPC       string
+	percent       uint64
+	id            uint64
+}
+
+func (p *donationParams) validateJSONRPC() error {
+	if _, err := helper.ParseJSONRPCAddress(p.jsonRPC); err != nil {
+		return fmt.Errorf("failed to parse json rpc address. Error: %w", err)
+	}
+
+	return nil
+}
+
+func (p *donationParams) validateAccount() error {
+	if err := p.validateJSONRPC(); err != nil {
+		return err
+	}
+
+	return sidechainHelper.ValidateSecretFlags(p.accountDir, p.accountConfig)
+}
+
+func (p *donationParams) validateProposeFlags() error {
+	if p.percent > 100 {
+		return errInvalidPercent
+	}
+
+	return p.validateAccount()
+}
+
+func (p *donationParams) validateIDFlags(withAccount bool) error {
+	if p.id == 0 {
+		return errInvalidID
+	}
+
+	if !withAccount {
+		return p.validateJSONRPC()
+	}
+
+	return p.validateAccount()
+}
@@ -0,0 +1,73 @@
+package donation
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/xgr-network/xgr-node/command/helper"
+)
+
+type donationTxResult struct {
+	Action      string `json:"action"`
+	Sender      string `json:"sender"`
+	ProposalID  uint64 `json:"proposalId"`
+	Percent     string `json:"percent,omitempty"`
+	BlockNumber uint64 `json:"blockNumber"`
+}
+
+func (r *donationTxResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString(fmt.Sprintf("\n[DONATION %s]\n", r.Action))
+
+	vals := make([]string, 0, 4)
+	vals = append(vals, fmt.Sprintf("Sender|%s", r.Sender))
+	vals = append(vals, fmt.Sprintf("Proposal ID|%d", r.ProposalID))
+
+	if r.Percent != "" {
+		vals = append(vals, fmt.Sprintf("Donation Percent|%s", r.Percent))
+	}
+
+	vals = append(vals, fmt.Sprintf("Inclusion Block Number|%d", r.BlockNumber))
+
+	buffer.WriteString(helper.FormatKV(vals))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
+
+type donationStatusResult struct {
+	ProposalID    uint64 `json:"proposalId"`
+	ProposalCount uint64 `json:"proposalCount"`
+	Proposer      string `json:"proposer"`
+	Percent       uint64 `json:"percent"`
+	ProposedBlock uint64 `json:"proposedBlock"`
+	Approvals     uint64 `json:"approvals"`
+	PassedBlock   uint64 `json:"passedBlock"`
+	Executed      bool   `json:"executed"`
+}
+
+func (r *donationStatusResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[DONATION PROPOSAL]\n")
+
+	passed := "no"
+	if r.PassedBlock != 0 {
+		passed = fmt.Sprintf("at block %d", r.PassedBlock)
+	}
+
+	vals := make([]string, 0, 8)
+	vals = append(vals, fmt.Sprintf("Proposal ID|%d of %d", r.ProposalID, r.ProposalCount))
+	vals = append(vals, fmt.Sprintf("Proposer|%s", r.Proposer))
+	vals = append(vals, fmt.Sprintf("Donation Percent|%d", r.Percent))
+	vals = append(vals, fmt.Sprintf("Proposed Block|%d", r.ProposedBlock))
+	vals = append(vals, fmt.Sprintf("Approvals|%d", r.Approvals))
+	vals = append(vals, fmt.Sprintf("Passed|%s", passed))
+	vals = append(vals, fmt.Sprintf("Executed|%t", r.Executed))
+
+	buffer.WriteString(helper.FormatKV(vals))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
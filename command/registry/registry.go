@@ -0,0 +1,27 @@
+package registry
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/xgr-network/xgr-node/command/registry/clone"
+	"github.com/xgr-network/xgr-node/command/registry/donation"
+)
+
+func GetCommand() *cobra.Command {
+	registryCmd := &cobra.Command{
+		Use:   "registry",
+		Short: "Top level command for working with the EngineRegistry state. Only accepts subcommands.",
+	}
+
+	registerSubcommands(registryCmd)
+
+	return registryCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// registry clone
+		clone.GetCommand(),
+		// registry donation
+		donation.GetCommand(),
+	)
+}
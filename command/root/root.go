@@ -8,6 +8,7 @@ import (
 
 	"github.com/xgr-network/xgr-node/command/backup"
 	"github.com/xgr-network/xgr-node/command/bridge"
+	"github.com/xgr-network/xgr-node/command/engine"
 	"github.com/xgr-network/xgr-node/command/genesis"
 	"github.com/xgr-network/xgr-node/command/helper"
 	"github.com/xgr-network/xgr-node/command/ibft"
@@ -16,10 +17,13 @@ import (
 	"github.com/xgr-network/xgr-node/command/peers"
 	"github.com/xgr-network/xgr-node/command/polybft"
 	"github.com/xgr-network/xgr-node/command/polybftsecrets"
+	"github.com/xgr-network/xgr-node/command/preview"
 	"github.com/xgr-network/xgr-node/command/regenesis"
+	"github.com/xgr-network/xgr-node/command/registry"
 	"github.com/xgr-network/xgr-node/command/rootchain"
 	"github.com/xgr-network/xgr-node/command/secrets"
 	"github.com/xgr-network/xgr-node/command/server"
+	"github.com/xgr-network/xgr-node/command/stats"
 	"github.com/xgr-network/xgr-node/command/status"
 	"github.com/xgr-network/xgr-node/command/txpool"
 	"github.com/xgr-network/xgr-node/command/version"
@@ -61,6 +65,10 @@ func (rc *RootCommand) registerSubCommands() {
 		polybft.GetCommand(),
 		bridge.GetCommand(),
 		regenesis.GetCommand(),
+		registry.GetCommand(),
+		engine.GetCommand(),
+		stats.GetCommand(),
+		preview.GetCommand(),
 	)
 }
 
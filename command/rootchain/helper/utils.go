@@ -160,6 +160,25 @@ func GetECDSAKey(privateKey, accountDir, accountConfig string) (ethgo.Key, error
 // and retrieves validator info for given address
 func GetValidatorInfo(validatorAddr ethgo.Address, supernetManagerAddr, stakeManagerAddr types.Address,
 	chainID int64, txRelayer txrelayer.TxRelayer) (*polybft.ValidatorInfo, error) {
+	validatorInfo, err := GetValidatorStatus(validatorAddr, supernetManagerAddr, txRelayer)
+	if err != nil {
+		return nil, err
+	}
+
+	stake, err := GetValidatorStake(validatorAddr, stakeManagerAddr, chainID, txRelayer)
+	if err != nil {
+		return nil, err
+	}
+
+	validatorInfo.Stake = stake
+
+	return validatorInfo, nil
+}
+
+// GetValidatorStatus queries SupernetManager smart contract on root
+// and retrieves whether the validator is whitelisted and active, the stake is not set
+func GetValidatorStatus(validatorAddr ethgo.Address, supernetManagerAddr types.Address,
+	txRelayer txrelayer.TxRelayer) (*polybft.ValidatorInfo, error) {
 	caller := ethgo.Address(contracts.SystemCaller)
 	getValidatorMethod := contractsapi.CustomSupernetManager.Abi.GetMethod("getValidator")
 
@@ -194,35 +213,33 @@ func GetValidatorInfo(validatorAddr ethgo.Address, supernetManagerAddr, stakeMan
 	}
 
 	//nolint:forcetypeassert
-	validatorInfo := &polybft.ValidatorInfo{
+	return &polybft.ValidatorInfo{
 		Address:       validatorAddr,
 		IsActive:      innerMap["isActive"].(bool),
 		IsWhitelisted: innerMap["isWhitelisted"].(bool),
-	}
+	}, nil
+}
 
+// GetValidatorStake queries StakeManager smart contract on root
+// and retrieves the stake of the validator on the given child chain
+func GetValidatorStake(validatorAddr ethgo.Address, stakeManagerAddr types.Address,
+	chainID int64, txRelayer txrelayer.TxRelayer) (*big.Int, error) {
 	stakeOfFn := &contractsapi.StakeOfStakeManagerFn{
 		ID:        new(big.Int).SetInt64(chainID),
 		Validator: types.Address(validatorAddr),
 	}
 
-	encode, err = stakeOfFn.EncodeAbi()
+	encode, err := stakeOfFn.EncodeAbi()
 	if err != nil {
 		return nil, err
 	}
 
-	response, err = txRelayer.Call(caller, ethgo.Address(stakeManagerAddr), encode)
+	response, err := txRelayer.Call(ethgo.Address(contracts.SystemCaller), ethgo.Address(stakeManagerAddr), encode)
 	if err != nil {
 		return nil, err
 	}
 
-	stake, err := common.ParseUint256orHex(&response)
-	if err != nil {
-		return nil, err
-	}
-
-	validatorInfo.Stake = stake
-
-	return validatorInfo, nil
+	return common.ParseUint256orHex(&response)
 }
 
 // CreateMintTxn encodes parameters for mint function on rootchain token contract
@@ -2,12 +2,22 @@ package validators
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/xgr-network/xgr-node/command/helper"
 	sidechainHelper "github.com/xgr-network/xgr-node/command/sidechain"
 )
 
+const (
+	timeoutFlag = "timeout"
+
+	defaultTimeout = 30 * time.Second
+)
+
+var errInvalidTimeout = errors.New("timeout must be positive")
+
 type validatorInfoParams struct {
 	accountDir             string
 	accountConfig          string
@@ -15,9 +25,14 @@ type validatorInfoParams struct {
 	supernetManagerAddress string
 	stakeManagerAddress    string
 	chainID                int64
+	timeout                time.Duration
 }
 
 func (v *validatorInfoParams) validateFlags() error {
+	if v.timeout <= 0 {
+		return errInvalidTimeout
+	}
+
 	if _, err := helper.ParseJSONRPCAddress(v.jsonRPC); err != nil {
 		return fmt.Errorf("failed to parse json rpc address. Error: %w", err)
 	}
@@ -30,6 +45,11 @@ type validatorsInfoResult struct {
 	Stake       uint64 `json:"stake"`
 	Active      bool   `json:"active"`
 	Whitelisted bool   `json:"whitelisted"`
+
+	// StakeError and StatusError are set if the stake or the whitelist and active status
+	// couldn't be queried, the other part of the info is still shown
+	StakeError  string `json:"stakeError,omitempty"`
+	StatusError string `json:"statusError,omitempty"`
 }
 
 func (vr validatorsInfoResult) GetOutput() string {
@@ -43,6 +63,15 @@ func (vr validatorsInfoResult) GetOutput() string {
 	vals[2] = fmt.Sprintf("Is Whitelisted|%v", vr.Whitelisted)
 	vals[3] = fmt.Sprintf("Is Active|%v", vr.Active)
 
+	if vr.StakeError != "" {
+		vals[1] = fmt.Sprintf("Stake|unavailable (%s)", vr.StakeError)
+	}
+
+	if vr.StatusError != "" {
+		vals[2] = fmt.Sprintf("Is Whitelisted|unavailable (%s)", vr.StatusError)
+		vals[3] = fmt.Sprintf("Is Active|unavailable (%s)", vr.StatusError)
+	}
+
 	buffer.WriteString(helper.FormatKV(vals))
 	buffer.WriteString("\n")
 
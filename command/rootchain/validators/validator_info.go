@@ -1,18 +1,34 @@
 package validators
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math/big"
+	"time"
 
+	"github.com/sethvargo/go-retry"
 	"github.com/spf13/cobra"
+	"github.com/umbracle/ethgo"
 	"github.com/xgr-network/xgr-node/command"
 	"github.com/xgr-network/xgr-node/command/helper"
 	"github.com/xgr-network/xgr-node/command/polybftsecrets"
 	rootHelper "github.com/xgr-network/xgr-node/command/rootchain/helper"
 	sidechainHelper "github.com/xgr-network/xgr-node/command/sidechain"
+	"github.com/xgr-network/xgr-node/consensus/polybft"
 	"github.com/xgr-network/xgr-node/txrelayer"
 	"github.com/xgr-network/xgr-node/types"
 )
 
+const (
+	// queryAttempts is the number of attempts of each rootchain query
+	queryAttempts = 5
+
+	// queryBackoff is the delay before the first retry of a failed rootchain query,
+	// it doubles with every further retry
+	queryBackoff = 500 * time.Millisecond
+)
+
 var (
 	params validatorInfoParams
 )
@@ -67,6 +83,13 @@ func setFlags(cmd *cobra.Command) {
 		polybftsecrets.ChainIDFlagDesc,
 	)
 
+	cmd.Flags().DurationVar(
+		&params.timeout,
+		timeoutFlag,
+		defaultTimeout,
+		"timeout of the rootchain queries, including their retries",
+	)
+
 	cmd.MarkFlagsMutuallyExclusive(polybftsecrets.AccountDirFlag, polybftsecrets.AccountConfigFlag)
 }
 
@@ -90,22 +113,96 @@ func runCommand(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
-	validatorAddr := validatorAccount.Ecdsa.Address()
-	supernetManagerAddr := types.StringToAddress(params.supernetManagerAddress)
-	stakeManagerAddr := types.StringToAddress(params.stakeManagerAddress)
+	ctx, cancel := context.WithTimeout(cmd.Context(), params.timeout)
+	defer cancel()
 
-	validatorInfo, err := rootHelper.GetValidatorInfo(validatorAddr,
-		supernetManagerAddr, stakeManagerAddr, params.chainID, txRelayer)
+	result, err := getValidatorInfo(ctx, validatorAccount.Ecdsa.Address(),
+		types.StringToAddress(params.supernetManagerAddress),
+		types.StringToAddress(params.stakeManagerAddress),
+		params.chainID, txRelayer, newQueryBackoff(queryBackoff))
 	if err != nil {
-		return fmt.Errorf("failed to get validator info for %s: %w", validatorAddr, err)
+		return err
 	}
 
-	outputter.WriteCommandResult(&validatorsInfoResult{
-		Address:     validatorInfo.Address.String(),
-		Stake:       validatorInfo.Stake.Uint64(),
-		Active:      validatorInfo.IsActive,
-		Whitelisted: validatorInfo.IsWhitelisted,
-	})
+	outputter.WriteCommandResult(result)
 
 	return nil
 }
+
+// getValidatorInfo queries the whitelist and active status and the stake of the validator.
+// If only one of the queries fails, the result has the data of the other one and the error
+func getValidatorInfo(ctx context.Context, validatorAddr ethgo.Address,
+	supernetManagerAddr, stakeManagerAddr types.Address, chainID int64,
+	txRelayer txrelayer.TxRelayer, backoff func() retry.Backoff) (*validatorsInfoResult, error) {
+	result := &validatorsInfoResult{Address: validatorAddr.String()}
+
+	status, statusErr := queryWithRetry(ctx, backoff(), func() (*polybft.ValidatorInfo, error) {
+		return rootHelper.GetValidatorStatus(validatorAddr, supernetManagerAddr, txRelayer)
+	})
+	if statusErr != nil {
+		result.StatusError = statusErr.Error()
+	} else {
+		result.Active = status.IsActive
+		result.Whitelisted = status.IsWhitelisted
+	}
+
+	stake, stakeErr := queryWithRetry(ctx, backoff(), func() (*big.Int, error) {
+		return rootHelper.GetValidatorStake(validatorAddr, stakeManagerAddr, chainID, txRelayer)
+	})
+	if stakeErr != nil {
+		result.StakeError = stakeErr.Error()
+	} else {
+		result.Stake = stake.Uint64()
+	}
+
+	if statusErr != nil && stakeErr != nil {
+		return nil, fmt.Errorf("failed to get validator info for %s: %w", validatorAddr,
+			errors.Join(statusErr, stakeErr))
+	}
+
+	return result, nil
+}
+
+// newQueryBackoff returns the constructor of the backoff of a rootchain query,
+// every query starts its retries anew
+func newQueryBackoff(base time.Duration) func() retry.Backoff {
+	return func() retry.Backoff {
+		return retry.WithMaxRetries(queryAttempts-1, retry.NewExponential(base))
+	}
+}
+
+// queryWithRetry runs the query until it succeeds or the backoff stops the retries.
+// It gives up once the context is done, even if the query itself still hangs
+func queryWithRetry[T any](ctx context.Context, backoff retry.Backoff, query func() (T, error)) (T, error) {
+	type response struct {
+		value T
+		err   error
+	}
+
+	var result T
+
+	err := retry.Do(ctx, backoff, func(ctx context.Context) error {
+		// buffered, so the query can finish after the context is done
+		responseCh := make(chan response, 1)
+
+		go func() {
+			value, err := query()
+			responseCh <- response{value: value, err: err}
+		}()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case resp := <-responseCh:
+			if resp.err != nil {
+				return retry.RetryableError(resp.err)
+			}
+
+			result = resp.value
+
+			return nil
+		}
+	})
+
+	return result, err
+}
@@ -0,0 +1,122 @@
+package validators
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/jsonrpc"
+
+	"github.com/xgr-network/xgr-node/txrelayer"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+var _ txrelayer.TxRelayer = (*dummyTxRelayer)(nil)
+
+type dummyTxRelayer struct {
+	mock.Mock
+}
+
+func (d *dummyTxRelayer) Call(from ethgo.Address, to ethgo.Address, input []byte) (string, error) {
+	args := d.Called(from, to, input)
+
+	return args.String(0), args.Error(1)
+}
+
+func (d *dummyTxRelayer) SendTransaction(txn *ethgo.Transaction, key ethgo.Key) (*ethgo.Receipt, error) {
+	args := d.Called(txn, key)
+
+	return args.Get(0).(*ethgo.Receipt), args.Error(1) //nolint:forcetypeassert
+}
+
+func (d *dummyTxRelayer) SendTransactionLocal(txn *ethgo.Transaction) (*ethgo.Receipt, error) {
+	args := d.Called(txn)
+
+	return args.Get(0).(*ethgo.Receipt), args.Error(1) //nolint:forcetypeassert
+}
+
+func (d *dummyTxRelayer) Client() *jsonrpc.Client {
+	return nil
+}
+
+var (
+	supernetManagerAddr = types.StringToAddress("0x1")
+	stakeManagerAddr    = types.StringToAddress("0x2")
+	validatorAddr       = ethgo.Address(types.StringToAddress("0x3"))
+
+	// getValidator output of a whitelisted and active validator:
+	// blsKey (4 words), stake, isWhitelisted, isActive
+	whitelistedValidatorResponse = "0x" + strings.Repeat("0", 5*64) +
+		strings.Repeat("0", 63) + "1" + strings.Repeat("0", 63) + "1"
+
+	errConnectionRefused = errors.New("connection refused")
+)
+
+func TestGetValidatorInfo_RetriesTransientFailure(t *testing.T) {
+	t.Parallel()
+
+	txRelayer := &dummyTxRelayer{}
+	txRelayer.On("Call", mock.Anything, ethgo.Address(supernetManagerAddr), mock.Anything).
+		Return("", errConnectionRefused).Once()
+	txRelayer.On("Call", mock.Anything, ethgo.Address(supernetManagerAddr), mock.Anything).
+		Return(whitelistedValidatorResponse, nil).Once()
+	txRelayer.On("Call", mock.Anything, ethgo.Address(stakeManagerAddr), mock.Anything).
+		Return("0x64", nil).Once()
+
+	result, err := getValidatorInfo(context.Background(), validatorAddr, supernetManagerAddr,
+		stakeManagerAddr, 100, txRelayer, newQueryBackoff(time.Millisecond))
+	require.NoError(t, err)
+	require.Equal(t, &validatorsInfoResult{
+		Address:     validatorAddr.String(),
+		Stake:       100,
+		Active:      true,
+		Whitelisted: true,
+	}, result)
+
+	txRelayer.AssertExpectations(t)
+}
+
+func TestGetValidatorInfo_PartialData(t *testing.T) {
+	t.Parallel()
+
+	txRelayer := &dummyTxRelayer{}
+	txRelayer.On("Call", mock.Anything, ethgo.Address(supernetManagerAddr), mock.Anything).
+		Return("", errConnectionRefused).Times(queryAttempts)
+	txRelayer.On("Call", mock.Anything, ethgo.Address(stakeManagerAddr), mock.Anything).
+		Return("0x64", nil).Once()
+
+	// the stake is shown even though the status query keeps failing
+	result, err := getValidatorInfo(context.Background(), validatorAddr, supernetManagerAddr,
+		stakeManagerAddr, 100, txRelayer, newQueryBackoff(time.Millisecond))
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), result.Stake)
+	require.Equal(t, errConnectionRefused.Error(), result.StatusError)
+	require.Empty(t, result.StakeError)
+	require.Contains(t, result.GetOutput(), "unavailable")
+
+	txRelayer.AssertExpectations(t)
+}
+
+func TestGetValidatorInfo_Timeout(t *testing.T) {
+	t.Parallel()
+
+	unresponsive := make(chan struct{})
+	t.Cleanup(func() { close(unresponsive) })
+
+	txRelayer := &dummyTxRelayer{}
+	txRelayer.On("Call", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(mock.Arguments) { <-unresponsive }).
+		Return("", errConnectionRefused)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := getValidatorInfo(ctx, validatorAddr, supernetManagerAddr,
+		stakeManagerAddr, 100, txRelayer, newQueryBackoff(time.Millisecond))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
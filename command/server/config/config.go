@@ -8,7 +8,11 @@ import (
 	"time"
 
 	"github.com/hashicorp/hcl"
+	"github.com/xgr-network/xgr-node/blockchain/storage"
+	"github.com/xgr-network/xgr-node/consensus/polybft"
+	"github.com/xgr-network/xgr-node/gasprice"
 	"github.com/xgr-network/xgr-node/network"
+	"github.com/xgr-network/xgr-node/syncer"
 	"gopkg.in/yaml.v3"
 )
 
@@ -17,6 +21,7 @@ type Config struct {
 	GenesisPath              string     `json:"chain_config" yaml:"chain_config"`
 	SecretsConfigPath        string     `json:"secrets_config" yaml:"secrets_config"`
 	DataDir                  string     `json:"data_dir" yaml:"data_dir"`
+	DBEngine                 string     `json:"db_engine" yaml:"db_engine"`
 	BlockGasTarget           string     `json:"block_gas_target" yaml:"block_gas_target"`
 	GRPCAddr                 string     `json:"grpc_addr" yaml:"grpc_addr"`
 	JSONRPCAddr              string     `json:"jsonrpc_addr" yaml:"jsonrpc_addr"`
@@ -26,15 +31,30 @@ type Config struct {
 	TxPool                   *TxPool    `json:"tx_pool" yaml:"tx_pool"`
 	LogLevel                 string     `json:"log_level" yaml:"log_level"`
 	RestoreFile              string     `json:"restore_file" yaml:"restore_file"`
+	ImportBlocksFile         string     `json:"import_blocks_file" yaml:"import_blocks_file"`
+	ImportBlocksFast         bool       `json:"import_blocks_fast" yaml:"import_blocks_fast"`
 	Headers                  *Headers   `json:"headers" yaml:"headers"`
 	LogFilePath              string     `json:"log_to" yaml:"log_to"`
 	JSONRPCBatchRequestLimit uint64     `json:"json_rpc_batch_request_limit" yaml:"json_rpc_batch_request_limit"`
 	JSONRPCBlockRangeLimit   uint64     `json:"json_rpc_block_range_limit" yaml:"json_rpc_block_range_limit"`
+	JSONRPCEnableSetHead     bool       `json:"json_rpc_enable_set_head" yaml:"json_rpc_enable_set_head"`
 	JSONLogFormat            bool       `json:"json_log_format" yaml:"json_log_format"`
 	CorsAllowedOrigins       []string   `json:"cors_allowed_origins" yaml:"cors_allowed_origins"`
 
 	Relayer               bool   `json:"relayer" yaml:"relayer"`
 	NumBlockConfirmations uint64 `json:"num_block_confirmations" yaml:"num_block_confirmations"`
+	ShadowFork            bool   `json:"shadow_fork" yaml:"shadow_fork"`
+	RewardAddress         string `json:"reward_address" yaml:"reward_address"`
+
+	EnginePriorityGasShare uint64 `json:"engine_priority_gas_share" yaml:"engine_priority_gas_share"`
+	MaxReorgDepth          uint64 `json:"max_reorg_depth" yaml:"max_reorg_depth"`
+	StateRetention         uint64 `json:"state_retention" yaml:"state_retention"`
+
+	FutureBlocksSize        uint64 `json:"future_blocks_size" yaml:"future_blocks_size"`
+	FutureBlocksMaxDistance uint64 `json:"future_blocks_max_distance" yaml:"future_blocks_max_distance"`
+
+	GasPriceOracleBlocks     uint64 `json:"gas_price_oracle_blocks" yaml:"gas_price_oracle_blocks"`
+	GasPriceOraclePercentile uint64 `json:"gas_price_oracle_percentile" yaml:"gas_price_oracle_percentile"`
 
 	ConcurrentRequestsDebug uint64 `json:"concurrent_requests_debug" yaml:"concurrent_requests_debug"`
 	WebSocketReadLimit      uint64 `json:"web_socket_read_limit" yaml:"web_socket_read_limit"`
@@ -49,20 +69,24 @@ type Telemetry struct {
 
 // Network defines the network configuration params
 type Network struct {
-	NoDiscover       bool   `json:"no_discover" yaml:"no_discover"`
-	Libp2pAddr       string `json:"libp2p_addr" yaml:"libp2p_addr"`
-	NatAddr          string `json:"nat_addr" yaml:"nat_addr"`
-	DNSAddr          string `json:"dns_addr" yaml:"dns_addr"`
-	MaxPeers         int64  `json:"max_peers,omitempty" yaml:"max_peers,omitempty"`
-	MaxOutboundPeers int64  `json:"max_outbound_peers,omitempty" yaml:"max_outbound_peers,omitempty"`
-	MaxInboundPeers  int64  `json:"max_inbound_peers,omitempty" yaml:"max_inbound_peers,omitempty"`
+	NoDiscover       bool     `json:"no_discover" yaml:"no_discover"`
+	Libp2pAddr       string   `json:"libp2p_addr" yaml:"libp2p_addr"`
+	NatAddr          string   `json:"nat_addr" yaml:"nat_addr"`
+	DNSAddr          string   `json:"dns_addr" yaml:"dns_addr"`
+	MaxPeers         int64    `json:"max_peers,omitempty" yaml:"max_peers,omitempty"`
+	MaxOutboundPeers int64    `json:"max_outbound_peers,omitempty" yaml:"max_outbound_peers,omitempty"`
+	MaxInboundPeers  int64    `json:"max_inbound_peers,omitempty" yaml:"max_inbound_peers,omitempty"`
+	StaticPeers      []string `json:"static_peers,omitempty" yaml:"static_peers,omitempty"`
 }
 
 // TxPool defines the TxPool configuration params
 type TxPool struct {
-	PriceLimit         uint64 `json:"price_limit" yaml:"price_limit"`
-	MaxSlots           uint64 `json:"max_slots" yaml:"max_slots"`
-	MaxAccountEnqueued uint64 `json:"max_account_enqueued" yaml:"max_account_enqueued"`
+	PriceLimit            uint64 `json:"price_limit" yaml:"price_limit"`
+	MaxSlots              uint64 `json:"max_slots" yaml:"max_slots"`
+	MaxAccountEnqueued    uint64 `json:"max_account_enqueued" yaml:"max_account_enqueued"`
+	MaxAccountTxs         uint64 `json:"max_account_txs" yaml:"max_account_txs"`
+	PriceBump             uint64 `json:"price_bump" yaml:"price_bump"`
+	UnderpricedTxLifetime uint64 `json:"underpriced_tx_lifetime" yaml:"underpriced_tx_lifetime"`
 }
 
 // Headers defines the HTTP response headers required to enable CORS.
@@ -86,6 +110,10 @@ const (
 	// on ethereum epoch lasts for 32 blocks. more details: https://www.alchemy.com/overviews/ethereum-commitment-levels
 	DefaultNumBlockConfirmations uint64 = 64
 
+	// DefaultMaxReorgDepth maximum number of canonical blocks a reorg may replace.
+	// It matches the polybft checkpoint interval, so a checkpointed block is never reorged out.
+	DefaultMaxReorgDepth = polybft.DefaultCheckpointsOffset
+
 	// DefaultConcurrentRequestsDebug specifies max number of allowed concurrent requests for debug endpoints
 	DefaultConcurrentRequestsDebug uint64 = 32
 
@@ -106,6 +134,7 @@ func DefaultConfig() *Config {
 	return &Config{
 		GenesisPath:    "./genesis.json",
 		DataDir:        "",
+		DBEngine:       storage.EngineLevelDB,
 		BlockGasTarget: "0x0", // Special value signaling the parent gas limit should be applied
 		Network: &Network{
 			NoDiscover:       defaultNetworkConfig.NoDiscover,
@@ -120,9 +149,12 @@ func DefaultConfig() *Config {
 		Telemetry:  &Telemetry{},
 		ShouldSeal: true,
 		TxPool: &TxPool{
-			PriceLimit:         0,
-			MaxSlots:           4096,
-			MaxAccountEnqueued: 128,
+			PriceLimit:            0,
+			MaxSlots:              4096,
+			MaxAccountEnqueued:    128,
+			MaxAccountTxs:         1024,
+			PriceBump:             10,
+			UnderpricedTxLifetime: 64,
 		},
 		LogLevel:    "INFO",
 		RestoreFile: "",
@@ -133,6 +165,12 @@ func DefaultConfig() *Config {
 		JSONRPCBatchRequestLimit: DefaultJSONRPCBatchRequestLimit,
 		JSONRPCBlockRangeLimit:   DefaultJSONRPCBlockRangeLimit,
 		Relayer:                  false,
+		ShadowFork:               false,
+		MaxReorgDepth:            DefaultMaxReorgDepth,
+		FutureBlocksSize:         syncer.DefaultFutureBlocksSize,
+		FutureBlocksMaxDistance:  syncer.DefaultFutureBlocksMaxDistance,
+		GasPriceOracleBlocks:     gasprice.DefaultGasHelperConfig.NumOfBlocksToCheck,
+		GasPriceOraclePercentile: gasprice.DefaultGasHelperConfig.PricePercentile,
 		NumBlockConfirmations:    DefaultNumBlockConfirmations,
 		ConcurrentRequestsDebug:  DefaultConcurrentRequestsDebug,
 		WebSocketReadLimit:       DefaultWebSocketReadLimit,
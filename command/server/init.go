@@ -11,15 +11,22 @@ import (
 	helperCommon "github.com/xgr-network/xgr-node/helper/common"
 	"github.com/xgr-network/xgr-node/network/common"
 
+	"github.com/xgr-network/xgr-node/blockchain/storage"
 	"github.com/xgr-network/xgr-node/chain"
 	"github.com/xgr-network/xgr-node/command/helper"
 	"github.com/xgr-network/xgr-node/network"
 	"github.com/xgr-network/xgr-node/secrets"
 	"github.com/xgr-network/xgr-node/server"
+	"github.com/xgr-network/xgr-node/types"
 )
 
 var (
-	errDataDirectoryUndefined = errors.New("data directory not defined")
+	errDataDirectoryUndefined     = errors.New("data directory not defined")
+	errInvalidEnginePriorityShare = errors.New("engine priority gas share must be between 0 and 100")
+	errInvalidDBEngine            = fmt.Errorf("db engine must be %s or %s", storage.EngineLevelDB, storage.EnginePebble)
+	errStateRetentionTooLow       = fmt.Errorf("state retention must be 0 or at least %d blocks", server.MinStateRetention)
+	errInvalidGasPriceOracle      = errors.New(
+		"gas price oracle must sample at least one block and use a percentile between 0 and 100")
 )
 
 func (p *serverParams) initConfigFromFile() error {
@@ -56,11 +63,46 @@ func (p *serverParams) initRawParams() error {
 	p.initPeerLimits()
 	p.initLogFileLocation()
 
-	p.relayer = p.rawConfig.Relayer
+	// a shadow fork never takes part in the network beyond syncing
+	p.relayer = p.rawConfig.Relayer && !p.rawConfig.ShadowFork
+
+	if err := p.initRewardAddress(); err != nil {
+		return err
+	}
+
+	if p.rawConfig.EnginePriorityGasShare > 100 {
+		return errInvalidEnginePriorityShare
+	}
+
+	if p.rawConfig.DBEngine != storage.EngineLevelDB && p.rawConfig.DBEngine != storage.EnginePebble {
+		return errInvalidDBEngine
+	}
+
+	if p.rawConfig.StateRetention != 0 && p.rawConfig.StateRetention < server.MinStateRetention {
+		return errStateRetentionTooLow
+	}
+
+	if p.rawConfig.GasPriceOracleBlocks == 0 || p.rawConfig.GasPriceOraclePercentile > 100 {
+		return errInvalidGasPriceOracle
+	}
 
 	return p.initAddresses()
 }
 
+func (p *serverParams) initRewardAddress() error {
+	if p.rawConfig.RewardAddress == "" {
+		return nil
+	}
+
+	if err := types.IsValidAddress(p.rawConfig.RewardAddress); err != nil {
+		return fmt.Errorf("invalid reward address: %w", err)
+	}
+
+	p.rewardAddress = types.StringToAddress(p.rawConfig.RewardAddress)
+
+	return nil
+}
+
 func (p *serverParams) initDataDirLocation() error {
 	if p.rawConfig.DataDir == "" {
 		return errDataDirectoryUndefined
@@ -138,6 +180,7 @@ func (p *serverParams) initDevConsensusConfig() {
 	p.genesisConfig.Params.Engine = map[string]interface{}{
 		string(server.DevConsensus): map[string]interface{}{
 			"interval": p.devInterval,
+			"instant":  p.devInstant,
 		},
 	}
 }
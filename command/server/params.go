@@ -11,12 +11,14 @@ import (
 	"github.com/xgr-network/xgr-node/network"
 	"github.com/xgr-network/xgr-node/secrets"
 	"github.com/xgr-network/xgr-node/server"
+	"github.com/xgr-network/xgr-node/types"
 )
 
 const (
 	configFlag                   = "config"
 	genesisPathFlag              = "chain"
 	dataDirFlag                  = "data-dir"
+	dbEngineFlag                 = "db.engine"
 	libp2pAddressFlag            = "libp2p"
 	prometheusAddressFlag        = "prometheus"
 	natFlag                      = "nat"
@@ -25,21 +27,40 @@ const (
 	maxPeersFlag                 = "max-peers"
 	maxInboundPeersFlag          = "max-inbound-peers"
 	maxOutboundPeersFlag         = "max-outbound-peers"
+	staticPeerFlag               = "static-peer"
 	priceLimitFlag               = "price-limit"
 	jsonRPCBatchRequestLimitFlag = "json-rpc-batch-request-limit"
 	jsonRPCBlockRangeLimitFlag   = "json-rpc-block-range-limit"
+	jsonRPCEnableSetHeadFlag     = "json-rpc-enable-set-head"
 	maxSlotsFlag                 = "max-slots"
 	maxEnqueuedFlag              = "max-enqueued"
+	maxAccountTxsFlag            = "max-account-txs"
+	priceBumpFlag                = "price-bump"
+	underpricedTxLifetimeFlag    = "underpriced-tx-lifetime"
 	blockGasTargetFlag           = "block-gas-target"
 	secretsConfigFlag            = "secrets-config"
 	restoreFlag                  = "restore"
+	importBlocksFlag             = "import-blocks"
+	importBlocksFastFlag         = "import-blocks.fast"
 	devIntervalFlag              = "dev-interval"
+	devInstantFlag               = "dev-instant"
 	devFlag                      = "dev"
 	corsOriginFlag               = "access-control-allow-origins"
 	logFileLocationFlag          = "log-to"
 
-	relayerFlag               = "relayer"
-	numBlockConfirmationsFlag = "num-block-confirmations"
+	relayerFlag                = "relayer"
+	numBlockConfirmationsFlag  = "num-block-confirmations"
+	shadowForkFlag             = "shadow-fork"
+	rewardAddressFlag          = "reward-address"
+	enginePriorityGasShareFlag = "engine-priority-gas-share"
+	maxReorgDepthFlag          = "max-reorg-depth"
+	stateRetentionFlag         = "state-retention"
+
+	futureBlocksSizeFlag        = "future-blocks-size"
+	futureBlocksMaxDistanceFlag = "future-blocks-max-distance"
+
+	gasPriceOracleBlocksFlag     = "gas-price-oracle-blocks"
+	gasPriceOraclePercentileFlag = "gas-price-oracle-percentile"
 
 	concurrentRequestsDebugFlag = "concurrent-requests-debug"
 	webSocketReadLimitFlag      = "websocket-read-limit"
@@ -84,6 +105,7 @@ type serverParams struct {
 
 	blockGasTarget uint64
 	devInterval    uint64
+	devInstant     bool
 	isDevMode      bool
 
 	ibftBaseTimeoutLegacy uint64
@@ -93,7 +115,8 @@ type serverParams struct {
 
 	logFileLocation string
 
-	relayer bool
+	relayer       bool
+	rewardAddress types.Address
 }
 
 func (p *serverParams) isMaxPeersSet() bool {
@@ -137,6 +160,14 @@ func (p *serverParams) getRestoreFilePath() *string {
 	return nil
 }
 
+func (p *serverParams) getImportBlocksFilePath() *string {
+	if p.rawConfig.ImportBlocksFile != "" {
+		return &p.rawConfig.ImportBlocksFile
+	}
+
+	return nil
+}
+
 func (p *serverParams) setRawGRPCAddress(grpcAddress string) {
 	p.rawConfig.GRPCAddr = grpcAddress
 }
@@ -159,6 +190,7 @@ func (p *serverParams) generateConfig() *server.Config {
 			BlockRangeLimit:          p.rawConfig.JSONRPCBlockRangeLimit,
 			ConcurrentRequestsDebug:  p.rawConfig.ConcurrentRequestsDebug,
 			WebSocketReadLimit:       p.rawConfig.WebSocketReadLimit,
+			EnableSetHead:            p.rawConfig.JSONRPCEnableSetHead,
 		},
 		GRPCAddr:   p.grpcAddress,
 		LibP2PAddr: p.libp2pAddress,
@@ -174,21 +206,40 @@ func (p *serverParams) generateConfig() *server.Config {
 			MaxPeers:         p.rawConfig.Network.MaxPeers,
 			MaxInboundPeers:  p.rawConfig.Network.MaxInboundPeers,
 			MaxOutboundPeers: p.rawConfig.Network.MaxOutboundPeers,
+			StaticPeers:      p.rawConfig.Network.StaticPeers,
 			Chain:            p.genesisConfig,
 		},
-		DataDir:            p.rawConfig.DataDir,
-		Seal:               p.rawConfig.ShouldSeal,
-		PriceLimit:         p.rawConfig.TxPool.PriceLimit,
-		MaxSlots:           p.rawConfig.TxPool.MaxSlots,
-		MaxAccountEnqueued: p.rawConfig.TxPool.MaxAccountEnqueued,
-		SecretsManager:     p.secretsConfig,
-		RestoreFile:        p.getRestoreFilePath(),
-		LogLevel:           hclog.LevelFromString(p.rawConfig.LogLevel),
-		JSONLogFormat:      p.rawConfig.JSONLogFormat,
-		LogFilePath:        p.logFileLocation,
+		DataDir:               p.rawConfig.DataDir,
+		DBEngine:              p.rawConfig.DBEngine,
+		Seal:                  p.rawConfig.ShouldSeal && !p.rawConfig.ShadowFork,
+		PriceLimit:            p.rawConfig.TxPool.PriceLimit,
+		MaxSlots:              p.rawConfig.TxPool.MaxSlots,
+		MaxAccountEnqueued:    p.rawConfig.TxPool.MaxAccountEnqueued,
+		MaxAccountTxs:         p.rawConfig.TxPool.MaxAccountTxs,
+		PriceBump:             p.rawConfig.TxPool.PriceBump,
+		UnderpricedTxLifetime: p.rawConfig.TxPool.UnderpricedTxLifetime,
+		SecretsManager:        p.secretsConfig,
+		RestoreFile:           p.getRestoreFilePath(),
+		ImportBlocksFile:      p.getImportBlocksFilePath(),
+		ImportBlocksFast:      p.rawConfig.ImportBlocksFast,
+		LogLevel:              hclog.LevelFromString(p.rawConfig.LogLevel),
+		JSONLogFormat:         p.rawConfig.JSONLogFormat,
+		LogFilePath:           p.logFileLocation,
 
 		Relayer:               p.relayer,
 		NumBlockConfirmations: p.rawConfig.NumBlockConfirmations,
+		ShadowFork:            p.rawConfig.ShadowFork,
+		RewardAddress:         p.rewardAddress,
 		MetricsInterval:       p.rawConfig.MetricsInterval,
+
+		EnginePriorityGasShare: p.rawConfig.EnginePriorityGasShare,
+		MaxReorgDepth:          p.rawConfig.MaxReorgDepth,
+		StateRetention:         p.rawConfig.StateRetention,
+
+		FutureBlocksSize:        p.rawConfig.FutureBlocksSize,
+		FutureBlocksMaxDistance: p.rawConfig.FutureBlocksMaxDistance,
+
+		GasPriceOracleBlocks:     p.rawConfig.GasPriceOracleBlocks,
+		GasPriceOraclePercentile: p.rawConfig.GasPriceOraclePercentile,
 	}
 }
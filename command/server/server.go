@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"github.com/xgr-network/xgr-node/blockchain/storage"
 	"github.com/xgr-network/xgr-node/command"
 	"github.com/xgr-network/xgr-node/command/helper"
 	"github.com/xgr-network/xgr-node/command/server/config"
@@ -67,6 +68,15 @@ func setFlags(cmd *cobra.Command) {
 		"the data directory used for storing XGRChain client data",
 	)
 
+	cmd.Flags().StringVar(
+		&params.rawConfig.DBEngine,
+		dbEngineFlag,
+		defaultConfig.DBEngine,
+		fmt.Sprintf("the database engine of the blockchain storage (%s or %s), "+
+			"a data directory can only be opened with the engine which created it",
+			storage.EngineLevelDB, storage.EnginePebble),
+	)
+
 	cmd.Flags().StringVar(
 		&params.rawConfig.Network.Libp2pAddr,
 		libp2pAddressFlag,
@@ -118,6 +128,21 @@ func setFlags(cmd *cobra.Command) {
 		"the path to the archive blockchain data to restore on initialization",
 	)
 
+	cmd.Flags().StringVar(
+		&params.rawConfig.ImportBlocksFile,
+		importBlocksFlag,
+		"",
+		"the path to a blocks file written by 'backup export-blocks' to import on initialization",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.ImportBlocksFast,
+		importBlocksFastFlag,
+		false,
+		"import the blocks file trusting its receipts: the blocks are not executed, "+
+			"only the transactions and receipts roots are checked and the indices are rebuilt",
+	)
+
 	cmd.Flags().BoolVar(
 		&params.rawConfig.ShouldSeal,
 		sealFlag,
@@ -161,6 +186,13 @@ func setFlags(cmd *cobra.Command) {
 	cmd.Flag(maxOutboundPeersFlag).DefValue = fmt.Sprintf("%d", defaultConfig.Network.MaxOutboundPeers)
 	cmd.MarkFlagsMutuallyExclusive(maxPeersFlag, maxOutboundPeersFlag)
 
+	cmd.Flags().StringArrayVar(
+		&params.rawConfig.Network.StaticPeers,
+		staticPeerFlag,
+		[]string{},
+		"the libp2p address of a peer the client keeps connected to, reconnecting after disconnects",
+	)
+
 	cmd.Flags().Uint64Var(
 		&params.rawConfig.TxPool.PriceLimit,
 		priceLimitFlag,
@@ -185,6 +217,28 @@ func setFlags(cmd *cobra.Command) {
 		"maximum number of enqueued transactions per account",
 	)
 
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TxPool.MaxAccountTxs,
+		maxAccountTxsFlag,
+		defaultConfig.TxPool.MaxAccountTxs,
+		"maximum number of transactions (promoted and enqueued) per account, 0 means no limit",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TxPool.PriceBump,
+		priceBumpFlag,
+		defaultConfig.TxPool.PriceBump,
+		"minimum fee increase (in percent) required to replace a pending transaction with the same nonce",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TxPool.UnderpricedTxLifetime,
+		underpricedTxLifetimeFlag,
+		defaultConfig.TxPool.UnderpricedTxLifetime,
+		"number of blocks a dynamic fee transaction with a fee cap below the base fee waits "+
+			"for the base fee to drop before being dropped, 0 rejects such transactions",
+	)
+
 	cmd.Flags().StringArrayVar(
 		&params.rawConfig.CorsAllowedOrigins,
 		corsOriginFlag,
@@ -207,6 +261,13 @@ func setFlags(cmd *cobra.Command) {
 			"that consider fromBlock/toBlock values (e.g. eth_getLogs), value of 0 disables it",
 	)
 
+	cmd.Flags().BoolVar(
+		&params.rawConfig.JSONRPCEnableSetHead,
+		jsonRPCEnableSetHeadFlag,
+		false,
+		"expose the debug_setHead json-rpc method, which rewinds the canonical chain of the running node",
+	)
+
 	cmd.Flags().StringVar(
 		&params.rawConfig.LogFilePath,
 		logFileLocationFlag,
@@ -228,6 +289,77 @@ func setFlags(cmd *cobra.Command) {
 		"minimal number of child blocks required for the parent block to be considered final",
 	)
 
+	cmd.Flags().BoolVar(
+		&params.rawConfig.ShadowFork,
+		shadowForkFlag,
+		defaultConfig.ShadowFork,
+		"follow the network without producing blocks or gossiping transactions, "+
+			"recording state divergences of the local execution instead of rejecting blocks",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.RewardAddress,
+		rewardAddressFlag,
+		defaultConfig.RewardAddress,
+		"the address credited with the fees of the blocks proposed by this validator (PolyBFT only). "+
+			"It must be registered on-chain and takes effect with the next epoch",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.EnginePriorityGasShare,
+		enginePriorityGasShareFlag,
+		defaultConfig.EnginePriorityGasShare,
+		"the share (in percent) of the block gas limit filled with the transactions of authorized "+
+			"engine EOAs ahead of all other transactions when building blocks (PolyBFT only), 0 disables it",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.MaxReorgDepth,
+		maxReorgDepthFlag,
+		defaultConfig.MaxReorgDepth,
+		"the maximum number of canonical blocks a reorg may replace, heavier branches forking off "+
+			"further back are refused and their peers treated as faulty, 0 disables the limit",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.StateRetention,
+		stateRetentionFlag,
+		defaultConfig.StateRetention,
+		fmt.Sprintf("the number of recent blocks whose state is kept, older state is pruned in the background "+
+			"(at least %d), 0 keeps the full archive", server.MinStateRetention),
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.FutureBlocksSize,
+		futureBlocksSizeFlag,
+		defaultConfig.FutureBlocksSize,
+		"the maximum number of blocks received from the peers ahead of the head which are buffered "+
+			"until the head reaches them, 0 disables the buffer",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.FutureBlocksMaxDistance,
+		futureBlocksMaxDistanceFlag,
+		defaultConfig.FutureBlocksMaxDistance,
+		"the maximum number of blocks a buffered block may be ahead of the head, "+
+			"the peers sending blocks farther ahead are penalized",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.GasPriceOracleBlocks,
+		gasPriceOracleBlocksFlag,
+		defaultConfig.GasPriceOracleBlocks,
+		"the number of recent blocks whose transaction tips are sampled to suggest "+
+			"eth_maxPriorityFeePerGas and eth_gasPrice",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.GasPriceOraclePercentile,
+		gasPriceOraclePercentileFlag,
+		defaultConfig.GasPriceOraclePercentile,
+		"the percentile (0-100) of the sampled transaction tips suggested as the priority fee",
+	)
+
 	cmd.Flags().Uint64Var(
 		&params.rawConfig.ConcurrentRequestsDebug,
 		concurrentRequestsDebugFlag,
@@ -286,6 +418,15 @@ func setDevFlags(cmd *cobra.Command) {
 	)
 
 	_ = cmd.Flags().MarkHidden(devIntervalFlag)
+
+	cmd.Flags().BoolVar(
+		&params.devInstant,
+		devInstantFlag,
+		false,
+		"should the dev consensus seal a block as soon as a transaction enters the pool (default false)",
+	)
+
+	_ = cmd.Flags().MarkHidden(devInstantFlag)
 }
 
 func runPreRun(cmd *cobra.Command, _ []string) error {
@@ -0,0 +1,56 @@
+package rewardaddress
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/xgr-network/xgr-node/command/helper"
+	sidechainHelper "github.com/xgr-network/xgr-node/command/sidechain"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+const (
+	addressFlag = "address"
+)
+
+type rewardAddressParams struct {
+	accountDir    string
+	accountConfig string
+	jsonRPC       string
+	address       string
+}
+
+func (r *rewardAddressParams) validateFlags() error {
+	if _, err := helper.ParseJSONRPCAddress(r.jsonRPC); err != nil {
+		return fmt.Errorf("failed to parse json rpc address. Error: %w", err)
+	}
+
+	if err := types.IsValidAddress(r.address); err != nil {
+		return fmt.Errorf("invalid reward address: %w", err)
+	}
+
+	return sidechainHelper.ValidateSecretFlags(r.accountDir, r.accountConfig)
+}
+
+type rewardAddressResult struct {
+	ValidatorAddress string `json:"validatorAddress"`
+	RewardAddress    string `json:"rewardAddress"`
+	BlockNumber      uint64 `json:"blockNumber"`
+}
+
+func (r *rewardAddressResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[REWARD ADDRESS]\n")
+
+	vals := make([]string, 0, 4)
+	vals = append(vals, fmt.Sprintf("Validator Address|%s", r.ValidatorAddress))
+	vals = append(vals, fmt.Sprintf("Reward Address|%s", r.RewardAddress))
+	vals = append(vals, fmt.Sprintf("Inclusion Block Number|%d", r.BlockNumber))
+	vals = append(vals, "Effective|from the next epoch")
+
+	buffer.WriteString(helper.FormatKV(vals))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,112 @@
+package rewardaddress
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/umbracle/ethgo"
+
+	"github.com/xgr-network/xgr-node/command"
+	"github.com/xgr-network/xgr-node/command/helper"
+	"github.com/xgr-network/xgr-node/command/polybftsecrets"
+	rootHelper "github.com/xgr-network/xgr-node/command/rootchain/helper"
+	sidechainHelper "github.com/xgr-network/xgr-node/command/sidechain"
+	"github.com/xgr-network/xgr-node/contracts"
+	"github.com/xgr-network/xgr-node/contracts/abis"
+	"github.com/xgr-network/xgr-node/txrelayer"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+var params rewardAddressParams
+
+func GetCommand() *cobra.Command {
+	rewardAddressCmd := &cobra.Command{
+		Use:     "reward-address",
+		Short:   "Registers the address credited with the fees of the blocks proposed by the validator",
+		PreRunE: runPreRun,
+		RunE:    runCommand,
+	}
+
+	helper.RegisterJSONRPCFlag(rewardAddressCmd)
+	setFlags(rewardAddressCmd)
+
+	return rewardAddressCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.accountDir,
+		polybftsecrets.AccountDirFlag,
+		"",
+		polybftsecrets.AccountDirFlagDesc,
+	)
+
+	cmd.Flags().StringVar(
+		&params.accountConfig,
+		polybftsecrets.AccountConfigFlag,
+		"",
+		polybftsecrets.AccountConfigFlagDesc,
+	)
+
+	cmd.Flags().StringVar(
+		&params.address,
+		addressFlag,
+		"",
+		"reward address, takes effect with the next epoch (zero address resets it to the validator address)",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive(polybftsecrets.AccountDirFlag, polybftsecrets.AccountConfigFlag)
+	_ = cmd.MarkFlagRequired(addressFlag)
+}
+
+func runPreRun(cmd *cobra.Command, _ []string) error {
+	params.jsonRPC = helper.GetJSONRPCAddress(cmd)
+
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) error {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	validatorAccount, err := sidechainHelper.GetAccount(params.accountDir, params.accountConfig)
+	if err != nil {
+		return err
+	}
+
+	txRelayer, err := txrelayer.NewTxRelayer(txrelayer.WithIPAddress(params.jsonRPC),
+		txrelayer.WithReceiptTimeout(150*time.Millisecond))
+	if err != nil {
+		return err
+	}
+
+	rewardAddress := types.StringToAddress(params.address)
+
+	encoded, err := abis.RewardAddressABI.Methods["setRewardAddress"].Encode(
+		[]interface{}{ethgo.Address(rewardAddress)})
+	if err != nil {
+		return err
+	}
+
+	receiver := (*ethgo.Address)(&contracts.RewardAddressPrecompile)
+	txn := rootHelper.CreateTransaction(validatorAccount.Ecdsa.Address(), receiver, encoded, nil, false)
+
+	receipt, err := txRelayer.SendTransaction(txn, validatorAccount.Ecdsa)
+	if err != nil {
+		return err
+	}
+
+	if receipt.Status != uint64(types.ReceiptSuccess) {
+		return fmt.Errorf("reward address transaction failed on block: %d", receipt.BlockNumber)
+	}
+
+	outputter.WriteCommandResult(
+		&rewardAddressResult{
+			ValidatorAddress: validatorAccount.Ecdsa.Address().String(),
+			RewardAddress:    rewardAddress.String(),
+			BlockNumber:      receipt.BlockNumber,
+		})
+
+	return nil
+}
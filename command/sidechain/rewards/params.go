@@ -1,17 +1,57 @@
 package rewards
 
 import (
+	"bufio"
 	"bytes"
+	"errors"
 	"fmt"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/xgr-network/xgr-node/command/helper"
 	sidechainHelper "github.com/xgr-network/xgr-node/command/sidechain"
 )
 
+const (
+	receiptTimeoutFlag = "receipt-timeout"
+	validatorsFileFlag = "validators-file"
+
+	defaultReceiptTimeout = 30 * time.Second
+
+	// receiptPollInterval is the interval between the receipt queries of the tx relayer
+	receiptPollInterval = 150 * time.Millisecond
+)
+
+var (
+	errInvalidReceiptTimeout = errors.New("receipt timeout must be positive")
+	errNoValidators          = errors.New("validators file doesn't list any validator")
+)
+
 type withdrawRewardsParams struct {
+	accountDir     string
+	accountConfig  string
+	jsonRPC        string
+	receiptTimeout time.Duration
+	validatorsFile string
+
+	// validators are the accounts listed in the validators file
+	validators []validatorAccount
+}
+
+// validatorAccount is the secrets location of a validator account,
+// either an account directory or a secrets manager config file
+type validatorAccount struct {
 	accountDir    string
 	accountConfig string
-	jsonRPC       string
+}
+
+func (v validatorAccount) String() string {
+	if v.accountConfig != "" {
+		return v.accountConfig
+	}
+
+	return v.accountDir
 }
 
 type withdrawRewardResult struct {
@@ -20,13 +60,77 @@ type withdrawRewardResult struct {
 }
 
 func (w *withdrawRewardsParams) validateFlags() error {
+	if w.receiptTimeout <= 0 {
+		return errInvalidReceiptTimeout
+	}
+
 	if _, err := helper.ParseJSONRPCAddress(w.jsonRPC); err != nil {
 		return fmt.Errorf("failed to parse json rpc address. Error: %w", err)
 	}
 
+	if w.validatorsFile != "" {
+		validators, err := readValidatorsFile(w.validatorsFile)
+		if err != nil {
+			return err
+		}
+
+		w.validators = validators
+
+		return nil
+	}
+
 	return sidechainHelper.ValidateSecretFlags(w.accountDir, w.accountConfig)
 }
 
+// readValidatorsFile reads the validator accounts listed in the file, one per line.
+// A line is either an account directory or a secrets manager config file,
+// empty lines and lines starting with # are skipped
+func readValidatorsFile(path string) ([]validatorAccount, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open validators file: %w", err)
+	}
+
+	defer file.Close()
+
+	var validators []validatorAccount
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		info, err := os.Stat(line)
+		if err != nil {
+			return nil, fmt.Errorf("invalid validator account '%s': %w", line, err)
+		}
+
+		if info.IsDir() {
+			validators = append(validators, validatorAccount{accountDir: line})
+		} else {
+			validators = append(validators, validatorAccount{accountConfig: line})
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read validators file: %w", err)
+	}
+
+	if len(validators) == 0 {
+		return nil, errNoValidators
+	}
+
+	return validators, nil
+}
+
+// receiptRetries returns the number of receipt queries of the tx relayer
+// which wait for the receipt at least for the receipt timeout
+func (w *withdrawRewardsParams) receiptRetries() int {
+	return int((w.receiptTimeout + receiptPollInterval - 1) / receiptPollInterval)
+}
+
 func (wr withdrawRewardResult) GetOutput() string {
 	var buffer bytes.Buffer
 
@@ -41,3 +145,60 @@ func (wr withdrawRewardResult) GetOutput() string {
 
 	return buffer.String()
 }
+
+// validatorWithdrawResult is the outcome of the withdrawal of one validator of a batch
+type validatorWithdrawResult struct {
+	Account          string `json:"account"`
+	ValidatorAddress string `json:"validatorAddress,omitempty"`
+	RewardAmount     uint64 `json:"rewardAmount"`
+	Error            string `json:"error,omitempty"`
+}
+
+type withdrawRewardsBatchResult struct {
+	Validators []*validatorWithdrawResult `json:"validators"`
+}
+
+// failures returns the number of validators whose withdrawal failed
+func (br withdrawRewardsBatchResult) failures() int {
+	failures := 0
+
+	for _, v := range br.Validators {
+		if v.Error != "" {
+			failures++
+		}
+	}
+
+	return failures
+}
+
+func (br withdrawRewardsBatchResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[WITHDRAW REWARDS]\n")
+
+	for _, v := range br.Validators {
+		vals := make([]string, 0, 3)
+		vals = append(vals, fmt.Sprintf("Account|%s", v.Account))
+
+		if v.ValidatorAddress != "" {
+			vals = append(vals, fmt.Sprintf("Validator Address|%s", v.ValidatorAddress))
+		}
+
+		if v.Error != "" {
+			vals = append(vals, fmt.Sprintf("Error|%s", v.Error))
+		} else {
+			vals = append(vals, fmt.Sprintf("Amount Withdrawn|%v", v.RewardAmount))
+		}
+
+		buffer.WriteString(helper.FormatKV(vals))
+		buffer.WriteString("\n\n")
+	}
+
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Validators|%d", len(br.Validators)),
+		fmt.Sprintf("Failures|%d", br.failures()),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
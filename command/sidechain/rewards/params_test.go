@@ -0,0 +1,65 @@
+package rewards
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReceiptTimeoutFlag(t *testing.T) {
+	parse := func(t *testing.T, args ...string) time.Duration {
+		t.Helper()
+
+		cmd := &cobra.Command{}
+		setFlags(cmd)
+
+		require.NoError(t, cmd.ParseFlags(args))
+
+		return params.receiptTimeout
+	}
+
+	require.Equal(t, defaultReceiptTimeout, parse(t))
+	require.Equal(t, 45*time.Second, parse(t, "--"+receiptTimeoutFlag, "45s"))
+}
+
+func TestWithdrawRewardsParams_ReceiptTimeout(t *testing.T) {
+	t.Parallel()
+
+	accountDir := t.TempDir()
+
+	for _, timeout := range []time.Duration{0, -time.Second} {
+		p := &withdrawRewardsParams{
+			accountDir:     accountDir,
+			jsonRPC:        "http://127.0.0.1:8545",
+			receiptTimeout: timeout,
+		}
+
+		require.ErrorIs(t, p.validateFlags(), errInvalidReceiptTimeout)
+	}
+
+	cases := []struct {
+		timeout time.Duration
+		retries int
+	}{
+		{defaultReceiptTimeout, 200},
+		{45 * time.Second, 300},
+		{200 * time.Millisecond, 2},
+		{time.Millisecond, 1},
+	}
+
+	for _, c := range cases {
+		p := &withdrawRewardsParams{
+			accountDir:     accountDir,
+			jsonRPC:        "http://127.0.0.1:8545",
+			receiptTimeout: c.timeout,
+		}
+
+		require.NoError(t, p.validateFlags())
+
+		// the relayer waits at least for the receipt timeout
+		require.Equal(t, c.retries, p.receiptRetries(), "timeout %s", c.timeout)
+		require.GreaterOrEqual(t, time.Duration(p.receiptRetries())*receiptPollInterval, c.timeout)
+	}
+}
@@ -2,7 +2,7 @@ package rewards
 
 import (
 	"fmt"
-	"time"
+	"math/big"
 
 	"github.com/spf13/cobra"
 	"github.com/umbracle/ethgo"
@@ -13,6 +13,7 @@ import (
 	rootHelper "github.com/xgr-network/xgr-node/command/rootchain/helper"
 	sidechainHelper "github.com/xgr-network/xgr-node/command/sidechain"
 	"github.com/xgr-network/xgr-node/consensus/polybft/contractsapi"
+	"github.com/xgr-network/xgr-node/consensus/polybft/wallet"
 	"github.com/xgr-network/xgr-node/contracts"
 	"github.com/xgr-network/xgr-node/helper/common"
 	"github.com/xgr-network/xgr-node/txrelayer"
@@ -24,7 +25,7 @@ var params withdrawRewardsParams
 func GetCommand() *cobra.Command {
 	unstakeCmd := &cobra.Command{
 		Use:     "withdraw-rewards",
-		Short:   "Withdraws pending rewards on child chain for given validator",
+		Short:   "Withdraws pending rewards on child chain for given validator or validators",
 		PreRunE: runPreRun,
 		RunE:    runCommand,
 	}
@@ -50,7 +51,24 @@ func setFlags(cmd *cobra.Command) {
 		polybftsecrets.AccountConfigFlagDesc,
 	)
 
+	cmd.Flags().DurationVar(
+		&params.receiptTimeout,
+		receiptTimeoutFlag,
+		defaultReceiptTimeout,
+		"how long to wait for the receipt of the withdraw transaction",
+	)
+
+	cmd.Flags().StringVar(
+		&params.validatorsFile,
+		validatorsFileFlag,
+		"",
+		"the file listing the validators to withdraw the rewards for, one account directory "+
+			"or secrets manager config file per line",
+	)
+
 	cmd.MarkFlagsMutuallyExclusive(polybftsecrets.AccountDirFlag, polybftsecrets.AccountConfigFlag)
+	cmd.MarkFlagsMutuallyExclusive(polybftsecrets.AccountDirFlag, validatorsFileFlag)
+	cmd.MarkFlagsMutuallyExclusive(polybftsecrets.AccountConfigFlag, validatorsFileFlag)
 }
 
 func runPreRun(cmd *cobra.Command, _ []string) error {
@@ -63,57 +81,112 @@ func runCommand(cmd *cobra.Command, _ []string) error {
 	outputter := command.InitializeOutputter(cmd)
 	defer outputter.WriteOutput()
 
-	validatorAccount, err := sidechainHelper.GetAccount(params.accountDir, params.accountConfig)
+	txRelayer, err := txrelayer.NewTxRelayer(txrelayer.WithIPAddress(params.jsonRPC),
+		txrelayer.WithReceiptTimeout(receiptPollInterval),
+		txrelayer.WithNumRetries(params.receiptRetries()))
 	if err != nil {
 		return err
 	}
 
-	validatorAddr := validatorAccount.Ecdsa.Address()
-	rewardPoolAddr := ethgo.Address(contracts.RewardPoolContract)
+	if len(params.validators) > 0 {
+		outputter.WriteCommandResult(withdrawRewardsBatch(txRelayer, params.validators))
 
-	txRelayer, err := txrelayer.NewTxRelayer(txrelayer.WithIPAddress(params.jsonRPC),
-		txrelayer.WithReceiptTimeout(150*time.Millisecond))
+		return nil
+	}
+
+	validatorAccount, err := sidechainHelper.GetAccount(params.accountDir, params.accountConfig)
 	if err != nil {
 		return err
 	}
 
-	encoded, err := contractsapi.RewardPool.Abi.Methods["pendingRewards"].Encode([]interface{}{validatorAddr})
+	amount, err := withdrawRewards(txRelayer, validatorAccount)
 	if err != nil {
 		return err
 	}
 
+	result := &withdrawRewardResult{
+		ValidatorAddress: validatorAccount.Ecdsa.Address().String(),
+		RewardAmount:     amount.Uint64(),
+	}
+
+	outputter.WriteCommandResult(result)
+
+	return nil
+}
+
+// withdrawRewardsBatch withdraws the pending rewards of every validator,
+// a failed withdrawal is reported and doesn't stop the batch
+func withdrawRewardsBatch(txRelayer txrelayer.TxRelayer, validators []validatorAccount) *withdrawRewardsBatchResult {
+	result := &withdrawRewardsBatchResult{
+		Validators: make([]*validatorWithdrawResult, 0, len(validators)),
+	}
+
+	for _, validator := range validators {
+		validatorResult := &validatorWithdrawResult{Account: validator.String()}
+		result.Validators = append(result.Validators, validatorResult)
+
+		validatorAccount, err := sidechainHelper.GetAccount(validator.accountDir, validator.accountConfig)
+		if err != nil {
+			validatorResult.Error = err.Error()
+
+			continue
+		}
+
+		validatorResult.ValidatorAddress = validatorAccount.Ecdsa.Address().String()
+
+		amount, err := withdrawRewards(txRelayer, validatorAccount)
+		if err != nil {
+			validatorResult.Error = err.Error()
+
+			continue
+		}
+
+		validatorResult.RewardAmount = amount.Uint64()
+	}
+
+	return result
+}
+
+// withdrawRewards withdraws the pending rewards of the validator and returns the withdrawn amount.
+// No transaction is sent if there are no pending rewards
+func withdrawRewards(txRelayer txrelayer.TxRelayer, validatorAccount *wallet.Account) (*big.Int, error) {
+	validatorAddr := validatorAccount.Ecdsa.Address()
+	rewardPoolAddr := ethgo.Address(contracts.RewardPoolContract)
+
+	encoded, err := contractsapi.RewardPool.Abi.Methods["pendingRewards"].Encode([]interface{}{validatorAddr})
+	if err != nil {
+		return nil, err
+	}
+
 	response, err := txRelayer.Call(validatorAddr, rewardPoolAddr, encoded)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	amount, err := common.ParseUint256orHex(&response)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	if amount.Sign() == 0 {
+		return amount, nil
 	}
 
 	encoded, err = contractsapi.RewardPool.Abi.Methods["withdrawReward"].Encode([]interface{}{})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	txn := rootHelper.CreateTransaction(validatorAddr, &rewardPoolAddr, encoded, nil, false)
 
 	receipt, err := txRelayer.SendTransaction(txn, validatorAccount.Ecdsa)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if receipt.Status != uint64(types.ReceiptSuccess) {
-		return fmt.Errorf("withdraw transaction failed on block: %d", receipt.BlockNumber)
-	}
-
-	result := &withdrawRewardResult{
-		ValidatorAddress: validatorAccount.Ecdsa.Address().String(),
-		RewardAmount:     amount.Uint64(),
+		return nil, fmt.Errorf("withdraw transaction failed on block: %d", receipt.BlockNumber)
 	}
 
-	outputter.WriteCommandResult(result)
-
-	return nil
+	return amount, nil
 }
@@ -0,0 +1,129 @@
+package rewards
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/jsonrpc"
+
+	"github.com/xgr-network/xgr-node/consensus/polybft/wallet"
+	"github.com/xgr-network/xgr-node/secrets/helper"
+	"github.com/xgr-network/xgr-node/txrelayer"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+var _ txrelayer.TxRelayer = (*dummyTxRelayer)(nil)
+
+type dummyTxRelayer struct {
+	mock.Mock
+}
+
+func (d *dummyTxRelayer) Call(from ethgo.Address, to ethgo.Address, input []byte) (string, error) {
+	args := d.Called(from, to, input)
+
+	return args.String(0), args.Error(1)
+}
+
+func (d *dummyTxRelayer) SendTransaction(txn *ethgo.Transaction, key ethgo.Key) (*ethgo.Receipt, error) {
+	args := d.Called(txn, key)
+
+	return args.Get(0).(*ethgo.Receipt), args.Error(1) //nolint:forcetypeassert
+}
+
+func (d *dummyTxRelayer) SendTransactionLocal(txn *ethgo.Transaction) (*ethgo.Receipt, error) {
+	args := d.Called(txn)
+
+	return args.Get(0).(*ethgo.Receipt), args.Error(1) //nolint:forcetypeassert
+}
+
+func (d *dummyTxRelayer) Client() *jsonrpc.Client {
+	return nil
+}
+
+// newValidatorDir stores a new validator account in a local secrets directory
+func newValidatorDir(t *testing.T) (string, ethgo.Address) {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	sm, err := helper.SetupLocalSecretsManager(dir)
+	require.NoError(t, err)
+
+	account, err := wallet.GenerateAccount()
+	require.NoError(t, err)
+	require.NoError(t, account.Save(sm))
+
+	return dir, account.Ecdsa.Address()
+}
+
+func TestWithdrawRewardsBatch(t *testing.T) {
+	t.Parallel()
+
+	rewardedDir, rewardedAddr := newValidatorDir(t)
+	idleDir, idleAddr := newValidatorDir(t)
+
+	validatorsFile := filepath.Join(t.TempDir(), "validators")
+	require.NoError(t, os.WriteFile(validatorsFile, []byte(
+		"# validators of the batch\n"+rewardedDir+"\n\n"+idleDir+"\n"), 0600))
+
+	validators, err := readValidatorsFile(validatorsFile)
+	require.NoError(t, err)
+	require.Equal(t, []validatorAccount{{accountDir: rewardedDir}, {accountDir: idleDir}}, validators)
+
+	txRelayer := &dummyTxRelayer{}
+	txRelayer.On("Call", rewardedAddr, mock.Anything, mock.Anything).Return("0x64", nil).Once()
+	txRelayer.On("Call", idleAddr, mock.Anything, mock.Anything).Return("0x0", nil).Once()
+	txRelayer.On("SendTransaction", mock.MatchedBy(func(txn *ethgo.Transaction) bool {
+		return txn.From == rewardedAddr
+	}), mock.Anything).Return(&ethgo.Receipt{Status: uint64(types.ReceiptSuccess)}, nil).Once()
+
+	// the validator without pending rewards doesn't send a withdraw transaction
+	result := withdrawRewardsBatch(txRelayer, validators)
+	require.Equal(t, []*validatorWithdrawResult{
+		{Account: rewardedDir, ValidatorAddress: rewardedAddr.String(), RewardAmount: 100},
+		{Account: idleDir, ValidatorAddress: idleAddr.String(), RewardAmount: 0},
+	}, result.Validators)
+	require.Equal(t, 0, result.failures())
+
+	txRelayer.AssertExpectations(t)
+
+	// a validator whose account can't be loaded is reported and the batch goes on
+	emptyDir := filepath.Join(t.TempDir(), "empty")
+	require.NoError(t, os.Mkdir(emptyDir, 0700))
+
+	txRelayer = &dummyTxRelayer{}
+	txRelayer.On("Call", idleAddr, mock.Anything, mock.Anything).Return("0x0", nil).Once()
+
+	result = withdrawRewardsBatch(txRelayer, []validatorAccount{{accountDir: emptyDir}, {accountDir: idleDir}})
+	require.Len(t, result.Validators, 2)
+	require.NotEmpty(t, result.Validators[0].Error)
+	require.Empty(t, result.Validators[1].Error)
+	require.Equal(t, 1, result.failures())
+
+	txRelayer.AssertExpectations(t)
+}
+
+func TestReadValidatorsFile_Errors(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	_, err := readValidatorsFile(filepath.Join(dir, "missing"))
+	require.Error(t, err)
+
+	emptyFile := filepath.Join(dir, "empty")
+	require.NoError(t, os.WriteFile(emptyFile, []byte("# no validators\n"), 0600))
+
+	_, err = readValidatorsFile(emptyFile)
+	require.ErrorIs(t, err, errNoValidators)
+
+	unknownFile := filepath.Join(dir, "unknown")
+	require.NoError(t, os.WriteFile(unknownFile, []byte(filepath.Join(dir, "unknown-account")+"\n"), 0600))
+
+	_, err = readValidatorsFile(unknownFile)
+	require.Error(t, err)
+}
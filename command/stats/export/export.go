@@ -0,0 +1,108 @@
+package export
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/xgr-network/xgr-node/blockchain/storage"
+	"github.com/xgr-network/xgr-node/command"
+	"github.com/xgr-network/xgr-node/command/helper"
+)
+
+/*
+./xgrchain stats export --data-dir ./node --from-block 0 --granularity day --out stats.csv
+./xgrchain stats export --jsonrpc http://localhost:8545 --granularity hour --format json --out stats.json
+*/
+func GetCommand() *cobra.Command {
+	exportCmd := &cobra.Command{
+		Use: "export",
+		Short: "Export the gas usage, base fee, transaction count, burned and donated wei of a block range " +
+			"aggregated per block, hour or day. An interrupted export is resumed from its progress file",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(exportCmd)
+	helper.SetRequiredFlags(exportCmd, params.getRequiredFlags())
+
+	return exportCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory of a stopped node to read the blocks from, the JSON-RPC endpoint is used if omitted",
+	)
+
+	cmd.Flags().StringVar(
+		&params.dbEngine,
+		dbEngineFlag,
+		storage.EngineLevelDB,
+		fmt.Sprintf("the database engine of the blockchain storage (%s or %s)",
+			storage.EngineLevelDB, storage.EnginePebble),
+	)
+
+	cmd.Flags().StringVar(
+		&params.fromRaw,
+		fromFlag,
+		"0",
+		"the first block to export",
+	)
+
+	cmd.Flags().StringVar(
+		&params.toRaw,
+		toFlag,
+		"",
+		"the last block to export, the head block if omitted",
+	)
+
+	cmd.Flags().StringVar(
+		&params.granularityRaw,
+		granularityFlag,
+		string(granularityDay),
+		fmt.Sprintf("the period of a row (%s, %s or %s), hours and days are UTC",
+			granularityBlock, granularityHour, granularityDay),
+	)
+
+	cmd.Flags().StringVar(
+		&params.format,
+		formatFlag,
+		formatCSV,
+		fmt.Sprintf("the format of the export (%s, or %s for one JSON object per line)", formatCSV, formatJSON),
+	)
+
+	cmd.Flags().StringVar(
+		&params.out,
+		outFlag,
+		"",
+		"the file to write the export to",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rpcRate,
+		rpcRateFlag,
+		defaultRPCRate,
+		"the maximum number of JSON-RPC requests per second",
+	)
+}
+
+func runPreRun(cmd *cobra.Command, _ []string) error {
+	params.jsonRPC = helper.GetJSONRPCAddress(cmd)
+
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.exportStats(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
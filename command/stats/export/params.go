@@ -0,0 +1,158 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/umbracle/ethgo/jsonrpc"
+	"github.com/xgr-network/xgr-node/blockchain/storage"
+	"github.com/xgr-network/xgr-node/command"
+	"github.com/xgr-network/xgr-node/helper/common"
+	"github.com/xgr-network/xgr-node/server"
+)
+
+const (
+	dataDirFlag     = "data-dir"
+	dbEngineFlag    = "db.engine"
+	fromFlag        = "from-block"
+	toFlag          = "to-block"
+	granularityFlag = "granularity"
+	formatFlag      = "format"
+	outFlag         = "out"
+	rpcRateFlag     = "rpc-rate"
+
+	defaultRPCRate = 20
+)
+
+var (
+	params = &exportParams{}
+)
+
+var (
+	errDecodeRange        = errors.New("unable to decode range value")
+	errInvalidRange       = errors.New(`invalid "to-block" value; must be >= "from-block"`)
+	errInvalidDBEngine    = errors.New("db engine must be " + storage.EngineLevelDB + " or " + storage.EnginePebble)
+	errInvalidGranularity = fmt.Errorf("granularity must be %s, %s or %s",
+		granularityBlock, granularityHour, granularityDay)
+	errInvalidFormat  = fmt.Errorf("format must be %s or %s", formatCSV, formatJSON)
+	errInvalidRPCRate = errors.New("rpc rate must be greater than 0")
+)
+
+type exportParams struct {
+	dataDir  string
+	dbEngine string
+	jsonRPC  string
+	out      string
+
+	fromRaw string
+	toRaw   string
+
+	from uint64
+	to   *uint64
+
+	granularityRaw string
+	format         string
+	rpcRate        uint64
+
+	result *ExportStatsResult
+}
+
+func (p *exportParams) validateFlags() error {
+	if p.dataDir != "" && p.dbEngine != storage.EngineLevelDB && p.dbEngine != storage.EnginePebble {
+		return errInvalidDBEngine
+	}
+
+	switch granularity(p.granularityRaw) {
+	case granularityBlock, granularityHour, granularityDay:
+	default:
+		return errInvalidGranularity
+	}
+
+	if p.format != formatCSV && p.format != formatJSON {
+		return errInvalidFormat
+	}
+
+	if p.rpcRate == 0 {
+		return errInvalidRPCRate
+	}
+
+	var parseErr error
+
+	if p.from, parseErr = common.ParseUint64orHex(&p.fromRaw); parseErr != nil {
+		return errDecodeRange
+	}
+
+	if p.toRaw != "" {
+		var parsedTo uint64
+
+		if parsedTo, parseErr = common.ParseUint64orHex(&p.toRaw); parseErr != nil {
+			return errDecodeRange
+		}
+
+		if p.from > parsedTo {
+			return errInvalidRange
+		}
+
+		p.to = &parsedTo
+	}
+
+	return nil
+}
+
+func (p *exportParams) getRequiredFlags() []string {
+	return []string{
+		outFlag,
+	}
+}
+
+// exportStats reads the blocks from the data directory if it is set, otherwise from the JSON-RPC endpoint
+func (p *exportParams) exportStats() error {
+	var source blockSource
+
+	if p.dataDir != "" {
+		logger := hclog.New(&hclog.LoggerOptions{
+			Name:  "stats-export",
+			Level: hclog.LevelFromString("INFO"),
+		})
+
+		db, err := server.NewBlockchainStorage(p.dbEngine, filepath.Join(p.dataDir, "blockchain"), logger)
+		if err != nil {
+			return err
+		}
+
+		defer db.Close()
+
+		source = &storageSource{db: db}
+	} else {
+		client, err := jsonrpc.NewClient(p.jsonRPC)
+		if err != nil {
+			return fmt.Errorf("failed to connect to %s: %w", p.jsonRPC, err)
+		}
+
+		defer client.Close()
+
+		source = newRPCSource(client, time.Second/time.Duration(p.rpcRate))
+	}
+
+	result, err := exportStats(source, &exportConfig{
+		from:        p.from,
+		to:          p.to,
+		granularity: granularity(p.granularityRaw),
+		format:      p.format,
+		out:         p.out,
+	})
+	if err != nil {
+		return err
+	}
+
+	p.result = result
+
+	return nil
+}
+
+func (p *exportParams) getResult() command.CommandResult {
+	return p.result
+}
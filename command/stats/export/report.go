@@ -0,0 +1,417 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strconv"
+	"time"
+)
+
+type granularity string
+
+const (
+	granularityBlock granularity = "block"
+	granularityHour  granularity = "hour"
+	granularityDay   granularity = "day"
+)
+
+const (
+	formatCSV  = "csv"
+	formatJSON = "json"
+)
+
+// progressInterval is the number of blocks between two saves of the progress file
+var progressInterval uint64 = 10_000
+
+var csvHeader = []string{
+	"period",
+	"from_block",
+	"to_block",
+	"blocks",
+	"tx_count",
+	"engine_tx_count",
+	"gas_used",
+	"avg_base_fee_wei",
+	"burned_wei",
+	"donated_wei",
+	"validator_fees_wei",
+	"blocks_without_economics",
+}
+
+// period returns the period of the block, hours and days are UTC
+func (g granularity) period(b *blockStats) string {
+	switch g {
+	case granularityHour:
+		return time.Unix(int64(b.timestamp), 0).UTC().Format("2006-01-02T15:00:00Z")
+	case granularityDay:
+		return time.Unix(int64(b.timestamp), 0).UTC().Format("2006-01-02")
+	default:
+		return strconv.FormatUint(b.number, 10)
+	}
+}
+
+// aggregate sums up the blocks of a period. It is saved in the progress file
+// while the period is open, so all of its fields are exported
+type aggregate struct {
+	Period           string   `json:"period"`
+	FromBlock        uint64   `json:"fromBlock"`
+	ToBlock          uint64   `json:"toBlock"`
+	Blocks           uint64   `json:"blocks"`
+	TxCount          uint64   `json:"txCount"`
+	EngineTxCount    uint64   `json:"engineTxCount"`
+	GasUsed          uint64   `json:"gasUsed"`
+	BaseFeeSum       *big.Int `json:"baseFeeSum"`
+	Burned           *big.Int `json:"burned"`
+	Donated          *big.Int `json:"donated"`
+	ValidatorFees    *big.Int `json:"validatorFees"`
+	MissingEconomics uint64   `json:"missingEconomics"`
+}
+
+func newAggregate(period string, fromBlock uint64) *aggregate {
+	return &aggregate{
+		Period:        period,
+		FromBlock:     fromBlock,
+		BaseFeeSum:    new(big.Int),
+		Burned:        new(big.Int),
+		Donated:       new(big.Int),
+		ValidatorFees: new(big.Int),
+	}
+}
+
+func (a *aggregate) add(b *blockStats) {
+	a.ToBlock = b.number
+	a.Blocks++
+	a.TxCount += b.txCount
+	a.GasUsed += b.gasUsed
+	a.BaseFeeSum.Add(a.BaseFeeSum, new(big.Int).SetUint64(b.baseFee))
+
+	if b.economics == nil {
+		a.MissingEconomics++
+
+		return
+	}
+
+	a.EngineTxCount += b.economics.EngineTxCount
+	a.Burned.Add(a.Burned, b.economics.TotalBurned)
+	a.Donated.Add(a.Donated, b.economics.TotalDonated)
+	a.ValidatorFees.Add(a.ValidatorFees, b.economics.TotalValidatorFees)
+}
+
+// row is an exported period
+type row struct {
+	Period           string `json:"period"`
+	FromBlock        uint64 `json:"fromBlock"`
+	ToBlock          uint64 `json:"toBlock"`
+	Blocks           uint64 `json:"blocks"`
+	TxCount          uint64 `json:"txCount"`
+	EngineTxCount    uint64 `json:"engineTxCount"`
+	GasUsed          uint64 `json:"gasUsed"`
+	AvgBaseFeeWei    string `json:"avgBaseFeeWei"`
+	BurnedWei        string `json:"burnedWei"`
+	DonatedWei       string `json:"donatedWei"`
+	ValidatorFeesWei string `json:"validatorFeesWei"`
+
+	// MissingEconomics is the number of blocks whose burned, donated and validator fees aren't included
+	MissingEconomics uint64 `json:"blocksWithoutEconomics"`
+}
+
+func (a *aggregate) row() *row {
+	avgBaseFee := new(big.Int)
+	if a.Blocks > 0 {
+		avgBaseFee.Div(a.BaseFeeSum, new(big.Int).SetUint64(a.Blocks))
+	}
+
+	return &row{
+		Period:           a.Period,
+		FromBlock:        a.FromBlock,
+		ToBlock:          a.ToBlock,
+		Blocks:           a.Blocks,
+		TxCount:          a.TxCount,
+		EngineTxCount:    a.EngineTxCount,
+		GasUsed:          a.GasUsed,
+		AvgBaseFeeWei:    avgBaseFee.String(),
+		BurnedWei:        a.Burned.String(),
+		DonatedWei:       a.Donated.String(),
+		ValidatorFeesWei: a.ValidatorFees.String(),
+		MissingEconomics: a.MissingEconomics,
+	}
+}
+
+func (r *row) csvRecord() []string {
+	return []string{
+		r.Period,
+		strconv.FormatUint(r.FromBlock, 10),
+		strconv.FormatUint(r.ToBlock, 10),
+		strconv.FormatUint(r.Blocks, 10),
+		strconv.FormatUint(r.TxCount, 10),
+		strconv.FormatUint(r.EngineTxCount, 10),
+		strconv.FormatUint(r.GasUsed, 10),
+		r.AvgBaseFeeWei,
+		r.BurnedWei,
+		r.DonatedWei,
+		r.ValidatorFeesWei,
+		strconv.FormatUint(r.MissingEconomics, 10),
+	}
+}
+
+// aggregator groups consecutive blocks by period and emits a row once its period is over
+type aggregator struct {
+	granularity granularity
+	open        *aggregate
+	emit        func(*row) error
+}
+
+func (a *aggregator) add(b *blockStats) error {
+	period := a.granularity.period(b)
+
+	if a.open != nil && a.open.Period != period {
+		if err := a.flush(); err != nil {
+			return err
+		}
+	}
+
+	if a.open == nil {
+		a.open = newAggregate(period, b.number)
+	}
+
+	a.open.add(b)
+
+	return nil
+}
+
+// flush emits the open period
+func (a *aggregator) flush() error {
+	if a.open == nil {
+		return nil
+	}
+
+	if err := a.emit(a.open.row()); err != nil {
+		return err
+	}
+
+	a.open = nil
+
+	return nil
+}
+
+// exportConfig is the range and the layout of an export
+type exportConfig struct {
+	from        uint64
+	to          *uint64
+	granularity granularity
+	format      string
+	out         string
+}
+
+// exportProgress is the state of an interrupted export. The out file holds
+// the rows of the periods before the open one, up to offset
+type exportProgress struct {
+	From        uint64      `json:"from"`
+	To          uint64      `json:"to"`
+	Granularity granularity `json:"granularity"`
+	Format      string      `json:"format"`
+	Next        uint64      `json:"next"`
+	Offset      int64       `json:"offset"`
+	Rows        uint64      `json:"rows"`
+	Open        *aggregate  `json:"open,omitempty"`
+}
+
+// matches checks if the progress belongs to an export with the given config
+func (p *exportProgress) matches(config *exportConfig) bool {
+	return p.From == config.from && p.Granularity == config.granularity && p.Format == config.format &&
+		(config.to == nil || *config.to == p.To)
+}
+
+func progressPath(out string) string {
+	return out + ".progress"
+}
+
+// readProgress reads the progress file of the out file, nil if there is none
+func readProgress(out string) (*exportProgress, error) {
+	raw, err := os.ReadFile(progressPath(out))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	progress := &exportProgress{}
+	if err := json.Unmarshal(raw, progress); err != nil {
+		return nil, fmt.Errorf("invalid progress file: %w", err)
+	}
+
+	return progress, nil
+}
+
+// writeProgress replaces the progress file, so an interruption leaves either the old or the new one
+func writeProgress(out string, progress *exportProgress) error {
+	raw, err := json.Marshal(progress)
+	if err != nil {
+		return err
+	}
+
+	tmp := progressPath(out) + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, progressPath(out))
+}
+
+// countingWriter counts the bytes written to the out file
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+
+	return n, err
+}
+
+// exportStats aggregates the blocks of the range and writes a row per period to the out file.
+// The progress is saved every progressInterval blocks, an export with the same config
+// continues from the last save. The progress file is removed once the export is complete
+func exportStats(source blockSource, config *exportConfig) (*ExportStatsResult, error) {
+	progress, err := readProgress(config.out)
+	if err != nil {
+		return nil, err
+	}
+
+	resumed := progress != nil && progress.matches(config)
+
+	if !resumed {
+		to := config.to
+		if to == nil {
+			head, err := source.headNumber()
+			if err != nil {
+				return nil, err
+			}
+
+			to = &head
+		}
+
+		if config.from > *to {
+			return nil, errInvalidRange
+		}
+
+		progress = &exportProgress{
+			From:        config.from,
+			To:          *to,
+			Granularity: config.granularity,
+			Format:      config.format,
+			Next:        config.from,
+		}
+	}
+
+	file, err := os.OpenFile(config.out, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	// drop the rows written after the last save
+	if err := file.Truncate(progress.Offset); err != nil {
+		return nil, err
+	}
+
+	if _, err := file.Seek(progress.Offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	counter := &countingWriter{w: file, n: progress.Offset}
+	buf := bufio.NewWriter(counter)
+	csvWriter := csv.NewWriter(buf)
+	encoder := json.NewEncoder(buf)
+
+	if config.format == formatCSV && progress.Offset == 0 {
+		if err := csvWriter.Write(csvHeader); err != nil {
+			return nil, err
+		}
+	}
+
+	agg := &aggregator{
+		granularity: config.granularity,
+		open:        progress.Open,
+		emit: func(r *row) error {
+			progress.Rows++
+
+			if config.format == formatJSON {
+				return encoder.Encode(r)
+			}
+
+			return csvWriter.Write(r.csvRecord())
+		},
+	}
+
+	// save writes the buffered rows before the progress which points past them
+	save := func() error {
+		csvWriter.Flush()
+
+		if err := csvWriter.Error(); err != nil {
+			return err
+		}
+
+		if err := buf.Flush(); err != nil {
+			return err
+		}
+
+		if err := file.Sync(); err != nil {
+			return err
+		}
+
+		progress.Offset = counter.n
+		progress.Open = agg.open
+
+		return writeProgress(config.out, progress)
+	}
+
+	for progress.Next <= progress.To {
+		block, err := source.block(progress.Next)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := agg.add(block); err != nil {
+			return nil, err
+		}
+
+		progress.Next++
+
+		if (progress.Next-progress.From)%progressInterval == 0 {
+			if err := save(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := agg.flush(); err != nil {
+		return nil, err
+	}
+
+	if err := save(); err != nil {
+		return nil, err
+	}
+
+	if err := os.Remove(progressPath(config.out)); err != nil {
+		return nil, err
+	}
+
+	return &ExportStatsResult{
+		Out:         config.out,
+		From:        progress.From,
+		To:          progress.To,
+		Granularity: string(progress.Granularity),
+		Rows:        progress.Rows,
+		Resumed:     resumed,
+	}, nil
+}
@@ -0,0 +1,215 @@
+package export
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+// testSource serves blocks 12 seconds apart, failing at failAt if it is set
+type testSource struct {
+	start  time.Time
+	head   uint64
+	failAt *uint64
+}
+
+func (s *testSource) headNumber() (uint64, error) {
+	return s.head, nil
+}
+
+func (s *testSource) block(number uint64) (*blockStats, error) {
+	if s.failAt != nil && *s.failAt == number {
+		return nil, errors.New("connection lost")
+	}
+
+	econ := types.NewBlockEconomics()
+	econ.TotalBurned.SetUint64(10)
+	econ.TotalDonated.SetUint64(number)
+
+	return &blockStats{
+		number:    number,
+		timestamp: uint64(s.start.Add(time.Duration(number) * 12 * time.Second).Unix()),
+		gasUsed:   100,
+		baseFee:   number,
+		txCount:   1,
+		economics: econ,
+	}, nil
+}
+
+func TestAggregator_PeriodBoundaries(t *testing.T) {
+	t.Parallel()
+
+	block := func(number uint64, at string) *blockStats {
+		timestamp, err := time.Parse(time.RFC3339, at)
+		require.NoError(t, err)
+
+		return &blockStats{
+			number:    number,
+			timestamp: uint64(timestamp.Unix()),
+			gasUsed:   number * 10,
+			baseFee:   number,
+			txCount:   1,
+		}
+	}
+
+	blocks := []*blockStats{
+		block(1, "2026-03-01T22:59:59Z"),
+		block(2, "2026-03-01T23:00:00Z"),
+		block(3, "2026-03-01T23:59:59Z"),
+		block(4, "2026-03-02T00:00:00Z"),
+		block(5, "2026-03-02T00:00:01Z"),
+	}
+
+	aggregate := func(g granularity) []*row {
+		var rows []*row
+
+		agg := &aggregator{
+			granularity: g,
+			emit: func(r *row) error {
+				rows = append(rows, r)
+
+				return nil
+			},
+		}
+
+		for _, b := range blocks {
+			require.NoError(t, agg.add(b))
+		}
+
+		require.NoError(t, agg.flush())
+
+		return rows
+	}
+
+	t.Run("hour", func(t *testing.T) {
+		t.Parallel()
+
+		rows := aggregate(granularityHour)
+		require.Len(t, rows, 3)
+
+		require.Equal(t, "2026-03-01T22:00:00Z", rows[0].Period)
+		require.Equal(t, "2026-03-01T23:00:00Z", rows[1].Period)
+		require.Equal(t, "2026-03-02T00:00:00Z", rows[2].Period)
+
+		require.Equal(t, uint64(2), rows[1].FromBlock)
+		require.Equal(t, uint64(3), rows[1].ToBlock)
+		require.Equal(t, uint64(2), rows[1].Blocks)
+		require.Equal(t, uint64(50), rows[1].GasUsed)
+		require.Equal(t, "2", rows[1].AvgBaseFeeWei)
+
+		// the economics of the blocks aren't available
+		require.Equal(t, uint64(2), rows[1].MissingEconomics)
+		require.Equal(t, "0", rows[1].BurnedWei)
+	})
+
+	t.Run("day", func(t *testing.T) {
+		t.Parallel()
+
+		rows := aggregate(granularityDay)
+		require.Len(t, rows, 2)
+
+		require.Equal(t, "2026-03-01", rows[0].Period)
+		require.Equal(t, uint64(1), rows[0].FromBlock)
+		require.Equal(t, uint64(3), rows[0].ToBlock)
+		require.Equal(t, uint64(60), rows[0].GasUsed)
+
+		require.Equal(t, "2026-03-02", rows[1].Period)
+		require.Equal(t, uint64(4), rows[1].FromBlock)
+		require.Equal(t, uint64(5), rows[1].ToBlock)
+		require.Equal(t, uint64(2), rows[1].TxCount)
+	})
+
+	t.Run("block", func(t *testing.T) {
+		t.Parallel()
+
+		rows := aggregate(granularityBlock)
+		require.Len(t, rows, len(blocks))
+
+		for i, r := range rows {
+			require.Equal(t, fmt.Sprint(i+1), r.Period)
+			require.Equal(t, uint64(1), r.Blocks)
+		}
+	})
+}
+
+func TestExportStats_Resume(t *testing.T) {
+	prevInterval := progressInterval
+	t.Cleanup(func() {
+		progressInterval = prevInterval
+	})
+
+	progressInterval = 100
+
+	dir := t.TempDir()
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	// 12 second blocks, 300 per hour
+	const head = 1000
+
+	export := func(out string, source *testSource) (*ExportStatsResult, error) {
+		return exportStats(source, &exportConfig{
+			from:        0,
+			granularity: granularityHour,
+			format:      formatCSV,
+			out:         out,
+		})
+	}
+
+	readRows := func(out string) [][]string {
+		file, err := os.Open(out)
+		require.NoError(t, err)
+
+		defer file.Close()
+
+		records, err := csv.NewReader(file).ReadAll()
+		require.NoError(t, err)
+
+		return records
+	}
+
+	complete := filepath.Join(dir, "complete.csv")
+
+	result, err := export(complete, &testSource{start: start, head: head})
+	require.NoError(t, err)
+	require.False(t, result.Resumed)
+	require.Equal(t, uint64(head), result.To)
+	require.Equal(t, uint64(4), result.Rows)
+
+	expected := readRows(complete)
+	require.Len(t, expected, 5)
+	require.Equal(t, csvHeader, expected[0])
+	require.Equal(t, []string{
+		"2026-03-01T00:00:00Z", "0", "299", "300", "300", "0", "30000",
+		"149", "3000", "44850", "0", "0",
+	}, expected[1])
+
+	_, err = os.Stat(progressPath(complete))
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	interrupted := filepath.Join(dir, "interrupted.csv")
+	failAt := uint64(750)
+
+	_, err = export(interrupted, &testSource{start: start, head: head, failAt: &failAt})
+	require.Error(t, err)
+
+	progress, err := readProgress(interrupted)
+	require.NoError(t, err)
+	require.Equal(t, uint64(700), progress.Next)
+	require.NotNil(t, progress.Open)
+
+	// the head moved on, the resumed export keeps the range of the interrupted one
+	result, err = export(interrupted, &testSource{start: start, head: head + 500})
+	require.NoError(t, err)
+	require.True(t, result.Resumed)
+	require.Equal(t, uint64(head), result.To)
+	require.Equal(t, uint64(4), result.Rows)
+
+	require.Equal(t, expected, readRows(interrupted))
+}
@@ -0,0 +1,34 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/xgr-network/xgr-node/command/helper"
+)
+
+type ExportStatsResult struct {
+	Out         string `json:"out"`
+	From        uint64 `json:"from"`
+	To          uint64 `json:"to"`
+	Granularity string `json:"granularity"`
+	Rows        uint64 `json:"rows"`
+	Resumed     bool   `json:"resumed"`
+}
+
+func (r *ExportStatsResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[STATS EXPORT]\n")
+	buffer.WriteString("Exported chain statistics successfully:\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("File|%s", r.Out),
+		fmt.Sprintf("From|%d", r.From),
+		fmt.Sprintf("To|%d", r.To),
+		fmt.Sprintf("Granularity|%s", r.Granularity),
+		fmt.Sprintf("Rows|%d", r.Rows),
+		fmt.Sprintf("Resumed|%t", r.Resumed),
+	}))
+
+	return buffer.String()
+}
@@ -0,0 +1,228 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/xgr-network/xgr-node/blockchain"
+	"github.com/xgr-network/xgr-node/blockchain/storage"
+	"github.com/xgr-network/xgr-node/helper/hex"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+var errHeadNotFound = errors.New("head block not found")
+
+// blockStats are the figures of a single block
+type blockStats struct {
+	number    uint64
+	timestamp uint64
+	gasUsed   uint64
+	baseFee   uint64
+	txCount   uint64
+
+	// economics are the fee split aggregates, nil if they aren't available for the block
+	economics *types.BlockEconomics
+}
+
+// blockSource reads the blocks of the export
+type blockSource interface {
+	headNumber() (uint64, error)
+	block(number uint64) (*blockStats, error)
+}
+
+// storageSource reads the blocks from the blockchain storage of a stopped node
+type storageSource struct {
+	db storage.Storage
+}
+
+func (s *storageSource) headNumber() (uint64, error) {
+	number, ok := s.db.ReadHeadNumber()
+	if !ok {
+		return 0, errHeadNotFound
+	}
+
+	return number, nil
+}
+
+// block reads the header and the body of the block. Blocks imported before the economics
+// were stored are computed from their receipts
+func (s *storageSource) block(number uint64) (*blockStats, error) {
+	hash, ok := s.db.ReadCanonicalHash(number)
+	if !ok {
+		return nil, fmt.Errorf("block %d not found", number)
+	}
+
+	header, err := s.db.ReadHeader(hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header %d: %w", number, err)
+	}
+
+	body, err := s.db.ReadBody(hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read body %d: %w", number, err)
+	}
+
+	stats := &blockStats{
+		number:    number,
+		timestamp: header.Timestamp,
+		gasUsed:   header.GasUsed,
+		baseFee:   header.BaseFee,
+		txCount:   uint64(len(body.Transactions)),
+	}
+
+	if econ, ok := s.db.ReadBlockEconomics(hash); ok {
+		stats.economics = econ
+	} else if len(body.Transactions) == 0 {
+		stats.economics = types.NewBlockEconomics()
+	} else if receipts, err := s.db.ReadReceipts(hash); err == nil && len(receipts) == len(body.Transactions) {
+		stats.economics = blockchain.ComputeBlockEconomics(body.Transactions, receipts)
+	}
+
+	return stats, nil
+}
+
+// rpcClient is the part of the JSON-RPC client used to read the blocks
+type rpcClient interface {
+	Call(method string, out interface{}, params ...interface{}) error
+}
+
+// rpcSource reads the blocks from a JSON-RPC endpoint, with at most one request per interval
+type rpcSource struct {
+	client   rpcClient
+	interval time.Duration
+	last     time.Time
+}
+
+func newRPCSource(client rpcClient, interval time.Duration) *rpcSource {
+	return &rpcSource{
+		client:   client,
+		interval: interval,
+	}
+}
+
+// rpcBlock is the part of an eth_getBlockByNumber response used by the export
+type rpcBlock struct {
+	Timestamp    string   `json:"timestamp"`
+	GasUsed      string   `json:"gasUsed"`
+	BaseFee      string   `json:"baseFeePerGas"`
+	Transactions []string `json:"transactions"`
+}
+
+// rpcBlockEconomics is a xgr_getBlockEconomics response
+type rpcBlockEconomics struct {
+	TotalBurnedWei        string `json:"totalBurnedWei"`
+	TotalDonatedWei       string `json:"totalDonatedWei"`
+	TotalValidatorFeesWei string `json:"totalValidatorFeesWei"`
+	EngineTxCount         string `json:"engineTxCount"`
+}
+
+// call waits for the rate limit and sends the request
+func (s *rpcSource) call(method string, out interface{}, params ...interface{}) error {
+	if wait := s.interval - time.Since(s.last); wait > 0 {
+		time.Sleep(wait)
+	}
+
+	s.last = time.Now()
+
+	if err := s.client.Call(method, out, params...); err != nil {
+		return fmt.Errorf("%s failed: %w", method, err)
+	}
+
+	return nil
+}
+
+func (s *rpcSource) headNumber() (uint64, error) {
+	var number string
+
+	if err := s.call("eth_blockNumber", &number); err != nil {
+		return 0, err
+	}
+
+	return hex.DecodeUint64(number)
+}
+
+// block reads the block and, if it has transactions, its economics.
+// A node which doesn't have the economics of the block reports them as unavailable
+func (s *rpcSource) block(number uint64) (*blockStats, error) {
+	var block *rpcBlock
+
+	if err := s.call("eth_getBlockByNumber", &block, hex.EncodeUint64(number), false); err != nil {
+		return nil, err
+	}
+
+	if block == nil {
+		return nil, fmt.Errorf("block %d not found", number)
+	}
+
+	stats := &blockStats{
+		number:  number,
+		txCount: uint64(len(block.Transactions)),
+	}
+
+	var err error
+
+	if stats.timestamp, err = hex.DecodeUint64(block.Timestamp); err != nil {
+		return nil, fmt.Errorf("invalid timestamp of block %d: %w", number, err)
+	}
+
+	if stats.gasUsed, err = hex.DecodeUint64(block.GasUsed); err != nil {
+		return nil, fmt.Errorf("invalid gas used of block %d: %w", number, err)
+	}
+
+	if block.BaseFee != "" {
+		if stats.baseFee, err = hex.DecodeUint64(block.BaseFee); err != nil {
+			return nil, fmt.Errorf("invalid base fee of block %d: %w", number, err)
+		}
+	}
+
+	if stats.txCount == 0 {
+		stats.economics = types.NewBlockEconomics()
+
+		return stats, nil
+	}
+
+	var econ rpcBlockEconomics
+
+	if err := s.call("xgr_getBlockEconomics", &econ, hex.EncodeUint64(number)); err != nil {
+		if strings.Contains(err.Error(), "not available") {
+			return stats, nil
+		}
+
+		return nil, err
+	}
+
+	if stats.economics, err = econ.toBlockEconomics(); err != nil {
+		return nil, fmt.Errorf("invalid economics of block %d: %w", number, err)
+	}
+
+	return stats, nil
+}
+
+func (e *rpcBlockEconomics) toBlockEconomics() (*types.BlockEconomics, error) {
+	var (
+		econ = types.NewBlockEconomics()
+		err  error
+	)
+
+	for _, field := range []struct {
+		raw   string
+		value **big.Int
+	}{
+		{e.TotalBurnedWei, &econ.TotalBurned},
+		{e.TotalDonatedWei, &econ.TotalDonated},
+		{e.TotalValidatorFeesWei, &econ.TotalValidatorFees},
+	} {
+		if *field.value, err = hex.DecodeHexToBig(field.raw); err != nil {
+			return nil, err
+		}
+	}
+
+	if econ.EngineTxCount, err = hex.DecodeUint64(e.EngineTxCount); err != nil {
+		return nil, err
+	}
+
+	return econ, nil
+}
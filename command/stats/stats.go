@@ -0,0 +1,27 @@
+package stats
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/xgr-network/xgr-node/command/helper"
+	"github.com/xgr-network/xgr-node/command/stats/export"
+)
+
+func GetCommand() *cobra.Command {
+	statsCmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Top level command for exporting chain statistics. Only accepts subcommands.",
+	}
+
+	helper.RegisterJSONRPCFlag(statsCmd)
+
+	registerSubcommands(statsCmd)
+
+	return statsCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// stats export
+		export.GetCommand(),
+	)
+}
@@ -0,0 +1,70 @@
+package dump
+
+import (
+	"github.com/xgr-network/xgr-node/helper/hex"
+	txpoolProto "github.com/xgr-network/xgr-node/txpool/proto"
+)
+
+// Account is the entry of an account in a pool dump file,
+// the file holds a JSON array of the accounts
+type Account struct {
+	Address string `json:"address"`
+	Nonce   uint64 `json:"nonce"`
+	Txns    []Txn  `json:"txns"`
+}
+
+// Txn is a transaction of an account in a pool dump file
+type Txn struct {
+	Hash    string `json:"hash"`
+	Raw     string `json:"raw"`
+	Origin  string `json:"origin"`
+	Status  string `json:"status"`
+	AddedAt int64  `json:"addedAt"`
+}
+
+// FromProto returns the dump file entry of a dumped account
+func FromProto(account *txpoolProto.TxPoolDumpAccount) *Account {
+	entry := &Account{
+		Address: account.Address,
+		Nonce:   account.Nonce,
+		Txns:    make([]Txn, 0, len(account.Txns)),
+	}
+
+	for _, txn := range account.Txns {
+		entry.Txns = append(entry.Txns, Txn{
+			Hash:    txn.Hash,
+			Raw:     hex.EncodeToHex(txn.Raw),
+			Origin:  txn.Origin,
+			Status:  txn.Status,
+			AddedAt: txn.AddedAt,
+		})
+	}
+
+	return entry
+}
+
+// ToProto returns the dumped account of a dump file entry
+func (a *Account) ToProto() (*txpoolProto.TxPoolDumpAccount, error) {
+	account := &txpoolProto.TxPoolDumpAccount{
+		Address: a.Address,
+		Nonce:   a.Nonce,
+		Txns:    make([]*txpoolProto.TxPoolDumpTxn, 0, len(a.Txns)),
+	}
+
+	for _, txn := range a.Txns {
+		raw, err := hex.DecodeHex(txn.Raw)
+		if err != nil {
+			return nil, err
+		}
+
+		account.Txns = append(account.Txns, &txpoolProto.TxPoolDumpTxn{
+			Hash:    txn.Hash,
+			Raw:     raw,
+			Origin:  txn.Origin,
+			Status:  txn.Status,
+			AddedAt: txn.AddedAt,
+		})
+	}
+
+	return account, nil
+}
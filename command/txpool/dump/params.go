@@ -0,0 +1,19 @@
+package dump
+
+const (
+	outFlag = "out"
+)
+
+var (
+	params = &dumpParams{}
+)
+
+type dumpParams struct {
+	out string
+}
+
+func (p *dumpParams) getRequiredFlags() []string {
+	return []string{
+		outFlag,
+	}
+}
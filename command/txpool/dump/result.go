@@ -0,0 +1,28 @@
+package dump
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/xgr-network/xgr-node/command/helper"
+)
+
+type TxPoolDumpResult struct {
+	Out          string `json:"out"`
+	Accounts     uint64 `json:"accounts"`
+	Transactions uint64 `json:"transactions"`
+}
+
+func (r *TxPoolDumpResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[TXPOOL DUMP]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("File|%s", r.Out),
+		fmt.Sprintf("Accounts|%d", r.Accounts),
+		fmt.Sprintf("Transactions|%d", r.Transactions),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
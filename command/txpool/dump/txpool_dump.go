@@ -0,0 +1,112 @@
+package dump
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/xgr-network/xgr-node/command"
+	"github.com/xgr-network/xgr-node/command/helper"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+func GetCommand() *cobra.Command {
+	txPoolDumpCmd := &cobra.Command{
+		Use:   "dump",
+		Short: "Writes the transactions in the transaction pool to a JSON file",
+		Run:   runCommand,
+	}
+
+	setFlags(txPoolDumpCmd)
+	helper.SetRequiredFlags(txPoolDumpCmd, params.getRequiredFlags())
+
+	return txPoolDumpCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.out,
+		outFlag,
+		"",
+		"the path of the dump file",
+	)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	result, err := dumpTxPool(helper.GetGRPCAddress(cmd), params.out)
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(result)
+}
+
+// dumpTxPool writes the streamed accounts to the dump file as they are received,
+// so large pools are never held in memory at once
+func dumpTxPool(grpcAddress, out string) (*TxPoolDumpResult, error) {
+	client, err := helper.GetTxPoolClientConnection(
+		grpcAddress,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := client.Dump(context.Background(), &empty.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Create(out)
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	result := &TxPoolDumpResult{Out: out}
+
+	if _, err := file.WriteString("["); err != nil {
+		return nil, err
+	}
+
+	for {
+		account, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read account: %w", err)
+		}
+
+		raw, err := json.Marshal(FromProto(account))
+		if err != nil {
+			return nil, err
+		}
+
+		separator := ",\n"
+		if result.Accounts == 0 {
+			separator = "\n"
+		}
+
+		if _, err := file.WriteString(separator + string(raw)); err != nil {
+			return nil, err
+		}
+
+		result.Accounts++
+		result.Transactions += uint64(len(account.Txns))
+	}
+
+	if _, err := file.WriteString("\n]\n"); err != nil {
+		return nil, err
+	}
+
+	return result, file.Sync()
+}
@@ -0,0 +1,19 @@
+package load
+
+const (
+	fileFlag = "file"
+)
+
+var (
+	params = &loadParams{}
+)
+
+type loadParams struct {
+	file string
+}
+
+func (p *loadParams) getRequiredFlags() []string {
+	return []string{
+		fileFlag,
+	}
+}
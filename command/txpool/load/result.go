@@ -0,0 +1,28 @@
+package load
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/xgr-network/xgr-node/command/helper"
+)
+
+type TxPoolLoadResult struct {
+	File   string `json:"file"`
+	Added  uint64 `json:"added"`
+	Failed uint64 `json:"failed"`
+}
+
+func (r *TxPoolLoadResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[TXPOOL LOAD]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("File|%s", r.File),
+		fmt.Sprintf("Added transactions|%d", r.Added),
+		fmt.Sprintf("Failed transactions|%d", r.Failed),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
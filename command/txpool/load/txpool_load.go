@@ -0,0 +1,108 @@
+package load
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/xgr-network/xgr-node/command"
+	"github.com/xgr-network/xgr-node/command/helper"
+	"github.com/xgr-network/xgr-node/command/txpool/dump"
+)
+
+var errInvalidDumpFile = errors.New("invalid txpool dump file, expected a JSON array of accounts")
+
+func GetCommand() *cobra.Command {
+	txPoolLoadCmd := &cobra.Command{
+		Use:   "load",
+		Short: "Adds the transactions of a txpool dump file to the transaction pool. Only accepted by dev consensus nodes",
+		Run:   runCommand,
+	}
+
+	setFlags(txPoolLoadCmd)
+	helper.SetRequiredFlags(txPoolLoadCmd, params.getRequiredFlags())
+
+	return txPoolLoadCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.file,
+		fileFlag,
+		"",
+		"the path of the dump file written by txpool dump",
+	)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	result, err := loadTxPool(helper.GetGRPCAddress(cmd), params.file)
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(result)
+}
+
+// loadTxPool streams the accounts of the dump file to the node as they are decoded
+func loadTxPool(grpcAddress, file string) (*TxPoolLoadResult, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	decoder := json.NewDecoder(f)
+
+	if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+		return nil, errInvalidDumpFile
+	}
+
+	client, err := helper.GetTxPoolClientConnection(
+		grpcAddress,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := client.Load(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	for decoder.More() {
+		var account dump.Account
+		if err := decoder.Decode(&account); err != nil {
+			return nil, fmt.Errorf("failed to decode account: %w", err)
+		}
+
+		entry, err := account.ToProto()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode the transactions of %s: %w", account.Address, err)
+		}
+
+		if err := stream.Send(entry); err != nil {
+			// the node's error is returned when closing the stream
+			break
+		}
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return nil, err
+	}
+
+	return &TxPoolLoadResult{
+		File:   file,
+		Added:  resp.Added,
+		Failed: resp.Failed,
+	}, nil
+}
@@ -8,17 +8,52 @@ import (
 )
 
 type TxPoolStatusResult struct {
-	Transactions uint64 `json:"transactions"`
+	Transactions       uint64                `json:"transactions"`
+	MaxAccountTxs      uint64                `json:"max_account_txs"`
+	MaxAccountEnqueued uint64                `json:"max_account_enqueued"`
+	Accounts           []AccountStatusResult `json:"accounts"`
+}
+
+type AccountStatusResult struct {
+	Address  string `json:"address"`
+	Promoted uint64 `json:"promoted"`
+	Enqueued uint64 `json:"enqueued"`
 }
 
 func (r *TxPoolStatusResult) GetOutput() string {
 	var buffer bytes.Buffer
 
+	maxAccountTxs := "unlimited"
+	if r.MaxAccountTxs > 0 {
+		maxAccountTxs = fmt.Sprintf("%d", r.MaxAccountTxs)
+	}
+
 	buffer.WriteString("\n[TXPOOL STATUS]\n")
 	buffer.WriteString(helper.FormatKV([]string{
 		fmt.Sprintf("Number of transactions in pool:|%d", r.Transactions),
+		fmt.Sprintf("Max transactions per account:|%s", maxAccountTxs),
+		fmt.Sprintf("Max enqueued transactions per account:|%d", r.MaxAccountEnqueued),
 	}))
 	buffer.WriteString("\n")
 
+	r.writeAccounts(&buffer)
+
 	return buffer.String()
 }
+
+func (r *TxPoolStatusResult) writeAccounts(buffer *bytes.Buffer) {
+	accounts := make([]string, len(r.Accounts)+1)
+	accounts[0] = "No accounts found"
+
+	if len(r.Accounts) > 0 {
+		accounts[0] = "ADDRESS|PROMOTED|ENQUEUED"
+
+		for i, a := range r.Accounts {
+			accounts[i+1] = fmt.Sprintf("%s|%d|%d", a.Address, a.Promoted, a.Enqueued)
+		}
+	}
+
+	buffer.WriteString("\n[ACCOUNTS]\n")
+	buffer.WriteString(helper.FormatList(accounts))
+	buffer.WriteString("\n")
+}
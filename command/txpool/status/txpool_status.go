@@ -14,7 +14,7 @@ import (
 func GetCommand() *cobra.Command {
 	return &cobra.Command{
 		Use:   "status",
-		Short: "Returns the number of transactions in the transaction pool",
+		Short: "Returns the number of transactions in the transaction pool and the per account counts",
 		Run:   runCommand,
 	}
 }
@@ -30,8 +30,20 @@ func runCommand(cmd *cobra.Command, _ []string) {
 		return
 	}
 
+	accounts := make([]AccountStatusResult, 0, len(statusResponse.Accounts))
+	for _, a := range statusResponse.Accounts {
+		accounts = append(accounts, AccountStatusResult{
+			Address:  a.Address,
+			Promoted: a.Promoted,
+			Enqueued: a.Enqueued,
+		})
+	}
+
 	outputter.SetCommandResult(&TxPoolStatusResult{
-		Transactions: statusResponse.Length,
+		Transactions:       statusResponse.Length,
+		MaxAccountTxs:      statusResponse.MaxAccountTxs,
+		MaxAccountEnqueued: statusResponse.MaxAccountEnqueued,
+		Accounts:           accounts,
 	})
 }
 
@@ -3,6 +3,8 @@ package txpool
 import (
 	"github.com/spf13/cobra"
 	"github.com/xgr-network/xgr-node/command/helper"
+	"github.com/xgr-network/xgr-node/command/txpool/dump"
+	"github.com/xgr-network/xgr-node/command/txpool/load"
 	"github.com/xgr-network/xgr-node/command/txpool/status"
 	"github.com/xgr-network/xgr-node/command/txpool/subscribe"
 )
@@ -26,5 +28,9 @@ func registerSubcommands(baseCmd *cobra.Command) {
 		status.GetCommand(),
 		// txpool subscribe
 		subscribe.GetCommand(),
+		// txpool dump
+		dump.GetCommand(),
+		// txpool load
+		load.GetCommand(),
 	)
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/xgr-network/xgr-node/network"
 	"github.com/xgr-network/xgr-node/secrets"
 	"github.com/xgr-network/xgr-node/state"
+	"github.com/xgr-network/xgr-node/syncer"
 	"github.com/xgr-network/xgr-node/txpool"
 	"github.com/xgr-network/xgr-node/types"
 	"google.golang.org/grpc"
@@ -68,6 +69,17 @@ type Config struct {
 	// IsRelayer is true if node is relayer
 	IsRelayer bool
 
+	// ShadowFork is true if the node must never take part in block production
+	ShadowFork bool
+
+	// RewardAddress is the address the validator expects to be credited with
+	// the fees of its blocks, zero if the signing address is used
+	RewardAddress types.Address
+
+	// EnginePriorityGasShare is the share (in percent) of the block gas limit filled with the
+	// transactions of authorized engine EOAs ahead of all other transactions, 0 disables it
+	EnginePriorityGasShare uint64
+
 	// RPCEndpoint
 	RPCEndpoint string
 }
@@ -86,6 +98,7 @@ type Params struct {
 
 	NumBlockConfirmations uint64
 	MetricsInterval       time.Duration
+	FutureBlocks          syncer.FutureBlocksConfig
 }
 
 // Factory is the factory function to create a discovery consensus
@@ -10,6 +10,7 @@ import (
 	"github.com/xgr-network/xgr-node/helper/progress"
 	"github.com/xgr-network/xgr-node/state"
 	"github.com/xgr-network/xgr-node/txpool"
+	"github.com/xgr-network/xgr-node/txpool/proto"
 	"github.com/xgr-network/xgr-node/types"
 )
 
@@ -17,18 +18,33 @@ const (
 	devConsensus = "dev-consensus"
 )
 
-// Dev consensus protocol seals any new transaction immediately
+var _ consensus.BlockPreviewer = (*Dev)(nil)
+
+// Dev consensus protocol seals the pool transactions in a fixed interval.
+// In instant mode, a block is also sealed as soon as a transaction becomes executable.
 type Dev struct {
 	logger hclog.Logger
 
-	notifyCh chan struct{}
-	closeCh  chan struct{}
+	closeCh chan struct{}
 
 	interval uint64
+	instant  bool
 	txpool   *txpool.TxPool
 
 	blockchain *blockchain.Blockchain
 	executor   *state.Executor
+
+	// txEvents notifies about transactions entering the pool in instant mode
+	txEvents    txSubscriber
+	txCh        <-chan *proto.TxPoolEvent
+	unsubscribe func()
+
+	// sealFn seals a block on top of the current head, skipping it if it would be empty and skipEmpty is set
+	sealFn func(skipEmpty bool) error
+}
+
+type txSubscriber interface {
+	TxPoolSubscribe(request *proto.SubscribeRequest) (<-chan *proto.TxPoolEvent, func(), error)
 }
 
 // Factory implements the base factory method
@@ -39,11 +55,15 @@ func Factory(
 
 	d := &Dev{
 		logger:     logger,
-		notifyCh:   make(chan struct{}),
 		closeCh:    make(chan struct{}),
 		blockchain: params.Blockchain,
 		executor:   params.Executor,
 		txpool:     params.TxPool,
+		txEvents:   params.TxPool,
+	}
+
+	d.sealFn = func(skipEmpty bool) error {
+		return d.writeNewBlock(d.blockchain.Header(), skipEmpty)
 	}
 
 	rawInterval, ok := params.Config.Config["interval"]
@@ -56,6 +76,15 @@ func Factory(
 		d.interval = interval
 	}
 
+	if rawInstant, ok := params.Config.Config["instant"]; ok {
+		instant, ok := rawInstant.(bool)
+		if !ok {
+			return nil, fmt.Errorf("instant expected bool")
+		}
+
+		d.instant = instant
+	}
+
 	return d, nil
 }
 
@@ -68,40 +97,73 @@ func (d *Dev) Initialize() error {
 
 // Start starts the consensus mechanism
 func (d *Dev) Start() error {
+	if d.instant {
+		// promoted transactions are executable, so they make it into the next block
+		txCh, unsubscribe, err := d.txEvents.TxPoolSubscribe(&proto.SubscribeRequest{
+			Types: []proto.EventType{proto.EventType_PROMOTED},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to subscribe to the txpool: %w", err)
+		}
+
+		d.txCh = txCh
+		d.unsubscribe = unsubscribe
+	}
+
 	go d.run()
 
 	return nil
 }
 
-func (d *Dev) nextNotify() chan struct{} {
+func (d *Dev) blockInterval() time.Duration {
 	if d.interval == 0 {
 		d.interval = 1
 	}
 
-	go func() {
-		<-time.After(time.Duration(d.interval) * time.Second)
-		d.notifyCh <- struct{}{}
-	}()
-
-	return d.notifyCh
+	return time.Duration(d.interval) * time.Second
 }
 
 func (d *Dev) run() {
-	d.logger.Info("consensus started")
+	d.logger.Info("consensus started", "interval", d.interval, "instant", d.instant)
+
+	timer := time.NewTimer(d.blockInterval())
+	defer timer.Stop()
+
+	// a nil channel never fires, so without instant mode only the timer seals blocks
+	txCh := d.txCh
 
 	for {
-		// wait until there is a new txn
+		skipEmpty := false
+
 		select {
-		case <-d.nextNotify():
+		case <-timer.C:
+		case _, ok := <-txCh:
+			if !ok {
+				txCh = nil
+
+				continue
+			}
+
+			// the transactions of a burst are sealed by the first event,
+			// the following events must not produce empty blocks
+			skipEmpty = true
 		case <-d.closeCh:
 			return
 		}
 
-		// There are new transactions in the pool, try to seal them
-		header := d.blockchain.Header()
-		if err := d.writeNewBlock(header); err != nil {
+		if err := d.sealFn(skipEmpty); err != nil {
 			d.logger.Error("failed to mine block", "err", err)
 		}
+
+		// the interval is measured from the last block
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+
+		timer.Reset(d.blockInterval())
 	}
 }
 
@@ -109,19 +171,29 @@ type transitionInterface interface {
 	Write(txn *types.Transaction) error
 }
 
-func (d *Dev) writeTransactions(gasLimit uint64, transition transitionInterface) []*types.Transaction {
+// txPool is the part of the pool which fills a block, the pool itself or a preview copy
+type txPool interface {
+	Prepare()
+	Peek() *types.Transaction
+	Pop(tx *types.Transaction)
+	Drop(tx *types.Transaction)
+	Demote(tx *types.Transaction)
+	Length() uint64
+}
+
+func (d *Dev) writeTransactions(pool txPool, gasLimit uint64, transition transitionInterface) []*types.Transaction {
 	var successful []*types.Transaction
 
-	d.txpool.Prepare()
+	pool.Prepare()
 
 	for {
-		tx := d.txpool.Peek()
+		tx := pool.Peek()
 		if tx == nil {
 			break
 		}
 
 		if tx.Gas > gasLimit {
-			d.txpool.Drop(tx)
+			pool.Drop(tx)
 
 			continue
 		}
@@ -130,28 +202,31 @@ func (d *Dev) writeTransactions(gasLimit uint64, transition transitionInterface)
 			if _, ok := err.(*state.GasLimitReachedTransitionApplicationError); ok { //nolint:errorlint
 				break
 			} else if appErr, ok := err.(*state.TransitionApplicationError); ok && appErr.IsRecoverable { //nolint:errorlint
-				d.txpool.Demote(tx)
+				pool.Demote(tx)
 			} else {
-				d.txpool.Drop(tx)
+				pool.Drop(tx)
 			}
 
 			continue
 		}
 
 		// no errors, pop the tx from the pool
-		d.txpool.Pop(tx)
+		pool.Pop(tx)
 
 		successful = append(successful, tx)
 	}
 
-	d.logger.Info("picked out txns from pool", "num", len(successful), "remaining", d.txpool.Length())
+	d.logger.Info("picked out txns from pool", "num", len(successful), "remaining", pool.Length())
 
 	return successful
 }
 
-// writeNewBLock generates a new block based on transactions from the pool,
-// and writes them to the blockchain
-func (d *Dev) writeNewBlock(parent *types.Header) error {
+// executeBlock executes the transactions of the pool in a new block on top of the parent,
+// it returns the header of the block, its transition and the included transactions
+func (d *Dev) executeBlock(
+	parent *types.Header,
+	pool txPool,
+) (*types.Header, *state.Transition, []*types.Transaction, error) {
 	// Generate the base block
 	num := parent.Number
 	header := &types.Header{
@@ -164,7 +239,7 @@ func (d *Dev) writeNewBlock(parent *types.Header) error {
 	// calculate gas limit based on parent header
 	gasLimit, err := d.blockchain.CalculateGasLimit(header.Number)
 	if err != nil {
-		return err
+		return nil, nil, nil, err
 	}
 
 	header.GasLimit = gasLimit
@@ -172,34 +247,59 @@ func (d *Dev) writeNewBlock(parent *types.Header) error {
 
 	miner, err := d.GetBlockCreator(header)
 	if err != nil {
-		return err
+		return nil, nil, nil, err
 	}
 
 	transition, err := d.executor.BeginTxn(parent.StateRoot, header, miner)
 
 	if err != nil {
-		return err
+		return nil, nil, nil, err
 	}
 
-	txns := d.writeTransactions(gasLimit, transition)
+	txns := d.writeTransactions(pool, gasLimit, transition)
 
-	// Commit the changes
-	_, root, err := transition.Commit()
-	if err != nil {
-		return fmt.Errorf("failed to commit the state changes: %w", err)
-	}
+	return header, transition, txns, nil
+}
 
+// buildBlock builds the block of the executed transition with the given state root
+func buildBlock(
+	header *types.Header,
+	transition *state.Transition,
+	txns []*types.Transaction,
+	root types.Hash,
+) *types.Block {
 	// Update the header
 	header.StateRoot = root
 	header.GasUsed = transition.TotalGas()
 
 	// Build the actual block
 	// The header hash is computed inside buildBlock
-	block := consensus.BuildBlock(consensus.BuildBlockParams{
+	return consensus.BuildBlock(consensus.BuildBlockParams{
 		Header:   header,
 		Txns:     txns,
 		Receipts: transition.Receipts(),
 	})
+}
+
+// writeNewBLock generates a new block based on transactions from the pool,
+// and writes them to the blockchain. No block is written if it has no transactions and skipEmpty is set
+func (d *Dev) writeNewBlock(parent *types.Header, skipEmpty bool) error {
+	header, transition, txns, err := d.executeBlock(parent, d.txpool)
+	if err != nil {
+		return err
+	}
+
+	if len(txns) == 0 && skipEmpty {
+		return nil
+	}
+
+	// Commit the changes
+	_, root, err := transition.Commit()
+	if err != nil {
+		return fmt.Errorf("failed to commit the state changes: %w", err)
+	}
+
+	block := buildBlock(header, transition, txns, root)
 
 	if _, err := d.blockchain.VerifyFinalizedBlock(block); err != nil {
 		return err
@@ -217,6 +317,22 @@ func (d *Dev) writeNewBlock(parent *types.Header) error {
 	return nil
 }
 
+// BuildBlockPreview builds the block the next seal would write, with a copy of the pool.
+// The state of the block is not written to the storage
+func (d *Dev) BuildBlockPreview() (*consensus.BlockPreview, error) {
+	header, transition, txns, err := d.executeBlock(d.blockchain.Header(), d.txpool.Preview())
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := transition.Root()
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute the state root: %w", err)
+	}
+
+	return consensus.NewBlockPreview(buildBlock(header, transition, txns, root), transition.Receipts()), nil
+}
+
 // REQUIRED BASE INTERFACE METHODS //
 
 func (d *Dev) VerifyHeader(header *types.Header) error {
@@ -244,6 +360,10 @@ func (d *Dev) GetSyncProgression() *progress.Progression {
 func (d *Dev) Close() error {
 	close(d.closeCh)
 
+	if d.unsubscribe != nil {
+		d.unsubscribe()
+	}
+
 	return nil
 }
 
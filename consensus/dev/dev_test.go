@@ -0,0 +1,212 @@
+package dev
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+	"github.com/xgr-network/xgr-node/state"
+	"github.com/xgr-network/xgr-node/txpool/proto"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+type mockTxSubscriber struct {
+	eventCh chan *proto.TxPoolEvent
+	types   []proto.EventType
+}
+
+func (m *mockTxSubscriber) TxPoolSubscribe(
+	request *proto.SubscribeRequest,
+) (<-chan *proto.TxPoolEvent, func(), error) {
+	m.types = request.Types
+
+	return m.eventCh, func() {}, nil
+}
+
+// sealRecorder records the seal requests of the dev consensus, a block is only
+// sealed if there are pending transactions or empty blocks aren't skipped
+type sealRecorder struct {
+	lock    sync.Mutex
+	pending int
+	blocks  int
+}
+
+func (r *sealRecorder) seal(skipEmpty bool) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.pending == 0 && skipEmpty {
+		return nil
+	}
+
+	r.pending = 0
+	r.blocks++
+
+	return nil
+}
+
+func (r *sealRecorder) addTx() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.pending++
+}
+
+func (r *sealRecorder) sealed() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.blocks
+}
+
+func newTestDev(t *testing.T, instant bool) (*mockTxSubscriber, *sealRecorder) {
+	t.Helper()
+
+	subscriber := &mockTxSubscriber{eventCh: make(chan *proto.TxPoolEvent)}
+	recorder := &sealRecorder{}
+
+	d := &Dev{
+		logger: hclog.NewNullLogger(),
+		// the timer never fires during the test
+		interval: 3600,
+		instant:  instant,
+		closeCh:  make(chan struct{}),
+		txEvents: subscriber,
+		sealFn:   recorder.seal,
+	}
+
+	require.NoError(t, d.Start())
+
+	t.Cleanup(func() {
+		require.NoError(t, d.Close())
+	})
+
+	return subscriber, recorder
+}
+
+func TestDev_InstantMode_SealsOnTransaction(t *testing.T) {
+	t.Parallel()
+
+	subscriber, recorder := newTestDev(t, true)
+
+	require.Equal(t, []proto.EventType{proto.EventType_PROMOTED}, subscriber.types)
+
+	recorder.addTx()
+	subscriber.eventCh <- &proto.TxPoolEvent{Type: proto.EventType_PROMOTED}
+
+	require.Eventually(t, func() bool {
+		return recorder.sealed() == 1
+	}, time.Second, 10*time.Millisecond)
+
+	// the remaining events of a burst don't produce empty blocks
+	subscriber.eventCh <- &proto.TxPoolEvent{Type: proto.EventType_PROMOTED}
+	subscriber.eventCh <- &proto.TxPoolEvent{Type: proto.EventType_PROMOTED}
+
+	recorder.addTx()
+	subscriber.eventCh <- &proto.TxPoolEvent{Type: proto.EventType_PROMOTED}
+
+	require.Eventually(t, func() bool {
+		return recorder.sealed() == 2
+	}, time.Second, 10*time.Millisecond)
+
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(t, 2, recorder.sealed())
+}
+
+func TestDev_IntervalMode_IgnoresTransactions(t *testing.T) {
+	t.Parallel()
+
+	subscriber, recorder := newTestDev(t, false)
+
+	require.Nil(t, subscriber.types)
+
+	recorder.addTx()
+
+	select {
+	case subscriber.eventCh <- &proto.TxPoolEvent{Type: proto.EventType_PROMOTED}:
+		require.FailNow(t, "the dev consensus must not consume txpool events")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	require.Equal(t, 0, recorder.sealed())
+}
+
+// recordingPool serves the given transactions once and records what happens to them
+type recordingPool struct {
+	txs      []*types.Transaction
+	popped   []*types.Transaction
+	dropped  []*types.Transaction
+	demoted  []*types.Transaction
+	prepared bool
+}
+
+func (p *recordingPool) Prepare() { p.prepared = true }
+
+func (p *recordingPool) Peek() *types.Transaction {
+	if len(p.txs) == 0 {
+		return nil
+	}
+
+	return p.txs[0]
+}
+
+func (p *recordingPool) next() { p.txs = p.txs[1:] }
+
+func (p *recordingPool) Pop(tx *types.Transaction) {
+	p.popped = append(p.popped, tx)
+	p.next()
+}
+
+func (p *recordingPool) Drop(tx *types.Transaction) {
+	p.dropped = append(p.dropped, tx)
+	p.next()
+}
+
+func (p *recordingPool) Demote(tx *types.Transaction) {
+	p.demoted = append(p.demoted, tx)
+	p.next()
+}
+
+func (p *recordingPool) Length() uint64 { return uint64(len(p.txs)) }
+
+// nonceTransition accepts the transactions with the expected nonce
+type nonceTransition struct {
+	nonce uint64
+}
+
+func (n *nonceTransition) Write(tx *types.Transaction) error {
+	switch {
+	case tx.Nonce < n.nonce:
+		return state.NewTransitionApplicationError(state.ErrNonceTooLow, false)
+	case tx.Nonce > n.nonce:
+		return state.NewTransitionApplicationError(state.ErrNonceTooHigh, true)
+	}
+
+	n.nonce++
+
+	return nil
+}
+
+func TestDev_WriteTransactions_NonceErrors(t *testing.T) {
+	t.Parallel()
+
+	var (
+		stale = &types.Transaction{Nonce: 1, Gas: 21000}
+		valid = &types.Transaction{Nonce: 2, Gas: 21000}
+		gap   = &types.Transaction{Nonce: 4, Gas: 21000}
+	)
+
+	d := &Dev{logger: hclog.NewNullLogger()}
+	pool := &recordingPool{txs: []*types.Transaction{stale, valid, gap}}
+
+	written := d.writeTransactions(pool, 1_000_000, &nonceTransition{nonce: 2})
+
+	// the used nonce is dropped, the nonce gap only skips the account for this block
+	require.True(t, pool.prepared)
+	require.Equal(t, []*types.Transaction{valid}, written)
+	require.Equal(t, []*types.Transaction{valid}, pool.popped)
+	require.Equal(t, []*types.Transaction{stale}, pool.dropped)
+	require.Equal(t, []*types.Transaction{gap}, pool.demoted)
+}
@@ -1,6 +1,10 @@
 package dummy
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
 	"github.com/hashicorp/go-hclog"
 	"github.com/xgr-network/xgr-node/blockchain"
 	"github.com/xgr-network/xgr-node/consensus"
@@ -10,6 +14,15 @@ import (
 	"github.com/xgr-network/xgr-node/types"
 )
 
+const (
+	dummyConsensus = "dummy"
+)
+
+var errClosed = errors.New("dummy consensus is closed")
+
+// Dummy consensus accepts all blocks. It only seals blocks of its own if a fixed block
+// interval is configured, or when SealBlock is called, which makes it suitable
+// for tests that need to advance the chain deterministically.
 type Dummy struct {
 	logger     hclog.Logger
 	notifyCh   chan struct{}
@@ -17,6 +30,18 @@ type Dummy struct {
 	txpool     *txpool.TxPool
 	blockchain *blockchain.Blockchain
 	executor   *state.Executor
+
+	// interval is the fixed block interval, 0 means blocks are only sealed on SealBlock
+	interval time.Duration
+	// sealCh hands SealBlock requests to the run loop
+	sealCh chan chan sealResult
+	// sealFn seals a block on top of the current head
+	sealFn func() (*types.Block, error)
+}
+
+type sealResult struct {
+	block *types.Block
+	err   error
 }
 
 func Factory(params *consensus.Params) (consensus.Consensus, error) {
@@ -29,6 +54,18 @@ func Factory(params *consensus.Params) (consensus.Consensus, error) {
 		blockchain: params.Blockchain,
 		executor:   params.Executor,
 		txpool:     params.TxPool,
+		sealCh:     make(chan chan sealResult),
+	}
+
+	d.sealFn = d.sealBlock
+
+	if rawInterval, ok := params.Config.Config["interval"]; ok {
+		interval, ok := rawInterval.(uint64)
+		if !ok {
+			return nil, fmt.Errorf("interval expected int")
+		}
+
+		d.interval = time.Duration(interval) * time.Second
 	}
 
 	return d, nil
@@ -83,8 +120,147 @@ func (d *Dummy) FilterExtra(extra []byte) ([]byte, error) {
 	return extra, nil
 }
 
+// SealBlock seals a single block with the pending transactions on top of the current head
+// and returns it once it is written. In manual mode (no interval) this is the only way
+// the dummy consensus produces blocks.
+func (d *Dummy) SealBlock() (*types.Block, error) {
+	resCh := make(chan sealResult, 1)
+
+	select {
+	case d.sealCh <- resCh:
+	case <-d.closeCh:
+		return nil, errClosed
+	}
+
+	res := <-resCh
+
+	return res.block, res.err
+}
+
 func (d *Dummy) run() {
-	d.logger.Info("started")
-	// do nothing
-	<-d.closeCh
+	d.logger.Info("started", "interval", d.interval)
+
+	// a nil channel never fires, so in manual mode only SealBlock produces blocks
+	var tickCh <-chan time.Time
+
+	if d.interval > 0 {
+		ticker := time.NewTicker(d.interval)
+		defer ticker.Stop()
+
+		tickCh = ticker.C
+	}
+
+	for {
+		select {
+		case <-tickCh:
+			if _, err := d.sealFn(); err != nil {
+				d.logger.Error("failed to seal block", "err", err)
+			}
+		case resCh := <-d.sealCh:
+			block, err := d.sealFn()
+			resCh <- sealResult{block: block, err: err}
+		case <-d.closeCh:
+			return
+		}
+	}
+}
+
+// sealBlock builds a block from the pool transactions on top of the current head.
+// The timestamp is derived from the parent, so block times don't depend on the wall clock.
+func (d *Dummy) sealBlock() (*types.Block, error) {
+	parent := d.blockchain.Header()
+
+	blockTime := uint64(d.interval / time.Second)
+	if blockTime == 0 {
+		blockTime = 1
+	}
+
+	header := &types.Header{
+		ParentHash: parent.Hash,
+		Number:     parent.Number + 1,
+		Timestamp:  parent.Timestamp + blockTime,
+	}
+
+	gasLimit, err := d.blockchain.CalculateGasLimit(header.Number)
+	if err != nil {
+		return nil, err
+	}
+
+	header.GasLimit = gasLimit
+	header.BaseFee = d.blockchain.CalculateBaseFee(parent)
+
+	miner, err := d.GetBlockCreator(header)
+	if err != nil {
+		return nil, err
+	}
+
+	transition, err := d.executor.BeginTxn(parent.StateRoot, header, miner)
+	if err != nil {
+		return nil, err
+	}
+
+	txns := d.writeTransactions(gasLimit, transition)
+
+	_, root, err := transition.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("failed to commit the state changes: %w", err)
+	}
+
+	header.StateRoot = root
+	header.GasUsed = transition.TotalGas()
+
+	block := consensus.BuildBlock(consensus.BuildBlockParams{
+		Header:   header,
+		Txns:     txns,
+		Receipts: transition.Receipts(),
+	})
+
+	if _, err := d.blockchain.VerifyFinalizedBlock(block); err != nil {
+		return nil, err
+	}
+
+	if err := d.blockchain.WriteBlock(block, dummyConsensus); err != nil {
+		return nil, err
+	}
+
+	d.txpool.ResetWithHeaders(block.Header)
+
+	return block, nil
+}
+
+func (d *Dummy) writeTransactions(gasLimit uint64, transition *state.Transition) []*types.Transaction {
+	var successful []*types.Transaction
+
+	d.txpool.Prepare()
+
+	for {
+		tx := d.txpool.Peek()
+		if tx == nil {
+			break
+		}
+
+		if tx.Gas > gasLimit {
+			d.txpool.Drop(tx)
+
+			continue
+		}
+
+		if err := transition.Write(tx); err != nil {
+			if _, ok := err.(*state.GasLimitReachedTransitionApplicationError); ok { //nolint:errorlint
+				break
+			} else if appErr, ok := err.(*state.TransitionApplicationError); ok && appErr.IsRecoverable { //nolint:errorlint
+				d.txpool.Demote(tx)
+			} else {
+				d.txpool.Drop(tx)
+			}
+
+			continue
+		}
+
+		d.txpool.Pop(tx)
+
+		successful = append(successful, tx)
+	}
+
+	return successful
 }
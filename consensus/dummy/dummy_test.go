@@ -0,0 +1,99 @@
+package dummy
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+// newTestDummy returns a dummy consensus whose sealed blocks are only counted
+func newTestDummy(t *testing.T, interval time.Duration) (*Dummy, func() uint64) {
+	t.Helper()
+
+	var (
+		lock   sync.Mutex
+		height uint64
+	)
+
+	d := &Dummy{
+		logger:   hclog.NewNullLogger(),
+		closeCh:  make(chan struct{}),
+		sealCh:   make(chan chan sealResult),
+		interval: interval,
+	}
+
+	d.sealFn = func() (*types.Block, error) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		height++
+
+		return &types.Block{Header: &types.Header{Number: height}}, nil
+	}
+
+	t.Cleanup(func() {
+		require.NoError(t, d.Close())
+	})
+
+	return d, func() uint64 {
+		lock.Lock()
+		defer lock.Unlock()
+
+		return height
+	}
+}
+
+func TestDummy_ManualMode_SealsOnlyOnTrigger(t *testing.T) {
+	t.Parallel()
+
+	d, height := newTestDummy(t, 0)
+	require.NoError(t, d.Start())
+
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, uint64(0), height())
+
+	for i := uint64(1); i <= 3; i++ {
+		block, err := d.SealBlock()
+		require.NoError(t, err)
+		require.Equal(t, i, block.Number())
+		require.Equal(t, i, height())
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, uint64(3), height())
+}
+
+func TestDummy_IntervalMode(t *testing.T) {
+	t.Parallel()
+
+	d, height := newTestDummy(t, 10*time.Millisecond)
+	require.NoError(t, d.Start())
+
+	require.Eventually(t, func() bool {
+		return height() >= 3
+	}, 2*time.Second, 10*time.Millisecond)
+
+	// manual triggers still work next to the interval
+	block, err := d.SealBlock()
+	require.NoError(t, err)
+	require.Greater(t, block.Number(), uint64(3))
+}
+
+func TestDummy_SealBlockAfterClose(t *testing.T) {
+	t.Parallel()
+
+	d := &Dummy{
+		logger:  hclog.NewNullLogger(),
+		closeCh: make(chan struct{}),
+		sealCh:  make(chan chan sealResult),
+	}
+
+	require.NoError(t, d.Close())
+
+	_, err := d.SealBlock()
+	require.ErrorIs(t, err, errClosed)
+}
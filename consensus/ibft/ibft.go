@@ -149,6 +149,7 @@ func Factory(params *consensus.Params) (consensus.Consensus, error) {
 			params.Network,
 			params.Blockchain,
 			time.Duration(params.BlockTime)*3*time.Second,
+			params.FutureBlocks,
 		),
 		secretsManager: params.SecretsManager,
 		Grpc:           params.Grpc,
@@ -25,6 +25,9 @@ type BlockBuilderParams struct {
 	// Coinbase that is signing the block
 	Coinbase types.Address
 
+	// RewardAddress is credited with the fees of the block, Coinbase if not set
+	RewardAddress types.Address
+
 	// GasLimit is the gas limit for the block
 	GasLimit uint64
 
@@ -39,6 +42,10 @@ type BlockBuilderParams struct {
 
 	// BaseFee is the base fee
 	BaseFee uint64
+
+	// EnginePriorityGasShare is the share (in percent) of the block gas limit filled with the
+	// transactions of authorized engine EOAs ahead of all other transactions, 0 disables it
+	EnginePriorityGasShare uint64
 }
 
 func NewBlockBuilder(params *BlockBuilderParams) *BlockBuilder {
@@ -64,6 +71,9 @@ type BlockBuilder struct {
 
 	// state is in memory state transition
 	state *state.Transition
+
+	// engineLane prefers authorized engine transactions, nil if disabled
+	engineLane *enginePriorityLane
 }
 
 // Init initializes block builder before adding transactions and actual block building
@@ -90,12 +100,18 @@ func (b *BlockBuilder) Reset() error {
 		Timestamp:    uint64(headerTime.Unix()),
 	}
 
-	transition, err := b.params.Executor.BeginTxn(b.params.Parent.StateRoot, b.header, b.params.Coinbase)
+	rewardAddress := b.params.RewardAddress
+	if rewardAddress == types.ZeroAddress {
+		rewardAddress = b.params.Coinbase
+	}
+
+	transition, err := b.params.Executor.BeginTxn(b.params.Parent.StateRoot, b.header, rewardAddress)
 	if err != nil {
 		return err
 	}
 
 	b.state = transition
+	b.engineLane = newEnginePriorityLane(transition, b.params.GasLimit, b.params.EnginePriorityGasShare)
 	b.block = nil
 	b.txns = []*types.Transaction{}
 
@@ -118,6 +134,22 @@ func (b *BlockBuilder) Build(handler func(h *types.Header)) (*types.FullBlock, e
 		return nil, fmt.Errorf("failed to commit the state changes: %w", err)
 	}
 
+	return b.build(stateRoot), nil
+}
+
+// BuildPreview creates the final block without writing its state to the storage,
+// the block builder has to be reset before it is used again
+func (b *BlockBuilder) BuildPreview() (*types.FullBlock, error) {
+	stateRoot, err := b.state.Root()
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute the state root: %w", err)
+	}
+
+	return b.build(stateRoot), nil
+}
+
+// build creates the final block with the given state root
+func (b *BlockBuilder) build(stateRoot types.Hash) *types.FullBlock {
 	b.header.StateRoot = stateRoot
 	b.header.GasUsed = b.state.TotalGas()
 	b.header.LogsBloom = types.CreateBloom(b.Receipts())
@@ -134,7 +166,7 @@ func (b *BlockBuilder) Build(handler func(h *types.Header)) (*types.FullBlock, e
 	return &types.FullBlock{
 		Block:    b.block,
 		Receipts: b.state.Receipts(),
-	}, nil
+	}
 }
 
 // WriteTx applies given transaction to the state. If transaction apply fails, it reverts the saved snapshot.
@@ -159,29 +191,52 @@ func (b *BlockBuilder) WriteTx(tx *types.Transaction) error {
 func (b *BlockBuilder) Fill() {
 	blockTimer := time.NewTimer(b.params.BlockTime)
 
+	if b.fill(blockTimer.C) {
+		return
+	}
+
+	//	wait for the timer to expire
+	<-blockTimer.C
+}
+
+// FillAll fills the block with all the executable transactions of the txpool
+// which fit into it, without waiting for the block time
+func (b *BlockBuilder) FillAll() {
+	b.fill(nil)
+}
+
+// fill writes the txpool transactions until the pool is exhausted, the block is full
+// or the stop channel fires, it reports whether the stop channel fired
+func (b *BlockBuilder) fill(stop <-chan time.Time) bool {
 	b.params.TxPool.Prepare()
-write:
+
+	if b.engineLane != nil {
+		b.params.TxPool.SetPriority(b.engineLane.isPriority)
+	}
+
 	for {
 		select {
-		case <-blockTimer.C:
-			return
+		case <-stop:
+			return true
 		default:
 			tx := b.params.TxPool.Peek()
+			inLane := tx != nil && b.engineLane != nil && b.engineLane.isPriority(tx)
 
 			// execute transactions one by one
 			finished, err := b.writeTxPoolTransaction(tx)
 			if err != nil {
 				b.params.Logger.Debug("Fill transaction error", "hash", tx.Hash, "err", err)
+			} else if inLane {
+				// the remaining budget changed, so the lane has to be re-evaluated
+				b.engineLane.add(tx)
+				b.params.TxPool.SetPriority(b.engineLane.isPriority)
 			}
 
 			if finished {
-				break write
+				return false
 			}
 		}
 	}
-
-	//	wait for the timer to expire
-	<-blockTimer.C
 }
 
 // Receipts returns the collection of transaction receipts for given block
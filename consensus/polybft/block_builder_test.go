@@ -158,3 +158,187 @@ func TestBlockBuilder_BuildBlockTxOneFailedTxAndOneTakesTooMuchGas(t *testing.T)
 	assert.False(t, fb.Block.Header.LogsBloom.IsLogInBloom(
 		&types.Log{Address: types.StringToAddress("111177779999")}))
 }
+
+// priorityTxPool serves the transactions by price, preferring the ones matching the priority predicate
+type priorityTxPool struct {
+	txPoolMock
+
+	txs        []*types.Transaction
+	isPriority func(*types.Transaction) bool
+}
+
+func (p *priorityTxPool) Prepare() {}
+
+func (p *priorityTxPool) SetPriority(isPriority func(*types.Transaction) bool) {
+	p.isPriority = isPriority
+}
+
+func (p *priorityTxPool) Peek() *types.Transaction {
+	best := -1
+
+	for i, tx := range p.txs {
+		if best == -1 || p.less(tx, p.txs[best]) {
+			best = i
+		}
+	}
+
+	if best == -1 {
+		return nil
+	}
+
+	tx := p.txs[best]
+	p.txs = append(p.txs[:best], p.txs[best+1:]...)
+
+	return tx
+}
+
+func (p *priorityTxPool) less(a, b *types.Transaction) bool {
+	if p.isPriority != nil {
+		if pa, pb := p.isPriority(a), p.isPriority(b); pa != pb {
+			return pa
+		}
+	}
+
+	if c := a.GasPrice.Cmp(b.GasPrice); c != 0 {
+		return c > 0
+	}
+
+	return a.From == b.From && a.Nonce < b.Nonce
+}
+
+func (p *priorityTxPool) Pop(*types.Transaction) {}
+
+func (p *priorityTxPool) Drop(*types.Transaction) {}
+
+func (p *priorityTxPool) Demote(*types.Transaction) {}
+
+func TestBlockBuilder_EnginePriorityLane(t *testing.T) {
+	const (
+		gasLimit      = 21000
+		blockGasLimit = gasLimit * 10
+		chainID       = 100
+	)
+
+	engine := generateTestAccount(t)
+	retail := [2]*wallet.Account{generateTestAccount(t), generateTestAccount(t)}
+	engineAddr := types.Address(engine.Ecdsa.Address())
+
+	prevBootstrap := chain.BootstrapEngineEOA
+	chain.BootstrapEngineEOA = engineAddr
+
+	t.Cleanup(func() {
+		chain.BootstrapEngineEOA = prevBootstrap
+	})
+
+	forks := &chain.Forks{}
+	logger := hclog.NewNullLogger()
+	signer := crypto.NewSigner(forks.At(0), chainID)
+
+	mchain := &chain.Chain{
+		Params: &chain.Params{
+			ChainID: chainID,
+			Forks:   forks,
+		},
+	}
+
+	executor := state.NewExecutor(mchain.Params, itrie.NewState(itrie.NewMemoryStorage()), logger)
+	executor.GetHash = func(header *types.Header) func(i uint64) types.Hash {
+		return func(i uint64) (res types.Hash) {
+			return types.BytesToHash(common.EncodeUint64ToBytes(i))
+		}
+	}
+
+	balanceMap := map[types.Address]*chain.GenesisAccount{}
+	for _, acc := range []*wallet.Account{engine, retail[0], retail[1]} {
+		balanceMap[types.Address(acc.Ecdsa.Address())] = &chain.GenesisAccount{Balance: ethgo.Ether(1)}
+	}
+
+	hash, err := executor.WriteGenesis(balanceMap, types.ZeroHash)
+	require.NoError(t, err)
+
+	newTx := func(acc *wallet.Account, nonce uint64, gasPrice int64) *types.Transaction {
+		t.Helper()
+
+		privateKey, err := acc.GetEcdsaPrivateKey()
+		require.NoError(t, err)
+
+		from := types.Address(acc.Ecdsa.Address())
+
+		tx, err := signer.SignTx(&types.Transaction{
+			Value:    big.NewInt(1),
+			GasPrice: big.NewInt(gasPrice),
+			Gas:      gasLimit,
+			Nonce:    nonce,
+			To:       &from,
+		}, privateKey)
+		require.NoError(t, err)
+
+		tx.From = from
+
+		return tx
+	}
+
+	engineTxs := []*types.Transaction{
+		newTx(engine, 0, 100),
+		newTx(engine, 1, 100),
+		newTx(engine, 2, 100),
+	}
+	retailTxs := []*types.Transaction{
+		newTx(retail[0], 0, 1000),
+		newTx(retail[1], 0, 500),
+	}
+
+	fill := func(share uint64) []*types.Transaction {
+		t.Helper()
+
+		pool := &priorityTxPool{
+			txs: append(append([]*types.Transaction{}, retailTxs...), engineTxs...),
+		}
+
+		bb := NewBlockBuilder(&BlockBuilderParams{
+			BlockTime:              time.Millisecond * 100,
+			Parent:                 &types.Header{StateRoot: hash, GasLimit: blockGasLimit},
+			Executor:               executor,
+			GasLimit:               blockGasLimit,
+			TxPool:                 pool,
+			Logger:                 logger,
+			EnginePriorityGasShare: share,
+		})
+
+		require.NoError(t, bb.Reset())
+		bb.Fill()
+
+		return bb.txns
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		assert.Equal(t, []*types.Transaction{
+			retailTxs[0], retailTxs[1], engineTxs[0], engineTxs[1], engineTxs[2],
+		}, fill(0))
+	})
+
+	t.Run("lane capped by the gas share", func(t *testing.T) {
+		// 25% of the block gas limit fits two engine transactions,
+		// the third one competes by price again
+		assert.Equal(t, []*types.Transaction{
+			engineTxs[0], engineTxs[1], retailTxs[0], retailTxs[1], engineTxs[2],
+		}, fill(25))
+	})
+
+	t.Run("unauthorized sender", func(t *testing.T) {
+		chain.BootstrapEngineEOA = types.Address(retail[1].Ecdsa.Address())
+
+		assert.Equal(t, []*types.Transaction{
+			retailTxs[1], retailTxs[0], engineTxs[0], engineTxs[1], engineTxs[2],
+		}, fill(100))
+	})
+}
+
+func TestEnginePriorityLane_Budget(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, newEnginePriorityLane(nil, 30_000_000, 0))
+	assert.Equal(t, uint64(7_500_000), newEnginePriorityLane(nil, 30_000_000, 25).budget)
+	assert.Equal(t, uint64(30_000_000), newEnginePriorityLane(nil, 30_000_000, 250).budget)
+	assert.Equal(t, uint64(49), newEnginePriorityLane(nil, 99, 50).budget)
+}
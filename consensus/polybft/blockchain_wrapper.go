@@ -34,7 +34,7 @@ type blockchainBackend interface {
 	CommitBlock(block *types.FullBlock) error
 
 	// NewBlockBuilder is a factory method that returns a block builder on top of 'parent'.
-	NewBlockBuilder(parent *types.Header, coinbase types.Address,
+	NewBlockBuilder(parent *types.Header, coinbase, rewardAddress types.Address,
 		txPool txPoolInterface, blockTime time.Duration, logger hclog.Logger) (blockBuilder, error)
 
 	// ProcessBlock builds a final block from given 'block' on top of 'parent'.
@@ -66,6 +66,16 @@ type blockchainBackend interface {
 
 	// GetReceiptsByHash retrieves receipts by hash
 	GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error)
+
+	// GetRewardAddress returns the address credited with the fees of the block of the given epoch
+	// built on top of 'parent' and proposed by 'proposer'
+	GetRewardAddress(parent *types.Header, proposer types.Address, epoch uint64) (types.Address, error)
+
+	// GetLatestRewardAddress returns the latest reward address registered by the validator
+	GetLatestRewardAddress(validator types.Address) (types.Address, error)
+
+	// SetFinalized marks the block with the given number as finalized, the chain is never reorged below it
+	SetFinalized(number uint64)
 }
 
 var _ blockchainBackend = &blockchainWrapper{}
@@ -73,6 +83,9 @@ var _ blockchainBackend = &blockchainWrapper{}
 type blockchainWrapper struct {
 	executor   *state.Executor
 	blockchain *blockchain.Blockchain
+
+	// enginePriorityGasShare is the block gas share of the engine priority lane
+	enginePriorityGasShare uint64
 }
 
 // CurrentHeader returns the header of blockchain block head
@@ -85,12 +98,32 @@ func (p *blockchainWrapper) CommitBlock(block *types.FullBlock) error {
 	return p.blockchain.WriteFullBlock(block, consensusSource)
 }
 
+// SetFinalized marks the block with the given number as finalized
+func (p *blockchainWrapper) SetFinalized(number uint64) {
+	p.blockchain.SetFinalized(number)
+}
+
 // ProcessBlock builds a final block from given 'block' on top of 'parent'
 func (p *blockchainWrapper) ProcessBlock(parent *types.Header, block *types.Block) (*types.FullBlock, error) {
 	header := block.Header.Copy()
 	start := time.Now().UTC()
 
-	transition, err := p.executor.BeginTxn(parent.StateRoot, header, types.BytesToAddress(header.Miner))
+	extra, err := GetIbftExtra(header.ExtraData)
+	if err != nil {
+		return nil, err
+	}
+
+	var epoch uint64
+	if extra.Checkpoint != nil {
+		epoch = extra.Checkpoint.EpochNumber
+	}
+
+	rewardAddress, err := p.GetRewardAddress(parent, types.BytesToAddress(header.Miner), epoch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve the reward address: %w", err)
+	}
+
+	transition, err := p.executor.BeginTxn(parent.StateRoot, header, rewardAddress)
 	if err != nil {
 		return nil, err
 	}
@@ -158,7 +191,7 @@ func (p *blockchainWrapper) GetHeaderByHash(hash types.Hash) (*types.Header, boo
 
 // NewBlockBuilder is an implementation of blockchainBackend interface
 func (p *blockchainWrapper) NewBlockBuilder(
-	parent *types.Header, coinbase types.Address,
+	parent *types.Header, coinbase, rewardAddress types.Address,
 	txPool txPoolInterface, blockTime time.Duration, logger hclog.Logger) (blockBuilder, error) {
 	gasLimit, err := p.blockchain.CalculateGasLimit(parent.Number + 1)
 	if err != nil {
@@ -166,14 +199,17 @@ func (p *blockchainWrapper) NewBlockBuilder(
 	}
 
 	return NewBlockBuilder(&BlockBuilderParams{
-		BlockTime: blockTime,
-		Parent:    parent,
-		Coinbase:  coinbase,
-		Executor:  p.executor,
-		GasLimit:  gasLimit,
-		BaseFee:   p.blockchain.CalculateBaseFee(parent),
-		TxPool:    txPool,
-		Logger:    logger,
+		BlockTime:     blockTime,
+		Parent:        parent,
+		Coinbase:      coinbase,
+		RewardAddress: rewardAddress,
+		Executor:      p.executor,
+		GasLimit:      gasLimit,
+		BaseFee:       p.blockchain.CalculateBaseFee(parent),
+		TxPool:        txPool,
+		Logger:        logger,
+
+		EnginePriorityGasShare: p.enginePriorityGasShare,
 	}), nil
 }
 
@@ -23,10 +23,11 @@ var (
 	// currentCheckpointBlockNumMethod is an ABI method object representation for
 	// currentCheckpointBlockNumber getter function on CheckpointManager contract
 	currentCheckpointBlockNumMethod = contractsapi.CheckpointManager.Abi.Methods["currentCheckpointBlockNumber"]
-	// frequency at which checkpoints are sent to the rootchain (in blocks count)
-	defaultCheckpointsOffset = uint64(900)
 )
 
+// DefaultCheckpointsOffset is the frequency at which checkpoints are sent to the rootchain (in blocks count)
+const DefaultCheckpointsOffset uint64 = 900
+
 type CheckpointManager interface {
 	EventSubscriber
 	PostBlock(req *PostBlockRequest) error
@@ -46,6 +46,7 @@ type txPoolInterface interface {
 	Pop(*types.Transaction)
 	Drop(*types.Transaction)
 	Demote(*types.Transaction)
+	SetPriority(func(*types.Transaction) bool)
 	SetSealing(bool)
 	ResetWithHeaders(...*types.Header)
 }
@@ -232,7 +233,7 @@ func (c *consensusRuntime) initCheckpointManager(logger hcf.Logger) error {
 
 		c.checkpointManager = newCheckpointManager(
 			wallet.NewEcdsaSigner(c.config.Key),
-			defaultCheckpointsOffset,
+			DefaultCheckpointsOffset,
 			c.config.PolyBFTConfig.Bridge.CheckpointManagerAddr,
 			txRelayer,
 			c.config.blockchain,
@@ -437,6 +438,11 @@ func (c *consensusRuntime) OnBlockInserted(fullBlock *types.FullBlock) {
 	c.epoch = epoch
 	c.lastBuiltBlock = fullBlock.Block.Header
 
+	// the epoch ending block carries the checkpoint of the epoch, the chain is never reorged below it
+	if isEndOfEpoch {
+		c.config.blockchain.SetFinalized(fullBlock.Block.Number())
+	}
+
 	// we will do PostBlock on checkpoint manager at the end, because it only
 	// sends a checkpoint in a separate routine. It doesn't do any db operations
 	if err := c.checkpointManager.PostBlock(postBlock); err != nil {
@@ -462,9 +468,17 @@ func (c *consensusRuntime) FSM() error {
 		return errNotAValidator
 	}
 
+	signer := types.Address(c.config.Key.Address())
+
+	rewardAddress, err := c.config.blockchain.GetRewardAddress(parent, signer, epoch.Number)
+	if err != nil {
+		return fmt.Errorf("cannot resolve reward address for fsm: %w", err)
+	}
+
 	blockBuilder, err := c.config.blockchain.NewBlockBuilder(
 		parent,
-		types.Address(c.config.Key.Address()),
+		signer,
+		rewardAddress,
 		c.config.txPool,
 		c.config.PolyBFTConfig.BlockTime.Duration,
 		c.logger,
@@ -536,6 +550,45 @@ func (c *consensusRuntime) FSM() error {
 	return nil
 }
 
+// BuildBlockPreview builds the block the node would propose on top of the last built block,
+// with the transactions of the given pool copy. The system transactions of the epoch ending
+// and sprint ending blocks are left out. The block is neither sealed nor broadcast and its state
+// is not written to the storage
+func (c *consensusRuntime) BuildBlockPreview(pool txPoolInterface) (*types.FullBlock, error) {
+	sharedData, err := c.getGuardedData()
+	if err != nil {
+		return nil, fmt.Errorf("cannot build block preview: %w", err)
+	}
+
+	parent, epoch := sharedData.lastBuiltBlock, sharedData.epoch
+	signer := types.Address(c.config.Key.Address())
+
+	rewardAddress, err := c.config.blockchain.GetRewardAddress(parent, signer, epoch.Number)
+	if err != nil {
+		return nil, fmt.Errorf("cannot resolve reward address for block preview: %w", err)
+	}
+
+	blockBuilder, err := c.config.blockchain.NewBlockBuilder(
+		parent,
+		signer,
+		rewardAddress,
+		pool,
+		c.config.PolyBFTConfig.BlockTime.Duration,
+		c.logger,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create block builder for block preview: %w", err)
+	}
+
+	if err := blockBuilder.Reset(); err != nil {
+		return nil, fmt.Errorf("failed to initialize block builder: %w", err)
+	}
+
+	blockBuilder.FillAll()
+
+	return blockBuilder.BuildPreview()
+}
+
 // restartEpoch resets the previously run epoch and moves to the next one
 // returns *epochMetadata different from nil if the lastEpoch is not the current one and everything was successful
 func (c *consensusRuntime) restartEpoch(header *types.Header, dbTx *bolt.Tx) (*epochMetadata, error) {
@@ -0,0 +1,57 @@
+package polybft
+
+import (
+	"github.com/xgr-network/xgr-node/state/runtime"
+	"github.com/xgr-network/xgr-node/state/runtime/precompiled"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+// enginePriorityLane prefers the transactions of authorized engine EOAs over all other
+// transactions while building a block, until their gas limits add up to the lane budget
+type enginePriorityLane struct {
+	host   runtime.Host
+	budget uint64
+	used   uint64
+
+	// authorized caches the EngineRegistry authorization of the senders for the block
+	authorized map[types.Address]bool
+}
+
+// newEnginePriorityLane returns the lane for a block with the given gas limit,
+// or nil if the lane is disabled (zero share)
+func newEnginePriorityLane(host runtime.Host, gasLimit, sharePercent uint64) *enginePriorityLane {
+	if sharePercent == 0 {
+		return nil
+	}
+
+	if sharePercent > 100 {
+		sharePercent = 100
+	}
+
+	return &enginePriorityLane{
+		host:       host,
+		budget:     gasLimit/100*sharePercent + gasLimit%100*sharePercent/100,
+		authorized: map[types.Address]bool{},
+	}
+}
+
+// isPriority reports whether the transaction is sent by an authorized engine EOA
+// and still fits into the lane budget
+func (l *enginePriorityLane) isPriority(tx *types.Transaction) bool {
+	if tx.Gas > l.budget-l.used {
+		return false
+	}
+
+	authorized, ok := l.authorized[tx.From]
+	if !ok {
+		authorized = precompiled.IsAuthorizedEngine(l.host, tx.From)
+		l.authorized[tx.From] = authorized
+	}
+
+	return authorized
+}
+
+// add accounts the gas limit of a transaction included through the lane
+func (l *enginePriorityLane) add(tx *types.Transaction) {
+	l.used += tx.Gas
+}
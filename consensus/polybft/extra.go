@@ -76,6 +76,10 @@ func (i *Extra) MarshalRLPWith(ar *fastrlp.Arena) *fastrlp.Value {
 
 // UnmarshalRLP defines the unmarshal function wrapper for Extra
 func (i *Extra) UnmarshalRLP(input []byte) error {
+	if len(input) < ExtraVanity {
+		return fmt.Errorf("wrong extra size: %d", len(input))
+	}
+
 	return fastrlp.UnmarshalRLP(input[ExtraVanity:], i)
 }
 
@@ -0,0 +1,66 @@
+package polybft
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xgr-network/xgr-node/consensus/polybft/bitmap"
+	"github.com/xgr-network/xgr-node/consensus/polybft/validator"
+	"github.com/xgr-network/xgr-node/consensus/polybft/wallet"
+	"github.com/xgr-network/xgr-node/crypto"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+func FuzzExtraUnmarshalRLP(f *testing.F) {
+	validators := validator.NewTestValidatorsWithAliases(f, []string{"A", "B", "C"})
+
+	digest := crypto.Keccak256([]byte("Dummy content to sign"))
+	signature, err := wallet.NewKey(validators.GetValidator("A").Account).Sign(digest)
+	require.NoError(f, err)
+
+	bmp := bitmap.Bitmap{}
+	bmp.Set(1)
+	bmp.Set(4)
+
+	removed := bitmap.Bitmap{}
+	removed.Set(2)
+
+	seeds := []*Extra{
+		{},
+		{
+			Validators: &validator.ValidatorSetDelta{
+				Added:   validators.GetPublicIdentities(),
+				Updated: validators.GetPublicIdentities("B"),
+				Removed: removed,
+			},
+			Parent:    &Signature{AggregatedSignature: signature, Bitmap: bmp},
+			Committed: &Signature{AggregatedSignature: signature, Bitmap: bmp},
+			Checkpoint: &CheckpointData{
+				BlockRound:            1,
+				EpochNumber:           3,
+				CurrentValidatorsHash: types.StringToHash("0x1"),
+				NextValidatorsHash:    types.StringToHash("0x2"),
+				EventRoot:             types.StringToHash("0x3"),
+			},
+		},
+	}
+
+	for _, extra := range seeds {
+		f.Add(extra.MarshalRLPTo(nil))
+	}
+
+	f.Add([]byte{})
+	f.Add(make([]byte, ExtraVanity))
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		extra := &Extra{}
+		if err := extra.UnmarshalRLP(input); err != nil {
+			return
+		}
+
+		// a decoded extra encodes and decodes to the same extra
+		decoded := &Extra{}
+		require.NoError(t, decoded.UnmarshalRLP(extra.MarshalRLPTo(nil)))
+		require.Equal(t, extra.MarshalRLPTo(nil), decoded.MarshalRLPTo(nil))
+	})
+}
@@ -139,6 +139,13 @@ func TestExtra_UnmarshalRLPWith_NegativeCases(t *testing.T) {
 		require.ErrorContains(t, extra.UnmarshalRLPWith(ar.NewArray()), "incorrect elements count to decode Extra, expected 4 but found 0")
 	})
 
+	t.Run("Input shorter than the vanity", func(t *testing.T) {
+		t.Parallel()
+
+		extra := &Extra{}
+		require.ErrorContains(t, extra.UnmarshalRLP(make([]byte, ExtraVanity-1)), "wrong extra size")
+	})
+
 	t.Run("Incorrect ValidatorSetDelta marshalled", func(t *testing.T) {
 		t.Parallel()
 
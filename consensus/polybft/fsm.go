@@ -27,7 +27,9 @@ type blockBuilder interface {
 	Reset() error
 	WriteTx(*types.Transaction) error
 	Fill()
+	FillAll()
 	Build(func(h *types.Header)) (*types.FullBlock, error)
+	BuildPreview() (*types.FullBlock, error)
 	GetState() *state.Transition
 	Receipts() []*types.Receipt
 }
@@ -48,6 +50,7 @@ var (
 	errValidatorSetDeltaMismatch           = errors.New("validator set delta mismatch")
 	errValidatorsUpdateInNonEpochEnding    = errors.New("trying to update validator set in a non epoch ending block")
 	errValidatorDeltaNilInEpochEndingBlock = errors.New("validator set delta is nil in epoch ending block")
+	errBlockSignerNotValidator             = errors.New("block signer is not a validator")
 )
 
 type fsm struct {
@@ -372,6 +375,12 @@ func (f *fsm) Validate(proposal []byte) error {
 		f.logger.Trace("[FSM Validate]", "Block", block.Number(), "parent validators", validators)
 	}
 
+	// the block fees are credited to the reward address registered by the signer given in the header.
+	// A re-proposed block keeps the signer of the round it was built in, so only membership is checked.
+	if !f.validators.Includes(types.BytesToAddress(block.Header.Miner)) {
+		return errBlockSignerNotValidator
+	}
+
 	stateBlock, err := f.backend.ProcessBlock(f.parent, &block)
 	if err != nil {
 		return err
@@ -34,7 +34,7 @@ func (m *blockchainMock) CommitBlock(block *types.FullBlock) error {
 	return args.Error(0)
 }
 
-func (m *blockchainMock) NewBlockBuilder(parent *types.Header, coinbase types.Address,
+func (m *blockchainMock) NewBlockBuilder(parent *types.Header, coinbase, rewardAddress types.Address,
 	txPool txPoolInterface, blockTime time.Duration, logger hclog.Logger) (blockBuilder, error) {
 	args := m.Called()
 
@@ -129,6 +129,23 @@ func (m *blockchainMock) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, e
 	return args.Get(0).([]*types.Receipt), args.Error(1) //nolint:forcetypeassert
 }
 
+func (m *blockchainMock) GetRewardAddress(
+	parent *types.Header, proposer types.Address, epoch uint64) (types.Address, error) {
+	args := m.Called(parent, proposer, epoch)
+
+	return args.Get(0).(types.Address), args.Error(1) //nolint:forcetypeassert
+}
+
+func (m *blockchainMock) GetLatestRewardAddress(validator types.Address) (types.Address, error) {
+	args := m.Called(validator)
+
+	return args.Get(0).(types.Address), args.Error(1) //nolint:forcetypeassert
+}
+
+func (m *blockchainMock) SetFinalized(number uint64) {
+	m.Called(number)
+}
+
 var _ polybftBackend = (*polybftBackendMock)(nil)
 
 type polybftBackendMock struct {
@@ -195,6 +212,10 @@ func (m *blockBuilderMock) Fill() {
 	m.Called()
 }
 
+func (m *blockBuilderMock) FillAll() {
+	m.Called()
+}
+
 // Receipts returns the collection of transaction receipts for given block
 func (m *blockBuilderMock) Receipts() []*types.Receipt {
 	args := m.Called()
@@ -202,6 +223,12 @@ func (m *blockBuilderMock) Receipts() []*types.Receipt {
 	return args.Get(0).([]*types.Receipt) //nolint:forcetypeassert
 }
 
+func (m *blockBuilderMock) BuildPreview() (*types.FullBlock, error) {
+	args := m.Called()
+
+	return args.Get(0).(*types.FullBlock), args.Error(1) //nolint:forcetypeassert
+}
+
 func (m *blockBuilderMock) Build(handler func(*types.Header)) (*types.FullBlock, error) {
 	args := m.Called(handler)
 	builtBlock := args.Get(0).(*types.FullBlock) //nolint:forcetypeassert
@@ -351,6 +378,10 @@ func (tp *txPoolMock) Demote(tx *types.Transaction) {
 	tp.Called(tx)
 }
 
+func (tp *txPoolMock) SetPriority(isPriority func(*types.Transaction) bool) {
+	tp.Called(isPriority)
+}
+
 func (tp *txPoolMock) SetSealing(v bool) {
 	tp.Called(v)
 }
@@ -25,7 +25,9 @@ import (
 	"github.com/xgr-network/xgr-node/helper/progress"
 	"github.com/xgr-network/xgr-node/network"
 	"github.com/xgr-network/xgr-node/state"
+	"github.com/xgr-network/xgr-node/state/runtime/addresslist"
 	"github.com/xgr-network/xgr-node/syncer"
+	"github.com/xgr-network/xgr-node/txpool"
 	"github.com/xgr-network/xgr-node/types"
 )
 
@@ -36,9 +38,17 @@ const (
 )
 
 var (
-	errMissingBridgeConfig = errors.New("invalid genesis configuration, missing bridge configuration")
+	errMissingBridgeConfig     = errors.New("invalid genesis configuration, missing bridge configuration")
+	errBlockPreviewUnavailable = errors.New("block preview is not available")
 )
 
+var _ consensus.BlockPreviewer = (*Polybft)(nil)
+
+// previewPool is implemented by the txpool, it copies the pool for a block preview
+type previewPool interface {
+	Preview() *txpool.PreviewPool
+}
+
 // polybftBackend is an interface defining polybft methods needed by fsm and sync tracker
 type polybftBackend interface {
 	// GetValidators retrieves validator set for the given block
@@ -127,6 +137,17 @@ type Polybft struct {
 	txPool txPoolInterface
 }
 
+// bridgeAccessListsEnforced returns which of the configured bridge allow and block lists the predicates enforce.
+// From the BridgeListPrecedence fork on, the allow list takes precedence over the block list as it does for
+// transactions and deployments. Genesis files without the fork keep both lists, so their genesis state is unchanged
+func bridgeAccessListsEnforced(forks *chain.Forks, allowList, blockList bool) (bool, bool) {
+	if forks != nil && forks.IsActive(chain.BridgeListPrecedence, 0) {
+		return addresslist.Enforced(allowList, blockList)
+	}
+
+	return allowList, blockList
+}
+
 func GenesisPostHookFactory(config *chain.Chain, engineName string) func(txn *state.Transition) error {
 	return func(transition *state.Transition) error {
 		polyBFTConfig, err := GetPolyBFTConfig(config)
@@ -203,8 +224,8 @@ func GenesisPostHookFactory(config *chain.Chain, engineName string) func(txn *st
 		if bridgeAllowListAdmin != types.ZeroAddress || bridgeBlockListAdmin != types.ZeroAddress {
 			// The owner of the contract will be the allow list admin or the block list admin, if any of them is set.
 			owner := contracts.SystemCaller
-			useBridgeAllowList := bridgeAllowListAdmin != types.ZeroAddress
-			useBridgeBlockList := bridgeBlockListAdmin != types.ZeroAddress
+			useBridgeAllowList, useBridgeBlockList := bridgeAccessListsEnforced(config.Params.Forks,
+				bridgeAllowListAdmin != types.ZeroAddress, bridgeBlockListAdmin != types.ZeroAddress)
 
 			if bridgeAllowListAdmin != types.ZeroAddress {
 				owner = bridgeAllowListAdmin
@@ -441,12 +462,28 @@ func (p *Polybft) Initialize() error {
 		p.config.Network,
 		p.config.Blockchain,
 		time.Duration(p.config.BlockTime)*3*time.Second,
+		p.config.FutureBlocks,
 	)
 
+	// the validators of the block vote on the donation percent
+	p.config.Executor.GetValidators = func(header *types.Header) ([]types.Address, error) {
+		if header.Number == 0 {
+			return nil, nil
+		}
+
+		validators, err := p.GetValidators(header.Number-1, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return validators.GetAddresses(), nil
+	}
+
 	// set blockchain backend
 	p.blockchain = &blockchainWrapper{
-		blockchain: p.config.Blockchain,
-		executor:   p.config.Executor,
+		blockchain:             p.config.Blockchain,
+		executor:               p.config.Executor,
+		enginePriorityGasShare: p.config.Config.EnginePriorityGasShare,
 	}
 
 	// create bridge and consensus topics
@@ -531,6 +568,8 @@ func (p *Polybft) Start() error {
 		return fmt.Errorf("consensus runtime start failed: %w", err)
 	}
 
+	p.checkRewardAddress()
+
 	// start state DB process
 	go p.state.startStatsReleasing()
 
@@ -618,7 +657,8 @@ func (p *Polybft) startConsensusProtocol() {
 			p.logger.Error("failed to query current validator set", "block number", latestHeader.Number, "error", err)
 		}
 
-		isValidator := currentValidators.ContainsNodeID(p.key.String())
+		// a shadow fork only syncs, even if its key belongs to the validator set
+		isValidator := !p.config.Config.ShadowFork && currentValidators.ContainsNodeID(p.key.String())
 		p.runtime.setIsActiveValidator(isValidator)
 
 		p.txPool.SetSealing(isValidator) // update tx pool
@@ -741,9 +781,10 @@ func (p *Polybft) ProcessHeaders(_ []*types.Header) error {
 	return nil
 }
 
-// GetBlockCreator retrieves the block creator (or signer) given the block header
+// GetBlockCreator retrieves the address credited with the fees of the block. It is the
+// signer given in the header, unless the signer has registered a reward address.
 func (p *Polybft) GetBlockCreator(h *types.Header) (types.Address, error) {
-	return types.BytesToAddress(h.Miner), nil
+	return p.getRewardAddress(h)
 }
 
 // PreCommitState a hook to be called before finalizing state transition on inserting block
@@ -792,6 +833,21 @@ func (p *Polybft) GetBridgeProvider() consensus.BridgeDataProvider {
 	return p.runtime
 }
 
+// BuildBlockPreview is an implementation of BlockPreviewer interface
+func (p *Polybft) BuildBlockPreview() (*consensus.BlockPreview, error) {
+	pool, ok := p.txPool.(previewPool)
+	if !ok || p.runtime == nil {
+		return nil, errBlockPreviewUnavailable
+	}
+
+	fullBlock, err := p.runtime.BuildBlockPreview(pool.Preview())
+	if err != nil {
+		return nil, err
+	}
+
+	return consensus.NewBlockPreview(fullBlock.Block, fullBlock.Receipts), nil
+}
+
 // FilterExtra is an implementation of Consensus interface
 func (p *Polybft) FilterExtra(extra []byte) ([]byte, error) {
 	return GetIbftExtraClean(extra)
@@ -281,6 +281,33 @@ func Test_Factory(t *testing.T) {
 	assert.Equal(t, params, polybft.config)
 }
 
+func Test_bridgeAccessListsEnforced(t *testing.T) {
+	t.Parallel()
+
+	preFork := chain.AllForksEnabled.Copy().RemoveFork(chain.BridgeListPrecedence)
+
+	cases := []struct {
+		name          string
+		forks         *chain.Forks
+		allowList     bool
+		blockList     bool
+		expectedAllow bool
+		expectedBlock bool
+	}{
+		{"both lists before the fork", preFork, true, true, true, true},
+		{"both lists without forks", nil, true, true, true, true},
+		{"both lists", chain.AllForksEnabled, true, true, true, false},
+		{"block list only", chain.AllForksEnabled, false, true, false, true},
+		{"allow list only", chain.AllForksEnabled, true, false, true, false},
+	}
+
+	for _, tc := range cases {
+		allow, block := bridgeAccessListsEnforced(tc.forks, tc.allowList, tc.blockList)
+		assert.Equal(t, tc.expectedAllow, allow, tc.name)
+		assert.Equal(t, tc.expectedBlock, block, tc.name)
+	}
+}
+
 func Test_GenesisPostHookFactory(t *testing.T) {
 	t.Parallel()
 
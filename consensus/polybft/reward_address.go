@@ -0,0 +1,171 @@
+package polybft
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/xgr-network/xgr-node/chain"
+	"github.com/xgr-network/xgr-node/contracts"
+	"github.com/xgr-network/xgr-node/state"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+// rewardStorageReader reads a storage slot of the reward address registry
+type rewardStorageReader func(key types.Hash) types.Hash
+
+// newRewardStorageReader returns a reader of the reward address registry storage in the snapshot
+func newRewardStorageReader(snap state.Snapshot) (rewardStorageReader, error) {
+	account, err := snap.GetAccount(contracts.RewardAddressPrecompile)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(key types.Hash) types.Hash {
+		if account == nil {
+			return types.ZeroHash
+		}
+
+		return snap.GetStorage(contracts.RewardAddressPrecompile, account.Root, key)
+	}, nil
+}
+
+// rewardAddressUpdates returns the number of reward address updates of the validator
+func rewardAddressUpdates(read rewardStorageReader, validator types.Address) uint64 {
+	count := read(contracts.RewardAddressCountSlotKey(validator))
+
+	return new(big.Int).SetBytes(count.Bytes()).Uint64()
+}
+
+// latestRewardAddress returns the latest reward address registered by the validator,
+// or the validator itself if it has never registered one
+func latestRewardAddress(read rewardStorageReader, validator types.Address) types.Address {
+	count := rewardAddressUpdates(read, validator)
+	if count == 0 {
+		return validator
+	}
+
+	addr, _ := contracts.DecodeRewardAddressEntry(read(contracts.RewardAddressEntrySlotKey(validator, count-1)))
+	if addr == types.ZeroAddress {
+		return validator
+	}
+
+	return addr
+}
+
+// resolveRewardAddress returns the address credited with the fees of a block of the given epoch
+// proposed by the validator. An update takes effect with the first epoch after the one it was made in,
+// so only updates made in blocks of earlier epochs are considered.
+func resolveRewardAddress(read rewardStorageReader, getHeader func(uint64) (*types.Header, bool),
+	validator types.Address, epoch uint64) (types.Address, error) {
+	for i := rewardAddressUpdates(read, validator); i > 0; i-- {
+		addr, blockNumber := contracts.DecodeRewardAddressEntry(
+			read(contracts.RewardAddressEntrySlotKey(validator, i-1)))
+
+		header, ok := getHeader(blockNumber)
+		if !ok {
+			return types.ZeroAddress, fmt.Errorf("header of reward address update at block %d not found", blockNumber)
+		}
+
+		extra, err := GetIbftExtra(header.ExtraData)
+		if err != nil {
+			return types.ZeroAddress, fmt.Errorf("failed to get extra of block %d: %w", blockNumber, err)
+		}
+
+		if extra.Checkpoint != nil && extra.Checkpoint.EpochNumber >= epoch {
+			continue
+		}
+
+		if addr == types.ZeroAddress {
+			return validator, nil
+		}
+
+		return addr, nil
+	}
+
+	return validator, nil
+}
+
+// GetRewardAddress returns the address credited with the fees of the block of the given epoch
+// built on top of 'parent' and proposed by 'proposer'
+func (p *blockchainWrapper) GetRewardAddress(
+	parent *types.Header, proposer types.Address, epoch uint64) (types.Address, error) {
+	if !p.blockchain.Config().Forks.IsActive(chain.RewardAddress, parent.Number+1) {
+		return proposer, nil
+	}
+
+	snap, err := p.executor.StateAt(parent.StateRoot)
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	read, err := newRewardStorageReader(snap)
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	return resolveRewardAddress(read, p.blockchain.GetHeaderByNumber, proposer, epoch)
+}
+
+// GetLatestRewardAddress returns the latest reward address registered by the validator at the head
+func (p *blockchainWrapper) GetLatestRewardAddress(validator types.Address) (types.Address, error) {
+	snap, err := p.executor.StateAt(p.CurrentHeader().StateRoot)
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	read, err := newRewardStorageReader(snap)
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	return latestRewardAddress(read, validator), nil
+}
+
+// getRewardAddress returns the address credited with the fees of the block
+func (p *Polybft) getRewardAddress(header *types.Header) (types.Address, error) {
+	proposer := types.BytesToAddress(header.Miner)
+
+	if header.Number == 0 {
+		return proposer, nil
+	}
+
+	parent, ok := p.blockchain.GetHeaderByHash(header.ParentHash)
+	if !ok {
+		return types.ZeroAddress, fmt.Errorf("unable to get parent header of block %d", header.Number)
+	}
+
+	extra, err := GetIbftExtra(header.ExtraData)
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	var epoch uint64
+	if extra.Checkpoint != nil {
+		epoch = extra.Checkpoint.EpochNumber
+	}
+
+	return p.blockchain.GetRewardAddress(parent, proposer, epoch)
+}
+
+// checkRewardAddress warns if the configured reward address is not the one registered on-chain
+func (p *Polybft) checkRewardAddress() {
+	configured := p.config.Config.RewardAddress
+	if configured == types.ZeroAddress {
+		return
+	}
+
+	signer := types.Address(p.key.Address())
+
+	registered, err := p.blockchain.GetLatestRewardAddress(signer)
+	if err != nil {
+		p.logger.Warn("failed to read the registered reward address", "err", err)
+
+		return
+	}
+
+	if registered != configured {
+		p.logger.Warn("configured reward address is not registered on-chain, "+
+			"register it with the 'polybft reward-address' command",
+			"configured", configured, "registered", registered)
+	}
+}
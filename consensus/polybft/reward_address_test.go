@@ -0,0 +1,98 @@
+package polybft
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/xgr-network/xgr-node/contracts"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+func TestResolveRewardAddress(t *testing.T) {
+	t.Parallel()
+
+	const epochSize = 10
+
+	var (
+		validator = types.StringToAddress("0x1")
+		first     = types.StringToAddress("0x100")
+		second    = types.StringToAddress("0x200")
+	)
+
+	// blocks 1-10 are in epoch 1, blocks 11-20 in epoch 2 and so on
+	getHeader := func(number uint64) (*types.Header, bool) {
+		extra := &Extra{Checkpoint: &CheckpointData{EpochNumber: (number-1)/epochSize + 1}}
+
+		return &types.Header{Number: number, ExtraData: extra.MarshalRLPTo(nil)}, true
+	}
+
+	storage := map[types.Hash]types.Hash{}
+	read := func(key types.Hash) types.Hash {
+		return storage[key]
+	}
+
+	register := func(addr types.Address, blockNumber uint64) {
+		count := rewardAddressUpdates(read, validator)
+
+		storage[contracts.RewardAddressEntrySlotKey(validator, count)] =
+			contracts.EncodeRewardAddressEntry(addr, blockNumber)
+		storage[contracts.RewardAddressCountSlotKey(validator)] =
+			types.BytesToHash(new(big.Int).SetUint64(count + 1).Bytes())
+	}
+
+	resolve := func(epoch uint64) types.Address {
+		addr, err := resolveRewardAddress(read, getHeader, validator, epoch)
+		require.NoError(t, err)
+
+		return addr
+	}
+
+	// nothing registered
+	require.Equal(t, validator, resolve(1))
+	require.Equal(t, validator, latestRewardAddress(read, validator))
+
+	// registered in epoch 1, effective from epoch 2
+	register(first, 5)
+	require.Equal(t, validator, resolve(1))
+	require.Equal(t, first, resolve(2))
+	require.Equal(t, first, latestRewardAddress(read, validator))
+
+	// registered in the last block of epoch 2, effective from epoch 3
+	register(second, 20)
+	require.Equal(t, first, resolve(2))
+	require.Equal(t, second, resolve(3))
+	require.Equal(t, second, resolve(10))
+
+	// the zero address resets the reward address to the validator
+	register(types.ZeroAddress, 25)
+	require.Equal(t, second, resolve(3))
+	require.Equal(t, validator, resolve(4))
+	require.Equal(t, validator, latestRewardAddress(read, validator))
+
+	// other validators are not affected
+	other := types.StringToAddress("0x2")
+
+	addr, err := resolveRewardAddress(read, getHeader, other, 4)
+	require.NoError(t, err)
+	require.Equal(t, other, addr)
+}
+
+func TestResolveRewardAddress_MissingHeader(t *testing.T) {
+	t.Parallel()
+
+	validator := types.StringToAddress("0x1")
+	storage := map[types.Hash]types.Hash{
+		contracts.RewardAddressCountSlotKey(validator): types.BytesToHash([]byte{1}),
+		contracts.RewardAddressEntrySlotKey(validator, 0): contracts.EncodeRewardAddressEntry(
+			types.StringToAddress("0x100"), 5),
+	}
+
+	_, err := resolveRewardAddress(func(key types.Hash) types.Hash {
+		return storage[key]
+	}, func(uint64) (*types.Header, bool) {
+		return nil, false
+	}, validator, 2)
+	require.ErrorContains(t, err, "not found")
+}
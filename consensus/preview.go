@@ -0,0 +1,82 @@
+package consensus
+
+import (
+	"math/big"
+
+	"github.com/xgr-network/xgr-node/types"
+)
+
+// BlockPreviewer is implemented by the consensus protocols which can preview the block
+// the node would build next
+type BlockPreviewer interface {
+	// BuildBlockPreview builds the next block on top of the current head from a copy of the
+	// pool transactions. The block is neither sealed nor broadcast, the pool is not modified
+	BuildBlockPreview() (*BlockPreview, error)
+}
+
+// BlockPreview is a block built by a BlockPreviewer
+type BlockPreview struct {
+	Header       *types.Header
+	Transactions []*BlockPreviewTx
+
+	// the fee split totals of the block
+	ValidatorFee *big.Int
+	DonationFee  *big.Int
+	BurnedFee    *big.Int
+}
+
+// BlockPreviewTx is a transaction of a block preview with its contribution to the fees
+type BlockPreviewTx struct {
+	Hash    types.Hash
+	From    types.Address
+	Nonce   uint64
+	GasUsed uint64
+	Status  types.ReceiptStatus
+
+	ValidatorFee *big.Int
+	DonationFee  *big.Int
+	BurnedFee    *big.Int
+}
+
+// NewBlockPreview returns the preview of the built block and its receipts
+func NewBlockPreview(block *types.Block, receipts []*types.Receipt) *BlockPreview {
+	preview := &BlockPreview{
+		Header:       block.Header,
+		Transactions: make([]*BlockPreviewTx, 0, len(block.Transactions)),
+		ValidatorFee: new(big.Int),
+		DonationFee:  new(big.Int),
+		BurnedFee:    new(big.Int),
+	}
+
+	for i, tx := range block.Transactions {
+		receipt := receipts[i]
+
+		previewTx := &BlockPreviewTx{
+			Hash:         tx.Hash,
+			From:         tx.From,
+			Nonce:        tx.Nonce,
+			GasUsed:      receipt.GasUsed,
+			ValidatorFee: new(big.Int),
+			DonationFee:  new(big.Int),
+			BurnedFee:    new(big.Int),
+		}
+
+		if receipt.Status != nil {
+			previewTx.Status = *receipt.Status
+		}
+
+		if split := receipt.FeeSplit; split != nil {
+			previewTx.ValidatorFee.Set(split.Validator)
+			previewTx.DonationFee.Set(split.Donation)
+			previewTx.BurnedFee.Set(split.Burned)
+		}
+
+		preview.ValidatorFee.Add(preview.ValidatorFee, previewTx.ValidatorFee)
+		preview.DonationFee.Add(preview.DonationFee, previewTx.DonationFee)
+		preview.BurnedFee.Add(preview.BurnedFee, previewTx.BurnedFee)
+
+		preview.Transactions = append(preview.Transactions, previewTx)
+	}
+
+	return preview
+}
@@ -10,4 +10,10 @@ var (
 
 	// ABI for Contract used in e2e stress test
 	StressTestABI = abi.MustNewABI(StressTestJSONABI)
+
+	// ABI for the reward address registry precompile
+	RewardAddressABI = abi.MustNewABI(RewardAddressJSONABI)
+
+	// ABI for the donation percent vote precompile
+	DonationGovernanceABI = abi.MustNewABI(DonationGovernanceJSONABI)
 )
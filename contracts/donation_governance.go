@@ -0,0 +1,95 @@
+package contracts
+
+import (
+	"encoding/binary"
+
+	"github.com/xgr-network/xgr-node/helper/keccak"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+var (
+	// donationSlotCount is the slot of the number of donation percent proposals
+	donationSlotCount = keccak.Keccak256(nil, []byte("XGR:DONATION:COUNT"))
+	// donationSlotProposal is the base slot of the donation percent proposals
+	donationSlotProposal = keccak.Keccak256(nil, []byte("XGR:DONATION:PROPOSAL"))
+	// donationSlotVote is the base slot of the approvals of the donation percent proposals
+	donationSlotVote = keccak.Keccak256(nil, []byte("XGR:DONATION:VOTE"))
+)
+
+// DonationProposal is a proposed donation percent and the state of its vote
+type DonationProposal struct {
+	Proposer      types.Address
+	Percent       uint64
+	ProposedBlock uint64
+	Approvals     uint64
+	// PassedBlock is the block the proposal reached the approval threshold in (zero if not passed)
+	PassedBlock uint64
+	Executed    bool
+}
+
+// DonationProposalCountSlotKey returns the DonationGovernancePrecompile storage key holding
+// the number of proposals, which is the id of the latest proposal
+func DonationProposalCountSlotKey() types.Hash {
+	return types.BytesToHash(donationSlotCount)
+}
+
+// DonationProposalSlotKeys returns the DonationGovernancePrecompile storage keys holding
+// the two words of the proposal with the id.
+// Custom 32+8+1 key schema: keccak256(slot ‖ uint64be(id) ‖ word)
+func DonationProposalSlotKeys(id uint64) (types.Hash, types.Hash) {
+	var b [41]byte
+
+	copy(b[:32], donationSlotProposal)
+	binary.BigEndian.PutUint64(b[32:40], id)
+
+	first := types.BytesToHash(keccak.Keccak256(nil, b[:]))
+
+	b[40] = 1
+
+	return first, types.BytesToHash(keccak.Keccak256(nil, b[:]))
+}
+
+// DonationVoteSlotKey returns the DonationGovernancePrecompile storage key marking
+// the approval of the proposal with the id by the voter.
+// Custom 32+8+20 key schema: keccak256(slot ‖ uint64be(id) ‖ voter[20])
+func DonationVoteSlotKey(id uint64, voter types.Address) types.Hash {
+	var b [60]byte
+
+	copy(b[:32], donationSlotVote)
+	binary.BigEndian.PutUint64(b[32:40], id)
+	copy(b[40:], voter[:])
+
+	return types.BytesToHash(keccak.Keccak256(nil, b[:]))
+}
+
+// EncodeDonationProposal packs a proposal into two storage words:
+// 4 zero bytes ‖ uint64be(proposedBlock) ‖ proposer[20] and
+// uint64be(percent) ‖ uint64be(approvals) ‖ uint64be(passedBlock) ‖ executed ‖ 7 zero bytes
+func EncodeDonationProposal(p *DonationProposal) (types.Hash, types.Hash) {
+	var first, second types.Hash
+
+	binary.BigEndian.PutUint64(first[4:12], p.ProposedBlock)
+	copy(first[12:], p.Proposer[:])
+
+	binary.BigEndian.PutUint64(second[0:8], p.Percent)
+	binary.BigEndian.PutUint64(second[8:16], p.Approvals)
+	binary.BigEndian.PutUint64(second[16:24], p.PassedBlock)
+
+	if p.Executed {
+		second[24] = 1
+	}
+
+	return first, second
+}
+
+// DecodeDonationProposal unpacks the storage words written by EncodeDonationProposal
+func DecodeDonationProposal(first, second types.Hash) *DonationProposal {
+	return &DonationProposal{
+		Proposer:      types.BytesToAddress(first[12:]),
+		ProposedBlock: binary.BigEndian.Uint64(first[4:12]),
+		Percent:       binary.BigEndian.Uint64(second[0:8]),
+		Approvals:     binary.BigEndian.Uint64(second[8:16]),
+		PassedBlock:   binary.BigEndian.Uint64(second[16:24]),
+		Executed:      second[24] != 0,
+	}
+}
@@ -0,0 +1,41 @@
+package contracts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/xgr-network/xgr-node/types"
+)
+
+func TestDonationGovernanceSlotKeys(t *testing.T) {
+	t.Parallel()
+
+	voter := types.StringToAddress("0x5000")
+
+	first, second := DonationProposalSlotKeys(1)
+	require.NotEqual(t, first, second)
+
+	nextFirst, _ := DonationProposalSlotKeys(2)
+	require.NotEqual(t, first, nextFirst)
+
+	require.NotEqual(t, DonationVoteSlotKey(1, voter), DonationVoteSlotKey(2, voter))
+	require.NotEqual(t, DonationVoteSlotKey(1, voter), DonationVoteSlotKey(1, types.StringToAddress("0x6000")))
+	require.NotEqual(t, DonationProposalCountSlotKey(), first)
+}
+
+func TestDonationProposalEncoding(t *testing.T) {
+	t.Parallel()
+
+	proposal := &DonationProposal{
+		Proposer:      types.StringToAddress("0xdeadbeef"),
+		Percent:       20,
+		ProposedBlock: 1 << 40,
+		Approvals:     3,
+		PassedBlock:   1<<40 + 5,
+		Executed:      true,
+	}
+
+	require.Equal(t, proposal, DecodeDonationProposal(EncodeDonationProposal(proposal)))
+	require.Equal(t, &DonationProposal{}, DecodeDonationProposal(types.ZeroHash, types.ZeroHash))
+}
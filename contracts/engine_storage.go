@@ -0,0 +1,52 @@
+package contracts
+
+import (
+	"math/big"
+
+	"github.com/xgr-network/xgr-node/helper/keccak"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+// engineSlotNextPid is the base slot of the per-user next process id in EngineExecutePrecompile storage
+var engineSlotNextPid = keccak.Keccak256(nil, []byte("XGR:ENGINE:NEXT_PID"))
+
+// engineSlotSessionGas is the base slot of the per-session billed gas units in EngineExecutePrecompile storage
+var engineSlotSessionGas = keccak.Keccak256(nil, []byte("XGR:ENGINE:SESSION_GAS"))
+
+// engineSlotSessionIteration is the base slot of the per-session last iteration in EngineExecutePrecompile storage
+var engineSlotSessionIteration = keccak.Keccak256(nil, []byte("XGR:ENGINE:SESSION_ITERATION"))
+
+// EngineNextPidSlotKey returns the EngineExecutePrecompile storage key holding the next process id of user.
+// Custom 32+20 key schema: keccak256(slot ‖ addr[20])
+func EngineNextPidSlotKey(user types.Address) types.Hash {
+	var b [52]byte
+
+	copy(b[:32], engineSlotNextPid)
+	copy(b[32:], user[:])
+
+	return types.BytesToHash(keccak.Keccak256(nil, b[:]))
+}
+
+// EngineSessionGasSlotKey returns the EngineExecutePrecompile storage key holding the gas units
+// billed so far in the session of user rooted at sessionID.
+// Key schema: keccak256(slot ‖ addr[20] ‖ uint256(sessionID))
+func EngineSessionGasSlotKey(user types.Address, sessionID *big.Int) types.Hash {
+	return engineSessionSlotKey(engineSlotSessionGas, user, sessionID)
+}
+
+// EngineSessionIterationSlotKey returns the EngineExecutePrecompile storage key holding the
+// last iteration executed in the session of user rooted at sessionID.
+// Key schema: keccak256(slot ‖ addr[20] ‖ uint256(sessionID))
+func EngineSessionIterationSlotKey(user types.Address, sessionID *big.Int) types.Hash {
+	return engineSessionSlotKey(engineSlotSessionIteration, user, sessionID)
+}
+
+func engineSessionSlotKey(slot []byte, user types.Address, sessionID *big.Int) types.Hash {
+	var b [84]byte
+
+	copy(b[:32], slot)
+	copy(b[32:52], user[:])
+	sessionID.FillBytes(b[52:])
+
+	return types.BytesToHash(keccak.Keccak256(nil, b[:]))
+}
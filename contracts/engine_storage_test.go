@@ -0,0 +1,48 @@
+package contracts
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/xgr-network/xgr-node/crypto"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+func TestEngineNextPidSlotKey(t *testing.T) {
+	t.Parallel()
+
+	user := types.StringToAddress("0x5000")
+
+	slot := crypto.Keccak256([]byte("XGR:ENGINE:NEXT_PID"))
+	expected := types.BytesToHash(crypto.Keccak256(slot, user.Bytes()))
+
+	require.Equal(t, expected, EngineNextPidSlotKey(user))
+	require.NotEqual(t, expected, EngineNextPidSlotKey(types.StringToAddress("0x6000")))
+}
+
+func TestEngineSessionGasSlotKey(t *testing.T) {
+	t.Parallel()
+
+	user := types.StringToAddress("0x5000")
+
+	slot := crypto.Keccak256([]byte("XGR:ENGINE:SESSION_GAS"))
+	expected := types.BytesToHash(crypto.Keccak256(slot, user.Bytes(), types.BytesToHash([]byte{7}).Bytes()))
+
+	require.Equal(t, expected, EngineSessionGasSlotKey(user, big.NewInt(7)))
+	require.NotEqual(t, expected, EngineSessionGasSlotKey(user, big.NewInt(8)))
+	require.NotEqual(t, expected, EngineSessionGasSlotKey(types.StringToAddress("0x6000"), big.NewInt(7)))
+}
+
+func TestEngineSessionIterationSlotKey(t *testing.T) {
+	t.Parallel()
+
+	user := types.StringToAddress("0x5000")
+
+	slot := crypto.Keccak256([]byte("XGR:ENGINE:SESSION_ITERATION"))
+	expected := types.BytesToHash(crypto.Keccak256(slot, user.Bytes(), types.BytesToHash([]byte{7}).Bytes()))
+
+	require.Equal(t, expected, EngineSessionIterationSlotKey(user, big.NewInt(7)))
+	require.NotEqual(t, EngineSessionGasSlotKey(user, big.NewInt(7)), EngineSessionIterationSlotKey(user, big.NewInt(7)))
+}
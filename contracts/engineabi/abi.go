@@ -73,6 +73,14 @@ const EngineMetaEventABI = `
     {"name":"apiSaves","type":"bytes"},
     {"name":"contractSaves","type":"bytes"}]}]`
 
+const GrantFeeChargedEventABI = `
+  [{"type":"event","name":"GrantFeeCharged","inputs":[
+    {"name":"payer","type":"address"},
+    {"name":"engine","type":"address"},
+    {"name":"seconds","type":"uint64"},
+    {"name":"perYearWei","type":"uint256"},
+    {"name":"fee","type":"uint256"}]}]`
+
 const EngineExtrasEventABI = `
   [{"type":"event","name":"EngineExtrasV2","inputs":[
     {"name":"gasUsed","type":"uint256"},
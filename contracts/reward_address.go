@@ -0,0 +1,56 @@
+package contracts
+
+import (
+	"encoding/binary"
+
+	"github.com/xgr-network/xgr-node/helper/keccak"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+var (
+	// rewardSlotCount is the base slot of the per-validator number of reward address updates
+	rewardSlotCount = keccak.Keccak256(nil, []byte("XGR:REWARD:COUNT"))
+	// rewardSlotEntry is the base slot of the per-validator reward address updates
+	rewardSlotEntry = keccak.Keccak256(nil, []byte("XGR:REWARD:ENTRY"))
+)
+
+// RewardAddressCountSlotKey returns the RewardAddressPrecompile storage key holding
+// the number of reward address updates of validator.
+// Custom 32+20 key schema: keccak256(slot ‖ addr[20])
+func RewardAddressCountSlotKey(validator types.Address) types.Hash {
+	var b [52]byte
+
+	copy(b[:32], rewardSlotCount)
+	copy(b[32:], validator[:])
+
+	return types.BytesToHash(keccak.Keccak256(nil, b[:]))
+}
+
+// RewardAddressEntrySlotKey returns the RewardAddressPrecompile storage key holding
+// the index-th reward address update of validator.
+// Custom 32+20+8 key schema: keccak256(slot ‖ addr[20] ‖ uint64be(index))
+func RewardAddressEntrySlotKey(validator types.Address, index uint64) types.Hash {
+	var b [60]byte
+
+	copy(b[:32], rewardSlotEntry)
+	copy(b[32:52], validator[:])
+	binary.BigEndian.PutUint64(b[52:], index)
+
+	return types.BytesToHash(keccak.Keccak256(nil, b[:]))
+}
+
+// EncodeRewardAddressEntry packs a reward address update into a single storage word:
+// 4 zero bytes ‖ uint64be(blockNumber) ‖ rewardAddress[20]
+func EncodeRewardAddressEntry(rewardAddress types.Address, blockNumber uint64) types.Hash {
+	var entry types.Hash
+
+	binary.BigEndian.PutUint64(entry[4:12], blockNumber)
+	copy(entry[12:], rewardAddress[:])
+
+	return entry
+}
+
+// DecodeRewardAddressEntry unpacks a storage word written by EncodeRewardAddressEntry
+func DecodeRewardAddressEntry(entry types.Hash) (types.Address, uint64) {
+	return types.BytesToAddress(entry[12:]), binary.BigEndian.Uint64(entry[4:12])
+}
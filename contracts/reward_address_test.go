@@ -0,0 +1,47 @@
+package contracts
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/xgr-network/xgr-node/crypto"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+func TestRewardAddressSlotKeys(t *testing.T) {
+	t.Parallel()
+
+	validator := types.StringToAddress("0x5000")
+
+	countSlot := crypto.Keccak256([]byte("XGR:REWARD:COUNT"))
+	require.Equal(t, types.BytesToHash(crypto.Keccak256(countSlot, validator.Bytes())),
+		RewardAddressCountSlotKey(validator))
+
+	var index [8]byte
+
+	binary.BigEndian.PutUint64(index[:], 3)
+
+	entrySlot := crypto.Keccak256([]byte("XGR:REWARD:ENTRY"))
+	require.Equal(t, types.BytesToHash(crypto.Keccak256(entrySlot, validator.Bytes(), index[:])),
+		RewardAddressEntrySlotKey(validator, 3))
+
+	require.NotEqual(t, RewardAddressEntrySlotKey(validator, 3), RewardAddressEntrySlotKey(validator, 4))
+	require.NotEqual(t, RewardAddressEntrySlotKey(validator, 3),
+		RewardAddressEntrySlotKey(types.StringToAddress("0x6000"), 3))
+}
+
+func TestRewardAddressEntry(t *testing.T) {
+	t.Parallel()
+
+	rewardAddress := types.StringToAddress("0xdeadbeef")
+
+	addr, blockNumber := DecodeRewardAddressEntry(EncodeRewardAddressEntry(rewardAddress, 1<<40))
+	require.Equal(t, rewardAddress, addr)
+	require.Equal(t, uint64(1<<40), blockNumber)
+
+	addr, blockNumber = DecodeRewardAddressEntry(types.ZeroHash)
+	require.Equal(t, types.ZeroAddress, addr)
+	require.Zero(t, blockNumber)
+}
@@ -82,6 +82,10 @@ var (
 	ConsolePrecompile = types.StringToAddress("0x000000000000000000636F6e736F6c652e6c6f67")
 	// EngineExecutePrecompile is an address of the XDaLa ENGINE_EXECUTE precompile
 	EngineExecutePrecompile = types.StringToAddress("0x00000000000000000000000000000000000000E1")
+	// RewardAddressPrecompile is an address of the validator reward address registry precompile
+	RewardAddressPrecompile = types.StringToAddress("0x2040")
+	// DonationGovernancePrecompile is an address of the donation percent vote precompile
+	DonationGovernancePrecompile = types.StringToAddress("0x2050")
 	// AllowListContractsAddr is the address of the contract deployer allow list
 	AllowListContractsAddr = types.StringToAddress("0x0200000000000000000000000000000000000000")
 	// BlockListContractsAddr is the address of the contract deployer block list
@@ -114,3 +118,20 @@ func GetProxyImplementationMapping() map[types.Address]types.Address {
 		RootMintableERC1155PredicateContract: RootMintableERC1155PredicateContractV1,
 	}
 }
+
+// IsBridgeContract reports whether addr is a child chain bridge contract (proxy or implementation)
+// whose callers are gated by the bridge allow and block lists
+func IsBridgeContract(addr types.Address) bool {
+	switch addr {
+	case L2StateSenderContract, L2StateSenderContractV1,
+		ChildERC20PredicateContract, ChildERC20PredicateContractV1,
+		ChildERC721PredicateContract, ChildERC721PredicateContractV1,
+		ChildERC1155PredicateContract, ChildERC1155PredicateContractV1,
+		RootMintableERC20PredicateContract, RootMintableERC20PredicateContractV1,
+		RootMintableERC721PredicateContract, RootMintableERC721PredicateContractV1,
+		RootMintableERC1155PredicateContract, RootMintableERC1155PredicateContractV1:
+		return true
+	default:
+		return false
+	}
+}
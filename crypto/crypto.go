@@ -14,6 +14,7 @@ import (
 	"github.com/coinbase/kryptology/pkg/signatures/bls/bls_sig"
 	"github.com/umbracle/fastrlp"
 	"github.com/xgr-network/xgr-node/helper/hex"
+	"github.com/xgr-network/xgr-node/helper/keccak"
 	"github.com/xgr-network/xgr-node/helper/keystore"
 	"github.com/xgr-network/xgr-node/secrets"
 	"github.com/xgr-network/xgr-node/types"
@@ -226,23 +227,27 @@ func VerifyBLSSignatureFromBytes(rawPubkey, rawSig, message []byte) error {
 
 // Keccak256 calculates the Keccak256
 func Keccak256(v ...[]byte) []byte {
-	h := sha3.NewLegacyKeccak256()
+	h := keccak.DefaultKeccakPool.Get()
+	defer keccak.DefaultKeccakPool.Put(h)
+
 	for _, i := range v {
-		h.Write(i)
+		h.Write(i) //nolint:errcheck
 	}
 
-	return h.Sum(nil)
+	return h.Sum(make([]byte, 0, types.HashLength))
 }
 
 // Keccak256Hash calculates and returns the Keccak256 hash of the input data,
 // converting it to an internal Hash data structure.
 func Keccak256Hash(v ...[]byte) (hash types.Hash) {
-	h := NewKeccakState()
+	h := keccak.DefaultKeccakPool.Get()
+	defer keccak.DefaultKeccakPool.Put(h)
+
 	for _, b := range v {
-		h.Write(b)
+		h.Write(b) //nolint:errcheck
 	}
 
-	h.Read(hash[:]) //nolint:errcheck
+	copy(hash[:], h.Read())
 
 	return hash
 }
@@ -747,3 +747,87 @@ func TestE2E_Consensus_EIP1559Check(t *testing.T) {
 		initialMinerBalance = finalMinerFinalBalance
 	}
 }
+
+func TestE2E_Consensus_RewardAddress(t *testing.T) {
+	const (
+		epochSize     = 5
+		validatorsNum = 4
+		maxTransfers  = 40
+	)
+
+	var (
+		premineBalance = ethgo.Ether(10)
+		rewardAddress  = types.StringToAddress("0x5200")
+	)
+
+	sender, err := wallet.GenerateKey()
+	require.NoError(t, err)
+
+	cluster := framework.NewTestCluster(t, validatorsNum,
+		framework.WithEpochSize(epochSize),
+		framework.WithNativeTokenConfig(fmt.Sprintf(framework.NativeTokenMintableTestCfg, sender.Address())),
+		framework.WithBurnContract(&polybft.BurnContractInfo{BlockNumber: 0, Address: types.ZeroAddress}),
+		framework.WithSecretsCallback(func(addresses []types.Address, config *framework.TestClusterConfig) {
+			config.Premine = append(config.Premine, fmt.Sprintf("%s:%s", sender.Address(), premineBalance))
+			for _, a := range addresses {
+				config.Premine = append(config.Premine, fmt.Sprintf("%s:%s", a, premineBalance))
+			}
+		}),
+	)
+	defer cluster.Stop()
+
+	cluster.WaitForReady(t)
+
+	srv := cluster.Servers[0]
+	client := srv.JSONRPC().Eth()
+
+	validatorAccount, err := sidechain.GetAccountFromDir(srv.DataDir())
+	require.NoError(t, err)
+
+	validatorAddr := validatorAccount.Ecdsa.Address()
+
+	require.NoError(t, srv.RegisterRewardAddress(rewardAddress))
+
+	// the reward address takes effect with the next epoch
+	registeredAt, err := client.BlockNumber()
+	require.NoError(t, err)
+	require.NoError(t, cluster.WaitForBlock(registeredAt+2*epochSize, time.Minute))
+
+	// send transfers until one of them is included in a block proposed by the validator
+	for i := 0; i < maxTransfers; i++ {
+		txn := cluster.Transfer(t, sender, types.StringToAddress("0x5100"), big.NewInt(1))
+		require.True(t, txn.Succeed())
+
+		blockNum := txn.Receipt().BlockNumber
+
+		block, err := client.GetBlockByNumber(ethgo.BlockNumber(blockNum), false)
+		require.NoError(t, err)
+
+		if block.Miner != validatorAddr {
+			continue
+		}
+
+		balanceOf := func(addr ethgo.Address, number uint64) *big.Int {
+			balance, err := client.GetBalance(addr, ethgo.BlockNumber(number))
+			require.NoError(t, err)
+
+			return balance
+		}
+
+		// the fee goes to the reward address, the signing account doesn't earn anything
+		require.Equal(t, 1, balanceOf(ethgo.Address(rewardAddress), blockNum).
+			Cmp(balanceOf(ethgo.Address(rewardAddress), blockNum-1)))
+		require.Equal(t, balanceOf(validatorAddr, blockNum-1), balanceOf(validatorAddr, blockNum))
+
+		// all nodes agree on the block
+		for _, s := range cluster.Servers[1:] {
+			other, err := s.JSONRPC().Eth().GetBlockByNumber(ethgo.BlockNumber(blockNum), false)
+			require.NoError(t, err)
+			require.Equal(t, block.Hash, other.Hash)
+		}
+
+		return
+	}
+
+	t.Fatalf("no transfer was included in a block proposed by %s", validatorAddr)
+}
@@ -0,0 +1,139 @@
+package e2e
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/wallet"
+
+	"github.com/xgr-network/xgr-node/chain"
+	"github.com/xgr-network/xgr-node/consensus/polybft"
+	"github.com/xgr-network/xgr-node/e2e-polybft/framework"
+	"github.com/xgr-network/xgr-node/helper/common"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+// shadowDivergence is the part of the xgr_getShadowDivergences result checked by the tests
+type shadowDivergence struct {
+	Number       string     `json:"number"`
+	Hash         types.Hash `json:"hash"`
+	ExpectedRoot types.Hash `json:"expectedRoot"`
+	LocalRoot    types.Hash `json:"localRoot"`
+	Accounts     []struct {
+		Address     types.Address `json:"address"`
+		PreBalance  string        `json:"preBalance"`
+		PostBalance string        `json:"postBalance"`
+	} `json:"accounts"`
+}
+
+func TestE2E_ShadowFork_DonationPercent(t *testing.T) {
+	const (
+		shadowPrefix          = "test-shadow-"
+		shadowDonationPercent = 50
+	)
+
+	sender, err := wallet.GenerateKey()
+	require.NoError(t, err)
+
+	receiver := types.StringToAddress("0x5100")
+	donationAddr := chain.DefaultDonationAddress
+
+	cluster := framework.NewTestCluster(t, 2,
+		framework.WithNativeTokenConfig(fmt.Sprintf(framework.NativeTokenMintableTestCfg, sender.Address())),
+		framework.WithPremine(types.Address(sender.Address())),
+		framework.WithBurnContract(&polybft.BurnContractInfo{BlockNumber: 0, Address: types.ZeroAddress}),
+	)
+	defer cluster.Stop()
+
+	cluster.WaitForReady(t)
+
+	// the shadow node runs the same genesis with a locally altered donation percent
+	raw, err := os.ReadFile(cluster.Config.Dir("genesis.json"))
+	require.NoError(t, err)
+
+	genesis := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(raw, &genesis))
+
+	params, ok := genesis["params"].(map[string]interface{})
+	require.True(t, ok)
+
+	params["defaultDonationPercent"] = shadowDonationPercent
+
+	raw, err = json.Marshal(genesis)
+	require.NoError(t, err)
+
+	shadowChain := cluster.Config.Dir("shadow-genesis.json")
+	require.NoError(t, os.WriteFile(shadowChain, raw, 0600))
+
+	_, err = cluster.InitSecrets(shadowPrefix, 1)
+	require.NoError(t, err)
+
+	shadow := cluster.InitShadowServer(t, shadowPrefix+"1", shadowChain)
+
+	txn := cluster.Transfer(t, sender, receiver, ethgo.Ether(1))
+	require.True(t, txn.Succeed())
+
+	blockNum := txn.Receipt().BlockNumber
+
+	require.NoError(t, cluster.WaitUntil(2*time.Minute, 2*time.Second, func() bool {
+		num, err := shadow.JSONRPC().Eth().BlockNumber()
+
+		return err == nil && num >= blockNum
+	}))
+
+	// the shadow node follows the cluster instead of forking off
+	header, err := shadow.JSONRPC().Eth().GetBlockByNumber(ethgo.BlockNumber(blockNum), false)
+	require.NoError(t, err)
+	require.Equal(t, txn.Receipt().BlockHash, header.Hash)
+
+	var divergences []*shadowDivergence
+
+	require.NoError(t, shadow.JSONRPC().Call("xgr_getShadowDivergences", &divergences, "earliest"))
+	require.NotEmpty(t, divergences)
+
+	var divergence *shadowDivergence
+
+	for _, d := range divergences {
+		number, err := common.ParseUint64orHex(&d.Number)
+		require.NoError(t, err)
+
+		if number == blockNum {
+			divergence = d
+		}
+	}
+
+	require.NotNil(t, divergence, "no divergence recorded for block %d", blockNum)
+	require.Equal(t, types.Hash(header.Hash), divergence.Hash)
+	require.Equal(t, types.Hash(header.StateRoot), divergence.ExpectedRoot)
+	require.NotEqual(t, divergence.ExpectedRoot, divergence.LocalRoot)
+
+	// the donation address gets a larger share of the fee on the shadow node
+	expected, err := cluster.Servers[0].JSONRPC().Eth().GetBalance(ethgo.Address(donationAddr), ethgo.BlockNumber(blockNum))
+	require.NoError(t, err)
+
+	found := false
+
+	for _, account := range divergence.Accounts {
+		if account.Address != donationAddr {
+			continue
+		}
+
+		found = true
+
+		postBalance, err := common.ParseUint256orHex(&account.PostBalance)
+		require.NoError(t, err)
+		require.Equal(t, 1, postBalance.Cmp(expected))
+	}
+
+	require.True(t, found, "donation address missing in the divergence report")
+
+	// queries for the diverged block are answered from the local state
+	local, err := shadow.JSONRPC().Eth().GetBalance(ethgo.Address(donationAddr), ethgo.BlockNumber(blockNum))
+	require.NoError(t, err)
+	require.Equal(t, 1, local.Cmp(expected))
+}
@@ -718,6 +718,35 @@ func (c *TestCluster) InitTestServer(t *testing.T,
 	c.Servers = append(c.Servers, srv)
 }
 
+// InitShadowServer starts a node in shadow-fork mode, which follows the cluster
+// while executing its blocks with the given chain configuration
+func (c *TestCluster) InitShadowServer(t *testing.T, dataDir string, chainPath string) *TestServer {
+	t.Helper()
+
+	dataDir = c.Config.Dir(dataDir)
+
+	srv := NewTestServer(t, c.Config, "", func(config *TestServerConfig) {
+		config.DataDir = dataDir
+		config.Chain = chainPath
+		config.P2PPort = c.getOpenPort()
+		config.LogLevel = os.Getenv(envLogLevel)
+		config.NumBlockConfirmations = c.Config.NumBlockConfirmations
+		config.ShadowFork = true
+	})
+
+	go func(node *node) {
+		<-node.Wait()
+
+		if !node.ExitResult().Signaled {
+			c.Fail(fmt.Errorf("shadow server at dir '%s' has stopped unexpectedly", dataDir))
+		}
+	}(srv.node)
+
+	c.Servers = append(c.Servers, srv)
+
+	return srv
+}
+
 func (c *TestCluster) cmdRun(args ...string) error {
 	return runCommand(c.Config.Binary, args, c.Config.GetStdout(args[0]))
 }
@@ -38,6 +38,7 @@ type TestServerConfig struct {
 	Relayer               bool
 	NumBlockConfirmations uint64
 	BridgeJSONRPC         string
+	ShadowFork            bool
 }
 
 type TestServerConfigCallback func(*TestServerConfig)
@@ -176,6 +177,10 @@ func (t *TestServer) Start() {
 		args = append(args, "--relayer")
 	}
 
+	if config.ShadowFork {
+		args = append(args, "--shadow-fork")
+	}
+
 	// Start the server
 	stdout := t.clusterConfig.GetStdout(t.config.Name)
 
@@ -330,6 +335,19 @@ func (t *TestServer) WithdrawRewards() error {
 	return runCommand(t.clusterConfig.Binary, args, t.clusterConfig.GetStdout("withdraw-rewards"))
 }
 
+// RegisterRewardAddress registers the address credited with the fees of the blocks proposed by the validator
+func (t *TestServer) RegisterRewardAddress(rewardAddress types.Address) error {
+	args := []string{
+		"polybft",
+		"reward-address",
+		"--" + polybftsecrets.AccountDirFlag, t.config.DataDir,
+		"--address", rewardAddress.String(),
+		"--jsonrpc", t.JSONRPCAddr(),
+	}
+
+	return runCommand(t.clusterConfig.Binary, args, t.clusterConfig.GetStdout("reward-address"))
+}
+
 // HasValidatorSealed checks whether given validator has signed at least single block for the given range of blocks
 func (t *TestServer) HasValidatorSealed(firstBlock, lastBlock uint64, validators validator.AccountSet,
 	validatorAddr ethgo.Address) (bool, error) {
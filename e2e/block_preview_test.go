@@ -0,0 +1,122 @@
+package e2e
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+
+	"github.com/xgr-network/xgr-node/e2e/framework"
+	"github.com/xgr-network/xgr-node/helper/common"
+	"github.com/xgr-network/xgr-node/helper/tests"
+	txpoolOp "github.com/xgr-network/xgr-node/txpool/proto"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+func TestBlockPreview_MatchesNextBlock(t *testing.T) {
+	const (
+		devInterval = 5
+		txCount     = 5
+	)
+
+	senderKey, senderAddress := tests.GenerateKeyAndAddr(t)
+	_, receiverAddress := tests.GenerateKeyAndAddr(t)
+
+	server := framework.NewTestServers(t, 1, func(config *framework.TestServerConfig) {
+		config.SetConsensus(framework.ConsensusDev)
+		config.SetDevInterval(devInterval)
+		config.Premine(senderAddress, framework.EthToWei(100))
+	})[0]
+
+	client := server.JSONRPC()
+	operator := server.TxnPoolOperator()
+
+	// the pool is frozen until the next dev block, so the transactions are sent right after one
+	head, err := client.Eth().BlockNumber()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*devInterval*time.Second)
+	defer cancel()
+
+	_, err = framework.WaitUntilBlockMined(ctx, server, head+1)
+	require.NoError(t, err)
+
+	hashes := make([]ethgo.Hash, txCount)
+
+	for i := range hashes {
+		signedTx, err := signer.SignTx(&types.Transaction{
+			Nonce:    uint64(i),
+			GasPrice: framework.TestGasPrice(),
+			Gas:      framework.DefaultGasLimit - 1,
+			To:       &receiverAddress,
+			Value:    big.NewInt(1),
+			V:        big.NewInt(1),
+			From:     types.ZeroAddress,
+		}, senderKey)
+		require.NoError(t, err)
+
+		response, err := operator.AddTxn(context.Background(), &txpoolOp.AddTxnReq{
+			Raw: &any.Any{
+				Value: signedTx.MarshalRLP(),
+			},
+			From: types.ZeroAddress.String(),
+		})
+		require.NoError(t, err)
+
+		hashes[i] = ethgo.Hash(types.StringToHash(response.TxHash))
+	}
+
+	parent, err := client.Eth().GetBlockByNumber(ethgo.Latest, false)
+	require.NoError(t, err)
+
+	preview, err := server.Operator().BlockPreview(context.Background(), &empty.Empty{})
+	require.NoError(t, err)
+
+	// the preview leaves the pool untouched, the next block includes the same transactions
+	receipt, err := tests.WaitForReceipt(ctx, client.Eth(), hashes[txCount-1])
+	require.NoError(t, err)
+
+	block, err := client.Eth().GetBlockByNumber(ethgo.BlockNumber(receipt.BlockNumber), false)
+	require.NoError(t, err)
+	require.Equal(t, parent.Hash, block.ParentHash, "a block was sealed while the transactions were sent")
+
+	require.Equal(t, block.Number, preview.Number)
+	require.Equal(t, block.ParentHash.String(), preview.ParentHash)
+	require.Equal(t, block.StateRoot.String(), preview.StateRoot)
+	require.Equal(t, block.GasUsed, preview.GasUsed)
+	require.Len(t, preview.Transactions, len(block.TransactionsHashes))
+
+	validatorFees := new(big.Int)
+
+	for i, tx := range preview.Transactions {
+		require.Equal(t, block.TransactionsHashes[i].String(), tx.Hash)
+
+		txReceipt, err := client.Eth().GetTransactionReceipt(ethgo.HexToHash(tx.Hash))
+		require.NoError(t, err)
+		require.Equal(t, txReceipt.GasUsed, tx.GasUsed)
+
+		validatorFee, ok := new(big.Int).SetString(tx.ValidatorFee, 10)
+		require.True(t, ok)
+
+		validatorFees.Add(validatorFees, validatorFee)
+	}
+
+	totalValidatorFees, ok := new(big.Int).SetString(preview.ValidatorFee, 10)
+	require.True(t, ok)
+	require.Equal(t, validatorFees, totalValidatorFees)
+
+	var economics struct {
+		TotalValidatorFeesWei string `json:"totalValidatorFeesWei"`
+	}
+
+	require.NoError(t, client.Call("xgr_getBlockEconomics", &economics, ethgo.BlockNumber(receipt.BlockNumber).String()))
+
+	blockValidatorFees, err := common.ParseUint256orHex(&economics.TotalValidatorFeesWei)
+	require.NoError(t, err)
+	require.Equal(t, blockValidatorFees, totalValidatorFees)
+}
@@ -47,6 +47,7 @@ type TestServerConfig struct {
 	Bootnodes               []string                 // Bootnode Addresses
 	PriceLimit              *uint64                  // Minimum gas price limit to enforce for acceptance into the pool
 	DevInterval             int                      // Dev consensus update interval [s]
+	DevInstant              bool                     // Dev consensus seals a block as soon as a transaction enters the pool
 	EpochSize               uint64                   // The epoch size in blocks for the IBFT layer
 	BlockGasLimit           uint64                   // Block gas limit
 	BlockGasTarget          uint64                   // Gas target for new blocks
@@ -144,6 +145,11 @@ func (t *TestServerConfig) SetDevInterval(interval int) {
 	t.DevInterval = interval
 }
 
+// SetDevInstant enables sealing a block as soon as a transaction enters the pool in the dev consensus
+func (t *TestServerConfig) SetDevInstant(instant bool) {
+	t.DevInstant = instant
+}
+
 // SetDevStakingAddresses sets the Staking smart contract staker addresses for the dev mode.
 // These addresses should be passed into the `validators` flag in genesis generation.
 // Since invoking the dev consensus will not generate the ibft base folders, this is the only way
@@ -1,6 +1,10 @@
 package framework
 
-import "math/big"
+import (
+	"math/big"
+
+	"github.com/xgr-network/xgr-node/types"
+)
 
 const (
 	// TestGasPriceBump is added on top of DefaultGasPrice in tests to ensure
@@ -33,3 +37,41 @@ func TestGasTipCap() *big.Int {
 func TestGasFeeCap() *big.Int {
 	return big.NewInt(Test1559FeeCap)
 }
+
+// TestAccessListGasPrice is the gas price of the EIP-2930 access list (type-1) transactions
+// used in e2e tests. They carry a single gas price just like legacy transactions.
+func TestAccessListGasPrice() *big.Int {
+	return big.NewInt(TestLegacyGasPrice)
+}
+
+// BuildAccessListTx returns an unsigned EIP-2930 access list transaction calling the given address.
+// Its sample access list warms the called address along with its first storage slot.
+func BuildAccessListTx(
+	chainID *big.Int,
+	nonce uint64,
+	to types.Address,
+	value *big.Int,
+	gas uint64,
+	input []byte,
+) *types.Transaction {
+	if value == nil {
+		value = big.NewInt(0)
+	}
+
+	return &types.Transaction{
+		Type:     types.AccessListTx,
+		ChainID:  new(big.Int).Set(chainID),
+		Nonce:    nonce,
+		GasPrice: TestAccessListGasPrice(),
+		Gas:      gas,
+		To:       &to,
+		Value:    value,
+		Input:    input,
+		AccessList: types.AccessList{
+			{
+				Address:     to,
+				StorageKeys: []types.Hash{types.ZeroHash},
+			},
+		},
+	}
+}
@@ -0,0 +1,49 @@
+package framework
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/xgr-network/xgr-node/crypto"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+func TestBuildAccessListTx(t *testing.T) {
+	t.Parallel()
+
+	var (
+		chainID = big.NewInt(100)
+		to      = types.StringToAddress("0x1234")
+	)
+
+	tx := BuildAccessListTx(chainID, 3, to, big.NewInt(7), 50_000, []byte{0x01})
+
+	require.Equal(t, types.AccessListTx, tx.Type)
+	require.Equal(t, chainID, tx.ChainID)
+	require.Equal(t, uint64(3), tx.Nonce)
+	require.Equal(t, TestAccessListGasPrice(), tx.GasPrice)
+	require.Nil(t, tx.GasTipCap)
+	require.Nil(t, tx.GasFeeCap)
+	require.Equal(t, to, *tx.To)
+	require.Equal(t, types.AccessList{
+		{
+			Address:     to,
+			StorageKeys: []types.Hash{types.ZeroHash},
+		},
+	}, tx.AccessList)
+
+	// the transaction can be signed and its sender recovered
+	key, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	signer := crypto.NewLondonSigner(chainID.Uint64(), true, crypto.NewEIP155Signer(chainID.Uint64(), true))
+
+	signed, err := signer.SignTx(tx, key)
+	require.NoError(t, err)
+
+	sender, err := signer.Sender(signed)
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubKeyToAddress(&key.PublicKey), sender)
+}
@@ -434,6 +434,10 @@ func (t *TestServer) Start(ctx context.Context) error {
 		if t.Config.DevInterval != 0 {
 			args = append(args, "--dev-interval", strconv.Itoa(t.Config.DevInterval))
 		}
+
+		if t.Config.DevInstant {
+			args = append(args, "--dev-instant")
+		}
 	case ConsensusDummy:
 		args = append(args, "--data-dir", t.Config.RootDir)
 	}
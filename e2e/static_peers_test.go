@@ -0,0 +1,64 @@
+package e2e
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xgr-network/xgr-node/e2e/framework"
+	"github.com/xgr-network/xgr-node/server/proto"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestStaticPeers_ReconnectAfterRestart(t *testing.T) {
+	srvs := framework.NewTestServers(t, 2, func(config *framework.TestServerConfig) {
+		config.SetConsensus(framework.ConsensusDummy)
+	})
+
+	node, staticPeer := srvs[0], srvs[1]
+
+	status, err := staticPeer.Operator().GetStatus(context.Background(), &empty.Empty{})
+	require.NoError(t, err)
+
+	staticPeerAddr := strings.Split(status.P2PAddr, ",")[0]
+	staticPeerID := staticPeerAddr[strings.LastIndex(staticPeerAddr, "/")+1:]
+
+	addCtx, cancelAdd := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelAdd()
+
+	_, err = node.Operator().PeersAdd(addCtx, &proto.PeersAddRequest{
+		Id:      staticPeerAddr,
+		Static:  true,
+		Trusted: true,
+	})
+	require.NoError(t, err)
+
+	waitForStaticPeer := func() {
+		t.Helper()
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		res, err := framework.WaitUntilPeerConnects(ctx, node, 1)
+		require.NoError(t, err)
+
+		require.Len(t, res.Peers, 1)
+		require.Equal(t, staticPeerID, res.Peers[0].Id)
+		require.True(t, res.Peers[0].Static)
+		require.True(t, res.Peers[0].Trusted)
+	}
+
+	waitForStaticPeer()
+
+	// the static peer is restored from the persisted store and redialed
+	node.Stop()
+
+	startCtx, cancelStart := context.WithTimeout(context.Background(), time.Minute)
+	defer cancelStart()
+
+	require.NoError(t, node.Start(startCtx))
+
+	waitForStaticPeer()
+}
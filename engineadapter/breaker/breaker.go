@@ -0,0 +1,271 @@
+package breaker
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/go-hclog"
+	"github.com/xgr-network/xgr-node/state/runtime"
+)
+
+const breakerMetrics = "engine_breaker"
+
+const (
+	// DefaultThreshold is the number of consecutive authorization failures that trips the breaker
+	DefaultThreshold = 3
+	// DefaultInitialBackoff is the delay before the first probe of a tripped breaker
+	DefaultInitialBackoff = 5 * time.Second
+	// DefaultMaxBackoff caps the delay between two probes
+	DefaultMaxBackoff = 5 * time.Minute
+)
+
+// State is the state of the circuit breaker
+type State int
+
+const (
+	// Closed lets ENGINE_EXECUTE submissions through
+	Closed State = iota
+	// Open stops submissions until a probe sees the engine authorized again
+	Open
+)
+
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	default:
+		return "unknown"
+	}
+}
+
+// ProbeFn is a cheap read-only check of the engine authorization.
+// It returns nil once the engine is allowed to submit again.
+type ProbeFn func() error
+
+// Config is the configuration of the circuit breaker
+type Config struct {
+	// Threshold is the number of consecutive authorization failures that trips the breaker
+	Threshold int
+	// InitialBackoff is the delay before the first probe, it doubles after every failed probe
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between two probes
+	MaxBackoff time.Duration
+}
+
+// DefaultConfig returns the default circuit breaker configuration
+func DefaultConfig() Config {
+	return Config{
+		Threshold:      DefaultThreshold,
+		InitialBackoff: DefaultInitialBackoff,
+		MaxBackoff:     DefaultMaxBackoff,
+	}
+}
+
+// Status is a snapshot of the circuit breaker
+type Status struct {
+	State               string     `json:"state"`
+	ConsecutiveFailures int        `json:"consecutiveFailures"`
+	Trips               uint64     `json:"trips"`
+	LastError           string     `json:"lastError,omitempty"`
+	NextProbe           *time.Time `json:"nextProbe,omitempty"`
+}
+
+// Breaker stops the engine from submitting ENGINE_EXECUTE transactions once they keep failing
+// because the engine is not authorized (registry paused, engine EOA deauthorized, ...).
+// While open, the engine authorization is probed on a backoff schedule and
+// submissions resume as soon as a probe succeeds.
+type Breaker struct {
+	logger hclog.Logger
+	config Config
+	probe  ProbeFn
+
+	lock      sync.Mutex
+	state     State
+	failures  int
+	trips     uint64
+	lastErr   error
+	backoff   time.Duration
+	nextProbe time.Time
+
+	// now is replaced in tests
+	now func() time.Time
+}
+
+// New creates a closed circuit breaker
+func New(config Config, probe ProbeFn, logger hclog.Logger) *Breaker {
+	def := DefaultConfig()
+
+	if config.Threshold <= 0 {
+		config.Threshold = def.Threshold
+	}
+
+	if config.InitialBackoff <= 0 {
+		config.InitialBackoff = def.InitialBackoff
+	}
+
+	if config.MaxBackoff < config.InitialBackoff {
+		config.MaxBackoff = config.InitialBackoff
+	}
+
+	if logger == nil {
+		logger = hclog.NewNullLogger()
+	}
+
+	b := &Breaker{
+		logger: logger.Named("engine_breaker"),
+		config: config,
+		probe:  probe,
+		now:    time.Now,
+	}
+
+	b.publishState()
+
+	return b
+}
+
+// IsAuthorizationError returns whether the precompile error is caused by the engine
+// not being allowed to execute, as opposed to a failure of the single call
+func IsAuthorizationError(err error) bool {
+	return errors.Is(err, runtime.ErrUnauthorizedCaller) ||
+		errors.Is(err, runtime.ErrNotAuth)
+}
+
+// Allow returns whether the engine may submit an ENGINE_EXECUTE transaction.
+// If the breaker is open and the next probe is due, the probe is run first.
+func (b *Breaker) Allow() bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.state == Closed {
+		return true
+	}
+
+	if b.now().Before(b.nextProbe) {
+		return false
+	}
+
+	if err := b.probe(); err != nil {
+		metrics.IncrCounter([]string{breakerMetrics, "probe_failures"}, 1)
+
+		b.lastErr = err
+		b.backoff *= 2
+
+		if b.backoff > b.config.MaxBackoff {
+			b.backoff = b.config.MaxBackoff
+		}
+
+		b.nextProbe = b.now().Add(b.backoff)
+
+		b.logger.Debug("engine authorization probe failed", "err", err, "next", b.nextProbe)
+
+		return false
+	}
+
+	b.logger.Info("engine authorization restored, resuming submissions", "trips", b.trips)
+
+	b.reset()
+
+	return true
+}
+
+// Record feeds the result of an ENGINE_EXECUTE submission into the breaker.
+// Only authorization errors count towards tripping it, any success resets the count.
+func (b *Breaker) Record(err error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.state == Open {
+		return
+	}
+
+	if err == nil {
+		b.failures = 0
+
+		return
+	}
+
+	if !IsAuthorizationError(err) {
+		return
+	}
+
+	b.failures++
+	b.lastErr = err
+
+	if b.failures < b.config.Threshold {
+		return
+	}
+
+	b.state = Open
+	b.trips++
+	b.backoff = b.config.InitialBackoff
+	b.nextProbe = b.now().Add(b.backoff)
+
+	metrics.IncrCounter([]string{breakerMetrics, "trips"}, 1)
+	b.publishState()
+
+	b.logger.Error("ALERT: engine circuit breaker tripped, ENGINE_EXECUTE submissions stopped",
+		"failures", b.failures, "err", err, "nextProbe", b.nextProbe)
+}
+
+// Status returns a snapshot of the breaker
+func (b *Breaker) Status() *Status {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	res := &Status{
+		State:               b.state.String(),
+		ConsecutiveFailures: b.failures,
+		Trips:               b.trips,
+	}
+
+	if b.lastErr != nil {
+		res.LastError = b.lastErr.Error()
+	}
+
+	if b.state == Open {
+		nextProbe := b.nextProbe
+		res.NextProbe = &nextProbe
+	}
+
+	return res
+}
+
+func (b *Breaker) reset() {
+	b.state = Closed
+	b.failures = 0
+	b.lastErr = nil
+	b.backoff = 0
+	b.nextProbe = time.Time{}
+
+	b.publishState()
+}
+
+func (b *Breaker) publishState() {
+	metrics.SetGauge([]string{breakerMetrics, "open"}, float32(b.state))
+}
+
+var (
+	activeLock sync.RWMutex
+	active     *Breaker
+)
+
+// SetActive publishes the breaker guarding the embedded engine submissions,
+// so that the engine and the status RPC share the same instance
+func SetActive(b *Breaker) {
+	activeLock.Lock()
+	defer activeLock.Unlock()
+
+	active = b
+}
+
+// Active returns the breaker guarding the embedded engine submissions, if any
+func Active() *Breaker {
+	activeLock.RLock()
+	defer activeLock.RUnlock()
+
+	return active
+}
@@ -0,0 +1,121 @@
+package breaker
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xgr-network/xgr-node/state/runtime"
+)
+
+var errProbe = errors.New("engine not authorized")
+
+type scriptedProbe struct {
+	results []error
+	calls   int
+}
+
+func (p *scriptedProbe) probe() error {
+	res := p.results[p.calls]
+	p.calls++
+
+	return res
+}
+
+func newTestBreaker(t *testing.T, probe ProbeFn) (*Breaker, *time.Time) {
+	t.Helper()
+
+	now := time.Unix(1_700_000_000, 0)
+
+	b := New(Config{
+		Threshold:      3,
+		InitialBackoff: time.Second,
+		MaxBackoff:     4 * time.Second,
+	}, probe, nil)
+	b.now = func() time.Time { return now }
+
+	return b, &now
+}
+
+func TestBreaker_TripsOnConsecutiveAuthorizationFailures(t *testing.T) {
+	t.Parallel()
+
+	b, _ := newTestBreaker(t, func() error { return errProbe })
+
+	// call failures unrelated to the authorization don't count
+	b.Record(runtime.ErrInvalidInputData)
+	b.Record(runtime.ErrUnauthorizedCaller)
+	b.Record(runtime.ErrUnauthorizedCaller)
+
+	// a success resets the count
+	b.Record(nil)
+	require.Equal(t, 0, b.Status().ConsecutiveFailures)
+
+	b.Record(runtime.ErrUnauthorizedCaller)
+	b.Record(runtime.ErrNotAuth)
+	require.True(t, b.Allow())
+	require.Equal(t, "closed", b.Status().State)
+
+	b.Record(runtime.ErrUnauthorizedCaller)
+
+	status := b.Status()
+	require.Equal(t, "open", status.State)
+	require.Equal(t, uint64(1), status.Trips)
+	require.Equal(t, runtime.ErrUnauthorizedCaller.Error(), status.LastError)
+	require.NotNil(t, status.NextProbe)
+	require.False(t, b.Allow())
+}
+
+func TestBreaker_ProbesWithBackoffAndRecovers(t *testing.T) {
+	t.Parallel()
+
+	p := &scriptedProbe{results: []error{errProbe, errProbe, errProbe, nil}}
+	b, now := newTestBreaker(t, p.probe)
+
+	for i := 0; i < 3; i++ {
+		b.Record(runtime.ErrUnauthorizedCaller)
+	}
+
+	steps := []struct {
+		advance time.Duration
+		allowed bool
+		calls   int
+	}{
+		// initial backoff not elapsed, the probe isn't run
+		{500 * time.Millisecond, false, 0},
+		// first probe fails, the backoff doubles to 2s
+		{500 * time.Millisecond, false, 1},
+		{time.Second, false, 1},
+		// second probe fails, the backoff doubles to 4s
+		{time.Second, false, 2},
+		// third probe fails, the backoff is capped at 4s
+		{4 * time.Second, false, 3},
+		{3 * time.Second, false, 3},
+		// authorization is back, submissions resume
+		{time.Second, true, 4},
+	}
+
+	for i, s := range steps {
+		*now = now.Add(s.advance)
+
+		require.Equal(t, s.allowed, b.Allow(), "step %d", i)
+		require.Equal(t, s.calls, p.calls, "step %d", i)
+	}
+
+	status := b.Status()
+	require.Equal(t, "closed", status.State)
+	require.Equal(t, 0, status.ConsecutiveFailures)
+	require.Nil(t, status.NextProbe)
+
+	// a closed breaker doesn't probe and trips again on new failures
+	require.True(t, b.Allow())
+	require.Equal(t, 4, p.calls)
+
+	for i := 0; i < 3; i++ {
+		b.Record(runtime.ErrUnauthorizedCaller)
+	}
+
+	require.Equal(t, uint64(2), b.Status().Trips)
+	require.False(t, b.Allow())
+}
@@ -205,6 +205,11 @@ func (g *GasHelper) MaxPriorityFeePerGas() (*big.Int, error) {
 		if err := collectPrices(currentBlock); err != nil {
 			return nil, err
 		}
+
+		currentBlock, found = g.backend.GetBlockByHash(currentBlock.ParentHash(), true)
+		if !found {
+			return nil, fmt.Errorf(couldNotFoundBlockFormat, currentHeader.Number, currentHeader.Hash)
+		}
 	}
 
 	price := lastPrice
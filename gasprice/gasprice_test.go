@@ -168,6 +168,61 @@ func TestGasHelper_MaxPriorityFeePerGas(t *testing.T) {
 	}
 }
 
+func TestGasHelper_MaxPriorityFeePerGas_KnownTips(t *testing.T) {
+	t.Parallel()
+
+	config := &Config{
+		NumOfBlocksToCheck: 5,
+		PricePercentile:    50,
+		SampleNumber:       2,
+		MaxPrice:           ethgo.Gwei(500),
+		LastPrice:          ethgo.Gwei(1),
+		IgnorePrice:        big.NewInt(2),
+	}
+
+	// block i holds transactions tipping i, i+10 and i+20 gwei, the two lowest ones are sampled:
+	// 1, 2, 3, 4, 5, 11, 12, 13, 14, 15 => the 50th percentile is 5 gwei
+	backend := createTestBlocks(t, 5)
+	setTestTips(t, backend, func(number uint64) []uint64 {
+		return []uint64{number + 20, number, number + 10}
+	})
+
+	gasHelper, err := NewGasHelper(config, backend)
+	require.NoError(t, err)
+
+	price, err := gasHelper.MaxPriorityFeePerGas()
+	require.NoError(t, err)
+	require.Equal(t, ethgo.Gwei(5), price)
+
+	// the price is cached per head, so changes of the sampled blocks go unnoticed...
+	setTestTips(t, backend, func(number uint64) []uint64 {
+		return []uint64{100, 100, 100}
+	})
+
+	price, err = gasHelper.MaxPriorityFeePerGas()
+	require.NoError(t, err)
+	require.Equal(t, ethgo.Gwei(5), price)
+
+	// ...until the head changes
+	head := &types.Block{
+		Header: &types.Header{
+			Number:     6,
+			Hash:       types.BytesToHash([]byte("Block 6")),
+			Miner:      types.ZeroAddress.Bytes(),
+			ParentHash: backend.Header().Hash,
+			BaseFee:    chain.GenesisBaseFee,
+		},
+	}
+	backend.blocks[head.Hash()] = head
+	backend.blocksByNumber[head.Number()] = head
+	backend.ExpectedCalls = nil
+	backend.On("Header").Return(head.Header)
+
+	price, err = gasHelper.MaxPriorityFeePerGas()
+	require.NoError(t, err)
+	require.Equal(t, ethgo.Gwei(100), price)
+}
+
 func createTestBlocks(t *testing.T, numOfBlocks int) *backendMock {
 	t.Helper()
 
@@ -236,6 +291,38 @@ func createTestTxs(t *testing.T, backend *backendMock, numOfTxsPerBlock, txCap i
 	}
 }
 
+// setTestTips replaces the transactions of every non genesis block with ones tipping the given gwei amounts
+func setTestTips(t *testing.T, backend *backendMock, tips func(number uint64) []uint64) {
+	t.Helper()
+
+	for _, b := range backend.blocks {
+		if b.Number() == 0 {
+			continue
+		}
+
+		signer := crypto.NewSigner(backend.Config().Forks.At(b.Number()),
+			uint64(backend.Config().ChainID))
+
+		b.Transactions = nil
+
+		for _, tip := range tips(b.Number()) {
+			senderKey, sender := tests.GenerateKeyAndAddr(t)
+
+			tx, err := signer.SignTx(&types.Transaction{
+				From:      sender,
+				Value:     ethgo.Ether(1),
+				To:        &types.ZeroAddress,
+				Type:      types.DynamicFeeTx,
+				GasTipCap: ethgo.Gwei(tip),
+				GasFeeCap: new(big.Int).Add(new(big.Int).SetUint64(b.Header.BaseFee), ethgo.Gwei(tip)),
+			}, senderKey)
+			require.NoError(t, err)
+
+			b.Transactions = append(b.Transactions, tx)
+		}
+	}
+}
+
 var _ Blockchain = (*backendMock)(nil)
 
 type backendMock struct {
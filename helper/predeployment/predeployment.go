@@ -117,7 +117,7 @@ func getModifiedStorageMap(radix *state.Txn, address types.Address) map[types.Ha
 	return storageMap
 }
 
-func getPredeployAccount(address types.Address, input []byte, chainID int64) (*chain.GenesisAccount, error) {
+func getPredeployAccount(address types.Address, input []byte, params *chain.Params) (*chain.GenesisAccount, error) {
 	// Create an instance of the state
 	st := itrie.NewState(itrie.NewMemoryStorage())
 
@@ -142,8 +142,8 @@ func getPredeployAccount(address types.Address, input []byte, chainID int64) (*c
 	config := chain.AllForksEnabled.At(0)
 
 	// Create a transition
-	transition := state.NewTransition(config, snapshot, radix)
-	transition.ContextPtr().ChainID = chainID
+	transition := state.NewTransition(params, config, snapshot, radix)
+	transition.ContextPtr().ChainID = params.ChainID
 
 	// Run the transition through the EVM
 	res := evm.NewEVM().Run(contract, transition, &config)
@@ -174,7 +174,7 @@ func GenerateGenesisAccountFromFile(
 	filepath string,
 	constructorArgs []string,
 	predeployAddress types.Address,
-	chainID int64,
+	params *chain.Params,
 ) (*chain.GenesisAccount, error) {
 	// Create the artifact from JSON
 	artifact, err := loadContractArtifact(filepath)
@@ -212,5 +212,5 @@ func GenerateGenesisAccountFromFile(
 		finalBytecode = append(artifact.Bytecode, constructor...)
 	}
 
-	return getPredeployAccount(predeployAddress, finalBytecode, chainID)
+	return getPredeployAccount(predeployAddress, finalBytecode, params)
 }
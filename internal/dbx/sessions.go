@@ -0,0 +1,88 @@
+package dbx
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// MaxSessionsPageSize is the maximum number of sessions returned by a single ListSessions call.
+const MaxSessionsPageSize = 500
+
+var ErrInvalidPagination = errors.New("invalid pagination: limit must be 1..500 and offset >= 0")
+
+// SessionsSchema is the schema of the sessions table the engine writes and ListSessions reads.
+// Owners are stored as lowercase 0x-prefixed addresses, the ids are uint256 values.
+const SessionsSchema = `
+CREATE TABLE IF NOT EXISTS sessions (
+	owner       TEXT           NOT NULL,
+	session_id  NUMERIC(78, 0) NOT NULL,
+	process_id  NUMERIC(78, 0) NOT NULL,
+	status      TEXT           NOT NULL,
+	created_at  TIMESTAMPTZ    NOT NULL DEFAULT now(),
+	updated_at  TIMESTAMPTZ    NOT NULL DEFAULT now(),
+	PRIMARY KEY (owner, session_id)
+);
+
+CREATE INDEX IF NOT EXISTS sessions_owner_created_at_idx ON sessions (owner, created_at DESC, session_id DESC);
+`
+
+const listSessionsQuery = `
+SELECT owner, session_id::text, process_id::text, status, created_at, updated_at
+FROM sessions
+WHERE owner = $1
+ORDER BY created_at DESC, session_id DESC
+LIMIT $2 OFFSET $3`
+
+// Session is a row of the sessions table.
+type Session struct {
+	Owner     string    `json:"owner"`
+	SessionID string    `json:"sessionId"`
+	ProcessID string    `json:"processId"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Querier is the query part of *pgxpool.Pool, *pgx.Conn and pgx.Tx.
+type Querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+// ListSessions returns a page of the sessions of the owner from the process-wide pool,
+// newest first. An owner without sessions, or a page past the last one, returns no rows.
+func ListSessions(ctx context.Context, owner string, limit, offset int) ([]Session, error) {
+	pool, err := GetPGXPool(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return QuerySessions(ctx, pool, owner, limit, offset)
+}
+
+// QuerySessions is ListSessions against the given querier (e.g. a dedicated pool from NewPGXPool).
+func QuerySessions(ctx context.Context, q Querier, owner string, limit, offset int) ([]Session, error) {
+	if limit <= 0 || limit > MaxSessionsPageSize || offset < 0 {
+		return nil, ErrInvalidPagination
+	}
+
+	rows, err := q.Query(ctx, listSessionsQuery, strings.ToLower(strings.TrimSpace(owner)), limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := make([]Session, 0, limit)
+	for rows.Next() {
+		var s Session
+		if err := rows.Scan(&s.Owner, &s.SessionID, &s.ProcessID, &s.Status, &s.CreatedAt, &s.UpdatedAt); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, s)
+	}
+
+	return sessions, rows.Err()
+}
@@ -0,0 +1,133 @@
+package dbx
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestPool returns a pool on a fresh schema holding the sessions table,
+// the database is taken from XGR_TEST_DB_DSN
+func newTestPool(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+
+	dsn := strings.TrimSpace(os.Getenv("XGR_TEST_DB_DSN"))
+	if dsn == "" {
+		t.Skip("XGR_TEST_DB_DSN is not set")
+	}
+
+	ctx := context.Background()
+	schema := fmt.Sprintf("dbx_test_%d", time.Now().UnixNano())
+
+	admin, err := NewPGXPool(ctx, dsn)
+	require.NoError(t, err)
+
+	_, err = admin.Exec(ctx, "CREATE SCHEMA "+schema)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_, _ = admin.Exec(context.Background(), "DROP SCHEMA "+schema+" CASCADE")
+		admin.Close()
+	})
+
+	// unknown DSN parameters are sent as runtime parameters
+	if strings.Contains(dsn, "://") {
+		separator := "?"
+		if strings.Contains(dsn, "?") {
+			separator = "&"
+		}
+
+		dsn += separator + "search_path=" + schema
+	} else {
+		dsn += " search_path=" + schema
+	}
+
+	pool, err := NewPGXPool(ctx, dsn)
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+
+	_, err = pool.Exec(ctx, SessionsSchema)
+	require.NoError(t, err)
+
+	return pool
+}
+
+func TestQuerySessions(t *testing.T) {
+	pool := newTestPool(t)
+	ctx := context.Background()
+
+	const (
+		owner = "0x00000000000000000000000000000000000000aa"
+		other = "0x00000000000000000000000000000000000000bb"
+	)
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	seed := func(owner string, sessionID int, createdAt time.Time) {
+		t.Helper()
+
+		_, err := pool.Exec(ctx,
+			`INSERT INTO sessions (owner, session_id, process_id, status, created_at, updated_at)
+			VALUES ($1, $2, $3, 'active', $4, $4)`,
+			owner, sessionID, sessionID*10, createdAt,
+		)
+		require.NoError(t, err)
+	}
+
+	for i := 1; i <= 5; i++ {
+		seed(owner, i, base.Add(time.Duration(i)*time.Minute))
+	}
+
+	seed(other, 100, base)
+
+	sessionIDs := func(sessions []Session) []string {
+		ids := make([]string, 0, len(sessions))
+		for _, s := range sessions {
+			ids = append(ids, s.SessionID)
+		}
+
+		return ids
+	}
+
+	t.Run("pagination", func(t *testing.T) {
+		// the owner is matched case-insensitively, newest sessions first
+		first, err := QuerySessions(ctx, pool, strings.ToUpper(owner), 2, 0)
+		require.NoError(t, err)
+		require.Equal(t, []string{"5", "4"}, sessionIDs(first))
+		require.Equal(t, owner, first[0].Owner)
+		require.Equal(t, "50", first[0].ProcessID)
+		require.Equal(t, "active", first[0].Status)
+		require.True(t, first[0].CreatedAt.Equal(base.Add(5*time.Minute)))
+
+		second, err := QuerySessions(ctx, pool, owner, 2, 2)
+		require.NoError(t, err)
+		require.Equal(t, []string{"3", "2"}, sessionIDs(second))
+
+		last, err := QuerySessions(ctx, pool, owner, 2, 4)
+		require.NoError(t, err)
+		require.Equal(t, []string{"1"}, sessionIDs(last))
+	})
+
+	t.Run("empty results", func(t *testing.T) {
+		sessions, err := QuerySessions(ctx, pool, owner, 2, 10)
+		require.NoError(t, err)
+		require.Empty(t, sessions)
+
+		sessions, err = QuerySessions(ctx, pool, "0x00000000000000000000000000000000000000cc", 10, 0)
+		require.NoError(t, err)
+		require.Empty(t, sessions)
+	})
+
+	t.Run("invalid pagination", func(t *testing.T) {
+		for _, page := range [][2]int{{0, 0}, {MaxSessionsPageSize + 1, 0}, {1, -1}} {
+			_, err := QuerySessions(ctx, pool, owner, page[0], page[1])
+			require.ErrorIs(t, err, ErrInvalidPagination)
+		}
+	})
+}
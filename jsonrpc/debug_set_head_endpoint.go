@@ -0,0 +1,48 @@
+package jsonrpc
+
+import (
+	"github.com/xgr-network/xgr-node/blockchain"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+// setHeadStore provides the chain rewind of the debug_setHead endpoint
+type setHeadStore interface {
+	// SetHead rewinds the canonical chain to the block with the given number
+	SetHead(number uint64) (*blockchain.RewindResult, error)
+}
+
+// DebugSetHead extends the debug namespace with the chain rewind.
+// It is only registered if the node enables it with --json-rpc-enable-set-head.
+type DebugSetHead struct {
+	store setHeadStore
+}
+
+// setHeadResult lists what a chain rewind removed
+type setHeadResult struct {
+	OldHead          argUint64  `json:"oldHead"`
+	NewHead          argUint64  `json:"newHead"`
+	NewHeadHash      types.Hash `json:"newHeadHash"`
+	RemovedBlocks    argUint64  `json:"removedBlocks"`
+	RemovedReceipts  argUint64  `json:"removedReceipts"`
+	RemovedTxLookups argUint64  `json:"removedTxLookups"`
+}
+
+// SetHead rewinds the canonical chain to the given block. The canonical hash mappings,
+// receipts and transaction lookups above it are removed, the state is kept so the node
+// re-executes forward. The rewind is refused below the finalized block or if the state
+// of the block is not available.
+func (d *DebugSetHead) SetHead(number argUint64) (interface{}, error) {
+	res, err := d.store.SetHead(uint64(number))
+	if err != nil {
+		return nil, err
+	}
+
+	return &setHeadResult{
+		OldHead:          argUint64(res.OldHead),
+		NewHead:          argUint64(res.NewHead),
+		NewHeadHash:      res.NewHeadHash,
+		RemovedBlocks:    argUint64(res.RemovedBlocks),
+		RemovedReceipts:  argUint64(res.RemovedReceipts),
+		RemovedTxLookups: argUint64(res.RemovedTxLookups),
+	}, nil
+}
@@ -0,0 +1,69 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xgr-network/xgr-node/blockchain"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+type mockSetHeadStore struct {
+	*mockStore
+
+	target uint64
+}
+
+func (m *mockSetHeadStore) SetHead(number uint64) (*blockchain.RewindResult, error) {
+	m.target = number
+
+	return &blockchain.RewindResult{
+		OldHead:          10,
+		NewHead:          number,
+		NewHeadHash:      types.StringToHash("head"),
+		RemovedBlocks:    10 - number,
+		RemovedReceipts:  10 - number,
+		RemovedTxLookups: 2,
+	}, nil
+}
+
+func TestDispatcher_SetHeadRequiresFlag(t *testing.T) {
+	t.Parallel()
+
+	req := Request{Method: "debug_setHead", Params: json.RawMessage(`["0x4"]`)}
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		dispatcher := newTestDispatcher(t, hclog.NewNullLogger(), &mockSetHeadStore{mockStore: newMockStore()},
+			&dispatcherParams{blockRangeLimit: 1000})
+
+		_, err := dispatcher.handleReq(req)
+		require.Error(t, err)
+		assert.Equal(t, NewMethodNotFoundError("debug_setHead"), err)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		t.Parallel()
+
+		store := &mockSetHeadStore{mockStore: newMockStore()}
+		dispatcher := newTestDispatcher(t, hclog.NewNullLogger(), store,
+			&dispatcherParams{blockRangeLimit: 1000, enableSetHead: true})
+
+		res, err := dispatcher.handleReq(req)
+		require.Nil(t, err)
+		assert.Equal(t, uint64(4), store.target)
+		assert.JSONEq(t, `{
+			"oldHead": "0xa",
+			"newHead": "0x4",
+			"newHeadHash": "`+types.StringToHash("head").String()+`",
+			"removedBlocks": "0x6",
+			"removedReceipts": "0x6",
+			"removedTxLookups": "0x2"
+		}`, string(res))
+	})
+}
@@ -15,9 +15,11 @@ import (
 
 	"github.com/armon/go-metrics"
 	"github.com/hashicorp/go-hclog"
+	"github.com/xgr-network/xgr-node/engineadapter/breaker"
 	"github.com/xgr-network/xgr-node/engineadapter/stub"
 	"github.com/xgr-network/xgr-node/engineiface"
 	xgrsvc "github.com/xgr-network/xgr-node/jsonrpc/xgr"
+	"github.com/xgr-network/xgr-node/types"
 )
 
 type serviceData struct {
@@ -26,6 +28,7 @@ type serviceData struct {
 }
 
 type funcData struct {
+	sv    reflect.Value
 	inNum int
 	reqt  []reflect.Type
 	fv    reflect.Value
@@ -37,13 +40,15 @@ func (f *funcData) numParams() int {
 }
 
 type endpoints struct {
-	Eth    *Eth
-	Web3   *Web3
-	Net    *Net
-	TxPool *TxPool
-	Bridge *Bridge
-	Debug  *Debug
-	XGR    *xgrsvc.XGR
+	Eth      *Eth
+	Web3     *Web3
+	Net      *Net
+	TxPool   *TxPool
+	Bridge   *Bridge
+	Debug    *Debug
+	SetHead  *DebugSetHead
+	XGR      *xgrsvc.XGR
+	XGRState *XGRState
 }
 
 // Dispatcher handles all json rpc requests by delegating
@@ -66,6 +71,20 @@ type dispatcherParams struct {
 	blockRangeLimit         uint64
 
 	concurrentRequestsDebug uint64
+
+	// enableSetHead registers the debug_setHead chain rewind
+	enableSetHead bool
+
+	// disableEngine rejects the xgr engine methods, the chain runs without ENGINE_EXECUTE
+	disableEngine bool
+
+	// donationPercent is the fallback donation percent reported by the xgr fee split methods
+	donationPercent uint64
+}
+
+// engineStateMethods are the state-backed xgr methods which serve engine data
+var engineStateMethods = map[string]struct{}{
+	"listSessions": {},
 }
 
 func (dp dispatcherParams) isExceedingBatchLengthLimit(value uint64) bool {
@@ -101,6 +120,7 @@ func (d *Dispatcher) registerEndpoints(store JSONRPCStore) error {
 		d.params.chainID,
 		d.filterManager,
 		d.params.priceLimit,
+		nil,
 	}
 	d.endpoints.Net = &Net{
 		store,
@@ -151,12 +171,46 @@ func (d *Dispatcher) registerEndpoints(store JSONRPCStore) error {
 			EngineEOA:   engineEOA,
 			EnginePub33: enginePub33,
 		})
+
+		// stop the engine from burning gas on ENGINE_EXECUTE while it is not authorized
+		if engineEOA != "" {
+			probe := xgrsvc.EngineProbe(func() (xgrsvc.StateReader, error) {
+				header := store.Header()
+				if header == nil {
+					return nil, ErrLatestNotFound
+				}
+
+				return &rootStateReader{store, header.StateRoot}, nil
+			}, types.StringToAddress(engineEOA))
+
+			engineBreaker := breaker.New(breaker.DefaultConfig(), probe, d.logger)
+
+			breaker.SetActive(engineBreaker)
+
+			d.endpoints.Eth.engine = &engineSubmissions{
+				breaker: engineBreaker,
+				engine:  types.StringToAddress(engineEOA),
+			}
+		}
 	} else {
 		stub.LogEnabled(d.logger)
 		d.endpoints.XGR = xgrsvc.New(xgrsvc.Config{
 			Logger:    d.logger.Named("xgr"),
 			EthRPCURL: ethRPCURL,
 		})
+		xgrsvc.BindState(d.endpoints.XGR, func() (xgrsvc.StateReader, error) {
+			header := store.Header()
+			if header == nil {
+				return nil, ErrLatestNotFound
+			}
+
+			return &rootStateReader{store, header.StateRoot}, nil
+		})
+	}
+	d.endpoints.XGRState = &XGRState{
+		store,
+		d.params.blockRangeLimit,
+		d.params.donationPercent,
 	}
 	d.endpoints.Debug = NewDebug(store, d.params.concurrentRequestsDebug)
 
@@ -181,10 +235,24 @@ func (d *Dispatcher) registerEndpoints(store JSONRPCStore) error {
 	if err = d.registerService("xgr", d.endpoints.XGR); err != nil {
 		return err
 	}
+	// state-backed xgr methods extend the engine namespace in every build mode
+	if err = d.registerService("xgr", d.endpoints.XGRState); err != nil {
+		return err
+	}
 
 	if err = d.registerService("debug", d.endpoints.Debug); err != nil {
 		return err
 	}
+
+	// the chain rewind extends the debug namespace only if it is explicitly enabled
+	if d.params.enableSetHead {
+		d.endpoints.SetHead = &DebugSetHead{store}
+
+		if err = d.registerService("debug", d.endpoints.SetHead); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 func (d *Dispatcher) getFnHandler(req Request) (*serviceData, *funcData, Error) {
@@ -206,9 +274,22 @@ func (d *Dispatcher) getFnHandler(req Request) (*serviceData, *funcData, Error)
 		return nil, nil, NewMethodNotFoundError(req.Method)
 	}
 
+	if d.params.disableEngine && serviceName == "xgr" && d.isEngineMethod(funcName, fd) {
+		return nil, nil, NewEngineDisabledError(req.Method)
+	}
+
 	return service, fd, nil
 }
 
+// isEngineMethod checks if the xgr method is served by the engine endpoint or reads engine data
+func (d *Dispatcher) isEngineMethod(funcName string, fd *funcData) bool {
+	if _, ok := engineStateMethods[funcName]; ok {
+		return true
+	}
+
+	return d.endpoints.XGR != nil && fd.sv.Interface() == d.endpoints.XGR
+}
+
 type wsConn interface {
 	WriteMessage(messageType int, data []byte) error
 	GetFilterID() string
@@ -258,8 +339,32 @@ func (d *Dispatcher) handleSubscribe(req Request, conn wsConn) (string, Error) {
 			return "", NewInternalError(err.Error())
 		}
 		filterID = d.filterManager.NewLogFilter(logQuery, conn)
+	} else if subscribeMethod == xgrSessionSubscription {
+		if len(params) < 2 {
+			return "", NewInvalidParamsError("Invalid params")
+		}
+
+		sessionQuery, err := decodeSessionQueryFromInterface(params[1])
+		if err != nil {
+			return "", NewInvalidParamsError(err.Error())
+		}
+		filterID = d.filterManager.NewSessionFilter(sessionQuery, conn)
 	} else if subscribeMethod == "newPendingTransactions" {
-		filterID = d.filterManager.NewPendingTxFilter(conn)
+		pendingTxQuery := newPendingTxQuery()
+
+		if len(params) > 1 {
+			var err error
+			if pendingTxQuery, err = decodePendingTxQueryFromInterface(params[1]); err != nil {
+				return "", NewInvalidParamsError(err.Error())
+			}
+		}
+
+		id, err := d.filterManager.NewPendingTxStreamFilter(pendingTxQuery, conn)
+		if err != nil {
+			return "", NewInvalidParamsError(err.Error())
+		}
+
+		filterID = id
 	} else {
 		return "", NewSubscriptionNotFoundError(subscribeMethod)
 	}
@@ -444,13 +549,13 @@ func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
 func (d *Dispatcher) handleReq(req Request) ([]byte, Error) {
 	d.logger.Debug("request", "method", req.Method, "id", req.ID)
 
-	service, fd, ferr := d.getFnHandler(req)
+	_, fd, ferr := d.getFnHandler(req)
 	if ferr != nil {
 		return nil, ferr
 	}
 
 	inArgs := make([]reflect.Value, fd.inNum)
-	inArgs[0] = service.sv
+	inArgs[0] = fd.sv
 
 	inputs := make([]interface{}, fd.numParams())
 
@@ -482,6 +587,14 @@ func (d *Dispatcher) handleReq(req Request) ([]byte, Error) {
 		metrics.IncrCounter([]string{jsonRPCMetric, req.Method + "_errors"}, 1)
 		d.logInternalError(req.Method, err)
 
+		if stateErr := newStateError(err); stateErr != nil {
+			return nil, stateErr
+		}
+
+		if paramsErr := newParamsError(err); paramsErr != nil {
+			return nil, paramsErr
+		}
+
 		if res := output[0].Interface(); res != nil {
 			data, ok = res.([]byte)
 
@@ -490,6 +603,10 @@ func (d *Dispatcher) handleReq(req Request) ([]byte, Error) {
 			}
 		}
 
+		if revertErr := newRevertError(err); revertErr != nil {
+			return data, revertErr
+		}
+
 		return data, NewInvalidRequestError(err.Error())
 	}
 
@@ -536,6 +653,7 @@ func (d *Dispatcher) registerService(serviceName string, service interface{}) er
 		fmt.Println("Registering:", serviceName+"_"+name)
 		funcName := serviceName + "_" + name
 		fd := &funcData{
+			sv: reflect.ValueOf(service),
 			fv: mv.Func,
 		}
 
@@ -555,6 +673,15 @@ func (d *Dispatcher) registerService(serviceName string, service interface{}) er
 		funcMap[name] = fd
 	}
 
+	// a namespace can be served by several receivers (e.g. engine xgr + state-backed xgr)
+	if existing, ok := d.serviceMap[serviceName]; ok {
+		for name, fd := range funcMap {
+			existing.funcMap[name] = fd
+		}
+
+		return nil
+	}
+
 	d.serviceMap[serviceName] = &serviceData{
 		sv:      reflect.ValueOf(service),
 		funcMap: funcMap,
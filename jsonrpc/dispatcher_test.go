@@ -2,12 +2,15 @@ package jsonrpc
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"reflect"
 	"testing"
 	"time"
 
+	"github.com/xgr-network/xgr-node/state"
+	"github.com/xgr-network/xgr-node/state/runtime/precompiled"
 	"github.com/xgr-network/xgr-node/txpool/proto"
 	"github.com/xgr-network/xgr-node/types"
 	"github.com/hashicorp/go-hclog"
@@ -316,6 +319,93 @@ func TestDispatcherFuncDecode(t *testing.T) {
 	}
 }
 
+// stateErrService returns the configured error from every call
+type stateErrService struct {
+	err error
+}
+
+func (s *stateErrService) Call() (interface{}, error) {
+	return nil, s.err
+}
+
+func TestDispatcher_StateErrors(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{
+			"state not found",
+			fmt.Errorf("unable to get snapshot: %w", state.ErrStateNotFound),
+			-32001,
+		},
+		{
+			"storage unavailable",
+			fmt.Errorf("unable to get snapshot: %w", state.ErrStorageUnavailable),
+			-32002,
+		},
+		{
+			"malformed engine calldata",
+			fmt.Errorf("%w, the precompile charges 21000 gas for it", precompiled.ErrMalformedEngineExecute),
+			-32602,
+		},
+		{
+			"other error",
+			errors.New("boom"),
+			-32600,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			dispatcher := newTestDispatcher(t,
+				hclog.NewNullLogger(),
+				newMockStore(),
+				&dispatcherParams{
+					jsonRPCBatchLengthLimit: 20,
+					blockRangeLimit:         1000,
+				},
+			)
+
+			require.NoError(t, dispatcher.registerService("mock", &stateErrService{err: c.err}))
+
+			_, err := dispatcher.handleReq(Request{Method: "mock_call"})
+			require.Error(t, err)
+			assert.Equal(t, c.code, err.ErrorCode())
+			assert.Equal(t, c.err.Error(), err.Error())
+		})
+	}
+}
+
+func TestDispatcher_PrunedStateError(t *testing.T) {
+	t.Parallel()
+
+	dispatcher := newTestDispatcher(t,
+		hclog.NewNullLogger(),
+		newMockStore(),
+		&dispatcherParams{
+			jsonRPCBatchLengthLimit: 20,
+			blockRangeLimit:         1000,
+		},
+	)
+
+	pruned := fmt.Errorf("unable to get snapshot: %w: %w", state.ErrStatePruned, state.ErrStateNotFound)
+
+	require.NoError(t, dispatcher.registerService("mock", &stateErrService{err: pruned}))
+
+	_, err := dispatcher.handleReq(Request{Method: "mock_call"})
+	require.Error(t, err)
+	assert.Equal(t, -32001, err.ErrorCode())
+	assert.Contains(t, err.Error(), "state not available (pruned)")
+	assert.Contains(t, err.Error(), "archive node")
+}
+
 func TestDispatcherBatchRequest(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,54 @@
+package jsonrpc
+
+import (
+	"errors"
+
+	"github.com/xgr-network/xgr-node/contracts"
+	"github.com/xgr-network/xgr-node/crypto"
+	"github.com/xgr-network/xgr-node/engineadapter/breaker"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+// errEngineBreakerOpen is returned for the ENGINE_EXECUTE submissions of the engine while its breaker is open
+var errEngineBreakerOpen = errors.New("engine circuit breaker is open, ENGINE_EXECUTE submissions are stopped")
+
+// engineSubmissions puts the ENGINE_EXECUTE transactions which the embedded engine submits
+// with eth_sendRawTransaction behind the engine circuit breaker
+type engineSubmissions struct {
+	breaker *breaker.Breaker
+	engine  types.Address
+}
+
+// check is called with every raw transaction before it is added to the pool. Other transactions
+// pass, the engine ones are rejected while the breaker is open. Otherwise they are executed on
+// top of the head first, and the result is recorded by the breaker.
+func (s *engineSubmissions) check(tx *types.Transaction, store ethStore, chainID uint64) error {
+	if tx.To == nil || *tx.To != contracts.EngineExecutePrecompile {
+		return nil
+	}
+
+	header := store.Header()
+
+	// a transaction with an invalid signature is rejected by the pool
+	from, err := crypto.NewSigner(store.GetForksInTime(header.Number), chainID).Sender(tx)
+	if err != nil || from != s.engine {
+		return nil
+	}
+
+	if !s.breaker.Allow() {
+		return errEngineBreakerOpen
+	}
+
+	call := tx.Copy()
+	call.From = from
+
+	result, err := store.ApplyTxn(header, call, nil, true)
+	if err != nil {
+		// the transaction is not executable on top of the head, which says nothing about the engine
+		return nil
+	}
+
+	s.breaker.Record(result.Err)
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package jsonrpc
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xgr-network/xgr-node/chain"
+	"github.com/xgr-network/xgr-node/contracts"
+	"github.com/xgr-network/xgr-node/crypto"
+	"github.com/xgr-network/xgr-node/engineadapter/breaker"
+	"github.com/xgr-network/xgr-node/state/runtime"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+// engineSubmissionStore executes every ENGINE_EXECUTE call with the configured error
+type engineSubmissionStore struct {
+	ethStore
+
+	callErr error
+	calls   int
+	added   []*types.Transaction
+}
+
+func (m *engineSubmissionStore) Header() *types.Header {
+	return &types.Header{Number: 1}
+}
+
+func (m *engineSubmissionStore) GetForksInTime(uint64) chain.ForksInTime {
+	return chain.AllForksEnabled.At(0)
+}
+
+func (m *engineSubmissionStore) ApplyTxn(
+	*types.Header, *types.Transaction, types.StateOverride, bool,
+) (*runtime.ExecutionResult, error) {
+	m.calls++
+
+	return &runtime.ExecutionResult{Err: m.callErr}, nil
+}
+
+func (m *engineSubmissionStore) AddTx(tx *types.Transaction) error {
+	m.added = append(m.added, tx)
+
+	return nil
+}
+
+func TestEth_SendRawTransaction_EngineBreaker(t *testing.T) {
+	engineKey, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	otherKey, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	signer := crypto.NewSigner(chain.AllForksEnabled.At(0), 100)
+
+	rawTx := func(key *ecdsa.PrivateKey, to types.Address, nonce uint64) []byte {
+		t.Helper()
+
+		tx, err := signer.SignTx(&types.Transaction{
+			Nonce:    nonce,
+			To:       &to,
+			Gas:      100000,
+			GasPrice: big.NewInt(1),
+			Value:    big.NewInt(0),
+		}, key)
+		require.NoError(t, err)
+
+		return tx.MarshalRLP()
+	}
+
+	store := &engineSubmissionStore{callErr: runtime.ErrUnauthorizedCaller}
+	engineBreaker := breaker.New(breaker.Config{Threshold: 3, InitialBackoff: time.Hour}, func() error {
+		return errEngineBreakerOpen
+	}, hclog.NewNullLogger())
+
+	eth := newTestEthEndpoint(store)
+	eth.engine = &engineSubmissions{
+		breaker: engineBreaker,
+		engine:  crypto.PubKeyToAddress(&engineKey.PublicKey),
+	}
+
+	// the failing submissions go through until the breaker trips
+	for nonce := uint64(0); nonce < 3; nonce++ {
+		_, err := eth.SendRawTransaction(rawTx(engineKey, contracts.EngineExecutePrecompile, nonce))
+		require.NoError(t, err)
+	}
+
+	require.Len(t, store.added, 3)
+	require.Equal(t, "open", engineBreaker.Status().State)
+
+	_, err = eth.SendRawTransaction(rawTx(engineKey, contracts.EngineExecutePrecompile, 3))
+	require.ErrorIs(t, err, errEngineBreakerOpen)
+	require.Len(t, store.added, 3)
+
+	// other transactions of the engine and the ENGINE_EXECUTE calls of other senders still pass
+	_, err = eth.SendRawTransaction(rawTx(engineKey, types.StringToAddress("0x1"), 3))
+	require.NoError(t, err)
+
+	_, err = eth.SendRawTransaction(rawTx(otherKey, contracts.EngineExecutePrecompile, 0))
+	require.NoError(t, err)
+
+	require.Len(t, store.added, 5)
+	require.Equal(t, 3, store.calls)
+}
@@ -5,7 +5,9 @@ import (
 	"fmt"
 
 	"github.com/umbracle/ethgo/abi"
+	"github.com/xgr-network/xgr-node/state"
 	"github.com/xgr-network/xgr-node/state/runtime"
+	"github.com/xgr-network/xgr-node/state/runtime/precompiled"
 )
 
 var (
@@ -52,6 +54,60 @@ func (e *invalidRequestError) ErrorCode() int {
 	return -32600
 }
 
+// stateNotFoundError is returned when the requested state doesn't exist on the node
+type stateNotFoundError struct {
+	err string
+}
+
+func (e *stateNotFoundError) Error() string {
+	return e.err
+}
+
+func (e *stateNotFoundError) ErrorCode() int {
+	return -32001
+}
+
+// storageUnavailableError is returned when the state storage is temporarily unavailable,
+// the request can be retried
+type storageUnavailableError struct {
+	err string
+}
+
+func (e *storageUnavailableError) Error() string {
+	return e.err
+}
+
+func (e *storageUnavailableError) ErrorCode() int {
+	return -32002
+}
+
+// revertError is returned when the EVM reverted the execution,
+// the response data holds the revert payload
+type revertError struct {
+	err string
+}
+
+func (e *revertError) Error() string {
+	return e.err
+}
+
+func (e *revertError) ErrorCode() int {
+	return 3
+}
+
+// engineDisabledError is returned by the xgr engine methods on a chain which runs without the engine
+type engineDisabledError struct {
+	err string
+}
+
+func (e *engineDisabledError) Error() string {
+	return e.err
+}
+
+func (e *engineDisabledError) ErrorCode() int {
+	return -32004
+}
+
 type subscriptionNotFoundError struct {
 	err string
 }
@@ -90,10 +146,54 @@ func NewInternalError(msg string) *internalError {
 	return &internalError{msg}
 }
 
+func NewEngineDisabledError(method string) *engineDisabledError {
+	return &engineDisabledError{fmt.Sprintf("the method %s is not supported, the engine is disabled on this chain", method)}
+}
+
 func NewSubscriptionNotFoundError(method string) *subscriptionNotFoundError {
 	return &subscriptionNotFoundError{fmt.Sprintf("subscribe method %s not found", method)}
 }
 
+// newStateError maps the state errors to their JSON-RPC errors.
+// It returns nil for any other error.
+func newStateError(err error) Error {
+	switch {
+	case errors.Is(err, state.ErrStorageUnavailable):
+		return &storageUnavailableError{err.Error()}
+	case errors.Is(err, state.ErrStatePruned):
+		return &stateNotFoundError{err.Error() +
+			"; this node only keeps the state of recent blocks, query an archive node for older state"}
+	case errors.Is(err, state.ErrStateNotFound):
+		return &stateNotFoundError{err.Error()}
+	default:
+		return nil
+	}
+}
+
+// newParamsError maps the errors caused by malformed endpoint arguments to invalid params errors.
+// It returns nil for any other error.
+func newParamsError(err error) Error {
+	switch {
+	case errors.Is(err, precompiled.ErrNotEngineExecute),
+		errors.Is(err, precompiled.ErrMalformedEngineExecute):
+		return NewInvalidParamsError(err.Error())
+	default:
+		return nil
+	}
+}
+
+// newRevertError maps the EVM revert to the revert error, the code clients expect with the revert data.
+// It returns nil for any other error.
+func newRevertError(err error) Error {
+	if !errors.Is(err, runtime.ErrExecutionReverted) {
+		return nil
+	}
+
+	return &revertError{err.Error()}
+}
+
+// constructErrorFromRevert decodes the Error(string) revert reason into the error message,
+// other payloads (custom errors, panics) are only returned as the data of the response
 func constructErrorFromRevert(result *runtime.ExecutionResult) error {
 	revertErrMsg, unpackErr := abi.UnpackRevertError(result.ReturnValue)
 	if unpackErr != nil {
@@ -0,0 +1,219 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xgr-network/xgr-node/chain"
+	"github.com/xgr-network/xgr-node/crypto"
+	"github.com/xgr-network/xgr-node/helper/hex"
+	"github.com/xgr-network/xgr-node/state"
+	itrie "github.com/xgr-network/xgr-node/state/immutable-trie"
+	"github.com/xgr-network/xgr-node/state/runtime"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+// revertCode returns the bytecode of a contract reverting with the payload
+func revertCode(payload []byte) []byte {
+	var code []byte
+
+	for offset := 0; offset < len(payload); offset += 32 {
+		word := make([]byte, 32)
+		copy(word, payload[offset:])
+
+		// PUSH32 word, PUSH1 offset, MSTORE
+		code = append(code, 0x7f)
+		code = append(code, word...)
+		code = append(code, 0x60, byte(offset), 0x52)
+	}
+
+	// PUSH1 len, PUSH1 0, REVERT
+	return append(code, 0x60, byte(len(payload)), 0x60, 0x00, 0xfd)
+}
+
+// newEVMStore returns a store executing the calls on a state holding the contracts
+func newEVMStore(t *testing.T, contracts map[types.Address][]byte) *mockSpecialStore {
+	t.Helper()
+
+	params := &chain.Params{
+		Forks: chain.AllForksEnabled,
+		BurnContract: map[uint64]types.Address{
+			0: types.ZeroAddress,
+		},
+	}
+
+	executor := state.NewExecutor(params, itrie.NewState(itrie.NewMemoryStorage()), hclog.NewNullLogger())
+	executor.GetHash = func(*types.Header) state.GetHashByNumber {
+		return func(uint64) types.Hash {
+			return types.ZeroHash
+		}
+	}
+
+	genesis := make(map[types.Address]*chain.GenesisAccount, len(contracts))
+	for addr, code := range contracts {
+		genesis[addr] = &chain.GenesisAccount{Code: code, Balance: big.NewInt(0)}
+	}
+
+	root, err := executor.WriteGenesis(genesis, types.ZeroHash)
+	require.NoError(t, err)
+
+	store := getExampleStore()
+	store.account.account.Balance = new(big.Int).SetUint64(1_000_000_000_000_000_000)
+	store.applyTxnOverrideHook = func(
+		header *types.Header, txn *types.Transaction, override types.StateOverride) (*runtime.ExecutionResult, error) {
+		transition, err := executor.BeginTxn(root, &types.Header{Number: 1, GasLimit: header.GasLimit}, types.ZeroAddress)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := transition.WithStateOverride(override); err != nil {
+			return nil, err
+		}
+
+		transition.SetNonPayable(true)
+
+		return transition.ApplyReadOnly(txn)
+	}
+
+	return store
+}
+
+func TestDispatcher_RevertErrors(t *testing.T) {
+	t.Parallel()
+
+	var (
+		stringReverter = types.StringToAddress("0x1001")
+		customReverter = types.StringToAddress("0x1002")
+		invalidOpcode  = types.StringToAddress("0x1003")
+		endlessLoop    = types.StringToAddress("0x1004")
+	)
+
+	// Error("revert reason")
+	stringPayload, err := hex.DecodeHex("08c379a0" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"000000000000000000000000000000000000000000000000000000000000000d" +
+		"72657665727420726561736f6e00000000000000000000000000000000000000")
+	require.NoError(t, err)
+
+	// InsufficientBalance(uint256 available, uint256 required)
+	customPayload := append(crypto.Keccak256([]byte("InsufficientBalance(uint256,uint256)"))[:4],
+		append(types.BytesToHash(big.NewInt(1).Bytes()).Bytes(), types.BytesToHash(big.NewInt(2).Bytes()).Bytes()...)...)
+
+	store := newEVMStore(t, map[types.Address][]byte{
+		stringReverter: revertCode(stringPayload),
+		customReverter: revertCode(customPayload),
+		invalidOpcode:  {0xfe},
+		// JUMPDEST, PUSH1 0, JUMP
+		endlessLoop: {0x5b, 0x60, 0x00, 0x56},
+	})
+
+	dispatcher := newTestDispatcher(t,
+		hclog.NewNullLogger(),
+		newMockStore(),
+		&dispatcherParams{
+			jsonRPCBatchLengthLimit: 20,
+			blockRangeLimit:         1000,
+		},
+	)
+
+	require.NoError(t, dispatcher.registerService("test", newTestEthEndpoint(store)))
+
+	cases := []struct {
+		name    string
+		method  string
+		to      types.Address
+		code    int
+		message string
+		data    string
+	}{
+		{
+			"call reverting with a string",
+			"test_call",
+			stringReverter,
+			3,
+			"execution reverted: revert reason",
+			hex.EncodeToHex(stringPayload),
+		},
+		{
+			"call reverting with a custom error",
+			"test_call",
+			customReverter,
+			3,
+			"execution reverted",
+			hex.EncodeToHex(customPayload),
+		},
+		{
+			"call hitting an invalid opcode",
+			"test_call",
+			invalidOpcode,
+			-32600,
+			"unable to execute call: opcode not found",
+			"",
+		},
+		{
+			"call running out of gas",
+			"test_call",
+			endlessLoop,
+			-32600,
+			"unable to execute call: out of gas",
+			"",
+		},
+		{
+			"estimate reverting with a string",
+			"test_estimateGas",
+			stringReverter,
+			3,
+			"execution reverted: revert reason",
+			hex.EncodeToHex(stringPayload),
+		},
+		{
+			"estimate reverting with a custom error",
+			"test_estimateGas",
+			customReverter,
+			3,
+			"execution reverted",
+			hex.EncodeToHex(customPayload),
+		},
+		{
+			"estimate running out of gas",
+			"test_estimateGas",
+			endlessLoop,
+			-32600,
+			"unable to apply transaction even for the highest gas limit 500000: out of gas",
+			"",
+		},
+	}
+
+	for _, c := range cases {
+		args := fmt.Sprintf(`{"from":"%s","to":"%s","gasPrice":"0x1"}`, addr0, c.to)
+
+		params := fmt.Sprintf(`[%s, "latest", null]`, args)
+		if c.method == "test_estimateGas" {
+			params = fmt.Sprintf(`[%s, "latest"]`, args)
+		}
+
+		data, rpcErr := dispatcher.handleReq(Request{Method: c.method, Params: json.RawMessage(params)})
+		require.Error(t, rpcErr, c.name)
+
+		raw, err := NewRPCResponse(1, "2.0", data, rpcErr).Bytes()
+		require.NoError(t, err, c.name)
+
+		var resp struct {
+			Error struct {
+				Code    int    `json:"code"`
+				Message string `json:"message"`
+				Data    string `json:"data"`
+			} `json:"error"`
+		}
+
+		require.NoError(t, json.Unmarshal(raw, &resp), c.name)
+		require.Equal(t, c.code, resp.Error.Code, c.name)
+		require.Equal(t, c.message, resp.Error.Message, c.name)
+		require.Equal(t, c.data, resp.Error.Data, c.name)
+	}
+}
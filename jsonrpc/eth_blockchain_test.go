@@ -1,6 +1,7 @@
 package jsonrpc
 
 import (
+	"encoding/json"
 	"errors"
 	"math/big"
 	"testing"
@@ -13,6 +14,7 @@ import (
 	"github.com/xgr-network/xgr-node/txpool/proto"
 	"github.com/xgr-network/xgr-node/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestEth_Block_GetBlockByNumber(t *testing.T) {
@@ -68,6 +70,44 @@ func TestEth_Block_GetBlockByHash(t *testing.T) {
 	assert.Nil(t, res)
 }
 
+func TestEth_Block_Economics(t *testing.T) {
+	store := &mockBlockStore{
+		economics: map[types.Hash]*types.BlockEconomics{
+			hash1: {
+				TotalBurned:        big.NewInt(2000),
+				TotalDonated:       big.NewInt(15),
+				TotalValidatorFees: big.NewInt(300),
+				EngineTxCount:      1,
+			},
+		},
+	}
+	store.add(newTestBlock(1, hash1))
+	store.add(newTestBlock(2, hash2))
+
+	eth := newTestEthEndpoint(store)
+
+	res, err := eth.GetBlockByNumber(BlockNumber(1), false)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(res.(*block).XGR)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"totalBurnedWei": "0x7d0",
+		"totalDonatedWei": "0xf",
+		"totalValidatorFeesWei": "0x12c",
+		"engineTxCount": "0x1"
+	}`, string(data))
+
+	res, err = eth.GetBlockByHash(hash1, false)
+	require.NoError(t, err)
+	assert.Equal(t, argUint64(1), res.(*block).XGR.EngineTxCount)
+
+	// blocks without economics omit the xgr object
+	res, err = eth.GetBlockByNumber(BlockNumber(2), false)
+	require.NoError(t, err)
+	assert.Nil(t, res.(*block).XGR)
+}
+
 func TestEth_Block_BlockNumber(t *testing.T) {
 	store := &mockBlockStore{}
 	store.add(&types.Block{
@@ -326,6 +366,78 @@ func TestEth_GasPrice_WithoutLondonFork(t *testing.T) {
 	})
 }
 
+func TestEth_SuggestedFees(t *testing.T) {
+	t.Parallel()
+
+	const (
+		baseFee = uint64(10000)
+		tip     = uint64(300)
+	)
+
+	newEth := func(minBaseFee uint64) *Eth {
+		store := newMockBlockStore()
+		store.minBaseFee = minBaseFee
+		store.maxPriorityFeePerGasFn = func() (*big.Int, error) {
+			return new(big.Int).SetUint64(tip), nil
+		}
+		store.blocks = []*types.Block{
+			{
+				Header: &types.Header{Number: uint64(1), BaseFee: baseFee},
+			},
+		}
+
+		return newTestEthEndpoint(store)
+	}
+
+	t.Run("gas price is the base fee plus the suggested tip", func(t *testing.T) {
+		t.Parallel()
+
+		eth := newEth(0)
+
+		res, err := eth.GasPrice()
+		require.NoError(t, err)
+		assert.Equal(t, argUint64(baseFee+tip), res)
+
+		res, err = eth.MaxPriorityFeePerGas()
+		require.NoError(t, err)
+		assert.Equal(t, argBigPtr(new(big.Int).SetUint64(tip)), res)
+	})
+
+	t.Run("suggestions respect the min base fee floor", func(t *testing.T) {
+		t.Parallel()
+
+		const minBaseFee = baseFee * 3
+
+		eth := newEth(minBaseFee)
+
+		res, err := eth.GasPrice()
+		require.NoError(t, err)
+		assert.Equal(t, argUint64(minBaseFee+tip), res)
+
+		tx := &types.Transaction{Type: types.DynamicFeeTx}
+		require.NoError(t, eth.fillTransactionGasPrice(tx))
+		assert.Equal(t, new(big.Int).SetUint64(tip), tx.GasTipCap)
+		assert.Equal(t, new(big.Int).SetUint64(2*minBaseFee+tip), tx.GasFeeCap)
+	})
+
+	t.Run("oracle errors are returned", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMockBlockStore()
+		store.maxPriorityFeePerGasFn = func() (*big.Int, error) {
+			return nil, errors.New("oracle failure")
+		}
+		store.blocks = []*types.Block{
+			{
+				Header: &types.Header{Number: uint64(1), BaseFee: baseFee},
+			},
+		}
+
+		_, err := newTestEthEndpoint(store).GasPrice()
+		require.ErrorContains(t, err, "oracle failure")
+	})
+}
+
 func TestEth_Call(t *testing.T) {
 	t.Parallel()
 
@@ -420,6 +532,8 @@ type mockBlockStore struct {
 	returnValue     []byte
 	forksInTime     chain.ForksInTime
 	baseFee         uint64
+	minBaseFee      uint64
+	economics       map[types.Hash]*types.BlockEconomics
 
 	maxPriorityFeePerGasFn func() (*big.Int, error)
 }
@@ -529,6 +643,12 @@ func (m *mockBlockStore) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, e
 	return receipts, nil
 }
 
+func (m *mockBlockStore) GetBlockEconomics(hash types.Hash) (*types.BlockEconomics, bool) {
+	econ, ok := m.economics[hash]
+
+	return econ, ok
+}
+
 func (m *mockBlockStore) GetBlockByNumber(blockNumber uint64, full bool) (*types.Block, bool) {
 	for _, b := range m.blocks {
 		if b.Number() == blockNumber {
@@ -631,6 +751,10 @@ func (m *mockBlockStore) MaxPriorityFeePerGas() (*big.Int, error) {
 	return big.NewInt(0), nil
 }
 
+func (m *mockBlockStore) MinBaseFee(_ *types.Header) uint64 {
+	return m.minBaseFee
+}
+
 func newTestBlock(number uint64, hash types.Hash) *types.Block {
 	return &types.Block{
 		Header: &types.Header{
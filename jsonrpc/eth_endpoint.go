@@ -36,8 +36,26 @@ type Account struct {
 	Nonce   uint64
 }
 
+// AccountProof is the merkle proof of an account and a set of its storage slots
+type AccountProof struct {
+	Balance      *big.Int
+	Nonce        uint64
+	CodeHash     types.Hash
+	StorageHash  types.Hash
+	AccountProof [][]byte
+	StorageProof []StorageProof
+}
+
+// StorageProof is the merkle proof of a single storage slot
+type StorageProof struct {
+	Key   types.Hash
+	Value []byte
+	Proof [][]byte
+}
+
 type ethStateStore interface {
 	GetAccount(root types.Hash, addr types.Address) (*Account, error)
+	GetProof(root types.Hash, addr types.Address, slots []types.Hash) (*AccountProof, error)
 	GetStorage(root types.Hash, addr types.Address, slot types.Hash) ([]byte, error)
 	GetForksInTime(blockNumber uint64) chain.ForksInTime
 	GetCode(root types.Hash, addr types.Address) ([]byte, error)
@@ -75,6 +93,12 @@ type ethBlockchainStore interface {
 
 	// GetSyncProgression retrieves the current sync progression, if any
 	GetSyncProgression() *progress.Progression
+
+	// GetBlockEconomics returns the fee split aggregates of a block
+	GetBlockEconomics(hash types.Hash) (*types.BlockEconomics, bool)
+
+	// MinBaseFee returns the EngineRegistry minBaseFee floor at the state of the header
+	MinBaseFee(header *types.Header) uint64
 }
 
 type ethFilter interface {
@@ -98,6 +122,9 @@ type Eth struct {
 	chainID       uint64
 	filterManager *FilterManager
 	priceLimit    uint64
+
+	// engine guards the ENGINE_EXECUTE submissions of the embedded engine, nil without the engine
+	engine *engineSubmissions
 }
 
 var (
@@ -142,7 +169,12 @@ func (e *Eth) GetBlockByNumber(number BlockNumber, fullTx bool) (interface{}, er
 		return nil, err
 	}
 
-	return toBlock(block, fullTx), nil
+	res := toBlock(block, fullTx)
+	if econ, ok := e.store.GetBlockEconomics(block.Hash()); ok {
+		res.XGR = toBlockEconomics(econ)
+	}
+
+	return res, nil
 }
 
 // GetBlockByHash returns information about a block by hash
@@ -156,7 +188,12 @@ func (e *Eth) GetBlockByHash(hash types.Hash, fullTx bool) (interface{}, error)
 		return nil, err
 	}
 
-	return toBlock(block, fullTx), nil
+	res := toBlock(block, fullTx)
+	if econ, ok := e.store.GetBlockEconomics(block.Hash()); ok {
+		res.XGR = toBlockEconomics(econ)
+	}
+
+	return res, nil
 }
 
 func (e *Eth) filterExtra(block *types.Block) error {
@@ -208,6 +245,12 @@ func (e *Eth) SendRawTransaction(buf argBytes) (interface{}, error) {
 		return nil, err
 	}
 
+	if e.engine != nil {
+		if err := e.engine.check(tx, e.store, e.chainID); err != nil {
+			return nil, err
+		}
+	}
+
 	// tx hash will be calculated inside e.store.AddTx
 	if err := e.store.AddTx(tx); err != nil {
 		return nil, err
@@ -368,6 +411,26 @@ func (e *Eth) GetStorageAt(
 	return argBytesPtr(result), nil
 }
 
+// GetProof returns the merkle proof of the account and the given storage slots (EIP-1186).
+// For a missing account the account proof is an exclusion proof against the state root.
+func (e *Eth) GetProof(
+	address types.Address,
+	storageKeys []types.Hash,
+	filter BlockNumberOrHash,
+) (interface{}, error) {
+	header, err := GetHeaderFromBlockNumberOrHash(filter, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := e.store.GetProof(header.StateRoot, address, storageKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	return toAccountProofResult(address, proof), nil
+}
+
 // GasPrice exposes "getGasPrice"'s function logic to public RPC interface
 func (e *Eth) GasPrice() (interface{}, error) {
 	gasPrice, err := e.getGasPrice()
@@ -415,12 +478,29 @@ func satMulU64(a, b uint64) uint64 {
 	return a * b
 }
 
+// suggestFees returns the base fee, the tip, the fee cap and the legacy gas price to suggest
+// at the current head. The tip is sampled by the gas price oracle from the recent blocks,
+// the base fee never falls below the EngineRegistry minBaseFee floor of the operator
 func (e *Eth) suggestFees() (uint64, uint64, uint64, uint64, error) {
 	header := e.store.Header()
+
 	baseFee := header.BaseFee
-	tip := uint64(0)
-	gasPrice := baseFee
-	feeCap := satMulU64(baseFee, 2)
+	if minBaseFee := e.store.MinBaseFee(header); baseFee < minBaseFee {
+		baseFee = minBaseFee
+	}
+
+	suggestedTip, err := e.store.MaxPriorityFeePerGas()
+	if err != nil {
+		return 0, 0, 0, 0, err
+	}
+
+	tip := ^uint64(0)
+	if suggestedTip.IsUint64() {
+		tip = suggestedTip.Uint64()
+	}
+
+	gasPrice := satAddU64(baseFee, tip)
+	feeCap := satAddU64(satMulU64(baseFee, 2), tip)
 
 	return baseFee, tip, feeCap, gasPrice, nil
 }
@@ -508,6 +588,20 @@ func (o *overrideAccount) ToType() types.OverrideAccount {
 // StateOverride is the collection of overridden accounts.
 type stateOverride map[types.Address]overrideAccount
 
+// toType converts the overrides of the request, it returns nil if none were passed
+func (s *stateOverride) toType() types.StateOverride {
+	if s == nil {
+		return nil
+	}
+
+	override := types.StateOverride{}
+	for addr, o := range *s {
+		override[addr] = o.ToType()
+	}
+
+	return override
+}
+
 // Call executes a smart contract call using the transaction object data
 func (e *Eth) Call(arg *txnArgs, filter BlockNumberOrHash, apiOverride *stateOverride) (interface{}, error) {
 	header, err := GetHeaderFromBlockNumberOrHash(filter, e.store)
@@ -530,16 +624,8 @@ func (e *Eth) Call(arg *txnArgs, filter BlockNumberOrHash, apiOverride *stateOve
 		return nil, err
 	}
 
-	var override types.StateOverride
-	if apiOverride != nil {
-		override = types.StateOverride{}
-		for addr, o := range *apiOverride {
-			override[addr] = o.ToType()
-		}
-	}
-
 	// The return value of the execution is saved in the transition (returnValue field)
-	result, err := e.store.ApplyTxn(header, transaction, override, true)
+	result, err := e.store.ApplyTxn(header, transaction, apiOverride.toType(), true)
 	if err != nil {
 		return nil, err
 	}
@@ -556,8 +642,9 @@ func (e *Eth) Call(arg *txnArgs, filter BlockNumberOrHash, apiOverride *stateOve
 	return argBytesPtr(result.ReturnValue), nil
 }
 
-// EstimateGas estimates the gas needed to execute a transaction
-func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error) {
+// EstimateGas estimates the gas needed to execute a transaction,
+// the state overrides are applied like in eth_call
+func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber, apiOverride *stateOverride) (interface{}, error) {
 	number := LatestBlockNumber
 	if rawNum != nil {
 		number = *rawNum
@@ -576,6 +663,7 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 	}
 
 	forksInTime := e.store.GetForksInTime(header.Number)
+	override := apiOverride.toType()
 
 	// IMPORTANT:
 	// A tx with empty data can still execute contract code (fallback/receive) if `to` is a contract.
@@ -591,6 +679,11 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 			return false, nil
 		}
 
+		// the overridden code replaces the code in state
+		if o, ok := override[*to]; ok && o.Code != nil {
+			return len(o.Code) > 0, nil
+		}
+
 		// Prefer querying code directly (robust across Account struct variants)
 		code, err := e.store.GetCode(header.StateRoot, *to)
 		if err != nil {
@@ -656,50 +749,9 @@ ESTIMATE_BY_EXECUTION:
 		highEnd = header.GasLimit
 	}
 
-	gasPriceInt := new(big.Int).Set(transaction.GasPrice)
-
-	var availableBalance *big.Int
-
-	// If the sender address is present, figure out how much available funds
-	// are we working with
-	if transaction.From != types.ZeroAddress {
-		// Get the account balance
-		// If the account is not initialized yet in state,
-		// assume it's an empty account
-		accountBalance := big.NewInt(0)
-		acc, err := e.store.GetAccount(header.StateRoot, transaction.From)
-
-		if err != nil && !errors.Is(err, ErrStateNotFound) {
-			// An unrelated error occurred, return it
-			return nil, err
-		} else if err == nil {
-			// No error when fetching the account,
-			// read the balance from state
-			accountBalance = acc.Balance
-		}
-
-		availableBalance = new(big.Int).Set(accountBalance)
-	}
-
-	// Recalculate the gas ceiling based on the available funds (if any)
-	// and the passed in gas price (if present)
-	if gasPriceInt.BitLen() != 0 && // Gas price has been set
-		availableBalance != nil && // Available balance is found
-		availableBalance.Cmp(big.NewInt(0)) > 0 { // Available balance > 0
-		gasAllowance := new(big.Int).Div(availableBalance, gasPriceInt)
-
-		// Check the gas allowance for this account, make sure high end is capped to it
-		if gasAllowance.IsUint64() && highEnd > gasAllowance.Uint64() {
-			e.logger.Debug(
-				fmt.Sprintf(
-					"Gas estimation high-end capped by allowance [%d]",
-					gasAllowance.Uint64(),
-				),
-			)
-
-			highEnd = gasAllowance.Uint64()
-		}
-	}
+	// The executions are non payable like eth_call, the sender doesn't pay for the gas,
+	// so the gas ceiling isn't capped by the balance of the sender either.
+	// This keeps the estimates of unfunded senders (funded by a balance override) working
 
 	// Checks if executor level valid gas errors occurred
 	isGasApplyError := func(err error) bool {
@@ -732,9 +784,14 @@ ESTIMATE_BY_EXECUTION:
 
 		transaction.Gas = gas
 
-		result, applyErr := e.store.ApplyTxn(header, transaction, nil, true)
+		result, applyErr := e.store.ApplyTxn(header, transaction, override, true)
 		if result != nil {
-			data = []byte(hex.EncodeToString(result.ReturnValue))
+			// only the revert payload is returned, out of gas and invalid opcodes have no data
+			data = []byte{}
+
+			if result.Reverted() {
+				data = []byte(hex.EncodeToString(result.ReturnValue))
+			}
 		}
 
 		if applyErr != nil {
@@ -771,6 +828,16 @@ ESTIMATE_BY_EXECUTION:
 		return false, nil, nil
 	}
 
+	// Skip the binary search if the lowest possible gas limit is enough already.
+	// Any failure only raises the lower bound, the binary search checks the errors
+	if lowEnd < highEnd {
+		if failed, _, _ := testTransaction(lowEnd, true); !failed {
+			return argUint64(lowEnd), nil
+		}
+
+		lowEnd++
+	}
+
 	// Start the binary search for the lowest possible gas price
 	for lowEnd < highEnd {
 		mid := lowEnd + ((highEnd - lowEnd) >> 1) // (lowEnd + highEnd) / 2 can overflow
@@ -793,6 +860,11 @@ ESTIMATE_BY_EXECUTION:
 
 	// Check if the highEnd is a good value to make the transaction pass
 	failed, retVal, err := testTransaction(highEnd, false)
+	if failed && isEVMRevertError(err) {
+		// The revert reason is returned as is, so clients can decode it
+		return retVal, err
+	}
+
 	if failed {
 		// The transaction shouldn't fail, for whatever reason, at highEnd
 		return retVal, fmt.Errorf(
@@ -11,6 +11,7 @@ import (
 	"github.com/xgr-network/xgr-node/state/runtime"
 	"github.com/xgr-network/xgr-node/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var (
@@ -575,6 +576,114 @@ func TestEth_State_GetStorageAt(t *testing.T) {
 	}
 }
 
+func TestEth_State_GetProof(t *testing.T) {
+	stateRoot := types.StringToHash("0x1234")
+	storageHash := types.StringToHash("0x5678")
+	slot := types.StringToHash("0x1")
+
+	store := &mockSpecialStore{
+		account: &mockAccount{
+			address: addr0,
+			account: &Account{
+				Balance: big.NewInt(100),
+				Nonce:   7,
+			},
+			storage: map[types.Hash][]byte{slot: {0x2a}},
+		},
+		block: &types.Block{
+			Header: &types.Header{
+				Hash:      types.ZeroHash,
+				Number:    0,
+				StateRoot: stateRoot,
+			},
+		},
+		proofHook: func(root types.Hash, addr types.Address, slots []types.Hash) (*AccountProof, error) {
+			assert.Equal(t, stateRoot, root)
+
+			if addr != addr0 {
+				return &AccountProof{
+					Balance:      big.NewInt(0),
+					CodeHash:     types.EmptyCodeHash,
+					StorageHash:  types.EmptyRootHash,
+					AccountProof: [][]byte{{0xc0}},
+					StorageProof: []StorageProof{},
+				}, nil
+			}
+
+			proof := &AccountProof{
+				Balance:      big.NewInt(100),
+				Nonce:        7,
+				CodeHash:     types.StringToHash("0xc0de"),
+				StorageHash:  storageHash,
+				AccountProof: [][]byte{{0x1}, {0x2}},
+			}
+
+			for _, s := range slots {
+				proof.StorageProof = append(proof.StorageProof, StorageProof{
+					Key:   s,
+					Value: []byte{0x2a},
+					Proof: [][]byte{{0x3}},
+				})
+			}
+
+			return proof, nil
+		},
+	}
+
+	eth := newTestEthEndpoint(store)
+	latest := LatestBlockNumber
+
+	t.Run("existing account", func(t *testing.T) {
+		res, err := eth.GetProof(addr0, []types.Hash{slot}, BlockNumberOrHash{BlockNumber: &latest})
+		assert.NoError(t, err)
+
+		proof, ok := res.(*accountProofResult)
+		assert.True(t, ok)
+		assert.Equal(t, addr0, proof.Address)
+		assert.Equal(t, []argBytes{{0x1}, {0x2}}, proof.AccountProof)
+		assert.Equal(t, argBig(*big.NewInt(100)), proof.Balance)
+		assert.Equal(t, argUint64(7), proof.Nonce)
+		assert.Equal(t, types.StringToHash("0xc0de"), proof.CodeHash)
+		assert.Equal(t, storageHash, proof.StorageHash)
+		assert.Equal(t, []storageProofResult{
+			{Key: slot, Value: argBig(*big.NewInt(42)), Proof: []argBytes{{0x3}}},
+		}, proof.StorageProof)
+	})
+
+	t.Run("non-existent account", func(t *testing.T) {
+		res, err := eth.GetProof(uninitializedAddress, []types.Hash{}, BlockNumberOrHash{BlockNumber: &latest})
+		assert.NoError(t, err)
+
+		proof, ok := res.(*accountProofResult)
+		assert.True(t, ok)
+		assert.Equal(t, []argBytes{{0xc0}}, proof.AccountProof)
+		assert.Equal(t, argBig(*big.NewInt(0)), proof.Balance)
+		assert.Equal(t, types.EmptyCodeHash, proof.CodeHash)
+		assert.Equal(t, types.EmptyRootHash, proof.StorageHash)
+		assert.Empty(t, proof.StorageProof)
+	})
+
+	t.Run("block does not exist", func(t *testing.T) {
+		blockNumber := BlockNumber(0x1)
+
+		_, err := eth.GetProof(addr0, nil, BlockNumberOrHash{BlockNumber: &blockNumber})
+		assert.Error(t, err)
+	})
+
+	t.Run("state error", func(t *testing.T) {
+		errState := errors.New("state unavailable")
+		hook := store.proofHook
+
+		store.proofHook = func(types.Hash, types.Address, []types.Hash) (*AccountProof, error) {
+			return nil, errState
+		}
+		defer func() { store.proofHook = hook }()
+
+		_, err := eth.GetProof(addr0, nil, BlockNumberOrHash{BlockNumber: &latest})
+		assert.ErrorIs(t, err, errState)
+	})
+}
+
 func constructMockTx(gasLimit *argUint64, data *argBytes) *txnArgs {
 	return &txnArgs{
 		From:     &addr0,
@@ -681,7 +790,7 @@ func TestEth_EstimateGas_GasLimit(t *testing.T) {
 			}
 
 			// Run the estimation
-			estimate, estimateErr := ethEndpoint.EstimateGas(testCase.transaction, nil)
+			estimate, estimateErr := ethEndpoint.EstimateGas(testCase.transaction, nil, nil)
 
 			if testCase.expectedError != nil {
 				if estimateErr == nil {
@@ -702,6 +811,47 @@ func TestEth_EstimateGas_GasLimit(t *testing.T) {
 			}
 		})
 	}
+
+	t.Run("failure at the lowest gas limit", func(t *testing.T) {
+		t.Parallel()
+
+		store := getExampleStore()
+		ethEndpoint := newTestEthEndpoint(store)
+
+		// only the lowest gas limit fails, the binary search finds the next one
+		store.applyTxnHook = func(
+			header *types.Header,
+			txn *types.Transaction,
+		) (*runtime.ExecutionResult, error) {
+			if txn.Gas <= state.TxGas {
+				return &runtime.ExecutionResult{Err: runtime.ErrInvalidInputData}, nil
+			}
+
+			return &runtime.ExecutionResult{}, nil
+		}
+
+		estimate, estimateErr := ethEndpoint.EstimateGas(constructMockTx(nil, nil), nil, nil)
+		assert.NoError(t, estimateErr)
+		assert.Equal(t, argUint64(state.TxGas+1), estimate)
+	})
+
+	t.Run("failure at every gas limit", func(t *testing.T) {
+		t.Parallel()
+
+		store := getExampleStore()
+		ethEndpoint := newTestEthEndpoint(store)
+
+		store.applyTxnHook = func(
+			header *types.Header,
+			txn *types.Transaction,
+		) (*runtime.ExecutionResult, error) {
+			return &runtime.ExecutionResult{Err: runtime.ErrInvalidInputData}, nil
+		}
+
+		estimate, estimateErr := ethEndpoint.EstimateGas(constructMockTx(nil, nil), nil, nil)
+		assert.ErrorIs(t, estimateErr, runtime.ErrInvalidInputData)
+		assert.Equal(t, []byte{}, estimate)
+	})
 }
 
 func TestEth_EstimateGas_Reverts(t *testing.T) {
@@ -757,6 +907,7 @@ func TestEth_EstimateGas_Reverts(t *testing.T) {
 		estimate, estimateErr := ethEndpoint.EstimateGas(
 			constructMockTx(nil, nil),
 			nil,
+			nil,
 		)
 
 		responseData, ok := estimate.([]byte)
@@ -791,6 +942,7 @@ func TestEth_EstimateGas_ValueTransfer(t *testing.T) {
 	estimate, err := ethEndpoint.EstimateGas(
 		mockTx,
 		nil,
+		nil,
 	)
 
 	assert.NotNil(t, estimate)
@@ -818,6 +970,7 @@ func TestEth_EstimateGas_ContractCreation(t *testing.T) {
 	estimate, err := ethEndpoint.EstimateGas(
 		mockTx,
 		nil,
+		nil,
 	)
 
 	assert.NotNil(t, estimate)
@@ -827,12 +980,129 @@ func TestEth_EstimateGas_ContractCreation(t *testing.T) {
 	assert.Equal(t, state.TxGasContractCreation, uint64(estimateUint64))
 }
 
+func TestEth_EstimateGas_StateOverride(t *testing.T) {
+	t.Parallel()
+
+	// reverts unless the caller holds at least 1 ether:
+	// PUSH8 1e18, CALLER, BALANCE, LT, PUSH1 16, JUMPI, STOP, JUMPDEST, PUSH1 0, DUP1, REVERT
+	fundedOnly := types.StringToAddress("0x2001")
+	code := []byte{
+		0x67, 0x0d, 0xe0, 0xb6, 0xb3, 0xa7, 0x64, 0x00, 0x00,
+		0x33, 0x31, 0x10, 0x60, 0x10, 0x57, 0x00,
+		0x5b, 0x60, 0x00, 0x80, 0xfd,
+	}
+
+	store := newEVMStore(t, map[types.Address][]byte{fundedOnly: code})
+	// a dust balance used to cap the gas ceiling to a single unit of gas
+	store.account.account.Balance = big.NewInt(1)
+
+	ethEndpoint := newTestEthEndpoint(store)
+
+	args := func() *txnArgs {
+		return &txnArgs{
+			From:     &addr0,
+			To:       &fundedOnly,
+			GasPrice: argBytesPtr([]byte{0x1}),
+			Nonce:    argUintPtr(0),
+		}
+	}
+
+	// the sender has no balance in state
+	_, err := ethEndpoint.EstimateGas(args(), nil, nil)
+	require.ErrorIs(t, err, runtime.ErrExecutionReverted)
+
+	balance := argUint64(1_000_000_000_000_000_000)
+
+	estimate, err := ethEndpoint.EstimateGas(args(), nil, &stateOverride{
+		addr0: overrideAccount{Balance: &balance},
+	})
+	require.NoError(t, err)
+	require.Greater(t, uint64(estimate.(argUint64)), state.TxGas) //nolint:forcetypeassert
+}
+
+func TestEth_EstimateGas_Threshold(t *testing.T) {
+	t.Parallel()
+
+	var (
+		// loops 100 times: PUSH1 100, JUMPDEST, PUSH1 1, SWAP1, SUB, DUP1, PUSH1 2, JUMPI, STOP
+		loop = types.StringToAddress("0x2002")
+		// STOP
+		noop = types.StringToAddress("0x2003")
+	)
+
+	store := newEVMStore(t, map[types.Address][]byte{
+		loop: {0x60, 0x64, 0x5b, 0x60, 0x01, 0x90, 0x03, 0x80, 0x60, 0x02, 0x57, 0x00},
+		noop: {0x00},
+	})
+
+	evmHook := store.applyTxnOverrideHook
+
+	var executions int
+
+	store.applyTxnOverrideHook = func(
+		header *types.Header, txn *types.Transaction, override types.StateOverride) (*runtime.ExecutionResult, error) {
+		executions++
+
+		return evmHook(header, txn, override)
+	}
+
+	ethEndpoint := newTestEthEndpoint(store)
+
+	args := func(to types.Address) *txnArgs {
+		return &txnArgs{
+			From:     &addr0,
+			To:       &to,
+			GasPrice: argBytesPtr([]byte{0x1}),
+			Nonce:    argUintPtr(0),
+		}
+	}
+
+	t.Run("succeeds only above a threshold", func(t *testing.T) {
+		estimate, err := ethEndpoint.EstimateGas(args(loop), nil, nil)
+		require.NoError(t, err)
+
+		gas := uint64(estimate.(argUint64)) //nolint:forcetypeassert
+		require.Greater(t, gas, state.TxGas)
+
+		apply := func(gas uint64) *runtime.ExecutionResult {
+			txn, err := DecodeTxn(args(loop), 0, store, true)
+			require.NoError(t, err)
+
+			txn.Gas = gas
+
+			result, err := store.ApplyTxn(store.block.Header, txn, nil, true)
+			require.NoError(t, err)
+
+			return result
+		}
+
+		// the estimate is the lowest gas limit the call succeeds with
+		require.NoError(t, apply(gas).Err)
+		require.ErrorIs(t, apply(gas-1).Err, runtime.ErrOutOfGas)
+	})
+
+	t.Run("succeeds with the lowest gas limit", func(t *testing.T) {
+		executions = 0
+
+		estimate, err := ethEndpoint.EstimateGas(args(noop), nil, nil)
+		require.NoError(t, err)
+		require.Equal(t, argUint64(state.TxGas), estimate)
+
+		// no binary search, only the lowest gas limit is executed
+		require.Equal(t, 1, executions)
+	})
+}
+
 type mockSpecialStore struct {
 	ethStore
 	account *mockAccount
 	block   *types.Block
 
 	applyTxnHook func(header *types.Header, txn *types.Transaction) (*runtime.ExecutionResult, error)
+	// applyTxnOverrideHook takes precedence over applyTxnHook and receives the state overrides
+	applyTxnOverrideHook func(
+		header *types.Header, txn *types.Transaction, override types.StateOverride) (*runtime.ExecutionResult, error)
+	proofHook    func(root types.Hash, addr types.Address, slots []types.Hash) (*AccountProof, error)
 }
 
 func (m *mockSpecialStore) GetBlockByHash(hash types.Hash, full bool) (*types.Block, bool) {
@@ -890,6 +1160,10 @@ func (m *mockSpecialStore) GetStorage(root types.Hash, addr types.Address, slot
 	return val, nil
 }
 
+func (m *mockSpecialStore) GetProof(root types.Hash, addr types.Address, slots []types.Hash) (*AccountProof, error) {
+	return m.proofHook(root, addr, slots)
+}
+
 func (m *mockSpecialStore) GetCode(root types.Hash, addr types.Address) ([]byte, error) {
 	if m.account.address != addr {
 		return nil, ErrStateNotFound
@@ -902,7 +1176,19 @@ func (m *mockSpecialStore) GetForksInTime(blockNumber uint64) chain.ForksInTime
 	return chain.AllForksEnabled.At(0)
 }
 
-func (m *mockSpecialStore) ApplyTxn(header *types.Header, txn *types.Transaction, _ types.StateOverride, _ bool) (*runtime.ExecutionResult, error) {
+func (m *mockSpecialStore) MaxPriorityFeePerGas() (*big.Int, error) {
+	return big.NewInt(0), nil
+}
+
+func (m *mockSpecialStore) MinBaseFee(_ *types.Header) uint64 {
+	return 0
+}
+
+func (m *mockSpecialStore) ApplyTxn(header *types.Header, txn *types.Transaction, override types.StateOverride, _ bool) (*runtime.ExecutionResult, error) {
+	if m.applyTxnOverrideHook != nil {
+		return m.applyTxnOverrideHook(header, txn, override)
+	}
+
 	if m.applyTxnHook != nil {
 		return m.applyTxnHook(header, txn)
 	}
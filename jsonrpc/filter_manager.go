@@ -14,6 +14,7 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/hashicorp/go-hclog"
 	"github.com/xgr-network/xgr-node/blockchain"
+	"github.com/xgr-network/xgr-node/txpool"
 	"github.com/xgr-network/xgr-node/txpool/proto"
 	"github.com/xgr-network/xgr-node/types"
 )
@@ -312,6 +313,13 @@ type filterManagerStore interface {
 	TxPoolSubscribe(request *proto.SubscribeRequest) (<-chan *proto.TxPoolEvent, func(), error)
 }
 
+// logIndexStore is implemented by stores keeping an index of the blocks with logs of an address or a first topic
+type logIndexStore interface {
+	// FilterLogBlocks returns the numbers of the blocks in the range which may contain a log
+	// of one of the addresses with one of the first topics, or false if it can't narrow the range
+	FilterLogBlocks(from, to uint64, addresses []types.Address, topics []types.Hash) ([]uint64, bool)
+}
+
 // FilterManager manages all running filters
 type FilterManager struct {
 	sync.RWMutex
@@ -385,6 +393,17 @@ func (f *FilterManager) Run() {
 
 	defer txPoolUnsubscribe()
 
+	// watch for the txs added to the tx pool, if the store delivers them
+	var pendingTxCh <-chan *txpool.PendingTx
+
+	if feed, ok := f.store.(pendingTxFeedStore); ok {
+		var pendingTxUnsubscribe func()
+
+		pendingTxCh, pendingTxUnsubscribe = feed.SubscribePendingTxs()
+
+		defer pendingTxUnsubscribe()
+	}
+
 	var timeoutCh <-chan time.Time
 
 	for {
@@ -409,6 +428,16 @@ func (f *FilterManager) Run() {
 				f.logger.Error("failed to dispatch tx pool event", "err", err)
 			}
 
+		case pending, ok := <-pendingTxCh:
+			if !ok {
+				// the tx pool is closed
+				pendingTxCh = nil
+
+				continue
+			}
+
+			f.processPendingTx(pending)
+
 		case <-timeoutCh:
 			// timeout for filter
 			// if filter still exists
@@ -459,6 +488,20 @@ func (f *FilterManager) NewLogFilter(logQuery *LogQuery, ws wsConn) string {
 	return f.addFilter(filter)
 }
 
+// NewSessionFilter adds new sessionFilter
+func (f *FilterManager) NewSessionFilter(query *SessionQuery, ws wsConn) string {
+	filter := &sessionFilter{
+		filterBase: newFilterBase(ws),
+		query:      query,
+	}
+
+	if filter.hasWSConn() {
+		ws.SetFilterID(filter.id)
+	}
+
+	return f.addFilter(filter)
+}
+
 // NewPendingTxFilter adds new PendingTxFilter
 func (f *FilterManager) NewPendingTxFilter(ws wsConn) string {
 	filter := &pendingTxFilter{
@@ -473,6 +516,53 @@ func (f *FilterManager) NewPendingTxFilter(ws wsConn) string {
 	return f.addFilter(filter)
 }
 
+// NewPendingTxStreamFilter adds a filter streaming the txs added to the tx pool to the web socket.
+// If the store doesn't deliver the txs, only the hashes of all txs can be subscribed to.
+func (f *FilterManager) NewPendingTxStreamFilter(query *PendingTxQuery, ws wsConn) (string, error) {
+	if _, ok := f.store.(pendingTxFeedStore); !ok {
+		if !query.isDefault() {
+			return "", ErrPendingTxFeedUnavailable
+		}
+
+		return f.NewPendingTxFilter(ws), nil
+	}
+
+	filter := &pendingTxStreamFilter{
+		filterBase: newFilterBase(ws),
+		query:      query,
+		msgCh:      make(chan string, pendingTxStreamBuffer),
+		closeCh:    make(chan struct{}),
+	}
+
+	ws.SetFilterID(filter.id)
+
+	go f.writePendingTxStream(filter)
+
+	return f.addFilter(filter), nil
+}
+
+// writePendingTxStream writes the queued messages of the filter to its web socket
+// until the filter is removed
+func (f *FilterManager) writePendingTxStream(filter *pendingTxStreamFilter) {
+	for {
+		select {
+		case msg := <-filter.msgCh:
+			if err := filter.writeMessageToWs(msg); err != nil {
+				if errors.Is(err, websocket.ErrCloseSent) || errors.Is(err, net.ErrClosed) {
+					f.logger.Warn(fmt.Sprintf("Subscription %s has been closed", filter.id))
+					f.Uninstall(filter.id)
+
+					return
+				}
+
+				f.logger.Error(fmt.Sprintf("Unable to write pending tx, %v", err))
+			}
+		case <-filter.closeCh:
+			return
+		}
+	}
+}
+
 // Exists checks the filter with given ID exists
 func (f *FilterManager) Exists(id string) bool {
 	f.RLock()
@@ -531,30 +621,76 @@ func (f *FilterManager) getLogsFromBlocks(query *LogQuery) ([]*Log, error) {
 		return nil, ErrBlockRangeTooHigh
 	}
 
+	if idx, ok := f.store.(logIndexStore); ok {
+		var topics []types.Hash
+		if len(query.Topics) > 0 {
+			topics = query.Topics[0]
+		}
+
+		// skip the blocks which cannot contain a matching log
+		if numbers, ok := idx.FilterLogBlocks(from, to, query.Addresses, topics); ok {
+			return f.getLogsFromBlockNumbers(query, numbers)
+		}
+	}
+
 	logs := make([]*Log, 0)
 
 	for i := from; i <= to; i++ {
-		block, ok := f.store.GetBlockByNumber(i, true)
-		if !ok {
-			break
+		blockLogs, found, err := f.getLogsFromBlockNumber(query, i)
+		if err != nil {
+			return nil, err
 		}
 
-		if len(block.Transactions) == 0 {
-			// do not check logs if no txs
-			continue
+		if !found {
+			break
 		}
 
-		blockLogs, err := f.getLogsFromBlock(query, block)
+		logs = append(logs, blockLogs...)
+	}
+
+	return logs, nil
+}
+
+// getLogsFromBlockNumbers returns the logs of the blocks with the ascending numbers
+func (f *FilterManager) getLogsFromBlockNumbers(query *LogQuery, numbers []uint64) ([]*Log, error) {
+	logs := make([]*Log, 0)
+
+	for _, i := range numbers {
+		blockLogs, found, err := f.getLogsFromBlockNumber(query, i)
 		if err != nil {
 			return nil, err
 		}
 
+		if !found {
+			break
+		}
+
 		logs = append(logs, blockLogs...)
 	}
 
 	return logs, nil
 }
 
+// getLogsFromBlockNumber returns the logs of the block with the number, or false if it is not found
+func (f *FilterManager) getLogsFromBlockNumber(query *LogQuery, number uint64) ([]*Log, bool, error) {
+	block, ok := f.store.GetBlockByNumber(number, true)
+	if !ok {
+		return nil, false, nil
+	}
+
+	if len(block.Transactions) == 0 {
+		// do not check logs if no txs
+		return nil, true, nil
+	}
+
+	logs, err := f.getLogsFromBlock(query, block)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return logs, true, nil
+}
+
 // GetLogsForQuery return array of logs for given query
 func (f *FilterManager) GetLogsForQuery(query *LogQuery) ([]*Log, error) {
 	if query.BlockHash != nil {
@@ -656,6 +792,10 @@ func (f *FilterManager) removeFilterByID(id string) bool {
 
 	delete(f.filters, id)
 
+	if streamFilter, ok := filter.(*pendingTxStreamFilter); ok {
+		streamFilter.close()
+	}
+
 	if removed := f.timeouts.removeFilter(filter.getFilterBase()); removed {
 		f.emitSignalToUpdateCh()
 	}
@@ -757,6 +897,13 @@ func (f *FilterManager) processBlockEvent(evnt *blockchain.Event) {
 	f.RLock()
 	defer f.RUnlock()
 
+	// the session steps of the blocks dropped by a reorg are reported as removed
+	for _, header := range evnt.OldChain {
+		if processErr := f.appendStepsToSessionFilters(header, true); processErr != nil {
+			f.logger.Error(fmt.Sprintf("Unable to process reorged block, %v", processErr))
+		}
+	}
+
 	for _, header := range evnt.NewChain {
 		block := toBlock(&types.Block{Header: header}, false)
 
@@ -767,6 +914,10 @@ func (f *FilterManager) processBlockEvent(evnt *blockchain.Event) {
 		if processErr := f.appendLogsToFilters(block); processErr != nil {
 			f.logger.Error(fmt.Sprintf("Unable to process block, %v", processErr))
 		}
+
+		if processErr := f.appendStepsToSessionFilters(header, false); processErr != nil {
+			f.logger.Error(fmt.Sprintf("Unable to process block, %v", processErr))
+		}
 	}
 }
 
@@ -819,6 +970,55 @@ func (f *FilterManager) appendLogsToFilters(header *block) error {
 	return nil
 }
 
+// appendStepsToSessionFilters makes each sessionFilter append the steps of its session in the block
+func (f *FilterManager) appendStepsToSessionFilters(header *types.Header, removed bool) error {
+	sessionFilters := make([]*sessionFilter, 0)
+
+	for _, f := range f.filters {
+		if sessionFilter, ok := f.(*sessionFilter); ok {
+			sessionFilters = append(sessionFilters, sessionFilter)
+		}
+	}
+
+	if len(sessionFilters) == 0 {
+		return nil
+	}
+
+	receipts, err := f.store.GetReceiptsByHash(header.Hash)
+	if err != nil {
+		return err
+	}
+
+	block, ok := f.store.GetBlockByHash(header.Hash, true)
+	if !ok {
+		f.logger.Error("could not find block in store", "hash", header.Hash.String())
+
+		return nil
+	}
+
+	for indx, receipt := range receipts {
+		txHash := receipt.TxHash
+		if txHash == types.ZeroHash && indx < len(block.Transactions) {
+			txHash = block.Transactions[indx].Hash
+		}
+
+		for _, log := range receipt.Logs {
+			meta, ok := decodeEngineMeta(log)
+			if !ok {
+				continue
+			}
+
+			for _, f := range sessionFilters {
+				if f.query.match(meta) {
+					f.appendStep(toSessionStep(meta, header, txHash, removed))
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
 // processTxEvent makes each filter refresh the pending tx hashes
 func (f *FilterManager) processTxEvent(evnt *proto.TxPoolEvent) {
 	f.RLock()
@@ -831,6 +1031,45 @@ func (f *FilterManager) processTxEvent(evnt *proto.TxPoolEvent) {
 	}
 }
 
+// processPendingTx queues the tx for the pending tx subscriptions matching its origin,
+// the subscriptions with a full buffer are removed
+func (f *FilterManager) processPendingTx(pending *txpool.PendingTx) {
+	msgs := &pendingTxMessages{tx: pending.Tx}
+	slowFilterIDs := make([]string, 0)
+
+	f.RLock()
+
+	for id, filter := range f.filters {
+		streamFilter, ok := filter.(*pendingTxStreamFilter)
+		if !ok || !streamFilter.query.match(pending.Local) {
+			continue
+		}
+
+		msg, err := msgs.get(streamFilter.query.FullTransactions)
+		if err != nil {
+			f.logger.Error(fmt.Sprintf("Unable to process pending tx, %v", err))
+
+			continue
+		}
+
+		if !streamFilter.push(msg) {
+			slowFilterIDs = append(slowFilterIDs, id)
+		}
+	}
+
+	f.RUnlock()
+
+	if len(slowFilterIDs) > 0 {
+		f.Lock()
+		for _, id := range slowFilterIDs {
+			f.removeFilterByID(id)
+		}
+		f.Unlock()
+
+		f.logger.Warn(fmt.Sprintf("Removed %d pending tx subscriptions not keeping up", len(slowFilterIDs)))
+	}
+}
+
 // flushWsFilters make each filters with web socket connection write the updates to web socket stream
 // flushWsFilters also removes the filters if flushWsFilters notices the connection is closed
 func (f *FilterManager) flushWsFilters(subType subscriptionType) error {
@@ -154,6 +154,86 @@ func Test_GetLogsForQuery(t *testing.T) {
 	}
 }
 
+// mockLogIndexStore is a mockBlockStore keeping a log index, which returns the candidates
+type mockLogIndexStore struct {
+	*mockBlockStore
+
+	candidates []uint64
+	indexed    bool
+
+	from, to uint64
+	topics   []types.Hash
+}
+
+func (m *mockLogIndexStore) FilterLogBlocks(
+	from, to uint64,
+	addresses []types.Address,
+	topics []types.Hash,
+) ([]uint64, bool) {
+	m.from, m.to, m.topics = from, to, topics
+
+	return m.candidates, m.indexed
+}
+
+func Test_GetLogsForQuery_LogIndex(t *testing.T) {
+	t.Parallel()
+
+	topics := []types.Hash{types.StringToHash("4"), types.StringToHash("5"), types.StringToHash("6")}
+
+	newStore := func(candidates []uint64, indexed bool) *mockLogIndexStore {
+		store := &mockBlockStore{topics: topics}
+		store.setupLogs()
+
+		for i := 0; i < 5; i++ {
+			store.appendBlocksToStore([]*types.Block{{
+				Header: &types.Header{
+					Number: uint64(i),
+					Hash:   types.StringToHash(strconv.Itoa(i)),
+				},
+				Transactions: []*types.Transaction{
+					{Value: big.NewInt(10)},
+					{Value: big.NewInt(11)},
+					{Value: big.NewInt(12)},
+				},
+			}})
+		}
+
+		return &mockLogIndexStore{mockBlockStore: store, candidates: candidates, indexed: indexed}
+	}
+
+	query := &LogQuery{
+		fromBlock: 0,
+		toBlock:   3,
+		Topics:    [][]types.Hash{topics[:1], topics[1:2]},
+	}
+
+	// only the candidate blocks are scanned
+	store := newStore([]uint64{1, 3}, true)
+
+	f := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	defer f.Close()
+
+	logs, err := f.GetLogsForQuery(query)
+	require.NoError(t, err)
+	require.Len(t, logs, 2)
+	assert.Equal(t, argUint64(1), logs[0].BlockNumber)
+	assert.Equal(t, argUint64(3), logs[1].BlockNumber)
+
+	assert.Equal(t, uint64(1), store.from)
+	assert.Equal(t, uint64(3), store.to)
+	assert.Equal(t, topics[:1], store.topics)
+
+	// the range is scanned if the index can't narrow it
+	store = newStore(nil, false)
+
+	f = NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	defer f.Close()
+
+	logs, err = f.GetLogsForQuery(query)
+	require.NoError(t, err)
+	require.Len(t, logs, 3)
+}
+
 func Test_getLogsFromBlock(t *testing.T) {
 	t.Parallel()
 
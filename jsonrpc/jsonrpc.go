@@ -57,6 +57,8 @@ type JSONRPCStore interface {
 	filterManagerStore
 	bridgeStore
 	debugStore
+	setHeadStore
+	xgrStateStore
 }
 
 type Config struct {
@@ -71,6 +73,11 @@ type Config struct {
 
 	ConcurrentRequestsDebug uint64
 	WebSocketReadLimit      uint64
+	EnableSetHead           bool
+	DisableEngine           bool
+
+	// DonationPercent is the donation percent of the chain while the registry is missing
+	DonationPercent uint64
 }
 
 // NewJSONRPC returns the JSONRPC http server
@@ -85,6 +92,9 @@ func NewJSONRPC(logger hclog.Logger, config *Config) (*JSONRPC, error) {
 			jsonRPCBatchLengthLimit: config.BatchLengthLimit,
 			blockRangeLimit:         config.BlockRangeLimit,
 			concurrentRequestsDebug: config.ConcurrentRequestsDebug,
+			enableSetHead:           config.EnableSetHead,
+			disableEngine:           config.DisableEngine,
+			donationPercent:         config.DonationPercent,
 		},
 	)
 
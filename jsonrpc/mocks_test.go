@@ -145,6 +145,10 @@ func (m *mockStore) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error)
 	return receipts, nil
 }
 
+func (m *mockStore) GetBlockEconomics(hash types.Hash) (*types.BlockEconomics, bool) {
+	return nil, false
+}
+
 func (m *mockStore) SubscribeEvents() blockchain.Subscription {
 	return m.subscription
 }
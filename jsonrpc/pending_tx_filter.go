@@ -0,0 +1,147 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"github.com/xgr-network/xgr-node/txpool"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+// pendingTxStreamBuffer is the number of messages buffered for a newPendingTransactions
+// subscription, a subscriber falling further behind is dropped
+const pendingTxStreamBuffer = 256
+
+var ErrPendingTxFeedUnavailable = errors.New("pending transaction options are not supported by the node")
+
+// pendingTxFeedStore is implemented by stores delivering the txs added to the tx pool
+type pendingTxFeedStore interface {
+	// SubscribePendingTxs subscribes to the txs added to the tx pool
+	SubscribePendingTxs() (<-chan *txpool.PendingTx, func())
+}
+
+// PendingTxQuery holds the options of a newPendingTransactions subscription
+type PendingTxQuery struct {
+	// FullTransactions delivers the tx objects instead of the tx hashes
+	FullTransactions bool `json:"fullTransactions"`
+
+	// IncludeLocal delivers the txs submitted to the node
+	IncludeLocal bool `json:"includeLocal"`
+
+	// IncludeRemote delivers the txs received from the peers
+	IncludeRemote bool `json:"includeRemote"`
+}
+
+// newPendingTxQuery returns the default options, the hashes of all txs are delivered
+func newPendingTxQuery() *PendingTxQuery {
+	return &PendingTxQuery{
+		IncludeLocal:  true,
+		IncludeRemote: true,
+	}
+}
+
+// isDefault returns true if the query selects the hashes of all txs
+func (q *PendingTxQuery) isDefault() bool {
+	return *q == *newPendingTxQuery()
+}
+
+// match returns true if the tx of the origin is delivered
+func (q *PendingTxQuery) match(local bool) bool {
+	if local {
+		return q.IncludeLocal
+	}
+
+	return q.IncludeRemote
+}
+
+func decodePendingTxQueryFromInterface(i interface{}) (*PendingTxQuery, error) {
+	// once the query is decoded as map[string]interface we cannot use unmarshal json
+	raw, err := json.Marshal(i)
+	if err != nil {
+		return nil, err
+	}
+
+	// the omitted options keep their defaults
+	query := newPendingTxQuery()
+	if err := json.Unmarshal(raw, query); err != nil {
+		return nil, err
+	}
+
+	return query, nil
+}
+
+// pendingTxStreamFilter streams the txs added to the tx pool to a web socket subscription.
+// The messages are written by a goroutine of the filter, so a slow connection doesn't hold
+// up the filter manager. The filter is removed once its buffer is full.
+type pendingTxStreamFilter struct {
+	filterBase
+
+	query *PendingTxQuery
+
+	msgCh     chan string
+	closeCh   chan struct{}
+	closeOnce sync.Once
+}
+
+// getSubscriptionType returns the type of the event the filter is subscribed to
+func (f *pendingTxStreamFilter) getSubscriptionType() subscriptionType {
+	return PendingTransactions
+}
+
+// getUpdates is not supported, the filter always has a web socket connection
+func (f *pendingTxStreamFilter) getUpdates() (interface{}, error) {
+	return nil, ErrWSFilterDoesNotSupportGetChanges
+}
+
+// sendUpdates does nothing, the messages are written as they are queued
+func (f *pendingTxStreamFilter) sendUpdates() error {
+	return nil
+}
+
+// push queues the message, it returns false if the buffer is full
+func (f *pendingTxStreamFilter) push(msg string) bool {
+	select {
+	case f.msgCh <- msg:
+		return true
+	default:
+		return false
+	}
+}
+
+// close stops the goroutine writing the messages
+func (f *pendingTxStreamFilter) close() {
+	f.closeOnce.Do(func() {
+		close(f.closeCh)
+	})
+}
+
+// pendingTxMessages renders the messages of a pending tx once for all subscriptions
+type pendingTxMessages struct {
+	tx *types.Transaction
+
+	hash string
+	full string
+}
+
+// get returns the message with the tx hash or the tx object
+func (m *pendingTxMessages) get(full bool) (string, error) {
+	if !full {
+		if m.hash == "" {
+			m.hash = `"` + m.tx.Hash.String() + `"`
+		}
+
+		return m.hash, nil
+	}
+
+	if m.full == "" {
+		raw, err := json.Marshal(toPendingTransaction(m.tx))
+		if err != nil {
+			return "", err
+		}
+
+		m.full = string(raw)
+	}
+
+	return m.full, nil
+}
@@ -0,0 +1,247 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xgr-network/xgr-node/txpool"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+// mockPendingTxStore is a mockStore delivering the txs added to the tx pool
+type mockPendingTxStore struct {
+	*mockStore
+
+	pendingTxCh chan *txpool.PendingTx
+}
+
+func newMockPendingTxStore() *mockPendingTxStore {
+	return &mockPendingTxStore{
+		mockStore:   newMockStore(),
+		pendingTxCh: make(chan *txpool.PendingTx, 1024),
+	}
+}
+
+func (m *mockPendingTxStore) SubscribePendingTxs() (<-chan *txpool.PendingTx, func()) {
+	return m.pendingTxCh, func() {}
+}
+
+func (m *mockPendingTxStore) emitPendingTx(nonce uint64, local bool) *types.Transaction {
+	tx := &types.Transaction{
+		Nonce:    nonce,
+		GasPrice: big.NewInt(1),
+		Value:    big.NewInt(0),
+		V:        big.NewInt(0),
+		R:        big.NewInt(0),
+		S:        big.NewInt(0),
+	}
+	tx.ComputeHash(1)
+
+	m.pendingTxCh <- &txpool.PendingTx{Tx: tx, Local: local}
+
+	return tx
+}
+
+func TestDecodePendingTxQuery(t *testing.T) {
+	t.Parallel()
+
+	query, err := decodePendingTxQueryFromInterface(map[string]interface{}{"fullTransactions": true})
+	require.NoError(t, err)
+	assert.Equal(t, &PendingTxQuery{FullTransactions: true, IncludeLocal: true, IncludeRemote: true}, query)
+	assert.False(t, query.isDefault())
+
+	query, err = decodePendingTxQueryFromInterface(map[string]interface{}{"includeRemote": false})
+	require.NoError(t, err)
+	assert.True(t, query.match(true))
+	assert.False(t, query.match(false))
+
+	query, err = decodePendingTxQueryFromInterface(map[string]interface{}{})
+	require.NoError(t, err)
+	assert.True(t, query.isDefault())
+
+	_, err = decodePendingTxQueryFromInterface(map[string]interface{}{"fullTransactions": "yes"})
+	assert.Error(t, err)
+}
+
+func TestFilterPendingTxStream(t *testing.T) {
+	t.Parallel()
+
+	store := newMockPendingTxStore()
+
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	defer m.Close()
+
+	go m.Run()
+
+	hashConn, hashCh := newMockWsConnWithMsgCh()
+	fullConn, fullCh := newMockWsConnWithMsgCh()
+
+	_, err := m.NewPendingTxStreamFilter(newPendingTxQuery(), hashConn)
+	require.NoError(t, err)
+
+	id, err := m.NewPendingTxStreamFilter(
+		&PendingTxQuery{FullTransactions: true, IncludeRemote: true},
+		fullConn,
+	)
+	require.NoError(t, err)
+
+	// we cannot call get filter changes for a websocket filter
+	_, err = m.GetFilterChanges(id)
+	assert.Equal(t, err, ErrWSFilterDoesNotSupportGetChanges)
+
+	// the local tx is not delivered to the full tx subscription
+	localTx := store.emitPendingTx(0, true)
+	remoteTx := store.emitPendingTx(1, false)
+
+	receive := func(msgCh <-chan []byte) json.RawMessage {
+		t.Helper()
+
+		select {
+		case msg := <-msgCh:
+			var notification struct {
+				Params struct {
+					Result json.RawMessage `json:"result"`
+				} `json:"params"`
+			}
+
+			require.NoError(t, json.Unmarshal(msg, &notification))
+
+			return notification.Params.Result
+		case <-time.After(2 * time.Second):
+			t.Fatal("no pending tx received in the predefined time slot")
+		}
+
+		return nil
+	}
+
+	for _, tx := range []*types.Transaction{localTx, remoteTx} {
+		var hash types.Hash
+
+		require.NoError(t, json.Unmarshal(receive(hashCh), &hash))
+		assert.Equal(t, tx.Hash, hash)
+	}
+
+	var tx transaction
+
+	require.NoError(t, json.Unmarshal(receive(fullCh), &tx))
+	assert.Equal(t, remoteTx.Hash, tx.Hash)
+	assert.Equal(t, argUint64(remoteTx.Nonce), tx.Nonce)
+	assert.Nil(t, tx.BlockHash)
+
+	select {
+	case <-fullCh:
+		t.Fatal("local tx delivered to a remote only subscription")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestFilterPendingTxStream_SlowSubscriber(t *testing.T) {
+	t.Parallel()
+
+	store := newMockPendingTxStore()
+
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	defer m.Close()
+
+	go m.Run()
+
+	// the connection doesn't write any message until it is released
+	releaseCh := make(chan struct{})
+	defer close(releaseCh)
+
+	slowConn, _ := newMockWsConnWithMsgCh()
+	slowConn.WriteMessageFn = func(int, []byte) error {
+		<-releaseCh
+
+		return nil
+	}
+
+	fastConn, fastCh := newMockWsConnWithMsgCh()
+
+	slowID, err := m.NewPendingTxStreamFilter(newPendingTxQuery(), slowConn)
+	require.NoError(t, err)
+
+	fastID, err := m.NewPendingTxStreamFilter(newPendingTxQuery(), fastConn)
+	require.NoError(t, err)
+
+	// one message is being written, the others fill the buffer until it overflows
+	for nonce := uint64(0); nonce < pendingTxStreamBuffer+2; nonce++ {
+		store.emitPendingTx(nonce, false)
+
+		select {
+		case <-fastCh:
+		case <-time.After(2 * time.Second):
+			t.Fatal("the slow subscriber holds up the other subscriptions")
+		}
+	}
+
+	require.Eventually(t, func() bool {
+		return !m.Exists(slowID)
+	}, 2*time.Second, 10*time.Millisecond)
+
+	assert.True(t, m.Exists(fastID))
+}
+
+func TestDispatcher_PendingTxSubscription(t *testing.T) {
+	t.Parallel()
+
+	newDispatcher := func(store JSONRPCStore) *Dispatcher {
+		return newTestDispatcher(t,
+			hclog.NewNullLogger(),
+			store,
+			&dispatcherParams{
+				jsonRPCBatchLengthLimit: 20,
+				blockRangeLimit:         1000,
+			},
+		)
+	}
+
+	subscribe := func(dispatcher *Dispatcher, params string) (<-chan []byte, error) {
+		t.Helper()
+
+		conn, msgCh := newMockWsConnWithMsgCh()
+
+		resp, err := dispatcher.HandleWs(
+			[]byte(`{"method": "eth_subscribe", "params": `+params+`}`),
+			conn,
+		)
+		require.NoError(t, err)
+
+		var id string
+
+		return msgCh, expectJSONResult(resp, &id)
+	}
+
+	store := newMockPendingTxStore()
+	dispatcher := newDispatcher(store)
+
+	msgCh, err := subscribe(dispatcher, `["newPendingTransactions", {"fullTransactions": true, "includeRemote": false}]`)
+	require.NoError(t, err)
+
+	tx := store.emitPendingTx(0, true)
+
+	select {
+	case msg := <-msgCh:
+		assert.Contains(t, string(msg), `"nonce":"0x0"`)
+		assert.Contains(t, string(msg), tx.Hash.String())
+	case <-time.After(2 * time.Second):
+		t.Fatal("\"newPendingTransactions\" event not received in 2 seconds")
+	}
+
+	_, err = subscribe(dispatcher, `["newPendingTransactions", {"fullTransactions": 1}]`)
+	assert.Error(t, err)
+
+	// the options need a store delivering the txs
+	dispatcher = newDispatcher(newMockStore())
+
+	_, err = subscribe(dispatcher, `["newPendingTransactions", {"fullTransactions": true}]`)
+	assert.ErrorContains(t, err, ErrPendingTxFeedUnavailable.Error())
+
+	_, err = subscribe(dispatcher, `["newPendingTransactions", {}]`)
+	assert.NoError(t, err)
+}
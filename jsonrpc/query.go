@@ -126,7 +126,7 @@ func (q *LogQuery) UnmarshalJSON(data []byte) error {
 	}
 
 	if obj.Topics != nil {
-		// decode topics, either "" or ["", ""] or null
+		// decode topics, either "" or ["", ""] or null, an empty list matches any topic
 		for _, item := range obj.Topics {
 			switch raw := item.(type) {
 			case string:
@@ -136,10 +136,16 @@ func (q *LogQuery) UnmarshalJSON(data []byte) error {
 				}
 
 			case []interface{}:
-				// ["", ""]
+				// ["", ""], a null in the list matches any topic as [null] does
 				res := []string{}
 
 				for _, i := range raw {
+					if i == nil {
+						res = nil
+
+						break
+					}
+
 					if item, ok := i.(string); ok {
 						res = append(res, item)
 					} else {
@@ -176,6 +182,8 @@ func (q *LogQuery) Match(log *types.Log) bool {
 		for _, addr := range q.Addresses {
 			if addr == log.Address {
 				match = true
+
+				break
 			}
 		}
 
@@ -1,6 +1,7 @@
 package jsonrpc
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/xgr-network/xgr-node/types"
@@ -231,3 +232,143 @@ func TestFilterMatch(t *testing.T) {
 		assert.Equal(t, c.match, c.filter.Match(c.log))
 	}
 }
+
+func TestFilterDecodeAndMatch_SpecShapes(t *testing.T) {
+	t.Parallel()
+
+	var (
+		logA    = &types.Log{Address: addr1, Topics: []types.Hash{hash1}}
+		logB    = &types.Log{Address: addr2, Topics: []types.Hash{hash2}}
+		logAB   = &types.Log{Address: addr1, Topics: []types.Hash{hash1, hash2}}
+		logBA   = &types.Log{Address: addr2, Topics: []types.Hash{hash2, hash1}}
+		logABC  = &types.Log{Address: addr1, Topics: []types.Hash{hash1, hash2, hash3}}
+		logCBD  = &types.Log{Address: addr2, Topics: []types.Hash{hash3, hash2, hash4}}
+		logNone = &types.Log{Address: addr1}
+		logs    = []*types.Log{logA, logB, logAB, logBA, logABC, logCBD, logNone}
+	)
+
+	quote := func(hash types.Hash) string {
+		return `"` + hash.String() + `"`
+	}
+
+	cases := []struct {
+		name    string
+		filter  string
+		matches []*types.Log
+	}{
+		{
+			"no filter",
+			`{}`,
+			logs,
+		},
+		{
+			"empty address and topic lists",
+			`{"address": [], "topics": []}`,
+			logs,
+		},
+		{
+			"null address and topics",
+			`{"address": null, "topics": null}`,
+			logs,
+		},
+		{
+			"single address",
+			`{"address": "` + addr2.String() + `"}`,
+			[]*types.Log{logB, logBA, logCBD},
+		},
+		{
+			"address list",
+			`{"address": ["` + addr1.String() + `", "` + addr2.String() + `"]}`,
+			logs,
+		},
+		{
+			"[A] matches A in the first position",
+			`{"topics": [` + quote(hash1) + `]}`,
+			[]*types.Log{logA, logAB, logABC},
+		},
+		{
+			"[null, B] matches anything in the first and B in the second position",
+			`{"topics": [null, ` + quote(hash2) + `]}`,
+			[]*types.Log{logAB, logABC, logCBD},
+		},
+		{
+			"[A, B] matches A in the first and B in the second position",
+			`{"topics": [` + quote(hash1) + `, ` + quote(hash2) + `]}`,
+			[]*types.Log{logAB, logABC},
+		},
+		{
+			"[[A, B], [A, B]] matches A or B in the first and second position",
+			`{"topics": [[` + quote(hash1) + `, ` + quote(hash2) + `], [` + quote(hash1) + `, ` + quote(hash2) + `]]}`,
+			[]*types.Log{logAB, logBA, logABC},
+		},
+		{
+			"[[A, B], null, C] matches A or B first, anything second and C third",
+			`{"topics": [[` + quote(hash1) + `, ` + quote(hash2) + `], null, ` + quote(hash3) + `]}`,
+			[]*types.Log{logABC},
+		},
+		{
+			"[[], B] matches anything in the first and B in the second position",
+			`{"topics": [[], ` + quote(hash2) + `]}`,
+			[]*types.Log{logAB, logABC, logCBD},
+		},
+		{
+			"[[A, null]] matches anything in the first position",
+			`{"topics": [[` + quote(hash1) + `, null]]}`,
+			[]*types.Log{logA, logB, logAB, logBA, logABC, logCBD},
+		},
+		{
+			"address list and topics",
+			`{"address": ["` + addr2.String() + `"], "topics": [null, ` + quote(hash2) + `]}`,
+			[]*types.Log{logCBD},
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			// eth_getLogs decodes the json params
+			query := &LogQuery{}
+			require.NoError(t, query.UnmarshalJSON([]byte(c.filter)))
+
+			// the log subscription decodes the params already decoded as map
+			var params map[string]interface{}
+
+			require.NoError(t, json.Unmarshal([]byte(c.filter), &params))
+
+			subQuery, err := decodeLogQueryFromInterface(params)
+			require.NoError(t, err)
+			require.Equal(t, query, subQuery)
+
+			for _, log := range logs {
+				expected := false
+
+				for _, match := range c.matches {
+					if match == log {
+						expected = true
+					}
+				}
+
+				assert.Equal(t, expected, query.Match(log), "log %v", log)
+			}
+		})
+	}
+}
+
+func TestFilterDecode_InvalidShapes(t *testing.T) {
+	t.Parallel()
+
+	for _, filter := range []string{
+		`{"address": 1}`,
+		`{"address": [null]}`,
+		`{"address": [["` + addr1.String() + `"]]}`,
+		`{"topics": [1]}`,
+		`{"topics": [[["` + hash1.String() + `"]]]}`,
+		`{"topics": [[1]]}`,
+		`{"topics": "` + hash1.String() + `"}`,
+	} {
+		assert.Error(t, (&LogQuery{}).UnmarshalJSON([]byte(filter)), filter)
+	}
+}
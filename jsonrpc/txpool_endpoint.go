@@ -29,6 +29,11 @@ type ContentResponse struct {
 	Queued  map[types.Address]map[uint64]*transaction `json:"queued"`
 }
 
+type ContentFromResponse struct {
+	Pending map[uint64]*transaction `json:"pending"`
+	Queued  map[uint64]*transaction `json:"queued"`
+}
+
 type InspectResponse struct {
 	Pending         map[string]map[string]string `json:"pending"`
 	Queued          map[string]map[string]string `json:"queued"`
@@ -48,11 +53,7 @@ func (t *TxPool) Content() (interface{}, error) {
 		result := make(map[types.Address]map[uint64]*transaction, len(txMap))
 
 		for addr, txs := range txMap {
-			result[addr] = make(map[uint64]*transaction, len(txs))
-
-			for _, tx := range txs {
-				result[addr][tx.Nonce] = toTransaction(tx, nil, &types.ZeroHash, nil)
-			}
+			result[addr] = toNonceTxMap(txs)
 		}
 
 		return result
@@ -67,6 +68,18 @@ func (t *TxPool) Content() (interface{}, error) {
 	return resp, nil
 }
 
+// Create response for txpool_contentFrom request.
+// See https://geth.ethereum.org/docs/rpc/ns-txpool#txpool_contentfrom.
+func (t *TxPool) ContentFrom(addr types.Address) (interface{}, error) {
+	pendingTxs, queuedTxs := t.store.GetTxs(true)
+	resp := ContentFromResponse{
+		Pending: toNonceTxMap(pendingTxs[addr]),
+		Queued:  toNonceTxMap(queuedTxs[addr]),
+	}
+
+	return resp, nil
+}
+
 // Create response for txpool_inspect request.
 // See https://geth.ethereum.org/docs/rpc/ns-txpool#txpool_inspect.
 func (t *TxPool) Inspect() (interface{}, error) {
@@ -78,9 +91,14 @@ func (t *TxPool) Inspect() (interface{}, error) {
 			result[addr.String()] = make(map[string]string, len(txs))
 
 			for _, tx := range txs {
+				to := "contract creation"
+				if tx.To != nil {
+					to = tx.To.String()
+				}
+
 				nonceStr := strconv.FormatUint(tx.Nonce, 10)
 				result[addr.String()][nonceStr] = fmt.Sprintf(
-					"%d wei + %d gas x %d wei", tx.Value, tx.Gas, tx.GetGasPrice(baseFee),
+					"%s: %d wei + %d gas × %d wei", to, tx.Value, tx.Gas, tx.GetGasPrice(baseFee),
 				)
 			}
 		}
@@ -125,3 +143,14 @@ func (t *TxPool) Status() (interface{}, error) {
 
 	return resp, nil
 }
+
+// toNonceTxMap converts the account transactions to their rpc representation keyed by nonce
+func toNonceTxMap(txs []*types.Transaction) map[uint64]*transaction {
+	result := make(map[uint64]*transaction, len(txs))
+
+	for _, tx := range txs {
+		result[tx.Nonce] = toTransaction(tx, nil, &types.ZeroHash, nil)
+	}
+
+	return result
+}
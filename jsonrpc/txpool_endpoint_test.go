@@ -153,6 +153,47 @@ func TestContentEndpoint(t *testing.T) {
 	})
 }
 
+func TestContentFromEndpoint(t *testing.T) {
+	t.Parallel()
+
+	mockStore := newMockTxPoolStore()
+	address1, address2 := types.Address{0x1}, types.Address{0x2}
+
+	// nonce 2 and 3 are executable, nonce 5 is gapped
+	mockStore.pending[address1] = []*types.Transaction{
+		newTestTransaction(2, address1),
+		newTestTransaction(3, address1),
+	}
+	mockStore.queued[address1] = []*types.Transaction{newTestTransaction(5, address1)}
+	mockStore.pending[address2] = []*types.Transaction{newTestTransaction(1, address2)}
+	txPoolEndpoint := &TxPool{mockStore}
+
+	result, err := txPoolEndpoint.ContentFrom(address1)
+	assert.NoError(t, err)
+
+	//nolint:forcetypeassert
+	response := result.(ContentFromResponse)
+
+	assert.True(t, mockStore.includeQueued)
+	assert.Len(t, response.Pending, 2)
+	assert.Len(t, response.Queued, 1)
+	assert.Equal(t, address1, response.Pending[2].From)
+	assert.Equal(t, address1, response.Pending[3].From)
+	assert.Equal(t, address1, response.Queued[5].From)
+
+	// unknown accounts have empty (non-nil) maps
+	result, err = txPoolEndpoint.ContentFrom(types.Address{0x3})
+	assert.NoError(t, err)
+
+	//nolint:forcetypeassert
+	response = result.(ContentFromResponse)
+
+	assert.NotNil(t, response.Pending)
+	assert.NotNil(t, response.Queued)
+	assert.Empty(t, response.Pending)
+	assert.Empty(t, response.Queued)
+}
+
 func TestInspectEndpoint(t *testing.T) {
 	t.Parallel()
 
@@ -219,6 +260,32 @@ func TestInspectEndpoint(t *testing.T) {
 		assert.NotNil(t, transactionInfo[strconv.FormatUint(testTx.Nonce, 10)])
 		assert.NotNil(t, transactionInfo[strconv.FormatUint(testTx2.Nonce, 10)])
 	})
+
+	t.Run("returns compact transaction summaries", func(t *testing.T) {
+		t.Parallel()
+
+		mockStore := newMockTxPoolStore()
+		address1 := types.Address{0x1}
+		testTx := newTestTransaction(2, address1)
+		createTx := newTestTransaction(4, address1)
+		createTx.To = nil
+		mockStore.pending[address1] = []*types.Transaction{testTx}
+		mockStore.queued[address1] = []*types.Transaction{createTx}
+		txPoolEndpoint := &TxPool{mockStore}
+
+		result, _ := txPoolEndpoint.Inspect()
+		//nolint:forcetypeassert
+		response := result.(InspectResponse)
+
+		assert.Equal(t,
+			addr1.String()+": 200 wei + 200 gas × 1 wei",
+			response.Pending[address1.String()]["2"],
+		)
+		assert.Equal(t,
+			"contract creation: 200 wei + 400 gas × 1 wei",
+			response.Queued[address1.String()]["4"],
+		)
+	})
 }
 
 func TestStatusEndpoint(t *testing.T) {
@@ -144,6 +144,24 @@ type block struct {
 	Transactions    []transactionOrHash `json:"transactions"`
 	Uncles          []types.Hash        `json:"uncles"`
 	BaseFee         argUint64           `json:"baseFeePerGas,omitempty"`
+	XGR             *blockEconomics     `json:"xgr,omitempty"`
+}
+
+// blockEconomics is the fee split of all transactions in a block
+type blockEconomics struct {
+	TotalBurnedWei        *argBig   `json:"totalBurnedWei"`
+	TotalDonatedWei       *argBig   `json:"totalDonatedWei"`
+	TotalValidatorFeesWei *argBig   `json:"totalValidatorFeesWei"`
+	EngineTxCount         argUint64 `json:"engineTxCount"`
+}
+
+func toBlockEconomics(econ *types.BlockEconomics) *blockEconomics {
+	return &blockEconomics{
+		TotalBurnedWei:        argBigPtr(econ.TotalBurned),
+		TotalDonatedWei:       argBigPtr(econ.TotalDonated),
+		TotalValidatorFeesWei: argBigPtr(econ.TotalValidatorFees),
+		EngineTxCount:         argUint64(econ.EngineTxCount),
+	}
 }
 
 func (b *block) Copy() *block {
@@ -227,10 +245,27 @@ type receipt struct {
 	ContractAddress   *types.Address `json:"contractAddress"`
 	FromAddr          types.Address  `json:"from"`
 	ToAddr            *types.Address `json:"to"`
+	FeeSplit          *feeSplit      `json:"feeSplit,omitempty"`
+}
+
+// feeSplit is the split of the transaction fee between the donation, the validator and the burn
+type feeSplit struct {
+	Donation  *argBig `json:"donation"`
+	Validator *argBig `json:"validator"`
+	Burned    *argBig `json:"burned"`
 }
 
 func toReceipt(src *types.Receipt, tx *types.Transaction,
 	txIndex uint64, header *types.Header, logs []*Log) *receipt {
+	var split *feeSplit
+	if src.FeeSplit != nil {
+		split = &feeSplit{
+			Donation:  argBigPtr(src.FeeSplit.Donation),
+			Validator: argBigPtr(src.FeeSplit.Validator),
+			Burned:    argBigPtr(src.FeeSplit.Burned),
+		}
+	}
+
 	return &receipt{
 		Root:              src.Root,
 		CumulativeGasUsed: argUint64(src.CumulativeGasUsed),
@@ -246,6 +281,7 @@ func toReceipt(src *types.Receipt, tx *types.Transaction,
 		FromAddr:          tx.From,
 		ToAddr:            tx.To,
 		Logs:              logs,
+		FeeSplit:          split,
 	}
 }
 
@@ -422,6 +458,54 @@ type feeHistoryResult struct {
 	Reward        [][]argUint64 `json:"reward,omitempty"`
 }
 
+// accountProofResult is the EIP-1186 response of eth_getProof
+type accountProofResult struct {
+	Address      types.Address        `json:"address"`
+	AccountProof []argBytes           `json:"accountProof"`
+	Balance      argBig               `json:"balance"`
+	CodeHash     types.Hash           `json:"codeHash"`
+	Nonce        argUint64            `json:"nonce"`
+	StorageHash  types.Hash           `json:"storageHash"`
+	StorageProof []storageProofResult `json:"storageProof"`
+}
+
+type storageProofResult struct {
+	Key   types.Hash `json:"key"`
+	Value argBig     `json:"value"`
+	Proof []argBytes `json:"proof"`
+}
+
+func toAccountProofResult(addr types.Address, proof *AccountProof) *accountProofResult {
+	res := &accountProofResult{
+		Address:      addr,
+		AccountProof: toArgBytesSlice(proof.AccountProof),
+		Balance:      argBig(*proof.Balance),
+		CodeHash:     proof.CodeHash,
+		Nonce:        argUint64(proof.Nonce),
+		StorageHash:  proof.StorageHash,
+		StorageProof: make([]storageProofResult, len(proof.StorageProof)),
+	}
+
+	for i, sp := range proof.StorageProof {
+		res.StorageProof[i] = storageProofResult{
+			Key:   sp.Key,
+			Value: argBig(*new(big.Int).SetBytes(sp.Value)),
+			Proof: toArgBytesSlice(sp.Proof),
+		}
+	}
+
+	return res
+}
+
+func toArgBytesSlice(slice [][]byte) []argBytes {
+	argSlice := make([]argBytes, len(slice))
+	for i, value := range slice {
+		argSlice[i] = argBytes(value)
+	}
+
+	return argSlice
+}
+
 func convertToArgUint64Slice(slice []uint64) []argUint64 {
 	argSlice := make([]argUint64, len(slice))
 	for i, value := range slice {
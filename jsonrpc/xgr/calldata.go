@@ -0,0 +1,272 @@
+package xgr
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/abi"
+
+	"github.com/xgr-network/xgr-node/consensus/polybft/contractsapi"
+	"github.com/xgr-network/xgr-node/contracts/abis"
+	"github.com/xgr-network/xgr-node/contracts/engineabi"
+	"github.com/xgr-network/xgr-node/helper/hex"
+	"github.com/xgr-network/xgr-node/state/runtime/addresslist"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+const selectorLength = 4
+
+var ErrCalldataTooShort = errors.New("calldata is shorter than a function selector")
+
+// DecodedCalldata is the result of decoding calldata against the known system ABIs.
+// Found is false if no known function has the selector, Candidates is set instead of
+// the decoded call if the calldata matches several functions.
+type DecodedCalldata struct {
+	Found      bool                 `json:"found"`
+	Selector   string               `json:"selector"`
+	Contract   string               `json:"contract,omitempty"`
+	Function   string               `json:"function,omitempty"`
+	Signature  string               `json:"signature,omitempty"`
+	Arguments  []*DecodedArgument   `json:"arguments,omitempty"`
+	Candidates []*CalldataCandidate `json:"candidates,omitempty"`
+}
+
+// DecodedArgument is a single decoded function argument. Tuples are expanded into
+// objects of their named fields, bytes are hex encoded and integers are decimal strings.
+type DecodedArgument struct {
+	Name  string      `json:"name"`
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+// CalldataCandidate is a known function matching the selector of ambiguous calldata
+type CalldataCandidate struct {
+	Contract  string `json:"contract"`
+	Function  string `json:"function"`
+	Signature string `json:"signature"`
+}
+
+type knownMethod struct {
+	contract string
+	method   *abi.Method
+}
+
+var (
+	knownMethodsOnce sync.Once
+	knownMethods     map[string][]*knownMethod
+)
+
+// knownABIs returns the ABIs of the system contracts and precompiles by contract name
+func knownABIs() map[string][]*abi.Method {
+	return map[string][]*abi.Method{
+		"EngineExecute": abiMethods(
+			abi.MustNewABI(engineabi.ExecuteABI),
+			abi.MustNewABI(engineabi.GetNextPidABI),
+			abi.MustNewABI(engineabi.IsPidUsedABI),
+		),
+		"AddressList": {
+			addresslist.SetAdminFunc,
+			addresslist.SetEnabledFunc,
+			addresslist.SetNoneFunc,
+			addresslist.ReadAddressListFunc,
+		},
+		"Staking":               abiMethods(abis.StakingABI),
+		"RewardAddress":         abiMethods(abis.RewardAddressABI),
+		"StakeManager":          abiMethods(contractsapi.StakeManager.Abi),
+		"CustomSupernetManager": abiMethods(contractsapi.CustomSupernetManager.Abi),
+		"ValidatorSet":          abiMethods(contractsapi.ValidatorSet.Abi),
+		"RewardPool":            abiMethods(contractsapi.RewardPool.Abi),
+	}
+}
+
+func abiMethods(list ...*abi.ABI) []*abi.Method {
+	var methods []*abi.Method
+
+	for _, a := range list {
+		for _, m := range a.Methods {
+			methods = append(methods, m)
+		}
+	}
+
+	return methods
+}
+
+// lookupSelector returns the known methods with the given selector
+func lookupSelector(selector []byte) []*knownMethod {
+	knownMethodsOnce.Do(func() {
+		knownMethods = map[string][]*knownMethod{}
+
+		for contract, methods := range knownABIs() {
+			for _, m := range methods {
+				id := string(m.ID())
+				knownMethods[id] = append(knownMethods[id], &knownMethod{contract: contract, method: m})
+			}
+		}
+	})
+
+	return knownMethods[string(selector)]
+}
+
+// DecodeCalldata decodes the calldata against the known system ABIs: the engine precompile,
+// the address lists and the staking, registry and reward pool contracts
+func DecodeCalldata(data []byte) (*DecodedCalldata, error) {
+	if len(data) < selectorLength {
+		return nil, ErrCalldataTooShort
+	}
+
+	selector := data[:selectorLength]
+	res := &DecodedCalldata{Selector: hex.EncodeToHex(selector)}
+
+	var (
+		matches   []*knownMethod
+		arguments [][]*DecodedArgument
+	)
+
+	for _, known := range lookupSelector(selector) {
+		// a selector clash is resolved if the arguments only decode for one of the functions
+		args, err := decodeArguments(known.method.Inputs, data[selectorLength:])
+		if err != nil {
+			continue
+		}
+
+		matches = append(matches, known)
+		arguments = append(arguments, args)
+	}
+
+	switch len(matches) {
+	case 0:
+		return res, nil
+	case 1:
+		res.Found = true
+		res.Contract = matches[0].contract
+		res.Function = matches[0].method.Name
+		res.Signature = matches[0].method.Sig()
+		res.Arguments = arguments[0]
+	default:
+		res.Found = true
+
+		for _, m := range matches {
+			res.Candidates = append(res.Candidates, &CalldataCandidate{
+				Contract:  m.contract,
+				Function:  m.method.Name,
+				Signature: m.method.Sig(),
+			})
+		}
+	}
+
+	return res, nil
+}
+
+func decodeArguments(inputs *abi.Type, data []byte) ([]*DecodedArgument, error) {
+	raw, err := abi.Decode(inputs, data)
+	if err != nil {
+		return nil, err
+	}
+
+	values, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected arguments type %T", raw)
+	}
+
+	elems := inputs.TupleElems()
+	args := make([]*DecodedArgument, 0, len(elems))
+
+	for i, elem := range elems {
+		name := tupleElemName(elem, i)
+
+		value, err := formatValue(elem.Elem, values[name])
+		if err != nil {
+			return nil, fmt.Errorf("argument %s: %w", name, err)
+		}
+
+		args = append(args, &DecodedArgument{
+			Name:  name,
+			Type:  elem.Elem.String(),
+			Value: value,
+		})
+	}
+
+	return args, nil
+}
+
+// tupleElemName returns the key of the element in a decoded tuple, unnamed elements are keyed by their index
+func tupleElemName(elem *abi.TupleElem, i int) string {
+	if elem.Name == "" {
+		return strconv.Itoa(i)
+	}
+
+	return elem.Name
+}
+
+// formatValue converts a decoded abi value into its JSON representation
+func formatValue(t *abi.Type, v interface{}) (interface{}, error) {
+	switch t.Kind() {
+	case abi.KindBool, abi.KindString:
+		return v, nil
+	case abi.KindUInt, abi.KindInt:
+		return fmt.Sprint(v), nil
+	case abi.KindAddress:
+		addr, ok := v.(ethgo.Address)
+		if !ok {
+			return nil, fmt.Errorf("unexpected address type %T", v)
+		}
+
+		return types.Address(addr).String(), nil
+	case abi.KindBytes:
+		b, ok := v.([]byte)
+		if !ok {
+			return nil, fmt.Errorf("unexpected bytes type %T", v)
+		}
+
+		return hex.EncodeToHex(b), nil
+	case abi.KindFixedBytes:
+		rv := reflect.ValueOf(v)
+		b := make([]byte, rv.Len())
+
+		for i := range b {
+			b[i] = byte(rv.Index(i).Uint())
+		}
+
+		return hex.EncodeToHex(b), nil
+	case abi.KindSlice, abi.KindArray:
+		rv := reflect.ValueOf(v)
+		res := make([]interface{}, 0, rv.Len())
+
+		for i := 0; i < rv.Len(); i++ {
+			elem, err := formatValue(t.Elem(), rv.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+
+			res = append(res, elem)
+		}
+
+		return res, nil
+	case abi.KindTuple:
+		values, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unexpected tuple type %T", v)
+		}
+
+		res := make(map[string]interface{}, len(values))
+
+		for i, elem := range t.TupleElems() {
+			name := tupleElemName(elem, i)
+
+			value, err := formatValue(elem.Elem, values[name])
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+
+			res[name] = value
+		}
+
+		return res, nil
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
@@ -0,0 +1,128 @@
+package xgr
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo/abi"
+
+	"github.com/xgr-network/xgr-node/contracts/engineabi"
+	"github.com/xgr-network/xgr-node/state/runtime/addresslist"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+func TestDecodeCalldata_EngineExecute(t *testing.T) {
+	t.Parallel()
+
+	engine := types.StringToAddress("0xe1")
+	target := types.StringToAddress("0xc0")
+
+	input, err := abi.MustNewABI(engineabi.ExecuteABI).GetMethod("ENGINE_EXECUTE").Encode(map[string]interface{}{
+		"grant": map[string]interface{}{
+			"from":        types.StringToAddress("0x1"),
+			"engine":      engine,
+			"xrc729":      types.ZeroAddress,
+			"ostcId":      "ostc-1",
+			"ostcHash":    [32]byte{0xab},
+			"processId":   big.NewInt(7),
+			"maxTotalGas": big.NewInt(1_000_000),
+			"expiry":      big.NewInt(1_700_000_000),
+			"sessionId":   big.NewInt(42),
+			"chainId":     big.NewInt(1879),
+		},
+		"call": map[string]interface{}{
+			"to":                 target,
+			"data":               []byte{0xde, 0xad, 0xbe, 0xef},
+			"valueWei":           big.NewInt(0),
+			"gasLimit":           uint64(21_000),
+			"validationGas":      uint64(0),
+			"maxFeePerGas":       big.NewInt(1),
+			"deadline":           uint64(0),
+			"grantFeeSeconds":    uint64(3600),
+			"grantFeePerYearWei": big.NewInt(31_536_000),
+		},
+		"meta": map[string]interface{}{
+			"iteration":     uint64(3),
+			"stepId":        "step-a",
+			"ruleContract":  types.ZeroAddress,
+			"ruleHash":      [32]byte{},
+			"payload":       []byte(`{"k":"v"}`),
+			"apiSaves":      []byte{},
+			"contractSaves": []byte{},
+			"extras":        []byte{0x01},
+		},
+	})
+	require.NoError(t, err)
+
+	res, err := DecodeCalldata(input)
+	require.NoError(t, err)
+
+	assert.True(t, res.Found)
+	assert.Empty(t, res.Candidates)
+	assert.Equal(t, "EngineExecute", res.Contract)
+	assert.Equal(t, "ENGINE_EXECUTE", res.Function)
+	require.Len(t, res.Arguments, 3)
+
+	assert.Equal(t, "grant", res.Arguments[0].Name)
+	assert.Equal(t, "call", res.Arguments[1].Name)
+	assert.Equal(t, "meta", res.Arguments[2].Name)
+
+	grant, ok := res.Arguments[0].Value.(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, engine.String(), grant["engine"])
+	assert.Equal(t, "ostc-1", grant["ostcId"])
+	assert.Equal(t, "0xab00000000000000000000000000000000000000000000000000000000000000", grant["ostcHash"])
+	assert.Equal(t, "42", grant["sessionId"])
+	assert.Equal(t, "1879", grant["chainId"])
+
+	call, ok := res.Arguments[1].Value.(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, target.String(), call["to"])
+	assert.Equal(t, "0xdeadbeef", call["data"])
+	assert.Equal(t, "21000", call["gasLimit"])
+
+	meta, ok := res.Arguments[2].Value.(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "3", meta["iteration"])
+	assert.Equal(t, "step-a", meta["stepId"])
+	assert.Equal(t, "0x01", meta["extras"])
+
+	// the result is plain JSON
+	_, err = json.Marshal(res)
+	require.NoError(t, err)
+}
+
+func TestDecodeCalldata_AddressListSetRole(t *testing.T) {
+	t.Parallel()
+
+	account := types.StringToAddress("0x501")
+
+	input, err := addresslist.SetEnabledFunc.Encode([]interface{}{account})
+	require.NoError(t, err)
+
+	res, err := DecodeCalldata(input)
+	require.NoError(t, err)
+
+	assert.True(t, res.Found)
+	assert.Equal(t, "AddressList", res.Contract)
+	assert.Equal(t, "setEnabled", res.Function)
+	assert.Equal(t, "setEnabled(address)", res.Signature)
+	require.Len(t, res.Arguments, 1)
+	assert.Equal(t, "address", res.Arguments[0].Type)
+	assert.Equal(t, account.String(), res.Arguments[0].Value)
+}
+
+func TestDecodeCalldata_Unknown(t *testing.T) {
+	t.Parallel()
+
+	res, err := DecodeCalldata([]byte{0xff, 0xff, 0xff, 0xff, 0x01})
+	require.NoError(t, err)
+	assert.False(t, res.Found)
+	assert.Equal(t, "0xffffffff", res.Selector)
+
+	_, err = DecodeCalldata([]byte{0x01})
+	require.ErrorIs(t, err, ErrCalldataTooShort)
+}
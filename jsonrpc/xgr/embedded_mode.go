@@ -11,3 +11,6 @@ var New = xgrext.New
 var LoadEngineConfigFlex = xgrext.LoadEngineConfigFlex
 
 func EmbeddedAvailable() bool { return true }
+
+// BindState is a no-op: the embedded engine resolves state on its own.
+func BindState(*XGR, LatestStateFn) {}
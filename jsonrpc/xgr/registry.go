@@ -0,0 +1,254 @@
+package xgr
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/xgr-network/xgr-node/chain"
+	"github.com/xgr-network/xgr-node/contracts"
+	"github.com/xgr-network/xgr-node/helper/hex"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+// StateReader gives read-only access to a single state root.
+// It is state-only on purpose, so the registry RPCs work in stub and embedded builds alike.
+type StateReader interface {
+	// GetStorage returns the raw storage value (zero hash if unset)
+	GetStorage(addr types.Address, slot types.Hash) (types.Hash, error)
+
+	// GetCode returns the deployed code (nil if none)
+	GetCode(addr types.Address) ([]byte, error)
+}
+
+// LatestStateFn resolves a StateReader for the latest state snapshot
+type LatestStateFn func() (StateReader, error)
+
+// EngineRegistryConfig is the effective EngineRegistry configuration at a given state root.
+// If the registry is missing (address==0 or code-size==0), the chain defaults are reported,
+// exactly as they are applied during block execution.
+type EngineRegistryConfig struct {
+	Registry         string `json:"registry"`
+	RegistryDeployed bool   `json:"registryDeployed"`
+	Paused           bool   `json:"paused"`
+	MinBaseFee       string `json:"minBaseFee"`
+	DonationAddress  string `json:"donationAddress"`
+	DonationPercent  uint64 `json:"donationPercent"`
+
+	// RequireGrantChainID rejects engine grants without a chainId
+	RequireGrantChainID bool `json:"requireGrantChainId"`
+	// RefundOnFailurePolicy excludes the execLimit from the engine refund of failed inner calls
+	RefundOnFailurePolicy bool `json:"refundOnFailurePolicy"`
+}
+
+// EngineAuthorization reports whether an engine EOA is authorized at a given state root.
+type EngineAuthorization struct {
+	Registry         string `json:"registry"`
+	RegistryDeployed bool   `json:"registryDeployed"`
+	Engine           string `json:"engine"`
+	Authorized       bool   `json:"authorized"`
+}
+
+// ReadEngineRegistryConfig reads paused, minBaseFee, donationAddress, donationPercent,
+// requireGrantChainId and refundOnFailurePolicy from the EngineRegistry storage.
+// defaultPercent is the donation percent of the chain while the registry is missing.
+func ReadEngineRegistryConfig(r StateReader, defaultPercent uint64) (*EngineRegistryConfig, error) {
+	reg := chain.EngineRegistryAddress
+
+	res := &EngineRegistryConfig{
+		Registry:        reg.String(),
+		MinBaseFee:      hex.EncodeUint64(chain.MinBaseFee),
+		DonationAddress: chain.DefaultDonationAddress.String(),
+		DonationPercent: defaultPercent,
+	}
+
+	deployed, err := registryDeployed(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if !deployed {
+		return res, nil
+	}
+
+	res.RegistryDeployed = true
+
+	paused, err := r.GetStorage(reg, chain.EngineRegistrySlotKeyPaused())
+	if err != nil {
+		return nil, err
+	}
+
+	res.Paused = paused != (types.Hash{})
+
+	// minBaseFee: uint256, values above uint64 are invalid -> fallback (same as block building)
+	rawMin, err := r.GetStorage(reg, chain.EngineRegistrySlotKeyMinBaseFee())
+	if err != nil {
+		return nil, err
+	}
+
+	if v := new(big.Int).SetBytes(rawMin[:]); v.BitLen() <= 64 {
+		res.MinBaseFee = hex.EncodeUint64(v.Uint64())
+	}
+
+	donationAddr, donationPercent, err := readDonation(r, defaultPercent)
+	if err != nil {
+		return nil, err
+	}
+
+	res.DonationAddress, res.DonationPercent = donationAddr.String(), donationPercent
+
+	requireChainID, err := r.GetStorage(reg, chain.EngineRegistrySlotKeyRequireGrantChainID())
+	if err != nil {
+		return nil, err
+	}
+
+	res.RequireGrantChainID = chain.EngineRegistryRequireGrantChainID(requireChainID)
+
+	refundPolicy, err := r.GetStorage(reg, chain.EngineRegistrySlotKeyRefundOnFailurePolicy())
+	if err != nil {
+		return nil, err
+	}
+
+	res.RefundOnFailurePolicy = chain.EngineRegistryRefundOnFailurePolicy(refundPolicy)
+
+	return res, nil
+}
+
+// FeeSplitConfig is the split of transaction fees at a given state root: the fixed burn,
+// the donation and (implicitly) the rest going to the block proposer.
+type FeeSplitConfig struct {
+	BurnedAddress   string `json:"burnedAddress"`
+	DonationAddress string `json:"donationAddress"`
+	DonationPercent uint64 `json:"donationPercent"`
+	FixedBurnWei    string `json:"fixedBurnWei"`
+}
+
+// ReadFeeSplitConfig resolves the fee split with the same fallbacks as block execution:
+// the donation is read from a deployed EngineRegistry, otherwise the chain defaults apply.
+func ReadFeeSplitConfig(r StateReader, defaultPercent uint64) (*FeeSplitConfig, error) {
+	res := &FeeSplitConfig{
+		BurnedAddress:   chain.DefaultBurnedAddress.String(),
+		DonationAddress: chain.DefaultDonationAddress.String(),
+		DonationPercent: defaultPercent,
+		FixedBurnWei:    hex.EncodeUint64(chain.FixedBurnWei),
+	}
+
+	deployed, err := registryDeployed(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if !deployed {
+		return res, nil
+	}
+
+	donationAddr, donationPercent, err := readDonation(r, defaultPercent)
+	if err != nil {
+		return nil, err
+	}
+
+	res.DonationAddress, res.DonationPercent = donationAddr.String(), donationPercent
+
+	return res, nil
+}
+
+// readDonation reads the donation recipient and percent of the deployed EngineRegistry
+func readDonation(r StateReader, defaultPercent uint64) (types.Address, uint64, error) {
+	addrSlot, err := r.GetStorage(chain.EngineRegistryAddress, chain.EngineRegistrySlotKeyDonationAddress())
+	if err != nil {
+		return types.ZeroAddress, 0, err
+	}
+
+	pctSlot, err := r.GetStorage(chain.EngineRegistryAddress, chain.EngineRegistrySlotKeyDonationPercent())
+	if err != nil {
+		return types.ZeroAddress, 0, err
+	}
+
+	donationAddr, donationPercent := chain.ResolveDonation(addrSlot, pctSlot, defaultPercent)
+
+	return donationAddr, donationPercent, nil
+}
+
+// ReadEngineAuthorization resolves authorizedEngines[engine] the same way the engine precompile does,
+// including the bootstrap EOA fallback and the paused flag.
+func ReadEngineAuthorization(r StateReader, engine types.Address) (*EngineAuthorization, error) {
+	reg := chain.EngineRegistryAddress
+
+	res := &EngineAuthorization{
+		Registry: reg.String(),
+		Engine:   engine.String(),
+	}
+
+	deployed, err := registryDeployed(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if !deployed {
+		res.Authorized = chain.BootstrapEngineEOA != (types.Address{}) && engine == chain.BootstrapEngineEOA
+
+		return res, nil
+	}
+
+	res.RegistryDeployed = true
+
+	paused, err := r.GetStorage(reg, chain.EngineRegistrySlotKeyPaused())
+	if err != nil {
+		return nil, err
+	}
+
+	if paused != (types.Hash{}) {
+		return res, nil
+	}
+
+	authorized, err := r.GetStorage(reg, chain.EngineRegistrySlotKeyAuthorizedEngine(engine))
+	if err != nil {
+		return nil, err
+	}
+
+	res.Authorized = authorized != (types.Hash{})
+
+	return res, nil
+}
+
+// ErrEngineNotAuthorized is returned by the engine probe while the engine may not execute
+var ErrEngineNotAuthorized = errors.New("engine is not authorized in the EngineRegistry")
+
+// EngineProbe returns a cheap read-only check of the engine authorization for the engine circuit breaker.
+// It mirrors ENGINE_GET_NEXT_PID on the engine EOA, to make sure the precompile state is readable,
+// and then resolves authorizedEngines[engine] including the paused flag.
+func EngineProbe(latestState LatestStateFn, engine types.Address) func() error {
+	return func() error {
+		r, err := latestState()
+		if err != nil {
+			return err
+		}
+
+		if _, err := r.GetStorage(contracts.EngineExecutePrecompile, contracts.EngineNextPidSlotKey(engine)); err != nil {
+			return err
+		}
+
+		auth, err := ReadEngineAuthorization(r, engine)
+		if err != nil {
+			return err
+		}
+
+		if !auth.Authorized {
+			return ErrEngineNotAuthorized
+		}
+
+		return nil
+	}
+}
+
+func registryDeployed(r StateReader) (bool, error) {
+	if chain.EngineRegistryAddress == (types.Address{}) {
+		return false, nil
+	}
+
+	code, err := r.GetCode(chain.EngineRegistryAddress)
+	if err != nil {
+		return false, err
+	}
+
+	return len(code) > 0, nil
+}
@@ -0,0 +1,253 @@
+package xgr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xgr-network/xgr-node/chain"
+	"github.com/xgr-network/xgr-node/helper/hex"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+type mockRegistryState struct {
+	code    map[types.Address][]byte
+	storage map[types.Address]map[types.Hash]types.Hash
+}
+
+func newMockRegistryState() *mockRegistryState {
+	return &mockRegistryState{
+		code:    map[types.Address][]byte{},
+		storage: map[types.Address]map[types.Hash]types.Hash{},
+	}
+}
+
+func (m *mockRegistryState) setStorage(addr types.Address, slot types.Hash, value types.Hash) {
+	if m.storage[addr] == nil {
+		m.storage[addr] = map[types.Hash]types.Hash{}
+	}
+
+	m.storage[addr][slot] = value
+}
+
+func (m *mockRegistryState) GetStorage(addr types.Address, slot types.Hash) (types.Hash, error) {
+	return m.storage[addr][slot], nil
+}
+
+func (m *mockRegistryState) GetCode(addr types.Address) ([]byte, error) {
+	return m.code[addr], nil
+}
+
+func withRegistry(t *testing.T, reg, bootstrap types.Address) {
+	t.Helper()
+
+	prevReg, prevBootstrap := chain.EngineRegistryAddress, chain.BootstrapEngineEOA
+	chain.EngineRegistryAddress, chain.BootstrapEngineEOA = reg, bootstrap
+
+	t.Cleanup(func() {
+		chain.EngineRegistryAddress, chain.BootstrapEngineEOA = prevReg, prevBootstrap
+	})
+}
+
+func TestReadEngineRegistryConfig_Fallback(t *testing.T) {
+	expected := &EngineRegistryConfig{
+		RegistryDeployed: false,
+		Paused:           false,
+		MinBaseFee:       hex.EncodeUint64(chain.MinBaseFee),
+		DonationAddress:  chain.DefaultDonationAddress.String(),
+		DonationPercent:  chain.DefaultDonationPercent,
+	}
+
+	t.Run("registry address unset", func(t *testing.T) {
+		withRegistry(t, types.ZeroAddress, types.ZeroAddress)
+
+		cfg, err := ReadEngineRegistryConfig(newMockRegistryState(), chain.DefaultDonationPercent)
+		require.NoError(t, err)
+
+		expected.Registry = types.ZeroAddress.String()
+		assert.Equal(t, expected, cfg)
+	})
+
+	t.Run("registry not deployed", func(t *testing.T) {
+		reg := types.StringToAddress("0x1000")
+		withRegistry(t, reg, types.ZeroAddress)
+
+		st := newMockRegistryState()
+		// storage without code must be ignored
+		st.setStorage(reg, chain.EngineRegistrySlotKeyPaused(), types.BytesToHash([]byte{1}))
+		st.setStorage(reg, chain.EngineRegistrySlotKeyDonationPercent(), types.BytesToHash([]byte{50}))
+
+		cfg, err := ReadEngineRegistryConfig(st, chain.DefaultDonationPercent)
+		require.NoError(t, err)
+
+		expected.Registry = reg.String()
+		assert.Equal(t, expected, cfg)
+	})
+}
+
+func TestReadEngineRegistryConfig_Deployed(t *testing.T) {
+	reg := types.StringToAddress("0x1000")
+	donation := types.StringToAddress("0x2000")
+
+	withRegistry(t, reg, types.ZeroAddress)
+
+	st := newMockRegistryState()
+	st.code[reg] = []byte{0x1}
+	st.setStorage(reg, chain.EngineRegistrySlotKeyPaused(), types.BytesToHash([]byte{1}))
+	st.setStorage(reg, chain.EngineRegistrySlotKeyMinBaseFee(), types.BytesToHash([]byte{0x3, 0xe8}))
+	st.setStorage(reg, chain.EngineRegistrySlotKeyDonationAddress(), types.BytesToHash(donation.Bytes()))
+	st.setStorage(reg, chain.EngineRegistrySlotKeyDonationPercent(), types.BytesToHash([]byte{20}))
+	// requireGrantChainId and refundOnFailurePolicy share slot 10 (offsets 0 and 1)
+	st.setStorage(reg, chain.EngineRegistrySlotKeyRequireGrantChainID(), types.BytesToHash([]byte{1, 1}))
+
+	cfg, err := ReadEngineRegistryConfig(st, chain.DefaultDonationPercent)
+	require.NoError(t, err)
+
+	assert.Equal(t, &EngineRegistryConfig{
+		Registry:         reg.String(),
+		RegistryDeployed: true,
+		Paused:           true,
+		MinBaseFee:       "0x3e8",
+		DonationAddress:  donation.String(),
+		DonationPercent:  20,
+
+		RequireGrantChainID:   true,
+		RefundOnFailurePolicy: true,
+	}, cfg)
+
+	// zero donation address disables the donation, out of range percent is ignored
+	st.setStorage(reg, chain.EngineRegistrySlotKeyDonationAddress(), types.ZeroHash)
+	st.setStorage(reg, chain.EngineRegistrySlotKeyDonationPercent(), types.BytesToHash([]byte{101}))
+
+	cfg, err = ReadEngineRegistryConfig(st, chain.DefaultDonationPercent)
+	require.NoError(t, err)
+
+	assert.Equal(t, chain.DefaultDonationAddress.String(), cfg.DonationAddress)
+	assert.Equal(t, uint64(0), cfg.DonationPercent)
+}
+
+func TestReadFeeSplitConfig(t *testing.T) {
+	reg := types.StringToAddress("0x1000")
+	donation := types.StringToAddress("0x2000")
+
+	defaults := &FeeSplitConfig{
+		BurnedAddress:   chain.DefaultBurnedAddress.String(),
+		DonationAddress: chain.DefaultDonationAddress.String(),
+		DonationPercent: chain.DefaultDonationPercent,
+		FixedBurnWei:    "0xe8d4a51000",
+	}
+
+	t.Run("registry not deployed", func(t *testing.T) {
+		withRegistry(t, reg, types.ZeroAddress)
+
+		st := newMockRegistryState()
+		// storage without code must be ignored
+		st.setStorage(reg, chain.EngineRegistrySlotKeyDonationAddress(), types.BytesToHash(donation.Bytes()))
+		st.setStorage(reg, chain.EngineRegistrySlotKeyDonationPercent(), types.BytesToHash([]byte{50}))
+
+		cfg, err := ReadFeeSplitConfig(st, chain.DefaultDonationPercent)
+		require.NoError(t, err)
+		assert.Equal(t, defaults, cfg)
+	})
+
+	t.Run("deployed registry", func(t *testing.T) {
+		withRegistry(t, reg, types.ZeroAddress)
+
+		st := newMockRegistryState()
+		st.code[reg] = []byte{0x1}
+		st.setStorage(reg, chain.EngineRegistrySlotKeyDonationAddress(), types.BytesToHash(donation.Bytes()))
+		st.setStorage(reg, chain.EngineRegistrySlotKeyDonationPercent(), types.BytesToHash([]byte{20}))
+
+		cfg, err := ReadFeeSplitConfig(st, chain.DefaultDonationPercent)
+		require.NoError(t, err)
+		assert.Equal(t, &FeeSplitConfig{
+			BurnedAddress:   chain.DefaultBurnedAddress.String(),
+			DonationAddress: donation.String(),
+			DonationPercent: 20,
+			FixedBurnWei:    defaults.FixedBurnWei,
+		}, cfg)
+
+		// an out of range percent falls back to the default
+		st.setStorage(reg, chain.EngineRegistrySlotKeyDonationPercent(), types.BytesToHash([]byte{101}))
+
+		cfg, err = ReadFeeSplitConfig(st, chain.DefaultDonationPercent)
+		require.NoError(t, err)
+		assert.Equal(t, chain.DefaultDonationPercent, cfg.DonationPercent)
+
+		// a zero donation address disables the donation
+		st.setStorage(reg, chain.EngineRegistrySlotKeyDonationAddress(), types.ZeroHash)
+
+		cfg, err = ReadFeeSplitConfig(st, chain.DefaultDonationPercent)
+		require.NoError(t, err)
+		assert.Equal(t, chain.DefaultDonationAddress.String(), cfg.DonationAddress)
+		assert.Equal(t, uint64(0), cfg.DonationPercent)
+	})
+}
+
+func TestReadEngineAuthorization(t *testing.T) {
+	reg := types.StringToAddress("0x1000")
+	bootstrap := types.StringToAddress("0x3000")
+	engine := types.StringToAddress("0x4000")
+
+	t.Run("bootstrap fallback when registry is missing", func(t *testing.T) {
+		withRegistry(t, reg, bootstrap)
+
+		st := newMockRegistryState()
+
+		auth, err := ReadEngineAuthorization(st, bootstrap)
+		require.NoError(t, err)
+		assert.False(t, auth.RegistryDeployed)
+		assert.True(t, auth.Authorized)
+
+		auth, err = ReadEngineAuthorization(st, engine)
+		require.NoError(t, err)
+		assert.False(t, auth.Authorized)
+	})
+
+	t.Run("deployed registry", func(t *testing.T) {
+		withRegistry(t, reg, bootstrap)
+
+		st := newMockRegistryState()
+		st.code[reg] = []byte{0x1}
+		st.setStorage(reg, chain.EngineRegistrySlotKeyAuthorizedEngine(engine), types.BytesToHash([]byte{1}))
+
+		auth, err := ReadEngineAuthorization(st, engine)
+		require.NoError(t, err)
+		assert.True(t, auth.RegistryDeployed)
+		assert.True(t, auth.Authorized)
+
+		// bootstrap EOA is not authorized once the registry is live
+		auth, err = ReadEngineAuthorization(st, bootstrap)
+		require.NoError(t, err)
+		assert.False(t, auth.Authorized)
+
+		// paused registry denies everyone
+		st.setStorage(reg, chain.EngineRegistrySlotKeyPaused(), types.BytesToHash([]byte{1}))
+
+		auth, err = ReadEngineAuthorization(st, engine)
+		require.NoError(t, err)
+		assert.False(t, auth.Authorized)
+	})
+}
+
+func TestEngineProbe(t *testing.T) {
+	reg := types.StringToAddress("0x1000")
+	bootstrap := types.StringToAddress("0x3000")
+	engine := types.StringToAddress("0x4000")
+
+	withRegistry(t, reg, bootstrap)
+
+	st := newMockRegistryState()
+	st.code[reg] = []byte{0x1}
+
+	probe := EngineProbe(func() (StateReader, error) { return st, nil }, engine)
+
+	require.ErrorIs(t, probe(), ErrEngineNotAuthorized)
+
+	st.setStorage(reg, chain.EngineRegistrySlotKeyAuthorizedEngine(engine), types.BytesToHash([]byte{1}))
+	require.NoError(t, probe())
+
+	st.setStorage(reg, chain.EngineRegistrySlotKeyPaused(), types.BytesToHash([]byte{1}))
+	require.ErrorIs(t, probe(), ErrEngineNotAuthorized)
+}
@@ -5,18 +5,27 @@ package xgr
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"os"
 	"strings"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/xgr-network/xgr-node/contracts"
+	"github.com/xgr-network/xgr-node/helper/hex"
+	"github.com/xgr-network/xgr-node/types"
 )
 
 var errEmbeddedUnavailable = fmt.Errorf("engine.mode=embedded requires a build with -tags engine_embedded")
 var errEngineDisabled = fmt.Errorf("xgr engine is disabled (stub mode)")
+var errStateUnavailable = fmt.Errorf("xgr state access is not configured")
+var errTooManyOwners = fmt.Errorf("too many owners, at most %d per request", maxNextPidOwners)
+
+// maxNextPidOwners caps the owners of one GetNextProcessIds request
+const maxNextPidOwners = 256
 
 type XGR struct {
-	logger hclog.Logger
+	logger      hclog.Logger
+	latestState LatestStateFn
 }
 
 type Config struct {
@@ -37,6 +46,12 @@ func LoadEngineConfigFlex(any) (string, []byte, error) {
 
 func EmbeddedAvailable() bool { return false }
 
+// BindState gives the stub endpoint read access to the latest chain state.
+// It is not a method, so it is not exposed as an RPC.
+func BindState(x *XGR, latestState LatestStateFn) {
+	x.latestState = latestState
+}
+
 type publicSaleResp struct {
 	PublicSale string `json:"publicSale"`
 }
@@ -56,6 +71,10 @@ type getNextPidRes struct {
 	Next  string `json:"next"`
 }
 
+type getNextPidsReq struct {
+	Owners []string `json:"owners"`
+}
+
 func (x *XGR) GetPublicSale(context.Context) (*publicSaleResp, error) {
 	return &publicSaleResp{PublicSale: strings.ToLower(strings.TrimSpace(os.Getenv("XGR_PUBLIC_SALE")))}, nil
 }
@@ -69,8 +88,88 @@ func (x *XGR) GetCoreAddrs(context.Context) (*coreAddrsResp, error) {
 	}, nil
 }
 
+// GetNextProcessId mirrors ENGINE_GET_NEXT_PID: it reads kNext(owner) from the
+// EngineExecutePrecompile storage of the latest state and returns next>0 ? next : 1
 func (x *XGR) GetNextProcessId(req getNextPidReq) (*getNextPidRes, error) {
-	return &getNextPidRes{Owner: strings.ToLower(strings.TrimSpace(req.Owner)), Next: "0x1"}, nil
+	owner, err := normalizeOwner(req.Owner)
+	if err != nil {
+		return nil, err
+	}
+
+	st, err := x.state()
+	if err != nil {
+		return nil, err
+	}
+
+	return nextProcessId(st, owner)
+}
+
+// GetNextProcessIds is GetNextProcessId for several owners, read from the same latest state.
+// The results are in the order of the owners
+func (x *XGR) GetNextProcessIds(req getNextPidsReq) ([]*getNextPidRes, error) {
+	if len(req.Owners) > maxNextPidOwners {
+		return nil, errTooManyOwners
+	}
+
+	owners := make([]string, len(req.Owners))
+
+	for i, owner := range req.Owners {
+		normalized, err := normalizeOwner(owner)
+		if err != nil {
+			return nil, fmt.Errorf("owner %d: %w", i, err)
+		}
+
+		owners[i] = normalized
+	}
+
+	st, err := x.state()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*getNextPidRes, len(owners))
+
+	for i, owner := range owners {
+		if res[i], err = nextProcessId(st, owner); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// state returns the reader of the latest state
+func (x *XGR) state() (StateReader, error) {
+	if x.latestState == nil {
+		return nil, errStateUnavailable
+	}
+
+	return x.latestState()
+}
+
+// normalizeOwner validates the owner address and returns it trimmed and lower case
+func normalizeOwner(owner string) (string, error) {
+	owner = strings.ToLower(strings.TrimSpace(owner))
+	if err := types.IsValidAddress(owner); err != nil {
+		return "", err
+	}
+
+	return owner, nil
+}
+
+// nextProcessId reads kNext(owner) of the state, an unused owner starts at 1
+func nextProcessId(st StateReader, owner string) (*getNextPidRes, error) {
+	raw, err := st.GetStorage(contracts.EngineExecutePrecompile, contracts.EngineNextPidSlotKey(types.StringToAddress(owner)))
+	if err != nil {
+		return nil, err
+	}
+
+	next := new(big.Int).SetBytes(raw[:])
+	if next.Sign() == 0 {
+		next.SetUint64(1)
+	}
+
+	return &getNextPidRes{Owner: owner, Next: hex.EncodeBig(next)}, nil
 }
 
 // Engine-backed calls are intentionally stubbed in public builds.
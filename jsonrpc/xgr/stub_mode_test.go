@@ -0,0 +1,129 @@
+//go:build !engine_embedded
+
+package xgr
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xgr-network/xgr-node/chain"
+	"github.com/xgr-network/xgr-node/contracts"
+	"github.com/xgr-network/xgr-node/state"
+	itrie "github.com/xgr-network/xgr-node/state/immutable-trie"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+type snapshotStateReader struct {
+	snap state.Snapshot
+}
+
+func (s *snapshotStateReader) GetStorage(addr types.Address, slot types.Hash) (types.Hash, error) {
+	acc, err := s.snap.GetAccount(addr)
+	if err != nil || acc == nil {
+		return types.Hash{}, err
+	}
+
+	return s.snap.GetStorage(addr, acc.Root, slot), nil
+}
+
+func (s *snapshotStateReader) GetCode(types.Address) ([]byte, error) {
+	return nil, nil
+}
+
+func TestXGR_GetNextProcessId(t *testing.T) {
+	owner := types.StringToAddress("0x5000")
+	other := types.StringToAddress("0x6000")
+
+	snap := itrie.NewState(itrie.NewMemoryStorage()).NewSnapshot()
+	transition := state.NewTransition(nil, chain.ForksInTime{}, snap, state.NewTxn(snap))
+
+	// same write the precompile does when a new root session is opened
+	transition.SetStorage(
+		contracts.EngineExecutePrecompile,
+		contracts.EngineNextPidSlotKey(owner),
+		types.BytesToHash(big.NewInt(42).Bytes()),
+		&chain.ForksInTime{},
+	)
+
+	committed, _, err := transition.Commit()
+	require.NoError(t, err)
+
+	x := New(Config{Logger: hclog.NewNullLogger()})
+
+	_, err = x.GetNextProcessId(getNextPidReq{Owner: owner.String()})
+	assert.ErrorIs(t, err, errStateUnavailable)
+
+	BindState(x, func() (StateReader, error) {
+		return &snapshotStateReader{committed}, nil
+	})
+
+	res, err := x.GetNextProcessId(getNextPidReq{Owner: owner.String()})
+	require.NoError(t, err)
+	assert.Equal(t, "0x2a", res.Next)
+
+	// unused owners start at 1
+	res, err = x.GetNextProcessId(getNextPidReq{Owner: other.String()})
+	require.NoError(t, err)
+	assert.Equal(t, "0x1", res.Next)
+
+	_, err = x.GetNextProcessId(getNextPidReq{Owner: "0x1234"})
+	assert.Error(t, err)
+}
+
+func TestXGR_GetNextProcessIds(t *testing.T) {
+	owner := types.StringToAddress("0x5000")
+	other := types.StringToAddress("0x6000")
+
+	snap := itrie.NewState(itrie.NewMemoryStorage()).NewSnapshot()
+	transition := state.NewTransition(nil, chain.ForksInTime{}, snap, state.NewTxn(snap))
+
+	transition.SetStorage(
+		contracts.EngineExecutePrecompile,
+		contracts.EngineNextPidSlotKey(owner),
+		types.BytesToHash(big.NewInt(42).Bytes()),
+		&chain.ForksInTime{},
+	)
+
+	committed, _, err := transition.Commit()
+	require.NoError(t, err)
+
+	x := New(Config{Logger: hclog.NewNullLogger()})
+	BindState(x, func() (StateReader, error) {
+		return &snapshotStateReader{committed}, nil
+	})
+
+	// the owners are trimmed and lower cased, the results keep their order
+	res, err := x.GetNextProcessIds(getNextPidsReq{Owners: []string{
+		"  " + strings.ToUpper(other.String()) + " ",
+		" " + owner.String(),
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, []*getNextPidRes{
+		{Owner: strings.ToLower(other.String()), Next: "0x1"},
+		{Owner: strings.ToLower(owner.String()), Next: "0x2a"},
+	}, res)
+
+	res, err = x.GetNextProcessIds(getNextPidsReq{})
+	require.NoError(t, err)
+	assert.Empty(t, res)
+
+	_, err = x.GetNextProcessIds(getNextPidsReq{Owners: []string{owner.String(), "0x1234"}})
+	assert.ErrorContains(t, err, "owner 1")
+
+	owners := make([]string, maxNextPidOwners+1)
+	for i := range owners {
+		owners[i] = owner.String()
+	}
+
+	_, err = x.GetNextProcessIds(getNextPidsReq{Owners: owners})
+	assert.ErrorIs(t, err, errTooManyOwners)
+
+	res, err = x.GetNextProcessIds(getNextPidsReq{Owners: owners[:maxNextPidOwners]})
+	require.NoError(t, err)
+	assert.Len(t, res, maxNextPidOwners)
+}
@@ -0,0 +1,219 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sync"
+
+	ethgo "github.com/umbracle/ethgo"
+	ethabi "github.com/umbracle/ethgo/abi"
+
+	"github.com/xgr-network/xgr-node/contracts"
+	"github.com/xgr-network/xgr-node/contracts/engineabi"
+	"github.com/xgr-network/xgr-node/helper/common"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+// xgrSessionSubscription is the eth_subscribe type streaming the steps of an engine session
+const xgrSessionSubscription = "xgr_session"
+
+var ErrSessionIDRequired = errors.New("sessionId is required")
+
+var (
+	engineMetaEvent = ethabi.MustNewABI(engineabi.EngineMetaEventABI).Events["EngineMeta"]
+	engineMetaTopic = func() types.Hash {
+		id := engineMetaEvent.ID()
+
+		return types.BytesToHash(id[:])
+	}()
+)
+
+// SessionQuery selects the EngineMeta events of an engine session.
+// The events don't carry the user of the session, they are matched
+// on the session id and, if set, on the orchestration address.
+type SessionQuery struct {
+	SessionID     *big.Int
+	Orchestration *types.Address
+}
+
+// UnmarshalJSON decodes a json object, the session id is a decimal or hex string
+func (q *SessionQuery) UnmarshalJSON(data []byte) error {
+	var obj struct {
+		SessionID     *string        `json:"sessionId"`
+		Orchestration *types.Address `json:"orchestration"`
+	}
+
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	if obj.SessionID == nil {
+		return ErrSessionIDRequired
+	}
+
+	sessionID, err := common.ParseUint256orHex(obj.SessionID)
+	if err != nil {
+		return err
+	}
+
+	q.SessionID = sessionID
+	q.Orchestration = obj.Orchestration
+
+	return nil
+}
+
+// match returns true if the EngineMeta event belongs to the session
+func (q *SessionQuery) match(meta *engineMeta) bool {
+	if q.SessionID.Cmp(meta.sessionID) != 0 {
+		return false
+	}
+
+	return q.Orchestration == nil || *q.Orchestration == meta.orchestration
+}
+
+func decodeSessionQueryFromInterface(i interface{}) (*SessionQuery, error) {
+	// once the query is decoded as map[string]interface we cannot use unmarshal json
+	raw, err := json.Marshal(i)
+	if err != nil {
+		return nil, err
+	}
+
+	query := &SessionQuery{}
+	if err := json.Unmarshal(raw, query); err != nil {
+		return nil, err
+	}
+
+	return query, nil
+}
+
+// engineMeta holds the EngineMeta event fields a session subscription reports
+type engineMeta struct {
+	sessionID     *big.Int
+	iteration     uint64
+	orchestration types.Address
+	stepID        string
+	execResult    bool
+}
+
+// decodeEngineMeta decodes the log if it is an EngineMeta event of the engine execute precompile
+func decodeEngineMeta(log *types.Log) (*engineMeta, bool) {
+	if log.Address != contracts.EngineExecutePrecompile || len(log.Topics) == 0 || log.Topics[0] != engineMetaTopic {
+		return nil, false
+	}
+
+	vals, err := engineMetaEvent.Inputs.Decode(log.Data)
+	if err != nil {
+		return nil, false
+	}
+
+	args, ok := vals.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	meta := &engineMeta{}
+
+	if meta.sessionID, ok = args["SessionId"].(*big.Int); !ok {
+		return nil, false
+	}
+
+	if meta.iteration, ok = args["iteration"].(uint64); !ok {
+		return nil, false
+	}
+
+	orchestration, ok := args["orchestration"].(ethgo.Address)
+	if !ok {
+		return nil, false
+	}
+
+	meta.orchestration = types.Address(orchestration)
+
+	if meta.stepID, ok = args["stepId"].(string); !ok {
+		return nil, false
+	}
+
+	if meta.execResult, ok = args["execResult"].(bool); !ok {
+		return nil, false
+	}
+
+	return meta, true
+}
+
+// sessionStep is a step of an engine session pushed to the subscribers.
+// Removed is set for the steps of blocks dropped by a reorg.
+type sessionStep struct {
+	SessionID   string     `json:"sessionId"`
+	Iteration   argUint64  `json:"iteration"`
+	StepID      string     `json:"stepId"`
+	ExecResult  bool       `json:"execResult"`
+	BlockNumber argUint64  `json:"blockNumber"`
+	TxHash      types.Hash `json:"txHash"`
+	Removed     bool       `json:"removed"`
+}
+
+func toSessionStep(meta *engineMeta, header *types.Header, txHash types.Hash, removed bool) *sessionStep {
+	return &sessionStep{
+		SessionID:   meta.sessionID.String(),
+		Iteration:   argUint64(meta.iteration),
+		StepID:      meta.stepID,
+		ExecResult:  meta.execResult,
+		BlockNumber: argUint64(header.Number),
+		TxHash:      txHash,
+		Removed:     removed,
+	}
+}
+
+// sessionFilter is a filter to store the steps of an engine session
+type sessionFilter struct {
+	filterBase
+	sync.Mutex
+
+	query *SessionQuery
+	steps []*sessionStep
+}
+
+// appendStep appends new step to steps
+func (f *sessionFilter) appendStep(step *sessionStep) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.steps = append(f.steps, step)
+}
+
+// takeStepUpdates returns all saved steps in filter and sets new step slice
+func (f *sessionFilter) takeStepUpdates() []*sessionStep {
+	f.Lock()
+	defer f.Unlock()
+
+	steps := f.steps
+	f.steps = []*sessionStep{}
+
+	return steps
+}
+
+// getUpdates returns stored steps
+func (f *sessionFilter) getUpdates() (interface{}, error) {
+	return f.takeStepUpdates(), nil
+}
+
+// sendUpdates writes stored steps to web socket stream
+func (f *sessionFilter) sendUpdates() error {
+	for _, step := range f.takeStepUpdates() {
+		res, err := json.Marshal(step)
+		if err != nil {
+			return err
+		}
+
+		if err := f.writeMessageToWs(string(res)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getSubscriptionType returns the type of the event the filter is subscribed to
+func (f *sessionFilter) getSubscriptionType() subscriptionType {
+	return Blocks
+}
@@ -0,0 +1,265 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xgr-network/xgr-node/contracts"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+var (
+	orchestration1 = types.StringToAddress("0x729a")
+	orchestration2 = types.StringToAddress("0x729b")
+)
+
+// engineMetaLog returns the EngineMeta log of a session step
+func engineMetaLog(t *testing.T, sessionID int64, iteration uint64, orchestration types.Address, stepID string) *types.Log {
+	t.Helper()
+
+	data, err := engineMetaEvent.Inputs.Encode([]interface{}{
+		big.NewInt(sessionID),
+		iteration,
+		orchestration,
+		"ostc",
+		[32]byte{},
+		stepID,
+		types.ZeroAddress,
+		[32]byte{},
+		types.ZeroAddress,
+		true,
+		[]byte{},
+		[]byte{},
+		[]byte{},
+	})
+	require.NoError(t, err)
+
+	return &types.Log{
+		Address: contracts.EngineExecutePrecompile,
+		Topics:  []types.Hash{engineMetaTopic},
+		Data:    data,
+	}
+}
+
+// sessionStepMessage is an eth_subscription message of a session subscription
+type sessionStepMessage struct {
+	Params struct {
+		Subscription string       `json:"subscription"`
+		Result       *sessionStep `json:"result"`
+	} `json:"params"`
+}
+
+// newMockWsConnWithSteps returns a ws connection collecting the session steps written to it
+func newMockWsConnWithSteps(t *testing.T) (*mockWsConn, <-chan *sessionStep) {
+	t.Helper()
+
+	var (
+		lock     sync.Mutex
+		filterID string
+		stepCh   = make(chan *sessionStep, 16)
+	)
+
+	mock := &mockWsConn{
+		SetFilterIDFn: func(s string) {
+			lock.Lock()
+			defer lock.Unlock()
+
+			filterID = s
+		},
+		GetFilterIDFn: func() string {
+			lock.Lock()
+			defer lock.Unlock()
+
+			return filterID
+		},
+		WriteMessageFn: func(_ int, b []byte) error {
+			var msg sessionStepMessage
+			if err := json.Unmarshal(b, &msg); err != nil {
+				return err
+			}
+
+			stepCh <- msg.Params.Result
+
+			return nil
+		},
+	}
+
+	return mock, stepCh
+}
+
+func receiveSessionSteps(t *testing.T, stepCh <-chan *sessionStep, n int) []*sessionStep {
+	t.Helper()
+
+	steps := make([]*sessionStep, 0, n)
+
+	for len(steps) < n {
+		select {
+		case step := <-stepCh:
+			steps = append(steps, step)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("received %d of %d session steps in the predefined time slot", len(steps), n)
+		}
+	}
+
+	select {
+	case step := <-stepCh:
+		t.Fatalf("unexpected session step %+v", step)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	return steps
+}
+
+func TestSessionQuery_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	query, err := decodeSessionQueryFromInterface(map[string]interface{}{
+		"user":          "0x0000000000000000000000000000000000000001",
+		"sessionId":     "42",
+		"orchestration": orchestration1.String(),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(42), query.SessionID.Uint64())
+	assert.Equal(t, orchestration1, *query.Orchestration)
+
+	query, err = decodeSessionQueryFromInterface(map[string]interface{}{"sessionId": "0x2a"})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(42), query.SessionID.Uint64())
+	assert.Nil(t, query.Orchestration)
+
+	_, err = decodeSessionQueryFromInterface(map[string]interface{}{"user": "0x1"})
+	assert.ErrorIs(t, err, ErrSessionIDRequired)
+
+	_, err = decodeSessionQueryFromInterface(map[string]interface{}{"sessionId": "abc"})
+	assert.Error(t, err)
+}
+
+func TestFilterSessionWebsocket(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	defer m.Close()
+
+	go m.Run()
+
+	conn1, steps1 := newMockWsConnWithSteps(t)
+	conn2, steps2 := newMockWsConnWithSteps(t)
+
+	id1 := m.NewSessionFilter(&SessionQuery{SessionID: big.NewInt(1)}, conn1)
+	id2 := m.NewSessionFilter(&SessionQuery{SessionID: big.NewInt(2), Orchestration: &orchestration2}, conn2)
+
+	// we cannot call get filter changes for a websocket filter
+	_, err := m.GetFilterChanges(id1)
+	assert.Equal(t, err, ErrWSFilterDoesNotSupportGetChanges)
+
+	// the hashes have to be distinct, the mock store keeps the receipts by block hash
+	tx1, tx2, tx3 := types.StringToHash("0x11"), types.StringToHash("0x12"), types.StringToHash("0x13")
+
+	block1 := &types.Header{Number: 1, Hash: types.StringToHash("0x1")}
+	block2 := &types.Header{Number: 2, Hash: types.StringToHash("0x2")}
+	reorged2 := &types.Header{Number: 2, Hash: types.StringToHash("0x3")}
+
+	for _, header := range []*types.Header{block1, block2, reorged2} {
+		store.addHeader(header)
+	}
+
+	// the steps of both sessions land in the same blocks
+	store.emitEvent(&mockEvent{
+		NewChain: []*mockHeader{
+			{
+				header: block1,
+				receipts: []*types.Receipt{
+					{
+						TxHash: tx1,
+						Logs: []*types.Log{
+							engineMetaLog(t, 1, 0, orchestration1, "s1-start"),
+							// not an engine log
+							{Address: types.StringToAddress("0x1"), Topics: []types.Hash{engineMetaTopic}},
+						},
+					},
+					{
+						TxHash: tx2,
+						Logs: []*types.Log{
+							engineMetaLog(t, 2, 0, orchestration2, "s2-start"),
+							// session 2 of another orchestration
+							engineMetaLog(t, 2, 0, orchestration1, "other"),
+						},
+					},
+				},
+			},
+			{
+				header: block2,
+				receipts: []*types.Receipt{
+					{
+						TxHash: tx3,
+						Logs: []*types.Log{
+							engineMetaLog(t, 2, 1, orchestration2, "s2-next"),
+							engineMetaLog(t, 1, 1, orchestration1, "s1-next"),
+						},
+					},
+				},
+			},
+		},
+	})
+
+	assert.Equal(t, []*sessionStep{
+		{SessionID: "1", Iteration: 0, StepID: "s1-start", ExecResult: true, BlockNumber: 1, TxHash: tx1},
+		{SessionID: "1", Iteration: 1, StepID: "s1-next", ExecResult: true, BlockNumber: 2, TxHash: tx3},
+	}, receiveSessionSteps(t, steps1, 2))
+
+	assert.Equal(t, []*sessionStep{
+		{SessionID: "2", Iteration: 0, StepID: "s2-start", ExecResult: true, BlockNumber: 1, TxHash: tx2},
+		{SessionID: "2", Iteration: 1, StepID: "s2-next", ExecResult: true, BlockNumber: 2, TxHash: tx3},
+	}, receiveSessionSteps(t, steps2, 2))
+
+	// a reorg drops block 2, only session 1 steps again in the new block
+	store.emitEvent(&mockEvent{
+		OldChain: []*mockHeader{
+			{
+				header: block2,
+				receipts: []*types.Receipt{
+					{
+						TxHash: tx3,
+						Logs: []*types.Log{
+							engineMetaLog(t, 2, 1, orchestration2, "s2-next"),
+							engineMetaLog(t, 1, 1, orchestration1, "s1-next"),
+						},
+					},
+				},
+			},
+		},
+		NewChain: []*mockHeader{
+			{
+				header: reorged2,
+				receipts: []*types.Receipt{
+					{
+						TxHash: tx3,
+						Logs: []*types.Log{
+							engineMetaLog(t, 1, 1, orchestration1, "s1-next"),
+						},
+					},
+				},
+			},
+		},
+	})
+
+	assert.Equal(t, []*sessionStep{
+		{SessionID: "1", Iteration: 1, StepID: "s1-next", ExecResult: true, BlockNumber: 2, TxHash: tx3, Removed: true},
+		{SessionID: "1", Iteration: 1, StepID: "s1-next", ExecResult: true, BlockNumber: 2, TxHash: tx3},
+	}, receiveSessionSteps(t, steps1, 2))
+
+	assert.Equal(t, []*sessionStep{
+		{SessionID: "2", Iteration: 1, StepID: "s2-next", ExecResult: true, BlockNumber: 2, TxHash: tx3, Removed: true},
+	}, receiveSessionSteps(t, steps2, 1))
+
+	assert.True(t, m.Uninstall(id2))
+}
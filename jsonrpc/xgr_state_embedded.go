@@ -0,0 +1,47 @@
+//go:build engine_embedded
+
+package jsonrpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/xgr-network/xgr-node/internal/dbx"
+	xgrsvc "github.com/xgr-network/xgr-node/jsonrpc/xgr"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+const (
+	// defaultSessionsPageSize is the number of sessions listed if no limit is given
+	defaultSessionsPageSize = 50
+
+	// listSessionsTimeout bounds the sessions query against the engine database
+	listSessionsTimeout = 5 * time.Second
+)
+
+// FeeSplitConfig returns the fee split (fixed burn and donation) applied at the latest state
+func (x *XGRState) FeeSplitConfig() (interface{}, error) {
+	header := x.store.Header()
+
+	return xgrsvc.ReadFeeSplitConfig(&rootStateReader{x.store, header.StateRoot}, x.donationPercent)
+}
+
+// ListSessions returns a page of the engine sessions of the owner, newest first.
+// The sessions are read from the sessions table of the engine database (see dbx.SessionsSchema).
+// It is registered after the engine endpoint, so it serves xgr_listSessions in engine builds
+func (x *XGRState) ListSessions(owner types.Address, limit, offset *argUint64) (interface{}, error) {
+	pageSize, skip := defaultSessionsPageSize, 0
+
+	if limit != nil {
+		pageSize = int(*limit)
+	}
+
+	if offset != nil {
+		skip = int(*offset)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), listSessionsTimeout)
+	defer cancel()
+
+	return dbx.ListSessions(ctx, owner.String(), pageSize, skip)
+}
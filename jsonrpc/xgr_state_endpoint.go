@@ -0,0 +1,300 @@
+package jsonrpc
+
+import (
+	"errors"
+
+	"github.com/xgr-network/xgr-node/blockchain"
+	"github.com/xgr-network/xgr-node/chain"
+	"github.com/xgr-network/xgr-node/engineadapter/breaker"
+	xgrsvc "github.com/xgr-network/xgr-node/jsonrpc/xgr"
+	"github.com/xgr-network/xgr-node/state/runtime/precompiled"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+// xgrStateStore interface provides access to the methods needed by the state-backed xgr endpoint
+type xgrStateStore interface {
+	blockGetter
+	GetStorage(root types.Hash, addr types.Address, slot types.Hash) ([]byte, error)
+	GetCode(root types.Hash, addr types.Address) ([]byte, error)
+
+	// GetAddressBloom returns the address activity bloom of the block
+	GetAddressBloom(hash types.Hash) (types.AddressBloom, bool)
+
+	// GetBlockEconomics returns the fee split aggregates of a block
+	GetBlockEconomics(hash types.Hash) (*types.BlockEconomics, bool)
+
+	// ShadowDivergences returns the divergences recorded in shadow-fork mode
+	ShadowDivergences(from uint64) ([]*blockchain.Divergence, bool)
+
+	// GetForksInTime returns the active forks at the given block height
+	GetForksInTime(blockNumber uint64) chain.ForksInTime
+}
+
+var (
+	ErrShadowForkDisabled        = errors.New("shadow fork mode is not enabled")
+	ErrBlockEconomicsUnavailable = errors.New("block economics are not available for this block")
+)
+
+// XGRState is the state-backed part of the xgr jsonrpc namespace.
+// It only touches chain state, so it is registered in stub and embedded engine builds.
+type XGRState struct {
+	store           xgrStateStore
+	blockRangeLimit uint64
+	donationPercent uint64
+}
+
+// addressActivityResult holds the candidate blocks for an address activity query
+type addressActivityResult struct {
+	Address    types.Address `json:"address"`
+	FromBlock  argUint64     `json:"fromBlock"`
+	ToBlock    argUint64     `json:"toBlock"`
+	Candidates []argUint64   `json:"candidates"`
+}
+
+// GetEngineRegistryConfig returns the EngineRegistry configuration at the given block
+func (x *XGRState) GetEngineRegistryConfig(filter BlockNumberOrHash) (interface{}, error) {
+	header, err := GetHeaderFromBlockNumberOrHash(filter, x.store)
+	if err != nil {
+		return nil, err
+	}
+
+	return xgrsvc.ReadEngineRegistryConfig(&rootStateReader{x.store, header.StateRoot}, x.donationPercent)
+}
+
+// IsEngineAuthorized returns whether the engine EOA is authorized in the EngineRegistry at the given block
+func (x *XGRState) IsEngineAuthorized(engine types.Address, filter BlockNumberOrHash) (interface{}, error) {
+	header, err := GetHeaderFromBlockNumberOrHash(filter, x.store)
+	if err != nil {
+		return nil, err
+	}
+
+	return xgrsvc.ReadEngineAuthorization(&rootStateReader{x.store, header.StateRoot}, engine)
+}
+
+// engineStatusResult reports the submission state of the embedded engine
+type engineStatusResult struct {
+	// BreakerEnabled is false if no engine circuit breaker is running, as in stub builds
+	BreakerEnabled bool            `json:"breakerEnabled"`
+	Breaker        *breaker.Status `json:"breaker,omitempty"`
+}
+
+// GetEngineStatus returns the state of the engine circuit breaker that guards
+// ENGINE_EXECUTE submissions of the embedded engine
+func (x *XGRState) GetEngineStatus() (interface{}, error) {
+	b := breaker.Active()
+	if b == nil {
+		return &engineStatusResult{}, nil
+	}
+
+	return &engineStatusResult{
+		BreakerEnabled: true,
+		Breaker:        b.Status(),
+	}, nil
+}
+
+// DecodeCalldata decodes calldata against the known system ABIs (engine precompile, address lists,
+// staking, registry and reward pool contracts). Calldata with an unknown selector is reported as not found.
+func (x *XGRState) DecodeCalldata(data argBytes) (interface{}, error) {
+	return xgrsvc.DecodeCalldata(data)
+}
+
+// engineGasEstimateResult is the gas breakdown of an ENGINE_EXECUTE call
+type engineGasEstimateResult struct {
+	PrecompileGasUnits argUint64 `json:"precompileGasUnits"`
+	EvmTxUnits         argUint64 `json:"evmTxUnits"`
+	TotalTxUnits       argUint64 `json:"totalTxUnits"`
+}
+
+// EstimateEngineGas returns the deterministic gas breakdown of ENGINE_EXECUTE calldata,
+// as charged by the engine execute precompile at the forks of the latest block and billed for the engine tx.
+// Calldata which is no ENGINE_EXECUTE call or doesn't decode is rejected as invalid params.
+func (x *XGRState) EstimateEngineGas(data argBytes) (interface{}, error) {
+	forks := x.store.GetForksInTime(x.store.Header().Number)
+
+	estimate, err := precompiled.EstimateEngineExecuteGas(data, &forks)
+	if err != nil {
+		return nil, err
+	}
+
+	return &engineGasEstimateResult{
+		PrecompileGasUnits: argUint64(estimate.PrecompileGasUnits),
+		EvmTxUnits:         argUint64(estimate.EvmTxUnits),
+		TotalTxUnits:       argUint64(estimate.TotalTxUnits),
+	}, nil
+}
+
+// GetAddressActivity returns the blocks in the given range whose address activity bloom
+// matches the address. The result may contain false positives and must be confirmed client-side.
+// Blocks without a stored bloom are always reported as candidates.
+func (x *XGRState) GetAddressActivity(
+	address types.Address,
+	fromBlock BlockNumber,
+	toBlock BlockNumber,
+) (interface{}, error) {
+	from, err := GetNumericBlockNumber(fromBlock, x.store)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := GetNumericBlockNumber(toBlock, x.store)
+	if err != nil {
+		return nil, err
+	}
+
+	if to < from {
+		return nil, ErrIncorrectBlockRange
+	}
+
+	// if not disabled, avoid handling large block ranges
+	if x.blockRangeLimit != 0 && to-from > x.blockRangeLimit {
+		return nil, ErrBlockRangeTooHigh
+	}
+
+	res := &addressActivityResult{
+		Address:    address,
+		FromBlock:  argUint64(from),
+		ToBlock:    argUint64(to),
+		Candidates: []argUint64{},
+	}
+
+	for i := from; i <= to; i++ {
+		header, ok := x.store.GetHeaderByNumber(i)
+		if !ok {
+			break
+		}
+
+		if bloom, ok := x.store.GetAddressBloom(header.Hash); ok && !bloom.Test(address) {
+			continue
+		}
+
+		res.Candidates = append(res.Candidates, argUint64(i))
+	}
+
+	return res, nil
+}
+
+// blockEconomicsResult is the fee split of all transactions in a block
+type blockEconomicsResult struct {
+	Number argUint64  `json:"number"`
+	Hash   types.Hash `json:"hash"`
+	*blockEconomics
+}
+
+// GetBlockEconomics returns the burned, donated and validator fees and the number of
+// engine transactions of the given block
+func (x *XGRState) GetBlockEconomics(filter BlockNumberOrHash) (interface{}, error) {
+	header, err := GetHeaderFromBlockNumberOrHash(filter, x.store)
+	if err != nil {
+		return nil, err
+	}
+
+	econ, ok := x.store.GetBlockEconomics(header.Hash)
+	if !ok {
+		return nil, ErrBlockEconomicsUnavailable
+	}
+
+	return &blockEconomicsResult{
+		Number:         argUint64(header.Number),
+		Hash:           header.Hash,
+		blockEconomics: toBlockEconomics(econ),
+	}, nil
+}
+
+// shadowDivergence is a block whose local execution diverged from the followed network
+type shadowDivergence struct {
+	Number               argUint64            `json:"number"`
+	Hash                 types.Hash           `json:"hash"`
+	ExpectedRoot         types.Hash           `json:"expectedRoot"`
+	LocalRoot            types.Hash           `json:"localRoot"`
+	ExpectedGasUsed      argUint64            `json:"expectedGasUsed"`
+	LocalGasUsed         argUint64            `json:"localGasUsed"`
+	ExpectedReceiptsRoot types.Hash           `json:"expectedReceiptsRoot"`
+	LocalReceiptsRoot    types.Hash           `json:"localReceiptsRoot"`
+	Accounts             []*shadowAccountDiff `json:"accounts"`
+}
+
+// shadowAccountDiff is an account written by a diverged block
+type shadowAccountDiff struct {
+	Address     types.Address `json:"address"`
+	PreNonce    argUint64     `json:"preNonce"`
+	PostNonce   argUint64     `json:"postNonce"`
+	PreBalance  *argBig       `json:"preBalance"`
+	PostBalance *argBig       `json:"postBalance"`
+	CodeChanged bool          `json:"codeChanged"`
+	Deleted     bool          `json:"deleted"`
+	Storage     []types.Hash  `json:"storage"`
+}
+
+// GetShadowDivergences returns the blocks, starting at the given block, whose local execution
+// result didn't match the followed network. It is only available in shadow-fork mode.
+func (x *XGRState) GetShadowDivergences(fromBlock BlockNumber) (interface{}, error) {
+	from, err := GetNumericBlockNumber(fromBlock, x.store)
+	if err != nil {
+		return nil, err
+	}
+
+	divergences, ok := x.store.ShadowDivergences(from)
+	if !ok {
+		return nil, ErrShadowForkDisabled
+	}
+
+	res := make([]*shadowDivergence, 0, len(divergences))
+
+	for _, d := range divergences {
+		accounts := make([]*shadowAccountDiff, 0, len(d.Accounts))
+		for _, a := range d.Accounts {
+			accounts = append(accounts, &shadowAccountDiff{
+				Address:     a.Address,
+				PreNonce:    argUint64(a.PreNonce),
+				PostNonce:   argUint64(a.PostNonce),
+				PreBalance:  argBigPtr(a.PreBalance),
+				PostBalance: argBigPtr(a.PostBalance),
+				CodeChanged: a.CodeChanged,
+				Deleted:     a.Deleted,
+				Storage:     a.Storage,
+			})
+		}
+
+		res = append(res, &shadowDivergence{
+			Number:               argUint64(d.Number),
+			Hash:                 d.Hash,
+			ExpectedRoot:         d.ExpectedRoot,
+			LocalRoot:            d.LocalRoot,
+			ExpectedGasUsed:      argUint64(d.ExpectedGasUsed),
+			LocalGasUsed:         argUint64(d.LocalGasUsed),
+			ExpectedReceiptsRoot: d.ExpectedReceiptsRoot,
+			LocalReceiptsRoot:    d.LocalReceiptsRoot,
+			Accounts:             accounts,
+		})
+	}
+
+	return res, nil
+}
+
+// rootStateReader binds a state store to a single state root
+type rootStateReader struct {
+	store xgrStateStore
+	root  types.Hash
+}
+
+func (r *rootStateReader) GetStorage(addr types.Address, slot types.Hash) (types.Hash, error) {
+	res, err := r.store.GetStorage(r.root, addr, slot)
+	if err != nil {
+		if errors.Is(err, ErrStateNotFound) {
+			return types.Hash{}, nil
+		}
+
+		return types.Hash{}, err
+	}
+
+	return types.BytesToHash(res), nil
+}
+
+func (r *rootStateReader) GetCode(addr types.Address) ([]byte, error) {
+	code, err := r.store.GetCode(r.root, addr)
+	if errors.Is(err, ErrStateNotFound) {
+		return nil, nil
+	}
+
+	return code, err
+}
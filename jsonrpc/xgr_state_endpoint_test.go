@@ -0,0 +1,202 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ethabi "github.com/umbracle/ethgo/abi"
+
+	"github.com/xgr-network/xgr-node/chain"
+	"github.com/xgr-network/xgr-node/contracts/engineabi"
+	xgrsvc "github.com/xgr-network/xgr-node/jsonrpc/xgr"
+	"github.com/xgr-network/xgr-node/state/runtime/precompiled"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+type mockXGRStateStore struct {
+	*mockStore
+}
+
+func (m *mockXGRStateStore) GetStorage(types.Hash, types.Address, types.Hash) ([]byte, error) {
+	return nil, ErrStateNotFound
+}
+
+func (m *mockXGRStateStore) GetCode(types.Hash, types.Address) ([]byte, error) {
+	return nil, ErrStateNotFound
+}
+
+func (m *mockXGRStateStore) GetForksInTime(uint64) chain.ForksInTime {
+	return chain.AllForksEnabled.At(0)
+}
+
+func TestXGRStateEndpoint_RegistryMissing(t *testing.T) {
+	prevReg := chain.EngineRegistryAddress
+	chain.EngineRegistryAddress = types.StringToAddress("0x1000")
+
+	t.Cleanup(func() {
+		chain.EngineRegistryAddress = prevReg
+	})
+
+	dispatcher := newTestDispatcher(t,
+		hclog.NewNullLogger(),
+		&mockXGRStateStore{newMockStore()},
+		&dispatcherParams{
+			jsonRPCBatchLengthLimit: 20,
+			blockRangeLimit:         1000,
+			donationPercent:         7,
+		},
+	)
+
+	// the engine part of the namespace is still served next to the state-backed methods
+	_, ok := dispatcher.serviceMap["xgr"].funcMap["getCoreAddrs"]
+	assert.True(t, ok)
+
+	data, err := dispatcher.Handle([]byte(`{
+		"method": "xgr_getEngineRegistryConfig",
+		"params": ["latest"],
+		"id": 1
+	}`))
+	require.NoError(t, err)
+
+	resp := new(SuccessResponse)
+	require.NoError(t, json.Unmarshal(data, resp))
+	require.Nil(t, resp.Error)
+
+	var cfg xgrsvc.EngineRegistryConfig
+
+	require.NoError(t, json.Unmarshal(resp.Result, &cfg))
+	assert.False(t, cfg.RegistryDeployed)
+	assert.Equal(t, uint64(7), cfg.DonationPercent)
+	assert.Equal(t, chain.DefaultDonationAddress.String(), cfg.DonationAddress)
+
+	data, err = dispatcher.Handle([]byte(`{
+		"method": "xgr_isEngineAuthorized",
+		"params": ["0x0000000000000000000000000000000000004000"],
+		"id": 2
+	}`))
+	require.NoError(t, err)
+
+	resp = new(SuccessResponse)
+	require.NoError(t, json.Unmarshal(data, resp))
+	require.Nil(t, resp.Error)
+
+	var auth xgrsvc.EngineAuthorization
+
+	require.NoError(t, json.Unmarshal(resp.Result, &auth))
+	assert.False(t, auth.RegistryDeployed)
+	assert.False(t, auth.Authorized)
+}
+
+type mockEconomicsStore struct {
+	*mockXGRStateStore
+	economics map[types.Hash]*types.BlockEconomics
+}
+
+func (m *mockEconomicsStore) GetBlockEconomics(hash types.Hash) (*types.BlockEconomics, bool) {
+	econ, ok := m.economics[hash]
+
+	return econ, ok
+}
+
+func TestXGRStateEndpoint_GetBlockEconomics(t *testing.T) {
+	store := &mockEconomicsStore{
+		mockXGRStateStore: &mockXGRStateStore{newMockStore()},
+		economics:         map[types.Hash]*types.BlockEconomics{},
+	}
+
+	x := &XGRState{store: store}
+	latest := LatestBlockNumber
+
+	_, err := x.GetBlockEconomics(BlockNumberOrHash{BlockNumber: &latest})
+	assert.ErrorIs(t, err, ErrBlockEconomicsUnavailable)
+
+	store.economics[store.header.Hash] = &types.BlockEconomics{
+		TotalBurned:        big.NewInt(3000),
+		TotalDonated:       big.NewInt(25),
+		TotalValidatorFees: big.NewInt(500),
+		EngineTxCount:      2,
+	}
+
+	res, err := x.GetBlockEconomics(BlockNumberOrHash{BlockNumber: &latest})
+	require.NoError(t, err)
+
+	data, err := json.Marshal(res)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"number": "0x0",
+		"hash": "`+store.header.Hash.String()+`",
+		"totalBurnedWei": "0xbb8",
+		"totalDonatedWei": "0x19",
+		"totalValidatorFeesWei": "0x1f4",
+		"engineTxCount": "0x2"
+	}`, string(data))
+}
+
+func TestXGRStateEndpoint_EstimateEngineGas(t *testing.T) {
+	x := &XGRState{store: &mockXGRStateStore{newMockStore()}}
+
+	input, err := ethabi.MustNewABI(engineabi.ExecuteABI).GetMethod("ENGINE_EXECUTE").Encode(map[string]interface{}{
+		"grant": map[string]interface{}{
+			"from":        types.StringToAddress("0x1"),
+			"engine":      types.StringToAddress("0xe"),
+			"xrc729":      types.ZeroAddress,
+			"ostcId":      "ostc-1",
+			"ostcHash":    [32]byte{0x1},
+			"processId":   big.NewInt(1),
+			"maxTotalGas": big.NewInt(1_000_000),
+			"expiry":      big.NewInt(0),
+			"sessionId":   big.NewInt(7),
+			"chainId":     big.NewInt(0),
+		},
+		"call": map[string]interface{}{
+			"to":                 types.StringToAddress("0x2"),
+			"data":               []byte{0xa9, 0x05, 0x9c, 0xbb, 0x0, 0x1},
+			"valueWei":           big.NewInt(0),
+			"gasLimit":           uint64(50_000),
+			"validationGas":      uint64(12_000),
+			"maxFeePerGas":       big.NewInt(1),
+			"deadline":           uint64(0),
+			"grantFeeSeconds":    uint64(3600),
+			"grantFeePerYearWei": big.NewInt(31_536_000),
+		},
+		"meta": map[string]interface{}{
+			"iteration":     uint64(2),
+			"stepId":        "step-1",
+			"ruleContract":  types.ZeroAddress,
+			"ruleHash":      [32]byte{},
+			"payload":       []byte(`{"a":1}`),
+			"apiSaves":      []byte{},
+			"contractSaves": []byte{},
+			"extras":        []byte{0x1, 0x2},
+		},
+	})
+	require.NoError(t, err)
+
+	forks := chain.AllForksEnabled.At(0)
+
+	expected, err := precompiled.EstimateEngineExecuteGas(input, &forks)
+	require.NoError(t, err)
+
+	res, err := x.EstimateEngineGas(input)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(res)
+	require.NoError(t, err)
+	assert.JSONEq(t, fmt.Sprintf(`{
+		"precompileGasUnits": "0x%x",
+		"evmTxUnits": "0x%x",
+		"totalTxUnits": "0x%x"
+	}`, expected.PrecompileGasUnits, expected.EvmTxUnits, expected.TotalTxUnits), string(data))
+	assert.Equal(t, expected.EvmTxUnits+12_000, expected.TotalTxUnits)
+
+	_, err = x.EstimateEngineGas(input[:4+64])
+	assert.ErrorIs(t, err, precompiled.ErrMalformedEngineExecute)
+
+	_, err = x.EstimateEngineGas([]byte{0x1, 0x2, 0x3, 0x4})
+	assert.ErrorIs(t, err, precompiled.ErrNotEngineExecute)
+}
@@ -0,0 +1,12 @@
+//go:build !engine_embedded
+
+package jsonrpc
+
+import "errors"
+
+var ErrFeeSplitConfigUnavailable = errors.New("xgr_feeSplitConfig requires a build with -tags engine_embedded")
+
+// FeeSplitConfig is only served by engine builds
+func (x *XGRState) FeeSplitConfig() (interface{}, error) {
+	return nil, ErrFeeSplitConfigUnavailable
+}
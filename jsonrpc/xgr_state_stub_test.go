@@ -0,0 +1,37 @@
+//go:build !engine_embedded
+
+package jsonrpc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestXGRStateEndpoint_FeeSplitConfigUnavailable(t *testing.T) {
+	t.Parallel()
+
+	dispatcher := newTestDispatcher(t,
+		hclog.NewNullLogger(),
+		&mockXGRStateStore{newMockStore()},
+		&dispatcherParams{
+			jsonRPCBatchLengthLimit: 20,
+			blockRangeLimit:         1000,
+		},
+	)
+
+	data, err := dispatcher.Handle([]byte(`{
+		"method": "xgr_feeSplitConfig",
+		"params": [],
+		"id": 1
+	}`))
+	require.NoError(t, err)
+
+	resp := new(ErrorResponse)
+	require.NoError(t, json.Unmarshal(data, resp))
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, ErrFeeSplitConfigUnavailable.Error(), resp.Error.Message)
+}
@@ -0,0 +1,226 @@
+// licenses-gen writes the third-party license manifest embedded into the xgrchain binary.
+// It lists the modules of the build graph of the main package with their license type and text,
+// and fails if the license of a module is unknown and the module is not in the allowlist.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/xgr-network/xgr-node/licenses"
+)
+
+const (
+	manifestFile  = "third_party_licenses.json"
+	allowlistFile = "third_party_allowlist.json"
+
+	unknownLicense = "Unknown"
+)
+
+// module is a module of the build graph
+type module struct {
+	Path    string
+	Version string
+	Dir     string
+}
+
+// licenseSignatures classify a license text by the phrases it contains, in order
+var licenseSignatures = []struct {
+	license string
+	phrases []string
+}{
+	{"Apache-2.0", []string{"apache license", "version 2.0"}},
+	{"MPL-2.0", []string{"mozilla public license", "2.0"}},
+	{"LGPL-3.0", []string{"gnu lesser general public license", "version 3"}},
+	{"ISC", []string{"permission to use, copy, modify, and", "distribute this software for any purpose with or without fee"}},
+	{"MIT", []string{"permission is hereby granted, free of charge"}},
+	{"BSD-3-Clause", []string{"redistribution and use in source and binary forms", "neither the name"}},
+	{"BSD-3-Clause", []string{"redistribution and use in source and binary forms", "names of its contributors"}},
+	{"BSD-2-Clause", []string{"redistribution and use in source and binary forms"}},
+	{"CC0-1.0", []string{"creative commons", "cc0"}},
+	{"Unlicense", []string{"this is free and unencumbered software released into the public domain"}},
+}
+
+func main() {
+	_, filename, _, _ := runtime.Caller(0) //nolint: dogsled
+	licensesPath := path.Join(path.Dir(filename), "..")
+	rootPath := path.Join(licensesPath, "..")
+
+	allowlist := map[string]string{}
+
+	raw, err := os.ReadFile(path.Join(licensesPath, allowlistFile))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := json.Unmarshal(raw, &allowlist); err != nil {
+		log.Fatalf("failed to parse %s: %v", allowlistFile, err)
+	}
+
+	modules, err := buildModules(rootPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	manifest := make([]licenses.ThirdPartyLicense, 0, len(modules))
+	unknown := []string{}
+
+	for _, m := range modules {
+		text, err := readLicenseText(m.Dir)
+		if err != nil {
+			log.Fatalf("failed to read the license of %s: %v", m.Path, err)
+		}
+
+		licenseType := classify(text)
+		if allowed, ok := allowlist[m.Path]; ok && licenseType == unknownLicense {
+			licenseType = allowed
+		}
+
+		if licenseType == unknownLicense {
+			unknown = append(unknown, m.Path)
+
+			continue
+		}
+
+		manifest = append(manifest, licenses.ThirdPartyLicense{
+			Module:  m.Path,
+			Version: m.Version,
+			Type:    licenseType,
+			Text:    text,
+		})
+	}
+
+	if len(unknown) > 0 {
+		log.Fatalf("unknown license of the modules (add them to %s after a review): %s",
+			allowlistFile, strings.Join(unknown, ", "))
+	}
+
+	out, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(path.Join(licensesPath, manifestFile), append(out, '\n'), 0600); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("%d third-party licenses written to %s\n", len(manifest), manifestFile)
+}
+
+// buildModules returns the third-party modules of the build graph of the main package, ordered by path.
+// Modules replaced by a local directory are part of this repository and left out.
+func buildModules(rootPath string) ([]module, error) {
+	cmd := exec.Command("go", "list", "-deps", "-f",
+		"{{with .Module}}{{if not .Main}}{{.Path}}\t{{.Version}}\t{{.Dir}}"+
+			"{{with .Replace}}\t{{.Path}}\t{{.Version}}{{end}}{{end}}{{end}}",
+		".")
+	cmd.Dir = rootPath
+	cmd.Stderr = os.Stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("go list failed: %w", err)
+	}
+
+	seen := map[string]struct{}{}
+	modules := []module{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), "\t")
+		if len(fields) < 3 {
+			continue
+		}
+
+		m := module{Path: fields[0], Version: fields[1], Dir: fields[2]}
+
+		if len(fields) == 5 {
+			if fields[4] == "" {
+				// local replacement
+				continue
+			}
+
+			m.Version = fields[4]
+		}
+
+		if _, ok := seen[m.Path]; ok {
+			continue
+		}
+
+		seen[m.Path] = struct{}{}
+		modules = append(modules, m)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(modules, func(i, j int) bool {
+		return modules[i].Path < modules[j].Path
+	})
+
+	return modules, nil
+}
+
+// readLicenseText returns the text of the license file in the module root, or an empty text
+func readLicenseText(dir string) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+
+		name := strings.ToUpper(e.Name())
+		if strings.HasPrefix(name, "LICENSE") || strings.HasPrefix(name, "LICENCE") ||
+			strings.HasPrefix(name, "COPYING") {
+			text, err := os.ReadFile(filepath.Join(dir, e.Name()))
+			if err != nil {
+				return "", err
+			}
+
+			return string(text), nil
+		}
+	}
+
+	return "", nil
+}
+
+// classify returns the SPDX identifier of the license text, or unknownLicense
+func classify(text string) string {
+	normalized := strings.ToLower(strings.Join(strings.Fields(text), " "))
+	if normalized == "" {
+		return unknownLicense
+	}
+
+	for _, sig := range licenseSignatures {
+		matches := true
+
+		for _, phrase := range sig.phrases {
+			if !strings.Contains(normalized, phrase) {
+				matches = false
+
+				break
+			}
+		}
+
+		if matches {
+			return sig.license
+		}
+	}
+
+	return unknownLicense
+}
@@ -13,6 +13,14 @@ type DepLicense struct {
 	Path    string  `json:"path"`
 }
 
+// ThirdPartyLicense is the license of a module bundled into the binary
+type ThirdPartyLicense struct {
+	Module  string `json:"module"`
+	Version string `json:"version"`
+	Type    string `json:"type"`
+	Text    string `json:"text"`
+}
+
 var (
 	// XGRChain License
 	License string
@@ -21,12 +29,22 @@ var (
 	//go:embed bsd_licenses.json
 	bsdLicensesJSON string
 	bsdLicenses     []DepLicense
+
+	// Third-party license manifest, generated at build time
+	thirdPartyJSON     string
+	thirdPartyLicenses []ThirdPartyLicense
 )
 
 func SetLicense(license string) {
 	License = license
 }
 
+// SetThirdParty sets the third-party license manifest (JSON list of ThirdPartyLicense)
+func SetThirdParty(manifest string) {
+	thirdPartyJSON = manifest
+	thirdPartyLicenses = nil
+}
+
 func GetBSDLicenses() ([]DepLicense, error) {
 	if bsdLicenses == nil {
 		if err := json.Unmarshal([]byte(bsdLicensesJSON), &bsdLicenses); err != nil {
@@ -36,3 +54,30 @@ func GetBSDLicenses() ([]DepLicense, error) {
 
 	return bsdLicenses, nil
 }
+
+// GetThirdPartyLicenses returns the licenses of the third-party modules, ordered by module
+func GetThirdPartyLicenses() ([]ThirdPartyLicense, error) {
+	if thirdPartyLicenses == nil && thirdPartyJSON != "" {
+		if err := json.Unmarshal([]byte(thirdPartyJSON), &thirdPartyLicenses); err != nil {
+			return nil, err
+		}
+	}
+
+	return thirdPartyLicenses, nil
+}
+
+// GetThirdPartyLicense returns the license of a third-party module
+func GetThirdPartyLicense(module string) (*ThirdPartyLicense, bool, error) {
+	all, err := GetThirdPartyLicenses()
+	if err != nil {
+		return nil, false, err
+	}
+
+	for i := range all {
+		if all[i].Module == module {
+			return &all[i], true, nil
+		}
+	}
+
+	return nil, false, nil
+}
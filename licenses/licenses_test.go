@@ -0,0 +1,36 @@
+package licenses
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThirdPartyManifest(t *testing.T) {
+	manifest, err := os.ReadFile("third_party_licenses.json")
+	require.NoError(t, err)
+
+	SetThirdParty(string(manifest))
+
+	all, err := GetThirdPartyLicenses()
+	require.NoError(t, err)
+	require.NotEmpty(t, all)
+
+	for _, l := range all {
+		assert.NotEmpty(t, l.Module)
+		assert.NotEmpty(t, l.Type)
+		assert.NotEqual(t, "Unknown", l.Type, l.Module)
+	}
+
+	hclog, ok, err := GetThirdPartyLicense("github.com/hashicorp/go-hclog")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "MIT", hclog.Type)
+	assert.NotEmpty(t, hclog.Text)
+
+	_, ok, err = GetThirdPartyLicense("github.com/example/missing")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -9,9 +9,13 @@ import (
 var (
 	//go:embed LICENSE
 	license string
+
+	//go:embed licenses/third_party_licenses.json
+	thirdPartyLicenses string
 )
 
 func main() {
 	licenses.SetLicense(license)
+	licenses.SetThirdParty(thirdPartyLicenses)
 	root.NewRootCommand().Execute()
 }
@@ -20,6 +20,7 @@ type Config struct {
 	MaxOutboundPeers int64                  // the maximum number of outbound peer connections
 	Chain            *chain.Chain           // the reference to the chain configuration
 	SecretsManager   secrets.SecretsManager // the secrets manager used for key storage
+	StaticPeers      []string               // the peers the node keeps connected to
 }
 
 func DefaultConfig() *Config {
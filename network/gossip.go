@@ -3,7 +3,9 @@ package network
 import (
 	"context"
 	"errors"
+	"fmt"
 	"reflect"
+	"runtime/debug"
 	"sync"
 	"sync/atomic"
 
@@ -29,6 +31,9 @@ type Topic struct {
 	closeCh   chan struct{}
 	closed    atomic.Bool
 	waitGroup sync.WaitGroup
+
+	// penalize is called with the sender of a message the handler panicked on
+	penalize func(from peer.ID, reason string)
 }
 
 func (t *Topic) createObj() proto.Message {
@@ -106,20 +111,37 @@ func (t *Topic) readLoop(sub *pubsub.Subscription, handler func(obj interface{},
 			continue
 		}
 
-		go func() {
-			obj := t.createObj()
-			if err := proto.Unmarshal(msg.Data, obj); err != nil {
-				t.logger.Error("failed to unmarshal topic", "err", err)
-				metrics.IncrCounter([]string{networkMetrics, "bad_messages"}, float32(1))
+		go t.handleMessage(msg.Data, msg.GetFrom(), handler)
+	}
+}
+
+// handleMessage decodes the message and passes it to the handler. A panic while decoding
+// or handling the message is recovered and the sender is penalized, so a malformed message
+// from a peer cannot crash the node.
+func (t *Topic) handleMessage(data []byte, from peer.ID, handler func(obj interface{}, from peer.ID)) {
+	defer func() {
+		if r := recover(); r != nil {
+			t.logger.Error("recovered from a panic while handling topic message",
+				"from", from, "panic", r, "stack", string(debug.Stack()))
+			metrics.IncrCounter([]string{networkMetrics, "bad_messages"}, float32(1))
 
-				return
+			if t.penalize != nil {
+				t.penalize(from, fmt.Sprintf("panic while handling topic message: %v", r))
 			}
+		}
+	}()
 
-			metrics.SetGauge([]string{networkMetrics, "ingress_bytes"}, float32(len(msg.Data)))
+	obj := t.createObj()
+	if err := proto.Unmarshal(data, obj); err != nil {
+		t.logger.Error("failed to unmarshal topic", "err", err)
+		metrics.IncrCounter([]string{networkMetrics, "bad_messages"}, float32(1))
 
-			handler(obj, msg.GetFrom())
-		}()
+		return
 	}
+
+	metrics.SetGauge([]string{networkMetrics, "ingress_bytes"}, float32(len(data)))
+
+	handler(obj, from)
 }
 
 func (s *Server) NewTopic(protoID string, obj proto.Message) (*Topic, error) {
@@ -129,10 +151,11 @@ func (s *Server) NewTopic(protoID string, obj proto.Message) (*Topic, error) {
 	}
 
 	tt := &Topic{
-		logger:  s.logger.Named(protoID),
-		topic:   topic,
-		typ:     reflect.TypeOf(obj).Elem(),
-		closeCh: make(chan struct{}),
+		logger:   s.logger.Named(protoID),
+		topic:    topic,
+		typ:      reflect.TypeOf(obj).Elem(),
+		closeCh:  make(chan struct{}),
+		penalize: s.PenalizePeer,
 	}
 	tt.closed.Store(false)
 
@@ -4,12 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"testing"
 	"time"
 
+	"github.com/hashicorp/go-hclog"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/stretchr/testify/require"
 	testproto "github.com/xgr-network/xgr-node/network/proto"
+	"google.golang.org/protobuf/proto"
 )
 
 func NumSubscribers(srv *Server, topic string) int {
@@ -108,3 +111,43 @@ func Test_RepeatedClose(t *testing.T) {
 	topic.Close()
 	topic.Close()
 }
+
+func TestTopic_HandleMessage_RecoversPanic(t *testing.T) {
+	penalized := map[peer.ID]int{}
+
+	topic := &Topic{
+		logger:  hclog.NewNullLogger(),
+		typ:     reflect.TypeOf(&testproto.GenericMessage{}).Elem(),
+		closeCh: make(chan struct{}),
+		penalize: func(from peer.ID, reason string) {
+			require.Contains(t, reason, "malformed message")
+
+			penalized[from]++
+		},
+	}
+
+	data, err := proto.Marshal(&testproto.GenericMessage{Message: "hello"})
+	require.NoError(t, err)
+
+	// the handler stands for a decoder panicking on a byzantine input
+	panickingHandler := func(obj interface{}, _ peer.ID) {
+		panic("malformed message")
+	}
+
+	var handled *testproto.GenericMessage
+
+	handler := func(obj interface{}, _ peer.ID) {
+		handled, _ = obj.(*testproto.GenericMessage)
+	}
+
+	require.NotPanics(t, func() {
+		topic.handleMessage(data, peer.ID("A"), panickingHandler)
+	})
+	require.Equal(t, map[peer.ID]int{peer.ID("A"): 1}, penalized)
+
+	// a well-behaved message is not penalized
+	topic.handleMessage(data, peer.ID("B"), handler)
+	require.NotNil(t, handled)
+	require.Equal(t, "hello", handled.Message)
+	require.Equal(t, map[peer.ID]int{peer.ID("A"): 1}, penalized)
+}
@@ -18,6 +18,7 @@ const PeerID = "peerID"
 
 var (
 	ErrInvalidChainID   = errors.New("invalid chain ID")
+	ErrInvalidChainSpec = errors.New("invalid chain spec hash")
 	ErrNoAvailableSlots = errors.New("no available Slots")
 )
 
@@ -52,6 +53,9 @@ type networkingServer interface {
 
 	// HasFreeConnectionSlot checks if there are available outbound connection slots [Thread safe]
 	HasFreeConnectionSlot(direction network.Direction) bool
+
+	// IsTrustedPeer checks if the peer is exempt from the peer limits [Thread safe]
+	IsTrustedPeer(peerID peer.ID) bool
 }
 
 // IdentityService is a networking service used to handle peer handshaking.
@@ -63,8 +67,9 @@ type IdentityService struct {
 	logger                 hclog.Logger     // The IdentityService logger
 	baseServer             networkingServer // The interface towards the base networking server
 
-	chainID int64   // The chain ID of the network
-	hostID  peer.ID // The base networking server's host peer ID
+	chainID  int64   // The chain ID of the network
+	specHash string  // The hash of the chain params, peers must run the same chain spec
+	hostID   peer.ID // The base networking server's host peer ID
 }
 
 // NewIdentityService returns a new instance of the IdentityService
@@ -72,12 +77,14 @@ func NewIdentityService(
 	server networkingServer,
 	logger hclog.Logger,
 	chainID int64,
+	specHash string,
 	hostID peer.ID,
 ) *IdentityService {
 	return &IdentityService{
 		logger:     logger.Named("identity"),
 		baseServer: server,
 		chainID:    chainID,
+		specHash:   specHash,
 		hostID:     hostID,
 	}
 }
@@ -93,7 +100,7 @@ func (i *IdentityService) GetNotifyBundle() *network.NotifyBundle {
 				return
 			}
 
-			if !i.baseServer.HasFreeConnectionSlot(conn.Stat().Direction) {
+			if !i.baseServer.HasFreeConnectionSlot(conn.Stat().Direction) && !i.baseServer.IsTrustedPeer(peerID) {
 				i.disconnectFromPeer(peerID, ErrNoAvailableSlots.Error())
 
 				return
@@ -182,6 +189,12 @@ func (i *IdentityService) handleConnected(peerID peer.ID, direction network.Dire
 		return ErrInvalidChainID
 	}
 
+	// Validate that the peers run the same chain params (e.g. the engine is enabled on both).
+	// Legacy peers don't send a chain spec hash, they are accepted to keep rolling upgrades possible
+	if resp.Genesis != "" && status.Genesis != resp.Genesis {
+		return ErrInvalidChainSpec
+	}
+
 	// If this is a NOT temporary connection, save it
 	if !resp.TemporaryDial && !status.TemporaryDial {
 		i.baseServer.AddPeer(peerID, direction)
@@ -210,6 +223,7 @@ func (i *IdentityService) constructStatus(peerID peer.ID) *proto.Status {
 			PeerID: i.hostID.String(),
 		},
 		Chain:         i.chainID,
+		Genesis:       i.specHash,
 		TemporaryDial: i.baseServer.IsTemporaryDial(peerID),
 	}
 }
@@ -122,3 +122,71 @@ func TestHandshake_Errors(t *testing.T) {
 	// Make sure no peers have been  added to the base networking server
 	assert.Len(t, peersArray, 0)
 }
+
+// TestHandshake_ChainSpecMismatch tests that peers with different chain params don't connect
+func TestHandshake_ChainSpecMismatch(t *testing.T) {
+	peersArray := make([]peer.ID, 0)
+
+	identityService := newIdentityService(
+		func(server *networkTesting.MockNetworkingServer) {
+			server.HookAddPeer(func(
+				id peer.ID,
+				direction network.Direction,
+			) {
+				peersArray = append(peersArray, id)
+			})
+
+			server.GetMockIdentityClient().HookHello(func(
+				ctx context.Context,
+				in *proto.Status,
+				opts ...grpc.CallOption,
+			) (*proto.Status, error) {
+				return &proto.Status{
+					Chain:   in.Chain,
+					Genesis: "0x2",
+				}, nil
+			})
+		},
+	)
+
+	identityService.specHash = "0x1"
+
+	assert.ErrorIs(
+		t,
+		identityService.handleConnected("TestPeer", network.DirInbound),
+		ErrInvalidChainSpec,
+	)
+
+	assert.Len(t, peersArray, 0)
+}
+
+// TestHandshake_LegacyPeer tests that peers which don't send a chain spec hash still connect
+func TestHandshake_LegacyPeer(t *testing.T) {
+	peersArray := make([]peer.ID, 0)
+
+	identityService := newIdentityService(
+		func(server *networkTesting.MockNetworkingServer) {
+			server.HookAddPeer(func(
+				id peer.ID,
+				direction network.Direction,
+			) {
+				peersArray = append(peersArray, id)
+			})
+
+			server.GetMockIdentityClient().HookHello(func(
+				ctx context.Context,
+				in *proto.Status,
+				opts ...grpc.CallOption,
+			) (*proto.Status, error) {
+				return &proto.Status{
+					Chain: in.Chain,
+				}, nil
+			})
+		},
+	)
+
+	identityService.specHash = "0x1"
+
+	assert.NoError(t, identityService.handleConnected("TestPeer", network.DirInbound))
+	assert.Len(t, peersArray, 1)
+}
@@ -41,6 +41,9 @@ const (
 
 	// networkMetrics is a prefix used for network-related metrics
 	networkMetrics = "network"
+
+	// maxPeerPenalties is the number of penalties after which a peer is disconnected
+	maxPeerPenalties = 3
 )
 
 const (
@@ -70,6 +73,9 @@ type Server struct {
 	peers     map[peer.ID]*PeerConnInfo // map of all peer connections
 	peersLock sync.Mutex                // lock for the peer map
 
+	penalties     map[peer.ID]int // number of penalties of the connected peers
+	penaltiesLock sync.Mutex      // lock for the penalties map
+
 	dialQueue *dial.DialQueue // queue used to asynchronously connect to peers
 
 	discovery *discovery.DiscoveryService // service used for discovering other peers
@@ -88,6 +94,8 @@ type Server struct {
 	temporaryDials sync.Map // map of temporary connections; peerID -> bool
 
 	bootnodes *bootnodesWrapper // reference of all bootnodes for the node
+
+	staticPeers *staticPeers // peers the node keeps connected to
 }
 
 // NewServer returns a new instance of the networking server
@@ -140,6 +148,7 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 		host:             host,
 		addrs:            host.Addrs(),
 		peers:            make(map[peer.ID]*PeerConnInfo),
+		penalties:        make(map[peer.ID]int),
 		dialQueue:        dial.NewDialQueue(),
 		closeCh:          make(chan struct{}),
 		emitterPeerEvent: emitter,
@@ -150,6 +159,7 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 			bootnodesMap:      make(map[peer.ID]*peer.AddrInfo),
 			bootnodeConnCount: 0,
 		},
+		staticPeers: newStaticPeers(host.ID()),
 		connectionCounts: NewBlankConnectionInfo(
 			config.MaxInboundPeers,
 			config.MaxOutboundPeers,
@@ -266,8 +276,13 @@ func (s *Server) Start() error {
 		}
 	}
 
+	if setupErr := s.setupStaticPeers(); setupErr != nil {
+		return fmt.Errorf("unable to setup static peers, %w", setupErr)
+	}
+
 	go s.runDial()
 	go s.keepAliveMinimumPeerConnections()
+	go s.keepStaticPeersConnected()
 
 	// watch for disconnected peers
 	s.host.Network().Notify(&network.NotifyBundle{
@@ -472,6 +487,11 @@ func (s *Server) GetProtocols(peerID peer.ID) ([]string, error) {
 func (s *Server) removePeer(peerID peer.ID) {
 	s.logger.Info("Peer disconnected", "id", peerID)
 
+	// Forget the penalties of the peer
+	s.penaltiesLock.Lock()
+	delete(s.penalties, peerID)
+	s.penaltiesLock.Unlock()
+
 	// Remove the peer from the peers map
 	connectionInfo := s.removePeerInfo(peerID)
 	if connectionInfo == nil {
@@ -542,6 +562,33 @@ func (s *Server) DisconnectFromPeer(peer peer.ID, reason string) {
 	}
 }
 
+// PenalizePeer records a penalty of the peer for misbehavior,
+// the peer is disconnected once it reaches maxPeerPenalties.
+// Trusted peers are never penalized [Thread safe]
+func (s *Server) PenalizePeer(peerID peer.ID, reason string) {
+	if s.IsTrustedPeer(peerID) {
+		s.logger.Debug("Penalty of trusted peer ignored", "id", peerID, "reason", reason)
+
+		return
+	}
+
+	s.logger.Warn("Peer penalized", "id", peerID, "reason", reason)
+	metrics.IncrCounter([]string{networkMetrics, "peer_penalties"}, float32(1))
+
+	s.penaltiesLock.Lock()
+	s.penalties[peerID]++
+	disconnect := s.penalties[peerID] >= maxPeerPenalties
+
+	if disconnect {
+		delete(s.penalties, peerID)
+	}
+	s.penaltiesLock.Unlock()
+
+	if disconnect {
+		s.DisconnectFromPeer(peerID, "too many penalties")
+	}
+}
+
 var (
 	// Anything below 35s is prone to false timeouts, as seen from empirical test data
 	DefaultJoinTimeout   = 100 * time.Second
@@ -13,6 +13,7 @@ import (
 	"github.com/xgr-network/xgr-node/network/grpc"
 	"github.com/xgr-network/xgr-node/network/identity"
 	"github.com/xgr-network/xgr-node/network/proto"
+	"github.com/xgr-network/xgr-node/types"
 	rawGrpc "google.golang.org/grpc"
 )
 
@@ -106,11 +107,19 @@ func (s *Server) IsTemporaryDial(peerID peer.ID) bool {
 
 // setupIdentity sets up the identity service for the node
 func (s *Server) setupIdentity() error {
+	var genesisHash types.Hash
+	if s.config.Chain.Genesis != nil {
+		genesisHash = s.config.Chain.Genesis.Hash()
+	}
+
+	specHash := s.config.Chain.Params.SpecHash(genesisHash)
+
 	// Create an instance of the identity service
 	identityService := identity.NewIdentityService(
 		s,
 		s.logger,
 		s.config.Chain.Params.ChainID,
+		specHash.String(),
 		s.host.ID(),
 	)
 
@@ -0,0 +1,287 @@
+package network
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	helperCommon "github.com/xgr-network/xgr-node/helper/common"
+	"github.com/xgr-network/xgr-node/network/common"
+)
+
+const (
+	// staticPeersFile is the file in the libp2p data directory
+	// holding the static peers added at runtime
+	staticPeersFile = "static-peers.json"
+
+	// staticPeersCheckInterval is the interval of the static peer connection checks
+	staticPeersCheckInterval = time.Second
+
+	// staticPeerMinBackoff is the delay before the second reconnection attempt to a static peer,
+	// it doubles with every failed attempt up to staticPeerMaxBackoff
+	staticPeerMinBackoff = 5 * time.Second
+	staticPeerMaxBackoff = 5 * time.Minute
+)
+
+var errSelfStaticPeer = errors.New("unable to add the node itself as a static peer")
+
+// StaticPeer is a persisted static peer entry
+type StaticPeer struct {
+	Addr    string `json:"addr"`
+	Trusted bool   `json:"trusted"`
+}
+
+// staticPeer is a peer the networking server keeps connected to
+type staticPeer struct {
+	addr      string
+	info      *peer.AddrInfo
+	trusted   bool
+	persisted bool // the peer was added at runtime, the configured peers aren't persisted
+
+	backoff  time.Duration // the delay before the next attempt after a failed one
+	nextDial time.Time     // the earliest time of the next reconnection attempt
+}
+
+// shouldDial checks if the static peer should be dialed at the given time,
+// and schedules the next attempt with the exponential backoff
+func (p *staticPeer) shouldDial(now time.Time, connected bool) bool {
+	if connected {
+		// reconnect right away on the next disconnect
+		p.backoff = 0
+		p.nextDial = time.Time{}
+
+		return false
+	}
+
+	if now.Before(p.nextDial) {
+		return false
+	}
+
+	switch {
+	case p.backoff == 0:
+		p.backoff = staticPeerMinBackoff
+	case p.backoff < staticPeerMaxBackoff:
+		p.backoff *= 2
+		if p.backoff > staticPeerMaxBackoff {
+			p.backoff = staticPeerMaxBackoff
+		}
+	}
+
+	p.nextDial = now.Add(p.backoff)
+
+	return true
+}
+
+// staticPeers is the set of static peers of the networking server
+type staticPeers struct {
+	sync.Mutex
+
+	self  peer.ID // the ID of the host, which can't be its own static peer
+	path  string  // the path of the persisted store, empty if the store is disabled
+	peers map[peer.ID]*staticPeer
+}
+
+// newStaticPeers returns an empty static peer set of the host
+func newStaticPeers(self peer.ID) *staticPeers {
+	return &staticPeers{
+		self:  self,
+		peers: make(map[peer.ID]*staticPeer),
+	}
+}
+
+// add adds the static peer, or updates the flags of an existing one.
+// It returns a flag indicating if the persisted peers changed [Thread safe]
+func (sp *staticPeers) add(rawAddr string, trusted, persisted bool) (*peer.AddrInfo, bool, error) {
+	info, err := common.StringToAddrInfo(rawAddr)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to parse static peer %s: %w", rawAddr, err)
+	}
+
+	if info.ID == sp.self {
+		return nil, false, errSelfStaticPeer
+	}
+
+	sp.Lock()
+	defer sp.Unlock()
+
+	existing, ok := sp.peers[info.ID]
+	if !ok {
+		sp.peers[info.ID] = &staticPeer{
+			addr:      rawAddr,
+			info:      info,
+			trusted:   trusted,
+			persisted: persisted,
+		}
+
+		return info, persisted, nil
+	}
+
+	changed := persisted && (!existing.persisted || (trusted && !existing.trusted))
+
+	existing.trusted = existing.trusted || trusted
+	existing.persisted = existing.persisted || persisted
+
+	return info, changed, nil
+}
+
+// isStatic checks if the peer is a static peer [Thread safe]
+func (sp *staticPeers) isStatic(peerID peer.ID) bool {
+	sp.Lock()
+	defer sp.Unlock()
+
+	_, ok := sp.peers[peerID]
+
+	return ok
+}
+
+// isTrusted checks if the peer is a trusted peer [Thread safe]
+func (sp *staticPeers) isTrusted(peerID peer.ID) bool {
+	sp.Lock()
+	defer sp.Unlock()
+
+	p, ok := sp.peers[peerID]
+
+	return ok && p.trusted
+}
+
+// dueDials returns the static peers to dial at the given time [Thread safe]
+func (sp *staticPeers) dueDials(now time.Time, isConnected func(peer.ID) bool) []*peer.AddrInfo {
+	sp.Lock()
+	defer sp.Unlock()
+
+	dials := make([]*peer.AddrInfo, 0)
+
+	for id, p := range sp.peers {
+		if p.shouldDial(now, isConnected(id)) {
+			dials = append(dials, p.info)
+		}
+	}
+
+	return dials
+}
+
+// load reads the persisted static peers into the set
+func (sp *staticPeers) load() error {
+	if sp.path == "" {
+		return nil
+	}
+
+	raw, err := os.ReadFile(sp.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	var entries []StaticPeer
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", sp.path, err)
+	}
+
+	for _, entry := range entries {
+		if _, _, err := sp.add(entry.Addr, entry.Trusted, true); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// save persists the static peers added at runtime [Thread safe]
+func (sp *staticPeers) save() error {
+	if sp.path == "" {
+		return nil
+	}
+
+	sp.Lock()
+
+	entries := make([]StaticPeer, 0, len(sp.peers))
+
+	for _, p := range sp.peers {
+		if p.persisted {
+			entries = append(entries, StaticPeer{Addr: p.addr, Trusted: p.trusted})
+		}
+	}
+
+	sp.Unlock()
+
+	raw, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return helperCommon.SaveFileSafe(sp.path, raw, 0660)
+}
+
+// setupStaticPeers loads the static peers of the configuration and the persisted store
+func (s *Server) setupStaticPeers() error {
+	if s.config.DataDir != "" {
+		s.staticPeers.path = filepath.Join(s.config.DataDir, staticPeersFile)
+	}
+
+	for _, rawAddr := range s.config.StaticPeers {
+		if _, _, err := s.staticPeers.add(rawAddr, false, false); err != nil {
+			return err
+		}
+	}
+
+	return s.staticPeers.load()
+}
+
+// AddStaticPeer adds a peer the networking server reconnects to whenever the connection drops,
+// a trusted peer is additionally exempt from the peer limits and the penalties.
+// The peer is persisted, so it is restored on restart
+func (s *Server) AddStaticPeer(rawPeerMultiaddr string, trusted bool) error {
+	info, changed, err := s.staticPeers.add(rawPeerMultiaddr, trusted, true)
+	if err != nil {
+		return err
+	}
+
+	if changed {
+		if err := s.staticPeers.save(); err != nil {
+			return fmt.Errorf("unable to persist static peer: %w", err)
+		}
+	}
+
+	s.logger.Info("Static peer added", "addr", info, "trusted", trusted)
+
+	if !s.IsConnected(info.ID) {
+		s.joinPeer(info)
+	}
+
+	return nil
+}
+
+// IsStaticPeer checks if the peer is a static peer [Thread safe]
+func (s *Server) IsStaticPeer(peerID peer.ID) bool {
+	return s.staticPeers.isStatic(peerID)
+}
+
+// IsTrustedPeer checks if the peer is exempt from the peer limits and the penalties [Thread safe]
+func (s *Server) IsTrustedPeer(peerID peer.ID) bool {
+	return s.staticPeers.isTrusted(peerID)
+}
+
+// keepStaticPeersConnected redials the disconnected static peers,
+// backing off exponentially while a peer stays unreachable
+func (s *Server) keepStaticPeersConnected() {
+	for {
+		for _, info := range s.staticPeers.dueDials(time.Now(), s.IsConnected) {
+			s.logger.Debug("Dialing static peer", "addr", info)
+			s.addToDialQueue(info, common.PriorityRequestedDial)
+		}
+
+		select {
+		case <-time.After(staticPeersCheckInterval):
+		case <-s.closeCh:
+			return
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xgr-network/xgr-node/network/common"
+)
+
+// randomPeerAddr returns the address of a random peer
+func randomPeerAddr(t *testing.T) (peer.ID, string) {
+	t.Helper()
+
+	_, pub, err := crypto.GenerateKeyPair(crypto.Secp256k1, 256)
+	require.NoError(t, err)
+
+	id, err := peer.IDFromPublicKey(pub)
+	require.NoError(t, err)
+
+	return id, fmt.Sprintf("/ip4/127.0.0.1/tcp/1478/p2p/%s", id)
+}
+
+func TestStaticPeer_Backoff(t *testing.T) {
+	t.Parallel()
+
+	var (
+		p   = &staticPeer{}
+		now = time.Now()
+	)
+
+	// the first attempt is immediate
+	assert.True(t, p.shouldDial(now, false))
+	assert.False(t, p.shouldDial(now.Add(staticPeerMinBackoff-time.Second), false))
+
+	// the delay doubles with every failed attempt
+	now = now.Add(staticPeerMinBackoff)
+	assert.True(t, p.shouldDial(now, false))
+	assert.Equal(t, 2*staticPeerMinBackoff, p.backoff)
+
+	for i := 0; i < 10; i++ {
+		now = p.nextDial
+		assert.True(t, p.shouldDial(now, false))
+	}
+
+	assert.Equal(t, staticPeerMaxBackoff, p.backoff)
+
+	// the backoff is reset once the peer is connected
+	assert.False(t, p.shouldDial(now, true))
+	assert.True(t, p.shouldDial(now, false))
+	assert.Equal(t, staticPeerMinBackoff, p.backoff)
+}
+
+func TestStaticPeers_Persistence(t *testing.T) {
+	t.Parallel()
+
+	var (
+		path                       = filepath.Join(t.TempDir(), staticPeersFile)
+		self, selfAddr             = randomPeerAddr(t)
+		configured, configuredAddr = randomPeerAddr(t)
+		added, addr                = randomPeerAddr(t)
+	)
+
+	sp := newStaticPeers(self)
+	sp.path = path
+
+	_, _, err := sp.add(selfAddr, false, true)
+	require.ErrorIs(t, err, errSelfStaticPeer)
+
+	_, changed, err := sp.add(configuredAddr, false, false)
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	_, changed, err = sp.add(addr, false, true)
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	// promoting the peer to a trusted one updates the store
+	_, changed, err = sp.add(addr, true, true)
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	_, changed, err = sp.add(addr, false, true)
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	require.NoError(t, sp.save())
+
+	// only the peers added at runtime are restored
+	restored := newStaticPeers(self)
+	restored.path = path
+
+	require.NoError(t, restored.load())
+	assert.True(t, restored.isStatic(added))
+	assert.True(t, restored.isTrusted(added))
+	assert.False(t, restored.isStatic(configured))
+}
+
+func TestStaticPeer_Reconnection(t *testing.T) {
+	servers, createErr := createServers(2, nil)
+	if createErr != nil {
+		t.Fatalf("Unable to create servers, %v", createErr)
+	}
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	addr, err := common.AddrInfoToString(servers[1].AddrInfo())
+	require.NoError(t, err)
+
+	require.NoError(t, servers[0].AddStaticPeer(addr, true))
+	assert.True(t, servers[0].IsStaticPeer(servers[1].AddrInfo().ID))
+	assert.True(t, servers[0].IsTrustedPeer(servers[1].AddrInfo().ID))
+
+	connectCtx, cancelFn := context.WithTimeout(context.Background(), DefaultJoinTimeout)
+	defer cancelFn()
+
+	_, err = WaitUntilPeerConnectsTo(connectCtx, servers[0], servers[1].AddrInfo().ID)
+	require.NoError(t, err)
+
+	// the penalties of a trusted peer are ignored
+	for i := 0; i < maxPeerPenalties; i++ {
+		servers[0].PenalizePeer(servers[1].AddrInfo().ID, "test")
+	}
+
+	assert.True(t, servers[0].IsConnected(servers[1].AddrInfo().ID))
+
+	// the static peer is redialed once the connection drops
+	require.NoError(t, DisconnectAndWait(servers[1], servers[0].AddrInfo().ID, DefaultLeaveTimeout))
+
+	_, err = WaitUntilPeerConnectsTo(connectCtx, servers[0], servers[1].AddrInfo().ID)
+	require.NoError(t, err)
+}
@@ -1,6 +1,9 @@
 package server
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/xgr-network/xgr-node/chain"
 	"github.com/xgr-network/xgr-node/consensus"
 	consensusDev "github.com/xgr-network/xgr-node/consensus/dev"
@@ -64,3 +67,44 @@ func ConsensusSupported(value string) bool {
 
 	return ok
 }
+
+// ValidateConsensusFactories checks that the consensus is supported and that its genesis and
+// fork manager factories are either all registered or none of them is. A consensus with only
+// some of them registered would start with a partially initialized genesis or fork manager
+func ValidateConsensusFactories(value string) error {
+	name := ConsensusType(value)
+
+	if _, ok := consensusBackends[name]; !ok {
+		return fmt.Errorf("consensus %s is not supported", value)
+	}
+
+	_, hasGenesis := genesisCreationFactory[name]
+	_, hasForkManager := forkManagerFactory[name]
+	_, hasForkManagerParams := forkManagerInitialParamsFactory[name]
+
+	factories := []struct {
+		name       string
+		registered bool
+	}{
+		{"genesis creation", hasGenesis},
+		{"fork manager", hasForkManager},
+		{"fork manager initial params", hasForkManagerParams},
+	}
+
+	var present, missing []string
+
+	for _, f := range factories {
+		if f.registered {
+			present = append(present, f.name)
+		} else {
+			missing = append(missing, f.name)
+		}
+	}
+
+	if len(present) > 0 && len(missing) > 0 {
+		return fmt.Errorf("consensus %s is partially configured, registered factories: %s, missing factories: %s",
+			value, strings.Join(present, ", "), strings.Join(missing, ", "))
+	}
+
+	return nil
+}
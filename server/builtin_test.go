@@ -0,0 +1,54 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xgr-network/xgr-node/chain"
+	consensusDev "github.com/xgr-network/xgr-node/consensus/dev"
+	consensusPolyBFT "github.com/xgr-network/xgr-node/consensus/polybft"
+	"github.com/xgr-network/xgr-node/state"
+)
+
+func TestValidateConsensusFactories_Builtin(t *testing.T) {
+	for name := range consensusBackends {
+		assert.NoError(t, ValidateConsensusFactories(string(name)), name)
+	}
+
+	assert.ErrorContains(t, ValidateConsensusFactories("unknown"), "not supported")
+}
+
+func TestValidateConsensusFactories_Partial(t *testing.T) {
+	const name ConsensusType = "partial"
+
+	consensusBackends[name] = consensusDev.Factory
+
+	t.Cleanup(func() {
+		delete(consensusBackends, name)
+		delete(genesisCreationFactory, name)
+		delete(forkManagerFactory, name)
+		delete(forkManagerInitialParamsFactory, name)
+	})
+
+	// no factories at all is a valid configuration
+	require.NoError(t, ValidateConsensusFactories(string(name)))
+
+	genesisCreationFactory[name] = func(*chain.Chain, string) func(*state.Transition) error {
+		return nil
+	}
+
+	err := ValidateConsensusFactories(string(name))
+	require.ErrorContains(t, err, "registered factories: genesis creation")
+	require.ErrorContains(t, err, "missing factories: fork manager, fork manager initial params")
+
+	forkManagerFactory[name] = consensusPolyBFT.ForkManagerFactory
+
+	err = ValidateConsensusFactories(string(name))
+	require.ErrorContains(t, err, "missing factories: fork manager initial params")
+
+	forkManagerInitialParamsFactory[name] = consensusPolyBFT.ForkManagerInitialParamsFactory
+
+	require.NoError(t, ValidateConsensusFactories(string(name)))
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/xgr-network/xgr-node/chain"
 	"github.com/xgr-network/xgr-node/network"
 	"github.com/xgr-network/xgr-node/secrets"
+	"github.com/xgr-network/xgr-node/types"
 )
 
 const DefaultGRPCPort int = 9632
@@ -22,16 +23,23 @@ type Config struct {
 	GRPCAddr   *net.TCPAddr
 	LibP2PAddr *net.TCPAddr
 
-	PriceLimit         uint64
-	MaxAccountEnqueued uint64
-	MaxSlots           uint64
+	PriceLimit            uint64
+	MaxAccountEnqueued    uint64
+	MaxAccountTxs         uint64
+	MaxSlots              uint64
+	PriceBump             uint64
+	UnderpricedTxLifetime uint64
 
 	Telemetry *Telemetry
 	Network   *network.Config
 
 	DataDir     string
+	DBEngine    string
 	RestoreFile *string
 
+	ImportBlocksFile *string
+	ImportBlocksFast bool
+
 	Seal bool
 
 	SecretsManager *secrets.SecretsManagerConfig
@@ -44,6 +52,36 @@ type Config struct {
 
 	Relayer bool
 
+	// ShadowFork follows the network without taking part in it and records
+	// the blocks whose local execution diverges instead of rejecting them
+	ShadowFork bool
+
+	// RewardAddress is the address the validator expects to be credited with
+	// the fees of its blocks, zero if the signing address is used
+	RewardAddress types.Address
+
+	// EnginePriorityGasShare is the share (in percent) of the block gas limit filled with the
+	// transactions of authorized engine EOAs ahead of all other transactions, 0 disables it
+	EnginePriorityGasShare uint64
+
+	// MaxReorgDepth is the maximum number of canonical blocks a reorg may replace, 0 if unlimited
+	MaxReorgDepth uint64
+
+	// FutureBlocksSize is the maximum number of buffered blocks received ahead of the head
+	FutureBlocksSize uint64
+
+	// FutureBlocksMaxDistance is the maximum number of blocks a buffered block may be ahead of the head
+	FutureBlocksMaxDistance uint64
+
+	// StateRetention is the number of recent blocks whose state is kept, 0 keeps the full archive
+	StateRetention uint64
+
+	// GasPriceOracleBlocks is the number of recent blocks sampled by the gas price oracle (0 keeps the default)
+	GasPriceOracleBlocks uint64
+
+	// GasPriceOraclePercentile is the percentile of the sampled tips suggested as the priority fee
+	GasPriceOraclePercentile uint64
+
 	NumBlockConfirmations uint64
 	MetricsInterval       time.Duration
 }
@@ -61,4 +99,5 @@ type JSONRPC struct {
 	BlockRangeLimit          uint64
 	ConcurrentRequestsDebug  uint64
 	WebSocketReadLimit       uint64
+	EnableSetHead            bool
 }
@@ -360,6 +360,10 @@ func (m *Peer) validate(all bool) error {
 
 	// no validation rules for Id
 
+	// no validation rules for Static
+
+	// no validation rules for Trusted
+
 	if len(errors) > 0 {
 		return PeerMultiError(errors)
 	}
@@ -470,6 +474,10 @@ func (m *PeersAddRequest) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
+	// no validation rules for Static
+
+	// no validation rules for Trusted
+
 	if len(errors) > 0 {
 		return PeersAddRequestMultiError(errors)
 	}
@@ -1320,6 +1328,160 @@ var _ interface {
 	ErrorName() string
 } = ExportEventValidationError{}
 
+// Validate checks the field values on BlockPreviewResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *BlockPreviewResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BlockPreviewResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// BlockPreviewResponseMultiError, or nil if none found.
+func (m *BlockPreviewResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BlockPreviewResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Number
+
+	// no validation rules for ParentHash
+
+	// no validation rules for StateRoot
+
+	// no validation rules for GasLimit
+
+	// no validation rules for GasUsed
+
+	// no validation rules for BaseFee
+
+	// no validation rules for BurnedFee
+
+	// no validation rules for DonationFee
+
+	// no validation rules for ValidatorFee
+
+	for idx, item := range m.GetTransactions() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, BlockPreviewResponseValidationError{
+						field:  fmt.Sprintf("Transactions[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, BlockPreviewResponseValidationError{
+						field:  fmt.Sprintf("Transactions[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return BlockPreviewResponseValidationError{
+					field:  fmt.Sprintf("Transactions[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return BlockPreviewResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// BlockPreviewResponseMultiError is an error wrapping multiple validation
+// errors returned by BlockPreviewResponse.ValidateAll() if the designated
+// constraints aren't met.
+type BlockPreviewResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BlockPreviewResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BlockPreviewResponseMultiError) AllErrors() []error { return m }
+
+// BlockPreviewResponseValidationError is the validation error returned by
+// BlockPreviewResponse.Validate if the designated constraints aren't met.
+type BlockPreviewResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BlockPreviewResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BlockPreviewResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BlockPreviewResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BlockPreviewResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BlockPreviewResponseValidationError) ErrorName() string {
+	return "BlockPreviewResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e BlockPreviewResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBlockPreviewResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BlockPreviewResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BlockPreviewResponseValidationError{}
+
 // Validate checks the field values on BlockchainEvent_Header with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
@@ -1531,3 +1693,121 @@ var _ interface {
 	Cause() error
 	ErrorName() string
 } = ServerStatus_BlockValidationError{}
+
+// Validate checks the field values on BlockPreviewResponse_Tx with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *BlockPreviewResponse_Tx) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BlockPreviewResponse_Tx with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// BlockPreviewResponse_TxMultiError, or nil if none found.
+func (m *BlockPreviewResponse_Tx) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BlockPreviewResponse_Tx) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Hash
+
+	// no validation rules for From
+
+	// no validation rules for Nonce
+
+	// no validation rules for GasUsed
+
+	// no validation rules for Status
+
+	// no validation rules for BurnedFee
+
+	// no validation rules for DonationFee
+
+	// no validation rules for ValidatorFee
+
+	if len(errors) > 0 {
+		return BlockPreviewResponse_TxMultiError(errors)
+	}
+
+	return nil
+}
+
+// BlockPreviewResponse_TxMultiError is an error wrapping multiple validation
+// errors returned by BlockPreviewResponse_Tx.ValidateAll() if the designated
+// constraints aren't met.
+type BlockPreviewResponse_TxMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BlockPreviewResponse_TxMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BlockPreviewResponse_TxMultiError) AllErrors() []error { return m }
+
+// BlockPreviewResponse_TxValidationError is the validation error returned by
+// BlockPreviewResponse_Tx.Validate if the designated constraints aren't met.
+type BlockPreviewResponse_TxValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BlockPreviewResponse_TxValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BlockPreviewResponse_TxValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BlockPreviewResponse_TxValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BlockPreviewResponse_TxValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BlockPreviewResponse_TxValidationError) ErrorName() string {
+	return "BlockPreviewResponse_TxValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e BlockPreviewResponse_TxValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBlockPreviewResponse_Tx.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BlockPreviewResponse_TxValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BlockPreviewResponse_TxValidationError{}
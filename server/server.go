@@ -15,6 +15,7 @@ import (
 	"github.com/xgr-network/xgr-node/blockchain/storage"
 	"github.com/xgr-network/xgr-node/blockchain/storage/leveldb"
 	"github.com/xgr-network/xgr-node/blockchain/storage/memory"
+	"github.com/xgr-network/xgr-node/blockchain/storage/pebble"
 	consensusPolyBFT "github.com/xgr-network/xgr-node/consensus/polybft"
 	"github.com/xgr-network/xgr-node/forkmanager"
 	"github.com/xgr-network/xgr-node/gasprice"
@@ -39,6 +40,7 @@ import (
 	"github.com/xgr-network/xgr-node/state/runtime"
 	"github.com/xgr-network/xgr-node/state/runtime/addresslist"
 	"github.com/xgr-network/xgr-node/state/runtime/tracer"
+	"github.com/xgr-network/xgr-node/syncer"
 	"github.com/xgr-network/xgr-node/txpool"
 	"github.com/xgr-network/xgr-node/types"
 	"github.com/xgr-network/xgr-node/validate"
@@ -46,8 +48,9 @@ import (
 )
 
 var (
-	errBlockTimeMissing = errors.New("block time configuration is missing")
-	errBlockTimeInvalid = errors.New("block time configuration is invalid")
+	errBlockTimeMissing        = errors.New("block time configuration is missing")
+	errBlockTimeInvalid        = errors.New("block time configuration is invalid")
+	errBlockPreviewUnsupported = errors.New("the consensus doesn't support block previews")
 )
 
 // Server is the central manager of the blockchain client
@@ -88,6 +91,9 @@ type Server struct {
 
 	// gasHelper is providing functions regarding gas and fees
 	gasHelper *gasprice.GasHelper
+
+	// statePruner prunes the state outside of the retention window (nil if disabled)
+	statePruner *statePruner
 }
 
 // newFileLogger returns logger instance that writes all logs to a specified file.
@@ -132,12 +138,28 @@ func newLoggerFromConfig(config *Config) (hclog.Logger, error) {
 }
 
 // NewServer creates a new Minimal server, using the passed in configuration
+// NewBlockchainStorage opens the blockchain storage at the path with the given database engine
+func NewBlockchainStorage(engine string, path string, logger hclog.Logger) (storage.Storage, error) {
+	switch engine {
+	case storage.EnginePebble:
+		return pebble.NewPebbleStorage(path, logger)
+	case storage.EngineLevelDB, "":
+		return leveldb.NewLevelDBStorage(path, logger)
+	default:
+		return nil, fmt.Errorf("unknown db engine %s", engine)
+	}
+}
+
 func NewServer(config *Config) (*Server, error) {
 	logger, err := newLoggerFromConfig(config)
 	if err != nil {
 		return nil, fmt.Errorf("could not setup new logger instance, %w", err)
 	}
 
+	if err := ValidateConsensusFactories(config.Chain.Params.GetEngine()); err != nil {
+		return nil, err
+	}
+
 	m := &Server{
 		logger:             logger.Named("server"),
 		config:             config,
@@ -150,6 +172,10 @@ func NewServer(config *Config) (*Server, error) {
 		m.logger.Info(common.IBFTImportantNotice)
 	}
 
+	for _, warning := range config.Chain.Params.Warnings() {
+		m.logger.Warn("genesis params", "warning", warning)
+	}
+
 	m.logger.Info("Data dir", "path", config.DataDir)
 
 	var dirPaths = []string{
@@ -203,7 +229,31 @@ func NewServer(config *Config) (*Server, error) {
 
 	m.stateStorage = stateStorage
 
-	st := itrie.NewState(stateStorage)
+	var (
+		st     state.State = itrie.NewState(stateStorage)
+		pruner *itrie.Pruner
+	)
+
+	if config.StateRetention != 0 {
+		prunable, ok := stateStorage.(itrie.PrunableStorage)
+		if !ok {
+			return nil, errors.New("state storage doesn't support pruning")
+		}
+
+		st, pruner = itrie.NewPrunedState(prunable, logger)
+
+		logger.Info("state pruning enabled", "retention", config.StateRetention)
+	}
+
+	// a shadow fork executes blocks whose state roots may not exist locally
+	var shadowState *state.ShadowState
+	if config.ShadowFork {
+		shadowState = state.NewShadowState(st, stateStorage)
+		st = shadowState
+
+		logger.Warn("shadow fork mode enabled, block production and transaction gossip are disabled")
+	}
+
 	m.state = st
 
 	m.executor = state.NewExecutor(config.Chain.Params, st, logger)
@@ -214,40 +264,43 @@ func NewServer(config *Config) (*Server, error) {
 		m.executor.GenesisPostHook = factory(m.config.Chain, engineName)
 	}
 
+	// the address lists are enumerable if the index fork is active from genesis
+	indexedLists := m.config.Chain.Params.Forks.IsActive(chain.AddressListIndex, 0)
+
 	// apply allow list contracts deployer genesis data
 	if m.config.Chain.Params.ContractDeployerAllowList != nil {
 		addresslist.ApplyGenesisAllocs(m.config.Chain.Genesis, contracts.AllowListContractsAddr,
-			m.config.Chain.Params.ContractDeployerAllowList)
+			m.config.Chain.Params.ContractDeployerAllowList, indexedLists)
 	}
 
 	// apply block list contracts deployer genesis data
 	if m.config.Chain.Params.ContractDeployerBlockList != nil {
 		addresslist.ApplyGenesisAllocs(m.config.Chain.Genesis, contracts.BlockListContractsAddr,
-			m.config.Chain.Params.ContractDeployerBlockList)
+			m.config.Chain.Params.ContractDeployerBlockList, indexedLists)
 	}
 
 	// apply transactions execution allow list genesis data
 	if m.config.Chain.Params.TransactionsAllowList != nil {
 		addresslist.ApplyGenesisAllocs(m.config.Chain.Genesis, contracts.AllowListTransactionsAddr,
-			m.config.Chain.Params.TransactionsAllowList)
+			m.config.Chain.Params.TransactionsAllowList, indexedLists)
 	}
 
 	// apply transactions execution block list genesis data
 	if m.config.Chain.Params.TransactionsBlockList != nil {
 		addresslist.ApplyGenesisAllocs(m.config.Chain.Genesis, contracts.BlockListTransactionsAddr,
-			m.config.Chain.Params.TransactionsBlockList)
+			m.config.Chain.Params.TransactionsBlockList, indexedLists)
 	}
 
 	// apply bridge allow list genesis data
 	if m.config.Chain.Params.BridgeAllowList != nil {
 		addresslist.ApplyGenesisAllocs(m.config.Chain.Genesis, contracts.AllowListBridgeAddr,
-			m.config.Chain.Params.BridgeAllowList)
+			m.config.Chain.Params.BridgeAllowList, indexedLists)
 	}
 
 	// apply bridge block list genesis data
 	if m.config.Chain.Params.BridgeBlockList != nil {
 		addresslist.ApplyGenesisAllocs(m.config.Chain.Genesis, contracts.BlockListBridgeAddr,
-			m.config.Chain.Params.BridgeBlockList)
+			m.config.Chain.Params.BridgeBlockList, indexedLists)
 	}
 
 	var initialStateRoot = types.ZeroHash
@@ -305,7 +358,8 @@ func NewServer(config *Config) (*Server, error) {
 				return nil, err
 			}
 		} else {
-			db, err = leveldb.NewLevelDBStorage(
+			db, err = NewBlockchainStorage(
+				m.config.DBEngine,
 				filepath.Join(m.config.DataDir, "blockchain"),
 				m.logger,
 			)
@@ -328,8 +382,31 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, err
 	}
 
-	// here we can provide some other configuration
-	m.gasHelper, err = gasprice.NewGasHelper(gasprice.DefaultGasHelperConfig, m.blockchain)
+	if shadowState != nil {
+		m.blockchain.SetShadowFork(blockchain.NewShadowFork(shadowState))
+	}
+
+	m.blockchain.SetMaxReorgDepth(config.MaxReorgDepth)
+
+	// the fee split metrics are only collected if the telemetry is enabled
+	if config.Telemetry.PrometheusAddr != nil {
+		m.blockchain.SetBlockObserver(blockchain.NewFeeMetrics())
+	}
+
+	if pruner != nil {
+		m.statePruner = newStatePruner(logger, m.blockchain, pruner, config.StateRetention)
+		m.statePruner.start()
+	}
+
+	// the percentile is applied on its own, 0 is a valid percentile unlike 0 blocks
+	gasHelperConfig := *gasprice.DefaultGasHelperConfig
+	gasHelperConfig.PricePercentile = config.GasPriceOraclePercentile
+
+	if config.GasPriceOracleBlocks != 0 {
+		gasHelperConfig.NumOfBlocksToCheck = config.GasPriceOracleBlocks
+	}
+
+	m.gasHelper, err = gasprice.NewGasHelper(&gasHelperConfig, m.blockchain)
 	if err != nil {
 		return nil, err
 	}
@@ -342,18 +419,28 @@ func NewServer(config *Config) (*Server, error) {
 			Blockchain: m.blockchain,
 		}
 
+		// a shadow fork doesn't gossip transactions
+		txPoolNetwork := m.network
+		if config.ShadowFork {
+			txPoolNetwork = nil
+		}
+
 		// start transaction pool
 		m.txpool, err = txpool.NewTxPool(
 			logger,
 			m.chain.Params.Forks,
 			hub,
 			m.grpcServer,
-			m.network,
+			txPoolNetwork,
 			&txpool.Config{
-				MaxSlots:           m.config.MaxSlots,
-				PriceLimit:         m.config.PriceLimit,
-				MaxAccountEnqueued: m.config.MaxAccountEnqueued,
-				ChainID:            big.NewInt(m.config.Chain.Params.ChainID),
+				MaxSlots:              m.config.MaxSlots,
+				PriceLimit:            m.config.PriceLimit,
+				MaxAccountEnqueued:    m.config.MaxAccountEnqueued,
+				MaxAccountTxs:         m.config.MaxAccountTxs,
+				PriceBump:             m.config.PriceBump,
+				UnderpricedTxLifetime: m.config.UnderpricedTxLifetime,
+				ChainID:               big.NewInt(m.config.Chain.Params.ChainID),
+				EnableLoad:            ConsensusType(engineName) == DevConsensus,
 			},
 		)
 		if err != nil {
@@ -378,6 +465,10 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, err
 	}
 
+	if err := m.blockchain.StartLogIndex(); err != nil {
+		return nil, err
+	}
+
 	// initialize data in consensus layer
 	if err := m.consensus.Initialize(); err != nil {
 		return nil, err
@@ -402,6 +493,11 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, err
 	}
 
+	// import blocks file before starting
+	if err := m.importBlocks(); err != nil {
+		return nil, err
+	}
+
 	// start consensus
 	if err := m.consensus.Start(); err != nil {
 		return nil, err
@@ -439,6 +535,19 @@ func (s *Server) restoreChain() error {
 	return nil
 }
 
+func (s *Server) importBlocks() error {
+	if s.config.ImportBlocksFile == nil {
+		return nil
+	}
+
+	return archive.ImportBlocks(
+		s.blockchain,
+		*s.config.ImportBlocksFile,
+		s.config.ImportBlocksFast,
+		s.restoreProgression,
+	)
+}
+
 type txpoolHub struct {
 	state state.State
 	*blockchain.Blockchain
@@ -487,6 +596,16 @@ func (t *txpoolHub) GetBalance(root types.Hash, addr types.Address) (*big.Int, e
 	return account.Balance, nil
 }
 
+func (t *txpoolHub) GetCodeHash(root types.Hash, addr types.Address) types.Hash {
+	account, err := getAccountImpl(t.state, root, addr)
+
+	if err != nil {
+		return types.ZeroHash
+	}
+
+	return types.BytesToHash(account.CodeHash)
+}
+
 // setupSecretsManager sets up the secrets manager
 func (s *Server) setupSecretsManager() error {
 	secretsManagerConfig := s.config.SecretsManager
@@ -558,11 +677,15 @@ func (s *Server) setupConsensus() error {
 	}
 
 	config := &consensus.Config{
-		Params:      s.config.Chain.Params,
-		Config:      engineConfig,
-		Path:        filepath.Join(s.config.DataDir, "consensus"),
-		IsRelayer:   s.config.Relayer,
-		RPCEndpoint: s.config.JSONRPC.JSONRPCAddr.String(),
+		Params:        s.config.Chain.Params,
+		Config:        engineConfig,
+		Path:          filepath.Join(s.config.DataDir, "consensus"),
+		IsRelayer:     s.config.Relayer,
+		ShadowFork:    s.config.ShadowFork,
+		RewardAddress: s.config.RewardAddress,
+		RPCEndpoint:   s.config.JSONRPC.JSONRPCAddr.String(),
+
+		EnginePriorityGasShare: s.config.EnginePriorityGasShare,
 	}
 
 	consensus, err := engine(
@@ -579,6 +702,10 @@ func (s *Server) setupConsensus() error {
 			BlockTime:             uint64(blockTime.Seconds()),
 			NumBlockConfirmations: s.config.NumBlockConfirmations,
 			MetricsInterval:       s.config.MetricsInterval,
+			FutureBlocks: syncer.FutureBlocksConfig{
+				Size:        s.config.FutureBlocksSize,
+				MaxDistance: s.config.FutureBlocksMaxDistance,
+			},
 		},
 	)
 
@@ -669,6 +796,58 @@ func (j *jsonRPCHub) GetStorage(stateRoot types.Hash, addr types.Address, slot t
 	return res.Bytes(), nil
 }
 
+// GetProof returns the merkle proofs of the account and its storage slots at the given state root
+func (j *jsonRPCHub) GetProof(
+	root types.Hash,
+	addr types.Address,
+	slots []types.Hash,
+) (*jsonrpc.AccountProof, error) {
+	snap, err := j.state.NewSnapshotAt(root)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get snapshot for root '%s': %w", root, err)
+	}
+
+	account, err := snap.GetAccount(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	accountProof, err := snap.GetAccountProof(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	proof := &jsonrpc.AccountProof{
+		Balance:      big.NewInt(0),
+		CodeHash:     types.EmptyCodeHash,
+		StorageHash:  types.EmptyRootHash,
+		AccountProof: accountProof,
+		StorageProof: make([]jsonrpc.StorageProof, len(slots)),
+	}
+
+	if account != nil {
+		proof.Balance = new(big.Int).Set(account.Balance)
+		proof.Nonce = account.Nonce
+		proof.CodeHash = types.BytesToHash(account.CodeHash)
+		proof.StorageHash = account.Root
+	}
+
+	for i, slot := range slots {
+		storageProof, err := snap.GetStorageProof(proof.StorageHash, slot)
+		if err != nil {
+			return nil, err
+		}
+
+		proof.StorageProof[i] = jsonrpc.StorageProof{
+			Key:   slot,
+			Value: snap.GetStorage(addr, proof.StorageHash, slot).Bytes(),
+			Proof: storageProof,
+		}
+	}
+
+	return proof, nil
+}
+
 func (j *jsonRPCHub) GetCode(root types.Hash, addr types.Address) ([]byte, error) {
 	account, err := getAccountImpl(j.state, root, addr)
 	if err != nil {
@@ -707,7 +886,7 @@ func (j *jsonRPCHub) ApplyTxn(
 
 	transition.SetNonPayable(nonPayable)
 
-	result, err = transition.Apply(txn)
+	result, err = transition.ApplyReadOnly(txn)
 
 	return
 }
@@ -873,6 +1052,9 @@ func (s *Server) setupJSONRPC() error {
 		BlockRangeLimit:          s.config.JSONRPC.BlockRangeLimit,
 		ConcurrentRequestsDebug:  s.config.JSONRPC.ConcurrentRequestsDebug,
 		WebSocketReadLimit:       s.config.JSONRPC.WebSocketReadLimit,
+		EnableSetHead:            s.config.JSONRPC.EnableSetHead,
+		DisableEngine:            !s.config.Chain.Params.IsEngineEnabled(),
+		DonationPercent:          s.config.Chain.Params.DonationPercent(),
 	}
 
 	srv, err := jsonrpc.NewJSONRPC(s.logger, conf)
@@ -916,6 +1098,11 @@ func (s *Server) JoinPeer(rawPeerMultiaddr string) error {
 	return s.network.JoinPeer(rawPeerMultiaddr)
 }
 
+// AddStaticPeer adds a peer the networking server keeps connected to across restarts
+func (s *Server) AddStaticPeer(rawPeerMultiaddr string, trusted bool) error {
+	return s.network.AddStaticPeer(rawPeerMultiaddr, trusted)
+}
+
 // Close closes the Minimal server (blockchain, networking, consensus)
 func (s *Server) Close() {
 	// Close the blockchain layer
@@ -933,6 +1120,10 @@ func (s *Server) Close() {
 		s.logger.Error("failed to close consensus", "err", err.Error())
 	}
 
+	if s.statePruner != nil {
+		s.statePruner.close()
+	}
+
 	// Close the state storage
 	if err := s.stateStorage.Close(); err != nil {
 		s.logger.Error("failed to close storage for trie", "err", err.Error())
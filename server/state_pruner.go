@@ -0,0 +1,116 @@
+package server
+
+import (
+	"sync/atomic"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/xgr-network/xgr-node/blockchain"
+	itrie "github.com/xgr-network/xgr-node/state/immutable-trie"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+// MinStateRetention is the smallest number of recent blocks whose state is kept when pruning,
+// consensus and JSON-RPC rely on the state of recent blocks being available
+const MinStateRetention uint64 = 128
+
+// statePruner prunes the state of blocks older than the retention window in the background
+type statePruner struct {
+	logger     hclog.Logger
+	blockchain *blockchain.Blockchain
+	pruner     *itrie.Pruner
+
+	// retention is the number of recent blocks whose state is kept
+	retention uint64
+	// interval is the number of new blocks between two prunes
+	interval uint64
+
+	lastPruned uint64
+	running    atomic.Bool
+	closeCh    chan struct{}
+}
+
+func newStatePruner(
+	logger hclog.Logger,
+	blockchain *blockchain.Blockchain,
+	pruner *itrie.Pruner,
+	retention uint64,
+) *statePruner {
+	// prune a few times per retention window, so the state never grows far beyond it
+	interval := retention / 4
+	if interval == 0 {
+		interval = 1
+	}
+
+	return &statePruner{
+		logger:     logger.Named("state_pruner"),
+		blockchain: blockchain,
+		pruner:     pruner,
+		retention:  retention,
+		interval:   interval,
+		closeCh:    make(chan struct{}),
+	}
+}
+
+func (p *statePruner) start() {
+	sub := p.blockchain.SubscribeEvents()
+
+	go func() {
+		defer p.blockchain.UnsubscribeEvents(sub)
+
+		for {
+			select {
+			case ev := <-sub.GetEventCh():
+				if ev.Type == blockchain.EventFork || len(ev.NewChain) == 0 {
+					continue
+				}
+
+				p.maybePrune(ev.Header().Number)
+			case <-p.closeCh:
+				return
+			}
+		}
+	}()
+}
+
+func (p *statePruner) close() {
+	close(p.closeCh)
+}
+
+// maybePrune starts a prune in the background if enough blocks were added since the last one.
+// The event loop must not block, the blockchain waits for its subscribers.
+func (p *statePruner) maybePrune(head uint64) {
+	if head <= p.retention || head < p.lastPruned+p.interval {
+		return
+	}
+
+	if !p.running.CompareAndSwap(false, true) {
+		return
+	}
+
+	p.lastPruned = head
+	roots := p.retainedRoots(head)
+
+	go func() {
+		defer p.running.Store(false)
+
+		if _, err := p.pruner.Prune(roots); err != nil {
+			p.logger.Error("failed to prune state", "head", head, "err", err)
+		}
+	}()
+}
+
+// retainedRoots returns the state roots of the blocks in the retention window ending at head
+func (p *statePruner) retainedRoots(head uint64) []types.Hash {
+	roots := make([]types.Hash, 0, p.retention)
+
+	for number := head - p.retention + 1; number <= head; number++ {
+		header, ok := p.blockchain.GetHeaderByNumber(number)
+		if !ok {
+			continue
+		}
+
+		roots = append(roots, header.StateRoot)
+	}
+
+	return roots
+}
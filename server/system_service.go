@@ -8,6 +8,7 @@ import (
 
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/xgr-network/xgr-node/blockchain"
+	"github.com/xgr-network/xgr-node/consensus"
 	"github.com/xgr-network/xgr-node/network/common"
 	"github.com/xgr-network/xgr-node/server/proto"
 	"github.com/xgr-network/xgr-node/types"
@@ -90,6 +91,19 @@ func (s *systemService) Subscribe(req *empty.Empty, stream proto.System_Subscrib
 
 // PeersAdd implements the 'peers add' operator service
 func (s *systemService) PeersAdd(_ context.Context, req *proto.PeersAddRequest) (*proto.PeersAddResponse, error) {
+	// a trusted peer is always a static one
+	if req.Static || req.Trusted {
+		if addErr := s.server.AddStaticPeer(req.Id, req.Trusted); addErr != nil {
+			return &proto.PeersAddResponse{
+				Message: "Unable to successfully add static peer",
+			}, addErr
+		}
+
+		return &proto.PeersAddResponse{
+			Message: "Static peer persisted and marked ready for dialing",
+		}, nil
+	}
+
 	if joinErr := s.server.JoinPeer(req.Id); joinErr != nil {
 		return &proto.PeersAddResponse{
 			Message: "Unable to successfully add peer",
@@ -134,6 +148,8 @@ func (s *systemService) getPeer(id peer.ID) (*proto.Peer, error) {
 		Id:        id.String(),
 		Protocols: protocols,
 		Addrs:     addrs,
+		Static:    s.server.network.IsStaticPeer(id),
+		Trusted:   s.server.network.IsTrustedPeer(id),
 	}
 
 	return peer, nil
@@ -176,6 +192,50 @@ func (s *systemService) BlockByNumber(
 	}, nil
 }
 
+// BlockPreview implements the 'preview' operator service. It builds the block the node would
+// produce next on top of its head, the block is neither sealed nor written and the pool is not modified
+func (s *systemService) BlockPreview(_ context.Context, _ *empty.Empty) (*proto.BlockPreviewResponse, error) {
+	previewer, ok := s.server.consensus.(consensus.BlockPreviewer)
+	if !ok {
+		return nil, errBlockPreviewUnsupported
+	}
+
+	preview, err := previewer.BuildBlockPreview()
+	if err != nil {
+		return nil, err
+	}
+
+	header := preview.Header
+
+	resp := &proto.BlockPreviewResponse{
+		Number:       header.Number,
+		ParentHash:   header.ParentHash.String(),
+		StateRoot:    header.StateRoot.String(),
+		GasLimit:     header.GasLimit,
+		GasUsed:      header.GasUsed,
+		BaseFee:      header.BaseFee,
+		BurnedFee:    preview.BurnedFee.String(),
+		DonationFee:  preview.DonationFee.String(),
+		ValidatorFee: preview.ValidatorFee.String(),
+		Transactions: make([]*proto.BlockPreviewResponse_Tx, 0, len(preview.Transactions)),
+	}
+
+	for _, tx := range preview.Transactions {
+		resp.Transactions = append(resp.Transactions, &proto.BlockPreviewResponse_Tx{
+			Hash:         tx.Hash.String(),
+			From:         tx.From.String(),
+			Nonce:        tx.Nonce,
+			GasUsed:      tx.GasUsed,
+			Status:       uint64(tx.Status),
+			BurnedFee:    tx.BurnedFee.String(),
+			DonationFee:  tx.DonationFee.String(),
+			ValidatorFee: tx.ValidatorFee.String(),
+		})
+	}
+
+	return resp, nil
+}
+
 func (s *systemService) Export(req *proto.ExportRequest, stream proto.System_ExportServer) error {
 	var (
 		from uint64 = 0
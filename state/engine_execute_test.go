@@ -0,0 +1,409 @@
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+	ethabi "github.com/umbracle/ethgo/abi"
+
+	"github.com/xgr-network/xgr-node/chain"
+	"github.com/xgr-network/xgr-node/contracts"
+	"github.com/xgr-network/xgr-node/contracts/engineabi"
+	"github.com/xgr-network/xgr-node/state/runtime"
+	"github.com/xgr-network/xgr-node/state/runtime/tracer/calltracer"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+// innerCallGasUsed executes an ENGINE_EXECUTE of user calling target with the given forks
+// and returns the gas used by the inner call
+func innerCallGasUsed(t *testing.T, forks *chain.Forks, engine, user, target types.Address, code []byte) uint64 {
+	t.Helper()
+
+	e := NewExecutor(&chain.Params{
+		Forks: forks,
+		BurnContract: map[uint64]types.Address{
+			0: types.ZeroAddress,
+		},
+	}, &preStateStore{
+		preState: map[types.Address]*PreState{
+			engine: {Balance: 1_000_000_000_000_000_000},
+			user:   {Balance: 1_000_000_000_000_000_000},
+		},
+	}, hclog.NewNullLogger())
+
+	e.GetHash = func(*types.Header) GetHashByNumber {
+		return func(uint64) types.Hash {
+			return types.ZeroHash
+		}
+	}
+
+	transition, err := e.BeginTxn(types.StringToHash("0x1"), &types.Header{Number: 1, GasLimit: 10_000_000}, types.ZeroAddress)
+	require.NoError(t, err)
+
+	transition.state.SetCode(target, code)
+
+	input, err := ethabi.MustNewABI(engineabi.ExecuteABI).GetMethod("ENGINE_EXECUTE").Encode(map[string]interface{}{
+		"grant": map[string]interface{}{
+			"from":        user,
+			"engine":      engine,
+			"xrc729":      types.ZeroAddress,
+			"ostcId":      "",
+			"ostcHash":    [32]byte{},
+			"processId":   big.NewInt(1),
+			"maxTotalGas": big.NewInt(0),
+			"expiry":      big.NewInt(0),
+			"sessionId":   big.NewInt(1),
+			"chainId":     big.NewInt(0),
+		},
+		"call": map[string]interface{}{
+			"to":                 target,
+			"data":               []byte{},
+			"valueWei":           big.NewInt(0),
+			"gasLimit":           uint64(100_000),
+			"validationGas":      uint64(0),
+			"maxFeePerGas":       big.NewInt(1),
+			"deadline":           uint64(0),
+			"grantFeeSeconds":    uint64(0),
+			"grantFeePerYearWei": big.NewInt(0),
+		},
+		"meta": map[string]interface{}{
+			"iteration":     uint64(0),
+			"stepId":        "",
+			"ruleContract":  types.ZeroAddress,
+			"ruleHash":      [32]byte{},
+			"payload":       []byte{},
+			"apiSaves":      []byte{},
+			"contractSaves": []byte{},
+			"extras":        []byte{},
+		},
+	})
+	require.NoError(t, err)
+
+	engineExecute := contracts.EngineExecutePrecompile
+
+	require.NoError(t, transition.Write(&types.Transaction{
+		From:     engine,
+		To:       &engineExecute,
+		Gas:      2_000_000,
+		GasPrice: big.NewInt(1),
+		Value:    big.NewInt(0),
+		Input:    input,
+	}))
+
+	receipts := transition.Receipts()
+	require.Len(t, receipts, 1)
+	require.Equal(t, types.ReceiptSuccess, *receipts[0].Status)
+
+	// the gas used by the inner call is the first field of the EngineExtrasV2 event
+	extrasEvent := ethabi.MustNewABI(engineabi.EngineExtrasEventABI).Events["EngineExtrasV2"]
+
+	for _, log := range receipts[0].Logs {
+		if id := extrasEvent.ID(); log.Topics[0] == types.BytesToHash(id[:]) {
+			extras, err := extrasEvent.Inputs.Decode(log.Data)
+			require.NoError(t, err)
+
+			return extras.(map[string]interface{})["gasUsed"].(*big.Int).Uint64() //nolint:forcetypeassert
+		}
+	}
+
+	require.FailNow(t, "no EngineExtrasV2 log")
+
+	return 0
+}
+
+func TestEngineExecute_InnerCallWarmAddresses(t *testing.T) {
+	var (
+		engine = types.StringToAddress("0xe0")
+		user   = types.StringToAddress("0x700")
+		target = types.StringToAddress("0x800")
+	)
+
+	prevReg, prevEOA := chain.EngineRegistryAddress, chain.BootstrapEngineEOA
+	t.Cleanup(func() {
+		chain.EngineRegistryAddress, chain.BootstrapEngineEOA = prevReg, prevEOA
+	})
+
+	chain.EngineRegistryAddress = types.ZeroAddress
+	chain.BootstrapEngineEOA = engine
+
+	// reads the balances of the caller and of the contract itself,
+	// both are cold unless the addresses are in the access list
+	code := []byte{
+		0x33, // CALLER
+		0x31, // BALANCE
+		0x50, // POP
+		0x30, // ADDRESS
+		0x31, // BALANCE
+		0x50, // POP
+		0x00, // STOP
+	}
+
+	// CALLER, POP, ADDRESS, POP
+	const otherGas = 2 + 2 + 2 + 2
+
+	// the user and the target are warm from the EngineWarmInnerCall fork
+	require.Equal(t, uint64(otherGas+2*100), innerCallGasUsed(t, chain.AllForksEnabled, engine, user, target, code))
+
+	// before the fork they are cold
+	require.Equal(t, uint64(otherGas+2*2600), innerCallGasUsed(t,
+		chain.AllForksEnabled.Copy().RemoveFork(chain.EngineWarmInnerCall), engine, user, target, code))
+}
+
+func TestEngineExecute_Disabled(t *testing.T) {
+	var (
+		user   = types.StringToAddress("0x700")
+		empty  = types.StringToAddress("0x900")
+		reader = types.StringToAddress("0xa00")
+	)
+
+	engineEnabled := false
+
+	e := NewExecutor(&chain.Params{
+		Forks:         chain.AllForksEnabled,
+		EngineEnabled: &engineEnabled,
+		BurnContract: map[uint64]types.Address{
+			0: types.ZeroAddress,
+		},
+	}, &preStateStore{
+		preState: map[types.Address]*PreState{
+			user: {Balance: 1_000_000_000_000_000_000},
+		},
+	}, hclog.NewNullLogger())
+
+	e.GetHash = func(*types.Header) GetHashByNumber {
+		return func(uint64) types.Hash {
+			return types.ZeroHash
+		}
+	}
+
+	transition, err := e.BeginTxn(types.StringToHash("0x1"), &types.Header{Number: 1, GasLimit: 10_000_000}, types.ZeroAddress)
+	require.NoError(t, err)
+
+	// reads the balance of the address, it is cold unless the address is in the access list
+	balanceOf := func(addr types.Address) []byte {
+		code := append([]byte{0x73}, addr.Bytes()...) // PUSH20
+
+		return append(code,
+			0x31, // BALANCE
+			0x50, // POP
+			0x00, // STOP
+		)
+	}
+
+	write := func(to types.Address, input []byte) *types.Receipt {
+		t.Helper()
+
+		require.NoError(t, transition.Write(&types.Transaction{
+			Nonce:    transition.state.GetNonce(user),
+			From:     user,
+			To:       &to,
+			Gas:      100_000,
+			GasPrice: big.NewInt(1),
+			Value:    big.NewInt(0),
+			Input:    input,
+		}))
+
+		receipts := transition.Receipts()
+
+		return receipts[len(receipts)-1]
+	}
+
+	engineExecute := contracts.EngineExecutePrecompile
+	input := []byte{0x01, 0x02, 0x03, 0x04}
+
+	// a call to the engine address behaves like a call to an empty account
+	engineReceipt := write(engineExecute, input)
+	emptyReceipt := write(empty, input)
+
+	require.Equal(t, types.ReceiptSuccess, *engineReceipt.Status)
+	require.Empty(t, engineReceipt.Logs)
+	require.Equal(t, emptyReceipt.GasUsed, engineReceipt.GasUsed)
+
+	// the engine address isn't warm at the start of a transaction
+	transition.state.SetCode(reader, balanceOf(engineExecute))
+	engineReceipt = write(reader, nil)
+
+	transition.state.SetCode(reader, balanceOf(empty))
+	emptyReceipt = write(reader, nil)
+
+	require.Equal(t, types.ReceiptSuccess, *engineReceipt.Status)
+	require.Equal(t, emptyReceipt.GasUsed, engineReceipt.GasUsed)
+
+	// PUSH20, cold BALANCE, POP
+	require.Equal(t, uint64(21_000+3+2600+2), engineReceipt.GasUsed)
+}
+
+func TestEngineExecute_TraceInnerFrames(t *testing.T) {
+	var (
+		engine = types.StringToAddress("0xe0")
+		user   = types.StringToAddress("0x700")
+		target = types.StringToAddress("0x800")
+		leaf   = types.StringToAddress("0x900")
+	)
+
+	prevReg, prevEOA := chain.EngineRegistryAddress, chain.BootstrapEngineEOA
+	t.Cleanup(func() {
+		chain.EngineRegistryAddress, chain.BootstrapEngineEOA = prevReg, prevEOA
+	})
+
+	chain.EngineRegistryAddress = types.ZeroAddress
+	chain.BootstrapEngineEOA = engine
+
+	// Error("nope"), padded to whole words
+	revertData := make([]byte, 128)
+	copy(revertData, []byte{0x08, 0xc3, 0x79, 0xa0})
+	revertData[35] = 0x20
+	revertData[67] = 0x04
+	copy(revertData[68:], "nope")
+
+	// calls the leaf and reverts with the reason
+	code := []byte{
+		0x60, 0x00, // PUSH1 0 (retSize)
+		0x60, 0x00, // PUSH1 0 (retOffset)
+		0x60, 0x00, // PUSH1 0 (argsSize)
+		0x60, 0x00, // PUSH1 0 (argsOffset)
+		0x60, 0x00, // PUSH1 0 (value)
+		0x73, // PUSH20
+	}
+	code = append(code, leaf.Bytes()...)
+	code = append(code,
+		0x5a, // GAS
+		0xf1, // CALL
+		0x50, // POP
+	)
+
+	for i := 0; i < len(revertData); i += 32 {
+		code = append(code, 0x7f) // PUSH32
+		code = append(code, revertData[i:i+32]...)
+		code = append(code,
+			0x60, byte(i), // PUSH1 offset
+			0x52, // MSTORE
+		)
+	}
+
+	code = append(code,
+		0x60, 0x64, // PUSH1 100
+		0x60, 0x00, // PUSH1 0
+		0xfd, // REVERT
+	)
+
+	e := NewExecutor(&chain.Params{
+		Forks: chain.AllForksEnabled,
+		BurnContract: map[uint64]types.Address{
+			0: types.ZeroAddress,
+		},
+	}, &preStateStore{
+		preState: map[types.Address]*PreState{
+			engine: {Balance: 1_000_000_000_000_000_000},
+			user:   {Balance: 1_000_000_000_000_000_000},
+		},
+	}, hclog.NewNullLogger())
+
+	e.GetHash = func(*types.Header) GetHashByNumber {
+		return func(uint64) types.Hash {
+			return types.ZeroHash
+		}
+	}
+
+	transition, err := e.BeginTxn(types.StringToHash("0x1"), &types.Header{Number: 1, GasLimit: 10_000_000}, types.ZeroAddress)
+	require.NoError(t, err)
+
+	transition.state.SetCode(target, code)
+	transition.state.SetCode(leaf, []byte{0x00}) // STOP
+
+	callTracer := &calltracer.CallTracer{}
+	transition.SetTracer(callTracer)
+
+	engineExecute := contracts.EngineExecutePrecompile
+
+	require.NoError(t, transition.Write(&types.Transaction{
+		From:     engine,
+		To:       &engineExecute,
+		Gas:      2_000_000,
+		GasPrice: big.NewInt(1),
+		Value:    big.NewInt(0),
+		Input:    encodeEngineExecute(t, user, engine, target, 100_000),
+	}))
+
+	// the engine transaction succeeds even though the inner call reverts
+	require.Equal(t, types.ReceiptSuccess, *transition.Receipts()[0].Status)
+
+	result, err := callTracer.GetResult()
+	require.NoError(t, err)
+
+	// CALL engine -> ENGINE_EXECUTE -> CALL user -> target -> CALL target -> leaf
+	root := result.(*calltracer.Call) //nolint:forcetypeassert
+	require.Equal(t, "CALL", root.Type)
+	require.Equal(t, engine.String(), root.From)
+	require.Equal(t, engineExecute.String(), root.To)
+	require.Empty(t, root.Error)
+	require.Len(t, root.Calls, 1)
+
+	frame := root.Calls[0]
+	require.Equal(t, "ENGINE_EXECUTE", frame.Type)
+	require.Equal(t, engine.String(), frame.From)
+	require.Equal(t, engineExecute.String(), frame.To)
+	require.Empty(t, frame.Error)
+	require.Len(t, frame.Calls, 1)
+
+	inner := frame.Calls[0]
+	require.Equal(t, "CALL", inner.Type)
+	require.Equal(t, user.String(), inner.From)
+	require.Equal(t, target.String(), inner.To)
+	require.Equal(t, runtime.ErrExecutionReverted.Error(), inner.Error)
+	require.Equal(t, "nope", inner.RevertReason)
+	require.Len(t, inner.Calls, 1)
+
+	nested := inner.Calls[0]
+	require.Equal(t, "CALL", nested.Type)
+	require.Equal(t, target.String(), nested.From)
+	require.Equal(t, leaf.String(), nested.To)
+	require.Empty(t, nested.Error)
+	require.Empty(t, nested.Calls)
+}
+
+// encodeEngineExecute encodes the ENGINE_EXECUTE calldata of a call of the user to the target
+func encodeEngineExecute(t *testing.T, user, engine, target types.Address, gasLimit uint64) []byte {
+	t.Helper()
+
+	input, err := ethabi.MustNewABI(engineabi.ExecuteABI).GetMethod("ENGINE_EXECUTE").Encode(map[string]interface{}{
+		"grant": map[string]interface{}{
+			"from":        user,
+			"engine":      engine,
+			"xrc729":      types.ZeroAddress,
+			"ostcId":      "",
+			"ostcHash":    [32]byte{},
+			"processId":   big.NewInt(1),
+			"maxTotalGas": big.NewInt(0),
+			"expiry":      big.NewInt(0),
+			"sessionId":   big.NewInt(1),
+			"chainId":     big.NewInt(0),
+		},
+		"call": map[string]interface{}{
+			"to":                 target,
+			"data":               []byte{},
+			"valueWei":           big.NewInt(0),
+			"gasLimit":           gasLimit,
+			"validationGas":      uint64(0),
+			"maxFeePerGas":       big.NewInt(1),
+			"deadline":           uint64(0),
+			"grantFeeSeconds":    uint64(0),
+			"grantFeePerYearWei": big.NewInt(0),
+		},
+		"meta": map[string]interface{}{
+			"iteration":     uint64(0),
+			"stepId":        "",
+			"ruleContract":  types.ZeroAddress,
+			"ruleHash":      [32]byte{},
+			"payload":       []byte{},
+			"apiSaves":      []byte{},
+			"contractSaves": []byte{},
+			"extras":        []byte{},
+		},
+	})
+	require.NoError(t, err)
+
+	return input
+}
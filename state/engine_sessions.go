@@ -0,0 +1,37 @@
+package state
+
+import (
+	"math/big"
+
+	"github.com/xgr-network/xgr-node/contracts"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+// EngineNextSessionAt returns kNext(user) of ENGINE_EXECUTE in the state at the given root,
+// which is the id of the next new engine session of the user. Session ids start at 1,
+// so the user has started EngineNextSessionAt-1 sessions by the block of the root.
+// Reading a historical root requires an archive node, the pruned roots aren't found
+func (e *Executor) EngineNextSessionAt(root types.Hash, user types.Address) (*big.Int, error) {
+	snap, err := e.StateAt(root)
+	if err != nil {
+		return nil, err
+	}
+
+	next := big.NewInt(1)
+
+	account, err := snap.GetAccount(contracts.EngineExecutePrecompile)
+	if err != nil {
+		return nil, err
+	}
+
+	if account == nil {
+		return next, nil
+	}
+
+	raw := snap.GetStorage(contracts.EngineExecutePrecompile, account.Root, contracts.EngineNextPidSlotKey(user))
+	if stored := new(big.Int).SetBytes(raw.Bytes()); stored.Sign() > 0 {
+		next = stored
+	}
+
+	return next, nil
+}
@@ -0,0 +1,75 @@
+package state
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xgr-network/xgr-node/chain"
+	"github.com/xgr-network/xgr-node/contracts"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+// rootedStateStore is a State holding the pre state of every known root
+type rootedStateStore struct {
+	faultyState
+
+	roots map[types.Hash]map[types.Address]*PreState
+}
+
+func (r *rootedStateStore) NewSnapshotAt(root types.Hash) (Snapshot, error) {
+	preState, ok := r.roots[root]
+	if !ok {
+		return nil, fmt.Errorf("state root %s: %w", root, ErrStateNotFound)
+	}
+
+	return newStateWithPreState(preState), nil
+}
+
+func TestExecutor_EngineNextSessionAt(t *testing.T) {
+	t.Parallel()
+
+	var (
+		user  = types.StringToAddress("0x700")
+		other = types.StringToAddress("0x800")
+
+		genesisRoot  = types.StringToHash("0x1")
+		sessionsRoot = types.StringToHash("0x2")
+	)
+
+	e := NewExecutor(&chain.Params{Forks: chain.AllForksEnabled}, &rootedStateStore{
+		roots: map[types.Hash]map[types.Address]*PreState{
+			genesisRoot: {
+				contracts.EngineExecutePrecompile: {},
+			},
+			// the user has started three sessions
+			sessionsRoot: {
+				contracts.EngineExecutePrecompile: {
+					State: map[types.Hash]types.Hash{
+						contracts.EngineNextPidSlotKey(user): types.BytesToHash(big.NewInt(4).Bytes()),
+					},
+				},
+			},
+		},
+	}, hclog.NewNullLogger())
+
+	next, err := e.EngineNextSessionAt(sessionsRoot, user)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(4), next)
+
+	// the users without sessions start at 1
+	next, err = e.EngineNextSessionAt(sessionsRoot, other)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1), next)
+
+	next, err = e.EngineNextSessionAt(genesisRoot, user)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1), next)
+
+	// a pruned root can't be read
+	_, err = e.EngineNextSessionAt(types.StringToHash("0x3"), user)
+	require.ErrorIs(t, err, ErrStateNotFound)
+}
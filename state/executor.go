@@ -8,6 +8,7 @@ import (
 	"math"
 	"math/big"
 	"strings"
+	"time"
 
 	"golang.org/x/crypto/sha3"
 
@@ -26,6 +27,31 @@ import (
 
 var SystemAddress = types.StringToAddress("0x0000000000000000000000000000000000009999")
 
+const (
+	// stateRetries is the number of times opening the parent state is retried
+	// when the state storage is temporarily unavailable
+	stateRetries = 3
+
+	// stateRetryDelay is the initial delay between the retries, it doubles on every retry
+	stateRetryDelay = 50 * time.Millisecond
+)
+
+// DefaultStateTxRecipients are the system contracts targeted by the consensus state transactions
+var DefaultStateTxRecipients = []types.Address{
+	contracts.StateReceiverContract,
+	contracts.ValidatorSetContract,
+	contracts.RewardPoolContract,
+}
+
+var (
+	// FeeSplitLogAddress is the pseudo address emitting the fee split log of every transaction
+	FeeSplitLogAddress = types.Address(HexToAddress("0x000000000000000000000000000000000000fEE1"))
+
+	// FeeSplitLogTopic is the topic of the fee split log.
+	// Its data holds the donated, validator and burned fee as 32 byte words
+	FeeSplitLogTopic = types.Hash(Keccak256Hash([]byte("XGRFeeSplit(uint256,uint256,uint256)")))
+)
+
 func Keccak256Hash(data []byte) [32]byte {
 	hash := sha3.NewLegacyKeccak256()
 	hash.Write(data)
@@ -58,6 +84,20 @@ func LeftPadBytes(input []byte, length int) []byte {
 	return padded
 }
 
+// appendWord appends v as a 32 byte word, like LeftPadBytes(v.Bytes(), 32) but without
+// allocating the intermediate slices
+func appendWord(dst []byte, v *big.Int) []byte {
+	if v.BitLen() > 256 {
+		return append(dst, v.Bytes()...)
+	}
+
+	n := len(dst)
+	dst = append(dst, make([]byte, 32)...)
+	v.FillBytes(dst[n:])
+
+	return dst
+}
+
 func hashToUint64(h types.Hash) uint64 {
 	return binary.BigEndian.Uint64(h[24:])
 }
@@ -86,6 +126,29 @@ type Executor struct {
 
 	PostHook        func(txn *Transition)
 	GenesisPostHook func(*Transition) error
+
+	// ReceiptHook is called with the final receipt of every transaction written to a block (optional).
+	// The receipt is part of the receipts root, the hook must not modify it
+	ReceiptHook func(receipt *types.Receipt, txn *types.Transaction)
+
+	// ForceEmptyAccountDeletion deletes empty accounts on commit even if EIP155 is not active,
+	// for regenesis and migrations which need the deletion semantics regardless of the forks
+	ForceEmptyAccountDeletion bool
+
+	// VerifyFeeConservation checks that the fee split of every transaction adds up to the
+	// charged gas, guarding against arithmetic regressions in the fee distribution
+	VerifyFeeConservation bool
+
+	// DonationPercentOverride takes precedence over the donation percent of the EngineRegistry
+	// and the default one, nil keeps the percent of the registry or the default (see resolveDonationConfig)
+	DonationPercentOverride *uint64
+
+	// GetValidators returns the validators of the given block (optional).
+	// They vote on the donation percent, without it nobody is a voter
+	GetValidators func(header *types.Header) ([]types.Address, error)
+
+	// evm is stateless and shared by all the transitions of the executor
+	evm *evm.EVM
 }
 
 // NewExecutor creates a new executor
@@ -94,6 +157,7 @@ func NewExecutor(config *chain.Params, s State, logger hclog.Logger) *Executor {
 		logger: logger,
 		config: config,
 		state:  s,
+		evm:    evm.NewEVM(),
 	}
 }
 
@@ -129,7 +193,7 @@ func (e *Executor) WriteGenesis(
 		auxState:    e.state,
 		gasPool:     uint64(env.GasLimit),
 		config:      config,
-		precompiles: precompiled.NewPrecompiled(),
+		precompiles: e.newPrecompiles(nil),
 	}
 
 	for addr, account := range alloc {
@@ -186,12 +250,41 @@ func (e *Executor) ProcessBlock(
 		return nil, err
 	}
 
-	for _, t := range block.Transactions {
+	// recovering the senders is the bulk of the work on a full block, it is done up front
+	// concurrently, with one signer of the fork config of the block
+	senderErrs := recoverSenders(crypto.NewSigner(txn.config, uint64(txn.ctx.ChainID)),
+		block.Transactions, senderRecoveryWorkers)
+
+	for i, t := range block.Transactions {
 		if t.Gas > block.Header.GasLimit {
+			// the transaction can never fit into the block, strict validation rejects the block
+			if e.config.StrictTxGasLimit {
+				return nil, fmt.Errorf("%w: transaction %d (%s) with gas %d, block gas limit %d",
+					ErrTxGasAboveBlockGasLimit, i, t.Hash, t.Gas, block.Header.GasLimit)
+			}
+
 			continue
 		}
 
+		// the gas pool of each transaction only allows what the previous ones left,
+		// a block which packs more gas than its limit is invalid as a whole
+		if used := txn.TotalGas(); used+t.Gas > block.Header.GasLimit {
+			return nil, fmt.Errorf("%w: transaction %d (%s) with gas %d exceeds the remaining %d of %d",
+				ErrBlockGasLimitExceeded, i, t.Hash, t.Gas, block.Header.GasLimit-used, block.Header.GasLimit)
+		}
+
+		// the same error Write returns for a transaction whose sender can't be recovered
+		if senderErrs[i] != nil {
+			return nil, NewTransitionApplicationError(senderErrs[i], false)
+		}
+
 		if err = txn.Write(t); err != nil {
+			// user transactions can't use the gas reserved for state transactions
+			var gasErr *GasLimitReachedTransitionApplicationError
+			if errors.As(err, &gasErr) {
+				return nil, fmt.Errorf("%w: transaction %d (%s): %w", ErrBlockGasLimitExceeded, i, t.Hash, err)
+			}
+
 			return nil, err
 		}
 	}
@@ -214,6 +307,55 @@ func (e *Executor) GetForksInTime(blockNumber uint64) chain.ForksInTime {
 	return e.config.Forks.At(blockNumber)
 }
 
+// newPrecompiles returns the precompiled contracts of the chain for the block,
+// without ENGINE_EXECUTE if the engine is disabled in the genesis.
+// The validators of the block vote on the donation percent
+func (e *Executor) newPrecompiles(header *types.Header) *precompiled.Precompiled {
+	p := newPrecompiles(e.config)
+
+	if header != nil && e.GetValidators != nil && e.config.DonationGovernance != nil {
+		p.SetDonationGovernance(e.config.DonationGovernance, func() ([]types.Address, error) {
+			return e.GetValidators(header)
+		})
+	}
+
+	return p
+}
+
+// newPrecompiles returns the precompiled contracts for the chain params,
+// nil params stand for the default params (the engine is enabled)
+func newPrecompiles(params *chain.Params) *precompiled.Precompiled {
+	p := precompiled.NewPrecompiled()
+	if params != nil && !params.IsEngineEnabled() {
+		p.UnregisterEngine()
+	}
+
+	if params != nil && params.DonationGovernance != nil {
+		p.SetDonationGovernance(params.DonationGovernance, nil)
+	}
+
+	return p
+}
+
+// snapshotWithRetry returns the snapshot at the given root. Opening it is retried
+// a bounded number of times if the state storage is temporarily unavailable,
+// an unknown root fails right away.
+func (e *Executor) snapshotWithRetry(root types.Hash) (Snapshot, error) {
+	snap, err := e.state.NewSnapshotAt(root)
+
+	delay := stateRetryDelay
+	for i := 0; i < stateRetries && errors.Is(err, ErrStorageUnavailable); i++ {
+		e.logger.Warn("state storage unavailable, retrying", "root", root, "attempt", i+1, "err", err)
+
+		time.Sleep(delay)
+		delay *= 2
+
+		snap, err = e.state.NewSnapshotAt(root)
+	}
+
+	return snap, err
+}
+
 func (e *Executor) BeginTxn(
 	parentRoot types.Hash,
 	header *types.Header,
@@ -221,7 +363,7 @@ func (e *Executor) BeginTxn(
 ) (*Transition, error) {
 	forkConfig := e.config.Forks.At(header.Number)
 
-	auxSnap2, err := e.state.NewSnapshotAt(parentRoot)
+	auxSnap2, err := e.snapshotWithRetry(parentRoot)
 	if err != nil {
 		return nil, err
 	}
@@ -257,47 +399,88 @@ func (e *Executor) BeginTxn(
 		config:   forkConfig,
 		gasPool:  uint64(txCtx.GasLimit),
 
+		systemGasReserve:  e.config.SystemTxGasReserve,
+		stateTxRecipients: newStateTxRecipients(e.config.StateTxRecipients),
+		emitFeeSplitLog:   !forkConfig.FeeSplitLogOptIn || e.config.EmitFeeSplitLog,
+
 		receipts:     []*types.Receipt{},
 		totalGas:     0,
 		donationFee:  nil,
 		validatorFee: nil,
 		burnedFee:    nil,
 
-		evm:         evm.NewEVM(),
-		precompiles: precompiled.NewPrecompiled(),
+		evm:         e.evm,
+		precompiles: e.newPrecompiles(header),
 		PostHook:    e.PostHook,
+		ReceiptHook: e.ReceiptHook,
+
+		engineDisabled: !e.config.IsEngineEnabled(),
+
+		forceEmptyAccountDeletion: e.ForceEmptyAccountDeletion,
+		verifyFeeConservation:     e.VerifyFeeConservation,
+		donationPercentOverride:   e.DonationPercentOverride,
+		defaultDonationPercent:    e.config.DonationPercent(),
 	}
 
 	// enable contract deployment allow list (if any)
 	if e.config.ContractDeployerAllowList != nil {
-		txn.deploymentAllowList = addresslist.NewAddressList(txn, contracts.AllowListContractsAddr)
+		txn.deploymentAllowList = addresslist.NewAddressList(txn, contracts.AllowListContractsAddr, forkConfig.AddressListIndex)
 	}
 
 	if e.config.ContractDeployerBlockList != nil {
-		txn.deploymentBlockList = addresslist.NewAddressList(txn, contracts.BlockListContractsAddr)
+		txn.deploymentBlockList = addresslist.NewAddressList(txn, contracts.BlockListContractsAddr, forkConfig.AddressListIndex)
 	}
 
 	// enable transactions allow list (if any)
 	if e.config.TransactionsAllowList != nil {
-		txn.txnAllowList = addresslist.NewAddressList(txn, contracts.AllowListTransactionsAddr)
+		txn.txnAllowList = addresslist.NewAddressList(txn, contracts.AllowListTransactionsAddr, forkConfig.AddressListIndex)
 	}
 
 	if e.config.TransactionsBlockList != nil {
-		txn.txnBlockList = addresslist.NewAddressList(txn, contracts.BlockListTransactionsAddr)
+		txn.txnBlockList = addresslist.NewAddressList(txn, contracts.BlockListTransactionsAddr, forkConfig.AddressListIndex)
 	}
 
 	// enable transactions allow list (if any)
 	if e.config.BridgeAllowList != nil {
-		txn.bridgeAllowList = addresslist.NewAddressList(txn, contracts.AllowListBridgeAddr)
+		txn.bridgeAllowList = addresslist.NewAddressList(txn, contracts.AllowListBridgeAddr, forkConfig.AddressListIndex)
 	}
 
 	if e.config.BridgeBlockList != nil {
-		txn.bridgeBlockList = addresslist.NewAddressList(txn, contracts.BlockListBridgeAddr)
+		txn.bridgeBlockList = addresslist.NewAddressList(txn, contracts.BlockListBridgeAddr, forkConfig.AddressListIndex)
 	}
 
+	txn.createPrecompileAccounts()
+
 	return txn, nil
 }
 
+// storagePrecompiles are the precompiles keeping their storage in their own account,
+// with the fork enabling each of them
+var storagePrecompiles = []struct {
+	addr    types.Address
+	enabled func(config *chain.ForksInTime) bool
+}{
+	{
+		addr:    contracts.RewardAddressPrecompile,
+		enabled: func(config *chain.ForksInTime) bool { return config.RewardAddress },
+	},
+	{
+		addr:    contracts.DonationGovernancePrecompile,
+		enabled: func(config *chain.ForksInTime) bool { return config.DonationVote },
+	},
+}
+
+// createPrecompileAccounts creates the accounts of the enabled precompiles keeping storage,
+// once their fork is active. Like a deployed contract they get the nonce 1,
+// so they are never removed as empty accounts (EIP-161) together with their storage
+func (t *Transition) createPrecompileAccounts() {
+	for _, p := range storagePrecompiles {
+		if p.enabled(&t.config) && !t.state.Exist(p.addr) {
+			t.state.SetNonce(p.addr, 1)
+		}
+	}
+}
+
 type Transition struct {
 	logger hclog.Logger
 
@@ -311,6 +494,19 @@ type Transition struct {
 	ctx     runtime.TxContext
 	gasPool uint64
 
+	// traceDepth is the depth of the innermost open frame of the tracer
+	traceDepth int
+
+	// systemGasReserve is the part of gasPool that is left for state transactions only
+	systemGasReserve uint64
+
+	// stateTxRecipients are the allowed targets of state transactions (nil disables the check)
+	stateTxRecipients map[types.Address]struct{}
+
+	// emitFeeSplitLog appends the fee split log to the receipt logs
+	// (always before the FeeSplitLogOptIn fork)
+	emitFeeSplitLog bool
+
 	// result
 	receipts     []*types.Receipt
 	totalGas     uint64
@@ -318,6 +514,34 @@ type Transition struct {
 	validatorFee *big.Int
 	burnedFee    *big.Int
 	PostHook     func(t *Transition)
+	ReceiptHook  func(receipt *types.Receipt, txn *types.Transaction)
+
+	// fees sums up the fee split of the written transactions
+	fees *types.BlockEconomics
+
+	// addressBloom collects the addresses that were active in the block
+	addressBloom types.AddressBloom
+
+	// committed are the objects written by Commit
+	committed []*Object
+
+	// deletedAccounts are the accounts deleted by the written transactions
+	deletedAccounts []types.Address
+
+	// forceEmptyAccountDeletion deletes empty accounts on commit regardless of EIP155
+	forceEmptyAccountDeletion bool
+
+	// verifyFeeConservation checks the fee split of every transaction
+	verifyFeeConservation bool
+
+	// donationPercentOverride overrides the donation percent of the fee split (optional)
+	donationPercentOverride *uint64
+
+	// defaultDonationPercent is the donation percent of the fee split while the registry is missing
+	defaultDonationPercent uint64
+
+	// engineDisabled keeps the ENGINE_EXECUTE address out of the warm precompiles
+	engineDisabled bool
 
 	// runtimes
 	evm         *evm.EVM
@@ -332,19 +556,32 @@ type Transition struct {
 	bridgeBlockList     *addresslist.AddressList
 }
 
-func NewTransition(config chain.ForksInTime, snap Snapshot, radix *Txn) *Transition {
+// NewTransition creates a standalone transition on top of the given state.
+// The precompiles follow the chain params, nil params stand for the default params
+func NewTransition(params *chain.Params, config chain.ForksInTime, snap Snapshot, radix *Txn) *Transition {
+	donationPercent := chain.DefaultDonationPercent
+	if params != nil {
+		donationPercent = params.DonationPercent()
+	}
+
 	return &Transition{
-		config:      config,
-		state:       radix,
-		snap:        snap,
-		evm:         evm.NewEVM(),
-		precompiles: precompiled.NewPrecompiled(),
+		config:                 config,
+		state:                  radix,
+		snap:                   snap,
+		evm:                    evm.NewEVM(),
+		precompiles:            newPrecompiles(params),
+		engineDisabled:         params != nil && !params.IsEngineEnabled(),
+		defaultDonationPercent: donationPercent,
 	}
 }
 
+// WithStateOverride applies the overrides on top of the current state.
+// State replaces the whole account storage, StateDiff only patches the given slots.
+// Both can't be combined, except for an empty (non-nil) State which clears the storage
+// before the diff is applied. Empty (non-nil) Code resets the account to EmptyCodeHash.
 func (t *Transition) WithStateOverride(override types.StateOverride) error {
 	for addr, o := range override {
-		if o.State != nil && o.StateDiff != nil {
+		if len(o.State) != 0 && o.StateDiff != nil {
 			return fmt.Errorf("cannot override both state and state diff")
 		}
 
@@ -376,6 +613,30 @@ func (t *Transition) TotalGas() uint64 {
 	return t.totalGas
 }
 
+// FeeSummary returns the fee split totals of the transactions written so far
+func (t *Transition) FeeSummary() *types.BlockEconomics {
+	if t.fees == nil {
+		return types.NewBlockEconomics()
+	}
+
+	return t.fees
+}
+
+// addFees adds the fee split of the last applied transaction to the fee summary
+func (t *Transition) addFees(txn *types.Transaction) {
+	if t.fees == nil {
+		t.fees = types.NewBlockEconomics()
+	}
+
+	t.fees.TotalDonated.Add(t.fees.TotalDonated, t.donationFee)
+	t.fees.TotalValidatorFees.Add(t.fees.TotalValidatorFees, t.validatorFee)
+	t.fees.TotalBurned.Add(t.fees.TotalBurned, t.burnedFee)
+
+	if txn.To != nil && *txn.To == contracts.EngineExecutePrecompile {
+		t.fees.EngineTxCount++
+	}
+}
+
 func (t *Transition) Receipts() []*types.Receipt {
 	return t.receipts
 }
@@ -396,6 +657,15 @@ func (t *Transition) Write(txn *types.Transaction) error {
 		}
 	}
 
+	t.addressBloom.Add(txn.From)
+
+	if txn.To != nil {
+		t.addressBloom.Add(*txn.To)
+	}
+
+	// EIP-1153: every transaction starts with an empty transient storage
+	t.state.ClearTransientStorage()
+
 	// Make a local copy and apply the transaction
 	msg := txn.Copy()
 
@@ -408,41 +678,48 @@ func (t *Transition) Write(txn *types.Transaction) error {
 
 	t.totalGas += result.GasUsed
 
-	topics := []types.Hash{
-		Keccak256Hash([]byte("XGRFeeSplit(uint256,uint256,uint256)")),
-	}
+	t.addFees(txn)
 
-	data := append(
-		LeftPadBytes(t.donationFee.Bytes(), 32),
-		append(
-			LeftPadBytes(t.validatorFee.Bytes(), 32),
-			LeftPadBytes(t.burnedFee.Bytes(), 32)...,
-		)...,
-	)
+	logs := t.state.Logs()
 
-	myLog := &types.Log{
-		Address:     HexToAddress("0x000000000000000000000000000000000000fEE1"),
-		Topics:      topics,
-		Data:        data,
-		BlockNumber: uint64(t.ctx.Number),
-		TxHash:      txn.Hash,
-	}
+	if t.emitFeeSplitLog {
+		data := make([]byte, 0, 3*32)
+		data = appendWord(data, t.donationFee)
+		data = appendWord(data, t.validatorFee)
+		data = appendWord(data, t.burnedFee)
 
-	logs := t.state.Logs()
-	logs = append(logs, myLog)
+		logs = append(logs, &types.Log{
+			Address:     FeeSplitLogAddress,
+			Topics:      []types.Hash{FeeSplitLogTopic},
+			Data:        data,
+			BlockNumber: uint64(t.ctx.Number),
+			TxHash:      txn.Hash,
+		})
+	}
 
 	receipt := &types.Receipt{
 		CumulativeGasUsed: t.totalGas,
 		TransactionType:   txn.Type,
 		TxHash:            txn.Hash,
 		GasUsed:           result.GasUsed,
+		From:              txn.From,
+		To:                txn.To,
+		EffectiveGasPrice: txn.GetGasPrice(t.ctx.BaseFee.Uint64()),
+		FeeSplit: &types.FeeSplitInfo{
+			Donation:  new(big.Int).Set(t.donationFee),
+			Validator: new(big.Int).Set(t.validatorFee),
+			Burned:    new(big.Int).Set(t.burnedFee),
+		},
 	}
 
 	// The suicided accounts are set as deleted for the next iteration
-	if err := t.state.CleanDeleteObjects(true); err != nil {
+	deleted, err := t.state.CleanDeleteObjects(true)
+	if err != nil {
 		return fmt.Errorf("failed to clean deleted objects: %w", err)
 	}
 
+	t.deletedAccounts = append(t.deletedAccounts, deleted...)
+
 	if result.Failed() {
 		receipt.SetStatus(types.ReceiptFailed)
 	} else {
@@ -459,16 +736,27 @@ func (t *Transition) Write(txn *types.Transaction) error {
 	receipt.LogsBloom = types.CreateBloom([]*types.Receipt{receipt})
 	t.receipts = append(t.receipts, receipt)
 
+	if t.ReceiptHook != nil {
+		t.ReceiptHook(receipt, txn)
+	}
+
 	return nil
 }
 
 // Commit commits the final result
 func (t *Transition) Commit() (Snapshot, types.Hash, error) {
-	objs, err := t.state.Commit(t.config.EIP155)
+	objs, err := t.state.Commit(t.config.EIP155 || t.forceEmptyAccountDeletion)
 	if err != nil {
 		return nil, types.ZeroHash, err
 	}
 
+	// every committed object was touched by the block (balance, nonce, code or storage)
+	for _, obj := range objs {
+		t.addressBloom.Add(obj.Address)
+	}
+
+	t.committed = objs
+
 	s2, root, err := t.snap.Commit(objs)
 	if err != nil {
 		return nil, types.ZeroHash, err
@@ -477,8 +765,40 @@ func (t *Transition) Commit() (Snapshot, types.Hash, error) {
 	return s2, types.BytesToHash(root), nil
 }
 
-func (t *Transition) subGasPool(amount uint64) error {
-	if t.gasPool < amount {
+// Root returns the state root of the transition without writing the state to the storage,
+// the block previews use it for transitions which are discarded afterwards
+func (t *Transition) Root() (types.Hash, error) {
+	objs, err := t.state.Commit(t.config.EIP155 || t.forceEmptyAccountDeletion)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	root, err := t.snap.Root(objs)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	return types.BytesToHash(root), nil
+}
+
+// AddressBloom returns the address activity bloom of the block.
+// It is complete only after Commit has been called.
+func (t *Transition) AddressBloom() types.AddressBloom {
+	return t.addressBloom
+}
+
+func (t *Transition) subGasPool(amount uint64, isStateTx bool) error {
+	available := t.gasPool
+	if !isStateTx {
+		// user transactions must leave the system reserve untouched
+		if available < t.systemGasReserve {
+			return ErrBlockLimitReached
+		}
+
+		available -= t.systemGasReserve
+	}
+
+	if available < amount {
 		return ErrBlockLimitReached
 	}
 
@@ -487,6 +807,15 @@ func (t *Transition) subGasPool(amount uint64) error {
 	return nil
 }
 
+// consumeSystemGasReserve charges the gas used by a state transaction against the system reserve
+func (t *Transition) consumeSystemGasReserve(gasUsed uint64) {
+	if gasUsed > t.systemGasReserve {
+		gasUsed = t.systemGasReserve
+	}
+
+	t.systemGasReserve -= gasUsed
+}
+
 func (t *Transition) addGasPool(amount uint64) {
 	t.gasPool += amount
 }
@@ -495,6 +824,18 @@ func (t *Transition) Txn() *Txn {
 	return t.state
 }
 
+// DirtyAccounts returns the accounts whose balance, nonce, code or storage was modified
+// during the transition, see Txn.DirtyAccounts
+func (t *Transition) DirtyAccounts() []types.Address {
+	return t.state.DirtyAccounts()
+}
+
+// DeletedAccounts returns the accounts deleted by the transactions written so far,
+// the suicided ones and the empty ones, in the order of deletion
+func (t *Transition) DeletedAccounts() []types.Address {
+	return t.deletedAccounts
+}
+
 // Apply applies a new transaction
 func (t *Transition) Apply(msg *types.Transaction) (*runtime.ExecutionResult, error) {
 	s := t.state.Snapshot()
@@ -513,6 +854,28 @@ func (t *Transition) Apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 	return result, err
 }
 
+// ApplyReadOnly runs the message and unconditionally reverts every state change afterwards
+// (nonces, balances, refunds, fee distribution and logs). It is meant for eth_call like
+// consumers, which are only interested in the execution result. Tracer hooks still fire.
+func (t *Transition) ApplyReadOnly(msg *types.Transaction) (*runtime.ExecutionResult, error) {
+	s := t.state.Snapshot()
+
+	// the block level counters are touched by apply as well
+	gasPool := t.gasPool
+	donationFee, validatorFee, burnedFee := t.donationFee, t.validatorFee, t.burnedFee
+
+	result, err := t.apply(msg)
+
+	t.gasPool = gasPool
+	t.donationFee, t.validatorFee, t.burnedFee = donationFee, validatorFee, burnedFee
+
+	if revertErr := t.state.RevertToSnapshot(s); revertErr != nil {
+		return nil, revertErr
+	}
+
+	return result, err
+}
+
 // ContextPtr returns reference of context
 // This method is called only by test
 func (t *Transition) ContextPtr() *runtime.TxContext {
@@ -536,26 +899,86 @@ func (t *Transition) subGasLimitPrice(msg *types.Transaction) error {
 func (t *Transition) nonceCheck(msg *types.Transaction) error {
 	nonce := t.state.GetNonce(msg.From)
 
-	if nonce != msg.Nonce {
-		return ErrNonceIncorrect
+	if msg.Nonce < nonce {
+		return fmt.Errorf("%w: address %v, tx: %d state: %d", ErrNonceTooLow, msg.From, msg.Nonce, nonce)
+	}
+
+	if msg.Nonce > nonce {
+		return fmt.Errorf("%w: address %v, tx: %d state: %d", ErrNonceTooHigh, msg.From, msg.Nonce, nonce)
 	}
 
 	return nil
 }
 
+// senderHasCode reports whether code is deployed at the address
+func (t *Transition) senderHasCode(addr types.Address) bool {
+	codeHash := t.state.GetCodeHash(addr)
+
+	return codeHash != types.ZeroHash && codeHash != types.EmptyCodeHash
+}
+
 // checkDynamicFees checks correctness of the EIP-1559 feature-related fields.
 // Basically, makes sure gas tip cap and gas fee cap are good for dynamic and legacy transactions
 func (t *Transition) checkDynamicFees(msg *types.Transaction) error {
 	return GetLondonFixHandler(uint64(t.ctx.Number)).checkDynamicFees(msg, t)
 }
 
+// checkMinBaseFee rejects transactions whose effective gas price is below the minimum
+// base fee of the EngineRegistry. There is no floor if the registry is not deployed
+func (t *Transition) checkMinBaseFee(msg *types.Transaction) error {
+	minBaseFee, ok := t.minBaseFee()
+	if !ok || minBaseFee == 0 {
+		return nil
+	}
+
+	var baseFee uint64
+	if t.ctx.BaseFee != nil {
+		baseFee = t.ctx.BaseFee.Uint64()
+	}
+
+	if gasPrice := msg.GetGasPrice(baseFee); gasPrice.Cmp(new(big.Int).SetUint64(minBaseFee)) < 0 {
+		return fmt.Errorf("%w: address %v, effective gas price: %s, minimum base fee: %d",
+			ErrGasPriceBelowMinBaseFee, msg.From, gasPrice, minBaseFee)
+	}
+
+	return nil
+}
+
+// minBaseFee reads the minimum base fee from the EngineRegistry storage.
+// It returns false if the registry is not deployed or the value doesn't fit into uint64
+func (t *Transition) minBaseFee() (uint64, bool) {
+	reg := chain.EngineRegistryAddress
+	if reg == (types.Address{}) || len(t.state.GetCode(reg)) == 0 {
+		return 0, false
+	}
+
+	raw := t.state.GetState(reg, chain.EngineRegistrySlotKeyMinBaseFee())
+
+	v := new(big.Int).SetBytes(raw.Bytes())
+	if v.BitLen() > 64 {
+		return 0, false
+	}
+
+	return v.Uint64(), true
+}
+
 // errors that can originate in the consensus rules checks of the apply method below
 // surfacing of these errors reject the transaction thus not including it in the block
 
 var (
-	ErrNonceIncorrect          = errors.New("incorrect nonce")
+	// ErrNonceIncorrect is the parent of ErrNonceTooLow and ErrNonceTooHigh
+	ErrNonceIncorrect = errors.New("incorrect nonce")
+
+	// ErrNonceTooLow is returned if the nonce of the transaction was already used by the sender
+	ErrNonceTooLow error = &nonceError{"nonce too low"}
+
+	// ErrNonceTooHigh is returned if the nonce of the transaction is ahead of the sender nonce
+	ErrNonceTooHigh error = &nonceError{"nonce too high"}
+
 	ErrNotEnoughFundsForGas    = errors.New("not enough funds to cover gas costs")
 	ErrBlockLimitReached       = errors.New("gas limit reached in the pool")
+	ErrBlockGasLimitExceeded   = errors.New("block transactions exceed the block gas limit")
+	ErrTxGasAboveBlockGasLimit = errors.New("transaction gas exceeds the block gas limit")
 	ErrIntrinsicGasOverflow    = errors.New("overflow in intrinsic gas calculation")
 	ErrNotEnoughIntrinsicGas   = errors.New("not enough gas supplied for intrinsic gas costs")
 	ErrMaxInitCodeSizeExceeded = errors.New("max initcode size exceeded")
@@ -581,8 +1004,32 @@ var (
 
 	// ErrNonceUintOverflow is returned if uint64 overflow happens
 	ErrNonceUintOverflow = errors.New("nonce uint64 overflow")
+
+	// ErrGasPriceBelowMinBaseFee is returned if the effective gas price of the transaction
+	// is less than the minimum base fee configured in the EngineRegistry
+	ErrGasPriceBelowMinBaseFee = errors.New("effective gas price below the minimum base fee")
+
+	// ErrSenderNoEOA is returned if the sender of a transaction has deployed code (EIP-3607)
+	ErrSenderNoEOA = errors.New("sender not an eoa")
+
+	// ErrFeeNotConserved is returned if the fee split and the refund of a transaction
+	// don't add up to the gas charged for it
+	ErrFeeNotConserved = errors.New("fee split does not add up to the charged gas")
 )
 
+// nonceError is a nonce check failure, it matches ErrNonceIncorrect
+type nonceError struct {
+	msg string
+}
+
+func (e *nonceError) Error() string {
+	return e.msg
+}
+
+func (e *nonceError) Is(target error) bool {
+	return target == ErrNonceIncorrect //nolint:errorlint
+}
+
 type TransitionApplicationError struct {
 	Err           error
 	IsRecoverable bool // Should the transaction be discarded, or put back in the queue.
@@ -592,6 +1039,10 @@ func (e *TransitionApplicationError) Error() string {
 	return e.Err.Error()
 }
 
+func (e *TransitionApplicationError) Unwrap() error {
+	return e.Err
+}
+
 func NewTransitionApplicationError(err error, isRecoverable bool) *TransitionApplicationError {
 	return &TransitionApplicationError{
 		Err:           err,
@@ -613,7 +1064,7 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 	var err error
 
 	if msg.Type == types.StateTx {
-		err = checkAndProcessStateTx(msg)
+		err = checkAndProcessStateTx(msg, t.stateTxRecipients)
 	} else {
 		err = checkAndProcessTx(msg, t)
 	}
@@ -623,7 +1074,7 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 	}
 
 	// the amount of gas required is available in the block
-	if err = t.subGasPool(msg.Gas); err != nil {
+	if err = t.subGasPool(msg.Gas, msg.Type == types.StateTx); err != nil {
 		return nil, NewGasLimitReachedTransitionApplicationError(err)
 	}
 
@@ -668,7 +1119,11 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 		if t.config.EIP3651 {
 			init = append(init, t.ctx.Coinbase)
 		}
-		t.ctx.AccessList = runtime.NewAccessList(init...)
+		if t.engineDisabled {
+			t.ctx.AccessList = runtime.NewAccessListWithoutEngine(init...)
+		} else {
+			t.ctx.AccessList = runtime.NewAccessList(init...)
+		}
 
 		if t.config.EIP2930 && len(msg.AccessList) > 0 {
 			for _, tuple := range msg.AccessList {
@@ -682,6 +1137,15 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 		t.ctx.AccessList = nil
 	}
 
+	// Simulations (eth_call, eth_estimateGas) credit the caller with the value it lacks,
+	// so calls with value succeed regardless of the sender balance. The callee still
+	// receives the full value.
+	if t.ctx.NonPayable && value.Sign() > 0 {
+		if balance := t.state.GetBalance(msg.From); balance.Cmp(value) < 0 {
+			t.state.AddBalance(msg.From, new(big.Int).Sub(value, balance))
+		}
+	}
+
 	var result *runtime.ExecutionResult
 	if msg.IsContractCreation() {
 		result = t.Create2(msg.From, msg.Input, value, gasLeft)
@@ -692,8 +1156,13 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 		result = t.Call2(msg.From, *msg.To, msg.Input, value, gasLeft)
 	}
 
+	// EIP-1153 and EIP-6780: the transient storage and the created accounts
+	// only live for the duration of the transaction
+	t.state.ClearTransientStorage()
+	t.state.ClearCreated()
+
 	refund := t.state.GetRefund()
-	result.UpdateGasUsed(msg.Gas, refund)
+	result.UpdateGasUsed(msg.Gas, refund, t.config.EIP3529)
 
 	if t.ctx.Tracer != nil {
 		t.ctx.Tracer.TxEnd(result.GasLeft)
@@ -706,62 +1175,21 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 	// Berechne gesamte Fee = gasUsed × gasPrice
 	totalFeeRaw := new(big.Int).Mul(new(big.Int).SetUint64(result.GasUsed), gasPrice)
 
-	// ziehe Burning Betrag ab (clamped; niemals negative Fees erzeugen)
-	burned := big.NewInt(0).Mul(big.NewInt(1000), big.NewInt(1_000_000_000))
-	burnedApplied := new(big.Int).Set(burned)
-	totalFee := new(big.Int).Set(totalFeeRaw)
-	if totalFee.Cmp(burnedApplied) <= 0 {
-		// Fee reicht nicht für den fixen Burn -> alles geht an Burn, Rest = 0
-		burnedApplied.Set(totalFee)
-		totalFee.SetInt64(0)
-	} else {
-		totalFee.Sub(totalFee, burnedApplied)
-	}
-
 	// Lade optionale Konfigurationen aus dem State
 	burnedAddr := chain.DefaultBurnedAddress
-	donationAddr := chain.DefaultDonationAddress
-	donationPercent := chain.DefaultDonationPercent
+	donationAddr, donationPercent := resolveDonationConfig(t)
 
-	// Donation config analog minBaseFee: read from EngineRegistry storage slots (if deployed).
-	// If registry is missing (address==0 or code-size==0), keep DefaultDonation*.
-	if chain.EngineRegistryAddress != (types.Address{}) {
-		if code := t.state.GetCode(chain.EngineRegistryAddress); len(code) > 0 {
-			// donationAddress: address is right-aligned in last 20 bytes of the slot
-			addrSlot := t.state.GetState(chain.EngineRegistryAddress, chain.EngineRegistrySlotKeyDonationAddress())
-			var regAddr types.Address
-			copy(regAddr[:], addrSlot[12:32])
-			// donationPercent: uint256 (accept 0..100)
-			pctSlot := t.state.GetState(chain.EngineRegistryAddress, chain.EngineRegistrySlotKeyDonationPercent())
-			pct := new(big.Int).SetBytes(pctSlot[:])
-			if pct.Sign() >= 0 && pct.BitLen() <= 64 {
-				p := pct.Uint64()
-				if p <= 100 {
-					donationPercent = p
-				}
-			}
-			// Safety: if address is zero => donation disabled
-			if regAddr == types.ZeroAddress {
-				donationPercent = 0
-			} else {
-				donationAddr = regAddr
-			}
+	donation, validator, burnedApplied := ComputeFeeSplit(
+		totalFeeRaw,
+		new(big.Int).SetUint64(chain.FixedBurnWei),
+		donationPercent,
+	)
+	if t.verifyFeeConservation {
+		charged := new(big.Int).Mul(new(big.Int).SetUint64(msg.Gas), gasPrice)
+		if err := verifyFeeConservation(charged, donation, validator, burnedApplied, remaining); err != nil {
+			return nil, err
 		}
 	}
-
-	// Berechne Aufteilung: Donation + Validator
-	donation := new(big.Int).Mul(totalFee, new(big.Int).SetUint64(donationPercent))
-	donation.Div(donation, big.NewInt(100))
-	if donation.Sign() < 0 {
-		donation.SetInt64(0)
-	}
-	if donation.Cmp(totalFee) > 0 {
-		donation.Set(totalFee)
-	}
-	validator := new(big.Int).Sub(totalFee, donation)
-	if validator.Sign() < 0 {
-		validator.SetInt64(0)
-	}
 	// Verteile Fee
 	if donation.Sign() > 0 {
 		t.state.AddBalance(donationAddr, donation)
@@ -777,9 +1205,102 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 	t.burnedFee = burnedApplied
 	// return gas to the pool
 	t.addGasPool(result.GasLeft)
+
+	if msg.Type == types.StateTx {
+		t.consumeSystemGasReserve(result.GasUsed)
+	}
+
 	return result, nil
 }
 
+// resolveDonationConfig returns the recipient and the percent of the donation fee.
+// The percent is taken from the first configured source, in order of precedence:
+//
+// 1. the executor override (Executor.DonationPercentOverride)
+// 2. the donationPercent slot of the EngineRegistry, if the registry is deployed
+// 3. the default percent of the chain (genesis params.defaultDonationPercent or chain.DefaultDonationPercent)
+//
+// The recipient is the donationAddress of a deployed EngineRegistry, or chain.DefaultDonationAddress.
+// A registry with a zero donationAddress disables the donation, unless the percent is overridden.
+// An override above 100 is ignored
+func resolveDonationConfig(host *Transition) (types.Address, uint64) {
+	donationAddr, donationPercent := chain.DefaultDonationAddress, host.defaultDonationPercent
+
+	// Donation config analog minBaseFee: read from EngineRegistry storage slots (if deployed).
+	// If registry is missing (address==0 or code-size==0), keep the default address and percent.
+	if chain.EngineRegistryAddress != (types.Address{}) {
+		if code := host.state.GetCode(chain.EngineRegistryAddress); len(code) > 0 {
+			donationAddr, donationPercent = chain.ResolveDonation(
+				host.state.GetState(chain.EngineRegistryAddress, chain.EngineRegistrySlotKeyDonationAddress()),
+				host.state.GetState(chain.EngineRegistryAddress, chain.EngineRegistrySlotKeyDonationPercent()),
+				host.defaultDonationPercent,
+			)
+		}
+	}
+
+	if override := host.donationPercentOverride; override != nil && *override <= 100 {
+		donationPercent = *override
+	}
+
+	return donationAddr, donationPercent
+}
+
+// ComputeFeeSplit splits the fee of a transaction into the donation, the validator fee and the burned amount.
+// The fixed burn is taken first and clamped to the fee, the donation is donationPercent of the remainder
+// and the validator gets the rest. A percent above 100 is clamped to 100. The parts always sum up to the fee
+func ComputeFeeSplit(totalFeeRaw, fixedBurn *big.Int, donationPercent uint64) (donation, validator, burned *big.Int) {
+	if donationPercent > 100 {
+		donationPercent = 100
+	}
+
+	// ziehe Burning Betrag ab (clamped; niemals negative Fees erzeugen)
+	burned = new(big.Int).Set(fixedBurn)
+	totalFee := new(big.Int).Set(totalFeeRaw)
+
+	if totalFee.Cmp(burned) <= 0 {
+		// Fee reicht nicht für den fixen Burn -> alles geht an Burn, Rest = 0
+		burned.Set(totalFee)
+		totalFee.SetInt64(0)
+	} else {
+		totalFee.Sub(totalFee, burned)
+	}
+
+	// Berechne Aufteilung: Donation + Validator
+	donation = new(big.Int).Mul(totalFee, new(big.Int).SetUint64(donationPercent))
+	donation.Div(donation, big.NewInt(100))
+
+	if donation.Sign() < 0 {
+		donation.SetInt64(0)
+	}
+
+	if donation.Cmp(totalFee) > 0 {
+		donation.Set(totalFee)
+	}
+
+	validator = new(big.Int).Sub(totalFee, donation)
+	if validator.Sign() < 0 {
+		validator.SetInt64(0)
+	}
+
+	return donation, validator, burned
+}
+
+// verifyFeeConservation checks that the gas charged for a transaction (gas limit × gas price)
+// equals exactly the donation, validator fee, burned amount and the refund of the unused gas
+func verifyFeeConservation(charged, donation, validator, burned, refund *big.Int) error {
+	distributed := new(big.Int).Add(donation, validator)
+	distributed.Add(distributed, burned)
+	distributed.Add(distributed, refund)
+
+	if distributed.Cmp(charged) != 0 {
+		return fmt.Errorf("%w: charged %s, donation %s + validator %s + burned %s + refund %s = %s (diff %s)",
+			ErrFeeNotConserved, charged, donation, validator, burned, refund, distributed,
+			new(big.Int).Sub(distributed, charged))
+	}
+
+	return nil
+}
+
 func (t *Transition) Create2(
 	caller types.Address,
 	code []byte,
@@ -788,6 +1309,7 @@ func (t *Transition) Create2(
 ) *runtime.ExecutionResult {
 	address := crypto.CreateAddress(caller, t.state.GetNonce(caller))
 	contract := runtime.NewContractCreation(1, caller, caller, address, value, gas, code)
+	defer runtime.ReleaseContract(contract)
 
 	return t.applyCreate(contract, t)
 }
@@ -800,6 +1322,7 @@ func (t *Transition) Call2(
 	gas uint64,
 ) *runtime.ExecutionResult {
 	c := runtime.NewContractCall(1, caller, caller, to, value, gas, t.state.GetCode(to), input)
+	defer runtime.ReleaseContract(c)
 
 	return t.applyCall(c, runtime.Call, t)
 }
@@ -810,42 +1333,63 @@ func (t *Transition) run(contract *runtime.Contract, host runtime.Host) *runtime
 	}
 
 	// check txns access lists, allow list takes precedence over block list
-	if t.txnAllowList != nil {
-		if contract.Caller != contracts.SystemCaller {
-			role := t.txnAllowList.GetRole(contract.Caller)
-			if !role.Enabled() {
-				t.logger.Debug(
-					"Failing transaction. Caller is not in the transaction allowlist",
-					"contract.Caller", contract.Caller,
-					"contract.Address", contract.Address,
-				)
+	if contract.Caller != contracts.SystemCaller {
+		if list, listType := addresslist.Check(t.txnAllowList, t.txnBlockList, contract.Caller); list != nil {
+			t.logger.Debug(
+				"Failing transaction. Caller is rejected by the transaction "+listType.String(),
+				"contract.Caller", contract.Caller,
+				"contract.Address", contract.Address,
+			)
 
-				return &runtime.ExecutionResult{
-					GasLeft: 0,
-					Err:     runtime.ErrNotAuth,
-				}
+			return &runtime.ExecutionResult{
+				GasLeft: 0,
+				Err:     runtime.ErrNotAuth,
 			}
 		}
-	} else if t.txnBlockList != nil {
-		if contract.Caller != contracts.SystemCaller {
-			role := t.txnBlockList.GetRole(contract.Caller)
-			if role == addresslist.EnabledRole {
-				t.logger.Debug(
-					"Failing transaction. Caller is in the transaction blocklist",
-					"contract.Caller", contract.Caller,
-					"contract.Address", contract.Address,
-				)
+	}
 
-				return &runtime.ExecutionResult{
-					GasLeft: 0,
-					Err:     runtime.ErrNotAuth,
-				}
+	// check bridge access lists on calls into the bridge. Calls made by the bridge itself
+	// (state sync deliveries and calls between bridge contracts) are not gated
+	if t.isGatedBridgeCall(contract) {
+		if list, listType := addresslist.Check(t.bridgeAllowList, t.bridgeBlockList, contract.Caller); list != nil {
+			t.logger.Debug(
+				"Failing bridge transaction. Caller is rejected by the bridge "+listType.String(),
+				"contract.Caller", contract.Caller,
+				"contract.Address", contract.Address,
+			)
+
+			return &runtime.ExecutionResult{
+				GasLeft: 0,
+				Err:     runtime.ErrNotAuth,
+			}
+		}
+	}
+
+	// in strict mode the recipient of a top-level value transfer has to pass the bridge block list too
+	if t.isGatedBridgeTransfer(contract) {
+		if list, listType := addresslist.Check(nil, t.bridgeBlockList, contract.Address); list != nil {
+			t.logger.Debug(
+				"Failing value transfer. Recipient is rejected by the bridge "+listType.String(),
+				"contract.Caller", contract.Caller,
+				"contract.Address", contract.Address,
+			)
+
+			return &runtime.ExecutionResult{
+				GasLeft: 0,
+				Err:     runtime.ErrNotAuth,
 			}
 		}
 	}
 
 	// check the precompiles
 	if t.precompiles.CanRun(contract, host, &t.config) {
+		if t.capturePrecompileStart(contract) {
+			result := t.precompiles.Run(contract, host, &t.config)
+			t.captureCallEnd(contract, result)
+
+			return result
+		}
+
 		return t.precompiles.Run(contract, host, &t.config)
 	}
 	// check the evm
@@ -989,6 +1533,9 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 			Err:     runtime.ErrOutOfGas,
 		}
 	}
+	// EIP-6780: the contract can only be deleted by SELFDESTRUCT in this transaction
+	t.state.MarkCreated(c.Address)
+
 	if t.config.EIP158 {
 		// Force the creation of the account
 		t.state.CreateAccount(c.Address)
@@ -1015,36 +1562,21 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 		t.captureCallEnd(c, result)
 	}()
 
-	// check if contract creation allow list is enabled
-	if t.deploymentAllowList != nil {
-		role := t.deploymentAllowList.GetRole(c.Caller)
-
-		if !role.Enabled() {
-			t.logger.Debug(
-				"Failing contract deployment. Caller is not in the deployment allowlist",
-				"contract.Caller", c.Caller,
-				"contract.Address", c.Address,
-			)
+	// check contract creation access lists, allow list takes precedence over block list
+	if list, listType := addresslist.Check(t.deploymentAllowList, t.deploymentBlockList, c.Caller); list != nil {
+		t.logger.Debug(
+			"Failing contract deployment. Caller is rejected by the deployment "+listType.String(),
+			"contract.Caller", c.Caller,
+			"contract.Address", c.Address,
+		)
 
-			return &runtime.ExecutionResult{
-				GasLeft: 0,
-				Err:     runtime.ErrNotAuth,
-			}
+		if t.config.DeploymentRejectedLog {
+			t.emitDeploymentRejected(list, listType, c)
 		}
-	} else if t.deploymentBlockList != nil {
-		role := t.deploymentBlockList.GetRole(c.Caller)
-
-		if role == addresslist.EnabledRole {
-			t.logger.Debug(
-				"Failing contract deployment. Caller is in the deployment blocklist",
-				"contract.Caller", c.Caller,
-				"contract.Address", c.Address,
-			)
 
-			return &runtime.ExecutionResult{
-				GasLeft: 0,
-				Err:     runtime.ErrNotAuth,
-			}
+		return &runtime.ExecutionResult{
+			GasLeft: 0,
+			Err:     runtime.ErrNotAuth,
 		}
 	}
 
@@ -1109,6 +1641,59 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 	return result
 }
 
+// isGatedBridgeCall reports whether the call enters the bridge from outside,
+// so its caller has to pass the bridge access lists (BridgeCallAccessLists fork)
+func (t *Transition) isGatedBridgeCall(contract *runtime.Contract) bool {
+	if !t.config.BridgeCallAccessLists || (t.bridgeAllowList == nil && t.bridgeBlockList == nil) {
+		return false
+	}
+
+	return contracts.IsBridgeContract(contract.Address) &&
+		contract.Caller != contracts.SystemCaller &&
+		contract.Caller != contracts.StateReceiverContract &&
+		!contracts.IsBridgeContract(contract.Caller)
+}
+
+// isGatedBridgeTransfer reports whether the call is a top-level value transfer to an account
+// without code, whose recipient has to pass the bridge block list (StrictBridgeBlockList fork).
+// Transfers made by contracts are nested calls and are not gated
+func (t *Transition) isGatedBridgeTransfer(contract *runtime.Contract) bool {
+	if !t.config.StrictBridgeBlockList || t.bridgeBlockList == nil {
+		return false
+	}
+
+	return contract.Depth == 1 &&
+		contract.Caller != contracts.SystemCaller &&
+		contract.Value != nil && contract.Value.Sign() > 0 &&
+		len(contract.Code) == 0
+}
+
+// AddressRoles returns the role of addr in each configured access list,
+// keyed by the list name used in the chain params (e.g. "transactionsAllowList")
+func (t *Transition) AddressRoles(addr types.Address) map[string]addresslist.Role {
+	lists := []struct {
+		name string
+		list *addresslist.AddressList
+	}{
+		{"contractDeployerAllowList", t.deploymentAllowList},
+		{"contractDeployerBlockList", t.deploymentBlockList},
+		{"transactionsAllowList", t.txnAllowList},
+		{"transactionsBlockList", t.txnBlockList},
+		{"bridgeAllowList", t.bridgeAllowList},
+		{"bridgeBlockList", t.bridgeBlockList},
+	}
+
+	roles := make(map[string]addresslist.Role, len(lists))
+
+	for _, l := range lists {
+		if l.list != nil {
+			roles[l.name] = l.list.GetRole(addr)
+		}
+	}
+
+	return roles
+}
+
 func (t *Transition) handleAllowBlockListsUpdate(contract *runtime.Contract,
 	host runtime.Host) *runtime.ExecutionResult {
 	// check contract deployment allow list (if any)
@@ -1148,6 +1733,14 @@ func (t *Transition) SetState(addr types.Address, key types.Hash, value types.Ha
 	t.state.SetState(addr, key, value)
 }
 
+func (t *Transition) GetTransientStorage(addr types.Address, key types.Hash) types.Hash {
+	return t.state.GetTransientState(addr, key)
+}
+
+func (t *Transition) SetTransientStorage(addr types.Address, key types.Hash, value types.Hash) {
+	t.state.SetTransientState(addr, key, value)
+}
+
 func (t *Transition) SetStorage(
 	addr types.Address,
 	key types.Hash,
@@ -1165,6 +1758,17 @@ func (t *Transition) GetBlockHash(number int64) (res types.Hash) {
 	return t.getHash(uint64(number))
 }
 
+// emitDeploymentRejected logs the rejection from the list contract address,
+// so the receipt of the failed deployment carries the reason (DeploymentRejectedLog fork)
+func (t *Transition) emitDeploymentRejected(
+	list *addresslist.AddressList,
+	listType addresslist.ListType,
+	c *runtime.Contract,
+) {
+	topics, data := addresslist.DeploymentRejectedLog(c.Caller, c.Address, listType)
+	t.state.EmitLog(list.Addr(), topics, data)
+}
+
 func (t *Transition) EmitLog(addr types.Address, topics []types.Hash, data []byte) {
 	t.state.EmitLog(addr, topics, data)
 }
@@ -1202,7 +1806,14 @@ func (t *Transition) GetNonce(addr types.Address) uint64 {
 }
 
 func (t *Transition) Selfdestruct(addr types.Address, beneficiary types.Address) {
-	if !t.state.HasSuicided(addr) {
+	if t.config.EIP6780 {
+		t.selfdestructEIP6780(addr, beneficiary)
+
+		return
+	}
+
+	// EIP-3529 removed the refund
+	if !t.state.HasSuicided(addr) && !t.config.EIP3529 {
 		t.state.AddRefund(24000)
 	}
 
@@ -1210,6 +1821,26 @@ func (t *Transition) Selfdestruct(addr types.Address, beneficiary types.Address)
 	t.state.Suicide(addr)
 }
 
+// selfdestructEIP6780 implements SELFDESTRUCT under EIP-6780. A contract created in the
+// current transaction is deleted as before, any other contract only sends its balance
+// to the beneficiary and keeps its code and storage. There is no refund in either case
+func (t *Transition) selfdestructEIP6780(addr types.Address, beneficiary types.Address) {
+	if t.state.IsCreated(addr) {
+		t.state.AddBalance(beneficiary, t.state.GetBalance(addr))
+		t.state.Suicide(addr)
+
+		return
+	}
+
+	// the balance stays with the contract if it is its own beneficiary
+	if beneficiary == addr {
+		return
+	}
+
+	t.state.AddBalance(beneficiary, t.state.GetBalance(addr))
+	t.state.SetBalance(addr, big.NewInt(0))
+}
+
 func (t *Transition) Callx(c *runtime.Contract, h runtime.Host) *runtime.ExecutionResult {
 	if c.Type == runtime.Create {
 		return t.applyCreate(c, h)
@@ -1366,9 +1997,16 @@ func checkAndProcessTx(msg *types.Transaction, t *Transition) error {
 	if t.config.EIP3860 && msg.IsContractCreation() && len(msg.Input) > TxPoolMaxInitCodeSize {
 		return NewTransitionApplicationError(ErrMaxInitCodeSizeExceeded, true)
 	}
-	// 1. the nonce of the message caller is correct
+	// 1. the nonce of the message caller is correct. A transaction with a nonce gap
+	// may become valid later in the block, a transaction with a used nonce never does
 	if err := t.nonceCheck(msg); err != nil {
-		return NewTransitionApplicationError(err, true)
+		return NewTransitionApplicationError(err, errors.Is(err, ErrNonceTooHigh))
+	}
+
+	// EIP-3607: the sender of a transaction can't have deployed code.
+	// Read-only calls may still impersonate a contract.
+	if t.config.EIP3607 && !t.ctx.NonPayable && t.senderHasCode(msg.From) {
+		return NewTransitionApplicationError(ErrSenderNoEOA, false)
 	}
 
 	if !t.ctx.NonPayable {
@@ -1377,6 +2015,13 @@ func checkAndProcessTx(msg *types.Transaction, t *Transition) error {
 			return NewTransitionApplicationError(err, true)
 		}
 
+		// the effective gas price covers the minimum base fee of the EngineRegistry
+		if t.config.MinBaseFeeFloor {
+			if err := t.checkMinBaseFee(msg); err != nil {
+				return NewTransitionApplicationError(err, true)
+			}
+		}
+
 		// 3. caller has enough balance to cover transaction
 		// Skip this check if the given flag is provided.
 		// It happens for eth_call and for other operations that do not change the state.
@@ -1388,7 +2033,7 @@ func checkAndProcessTx(msg *types.Transaction, t *Transition) error {
 	return nil
 }
 
-func checkAndProcessStateTx(msg *types.Transaction) error {
+func checkAndProcessStateTx(msg *types.Transaction, recipients map[types.Address]struct{}) error {
 	if msg.GasPrice.Cmp(big.NewInt(0)) != 0 {
 		return NewTransitionApplicationError(
 			errors.New("gasPrice of state transaction must be zero"),
@@ -1417,17 +2062,45 @@ func checkAndProcessStateTx(msg *types.Transaction) error {
 		)
 	}
 
+	if recipients != nil {
+		if _, ok := recipients[*msg.To]; !ok {
+			return NewTransitionApplicationError(
+				fmt.Errorf("state transaction recipient %v is not allowed", *msg.To),
+				true,
+			)
+		}
+	}
+
 	return nil
 }
 
-// captureCallStart calls CallStart in Tracer if context has the tracer
+// newStateTxRecipients returns the set of allowed state transaction targets,
+// falling back to DefaultStateTxRecipients if none are configured
+func newStateTxRecipients(configured []types.Address) map[types.Address]struct{} {
+	if len(configured) == 0 {
+		configured = DefaultStateTxRecipients
+	}
+
+	recipients := make(map[types.Address]struct{}, len(configured))
+	for _, addr := range configured {
+		recipients[addr] = struct{}{}
+	}
+
+	return recipients
+}
+
+// captureCallStart calls CallStart in Tracer if context has the tracer.
+// The depth of the frame is its depth in the trace, the call of a precompile
+// to another contract is traced below the frame of the precompile
 func (t *Transition) captureCallStart(c *runtime.Contract, callType runtime.CallType) {
 	if t.ctx.Tracer == nil {
 		return
 	}
 
+	t.traceDepth++
+
 	t.ctx.Tracer.CallStart(
-		c.Depth,
+		t.traceDepth,
 		c.Caller,
 		c.Address,
 		int(callType),
@@ -1444,8 +2117,38 @@ func (t *Transition) captureCallEnd(c *runtime.Contract, result *runtime.Executi
 	}
 
 	t.ctx.Tracer.CallEnd(
-		c.Depth,
+		t.traceDepth,
 		result.ReturnValue,
 		result.Err,
 	)
+
+	t.traceDepth--
+}
+
+// capturePrecompileStart opens the synthetic frame of a precompile function which calls
+// other contracts, if the tracer shows it. It reports whether the frame was opened,
+// the frame is closed with captureCallEnd
+func (t *Transition) capturePrecompileStart(c *runtime.Contract) bool {
+	precompileTracer, ok := t.ctx.Tracer.(tracer.PrecompileTracer)
+	if !ok {
+		return false
+	}
+
+	name, ok := precompiled.TraceFrameName(c.CodeAddress, c.Input)
+	if !ok {
+		return false
+	}
+
+	t.traceDepth++
+
+	precompileTracer.PrecompileStart(
+		t.traceDepth,
+		c.Caller,
+		c.Address,
+		name,
+		c.Gas,
+		c.Input,
+	)
+
+	return true
 }
@@ -2,13 +2,19 @@ package state
 
 import (
 	"fmt"
+	"math"
 	"math/big"
+	"sync"
 	"testing"
 
+	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/xgr-network/xgr-node/chain"
+	"github.com/xgr-network/xgr-node/contracts"
+	"github.com/xgr-network/xgr-node/crypto"
+	"github.com/xgr-network/xgr-node/helper/hex"
 	"github.com/xgr-network/xgr-node/state/runtime"
 	"github.com/xgr-network/xgr-node/types"
 )
@@ -35,7 +41,7 @@ func TestOverride(t *testing.T) {
 	balance := big.NewInt(2)
 	code := []byte{0x1}
 
-	tt := NewTransition(chain.ForksInTime{}, state, newTxn(state))
+	tt := NewTransition(nil, chain.ForksInTime{}, state, newTxn(state))
 
 	require.Empty(t, tt.state.GetCode(types.ZeroAddress))
 
@@ -66,6 +72,69 @@ func TestOverride(t *testing.T) {
 	require.Equal(t, types.Hash{0x1}, tt.state.GetState(types.Address{0x1}, types.Hash{0x1}))
 }
 
+func TestOverride_ClearStorageThenDiff(t *testing.T) {
+	t.Parallel()
+
+	addr := types.Address{0x1}
+
+	state := newStateWithPreState(map[types.Address]*PreState{
+		addr: {
+			State: map[types.Hash]types.Hash{
+				{0x1}: {0x1},
+				{0x2}: {0x2},
+			},
+		},
+	})
+
+	tt := NewTransition(nil, chain.ForksInTime{}, state, newTxn(state))
+
+	// pending write of a previous tx in the same block
+	tt.state.SetState(addr, types.Hash{0x3}, types.Hash{0x3})
+
+	err := tt.WithStateOverride(types.StateOverride{
+		addr: types.OverrideAccount{
+			State: map[types.Hash]types.Hash{},
+			StateDiff: map[types.Hash]types.Hash{
+				{0x2}: {0x4},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, types.Hash{}, tt.state.GetState(addr, types.Hash{0x1}))
+	require.Equal(t, types.Hash{}, tt.state.GetState(addr, types.Hash{0x3}))
+	require.Equal(t, types.Hash{0x4}, tt.state.GetState(addr, types.Hash{0x2}))
+
+	// a non-empty state still can't be combined with a diff
+	err = tt.WithStateOverride(types.StateOverride{
+		addr: types.OverrideAccount{
+			State:     map[types.Hash]types.Hash{{0x1}: {0x1}},
+			StateDiff: map[types.Hash]types.Hash{{0x2}: {0x2}},
+		},
+	})
+	require.Error(t, err)
+}
+
+func TestOverride_EmptyCode(t *testing.T) {
+	t.Parallel()
+
+	addr := types.Address{0x1}
+	state := newStateWithPreState(map[types.Address]*PreState{addr: {}})
+
+	tt := NewTransition(nil, chain.ForksInTime{}, state, newTxn(state))
+
+	require.NoError(t, tt.WithStateOverride(types.StateOverride{
+		addr: types.OverrideAccount{Code: []byte{0x1}},
+	}))
+	require.NotEqual(t, types.EmptyCodeHash, tt.state.GetCodeHash(addr))
+
+	require.NoError(t, tt.WithStateOverride(types.StateOverride{
+		addr: types.OverrideAccount{Code: []byte{}},
+	}))
+	require.Equal(t, types.EmptyCodeHash, tt.state.GetCodeHash(addr))
+	require.Empty(t, tt.state.GetCode(addr))
+}
+
 func Test_Transition_checkDynamicFees(t *testing.T) {
 	t.Parallel()
 
@@ -157,3 +226,1269 @@ func Test_Transition_checkDynamicFees(t *testing.T) {
 		})
 	}
 }
+
+// faultyState is a State whose first NewSnapshotAt calls fail with the given error
+type faultyState struct {
+	err      error
+	failures int
+	calls    int
+}
+
+func (f *faultyState) NewSnapshotAt(root types.Hash) (Snapshot, error) {
+	f.calls++
+
+	if f.calls <= f.failures {
+		return nil, fmt.Errorf("failed to get storage root %s: %w", root, f.err)
+	}
+
+	return newStateWithPreState(nil), nil
+}
+
+func (f *faultyState) NewSnapshot() Snapshot {
+	return newStateWithPreState(nil)
+}
+
+func (f *faultyState) GetCode(types.Hash) ([]byte, bool) {
+	return nil, false
+}
+
+func TestExecutor_BeginTxn_StateErrors(t *testing.T) {
+	t.Parallel()
+
+	newExecutor := func(st State) *Executor {
+		e := NewExecutor(&chain.Params{
+			Forks: chain.AllForksEnabled,
+			BurnContract: map[uint64]types.Address{
+				0: types.ZeroAddress,
+			},
+		}, st, hclog.NewNullLogger())
+
+		e.GetHash = func(*types.Header) GetHashByNumber {
+			return func(uint64) types.Hash {
+				return types.ZeroHash
+			}
+		}
+
+		return e
+	}
+
+	header := &types.Header{Number: 1, GasLimit: 1_000_000}
+
+	t.Run("storage unavailable is retried", func(t *testing.T) {
+		t.Parallel()
+
+		st := &faultyState{err: ErrStorageUnavailable, failures: stateRetries}
+
+		txn, err := newExecutor(st).BeginTxn(types.StringToHash("0x1"), header, types.ZeroAddress)
+		require.NoError(t, err)
+		require.NotNil(t, txn)
+		require.Equal(t, stateRetries+1, st.calls)
+	})
+
+	t.Run("retries are bounded", func(t *testing.T) {
+		t.Parallel()
+
+		st := &faultyState{err: ErrStorageUnavailable, failures: stateRetries + 1}
+
+		_, err := newExecutor(st).BeginTxn(types.StringToHash("0x1"), header, types.ZeroAddress)
+		require.ErrorIs(t, err, ErrStorageUnavailable)
+		require.Equal(t, stateRetries+1, st.calls)
+	})
+
+	t.Run("unknown root is not retried", func(t *testing.T) {
+		t.Parallel()
+
+		st := &faultyState{err: ErrStateNotFound, failures: 1}
+
+		_, err := newExecutor(st).BeginTxn(types.StringToHash("0x1"), header, types.ZeroAddress)
+		require.ErrorIs(t, err, ErrStateNotFound)
+		require.Equal(t, 1, st.calls)
+	})
+}
+
+func TestExecutor_BeginTxn_PrecompileAccounts(t *testing.T) {
+	t.Parallel()
+
+	newTransition := func(forks *chain.Forks) *Transition {
+		e := NewExecutor(&chain.Params{
+			Forks: forks,
+			BurnContract: map[uint64]types.Address{
+				0: types.ZeroAddress,
+			},
+		}, &faultyState{}, hclog.NewNullLogger())
+
+		e.GetHash = func(*types.Header) GetHashByNumber {
+			return func(uint64) types.Hash {
+				return types.ZeroHash
+			}
+		}
+
+		txn, err := e.BeginTxn(types.StringToHash("0x1"), &types.Header{Number: 1, GasLimit: 1_000_000}, types.ZeroAddress)
+		require.NoError(t, err)
+
+		return txn
+	}
+
+	txn := newTransition(chain.AllForksEnabled)
+	require.Equal(t, uint64(1), txn.GetNonce(contracts.RewardAddressPrecompile))
+	require.Equal(t, uint64(1), txn.GetNonce(contracts.DonationGovernancePrecompile))
+
+	// the storage of the precompile survives the removal of the empty accounts
+	txn.state.SetState(contracts.RewardAddressPrecompile, types.StringToHash("0x1"), types.StringToHash("0x2"))
+
+	objs, err := txn.state.Commit(true)
+	require.NoError(t, err)
+
+	committed := false
+
+	for _, obj := range objs {
+		if obj.Address == contracts.RewardAddressPrecompile {
+			committed = true
+
+			require.False(t, obj.Deleted)
+			require.Len(t, obj.Storage, 1)
+		}
+	}
+
+	require.True(t, committed)
+
+	// the accounts are only created once their fork is active
+	txn = newTransition(chain.AllForksEnabled.Copy().RemoveFork(chain.RewardAddress))
+	require.False(t, txn.state.Exist(contracts.RewardAddressPrecompile))
+	require.True(t, txn.state.Exist(contracts.DonationGovernancePrecompile))
+}
+
+func TestExecutor_ProcessBlock_GasLimitExceeded(t *testing.T) {
+	t.Parallel()
+
+	e := NewExecutor(&chain.Params{
+		Forks: chain.AllForksEnabled,
+		BurnContract: map[uint64]types.Address{
+			0: types.ZeroAddress,
+		},
+	}, &faultyState{}, hclog.NewNullLogger())
+
+	e.GetHash = func(*types.Header) GetHashByNumber {
+		return func(uint64) types.Hash {
+			return types.ZeroHash
+		}
+	}
+
+	sender := types.StringToAddress("0x700")
+	receiver := types.StringToAddress("0x800")
+
+	newTx := func(nonce uint64) *types.Transaction {
+		tx := &types.Transaction{
+			From:     sender,
+			To:       &receiver,
+			Nonce:    nonce,
+			Gas:      30_000,
+			GasPrice: big.NewInt(0),
+			Value:    big.NewInt(0),
+		}
+		tx.ComputeHash(1)
+
+		return tx
+	}
+
+	// the first transfer uses 21000 gas, the second one needs 30000 of the remaining 29000
+	block := &types.Block{
+		Header:       &types.Header{Number: 1, GasLimit: 50_000},
+		Transactions: []*types.Transaction{newTx(0), newTx(1)},
+	}
+
+	_, err := e.ProcessBlock(types.StringToHash("0x1"), block, types.ZeroAddress)
+	require.ErrorIs(t, err, ErrBlockGasLimitExceeded)
+	require.ErrorContains(t, err, "transaction 1")
+
+	// the block is valid once the limit covers both transfers
+	block.Header.GasLimit = 51_000
+
+	txn, err := e.ProcessBlock(types.StringToHash("0x1"), block, types.ZeroAddress)
+	require.NoError(t, err)
+	require.Equal(t, uint64(42_000), txn.TotalGas())
+}
+
+func TestExecutor_ProcessBlock_TxGasAboveBlockGasLimit(t *testing.T) {
+	t.Parallel()
+
+	sender := types.StringToAddress("0x700")
+	receiver := types.StringToAddress("0x800")
+
+	newTx := func(nonce, gas uint64) *types.Transaction {
+		tx := &types.Transaction{
+			From:     sender,
+			To:       &receiver,
+			Nonce:    nonce,
+			Gas:      gas,
+			GasPrice: big.NewInt(0),
+			Value:    big.NewInt(0),
+		}
+		tx.ComputeHash(1)
+
+		return tx
+	}
+
+	// the second transaction needs more gas than the whole block has
+	block := &types.Block{
+		Header:       &types.Header{Number: 1, GasLimit: 50_000},
+		Transactions: []*types.Transaction{newTx(0, 21_000), newTx(1, 60_000)},
+	}
+
+	for _, strict := range []bool{false, true} {
+		e := NewExecutor(&chain.Params{
+			Forks: chain.AllForksEnabled,
+			BurnContract: map[uint64]types.Address{
+				0: types.ZeroAddress,
+			},
+			StrictTxGasLimit: strict,
+		}, &faultyState{}, hclog.NewNullLogger())
+
+		e.GetHash = func(*types.Header) GetHashByNumber {
+			return func(uint64) types.Hash {
+				return types.ZeroHash
+			}
+		}
+
+		txn, err := e.ProcessBlock(types.StringToHash("0x1"), block, types.ZeroAddress)
+
+		if strict {
+			require.ErrorIs(t, err, ErrTxGasAboveBlockGasLimit)
+			require.ErrorContains(t, err, "transaction 1")
+
+			continue
+		}
+
+		// the over-limit transaction is skipped
+		require.NoError(t, err)
+		require.Len(t, txn.Receipts(), 1)
+		require.Equal(t, uint64(21_000), txn.TotalGas())
+	}
+}
+
+func TestExecutor_ForceEmptyAccountDeletion(t *testing.T) {
+	t.Parallel()
+
+	empty := types.StringToAddress("0x900")
+
+	cases := []struct {
+		name    string
+		forks   *chain.Forks
+		force   bool
+		deleted bool
+	}{
+		{"retained before EIP155", &chain.Forks{}, false, false},
+		{"deleted before EIP155 with the override", &chain.Forks{}, true, true},
+		{"deleted with EIP155", &chain.Forks{chain.EIP155: chain.NewFork(0)}, false, true},
+		{"deleted with EIP155 and the override", &chain.Forks{chain.EIP155: chain.NewFork(0)}, true, true},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := NewExecutor(&chain.Params{Forks: c.forks}, &faultyState{}, hclog.NewNullLogger())
+			e.ForceEmptyAccountDeletion = c.force
+
+			txn, err := e.BeginTxn(types.ZeroHash, &types.Header{Number: 1}, types.ZeroAddress)
+			require.NoError(t, err)
+
+			txn.state.TouchAccount(empty)
+
+			_, _, err = txn.Commit()
+			require.NoError(t, err)
+
+			require.Len(t, txn.committed, 1)
+			require.Equal(t, empty, txn.committed[0].Address)
+			require.Equal(t, c.deleted, txn.committed[0].Deleted)
+		})
+	}
+}
+
+// preStateStore is a State whose snapshots hold the given pre state
+type preStateStore struct {
+	faultyState
+
+	preState map[types.Address]*PreState
+}
+
+func (p *preStateStore) NewSnapshotAt(types.Hash) (Snapshot, error) {
+	return newStateWithPreState(p.preState), nil
+}
+
+func TestTransition_DirtyAccounts(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sender    = types.StringToAddress("0x700")
+		receiver  = types.StringToAddress("0x800")
+		coinbase  = types.StringToAddress("0x900")
+		untouched = types.StringToAddress("0xa00")
+	)
+
+	// without the precompile accounts created in the block
+	e := NewExecutor(&chain.Params{
+		Forks: chain.AllForksEnabled.Copy().RemoveFork(chain.RewardAddress).RemoveFork(chain.DonationVote),
+		BurnContract: map[uint64]types.Address{
+			0: types.ZeroAddress,
+		},
+	}, &preStateStore{
+		preState: map[types.Address]*PreState{
+			sender:    {Balance: 1_000_000_000_000_000_000},
+			untouched: {Balance: 1},
+		},
+	}, hclog.NewNullLogger())
+
+	e.GetHash = func(*types.Header) GetHashByNumber {
+		return func(uint64) types.Hash {
+			return types.ZeroHash
+		}
+	}
+
+	txn, err := e.BeginTxn(types.StringToHash("0x1"), &types.Header{Number: 1, GasLimit: 1_000_000}, coinbase)
+	require.NoError(t, err)
+	require.Empty(t, txn.DirtyAccounts())
+
+	// reading an account does not make it dirty
+	require.Equal(t, uint64(1), txn.GetBalance(untouched).Uint64())
+
+	// the fee of 21000 gas at 1 Gwei exceeds the fixed burn
+	require.NoError(t, txn.Write(&types.Transaction{
+		From:     sender,
+		To:       &receiver,
+		Gas:      21_000,
+		GasPrice: big.NewInt(1_000_000_000),
+		Value:    big.NewInt(1),
+	}))
+
+	require.ElementsMatch(t, []types.Address{
+		sender,
+		receiver,
+		coinbase,
+		chain.DefaultBurnedAddress,
+	}, txn.DirtyAccounts())
+	require.Equal(t, chain.DefaultBurnedAddress, chain.DefaultDonationAddress)
+}
+
+func TestTransition_NonPayableCallWithValue(t *testing.T) {
+	t.Parallel()
+
+	var (
+		minter = types.StringToAddress("0x700")
+		token  = types.StringToAddress("0x800")
+	)
+
+	// payable mint: stores the call value in slot 0 and returns the balance of the contract
+	code := []byte{
+		0x34,       // CALLVALUE
+		0x60, 0x00, // PUSH1 0
+		0x55,       // SSTORE
+		0x47,       // SELFBALANCE
+		0x60, 0x00, // PUSH1 0
+		0x52,       // MSTORE
+		0x60, 0x20, // PUSH1 32
+		0x60, 0x00, // PUSH1 0
+		0xf3, // RETURN
+	}
+
+	e := NewExecutor(&chain.Params{
+		Forks: chain.AllForksEnabled,
+		BurnContract: map[uint64]types.Address{
+			0: types.ZeroAddress,
+		},
+	}, &faultyState{}, hclog.NewNullLogger())
+
+	e.GetHash = func(*types.Header) GetHashByNumber {
+		return func(uint64) types.Hash {
+			return types.ZeroHash
+		}
+	}
+
+	mint := func(nonPayable bool) (*Transition, *runtime.ExecutionResult) {
+		t.Helper()
+
+		txn, err := e.BeginTxn(types.StringToHash("0x1"), &types.Header{Number: 1, GasLimit: 1_000_000}, types.ZeroAddress)
+		require.NoError(t, err)
+
+		txn.state.SetCode(token, code)
+		txn.SetNonPayable(nonPayable)
+
+		result, err := txn.Apply(&types.Transaction{
+			From:     minter,
+			To:       &token,
+			Gas:      100_000,
+			GasPrice: big.NewInt(0),
+			Value:    big.NewInt(5),
+		})
+		require.NoError(t, err)
+
+		return txn, result
+	}
+
+	// a transaction from the zero-balance address fails
+	_, result := mint(false)
+	require.ErrorIs(t, result.Err, runtime.ErrInsufficientBalance)
+
+	// the simulation succeeds and the contract sees the value
+	txn, result := mint(true)
+	require.NoError(t, result.Err)
+	require.Equal(t, types.BytesToHash(big.NewInt(5).Bytes()).Bytes(), result.ReturnValue)
+	require.Equal(t, types.BytesToHash(big.NewInt(5).Bytes()), txn.state.GetState(token, types.ZeroHash))
+	require.Equal(t, uint64(5), txn.state.GetBalance(token).Uint64())
+	require.Equal(t, uint64(0), txn.state.GetBalance(minter).Uint64())
+}
+
+func TestTransition_SenderWithCode(t *testing.T) {
+	t.Parallel()
+
+	var (
+		contract = types.StringToAddress("0x700")
+		receiver = types.StringToAddress("0x800")
+	)
+
+	e := NewExecutor(&chain.Params{
+		Forks: chain.AllForksEnabled,
+		BurnContract: map[uint64]types.Address{
+			0: types.ZeroAddress,
+		},
+	}, &faultyState{}, hclog.NewNullLogger())
+
+	e.GetHash = func(*types.Header) GetHashByNumber {
+		return func(uint64) types.Hash {
+			return types.ZeroHash
+		}
+	}
+
+	transfer := func(nonPayable bool) error {
+		t.Helper()
+
+		txn, err := e.BeginTxn(types.StringToHash("0x1"), &types.Header{Number: 1, GasLimit: 1_000_000}, types.ZeroAddress)
+		require.NoError(t, err)
+
+		txn.state.SetCode(contract, []byte{0x00})
+		txn.SetNonPayable(nonPayable)
+
+		return txn.Write(&types.Transaction{
+			From:     contract,
+			To:       &receiver,
+			Gas:      21_000,
+			GasPrice: big.NewInt(0),
+			Value:    big.NewInt(0),
+		})
+	}
+
+	// the transaction is rejected for good, it isn't put back into the pool
+	err := transfer(false)
+	require.ErrorIs(t, err, ErrSenderNoEOA)
+
+	var appErr *TransitionApplicationError
+
+	require.ErrorAs(t, err, &appErr)
+	require.False(t, appErr.IsRecoverable)
+
+	// a read-only call may impersonate the contract
+	require.NoError(t, transfer(true))
+
+	// before EIP-3607 the contract can send transactions
+	e.config.Forks = chain.AllForksEnabled.Copy().RemoveFork(chain.EIP3607)
+	require.NoError(t, transfer(false))
+}
+
+func TestTransition_NonceCheck(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sender   = types.StringToAddress("0x700")
+		receiver = types.StringToAddress("0x800")
+	)
+
+	e := NewExecutor(&chain.Params{
+		Forks: chain.AllForksEnabled,
+		BurnContract: map[uint64]types.Address{
+			0: types.ZeroAddress,
+		},
+	}, &faultyState{}, hclog.NewNullLogger())
+
+	e.GetHash = func(*types.Header) GetHashByNumber {
+		return func(uint64) types.Hash {
+			return types.ZeroHash
+		}
+	}
+
+	txn, err := e.BeginTxn(types.StringToHash("0x1"), &types.Header{Number: 1, GasLimit: 1_000_000}, types.ZeroAddress)
+	require.NoError(t, err)
+
+	txn.state.SetNonce(sender, 5)
+
+	write := func(nonce uint64) error {
+		return txn.Write(&types.Transaction{
+			From:     sender,
+			To:       &receiver,
+			Nonce:    nonce,
+			Gas:      21_000,
+			GasPrice: big.NewInt(0),
+			Value:    big.NewInt(0),
+		})
+	}
+
+	cases := []struct {
+		nonce       uint64
+		expected    error
+		recoverable bool
+	}{
+		// the nonce was used, the transaction can't be included anymore
+		{4, ErrNonceTooLow, false},
+		// the nonce gap may be filled by another transaction of the block
+		{7, ErrNonceTooHigh, true},
+	}
+
+	for _, c := range cases {
+		err := write(c.nonce)
+		require.ErrorIs(t, err, c.expected)
+		require.ErrorIs(t, err, ErrNonceIncorrect)
+		require.ErrorContains(t, err, fmt.Sprintf("tx: %d state: 5", c.nonce))
+
+		var appErr *TransitionApplicationError
+
+		require.ErrorAs(t, err, &appErr)
+		require.Equal(t, c.recoverable, appErr.IsRecoverable)
+	}
+
+	require.NotErrorIs(t, ErrNonceTooLow, ErrNonceTooHigh)
+	require.NoError(t, write(5))
+}
+
+func TestTransition_DeletedAccounts(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sender   = types.StringToAddress("0x700")
+		contract = types.StringToAddress("0x800")
+		coinbase = types.StringToAddress("0x900")
+	)
+
+	// sends the balance of the contract to the caller and destructs it
+	code := []byte{
+		0x33, // CALLER
+		0xff, // SELFDESTRUCT
+	}
+
+	// pre-existing contracts are only destructed before EIP-6780
+	e := NewExecutor(&chain.Params{
+		Forks: chain.AllForksEnabled.Copy().RemoveFork(chain.EIP6780),
+		BurnContract: map[uint64]types.Address{
+			0: types.ZeroAddress,
+		},
+	}, &faultyState{}, hclog.NewNullLogger())
+
+	e.GetHash = func(*types.Header) GetHashByNumber {
+		return func(uint64) types.Hash {
+			return types.ZeroHash
+		}
+	}
+
+	txn, err := e.BeginTxn(types.StringToHash("0x1"), &types.Header{Number: 1, GasLimit: 1_000_000}, coinbase)
+	require.NoError(t, err)
+
+	txn.state.AddBalance(sender, new(big.Int).SetUint64(1_000_000_000_000_000_000))
+	txn.state.SetCode(contract, code)
+	require.Empty(t, txn.DeletedAccounts())
+
+	require.NoError(t, txn.Write(&types.Transaction{
+		From:     sender,
+		To:       &contract,
+		Gas:      100_000,
+		GasPrice: big.NewInt(1_000_000_000),
+	}))
+
+	require.Equal(t, []types.Address{contract}, txn.DeletedAccounts())
+
+	// the destructed account is reported once
+	require.NoError(t, txn.Write(&types.Transaction{
+		From:     sender,
+		To:       &coinbase,
+		Nonce:    1,
+		Gas:      21_000,
+		GasPrice: big.NewInt(1_000_000_000),
+		Value:    big.NewInt(1),
+	}))
+
+	require.Equal(t, []types.Address{contract}, txn.DeletedAccounts())
+}
+
+func TestTransition_FeeSplit(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sender   = types.StringToAddress("0x700")
+		receiver = types.StringToAddress("0x800")
+		coinbase = types.StringToAddress("0x900")
+	)
+
+	cases := []struct {
+		name     string
+		optIn    bool
+		emitLog  bool
+		expected bool
+	}{
+		{"always emitted before the opt-in fork", false, false, true},
+		{"disabled after the opt-in fork", true, false, false},
+		{"enabled after the opt-in fork", true, true, true},
+	}
+
+	for _, c := range cases {
+		forks := chain.AllForksEnabled.Copy()
+		if !c.optIn {
+			forks.RemoveFork(chain.FeeSplitLogOptIn)
+		}
+
+		e := NewExecutor(&chain.Params{
+			Forks: forks,
+			BurnContract: map[uint64]types.Address{
+				0: types.ZeroAddress,
+			},
+			EmitFeeSplitLog: c.emitLog,
+		}, &faultyState{}, hclog.NewNullLogger())
+
+		e.GetHash = func(*types.Header) GetHashByNumber {
+			return func(uint64) types.Hash {
+				return types.ZeroHash
+			}
+		}
+
+		txn, err := e.BeginTxn(types.StringToHash("0x1"), &types.Header{Number: 1, GasLimit: 1_000_000}, coinbase)
+		require.NoError(t, err)
+
+		txn.state.AddBalance(sender, new(big.Int).SetUint64(1_000_000_000_000_000_000))
+
+		// the fee of 21000 gas at 1 Gwei exceeds the fixed burn
+		require.NoError(t, txn.Write(&types.Transaction{
+			From:     sender,
+			To:       &receiver,
+			Gas:      21_000,
+			GasPrice: big.NewInt(1_000_000_000),
+			Value:    big.NewInt(1),
+		}))
+
+		receipt := txn.Receipts()[0]
+		require.NotNil(t, receipt.FeeSplit)
+
+		// the split adds up to the paid fee
+		total := new(big.Int).Add(receipt.FeeSplit.Donation, receipt.FeeSplit.Validator)
+		total.Add(total, receipt.FeeSplit.Burned)
+		require.Equal(t, uint64(21_000*1_000_000_000), total.Uint64())
+		require.Equal(t, chain.FixedBurnWei, receipt.FeeSplit.Burned.Uint64())
+
+		feeSplitLogs := 0
+
+		for _, log := range receipt.Logs {
+			if log.Address == FeeSplitLogAddress {
+				feeSplitLogs++
+
+				require.Equal(t, receipt.FeeSplit.Burned.Uint64(), new(big.Int).SetBytes(log.Data[64:]).Uint64())
+			}
+		}
+
+		if c.expected {
+			require.Equal(t, 1, feeSplitLogs, c.name)
+			require.True(t, receipt.LogsBloom.IsAddressInBloom(FeeSplitLogAddress), c.name)
+		} else {
+			require.Equal(t, 0, feeSplitLogs, c.name)
+			require.False(t, receipt.LogsBloom.IsAddressInBloom(FeeSplitLogAddress), c.name)
+		}
+
+		// the fees are distributed whether the log is emitted or not
+		// (the default donation and burned addresses may be the same)
+		expectedBalances := map[types.Address]uint64{receiver: 1}
+		expectedBalances[coinbase] += receipt.FeeSplit.Validator.Uint64()
+		expectedBalances[chain.DefaultDonationAddress] += receipt.FeeSplit.Donation.Uint64()
+		expectedBalances[chain.DefaultBurnedAddress] += receipt.FeeSplit.Burned.Uint64()
+
+		for addr, balance := range expectedBalances {
+			require.Equal(t, balance, txn.GetBalance(addr).Uint64(), "%s, address %s", c.name, addr)
+		}
+	}
+}
+
+func TestExecutor_ReceiptHook(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sender   = types.StringToAddress("0x700")
+		receiver = types.StringToAddress("0x800")
+		coinbase = types.StringToAddress("0x900")
+	)
+
+	e := NewExecutor(&chain.Params{
+		Forks: chain.AllForksEnabled,
+		BurnContract: map[uint64]types.Address{
+			0: types.ZeroAddress,
+		},
+		EmitFeeSplitLog: true,
+	}, &faultyState{}, hclog.NewNullLogger())
+
+	e.GetHash = func(*types.Header) GetHashByNumber {
+		return func(uint64) types.Hash {
+			return types.ZeroHash
+		}
+	}
+
+	var (
+		hookReceipts []*types.Receipt
+		hookTxs      []*types.Transaction
+	)
+
+	e.ReceiptHook = func(receipt *types.Receipt, txn *types.Transaction) {
+		hookReceipts = append(hookReceipts, receipt)
+		hookTxs = append(hookTxs, txn)
+	}
+
+	txn, err := e.BeginTxn(types.StringToHash("0x1"), &types.Header{Number: 1, GasLimit: 1_000_000}, coinbase)
+	require.NoError(t, err)
+
+	txn.state.AddBalance(sender, new(big.Int).SetUint64(1_000_000_000_000_000_000))
+
+	txs := make([]*types.Transaction, 2)
+
+	for i := range txs {
+		txs[i] = &types.Transaction{
+			From:     sender,
+			To:       &receiver,
+			Nonce:    uint64(i),
+			Gas:      21_000,
+			GasPrice: big.NewInt(1_000_000_000),
+			Value:    big.NewInt(1),
+		}
+		txs[i].ComputeHash(1)
+
+		require.NoError(t, txn.Write(txs[i]))
+	}
+
+	// the hook sees the final receipts of the block, in order
+	require.Equal(t, txs, hookTxs)
+	require.Equal(t, txn.Receipts(), hookReceipts)
+
+	for i, receipt := range hookReceipts {
+		require.Equal(t, txs[i].Hash, receipt.TxHash)
+		require.Equal(t, types.ReceiptSuccess, *receipt.Status)
+		require.Equal(t, uint64(21_000*(i+1)), receipt.CumulativeGasUsed)
+		require.NotNil(t, receipt.FeeSplit)
+
+		// the fee split log is part of the receipt and its bloom
+		require.Len(t, receipt.Logs, 1)
+		require.Equal(t, FeeSplitLogAddress, receipt.Logs[0].Address)
+		require.Equal(t, receipt.FeeSplit.Burned.Uint64(), new(big.Int).SetBytes(receipt.Logs[0].Data[64:]).Uint64())
+		require.True(t, receipt.LogsBloom.IsAddressInBloom(FeeSplitLogAddress))
+	}
+
+	// a failed transaction has no receipt
+	require.Error(t, txn.Write(&types.Transaction{
+		From:     sender,
+		To:       &receiver,
+		Nonce:    0,
+		Gas:      21_000,
+		GasPrice: big.NewInt(1_000_000_000),
+		Value:    big.NewInt(1),
+	}))
+	require.Len(t, hookReceipts, len(txs))
+}
+
+func TestTransition_VerifyFeeConservation(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sender   = types.StringToAddress("0x700")
+		receiver = types.StringToAddress("0x800")
+		coinbase = types.StringToAddress("0x900")
+	)
+
+	e := NewExecutor(&chain.Params{
+		Forks: chain.AllForksEnabled,
+		BurnContract: map[uint64]types.Address{
+			0: types.ZeroAddress,
+		},
+	}, &faultyState{}, hclog.NewNullLogger())
+	e.VerifyFeeConservation = true
+
+	e.GetHash = func(*types.Header) GetHashByNumber {
+		return func(uint64) types.Hash {
+			return types.ZeroHash
+		}
+	}
+
+	txn, err := e.BeginTxn(types.StringToHash("0x1"), &types.Header{Number: 1, GasLimit: 1_000_000}, coinbase)
+	require.NoError(t, err)
+
+	txn.state.AddBalance(sender, new(big.Int).SetUint64(1_000_000_000_000_000_000))
+
+	// the unused gas is refunded and accounted for by the check
+	require.NoError(t, txn.Write(&types.Transaction{
+		From:     sender,
+		To:       &receiver,
+		Gas:      50_000,
+		GasPrice: big.NewInt(1_000_000_000),
+		Value:    big.NewInt(1),
+	}))
+	require.Len(t, txn.Receipts(), 1)
+
+	var (
+		charged   = big.NewInt(50_000 * 1_000_000_000)
+		donation  = big.NewInt(2_000_000_000_000)
+		validator = big.NewInt(18_000_000_000_000)
+		burned    = big.NewInt(1_000_000_000_000)
+		refund    = big.NewInt(29_000 * 1_000_000_000)
+	)
+
+	require.NoError(t, verifyFeeConservation(charged, donation, validator, burned, refund))
+
+	// one wei too much for the validator
+	perturbed := new(big.Int).Add(validator, big.NewInt(1))
+
+	err = verifyFeeConservation(charged, donation, perturbed, burned, refund)
+	require.ErrorIs(t, err, ErrFeeNotConserved)
+	require.ErrorContains(t, err, "diff 1)")
+
+	// one wei lost from the donation
+	perturbed = new(big.Int).Sub(donation, big.NewInt(1))
+
+	err = verifyFeeConservation(charged, perturbed, validator, burned, refund)
+	require.ErrorIs(t, err, ErrFeeNotConserved)
+	require.ErrorContains(t, err, "diff -1)")
+}
+
+func TestResolveDonationConfig(t *testing.T) {
+	var (
+		registry  = types.StringToAddress("0xe9")
+		recipient = types.StringToAddress("0xd0")
+	)
+
+	prevRegistry := chain.EngineRegistryAddress
+	t.Cleanup(func() {
+		chain.EngineRegistryAddress = prevRegistry
+	})
+
+	chain.EngineRegistryAddress = registry
+
+	genesisPercent := uint64(7)
+
+	newTransition := func(deployed bool, override *uint64, params *chain.Params) *Transition {
+		txn := newTestTxn(map[types.Address]*PreState{})
+
+		if deployed {
+			txn.SetCode(registry, []byte{0x00})
+			txn.SetState(registry, chain.EngineRegistrySlotKeyDonationAddress(), types.BytesToHash(recipient.Bytes()))
+			txn.SetState(registry, chain.EngineRegistrySlotKeyDonationPercent(), types.BytesToHash([]byte{30}))
+		}
+
+		transition := NewTransition(params, chain.ForksInTime{}, nil, txn)
+		transition.donationPercentOverride = override
+
+		return transition
+	}
+
+	override := func(pct uint64) *uint64 {
+		return &pct
+	}
+
+	testCases := []struct {
+		name            string
+		deployed        bool
+		override        *uint64
+		params          *chain.Params
+		expectedAddr    types.Address
+		expectedPercent uint64
+	}{
+		{"default", false, nil, nil, chain.DefaultDonationAddress, chain.DefaultDonationPercent},
+		{"genesis default", false, nil, &chain.Params{DefaultDonationPercent: &genesisPercent},
+			chain.DefaultDonationAddress, genesisPercent},
+		{"registry over default", true, nil, nil, recipient, 30},
+		{"override over registry", true, override(5), nil, recipient, 5},
+		{"override over default", false, override(0), nil, chain.DefaultDonationAddress, 0},
+		{"invalid override ignored", true, override(101), nil, recipient, 30},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			addr, percent := resolveDonationConfig(newTransition(tc.deployed, tc.override, tc.params))
+			require.Equal(t, tc.expectedAddr, addr)
+			require.Equal(t, tc.expectedPercent, percent)
+		})
+	}
+}
+
+func TestComputeFeeSplit(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name              string
+		fee               int64
+		fixedBurn         int64
+		donationPercent   uint64
+		expectedDonation  int64
+		expectedValidator int64
+		expectedBurned    int64
+	}{
+		{"zero fee", 0, 10, 50, 0, 0, 0},
+		{"fee below burn", 7, 10, 50, 0, 0, 7},
+		{"fee equal to burn", 10, 10, 50, 0, 0, 10},
+		{"donation 0%", 110, 10, 0, 0, 100, 10},
+		{"donation 100%", 110, 10, 100, 100, 0, 10},
+		{"donation rounds down", 113, 10, 50, 51, 52, 10},
+		{"donation exceeding remainder", 110, 10, 150, 100, 0, 10},
+		{"donation percent clamped to 100", 1_000_010, 10, 150, 1_000_000, 0, 10},
+		{"max donation percent", 1_000_010, 10, math.MaxUint64, 1_000_000, 0, 10},
+		{"no fixed burn", 100, 0, 25, 25, 75, 0},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			fee := big.NewInt(tc.fee)
+
+			donation, validator, burned := ComputeFeeSplit(fee, big.NewInt(tc.fixedBurn), tc.donationPercent)
+			require.Equal(t, tc.expectedDonation, donation.Int64())
+			require.Equal(t, tc.expectedValidator, validator.Int64())
+			require.Equal(t, tc.expectedBurned, burned.Int64())
+			require.LessOrEqual(t, donation.Int64(), tc.fee)
+
+			// the parts always sum up to the fee, which is left untouched
+			sum := new(big.Int).Add(donation, validator)
+			require.Equal(t, tc.fee, sum.Add(sum, burned).Int64())
+			require.Equal(t, tc.fee, fee.Int64())
+		})
+	}
+}
+
+func TestTransition_SelfdestructEIP6780(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sender      = types.StringToAddress("0x700")
+		existing    = types.StringToAddress("0x800")
+		beneficiary = types.StringToAddress("0x900")
+	)
+
+	// sends the balance of the contract to the beneficiary and destructs it
+	selfdestruct := append([]byte{0x73}, beneficiary.Bytes()...) // PUSH20 beneficiary
+	selfdestruct = append(selfdestruct, 0xff)                    // SELFDESTRUCT
+
+	newTransition := func(t *testing.T, forks *chain.Forks) *Transition {
+		t.Helper()
+
+		e := NewExecutor(&chain.Params{
+			Forks: forks,
+			BurnContract: map[uint64]types.Address{
+				0: types.ZeroAddress,
+			},
+		}, &faultyState{}, hclog.NewNullLogger())
+
+		e.GetHash = func(*types.Header) GetHashByNumber {
+			return func(uint64) types.Hash {
+				return types.ZeroHash
+			}
+		}
+
+		transition, err := e.BeginTxn(types.StringToHash("0x1"), &types.Header{Number: 1, GasLimit: 1_000_000}, types.ZeroAddress)
+		require.NoError(t, err)
+
+		transition.state.AddBalance(sender, new(big.Int).SetUint64(1_000_000_000_000_000_000))
+
+		return transition
+	}
+
+	t.Run("pre-existing contract", func(t *testing.T) {
+		t.Parallel()
+
+		transition := newTransition(t, chain.AllForksEnabled)
+		transition.state.SetCode(existing, selfdestruct)
+		transition.state.SetState(existing, types.StringToHash("0x1"), types.StringToHash("0x2"))
+		transition.state.AddBalance(existing, big.NewInt(1000))
+
+		require.NoError(t, transition.Write(&types.Transaction{
+			From:     sender,
+			To:       &existing,
+			Gas:      100_000,
+			GasPrice: big.NewInt(1),
+		}))
+
+		// only the balance is transferred, the code and the storage are kept
+		require.Empty(t, transition.DeletedAccounts())
+		require.False(t, transition.state.HasSuicided(existing))
+		require.Equal(t, selfdestruct, transition.state.GetCode(existing))
+		require.Equal(t, types.StringToHash("0x2"), transition.state.GetState(existing, types.StringToHash("0x1")))
+		require.Zero(t, transition.state.GetBalance(existing).Sign())
+		require.Equal(t, big.NewInt(1000), transition.state.GetBalance(beneficiary))
+		require.Zero(t, transition.state.GetRefund())
+	})
+
+	t.Run("contract created in the same transaction", func(t *testing.T) {
+		t.Parallel()
+
+		transition := newTransition(t, chain.AllForksEnabled)
+
+		// the init code destructs the contract being created
+		require.NoError(t, transition.Write(&types.Transaction{
+			From:     sender,
+			Gas:      100_000,
+			GasPrice: big.NewInt(1),
+			Value:    big.NewInt(1000),
+			Input:    selfdestruct,
+		}))
+
+		created := crypto.CreateAddress(sender, 0)
+
+		require.Equal(t, []types.Address{created}, transition.DeletedAccounts())
+		require.Equal(t, big.NewInt(1000), transition.state.GetBalance(beneficiary))
+
+		// the created marks don't outlive the transaction
+		require.False(t, transition.state.IsCreated(created))
+	})
+
+	t.Run("legacy semantics before the fork", func(t *testing.T) {
+		t.Parallel()
+
+		transition := newTransition(t, chain.AllForksEnabled.Copy().RemoveFork(chain.EIP6780))
+		transition.state.SetCode(existing, selfdestruct)
+		transition.state.AddBalance(existing, big.NewInt(1000))
+
+		require.NoError(t, transition.Write(&types.Transaction{
+			From:     sender,
+			To:       &existing,
+			Gas:      100_000,
+			GasPrice: big.NewInt(1),
+		}))
+
+		require.Equal(t, []types.Address{existing}, transition.DeletedAccounts())
+		require.Equal(t, big.NewInt(1000), transition.state.GetBalance(beneficiary))
+	})
+}
+
+func TestTransition_EIP3529Refunds(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sender   = types.StringToAddress("0x700")
+		contract = types.StringToAddress("0x800")
+		slot     = types.ZeroHash
+	)
+
+	// the intrinsic gas of a transaction with the storage slot in its access list,
+	// the test cases of EIP-3529 assume a warm slot
+	const intrinsicGas = 21_000 + 2_400 + 1_900
+
+	run := func(t *testing.T, forks *chain.Forks, code []byte, original uint64) *types.Receipt {
+		t.Helper()
+
+		e := NewExecutor(&chain.Params{
+			Forks: forks,
+			BurnContract: map[uint64]types.Address{
+				0: types.ZeroAddress,
+			},
+		}, &preStateStore{
+			preState: map[types.Address]*PreState{
+				sender: {Balance: 1_000_000_000_000_000_000},
+				contract: {
+					Balance: 1,
+					State: map[types.Hash]types.Hash{
+						slot: types.BytesToHash(new(big.Int).SetUint64(original).Bytes()),
+					},
+				},
+			},
+		}, hclog.NewNullLogger())
+
+		e.GetHash = func(*types.Header) GetHashByNumber {
+			return func(uint64) types.Hash {
+				return types.ZeroHash
+			}
+		}
+
+		transition, err := e.BeginTxn(types.StringToHash("0x1"), &types.Header{Number: 1, GasLimit: 1_000_000}, types.ZeroAddress)
+		require.NoError(t, err)
+
+		transition.state.SetCode(contract, code)
+
+		require.NoError(t, transition.Write(&types.Transaction{
+			Type:     types.AccessListTx,
+			From:     sender,
+			To:       &contract,
+			Gas:      200_000,
+			GasPrice: big.NewInt(1),
+			Value:    big.NewInt(0),
+			AccessList: types.AccessList{
+				{Address: contract, StorageKeys: []types.Hash{slot}},
+			},
+		}))
+
+		receipts := transition.Receipts()
+		require.Len(t, receipts, 1)
+		require.Equal(t, types.ReceiptSuccess, *receipts[0].Status)
+
+		return receipts[0]
+	}
+
+	// the test cases of EIP-3529, the used gas excludes the intrinsic gas
+	testCases := []struct {
+		code     string
+		usedGas  uint64
+		refund   uint64
+		original uint64
+	}{
+		{"0x60006000556000600055", 212, 0, 0},
+		{"0x60006000556001600055", 20112, 0, 0},
+		{"0x60016000556000600055", 20112, 19900, 0},
+		{"0x60016000556002600055", 20112, 0, 0},
+		{"0x60016000556001600055", 20112, 0, 0},
+		{"0x60006000556000600055", 3012, 4800, 1},
+		{"0x60006000556001600055", 3012, 2800, 1},
+		{"0x60006000556002600055", 3012, 0, 1},
+		{"0x60026000556000600055", 3012, 4800, 1},
+		{"0x60026000556003600055", 3012, 0, 1},
+		{"0x60026000556001600055", 3012, 2800, 1},
+		{"0x60026000556002600055", 3012, 0, 1},
+		{"0x60016000556000600055", 3012, 4800, 1},
+		{"0x60016000556002600055", 3012, 0, 1},
+		{"0x60016000556001600055", 212, 0, 1},
+		{"0x600160005560006000556001600055", 40118, 19900, 0},
+		{"0x600060005560016000556000600055", 5918, 7600, 1},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(fmt.Sprintf("%s original %d", tc.code, tc.original), func(t *testing.T) {
+			t.Parallel()
+
+			receipt := run(t, chain.AllForksEnabled, hex.MustDecodeHex(tc.code), tc.original)
+
+			// the refund is capped at a fifth of the gas used
+			gasUsed := intrinsicGas + tc.usedGas
+			refund := tc.refund
+
+			if maxRefund := gasUsed / 5; refund > maxRefund {
+				refund = maxRefund
+			}
+
+			require.Equal(t, gasUsed-refund, receipt.GasUsed)
+		})
+	}
+
+	t.Run("refund cap before the fork", func(t *testing.T) {
+		t.Parallel()
+
+		// clears the slot, the refund of 15000 is capped at half the gas used
+		receipt := run(t, chain.AllForksEnabled.Copy().RemoveFork(chain.EIP3529),
+			hex.MustDecodeHex("0x6000600055"), 1)
+
+		const gasUsed = intrinsicGas + 3 + 3 + 2_900
+
+		require.Equal(t, uint64(gasUsed-gasUsed/2), receipt.GasUsed)
+	})
+
+	t.Run("no selfdestruct refund", func(t *testing.T) {
+		t.Parallel()
+
+		// sends the balance to the warm sender, which exists
+		code := append([]byte{0x73}, sender.Bytes()...) // PUSH20 sender
+		code = append(code, 0xff)                       // SELFDESTRUCT
+
+		const gasUsed = intrinsicGas + 3 + 5_000
+
+		// the refund of 24000 is capped at half the gas used before the fork
+		receipt := run(t, chain.AllForksEnabled.Copy().RemoveFork(chain.EIP6780).RemoveFork(chain.EIP3529), code, 0)
+		require.Equal(t, uint64(gasUsed-gasUsed/2), receipt.GasUsed)
+
+		receipt = run(t, chain.AllForksEnabled.Copy().RemoveFork(chain.EIP6780), code, 0)
+		require.Equal(t, uint64(gasUsed), receipt.GasUsed)
+	})
+}
+
+// TestExecutor_ParallelTransitions runs calls on parallel transitions of one executor,
+// which share its EVM like concurrent eth_calls do. Meant to be run with the race detector
+func TestExecutor_ParallelTransitions(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sender = types.StringToAddress("0x700")
+		target = types.StringToAddress("0x800")
+	)
+
+	// stores the hash of 0x2a and returns 0x2a
+	code := []byte{
+		0x60, 0x2a, // PUSH1 0x2a
+		0x60, 0x00, // PUSH1 0x00
+		0x52,       // MSTORE
+		0x60, 0x20, // PUSH1 0x20
+		0x60, 0x00, // PUSH1 0x00
+		0x20,       // SHA3
+		0x60, 0x00, // PUSH1 0x00
+		0x55,       // SSTORE
+		0x60, 0x20, // PUSH1 0x20
+		0x60, 0x00, // PUSH1 0x00
+		0xf3, // RETURN
+	}
+
+	e := NewExecutor(&chain.Params{
+		Forks: chain.AllForksEnabled,
+		BurnContract: map[uint64]types.Address{
+			0: types.ZeroAddress,
+		},
+	}, &preStateStore{
+		preState: map[types.Address]*PreState{
+			sender: {Balance: 1_000_000_000_000_000_000},
+		},
+	}, hclog.NewNullLogger())
+
+	e.GetHash = func(*types.Header) GetHashByNumber {
+		return func(uint64) types.Hash {
+			return types.ZeroHash
+		}
+	}
+
+	call := func() (*runtime.ExecutionResult, error) {
+		transition, err := e.BeginTxn(types.StringToHash("0x1"), &types.Header{Number: 1, GasLimit: 10_000_000}, types.ZeroAddress)
+		if err != nil {
+			return nil, err
+		}
+
+		transition.state.SetCode(target, code)
+
+		return transition.Apply(&types.Transaction{
+			From:     sender,
+			To:       &target,
+			Gas:      100_000,
+			GasPrice: big.NewInt(1_000_000_000),
+			Value:    big.NewInt(0),
+		})
+	}
+
+	expected, err := call()
+	require.NoError(t, err)
+	require.NoError(t, expected.Err)
+	require.Equal(t, types.BytesToHash([]byte{0x2a}).Bytes(), expected.ReturnValue)
+
+	var wg sync.WaitGroup
+
+	for worker := 0; worker < 8; worker++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := 0; i < 50; i++ {
+				result, err := call()
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				assert.Equal(t, expected, result)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
@@ -235,6 +235,12 @@ func NewKV(db *leveldb.DB) *KVStorage {
 	return &KVStorage{db: db}
 }
 
+// NewImportKV creates a storage for bulk imports whose batches are created by newBatch,
+// e.g. batches which are flushed on their way instead of being kept in memory until they are written
+func NewImportKV(db *leveldb.DB, newBatch func() Batch) *KVStorage {
+	return &KVStorage{db: db, newBatch: newBatch}
+}
+
 func NewTrieWithRoot(root Node) *Trie {
 	return &Trie{
 		root: root,
@@ -0,0 +1,157 @@
+package itrie
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/umbracle/fastrlp"
+	"github.com/xgr-network/xgr-node/crypto"
+	"github.com/xgr-network/xgr-node/state"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+var (
+	// ErrProofMissingNode is returned when a proof does not contain a node referenced on the key path
+	ErrProofMissingNode = errors.New("proof is missing a trie node")
+
+	// ErrInvalidProofNode is returned when a proof node can not be decoded as a trie node
+	ErrInvalidProofNode = errors.New("invalid trie node in proof")
+)
+
+// nodeResolver returns the RLP encoding of the trie node with the given hash
+type nodeResolver func(hash []byte) ([]byte, error)
+
+// walkProof follows key from the root down the trie, resolving hashed nodes with resolve.
+// It returns the RLP encoded nodes visited on the path (in root to leaf order) and the
+// value stored under key. A nil value together with a nil error means that the key is
+// not part of the trie and the returned nodes form an exclusion proof.
+func walkProof(root types.Hash, key []byte, resolve nodeResolver) ([][]byte, []byte, error) {
+	if root == types.EmptyRootHash {
+		return [][]byte{}, nil, nil
+	}
+
+	proof := [][]byte{}
+
+	next := func(hash []byte) (*fastrlp.Value, error) {
+		data, err := resolve(hash)
+		if err != nil {
+			return nil, err
+		}
+
+		proof = append(proof, data)
+
+		// each node gets its own parser, values of the previous nodes are still referenced
+		v, err := (&fastrlp.Parser{}).Parse(data)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidProofNode, err)
+		}
+
+		return v, nil
+	}
+
+	v, err := next(root.Bytes())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nibbles := bytesToHexNibbles(key)
+
+	for {
+		switch {
+		case v.Type() == fastrlp.TypeBytes:
+			ref := v.Raw()
+			if len(ref) == 0 {
+				return proof, nil, nil
+			}
+
+			if len(ref) != types.HashLength {
+				return nil, nil, fmt.Errorf("%w: reference of %d bytes", ErrInvalidProofNode, len(ref))
+			}
+
+			if v, err = next(ref); err != nil {
+				return nil, nil, err
+			}
+
+		case v.Elems() == 17:
+			if nibbles[0] == 16 {
+				return proof, copyValue(v.Get(16).Raw()), nil
+			}
+
+			v = v.Get(int(nibbles[0]))
+			nibbles = nibbles[1:]
+
+		case v.Elems() == 2:
+			if v.Get(0).Type() != fastrlp.TypeBytes {
+				return nil, nil, fmt.Errorf("%w: short node key expected to be bytes", ErrInvalidProofNode)
+			}
+
+			nodeKey := decodeCompact(v.Get(0).Raw())
+
+			if hasTerminator(nodeKey) {
+				if !bytes.Equal(nodeKey, nibbles) {
+					return proof, nil, nil
+				}
+
+				return proof, copyValue(v.Get(1).Raw()), nil
+			}
+
+			if !bytes.HasPrefix(nibbles, nodeKey) {
+				return proof, nil, nil
+			}
+
+			v = v.Get(1)
+			nibbles = nibbles[len(nodeKey):]
+
+		default:
+			return nil, nil, fmt.Errorf("%w: node with %d elements", ErrInvalidProofNode, v.Elems())
+		}
+	}
+}
+
+// copyValue detaches a value from the parser buffer, an empty value means the key is absent
+func copyValue(b []byte) []byte {
+	if len(b) == 0 {
+		return nil
+	}
+
+	return append([]byte{}, b...)
+}
+
+// prove collects the proof for key in the trie with the given root from storage
+func (s *State) prove(root types.Hash, key []byte) ([][]byte, error) {
+	proof, _, err := walkProof(root, key, func(hash []byte) ([]byte, error) {
+		data, ok, err := s.storage.Get(hash)
+		if err != nil {
+			return nil, storageReadError(err)
+		}
+
+		if !ok {
+			return nil, fmt.Errorf("%w at hash %s", state.ErrStateNotFound, types.BytesToHash(hash))
+		}
+
+		return data, nil
+	})
+
+	return proof, err
+}
+
+// VerifyProof checks the merkle proof for key against root. It returns the value
+// stored under key or nil if the proof shows that the key is not part of the trie.
+func VerifyProof(root types.Hash, key []byte, proof [][]byte) ([]byte, error) {
+	nodes := make(map[types.Hash][]byte, len(proof))
+	for _, node := range proof {
+		nodes[types.BytesToHash(crypto.Keccak256(node))] = node
+	}
+
+	_, value, err := walkProof(root, key, func(hash []byte) ([]byte, error) {
+		node, ok := nodes[types.BytesToHash(hash)]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrProofMissingNode, types.BytesToHash(hash))
+		}
+
+		return node, nil
+	})
+
+	return value, err
+}
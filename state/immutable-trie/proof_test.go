@@ -0,0 +1,146 @@
+package itrie
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/fastrlp"
+
+	"github.com/xgr-network/xgr-node/crypto"
+	"github.com/xgr-network/xgr-node/state"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+func TestProof_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	st := NewState(NewMemoryStorage())
+
+	objs := make([]*state.Object, 0, 64)
+
+	for i := 1; i <= 64; i++ {
+		obj := &state.Object{
+			Address:  types.BytesToAddress([]byte{byte(i)}),
+			Balance:  big.NewInt(int64(i * 1000)),
+			Nonce:    uint64(i),
+			CodeHash: types.EmptyCodeHash,
+			Root:     types.EmptyRootHash,
+		}
+
+		if i%2 == 0 {
+			for j := 1; j <= i/8+1; j++ {
+				obj.Storage = append(obj.Storage, &state.StorageObject{
+					Key: types.BytesToHash([]byte{byte(j)}).Bytes(),
+					Val: types.BytesToHash([]byte{byte(i), byte(j)}).Bytes(),
+				})
+			}
+		}
+
+		objs = append(objs, obj)
+	}
+
+	snap, root, err := st.NewSnapshot().Commit(objs)
+	require.NoError(t, err)
+
+	stateRoot := types.BytesToHash(root)
+
+	// reload the snapshot so that the proof is built from persisted nodes only
+	snap, err = NewState(st.storage).NewSnapshotAt(stateRoot)
+	require.NoError(t, err)
+
+	t.Run("existing account and storage", func(t *testing.T) {
+		t.Parallel()
+
+		for _, obj := range objs {
+			proof, err := snap.GetAccountProof(obj.Address)
+			require.NoError(t, err)
+			require.NotEmpty(t, proof)
+			require.Equal(t, stateRoot.Bytes(), crypto.Keccak256(proof[0]))
+
+			val, err := VerifyProof(stateRoot, crypto.Keccak256(obj.Address.Bytes()), proof)
+			require.NoError(t, err)
+			require.NotNil(t, val)
+
+			var account state.Account
+			require.NoError(t, account.UnmarshalRlp(val))
+			require.Equal(t, obj.Nonce, account.Nonce)
+			require.Equal(t, obj.Balance, account.Balance)
+
+			for _, entry := range obj.Storage {
+				slot := types.BytesToHash(entry.Key)
+
+				storageProof, err := snap.GetStorageProof(account.Root, slot)
+				require.NoError(t, err)
+
+				val, err := VerifyProof(account.Root, crypto.Keccak256(slot.Bytes()), storageProof)
+				require.NoError(t, err)
+				require.NotNil(t, val)
+
+				v, err := (&fastrlp.Parser{}).Parse(val)
+				require.NoError(t, err)
+
+				raw, err := v.Bytes()
+				require.NoError(t, err)
+				require.Equal(t, snap.GetStorage(obj.Address, account.Root, slot), types.BytesToHash(raw))
+			}
+
+			// slot which was never written
+			missingSlot := types.StringToHash("0xff")
+
+			storageProof, err := snap.GetStorageProof(account.Root, missingSlot)
+			require.NoError(t, err)
+
+			val, err = VerifyProof(account.Root, crypto.Keccak256(missingSlot.Bytes()), storageProof)
+			require.NoError(t, err)
+			require.Nil(t, val)
+		}
+	})
+
+	t.Run("non-existent account", func(t *testing.T) {
+		t.Parallel()
+
+		addr := types.StringToAddress("0xdeadbeef")
+
+		proof, err := snap.GetAccountProof(addr)
+		require.NoError(t, err)
+		require.NotEmpty(t, proof)
+
+		val, err := VerifyProof(stateRoot, crypto.Keccak256(addr.Bytes()), proof)
+		require.NoError(t, err)
+		require.Nil(t, val)
+	})
+
+	t.Run("tampered proof", func(t *testing.T) {
+		t.Parallel()
+
+		addr := objs[0].Address
+
+		proof, err := snap.GetAccountProof(addr)
+		require.NoError(t, err)
+
+		_, err = VerifyProof(stateRoot, crypto.Keccak256(addr.Bytes()), proof[:len(proof)-1])
+		require.ErrorIs(t, err, ErrProofMissingNode)
+
+		_, err = VerifyProof(types.StringToHash("0x1"), crypto.Keccak256(addr.Bytes()), proof)
+		require.ErrorIs(t, err, ErrProofMissingNode)
+	})
+}
+
+func TestProof_EmptyTrie(t *testing.T) {
+	t.Parallel()
+
+	snap := NewState(NewMemoryStorage()).NewSnapshot()
+
+	proof, err := snap.GetAccountProof(types.StringToAddress("0x1"))
+	require.NoError(t, err)
+	require.Empty(t, proof)
+
+	val, err := VerifyProof(types.EmptyRootHash, crypto.Keccak256(types.StringToAddress("0x1").Bytes()), proof)
+	require.NoError(t, err)
+	require.Nil(t, val)
+
+	proof, err = snap.GetStorageProof(types.EmptyRootHash, types.StringToHash("0x1"))
+	require.NoError(t, err)
+	require.Empty(t, proof)
+}
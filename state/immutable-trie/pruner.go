@@ -0,0 +1,260 @@
+package itrie
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/xgr-network/xgr-node/state"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+// defaultPruneBatchSize is the number of trie nodes deleted with a single batch
+const defaultPruneBatchSize = 10_000
+
+// Pruner deletes the trie nodes that are not reachable from a set of retained state roots.
+// It is a mark-and-sweep: all nodes reachable from the retained roots (including the storage
+// tries of their accounts) are marked, every other node is deleted in batches.
+// Nodes written since the previous prune started are never deleted, so blocks can be committed
+// concurrently and a block whose state is committed before its header is written keeps its state.
+// Contract code is never pruned.
+type Pruner struct {
+	logger    hclog.Logger
+	storage   PrunableStorage
+	batchSize int
+
+	// runLock allows a single prune at a time
+	runLock sync.Mutex
+
+	// written holds the nodes written since the current prune started,
+	// prevWritten the ones written between the previous and the current prune
+	writtenLock sync.Mutex
+	written     map[types.Hash]struct{}
+	prevWritten map[types.Hash]struct{}
+
+	pruned atomic.Bool
+}
+
+// NewPruner creates a pruner of the given storage
+func NewPruner(storage PrunableStorage, logger hclog.Logger) *Pruner {
+	return &Pruner{
+		logger:    logger.Named("pruner"),
+		storage:   storage,
+		batchSize: defaultPruneBatchSize,
+		written:   map[types.Hash]struct{}{},
+	}
+}
+
+// Storage returns the storage the state must write through, so that nodes
+// written while pruning are protected from deletion
+func (p *Pruner) Storage() Storage {
+	return &pruneTrackingStorage{PrunableStorage: p.storage, pruner: p}
+}
+
+// HasPruned returns whether any state has been pruned yet
+func (p *Pruner) HasPruned() bool {
+	return p.pruned.Load()
+}
+
+// Prune deletes all trie nodes that are not reachable from the retained roots
+// and returns the number of deleted nodes
+func (p *Pruner) Prune(retained []types.Hash) (int, error) {
+	p.runLock.Lock()
+	defer p.runLock.Unlock()
+
+	p.writtenLock.Lock()
+	p.prevWritten, p.written = p.written, map[types.Hash]struct{}{}
+	p.writtenLock.Unlock()
+
+	marked := map[types.Hash]struct{}{}
+
+	for _, root := range retained {
+		if root == types.EmptyRootHash {
+			continue
+		}
+
+		if err := p.markHash(root.Bytes(), marked, false); err != nil {
+			return 0, fmt.Errorf("failed to mark state root %s: %w", root, err)
+		}
+	}
+
+	deleted, err := p.sweep(marked)
+	if err != nil {
+		return deleted, err
+	}
+
+	p.pruned.Store(true)
+
+	p.logger.Info("state pruned", "retained_roots", len(retained), "retained_nodes", len(marked), "deleted_nodes", deleted)
+
+	return deleted, nil
+}
+
+func (p *Pruner) markHash(hash []byte, marked map[types.Hash]struct{}, isStorage bool) error {
+	key := types.BytesToHash(hash)
+	if _, ok := marked[key]; ok {
+		// shared subtrie, already marked
+		return nil
+	}
+
+	node, data, err := getCustomNode(hash, p.storage)
+	if err != nil {
+		return err
+	}
+
+	if data == nil {
+		return fmt.Errorf("%w at hash %s", state.ErrStateNotFound, key)
+	}
+
+	marked[key] = struct{}{}
+
+	return p.markNode(node, marked, isStorage)
+}
+
+func (p *Pruner) markNode(node Node, marked map[types.Hash]struct{}, isStorage bool) error {
+	switch n := node.(type) {
+	case nil:
+		return nil
+	case *FullNode:
+		for _, child := range n.children {
+			if err := p.markNode(child, marked, isStorage); err != nil {
+				return err
+			}
+		}
+
+		return p.markNode(n.value, marked, isStorage)
+	case *ShortNode:
+		return p.markNode(n.child, marked, isStorage)
+	case *ValueNode:
+		if n.hash {
+			return p.markHash(n.buf, marked, isStorage)
+		}
+
+		if isStorage {
+			return nil
+		}
+
+		var account state.Account
+		if err := account.UnmarshalRlp(n.buf); err != nil {
+			return fmt.Errorf("can't parse account: %w", err)
+		}
+
+		if account.Root != types.EmptyRootHash && account.Root != (types.Hash{}) {
+			return p.markHash(account.Root.Bytes(), marked, true)
+		}
+	}
+
+	return nil
+}
+
+func (p *Pruner) sweep(marked map[types.Hash]struct{}) (int, error) {
+	var (
+		deleted  int
+		batch    = make([][]byte, 0, p.batchSize)
+		flushErr error
+	)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		// recently written nodes may belong to roots that are not retained yet,
+		// so the batch is filtered and deleted without letting new writes in between
+		p.writtenLock.Lock()
+		defer p.writtenLock.Unlock()
+
+		keys := batch[:0]
+
+		for _, k := range batch {
+			if !p.isRecentlyWritten(types.BytesToHash(k)) {
+				keys = append(keys, k)
+			}
+		}
+
+		if err := p.storage.Delete(keys); err != nil {
+			return err
+		}
+
+		deleted += len(keys)
+		batch = batch[:0]
+
+		return nil
+	}
+
+	err := p.storage.IterateNodeKeys(func(k []byte) bool {
+		if _, ok := marked[types.BytesToHash(k)]; ok {
+			return true
+		}
+
+		batch = append(batch, k)
+
+		if len(batch) >= p.batchSize {
+			if flushErr = flush(); flushErr != nil {
+				return false
+			}
+		}
+
+		return true
+	})
+	if err != nil {
+		return deleted, err
+	}
+
+	if flushErr != nil {
+		return deleted, flushErr
+	}
+
+	return deleted, flush()
+}
+
+// isRecentlyWritten returns whether the node was written since the previous prune started,
+// the caller must hold writtenLock
+func (p *Pruner) isRecentlyWritten(k types.Hash) bool {
+	if _, ok := p.written[k]; ok {
+		return true
+	}
+
+	_, ok := p.prevWritten[k]
+
+	return ok
+}
+
+// track protects a written node from being deleted by the next two prunes
+func (p *Pruner) track(k []byte) {
+	if !isNodeKey(k) {
+		return
+	}
+
+	p.writtenLock.Lock()
+	defer p.writtenLock.Unlock()
+
+	p.written[types.BytesToHash(k)] = struct{}{}
+}
+
+// pruneTrackingStorage reports all writes to the pruner
+type pruneTrackingStorage struct {
+	PrunableStorage
+	pruner *Pruner
+}
+
+func (s *pruneTrackingStorage) Put(k, v []byte) error {
+	s.pruner.track(k)
+
+	return s.PrunableStorage.Put(k, v)
+}
+
+func (s *pruneTrackingStorage) Batch() Batch {
+	return &pruneTrackingBatch{Batch: s.PrunableStorage.Batch(), pruner: s.pruner}
+}
+
+type pruneTrackingBatch struct {
+	Batch
+	pruner *Pruner
+}
+
+func (b *pruneTrackingBatch) Put(k, v []byte) {
+	b.pruner.track(k)
+	b.Batch.Put(k, v)
+}
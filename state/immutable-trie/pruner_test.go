@@ -0,0 +1,144 @@
+package itrie
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xgr-network/xgr-node/state"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+func TestPruner_KeepsRetainedRoots(t *testing.T) {
+	t.Parallel()
+
+	const (
+		blocks    = 10
+		retention = 3
+	)
+
+	storage, ok := NewMemoryStorage().(PrunableStorage)
+	require.True(t, ok)
+
+	st, pruner := NewPrunedState(storage, hclog.NewNullLogger())
+
+	addrs := []types.Address{
+		types.StringToAddress("1"),
+		types.StringToAddress("2"),
+		types.StringToAddress("3"),
+	}
+
+	counterSlot := types.BytesToHash([]byte("counter"))
+	one := types.BytesToHash([]byte{1})
+
+	code := []byte{0x60, 0x00, 0x60, 0x00, 0xf3}
+	codeHash := types.BytesToHash([]byte("code"))
+
+	var (
+		snap  = st.NewSnapshot()
+		roots = make([]types.Hash, 0, blocks)
+	)
+
+	// every block updates the balance and storage of one account
+	for i := 1; i <= blocks; i++ {
+		addr := addrs[i%len(addrs)]
+
+		obj := &state.Object{
+			Address:  addr,
+			Balance:  big.NewInt(int64(i)),
+			Nonce:    uint64(i),
+			CodeHash: types.EmptyCodeHash,
+			Root:     types.EmptyRootHash,
+			Storage: []*state.StorageObject{
+				{Key: counterSlot.Bytes(), Val: types.BytesToHash([]byte{byte(i)}).Bytes()},
+				{Key: types.BytesToHash([]byte{byte(i)}).Bytes(), Val: one.Bytes()},
+			},
+		}
+
+		account, err := snap.GetAccount(addr)
+		require.NoError(t, err)
+
+		if account != nil {
+			obj.Root = account.Root
+		}
+
+		if i == 1 {
+			obj.CodeHash = codeHash
+			obj.Code = code
+			obj.DirtyCode = true
+		}
+
+		var root []byte
+
+		snap, root, err = snap.Commit([]*state.Object{obj})
+		require.NoError(t, err)
+
+		roots = append(roots, types.BytesToHash(root))
+	}
+
+	retained := roots[blocks-retention:]
+
+	// the nodes were all written after the previous prune, they are protected once more
+	deleted, err := pruner.Prune(retained)
+	require.NoError(t, err)
+	require.Zero(t, deleted)
+
+	deleted, err = pruner.Prune(retained)
+	require.NoError(t, err)
+	require.Positive(t, deleted)
+
+	// drop the cached tries, so that the roots are loaded from the storage
+	st.cache.Purge()
+
+	for _, root := range roots[:blocks-retention] {
+		_, err := st.NewSnapshotAt(root)
+		require.ErrorIs(t, err, state.ErrStatePruned)
+		require.ErrorIs(t, err, state.ErrStateNotFound)
+	}
+
+	for i, root := range retained {
+		number := blocks - retention + i + 1
+
+		snap, err := st.NewSnapshotAt(root)
+		require.NoError(t, err)
+
+		account, err := snap.GetAccount(addrs[number%len(addrs)])
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(int64(number)), account.Balance)
+
+		require.Equal(
+			t,
+			types.BytesToHash([]byte{byte(number)}),
+			snap.GetStorage(addrs[number%len(addrs)], account.Root, counterSlot),
+		)
+
+		// storage written by older blocks is still reachable
+		for _, addr := range addrs {
+			account, err := snap.GetAccount(addr)
+			require.NoError(t, err)
+			require.NotNil(t, account)
+
+			for j := 1; j <= number; j++ {
+				if addrs[j%len(addrs)] != addr {
+					continue
+				}
+
+				require.Equal(
+					t,
+					one,
+					snap.GetStorage(addr, account.Root, types.BytesToHash([]byte{byte(j)})),
+				)
+			}
+		}
+	}
+
+	// contract code is never pruned
+	snap, err = st.NewSnapshotAt(roots[blocks-1])
+	require.NoError(t, err)
+
+	stored, ok := snap.GetCode(codeHash)
+	require.True(t, ok)
+	require.Equal(t, code, stored)
+}
@@ -70,6 +70,26 @@ func (s *Snapshot) GetAccount(addr types.Address) (*state.Account, error) {
 	return &account, nil
 }
 
+// GetAccountProof returns the merkle proof of the account in the state trie
+func (s *Snapshot) GetAccountProof(addr types.Address) ([][]byte, error) {
+	// nodes loaded from storage do not carry their hash, so it is recomputed from the children
+	root, err := s.trie.Txn(s.state.storage).Hash()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.state.prove(types.BytesToHash(root), crypto.Keccak256(addr.Bytes()))
+}
+
+// GetStorageProof returns the merkle proof of the slot in the storage trie with the given root
+func (s *Snapshot) GetStorageProof(root types.Hash, rawkey types.Hash) ([][]byte, error) {
+	if root == emptyStateHash {
+		return [][]byte{}, nil
+	}
+
+	return s.state.prove(root, crypto.Keccak256(rawkey.Bytes()))
+}
+
 func (s *Snapshot) GetCode(hash types.Hash) ([]byte, bool) {
 	return s.state.GetCode(hash)
 }
@@ -77,6 +97,35 @@ func (s *Snapshot) GetCode(hash types.Hash) ([]byte, bool) {
 func (s *Snapshot) Commit(objs []*state.Object) (state.Snapshot, []byte, error) {
 	batch := s.state.storage.Batch()
 
+	nTrie, root, err := s.apply(objs, batch, true)
+	if err != nil {
+		return nil, types.ZeroHash[:], err
+	}
+
+	// Write all the entries to db
+	if err := batch.Write(); err != nil {
+		return nil, types.ZeroHash[:], fmt.Errorf("snapshot commit db write error: %w", err)
+	}
+
+	s.state.AddState(types.BytesToHash(root), nTrie)
+
+	return &Snapshot{trie: nTrie, state: s.state}, root, nil
+}
+
+// Root returns the state root with the objects applied. Neither the trie nodes
+// nor the code are written to the storage, and the tries are not cached
+func (s *Snapshot) Root(objs []*state.Object) ([]byte, error) {
+	_, root, err := s.apply(objs, discardBatch{}, false)
+	if err != nil {
+		return types.ZeroHash[:], err
+	}
+
+	return root, nil
+}
+
+// apply applies the objects to the trie, the new nodes and the code go to the batch.
+// The new account storage tries are cached if cache is set
+func (s *Snapshot) apply(objs []*state.Object, batch Batch, cache bool) (*Trie, []byte, error) {
 	tt := s.trie.Txn(s.state.storage)
 	tt.batch = batch
 
@@ -97,7 +146,7 @@ func (s *Snapshot) Commit(objs []*state.Object) (state.Snapshot, []byte, error)
 			if len(obj.Storage) != 0 {
 				trie, err := s.state.newTrieAt(obj.Root)
 				if err != nil {
-					return nil, types.ZeroHash[:], fmt.Errorf("snapshot commit failed to create trie: %w", err)
+					return nil, nil, fmt.Errorf("snapshot commit failed to create trie: %w", err)
 				}
 
 				localTxn := trie.Txn(s.state.storage)
@@ -114,10 +163,13 @@ func (s *Snapshot) Commit(objs []*state.Object) (state.Snapshot, []byte, error)
 				}
 
 				accountStateRoot, _ := localTxn.Hash()
-				accountStateTrie := localTxn.Commit()
 
-				// Add this to the cache
-				s.state.AddState(types.BytesToHash(accountStateRoot), accountStateTrie)
+				if cache {
+					accountStateTrie := localTxn.Commit()
+
+					// Add this to the cache
+					s.state.AddState(types.BytesToHash(accountStateRoot), accountStateTrie)
+				}
 
 				account.Root = types.BytesToHash(accountStateRoot)
 			}
@@ -136,17 +188,17 @@ func (s *Snapshot) Commit(objs []*state.Object) (state.Snapshot, []byte, error)
 
 	root, err := tt.Hash()
 	if err != nil {
-		return nil, types.ZeroHash[:], fmt.Errorf("snapshot commit can not retrieve hash: %w", err)
+		return nil, nil, fmt.Errorf("snapshot commit can not retrieve hash: %w", err)
 	}
 
-	nTrie := tt.Commit()
+	return tt.Commit(), root, nil
+}
 
-	// Write all the entries to db
-	if err := batch.Write(); err != nil {
-		return nil, types.ZeroHash[:], fmt.Errorf("snapshot commit db write error: %w", err)
-	}
+// discardBatch drops the writes, it computes a root without persisting the nodes
+type discardBatch struct{}
 
-	s.state.AddState(types.BytesToHash(root), nTrie)
+func (discardBatch) Put(k, v []byte) {}
 
-	return &Snapshot{trie: nTrie, state: s.state}, root, nil
+func (discardBatch) Write() error {
+	return nil
 }
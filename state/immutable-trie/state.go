@@ -3,6 +3,7 @@ package itrie
 import (
 	"fmt"
 
+	"github.com/hashicorp/go-hclog"
 	lru "github.com/hashicorp/golang-lru"
 
 	"github.com/xgr-network/xgr-node/state"
@@ -12,6 +13,7 @@ import (
 type State struct {
 	storage Storage
 	cache   *lru.Cache
+	pruner  *Pruner
 }
 
 func NewState(storage Storage) *State {
@@ -25,6 +27,17 @@ func NewState(storage Storage) *State {
 	return s
 }
 
+// NewPrunedState creates a state whose unreachable trie nodes can be deleted by the returned pruner.
+// Missing roots are reported as pruned once the pruner ran.
+func NewPrunedState(storage PrunableStorage, logger hclog.Logger) (*State, *Pruner) {
+	pruner := NewPruner(storage, logger)
+
+	s := NewState(pruner.Storage())
+	s.pruner = pruner
+
+	return s, pruner
+}
+
 func (s *State) NewSnapshot() state.Snapshot {
 	return &Snapshot{state: s, trie: s.newTrie()}
 }
@@ -77,7 +90,11 @@ func (s *State) newTrieAt(root types.Hash) (*Trie, error) {
 	}
 
 	if !ok {
-		return nil, fmt.Errorf("state not found at hash %s", root)
+		if s.pruner != nil && s.pruner.HasPruned() {
+			return nil, fmt.Errorf("%w: %w at hash %s", state.ErrStatePruned, state.ErrStateNotFound, root)
+		}
+
+		return nil, fmt.Errorf("%w at hash %s", state.ErrStateNotFound, root)
 	}
 
 	t := &Trie{
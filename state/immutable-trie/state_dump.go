@@ -0,0 +1,529 @@
+package itrie
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/xgr-network/xgr-node/crypto"
+	"github.com/xgr-network/xgr-node/state"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+// A state dump is a gzip compressed stream of records. It starts with the magic, the format version
+// and the state root, followed by one record per account in trie order. Every account record is
+// followed by the code of the account (unless the code was already dumped) and its storage slots.
+// The dump ends with a record holding the number of dumped accounts, codes and slots.
+//
+// Trie keys are hashed, so accounts and slots are dumped by their hashed keys,
+// with their values as stored in the trie.
+
+const (
+	stateDumpVersion = 1
+
+	recordAccount byte = 0x01
+	recordCode    byte = 0x02
+	recordSlot    byte = 0x03
+	recordEnd     byte = 0xff
+
+	// maxDumpValueLength bounds the length of a single value read from a dump
+	maxDumpValueLength = 1 << 26
+
+	// dumpProgressInterval is the number of accounts between two progress reports
+	dumpProgressInterval = 10_000
+)
+
+var (
+	stateDumpMagic = []byte("XGRSTATE")
+
+	ErrInvalidStateDump  = errors.New("invalid state dump")
+	ErrStateRootMismatch = errors.New("imported state root doesn't match the dump")
+)
+
+// StateDumpStats are the number of accounts, codes and storage slots of a state dump
+type StateDumpStats struct {
+	Accounts uint64 `json:"accounts"`
+	Codes    uint64 `json:"codes"`
+	Slots    uint64 `json:"slots"`
+}
+
+// ProgressFn is called periodically while a state dump is exported or imported
+type ProgressFn func(stats StateDumpStats)
+
+// ExportState streams the state at root into w. The trie is walked node by node,
+// so the state is never held in memory as a whole.
+func ExportState(root types.Hash, storage Storage, w io.Writer, progress ProgressFn) (*StateDumpStats, error) {
+	zw := gzip.NewWriter(w)
+	bw := bufio.NewWriter(zw)
+
+	dw := &dumpWriter{w: bw}
+	dw.write(stateDumpMagic)
+	dw.write([]byte{stateDumpVersion})
+	dw.write(root.Bytes())
+
+	var (
+		stats       StateDumpStats
+		dumpedCodes = map[types.Hash]struct{}{}
+	)
+
+	err := walkLeaves(root, storage, func(key, value []byte) error {
+		var account state.Account
+		if err := account.UnmarshalRlp(value); err != nil {
+			return fmt.Errorf("can't parse account %x: %w", key, err)
+		}
+
+		dw.write([]byte{recordAccount})
+		dw.write(key)
+		dw.writeBytes(value)
+
+		stats.Accounts++
+
+		codeHash := types.BytesToHash(account.CodeHash)
+		if len(account.CodeHash) != 0 && codeHash != types.EmptyCodeHash {
+			if _, ok := dumpedCodes[codeHash]; !ok {
+				code, ok := storage.GetCode(codeHash)
+				if !ok {
+					return fmt.Errorf("can't find code %s", codeHash)
+				}
+
+				dw.write([]byte{recordCode})
+				dw.writeBytes(code)
+
+				dumpedCodes[codeHash] = struct{}{}
+				stats.Codes++
+			}
+		}
+
+		if account.Root != types.EmptyRootHash && account.Root != (types.Hash{}) {
+			err := walkLeaves(account.Root, storage, func(slot, value []byte) error {
+				dw.write([]byte{recordSlot})
+				dw.write(slot)
+				dw.writeBytes(value)
+
+				stats.Slots++
+
+				return dw.err
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if progress != nil && stats.Accounts%dumpProgressInterval == 0 {
+			progress(stats)
+		}
+
+		return dw.err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dw.write([]byte{recordEnd})
+	dw.writeUvarint(stats.Accounts)
+	dw.writeUvarint(stats.Codes)
+	dw.writeUvarint(stats.Slots)
+
+	if dw.err != nil {
+		return nil, dw.err
+	}
+
+	if err := bw.Flush(); err != nil {
+		return nil, err
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	if progress != nil {
+		progress(stats)
+	}
+
+	return &stats, nil
+}
+
+// ImportState loads a state dump into storage and verifies that the recomputed state root
+// matches the root of the dump. The storage tries and codes are written account by account,
+// the account trie is written once its root is verified.
+func ImportState(r io.Reader, storage Storage, progress ProgressFn) (types.Hash, *StateDumpStats, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return types.Hash{}, nil, fmt.Errorf("%w: %w", ErrInvalidStateDump, err)
+	}
+	defer zr.Close()
+
+	dr := &dumpReader{r: bufio.NewReader(zr)}
+
+	magic := dr.read(len(stateDumpMagic))
+	version := dr.read(1)
+	root := types.BytesToHash(dr.read(types.HashLength))
+
+	if dr.err != nil {
+		return types.Hash{}, nil, dr.err
+	}
+
+	if !bytes.Equal(magic, stateDumpMagic) || version[0] != stateDumpVersion {
+		return types.Hash{}, nil, fmt.Errorf("%w: unknown format", ErrInvalidStateDump)
+	}
+
+	imp := &stateImporter{
+		storage:       storage,
+		accounts:      NewTrie().Txn(storage),
+		importedCodes: map[types.Hash]struct{}{},
+	}
+
+	for {
+		kind := dr.read(1)
+		if dr.err != nil {
+			return types.Hash{}, nil, dr.err
+		}
+
+		switch kind[0] {
+		case recordAccount:
+			key := dr.read(types.HashLength)
+			value := dr.readBytes()
+
+			if dr.err != nil {
+				return types.Hash{}, nil, dr.err
+			}
+
+			if err := imp.startAccount(key, value); err != nil {
+				return types.Hash{}, nil, err
+			}
+
+			if progress != nil && imp.stats.Accounts%dumpProgressInterval == 0 {
+				progress(imp.stats)
+			}
+		case recordCode:
+			code := dr.readBytes()
+			if dr.err != nil {
+				return types.Hash{}, nil, dr.err
+			}
+
+			if err := imp.addCode(code); err != nil {
+				return types.Hash{}, nil, err
+			}
+		case recordSlot:
+			slot := dr.read(types.HashLength)
+			value := dr.readBytes()
+
+			if dr.err != nil {
+				return types.Hash{}, nil, dr.err
+			}
+
+			if err := imp.addSlot(slot, value); err != nil {
+				return types.Hash{}, nil, err
+			}
+		case recordEnd:
+			expected := StateDumpStats{
+				Accounts: dr.readUvarint(),
+				Codes:    dr.readUvarint(),
+				Slots:    dr.readUvarint(),
+			}
+
+			if dr.err != nil {
+				return types.Hash{}, nil, dr.err
+			}
+
+			if expected != imp.stats {
+				return types.Hash{}, nil, fmt.Errorf("%w: expected %+v records, got %+v",
+					ErrInvalidStateDump, expected, imp.stats)
+			}
+
+			importedRoot, err := imp.finish(root)
+			if err != nil {
+				return importedRoot, nil, err
+			}
+
+			if progress != nil {
+				progress(imp.stats)
+			}
+
+			return importedRoot, &imp.stats, nil
+		default:
+			return types.Hash{}, nil, fmt.Errorf("%w: unknown record type %d", ErrInvalidStateDump, kind[0])
+		}
+	}
+}
+
+// stateImporter rebuilds the tries of a state dump
+type stateImporter struct {
+	storage  Storage
+	accounts *Txn
+	stats    StateDumpStats
+
+	// the account whose code and storage records are being read
+	key     []byte
+	account *state.Account
+	slots   *Txn
+	batch   Batch
+
+	importedCodes map[types.Hash]struct{}
+}
+
+func (i *stateImporter) startAccount(key, value []byte) error {
+	if err := i.finishAccount(); err != nil {
+		return err
+	}
+
+	account := &state.Account{}
+	if err := account.UnmarshalRlp(value); err != nil {
+		return fmt.Errorf("%w: can't parse account %x: %w", ErrInvalidStateDump, key, err)
+	}
+
+	i.key = key
+	i.account = account
+	i.batch = i.storage.Batch()
+	i.slots = NewTrie().Txn(i.storage)
+	i.slots.batch = i.batch
+
+	i.accounts.Insert(key, value)
+	i.stats.Accounts++
+
+	return nil
+}
+
+func (i *stateImporter) addCode(code []byte) error {
+	if i.account == nil {
+		return fmt.Errorf("%w: code without account", ErrInvalidStateDump)
+	}
+
+	hash := types.BytesToHash(crypto.Keccak256(code))
+	if hash != types.BytesToHash(i.account.CodeHash) {
+		return fmt.Errorf("%w: unexpected code %s for account %x", ErrInvalidStateDump, hash, i.key)
+	}
+
+	i.batch.Put(GetCodeKey(hash), code)
+	i.importedCodes[hash] = struct{}{}
+	i.stats.Codes++
+
+	return nil
+}
+
+func (i *stateImporter) addSlot(slot, value []byte) error {
+	if i.account == nil {
+		return fmt.Errorf("%w: storage slot without account", ErrInvalidStateDump)
+	}
+
+	i.slots.Insert(slot, value)
+	i.stats.Slots++
+
+	return nil
+}
+
+// finishAccount verifies the code and storage root of the current account and writes them
+func (i *stateImporter) finishAccount() error {
+	if i.account == nil {
+		return nil
+	}
+
+	codeHash := types.BytesToHash(i.account.CodeHash)
+	if len(i.account.CodeHash) != 0 && codeHash != types.EmptyCodeHash {
+		if _, ok := i.importedCodes[codeHash]; !ok {
+			return fmt.Errorf("%w: missing code %s of account %x", ErrInvalidStateDump, codeHash, i.key)
+		}
+	}
+
+	storageRoot, err := i.slots.Hash()
+	if err != nil {
+		return err
+	}
+
+	expectedRoot := i.account.Root
+	if expectedRoot == (types.Hash{}) {
+		expectedRoot = types.EmptyRootHash
+	}
+
+	if types.BytesToHash(storageRoot) != expectedRoot {
+		return fmt.Errorf("%w: storage root of account %x is %s, expected %s",
+			ErrStateRootMismatch, i.key, types.BytesToHash(storageRoot), expectedRoot)
+	}
+
+	if err := i.batch.Write(); err != nil {
+		return err
+	}
+
+	i.key, i.account, i.slots, i.batch = nil, nil, nil, nil
+
+	return nil
+}
+
+// finish writes the account trie if its root matches the expected one
+func (i *stateImporter) finish(expected types.Hash) (types.Hash, error) {
+	if err := i.finishAccount(); err != nil {
+		return types.Hash{}, err
+	}
+
+	batch := i.storage.Batch()
+	i.accounts.batch = batch
+
+	rawRoot, err := i.accounts.Hash()
+	if err != nil {
+		return types.Hash{}, err
+	}
+
+	root := types.BytesToHash(rawRoot)
+	if root != expected {
+		return root, fmt.Errorf("%w: got %s, expected %s", ErrStateRootMismatch, root, expected)
+	}
+
+	return root, batch.Write()
+}
+
+// walkLeaves calls fn with the key and value of every leaf of the trie with the given root
+func walkLeaves(root types.Hash, storage Storage, fn func(key, value []byte) error) error {
+	if root == types.EmptyRootHash {
+		return nil
+	}
+
+	node, ok, err := GetNode(root.Bytes(), storage)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return fmt.Errorf("%w at hash %s", state.ErrStateNotFound, root)
+	}
+
+	return walkNode(node, nil, storage, fn)
+}
+
+func walkNode(node Node, path []byte, storage Storage, fn func(key, value []byte) error) error {
+	switch n := node.(type) {
+	case nil:
+		return nil
+	case *ValueNode:
+		if n.hash {
+			child, ok, err := GetNode(n.buf, storage)
+			if err != nil {
+				return err
+			}
+
+			if !ok {
+				return fmt.Errorf("%w at hash %x", state.ErrStateNotFound, n.buf)
+			}
+
+			return walkNode(child, path, storage, fn)
+		}
+
+		key, err := nibblesToKey(path)
+		if err != nil {
+			return err
+		}
+
+		return fn(key, n.buf)
+	case *ShortNode:
+		return walkNode(n.child, concat(path, n.key), storage, fn)
+	case *FullNode:
+		if err := walkNode(n.value, path, storage, fn); err != nil {
+			return err
+		}
+
+		for i, child := range n.children {
+			if child == nil {
+				continue
+			}
+
+			if err := walkNode(child, concat(path, []byte{byte(i)}), storage, fn); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("unknown node type %T", node)
+	}
+}
+
+// nibblesToKey packs the nibbles of a trie path into the key, dropping the terminator
+func nibblesToKey(path []byte) ([]byte, error) {
+	if hasTerminator(path) {
+		path = path[:len(path)-1]
+	}
+
+	if len(path)%2 != 0 {
+		return nil, fmt.Errorf("odd trie path length %d", len(path))
+	}
+
+	key := make([]byte, len(path)/2)
+	for i := range key {
+		key[i] = path[2*i]<<4 | path[2*i+1]
+	}
+
+	return key, nil
+}
+
+// dumpWriter writes dump fields and keeps the first error
+type dumpWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (d *dumpWriter) write(b []byte) {
+	if d.err == nil {
+		_, d.err = d.w.Write(b)
+	}
+}
+
+func (d *dumpWriter) writeUvarint(v uint64) {
+	d.write(binary.AppendUvarint(nil, v))
+}
+
+func (d *dumpWriter) writeBytes(b []byte) {
+	d.writeUvarint(uint64(len(b)))
+	d.write(b)
+}
+
+// dumpReader reads dump fields and keeps the first error
+type dumpReader struct {
+	r   *bufio.Reader
+	err error
+}
+
+func (d *dumpReader) read(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+
+	b := make([]byte, n)
+	if _, err := io.ReadFull(d.r, b); err != nil {
+		d.err = fmt.Errorf("%w: %w", ErrInvalidStateDump, err)
+
+		return nil
+	}
+
+	return b
+}
+
+func (d *dumpReader) readUvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+
+	v, err := binary.ReadUvarint(d.r)
+	if err != nil {
+		d.err = fmt.Errorf("%w: %w", ErrInvalidStateDump, err)
+	}
+
+	return v
+}
+
+func (d *dumpReader) readBytes() []byte {
+	n := d.readUvarint()
+	if d.err != nil {
+		return nil
+	}
+
+	if n > maxDumpValueLength {
+		d.err = fmt.Errorf("%w: value of %d bytes", ErrInvalidStateDump, n)
+
+		return nil
+	}
+
+	return d.read(int(n))
+}
@@ -0,0 +1,134 @@
+package itrie
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/xgr-network/xgr-node/crypto"
+	"github.com/xgr-network/xgr-node/state"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+func TestStateDump_ExportImport(t *testing.T) {
+	t.Parallel()
+
+	st := NewState(NewMemoryStorage())
+
+	code := []byte{0x60, 0x00, 0x60, 0x00, 0xf3}
+	codeHash := types.BytesToHash(crypto.Keccak256(code))
+
+	objs := make([]*state.Object, 0, 100)
+
+	for i := 1; i <= 100; i++ {
+		obj := &state.Object{
+			Address:  types.BytesToAddress([]byte{byte(i)}),
+			Balance:  big.NewInt(int64(i * 1000)),
+			Nonce:    uint64(i),
+			CodeHash: types.EmptyCodeHash,
+			Root:     types.EmptyRootHash,
+		}
+
+		// contracts share the same code, it is only dumped once
+		if i%10 == 0 {
+			obj.CodeHash = codeHash
+			obj.Code = code
+			obj.DirtyCode = true
+
+			for j := 1; j <= i; j++ {
+				obj.Storage = append(obj.Storage, &state.StorageObject{
+					Key: types.BytesToHash([]byte{byte(j)}).Bytes(),
+					Val: types.BytesToHash([]byte{byte(i), byte(j)}).Bytes(),
+				})
+			}
+		}
+
+		objs = append(objs, obj)
+	}
+
+	_, rawRoot, err := st.NewSnapshot().Commit(objs)
+	require.NoError(t, err)
+
+	root := types.BytesToHash(rawRoot)
+
+	var (
+		dump     bytes.Buffer
+		reported []StateDumpStats
+	)
+
+	stats, err := ExportState(root, st.storage, &dump, func(s StateDumpStats) {
+		reported = append(reported, s)
+	})
+	require.NoError(t, err)
+	require.Equal(t, StateDumpStats{Accounts: 100, Codes: 1, Slots: 550}, *stats)
+	require.Equal(t, *stats, reported[len(reported)-1])
+
+	imported := NewMemoryStorage()
+
+	importedRoot, importStats, err := ImportState(bytes.NewReader(dump.Bytes()), imported, nil)
+	require.NoError(t, err)
+	require.Equal(t, root, importedRoot)
+	require.Equal(t, stats, importStats)
+
+	// the imported state is complete
+	checkedRoot, err := HashChecker(root.Bytes(), imported)
+	require.NoError(t, err)
+	require.Equal(t, root, checkedRoot)
+
+	snap, err := NewState(imported).NewSnapshotAt(root)
+	require.NoError(t, err)
+
+	for _, obj := range objs {
+		account, err := snap.GetAccount(obj.Address)
+		require.NoError(t, err)
+		require.Equal(t, obj.Balance, account.Balance)
+		require.Equal(t, obj.Nonce, account.Nonce)
+
+		for _, entry := range obj.Storage {
+			require.Equal(
+				t,
+				types.BytesToHash(entry.Val),
+				snap.GetStorage(obj.Address, account.Root, types.BytesToHash(entry.Key)),
+			)
+		}
+	}
+
+	storedCode, ok := snap.GetCode(codeHash)
+	require.True(t, ok)
+	require.Equal(t, code, storedCode)
+}
+
+func TestStateDump_ImportRejectsInvalidDumps(t *testing.T) {
+	t.Parallel()
+
+	st := NewState(NewMemoryStorage())
+
+	_, rawRoot, err := st.NewSnapshot().Commit([]*state.Object{
+		{
+			Address:  types.StringToAddress("1"),
+			Balance:  big.NewInt(1),
+			CodeHash: types.EmptyCodeHash,
+			Root:     types.EmptyRootHash,
+		},
+	})
+	require.NoError(t, err)
+
+	var dump bytes.Buffer
+
+	_, err = ExportState(types.BytesToHash(rawRoot), st.storage, &dump, nil)
+	require.NoError(t, err)
+
+	// truncated dump
+	_, _, err = ImportState(bytes.NewReader(dump.Bytes()[:dump.Len()/2]), NewMemoryStorage(), nil)
+	require.ErrorIs(t, err, ErrInvalidStateDump)
+
+	// not a dump
+	_, _, err = ImportState(bytes.NewReader([]byte("not a dump")), NewMemoryStorage(), nil)
+	require.ErrorIs(t, err, ErrInvalidStateDump)
+
+	// unknown root
+	_, err = ExportState(types.StringToHash("0x1234"), st.storage, &bytes.Buffer{}, nil)
+	require.ErrorIs(t, err, state.ErrStateNotFound)
+}
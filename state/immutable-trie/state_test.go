@@ -1,9 +1,19 @@
 package itrie
 
 import (
+	"errors"
+	"io/fs"
+	"math/big"
+	"syscall"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+	"github.com/syndtr/goleveldb/leveldb"
+	lerrors "github.com/syndtr/goleveldb/leveldb/errors"
+
+	"github.com/xgr-network/xgr-node/crypto"
 	"github.com/xgr-network/xgr-node/state"
+	"github.com/xgr-network/xgr-node/types"
 )
 
 func TestState(t *testing.T) {
@@ -16,3 +26,87 @@ func buildPreState(pre state.PreStates) state.Snapshot {
 
 	return st.NewSnapshot()
 }
+
+// faultyStorage fails every read of the underlying storage
+type faultyStorage struct {
+	Storage
+	err error
+}
+
+func (f *faultyStorage) Get([]byte) ([]byte, bool, error) {
+	return nil, false, f.err
+}
+
+func TestState_NewSnapshotAt_Errors(t *testing.T) {
+	t.Parallel()
+
+	storage := NewMemoryStorage()
+
+	_, err := NewState(storage).NewSnapshotAt(types.StringToHash("0x1"))
+	require.ErrorIs(t, err, state.ErrStateNotFound)
+
+	errIO := &fs.PathError{Op: "read", Path: "000042.ldb", Err: syscall.EIO}
+
+	_, err = NewState(&faultyStorage{Storage: storage, err: errIO}).NewSnapshotAt(types.StringToHash("0x1"))
+	require.ErrorIs(t, err, state.ErrStorageUnavailable)
+	require.ErrorIs(t, err, syscall.EIO)
+	require.NotErrorIs(t, err, state.ErrStateNotFound)
+
+	_, err = NewState(&faultyStorage{Storage: storage, err: leveldb.ErrClosed}).NewSnapshotAt(types.StringToHash("0x1"))
+	require.ErrorIs(t, err, state.ErrStorageUnavailable)
+
+	// corrupted data is not retried as unavailable storage
+	errCorrupted := &lerrors.ErrCorrupted{Err: errors.New("checksum mismatch")}
+
+	_, err = NewState(&faultyStorage{Storage: storage, err: errCorrupted}).NewSnapshotAt(types.StringToHash("0x1"))
+	require.ErrorIs(t, err, errCorrupted)
+	require.NotErrorIs(t, err, state.ErrStorageUnavailable)
+	require.NotErrorIs(t, err, state.ErrStateNotFound)
+}
+
+func TestSnapshot_Root(t *testing.T) {
+	t.Parallel()
+
+	st := NewState(NewMemoryStorage())
+	code := []byte{0x60, 0x00}
+	codeHash := types.BytesToHash(crypto.Keccak256(code))
+
+	objs := []*state.Object{
+		{
+			Address:  types.StringToAddress("0x1"),
+			Balance:  big.NewInt(10),
+			Nonce:    1,
+			Root:     types.EmptyRootHash,
+			CodeHash: types.EmptyCodeHash,
+		},
+		{
+			Address:   types.StringToAddress("0x2"),
+			Balance:   big.NewInt(0),
+			Root:      types.EmptyRootHash,
+			CodeHash:  codeHash,
+			DirtyCode: true,
+			Code:      code,
+			Storage: []*state.StorageObject{
+				{Key: types.StringToHash("0x1").Bytes(), Val: types.StringToHash("0x2").Bytes()},
+			},
+		},
+	}
+
+	root, err := st.NewSnapshot().Root(objs)
+	require.NoError(t, err)
+
+	// neither the nodes nor the code are written
+	_, err = st.NewSnapshotAt(types.BytesToHash(root))
+	require.ErrorIs(t, err, state.ErrStateNotFound)
+
+	_, ok := st.GetCode(codeHash)
+	require.False(t, ok)
+
+	// the commit writes the same root
+	_, committed, err := st.NewSnapshot().Commit(objs)
+	require.NoError(t, err)
+	require.Equal(t, committed, root)
+
+	_, err = st.NewSnapshotAt(types.BytesToHash(root))
+	require.NoError(t, err)
+}
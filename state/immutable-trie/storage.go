@@ -1,13 +1,17 @@
 package itrie
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"sync"
+	"syscall"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/umbracle/fastrlp"
 	"github.com/xgr-network/xgr-node/helper/hex"
+	"github.com/xgr-network/xgr-node/state"
 	"github.com/xgr-network/xgr-node/types"
 )
 
@@ -40,9 +44,22 @@ type Storage interface {
 	Close() error
 }
 
+// PrunableStorage is a Storage whose trie nodes can be enumerated and deleted, as required by the Pruner
+type PrunableStorage interface {
+	Storage
+
+	// IterateNodeKeys calls fn with the key of every stored trie node until fn returns false
+	IterateNodeKeys(fn func(k []byte) bool) error
+	// Delete deletes the keys in a single batch
+	Delete(keys [][]byte) error
+}
+
 // KVStorage is a k/v storage on memory using leveldb
 type KVStorage struct {
 	db *leveldb.DB
+
+	// newBatch creates the batches, nil creates a batch written at once by Write
+	newBatch func() Batch
 }
 
 // KVBatch is a batch write for leveldb
@@ -73,6 +90,10 @@ func (kv *KVStorage) GetCode(hash types.Hash) ([]byte, bool) {
 }
 
 func (kv *KVStorage) Batch() Batch {
+	if kv.newBatch != nil {
+		return kv.newBatch()
+	}
+
 	return &KVBatch{db: kv.db, batch: &leveldb.Batch{}}
 }
 
@@ -93,6 +114,34 @@ func (kv *KVStorage) Get(k []byte) ([]byte, bool, error) {
 	return data, true, nil
 }
 
+func (kv *KVStorage) IterateNodeKeys(fn func(k []byte) bool) error {
+	// the iterator reads from a snapshot of the db, so keys may be deleted while iterating
+	iter := kv.db.NewIterator(nil, nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		if !isNodeKey(iter.Key()) {
+			continue
+		}
+
+		if !fn(append([]byte{}, iter.Key()...)) {
+			break
+		}
+	}
+
+	return iter.Error()
+}
+
+func (kv *KVStorage) Delete(keys [][]byte) error {
+	batch := &leveldb.Batch{}
+
+	for _, k := range keys {
+		batch.Delete(k)
+	}
+
+	return kv.db.Write(batch, nil)
+}
+
 func (kv *KVStorage) Close() error {
 	return kv.db.Close()
 }
@@ -103,7 +152,7 @@ func NewLevelDBStorage(path string, logger hclog.Logger) (Storage, error) {
 		return nil, err
 	}
 
-	return &KVStorage{db}, nil
+	return &KVStorage{db: db}, nil
 }
 
 type memStorage struct {
@@ -162,6 +211,46 @@ func (m *memStorage) Batch() Batch {
 	return &memBatch{db: &m.db, l: new(sync.Mutex)}
 }
 
+func (m *memStorage) IterateNodeKeys(fn func(k []byte) bool) error {
+	m.l.Lock()
+
+	keys := make([][]byte, 0, len(m.db))
+
+	for k := range m.db {
+		key, err := hex.DecodeHex(k)
+		if err != nil {
+			m.l.Unlock()
+
+			return err
+		}
+
+		if isNodeKey(key) {
+			keys = append(keys, key)
+		}
+	}
+
+	m.l.Unlock()
+
+	for _, k := range keys {
+		if !fn(k) {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (m *memStorage) Delete(keys [][]byte) error {
+	m.l.Lock()
+	defer m.l.Unlock()
+
+	for _, k := range keys {
+		delete(m.db, hex.EncodeToHex(k))
+	}
+
+	return nil
+}
+
 func (m *memStorage) Close() error {
 	return nil
 }
@@ -182,8 +271,12 @@ func (m *memBatch) Write() error {
 // GetNode retrieves a node from storage
 func GetNode(root []byte, storage Storage) (Node, bool, error) {
 	data, ok, err := storage.Get(root)
-	if err != nil || !ok || len(data) == 0 {
-		return nil, false, err
+	if err != nil {
+		return nil, false, storageReadError(err)
+	}
+
+	if !ok || len(data) == 0 {
+		return nil, false, nil
 	}
 
 	// NOTE. We dont need to make copies of the bytes because the nodes
@@ -205,6 +298,21 @@ func GetNode(root []byte, storage Storage) (Node, bool, error) {
 	return n, err == nil, err
 }
 
+// storageReadError wraps a failed read of the trie storage with ErrStorageUnavailable if it is
+// an I/O error, which may be temporary. Other errors, like corrupted data, are returned as they are
+func storageReadError(err error) error {
+	var (
+		pathErr *fs.PathError
+		errno   syscall.Errno
+	)
+
+	if errors.Is(err, leveldb.ErrClosed) || errors.As(err, &pathErr) || errors.As(err, &errno) {
+		return fmt.Errorf("%w: %w", state.ErrStorageUnavailable, err)
+	}
+
+	return err
+}
+
 func decodeNode(v *fastrlp.Value, s Storage) (Node, error) {
 	if v.Type() == fastrlp.TypeBytes {
 		vv := &ValueNode{
@@ -275,6 +383,11 @@ func decodeNode(v *fastrlp.Value, s Storage) (Node, error) {
 	return nil, fmt.Errorf("node has incorrect number of leafs")
 }
 
+// isNodeKey returns whether the key is the hash of a trie node, as opposed to a code key
+func isNodeKey(k []byte) bool {
+	return len(k) == types.HashLength
+}
+
 func GetCodeKey(hash types.Hash) []byte {
 	return append(codePrefix, hash.Bytes()...)
 }
@@ -0,0 +1,88 @@
+package state
+
+import (
+	"github.com/xgr-network/xgr-node/helper/hex"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+// RPCReceipt is a receipt in the shape returned by eth_getTransactionReceipt,
+// the quantities are hex encoded with the 0x prefix
+type RPCReceipt struct {
+	TxHash            types.Hash     `json:"transactionHash"`
+	TxIndex           string         `json:"transactionIndex"`
+	BlockHash         types.Hash     `json:"blockHash"`
+	BlockNumber       string         `json:"blockNumber"`
+	From              types.Address  `json:"from"`
+	To                *types.Address `json:"to"`
+	CumulativeGasUsed string         `json:"cumulativeGasUsed"`
+	GasUsed           string         `json:"gasUsed"`
+	EffectiveGasPrice string         `json:"effectiveGasPrice"`
+	ContractAddress   *types.Address `json:"contractAddress"`
+	Logs              []*RPCLog      `json:"logs"`
+	LogsBloom         types.Bloom    `json:"logsBloom"`
+	Type              string         `json:"type"`
+	Status            string         `json:"status"`
+}
+
+// RPCLog is a log of an RPCReceipt
+type RPCLog struct {
+	Address     types.Address `json:"address"`
+	Topics      []types.Hash  `json:"topics"`
+	Data        string        `json:"data"`
+	BlockNumber string        `json:"blockNumber"`
+	TxHash      types.Hash    `json:"transactionHash"`
+	TxIndex     string        `json:"transactionIndex"`
+	BlockHash   types.Hash    `json:"blockHash"`
+	LogIndex    string        `json:"logIndex"`
+	Removed     bool          `json:"removed"`
+}
+
+// ReceiptToRPC converts the receipt written by the transition into its JSON-RPC shape.
+// The log indexes count from the first log of the receipt, the block wide index
+// requires the receipts of the preceding transactions.
+func ReceiptToRPC(receipt *types.Receipt, txIndex uint64, blockHash types.Hash, blockNumber uint64) *RPCReceipt {
+	res := &RPCReceipt{
+		TxHash:            receipt.TxHash,
+		TxIndex:           hex.EncodeUint64(txIndex),
+		BlockHash:         blockHash,
+		BlockNumber:       hex.EncodeUint64(blockNumber),
+		From:              receipt.From,
+		To:                receipt.To,
+		CumulativeGasUsed: hex.EncodeUint64(receipt.CumulativeGasUsed),
+		GasUsed:           hex.EncodeUint64(receipt.GasUsed),
+		EffectiveGasPrice: "0x0",
+		ContractAddress:   receipt.ContractAddress,
+		Logs:              make([]*RPCLog, len(receipt.Logs)),
+		LogsBloom:         receipt.LogsBloom,
+		Type:              hex.EncodeUint64(uint64(receipt.TransactionType)),
+		Status:            "0x0",
+	}
+
+	if receipt.EffectiveGasPrice != nil {
+		res.EffectiveGasPrice = hex.EncodeBig(receipt.EffectiveGasPrice)
+	}
+
+	if receipt.Status != nil {
+		res.Status = hex.EncodeUint64(uint64(*receipt.Status))
+	}
+
+	for i, log := range receipt.Logs {
+		topics := log.Topics
+		if topics == nil {
+			topics = []types.Hash{}
+		}
+
+		res.Logs[i] = &RPCLog{
+			Address:     log.Address,
+			Topics:      topics,
+			Data:        hex.EncodeToHex(log.Data),
+			BlockNumber: hex.EncodeUint64(blockNumber),
+			TxHash:      receipt.TxHash,
+			TxIndex:     hex.EncodeUint64(txIndex),
+			BlockHash:   blockHash,
+			LogIndex:    hex.EncodeUint64(uint64(i)),
+		}
+	}
+
+	return res
+}
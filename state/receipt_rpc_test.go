@@ -0,0 +1,120 @@
+package state
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xgr-network/xgr-node/chain"
+	"github.com/xgr-network/xgr-node/crypto"
+	"github.com/xgr-network/xgr-node/state/runtime"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+func TestReceiptToRPC(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sender    = types.StringToAddress("0x500")
+		emitter   = types.StringToAddress("0x600")
+		blockHash = types.StringToHash("0xabcd")
+	)
+
+	txn := newTestTxn(map[types.Address]*PreState{
+		sender: {Balance: 1_000_000_000_000},
+	})
+
+	// PUSH1 0x01 (topic), PUSH1 0 (size), PUSH1 0 (offset), LOG1, STOP
+	txn.SetCode(emitter, []byte{0x60, 0x01, 0x60, 0x00, 0x60, 0x00, 0xa1, 0x00})
+
+	transition := NewTransition(nil, chain.ForksInTime{}, nil, txn)
+	transition.logger = hclog.NewNullLogger()
+	transition.ctx = runtime.TxContext{BaseFee: big.NewInt(0)}
+	transition.gasPool = 1_000_000
+
+	// the contract creation runs an empty init code
+	require.NoError(t, transition.Write(&types.Transaction{
+		From:     sender,
+		Nonce:    0,
+		Gas:      100_000,
+		GasPrice: big.NewInt(3),
+		Value:    big.NewInt(0),
+		Input:    []byte{0x00},
+		Hash:     types.StringToHash("0x1"),
+	}))
+
+	require.NoError(t, transition.Write(&types.Transaction{
+		From:     sender,
+		To:       &emitter,
+		Nonce:    1,
+		Gas:      100_000,
+		GasPrice: big.NewInt(5),
+		Value:    big.NewInt(0),
+		Hash:     types.StringToHash("0x2"),
+	}))
+
+	receipts := transition.Receipts()
+	require.Len(t, receipts, 2)
+
+	decode := func(r *RPCReceipt) map[string]interface{} {
+		t.Helper()
+
+		raw, err := json.Marshal(r)
+		require.NoError(t, err)
+
+		fields := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(raw, &fields))
+
+		return fields
+	}
+
+	t.Run("contract creation", func(t *testing.T) {
+		t.Parallel()
+
+		fields := decode(ReceiptToRPC(receipts[0], 0, blockHash, 7))
+
+		require.Equal(t, crypto.CreateAddress(sender, 0).String(), fields["contractAddress"])
+		require.Nil(t, fields["to"])
+		require.Equal(t, sender.String(), fields["from"])
+		require.Equal(t, types.StringToHash("0x1").String(), fields["transactionHash"])
+		require.Equal(t, "0x0", fields["transactionIndex"])
+		require.Equal(t, blockHash.String(), fields["blockHash"])
+		require.Equal(t, "0x7", fields["blockNumber"])
+		require.Equal(t, "0x3", fields["effectiveGasPrice"])
+		require.Equal(t, "0x0", fields["type"])
+		require.Equal(t, "0x1", fields["status"])
+		require.Equal(t, fields["gasUsed"], fields["cumulativeGasUsed"])
+		require.Equal(t, receipts[0].LogsBloom.String(), fields["logsBloom"])
+		require.Equal(t, []interface{}{}, fields["logs"])
+	})
+
+	t.Run("call", func(t *testing.T) {
+		t.Parallel()
+
+		fields := decode(ReceiptToRPC(receipts[1], 1, blockHash, 7))
+
+		require.Nil(t, fields["contractAddress"])
+		require.Equal(t, emitter.String(), fields["to"])
+		require.Equal(t, "0x1", fields["transactionIndex"])
+		require.Equal(t, "0x5", fields["effectiveGasPrice"])
+		require.Equal(t, "0x1", fields["status"])
+		require.Equal(t, receipts[1].LogsBloom.String(), fields["logsBloom"])
+
+		logs, ok := fields["logs"].([]interface{})
+		require.True(t, ok)
+		require.Len(t, logs, 1)
+
+		log, ok := logs[0].(map[string]interface{})
+		require.True(t, ok)
+		require.Equal(t, emitter.String(), log["address"])
+		require.Equal(t, []interface{}{types.StringToHash("0x1").String()}, log["topics"])
+		require.Equal(t, "0x", log["data"])
+		require.Equal(t, "0x7", log["blockNumber"])
+		require.Equal(t, "0x1", log["transactionIndex"])
+		require.Equal(t, "0x0", log["logIndex"])
+		require.Equal(t, false, log["removed"])
+	})
+}
@@ -47,6 +47,26 @@ func NewAccessList(init ...types.Address) *AccessList {
 	return al
 }
 
+// NewAccessListWithoutEngine is NewAccessList for a chain without the ENGINE_EXECUTE precompile,
+// its address is only warm if it is in init
+func NewAccessListWithoutEngine(init ...types.Address) *AccessList {
+	al := &AccessList{
+		addresses: make(map[types.Address]struct{}, len(init)+len(precompiledContracts)),
+		slots:     make(map[types.Address]map[types.Hash]struct{}),
+	}
+
+	for _, a := range precompiledContracts {
+		if a != contracts.EngineExecutePrecompile {
+			al.addresses[a] = struct{}{}
+		}
+	}
+	for _, a := range init {
+		al.addresses[a] = struct{}{}
+	}
+
+	return al
+}
+
 func (al *AccessList) Copy() *AccessList {
 	cp := &AccessList{
 		addresses: make(map[types.Address]struct{}, len(al.addresses)),
@@ -3,9 +3,11 @@ package addresslist
 import (
 	"bytes"
 	"fmt"
+	"math/big"
 
 	"github.com/umbracle/ethgo/abi"
 	"github.com/xgr-network/xgr-node/chain"
+	"github.com/xgr-network/xgr-node/helper/keccak"
 	"github.com/xgr-network/xgr-node/state/runtime"
 	"github.com/xgr-network/xgr-node/types"
 )
@@ -18,25 +20,98 @@ var (
 	ReadAddressListFunc = abi.MustNewMethod("function readAddressList(address) returns (uint256)")
 )
 
+// DeploymentRejectedEvent is emitted from the list contract address when the list rejects a contract deployment
+var DeploymentRejectedEvent = abi.MustNewEvent(
+	"event DeploymentRejected(address indexed caller, address indexed contractAddress, uint8 listType)")
+
+// ListType identifies the kind of list that rejected an operation
+type ListType uint8
+
+const (
+	AllowListType ListType = iota + 1
+	BlockListType
+)
+
+func (l ListType) String() string {
+	switch l {
+	case AllowListType:
+		return "allowlist"
+	case BlockListType:
+		return "blocklist"
+	default:
+		return fmt.Sprintf("ListType(%d)", uint8(l))
+	}
+}
+
 // list of gas costs for the operations
 var (
+	// writeAddressListCost is charged for the role slot of a role change,
+	// it matches the cost of an SSTORE setting a fresh slot
 	writeAddressListCost = uint64(20000)
 	readAddressListCost  = uint64(5000)
+
+	// index slots of a role change are charged like the SSTORE they amount to
+	indexSlotSetCost   = uint64(20000)
+	indexSlotResetCost = uint64(5000)
 )
 
 type AddressList struct {
 	state stateRef
 	addr  types.Address
+
+	// indexed keeps the addresses with a role enumerable (AddressListIndex fork)
+	indexed bool
 }
 
-func NewAddressList(state stateRef, addr types.Address) *AddressList {
-	return &AddressList{state: state, addr: addr}
+func NewAddressList(state stateRef, addr types.Address, indexed bool) *AddressList {
+	return &AddressList{state: state, addr: addr, indexed: indexed}
 }
 
 func (a *AddressList) Addr() types.Address {
 	return a.addr
 }
 
+// Check returns the list that rejects addr together with its type, or nil if addr is permitted.
+// Lists that are not configured are nil. The allow list takes precedence over the block list:
+// if both are configured, only the allow list is consulted.
+func Check(allowList, blockList *AddressList, addr types.Address) (*AddressList, ListType) {
+	if allowList != nil {
+		if !allowList.GetRole(addr).Enabled() {
+			return allowList, AllowListType
+		}
+
+		return nil, 0
+	}
+
+	if blockList != nil && blockList.GetRole(addr) == EnabledRole {
+		return blockList, BlockListType
+	}
+
+	return nil, 0
+}
+
+// Enforced returns which of the configured allow and block lists are enforced,
+// following the precedence of Check
+func Enforced(allowList, blockList bool) (bool, bool) {
+	return allowList, blockList && !allowList
+}
+
+// DeploymentRejectedLog returns the topics and data of the DeploymentRejected event
+func DeploymentRejectedLog(caller, contractAddr types.Address, listType ListType) ([]types.Hash, []byte) {
+	id := DeploymentRejectedEvent.ID()
+
+	topics := []types.Hash{
+		types.BytesToHash(id[:]),
+		types.BytesToHash(caller.Bytes()),
+		types.BytesToHash(contractAddr.Bytes()),
+	}
+
+	data := types.Hash{}
+	data[len(data)-1] = byte(listType)
+
+	return topics, data.Bytes()
+}
+
 func (a *AddressList) Run(c *runtime.Contract, host runtime.Host, _ *chain.ForksInTime) *runtime.ExecutionResult {
 	ret, gasUsed, err := a.runInputCall(c.Caller, c.Input, c.Gas, c.Static)
 
@@ -111,7 +186,7 @@ func (a *AddressList) runInputCall(caller types.Address, input []byte,
 		return nil, 0, errFunctionNotFound
 	}
 
-	if err := consumeGas(writeAddressListCost); err != nil {
+	if err := consumeGas(a.roleUpdateGas(inputAddr, updateRole)); err != nil {
 		return nil, gasUsed, err
 	}
 
@@ -138,6 +213,10 @@ func (a *AddressList) runInputCall(caller types.Address, input []byte,
 
 func (a *AddressList) SetRole(addr types.Address, role Role) {
 	a.state.SetState(a.addr, types.BytesToHash(addr.Bytes()), types.Hash(role))
+
+	if a.indexed {
+		a.updateIndex(addr, role)
+	}
 }
 
 func (a *AddressList) GetRole(addr types.Address) Role {
@@ -146,6 +225,131 @@ func (a *AddressList) GetRole(addr types.Address) Role {
 	return Role(res)
 }
 
+// The index is laid out like a solidity `address[] entries` with a
+// `mapping(address => uint256) positions` holding the 1-based entry position.
+// Both live at hashed slots, so they can't collide with the role slots.
+var (
+	indexLengthSlot    = types.BytesToHash(keccak256([]byte("addresslist.index.entries")))
+	indexPositionsSlot = types.BytesToHash(keccak256([]byte("addresslist.index.positions")))
+	indexEntriesBase   = new(big.Int).SetBytes(keccak256(indexLengthSlot.Bytes()))
+)
+
+// List returns the addresses that currently have a role in the list.
+// Only roles set after the AddressListIndex fork are enumerable.
+func (a *AddressList) List() []types.Address {
+	length := a.indexLength()
+	res := make([]types.Address, 0, length)
+
+	for i := uint64(0); i < length; i++ {
+		res = append(res, types.BytesToAddress(a.state.GetStorage(a.addr, indexEntrySlot(i)).Bytes()))
+	}
+
+	return res
+}
+
+// roleUpdateGas returns the gas of setting the role of addr: the role slot is charged
+// as writeAddressListCost and, on indexed lists, every index slot as the SSTORE it amounts to
+func (a *AddressList) roleUpdateGas(addr types.Address, role Role) uint64 {
+	gas := writeAddressListCost
+
+	if !a.indexed {
+		return gas
+	}
+
+	for _, w := range a.indexWrites(addr, role) {
+		if w.value != types.ZeroHash && a.state.GetStorage(a.addr, w.slot) == types.ZeroHash {
+			gas += indexSlotSetCost
+		} else {
+			gas += indexSlotResetCost
+		}
+	}
+
+	return gas
+}
+
+// updateIndex adds the address to the index or swaps it out once it has no role anymore
+func (a *AddressList) updateIndex(addr types.Address, role Role) {
+	for _, w := range a.indexWrites(addr, role) {
+		a.state.SetState(a.addr, w.slot, w.value)
+	}
+}
+
+type slotWrite struct {
+	slot  types.Hash
+	value types.Hash
+}
+
+// indexWrites returns the index slots to write when the role of addr changes to role
+func (a *AddressList) indexWrites(addr types.Address, role Role) []slotWrite {
+	positionSlot := indexPositionSlot(addr)
+	position := new(big.Int).SetBytes(a.state.GetStorage(a.addr, positionSlot).Bytes()).Uint64()
+	length := a.indexLength()
+
+	if role != NoRole {
+		if position != 0 {
+			return nil
+		}
+
+		return []slotWrite{
+			{indexEntrySlot(length), types.BytesToHash(addr.Bytes())},
+			{positionSlot, uint64ToHash(length + 1)},
+			{indexLengthSlot, uint64ToHash(length + 1)},
+		}
+	}
+
+	if position == 0 {
+		return nil
+	}
+
+	writes := make([]slotWrite, 0, 5)
+
+	// move the last entry into the freed position
+	if last := length - 1; position-1 != last {
+		lastAddr := types.BytesToAddress(a.state.GetStorage(a.addr, indexEntrySlot(last)).Bytes())
+
+		writes = append(writes,
+			slotWrite{indexEntrySlot(position - 1), types.BytesToHash(lastAddr.Bytes())},
+			slotWrite{indexPositionSlot(lastAddr), uint64ToHash(position)},
+		)
+	}
+
+	return append(writes,
+		slotWrite{indexEntrySlot(length - 1), types.ZeroHash},
+		slotWrite{positionSlot, types.ZeroHash},
+		slotWrite{indexLengthSlot, uint64ToHash(length - 1)},
+	)
+}
+
+func (a *AddressList) indexLength() uint64 {
+	return new(big.Int).SetBytes(a.state.GetStorage(a.addr, indexLengthSlot).Bytes()).Uint64()
+}
+
+// indexEntrySlot returns keccak256(indexLengthSlot) + i
+func indexEntrySlot(i uint64) types.Hash {
+	slot := new(big.Int).Add(indexEntriesBase, new(big.Int).SetUint64(i))
+
+	return types.BytesToHash(slot.Bytes())
+}
+
+// indexPositionSlot returns keccak256(pad32(addr) || indexPositionsSlot)
+func indexPositionSlot(addr types.Address) types.Hash {
+	return types.BytesToHash(keccak256(types.BytesToHash(addr.Bytes()).Bytes(), indexPositionsSlot.Bytes()))
+}
+
+func uint64ToHash(v uint64) types.Hash {
+	return types.BytesToHash(new(big.Int).SetUint64(v).Bytes())
+}
+
+func keccak256(data ...[]byte) []byte {
+	k := keccak.NewKeccak256()
+
+	for _, d := range data {
+		_, _ = k.Write(d)
+	}
+
+	return k.Sum(nil)
+}
+
 type Role types.Hash
 
 var (
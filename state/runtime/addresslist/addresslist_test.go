@@ -26,7 +26,7 @@ func newMockAddressList() *AddressList {
 		state: map[types.Hash]types.Hash{},
 	}
 
-	return NewAddressList(state, types.Address{})
+	return NewAddressList(state, types.Address{}, false)
 }
 
 func TestAddressList_WrongInput(t *testing.T) {
@@ -153,6 +153,47 @@ func TestAddressList_WriteOp_Full(t *testing.T) {
 	}
 }
 
+func TestAddressList_WriteOp_IndexedGas(t *testing.T) {
+	state := &mockState{
+		state: map[types.Hash]types.Hash{},
+	}
+
+	a := NewAddressList(state, types.Address{}, true)
+	a.SetRole(types.Address{}, AdminRole)
+
+	targetAddr := types.Address{0x1}
+
+	cases := []struct {
+		name   string
+		method *abi.Method
+		gas    uint64
+	}{
+		// role slot, fresh entry and position slots, reset of the length slot
+		{"add to index", SetEnabledFunc, writeAddressListCost + 2*indexSlotSetCost + indexSlotResetCost},
+		// the address is indexed already, only the role slot is written
+		{"update role", SetAdminFunc, writeAddressListCost},
+		// entry, position and length slots are reset
+		{"remove from index", SetNoneFunc, writeAddressListCost + 3*indexSlotResetCost},
+		{"remove without role", SetNoneFunc, writeAddressListCost},
+	}
+
+	for _, c := range cases {
+		input, _ := c.method.Encode([]interface{}{targetAddr})
+
+		// a role change is never cheaper than an SSTORE of a fresh slot
+		require.GreaterOrEqual(t, c.gas, writeAddressListCost, c.name)
+
+		_, _, err := a.runInputCall(types.Address{}, input, c.gas-1, false)
+		require.ErrorIs(t, err, runtime.ErrOutOfGas, c.name)
+
+		_, gasCost, err := a.runInputCall(types.Address{}, input, c.gas, false)
+		require.NoError(t, err, c.name)
+		require.Equal(t, c.gas, gasCost, c.name)
+	}
+
+	require.Equal(t, []types.Address{{}}, a.List())
+}
+
 func TestRole_ToUint(t *testing.T) {
 	cases := []struct {
 		role Role
@@ -182,3 +223,124 @@ func TestRole_Enabled(t *testing.T) {
 		require.Equal(t, c.enabled, c.role.Enabled())
 	}
 }
+
+func TestCheck_Precedence(t *testing.T) {
+	var (
+		listed   = types.StringToAddress("0x1")
+		unlisted = types.StringToAddress("0x2")
+	)
+
+	allowList := newMockAddressList()
+	allowList.SetRole(listed, EnabledRole)
+
+	blockList := newMockAddressList()
+	blockList.SetRole(listed, EnabledRole)
+
+	cases := []struct {
+		name      string
+		allowList *AddressList
+		blockList *AddressList
+		addr      types.Address
+		rejected  *AddressList
+		listType  ListType
+	}{
+		{"no lists", nil, nil, listed, nil, 0},
+		{"allowlist, listed", allowList, nil, listed, nil, 0},
+		{"allowlist, unlisted", allowList, nil, unlisted, allowList, AllowListType},
+		{"blocklist, listed", nil, blockList, listed, blockList, BlockListType},
+		{"blocklist, unlisted", nil, blockList, unlisted, nil, 0},
+		// the allow list takes precedence, the block list is not consulted
+		{"both lists, listed", allowList, blockList, listed, nil, 0},
+		{"both lists, unlisted", allowList, blockList, unlisted, allowList, AllowListType},
+	}
+
+	for _, c := range cases {
+		list, listType := Check(c.allowList, c.blockList, c.addr)
+		require.Same(t, c.rejected, list, c.name)
+		require.Equal(t, c.listType, listType, c.name)
+	}
+}
+
+func TestEnforced(t *testing.T) {
+	cases := []struct {
+		allowList, blockList         bool
+		allowEnforced, blockEnforced bool
+	}{
+		{false, false, false, false},
+		{true, false, true, false},
+		{false, true, false, true},
+		{true, true, true, false},
+	}
+
+	for _, c := range cases {
+		allowEnforced, blockEnforced := Enforced(c.allowList, c.blockList)
+		require.Equal(t, c.allowEnforced, allowEnforced)
+		require.Equal(t, c.blockEnforced, blockEnforced)
+	}
+}
+
+func TestAddressList_List(t *testing.T) {
+	state := &mockState{
+		state: map[types.Hash]types.Hash{},
+	}
+
+	a := NewAddressList(state, types.Address{}, true)
+
+	one, two, three := types.Address{0x1}, types.Address{0x2}, types.Address{0x3}
+
+	a.SetRole(one, AdminRole)
+	a.SetRole(two, EnabledRole)
+	a.SetRole(three, EnabledRole)
+
+	// updating a role doesn't add the address twice
+	a.SetRole(one, EnabledRole)
+	require.Equal(t, []types.Address{one, two, three}, a.List())
+
+	// the last entry takes the place of a removed one
+	a.SetRole(one, NoRole)
+	require.Equal(t, []types.Address{three, two}, a.List())
+
+	// removing an address without a role is a no-op
+	a.SetRole(one, NoRole)
+	require.Equal(t, []types.Address{three, two}, a.List())
+
+	// the list can be read from the raw state by another instance
+	require.Equal(t, []types.Address{three, two}, NewAddressList(state, types.Address{}, false).List())
+
+	a.SetRole(two, NoRole)
+	a.SetRole(three, NoRole)
+	require.Empty(t, a.List())
+
+	// only the role slots of the removed addresses are left (all zero)
+	for key, value := range state.state {
+		require.Equal(t, types.ZeroHash, value, "slot %s", key)
+	}
+}
+
+func TestAddressList_List_SyntheticState(t *testing.T) {
+	entries := []types.Address{{0xa}, {0xb}, {0xc}}
+
+	state := &mockState{
+		state: map[types.Hash]types.Hash{
+			indexLengthSlot: uint64ToHash(uint64(len(entries))),
+		},
+	}
+
+	for i, addr := range entries {
+		state.state[indexEntrySlot(uint64(i))] = types.BytesToHash(addr.Bytes())
+		state.state[indexPositionSlot(addr)] = uint64ToHash(uint64(i + 1))
+		state.state[types.BytesToHash(addr.Bytes())] = types.Hash(EnabledRole)
+	}
+
+	a := NewAddressList(state, types.Address{}, true)
+	require.Equal(t, entries, a.List())
+
+	// the index of the synthetic state is kept consistent
+	a.SetRole(entries[0], NoRole)
+	require.Equal(t, []types.Address{entries[2], entries[1]}, a.List())
+	require.Equal(t, uint64ToHash(1), state.state[indexPositionSlot(entries[2])])
+
+	// an unindexed list doesn't touch the index
+	NewAddressList(state, types.Address{}, false).SetRole(entries[0], AdminRole)
+	require.Equal(t, []types.Address{entries[2], entries[1]}, a.List())
+}
@@ -7,10 +7,16 @@ import (
 	"github.com/xgr-network/xgr-node/types"
 )
 
-func ApplyGenesisAllocs(chain *chain.Genesis, addressListAddr types.Address, config *chain.AddressListConfig) {
+func ApplyGenesisAllocs(
+	chain *chain.Genesis,
+	addressListAddr types.Address,
+	config *chain.AddressListConfig,
+	indexed bool,
+) {
 	allocList := &AddressList{
-		addr:  addressListAddr,
-		state: &genesisState{chain},
+		addr:    addressListAddr,
+		state:   &genesisState{chain},
+		indexed: indexed,
 	}
 
 	// enabled addr
@@ -47,7 +53,11 @@ func (g *genesisState) SetState(addr types.Address, key, value types.Hash) {
 }
 
 func (g *genesisState) GetStorage(addr types.Address, key types.Hash) types.Hash {
-	// since `genesisState` is used only as part of `ApplyGenesisAllocs` to set the initial
-	// roles in the contract. It never calls this `GetStorage` function.
-	return types.Hash{}
+	// the roles are never read, but the list index is
+	alloc, ok := g.chain.Alloc[addr]
+	if !ok {
+		return types.Hash{}
+	}
+
+	return alloc.Storage[key]
 }
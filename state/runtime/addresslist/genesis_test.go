@@ -30,7 +30,7 @@ func TestGenesis(t *testing.T) {
 		},
 	}
 
-	ApplyGenesisAllocs(gen, types.Address{}, config)
+	ApplyGenesisAllocs(gen, types.Address{}, config, false)
 
 	expect := &chain.GenesisAccount{
 		Balance: big.NewInt(1),
@@ -43,3 +43,25 @@ func TestGenesis(t *testing.T) {
 
 	require.Equal(t, expect, gen.Alloc[types.Address{}])
 }
+
+func TestGenesis_Indexed(t *testing.T) {
+	one := types.Address{0x1}
+	two := types.Address{0x2}
+
+	gen := &chain.Genesis{
+		Alloc: map[types.Address]*chain.GenesisAccount{},
+	}
+
+	config := &chain.AddressListConfig{
+		AdminAddresses:   []types.Address{one},
+		EnabledAddresses: []types.Address{two, one},
+	}
+
+	ApplyGenesisAllocs(gen, types.Address{}, config, true)
+
+	state := &mockState{state: gen.Alloc[types.Address{}].Storage}
+	list := NewAddressList(state, types.Address{}, true)
+
+	require.Equal(t, []types.Address{two, one}, list.List())
+	require.Equal(t, AdminRole, list.GetRole(one))
+}
@@ -1,6 +1,11 @@
 package evm
 
-import "fmt"
+import (
+	"fmt"
+	"sync"
+
+	"github.com/xgr-network/xgr-node/chain"
+)
 
 type handler struct {
 	inst  instruction
@@ -8,14 +13,87 @@ type handler struct {
 	gas   uint64
 }
 
-var dispatchTable [256]handler
+// instructionSet is the dispatch table of the interpreter, indexed by opcode
+type instructionSet [256]handler
+
+// baseInstructions holds every instruction regardless of the forks
+var baseInstructions instructionSet
+
+// instructionSets caches the instruction set of every fork combination seen so far.
+// The sets are never modified once stored, so they are shared by concurrent executions
+var instructionSets sync.Map // chain.ForksInTime -> *instructionSet
+
+// instructionSetFor returns the instruction set of the fork combination,
+// it is built on the first use and cached for the following executions
+func instructionSetFor(config *chain.ForksInTime) *instructionSet {
+	if config == nil {
+		return &baseInstructions
+	}
+
+	if set, ok := instructionSets.Load(*config); ok {
+		return set.(*instructionSet) //nolint:forcetypeassert
+	}
+
+	set, _ := instructionSets.LoadOrStore(*config, newInstructionSet(config))
+
+	return set.(*instructionSet) //nolint:forcetypeassert
+}
+
+// forkInstructions lists the instructions introduced by the forks,
+// they are removed from the instruction sets of the fork combinations without the fork
+var forkInstructions = []struct {
+	enabled func(config *chain.ForksInTime) bool
+	ops     []OpCode
+}{
+	{
+		enabled: func(config *chain.ForksInTime) bool { return config.Homestead },
+		ops:     []OpCode{DELEGATECALL},
+	},
+	{
+		enabled: func(config *chain.ForksInTime) bool { return config.Byzantium },
+		ops:     []OpCode{RETURNDATASIZE, RETURNDATACOPY, STATICCALL, REVERT},
+	},
+	{
+		enabled: func(config *chain.ForksInTime) bool { return config.Constantinople },
+		ops:     []OpCode{SHL, SHR, SAR, EXTCODEHASH, CREATE2},
+	},
+	{
+		enabled: func(config *chain.ForksInTime) bool { return config.Istanbul },
+		ops:     []OpCode{CHAINID, SELFBALANCE},
+	},
+	{
+		enabled: func(config *chain.ForksInTime) bool { return config.London },
+		ops:     []OpCode{BASEFEE},
+	},
+	{
+		enabled: func(config *chain.ForksInTime) bool { return config.EIP1153 },
+		ops:     []OpCode{TLOAD, TSTORE},
+	},
+}
+
+// newInstructionSet builds the instruction set of the fork combination
+func newInstructionSet(config *chain.ForksInTime) *instructionSet {
+	set := baseInstructions
+
+	for _, fork := range forkInstructions {
+		if fork.enabled(config) {
+			continue
+		}
+
+		for _, op := range fork.ops {
+			set[op] = handler{}
+		}
+	}
+
+	return &set
+}
 
 func register(op OpCode, h handler) {
-	if dispatchTable[op].inst != nil {
+	if baseInstructions[op].inst != nil {
 		panic(fmt.Errorf("instruction already exists")) //nolint:gocritic
 	}
 
-	dispatchTable[op] = h
+	baseInstructions[op] = h
 }
 
 func registerRange(from, to OpCode, factory func(n int) instruction, gas uint64) {
@@ -91,6 +169,8 @@ func init() {
 	// storage
 	register(SLOAD, handler{opSload, 1, 0})
 	register(SSTORE, handler{opSStore, 2, 0})
+	register(TLOAD, handler{opTload, 1, 100})
+	register(TSTORE, handler{opTstore, 2, 100})
 	register(SHA3, handler{opSha3, 2, 30})
 	register(POP, handler{opPop, 1, 2})
 
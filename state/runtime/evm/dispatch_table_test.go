@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/xgr-network/xgr-node/chain"
 )
 
 func TestPushOpcodes(t *testing.T) {
@@ -20,7 +21,7 @@ func TestPushOpcodes(t *testing.T) {
 			code: code,
 		}
 
-		inst := dispatchTable[i]
+		inst := baseInstructions[i]
 		inst.inst(s)
 
 		assert.False(t, s.stop)
@@ -32,3 +33,68 @@ func TestPushOpcodes(t *testing.T) {
 		c++
 	}
 }
+
+func TestInstructionSetFor(t *testing.T) {
+	all := chain.AllForksEnabled.At(0)
+	allCopy := all
+	noLondon := chain.AllForksEnabled.Copy().RemoveFork(chain.London).At(0)
+
+	// the set is built once per fork combination
+	assert.Same(t, instructionSetFor(&all), instructionSetFor(&allCopy))
+	assert.NotSame(t, instructionSetFor(&all), instructionSetFor(&noLondon))
+	assert.Same(t, &baseInstructions, instructionSetFor(nil))
+
+	for op := range baseInstructions {
+		assert.Equal(t, baseInstructions[op].inst == nil, instructionSetFor(&all)[op].inst == nil, "opcode %d", op)
+	}
+}
+
+func TestInstructionSetFor_EIP1153(t *testing.T) {
+	noEIP1153 := chain.AllForksEnabled.Copy().RemoveFork(chain.EIP1153).At(0)
+
+	set := instructionSetFor(&noEIP1153)
+	assert.Nil(t, set[TLOAD].inst)
+	assert.Nil(t, set[TSTORE].inst)
+
+	all := chain.AllForksEnabled.At(0)
+
+	set = instructionSetFor(&all)
+	assert.NotNil(t, set[TLOAD].inst)
+	assert.NotNil(t, set[TSTORE].inst)
+}
+
+func TestInstructionSetFor_ForkGated(t *testing.T) {
+	cases := []struct {
+		fork string
+		ops  []OpCode
+	}{
+		{chain.Homestead, []OpCode{DELEGATECALL}},
+		{chain.Byzantium, []OpCode{RETURNDATASIZE, RETURNDATACOPY, STATICCALL, REVERT}},
+		{chain.Constantinople, []OpCode{SHL, SHR, SAR, EXTCODEHASH, CREATE2}},
+		{chain.Istanbul, []OpCode{CHAINID, SELFBALANCE}},
+		{chain.London, []OpCode{BASEFEE}},
+	}
+
+	all := chain.AllForksEnabled.At(0)
+
+	for _, c := range cases {
+		config := chain.AllForksEnabled.Copy().RemoveFork(c.fork).At(0)
+		set := instructionSetFor(&config)
+
+		for _, op := range c.ops {
+			assert.Nil(t, set[op].inst, "%s without %s", op, c.fork)
+			assert.NotNil(t, instructionSetFor(&all)[op].inst, "%s with %s", op, c.fork)
+		}
+
+		// only the instructions of the fork are removed
+		removed := 0
+
+		for op := range set {
+			if set[op].inst == nil && baseInstructions[op].inst != nil {
+				removed++
+			}
+		}
+
+		assert.Equal(t, len(c.ops), removed, c.fork)
+	}
+}
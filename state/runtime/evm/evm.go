@@ -9,7 +9,8 @@ import (
 
 var _ runtime.Runtime = &EVM{}
 
-// EVM is the ethereum virtual machine
+// EVM is the ethereum virtual machine. It holds no execution state,
+// so a single instance is safe to share between concurrent executions
 type EVM struct {
 }
 
@@ -39,6 +40,7 @@ func (e *EVM) Run(c *runtime.Contract, host runtime.Host, config *chain.ForksInT
 	contract.gas = c.Gas
 	contract.host = host
 	contract.config = config
+	contract.instructions = instructionSetFor(config)
 
 	contract.bitmap.setCode(c.Code)
 
@@ -16,10 +16,11 @@ var _ runtime.Host = &mockHostF{}
 // mockHostF is a struct which meets the requirements of runtime.Host interface but returns naive data
 type mockHostF struct {
 	// to use
-	tracer   runtime.VMTracer
-	storage  map[types.Address]map[types.Hash]types.Hash
-	balances map[types.Address]*big.Int
-	nonces   map[types.Address]uint64
+	tracer    runtime.VMTracer
+	storage   map[types.Address]map[types.Hash]types.Hash
+	transient map[types.Address]map[types.Hash]types.Hash
+	balances  map[types.Address]*big.Int
+	nonces    map[types.Address]uint64
 
 	// to fuzz
 	refund    uint64
@@ -57,6 +58,18 @@ func (m *mockHostF) SetStorage(
 	return runtime.StorageModified
 }
 
+func (m *mockHostF) GetTransientStorage(addr types.Address, key types.Hash) types.Hash {
+	return m.transient[addr][key]
+}
+
+func (m *mockHostF) SetTransientStorage(addr types.Address, key types.Hash, value types.Hash) {
+	if _, ok := m.transient[addr]; !ok {
+		m.transient[addr] = make(map[types.Hash]types.Hash)
+	}
+
+	m.transient[addr][key] = value
+}
+
 func (m *mockHostF) SetState(addr types.Address, key types.Hash, value types.Hash) {
 	return
 }
@@ -181,9 +194,10 @@ func FuzzTestEVM(f *testing.F) {
 		blockHash := types.BytesToHash(blockHashI)
 		host := &mockHostF{
 			refund: refund, blockHash: blockHash,
-			storage:  make(map[types.Address]map[types.Hash]types.Hash),
-			balances: make(map[types.Address]*big.Int),
-			nonces:   make(map[types.Address]uint64),
+			storage:   make(map[types.Address]map[types.Hash]types.Hash),
+			transient: make(map[types.Address]map[types.Hash]types.Hash),
+			balances:  make(map[types.Address]*big.Int),
+			nonces:    make(map[types.Address]uint64),
 		}
 
 		code, err := tp.GetBytes()
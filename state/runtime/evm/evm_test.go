@@ -1,10 +1,14 @@
 package evm
 
 import (
+	"errors"
 	"math/big"
+	"math/rand"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/xgr-network/xgr-node/chain"
 	"github.com/xgr-network/xgr-node/state/runtime"
 	"github.com/xgr-network/xgr-node/state/runtime/tracer"
@@ -114,6 +118,14 @@ func (m *mockHost) GetRefund() uint64 {
 	panic("Not implemented in tests") //nolint:gocritic
 }
 
+func (m *mockHost) GetTransientStorage(addr types.Address, key types.Hash) types.Hash {
+	panic("Not implemented in tests") //nolint:gocritic
+}
+
+func (m *mockHost) SetTransientStorage(addr types.Address, key types.Hash, value types.Hash) {
+	panic("Not implemented in tests") //nolint:gocritic
+}
+
 func TestRun(t *testing.T) {
 	t.Parallel()
 
@@ -150,6 +162,20 @@ func TestRun(t *testing.T) {
 				GasUsed:     24,
 			},
 		},
+		{
+			name:  "should charge static, copy and memory expansion gas for MCOPY",
+			value: big.NewInt(0),
+			gas:   5000,
+			code: []byte{
+				PUSH1, 0x20, PUSH1, 0x00, PUSH1, 0x20, MCOPY,
+			},
+			expected: &runtime.ExecutionResult{
+				ReturnValue: nil,
+				// 3 push1 ops (9), MCOPY (3), 1 copied word (3), 2 words of memory (6)
+				GasLeft: 4979,
+				GasUsed: 21,
+			},
+		},
 		{
 			name:  "should fail and consume all gas by error",
 			value: big.NewInt(0),
@@ -386,3 +412,206 @@ func TestRunWithTracer(t *testing.T) {
 		})
 	}
 }
+
+// runFresh runs the contract like EVM.Run, but on a newly allocated state instead of a pooled one
+func runFresh(c *runtime.Contract, host runtime.Host, config *chain.ForksInTime) *runtime.ExecutionResult {
+	s := new(state)
+	s.msg = c
+	s.code = c.Code
+	s.gas = c.Gas
+	s.host = host
+	s.config = config
+	s.instructions = &baseInstructions
+	s.bitmap.setCode(c.Code)
+
+	ret, err := s.Run()
+
+	var returnValue []byte
+	returnValue = append(returnValue[:0], ret...)
+
+	gasLeft := s.gas
+	if err != nil && !errors.Is(err, errRevert) {
+		gasLeft = 0
+	}
+
+	return &runtime.ExecutionResult{
+		ReturnValue: returnValue,
+		GasLeft:     gasLeft,
+		GasUsed:     c.Gas - gasLeft,
+		Err:         err,
+	}
+}
+
+// randomProgram returns bytecode of random arithmetic, stack, memory and hashing operations
+// which returns or reverts with the top of the stack
+func randomProgram(r *rand.Rand) []byte {
+	ops := []struct {
+		op   byte
+		pops int
+	}{
+		{ADD, 2}, {MUL, 2}, {SUB, 2}, {DIV, 2}, {SDIV, 2}, {MOD, 2}, {SMOD, 2},
+		{ADDMOD, 3}, {MULMOD, 3}, {EXP, 2}, {SIGNEXTEND, 2},
+		{LT, 2}, {GT, 2}, {SLT, 2}, {SGT, 2}, {EQ, 2}, {ISZERO, 1},
+		{AND, 2}, {OR, 2}, {XOR, 2}, {NOT, 1}, {BYTE, 2}, {SHL, 2}, {SHR, 2}, {SAR, 2},
+		{POP, 1}, {DUP1, 1}, {DUP1 + 3, 4}, {SWAP1, 2}, {SWAP1 + 2, 4},
+	}
+
+	var (
+		code  []byte
+		depth int
+	)
+
+	push := func() {
+		if r.Intn(2) == 0 {
+			code = append(code, PUSH1, byte(r.Intn(256)))
+		} else {
+			word := make([]byte, 32)
+			r.Read(word)
+			code = append(code, PUSH32)
+			code = append(code, word...)
+		}
+
+		depth++
+	}
+
+	// some programs fail with a stack underflow
+	if r.Intn(20) == 0 {
+		code = append(code, ADD)
+	}
+
+	for i := r.Intn(100); i > 0; i-- {
+		switch r.Intn(10) {
+		case 0:
+			// store the top of the stack in memory
+			if depth == 0 {
+				push()
+			}
+
+			code = append(code, PUSH1, byte(r.Intn(64)), MSTORE)
+			depth--
+		case 1:
+			code = append(code, PUSH1, byte(r.Intn(64)), MLOAD)
+			depth++
+		case 2:
+			code = append(code, PUSH1, byte(r.Intn(64)), PUSH1, byte(r.Intn(64)), SHA3)
+			depth++
+		default:
+			op := ops[r.Intn(len(ops))]
+			for depth < op.pops {
+				push()
+			}
+
+			code = append(code, op.op)
+
+			switch {
+			case op.op == POP:
+				depth--
+			case op.op >= DUP1 && op.op <= DUP1+15:
+				depth++
+			case op.op >= SWAP1 && op.op <= SWAP1+15:
+				// swaps keep the depth
+			default:
+				depth -= op.pops - 1
+			}
+		}
+	}
+
+	if depth == 0 {
+		push()
+	}
+
+	code = append(code, PUSH1, 0x00, MSTORE, PUSH1, 0x20, PUSH1, 0x00)
+
+	if r.Intn(5) == 0 {
+		return append(code, REVERT)
+	}
+
+	return append(code, RETURN)
+}
+
+// TestEVM_PooledStateDifferential replays 1000 blocks of random programs through the pooled
+// states and checks that the results are identical to runs on newly allocated states
+func TestEVM_PooledStateDifferential(t *testing.T) {
+	t.Parallel()
+
+	var (
+		r      = rand.New(rand.NewSource(1)) //nolint:gosec
+		evm    = NewEVM()
+		host   = &mockHost{}
+		config = chain.AllForksEnabled.At(0)
+	)
+
+	for block := 0; block < 1000; block++ {
+		for tx := r.Intn(10); tx > 0; tx-- {
+			code := randomProgram(r)
+			value := big.NewInt(int64(r.Intn(1000)))
+
+			expected := runFresh(newMockContract(value, 1_000_000, code), host, &config)
+			actual := evm.Run(newMockContract(value, 1_000_000, code), host, &config)
+
+			require.Equal(t, expected, actual, "block %d, code %x", block, code)
+		}
+	}
+}
+
+// TestEVM_SharedConcurrentRuns runs random programs on a single EVM from parallel goroutines
+// with different forks, meant to be run with the race detector
+func TestEVM_SharedConcurrentRuns(t *testing.T) {
+	t.Parallel()
+
+	var (
+		evm     = NewEVM()
+		host    = &mockHost{}
+		configs = []chain.ForksInTime{
+			chain.AllForksEnabled.At(0),
+			chain.AllForksEnabled.Copy().RemoveFork(chain.London).At(0),
+			{},
+		}
+	)
+
+	var wg sync.WaitGroup
+
+	for worker := 0; worker < 8; worker++ {
+		wg.Add(1)
+
+		go func(worker int) {
+			defer wg.Done()
+
+			r := rand.New(rand.NewSource(int64(worker))) //nolint:gosec
+
+			for i := 0; i < 200; i++ {
+				config := configs[(worker+i)%len(configs)]
+				code := randomProgram(r)
+
+				expected := runFresh(newMockContract(big.NewInt(0), 1_000_000, code), host, &config)
+				actual := evm.Run(newMockContract(big.NewInt(0), 1_000_000, code), host, &config)
+
+				assert.Equal(t, expected, actual, "worker %d, code %x", worker, code)
+			}
+		}(worker)
+	}
+
+	wg.Wait()
+}
+
+func BenchmarkEVM_Run(b *testing.B) {
+	r := rand.New(rand.NewSource(1)) //nolint:gosec
+
+	programs := make([][]byte, 100)
+	for i := range programs {
+		programs[i] = randomProgram(r)
+	}
+
+	evm := NewEVM()
+	host := &mockHost{}
+	config := chain.AllForksEnabled.At(0)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		contract := newMockContract(big.NewInt(0), 1_000_000, programs[i%len(programs)])
+		evm.Run(contract, host, &config)
+		runtime.ReleaseContract(contract)
+	}
+}
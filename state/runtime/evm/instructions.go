@@ -22,7 +22,8 @@ var (
 	wordSize = big.NewInt(32)
 )
 
-// MCOPY (EIP-5656)
+// MCOPY (EIP-5656) costs 3 + 3*words plus the memory expansion up to the end of
+// the farther of both regions. Overlapping regions are copied like memmove.
 func opMcopy(c *state) {
 	destOffset := c.pop()
 	srcOffset := c.pop()
@@ -32,11 +33,13 @@ func opMcopy(c *state) {
 		return
 	}
 
-	if !c.allocateMemory(destOffset, length) {
-		return
+	// expanding to the farther region covers the nearer one as well
+	farthest := destOffset
+	if srcOffset.Cmp(destOffset) > 0 {
+		farthest = srcOffset
 	}
 
-	if !c.allocateMemory(srcOffset, length) {
+	if !c.allocateMemory(farthest, length) {
 		return
 	}
 
@@ -638,6 +641,29 @@ const (
 	maxInitCodeSize uint64 = 49152
 )
 
+// TLOAD and TSTORE (EIP-1153) access the transient storage, which is discarded at the end
+// of the transaction. Both have a flat cost of a warm storage read, and are removed
+// from the instruction sets without the fork.
+func opTload(c *state) {
+	loc := c.top()
+
+	val := c.host.GetTransientStorage(c.msg.Address, bigToHash(loc))
+	loc.SetBytes(val.Bytes())
+}
+
+func opTstore(c *state) {
+	if c.inStaticCall() {
+		c.exit(errWriteProtection)
+
+		return
+	}
+
+	key := c.popHash()
+	val := c.popHash()
+
+	c.host.SetTransientStorage(c.msg.Address, key, val)
+}
+
 func opSha3(c *state) {
 	offset := c.pop()
 	length := c.pop()
@@ -1201,6 +1227,7 @@ func opCreate(op OpCode) instruction {
 
 			if contract != nil {
 				c.gas += contract.Gas
+				runtime.ReleaseContract(contract)
 			}
 
 			return
@@ -1233,6 +1260,8 @@ func opCreate(op OpCode) instruction {
 			v.SetBytes(contract.Address.Bytes())
 		}
 
+		runtime.ReleaseContract(contract)
+
 		c.gas += result.GasLeft
 
 		if result.Reverted() {
@@ -1290,6 +1319,7 @@ func opCall(op OpCode) instruction {
 
 			if contract != nil {
 				c.gas += contract.Gas
+				runtime.ReleaseContract(contract)
 			}
 
 			return
@@ -1303,6 +1333,8 @@ func opCall(op OpCode) instruction {
 
 		result := c.host.Callx(contract, c.host)
 
+		runtime.ReleaseContract(contract)
+
 		v := c.push1()
 		if result.Succeeded() {
 			v.Set(one)
@@ -135,6 +135,105 @@ func TestMcopy(t *testing.T) {
 		assert.Equal(t, []byte{0x01, 0x01, 0x02, 0x03}, s.memory)
 	})
 
+	t.Run("handles backward overlapping copies", func(t *testing.T) {
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.gas = 1000
+		s.memory = make([]byte, 64)
+		for i := range s.memory {
+			s.memory[i] = byte(i)
+		}
+
+		s.push(big.NewInt(40)) // length
+		s.push(big.NewInt(20)) // src
+		s.push(big.NewInt(4))  // dest
+
+		opMcopy(s)
+
+		for i := 0; i < 40; i++ {
+			assert.Equal(t, byte(20+i), s.memory[4+i])
+		}
+
+		assert.Equal(t, []byte{0, 1, 2, 3}, s.memory[:4])
+		assert.Equal(t, []byte{60, 61, 62, 63}, s.memory[60:])
+	})
+
+	t.Run("handles forward overlapping copies across words", func(t *testing.T) {
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.gas = 1000
+		s.memory = make([]byte, 64)
+		for i := range s.memory {
+			s.memory[i] = byte(i)
+		}
+
+		s.push(big.NewInt(40)) // length
+		s.push(big.NewInt(4))  // src
+		s.push(big.NewInt(20)) // dest
+
+		opMcopy(s)
+
+		for i := 0; i < 40; i++ {
+			assert.Equal(t, byte(4+i), s.memory[20+i])
+		}
+
+		assert.Equal(t, []byte{0, 1, 2, 3}, s.memory[:4])
+	})
+
+	t.Run("charges copy and memory expansion gas", func(t *testing.T) {
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.gas = 1000
+
+		s.push(big.NewInt(40)) // length
+		s.push(big.NewInt(0))  // src
+		s.push(big.NewInt(32)) // dest
+
+		opMcopy(s)
+
+		// 2 words copied (6) and memory expanded to 3 words (9)
+		assert.Equal(t, uint64(1000-15), s.gas)
+		assert.Len(t, s.memory, 96)
+	})
+
+	t.Run("charges memory expansion of the source region", func(t *testing.T) {
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.gas = 1000
+
+		s.push(big.NewInt(32)) // length
+		s.push(big.NewInt(64)) // src
+		s.push(big.NewInt(0))  // dest
+
+		opMcopy(s)
+
+		// 1 word copied (3) and memory expanded to 3 words (9)
+		assert.Equal(t, uint64(1000-12), s.gas)
+		assert.Len(t, s.memory, 96)
+	})
+
+	t.Run("fails with out of gas when memory expansion exceeds the gas", func(t *testing.T) {
+		s, closeFn := getState()
+		defer closeFn()
+
+		// enough for the copy of a single word, not for expanding to 33 words
+		s.gas = 10
+
+		s.push(big.NewInt(32))   // length
+		s.push(big.NewInt(0))    // src
+		s.push(big.NewInt(1024)) // dest
+
+		opMcopy(s)
+
+		assert.True(t, s.stop)
+		assert.ErrorIs(t, s.err, errOutOfGas)
+		assert.Empty(t, s.memory)
+	})
+
 	t.Run("expands memory as needed", func(t *testing.T) {
 		s, closeFn := getState()
 		defer closeFn()
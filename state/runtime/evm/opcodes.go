@@ -8,6 +8,12 @@ import (
 type OpCode int
 
 const (
+	// TLOAD reads a (u)int256 from the transient storage (EIP-1153)
+	TLOAD = 0x5c
+
+	// TSTORE writes a (u)int256 to the transient storage (EIP-1153)
+	TSTORE = 0x5d
+
 	// MCOPY copies memory regions efficiently (EIP-5656)
 	MCOPY = 0x5e
 
@@ -342,6 +348,8 @@ var opCodeToString = map[OpCode]string{
 	CHAINID:        "CHAINID",
 	SELFBALANCE:    "SELFBALANCE",
 	PUSH0:          "PUSH0",
+	TLOAD:          "TLOAD",
+	TSTORE:         "TSTORE",
 	MCOPY:          "MCOPY",
 }
 
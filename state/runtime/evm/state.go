@@ -62,6 +62,9 @@ type state struct {
 	msg    *runtime.Contract // change with msg
 	config *chain.ForksInTime
 
+	// instructions is the instruction set of the forks in config
+	instructions *instructionSet
+
 	// memory
 	memory      []byte
 	lastGasCost uint64
@@ -70,6 +73,10 @@ type state struct {
 	stack []*big.Int
 	sp    int
 
+	// words are the stack words allocated by this state. They are kept when the
+	// state is released to the pool and reused by the stack of the next execution
+	words []*big.Int
+
 	// remove later
 	evm *EVM
 
@@ -82,11 +89,10 @@ type state struct {
 	// bitvec bitvec
 	bitmap bitmap
 
-	returnData       []byte
-	ret              []byte
-	transientStorage map[string]*big.Int
-	pc               uint64
-	stackFrames      []*stackFrame
+	returnData  []byte
+	ret         []byte
+	pc          uint64
+	stackFrames []*stackFrame
 }
 
 func (c *state) reset() {
@@ -107,6 +113,9 @@ func (c *state) reset() {
 	}
 
 	c.stack = c.stack[:0]
+	c.msg = nil
+	c.host = nil
+	c.instructions = nil
 	c.tmp = c.tmp[:0]
 	c.ret = c.ret[:0]
 	c.code = c.code[:0]
@@ -147,7 +156,16 @@ func (c *state) push1() *big.Int {
 		return c.stack[c.sp-1]
 	}
 
-	v := big.NewInt(0)
+	var v *big.Int
+
+	// every caller of push1 sets the value, the words don't have to be cleared
+	if n := len(c.stack); n < len(c.words) {
+		v = c.words[n]
+	} else {
+		v = new(big.Int)
+		c.words = append(c.words, v)
+	}
+
 	c.stack = append(c.stack, v)
 	c.sp++
 
@@ -229,6 +247,10 @@ func (c *state) Run() ([]byte, error) {
 		ok bool
 	)
 
+	if c.instructions == nil {
+		c.instructions = instructionSetFor(c.config)
+	}
+
 	for !c.stop {
 		op, ok = c.CurrentOpCode()
 		gasCopy, ipCopy := c.gas, uint64(c.ip)
@@ -243,7 +265,7 @@ func (c *state) Run() ([]byte, error) {
 			break
 		}
 
-		inst := dispatchTable[op]
+		inst := c.instructions[op]
 		if inst.inst == nil {
 			c.exit(errOpCodeNotFound)
 			c.captureExecution(op.String(), uint64(c.ip), gasCopy, 0)
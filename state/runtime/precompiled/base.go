@@ -21,7 +21,7 @@ func (e *ecrecover) gas(input []byte, config *chain.ForksInTime) uint64 {
 	return 3000
 }
 
-func (e *ecrecover) run(input []byte, caller types.Address, _ runtime.Host) ([]byte, error) {
+func (e *ecrecover) run(input []byte, caller types.Address, _ runtime.Host, _ *chain.ForksInTime) ([]byte, error) {
 	input, _ = e.p.get(input, 128)
 
 	// recover the value v. Expect all zeros except the last byte
@@ -57,7 +57,7 @@ func (i *identity) gas(input []byte, config *chain.ForksInTime) uint64 {
 	return baseGasCalc(input, 15, 3)
 }
 
-func (i *identity) run(input []byte, _ types.Address, _ runtime.Host) ([]byte, error) {
+func (i *identity) run(input []byte, _ types.Address, _ runtime.Host, _ *chain.ForksInTime) ([]byte, error) {
 	return input, nil
 }
 
@@ -68,7 +68,7 @@ func (s *sha256h) gas(input []byte, config *chain.ForksInTime) uint64 {
 	return baseGasCalc(input, 60, 12)
 }
 
-func (s *sha256h) run(input []byte, _ types.Address, _ runtime.Host) ([]byte, error) {
+func (s *sha256h) run(input []byte, _ types.Address, _ runtime.Host, _ *chain.ForksInTime) ([]byte, error) {
 	h := sha256.Sum256(input)
 
 	return h[:], nil
@@ -82,7 +82,7 @@ func (r *ripemd160h) gas(input []byte, config *chain.ForksInTime) uint64 {
 	return baseGasCalc(input, 600, 120)
 }
 
-func (r *ripemd160h) run(input []byte, _ types.Address, _ runtime.Host) ([]byte, error) {
+func (r *ripemd160h) run(input []byte, _ types.Address, _ runtime.Host, _ *chain.ForksInTime) ([]byte, error) {
 	ripemd := ripemd160.New()
 	ripemd.Write(input)
 	res := ripemd.Sum(nil)
@@ -21,7 +21,7 @@ func testPrecompiled(t *testing.T, p contract, cases []precompiledTest) {
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
 			h, _ := hex.DecodeString(c.Input)
-			found, err := p.run(h, types.ZeroAddress, nil)
+			found, err := p.run(h, types.ZeroAddress, nil, nil)
 
 			assert.NoError(t, err)
 			assert.Equal(t, c.Expected, hex.EncodeToString(found))
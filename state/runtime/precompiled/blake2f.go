@@ -22,7 +22,7 @@ func (e *blake2f) gas(input []byte, config *chain.ForksInTime) uint64 {
 	return uint64(binary.BigEndian.Uint32(input[0:4]))
 }
 
-func (e *blake2f) run(input []byte, _ types.Address, _ runtime.Host) ([]byte, error) {
+func (e *blake2f) run(input []byte, _ types.Address, _ runtime.Host, _ *chain.ForksInTime) ([]byte, error) {
 	// validate input
 	if len(input) != 213 {
 		return nil, fmt.Errorf("bad length")
@@ -13,7 +13,7 @@ func TestBlake2f(t *testing.T) {
 	ReadTestCase(t, "blake2f.json", func(t *testing.T, c *TestCase) {
 		t.Helper()
 
-		out, err := b.run(c.Input, types.ZeroAddress, nil)
+		out, err := b.run(c.Input, types.ZeroAddress, nil, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -39,7 +39,7 @@ func (c *blsAggSignsVerification) gas(input []byte, _ *chain.ForksInTime) uint64
 // Run runs the precompiled contract with the given input.
 // Input must be ABI encoded: tuple(bytes, bytes[], bytes)
 // Output could be an error or ABI encoded "bool" value
-func (c *blsAggSignsVerification) run(input []byte, caller types.Address, host runtime.Host) ([]byte, error) {
+func (c *blsAggSignsVerification) run(input []byte, caller types.Address, host runtime.Host, _ *chain.ForksInTime) ([]byte, error) {
 	rawData, err := abi.Decode(inputDataABIType, input)
 	if err != nil {
 		return nil, err
@@ -21,37 +21,37 @@ func Test_BlsAggSignsVerification(t *testing.T) {
 
 	// correct message, correct signers and signature
 	testCase := generateInput(t, message, pubKeys, signatures)
-	out, err := b.run(testCase, types.ZeroAddress, nil)
+	out, err := b.run(testCase, types.ZeroAddress, nil, nil)
 	require.NoError(t, err)
 	assert.Equal(t, abiBoolTrue, out)
 
 	// empty message
 	testCase = generateInput(t, nil, pubKeys, signatures)
-	out, err = b.run(testCase, types.ZeroAddress, nil)
+	out, err = b.run(testCase, types.ZeroAddress, nil, nil)
 	require.NoError(t, err)
 	assert.Equal(t, abiBoolFalse, out)
 
 	// missing signer
 	testCase = generateInput(t, message, pubKeys[1:], signatures)
-	out, err = b.run(testCase, types.ZeroAddress, nil)
+	out, err = b.run(testCase, types.ZeroAddress, nil, nil)
 	require.NoError(t, err)
 	assert.Equal(t, abiBoolFalse, out)
 
 	// missing signature
 	testCase = generateInput(t, message, pubKeys, signatures[1:])
-	out, err = b.run(testCase, types.ZeroAddress, nil)
+	out, err = b.run(testCase, types.ZeroAddress, nil, nil)
 	require.NoError(t, err)
 	assert.Equal(t, abiBoolFalse, out)
 
 	// invalid signer
 	testCase = generateInput(t, message, append(pubKeys[2:], pubKeys[0]), signatures[1:])
-	out, err = b.run(testCase, types.ZeroAddress, nil)
+	out, err = b.run(testCase, types.ZeroAddress, nil, nil)
 	require.NoError(t, err)
 	assert.Equal(t, abiBoolFalse, out)
 
 	// invalid signature
 	testCase = generateInput(t, message, pubKeys[1:], append(signatures[2:], signatures[0]))
-	out, err = b.run(testCase, types.ZeroAddress, nil)
+	out, err = b.run(testCase, types.ZeroAddress, nil, nil)
 	require.NoError(t, err)
 	assert.Equal(t, abiBoolFalse, out)
 }
@@ -22,7 +22,7 @@ func (b *bn256Add) gas(input []byte, config *chain.ForksInTime) uint64 {
 	return 500
 }
 
-func (b *bn256Add) run(input []byte, _ types.Address, _ runtime.Host) ([]byte, error) {
+func (b *bn256Add) run(input []byte, _ types.Address, _ runtime.Host, _ *chain.ForksInTime) ([]byte, error) {
 	var val []byte
 
 	b1 := new(bn256.G1)
@@ -56,7 +56,7 @@ func (b *bn256Mul) gas(input []byte, config *chain.ForksInTime) uint64 {
 	return 40000
 }
 
-func (b *bn256Mul) run(input []byte, _ types.Address, _ runtime.Host) ([]byte, error) {
+func (b *bn256Mul) run(input []byte, _ types.Address, _ runtime.Host, _ *chain.ForksInTime) ([]byte, error) {
 	var v []byte
 
 	b0 := new(bn256.G1)
@@ -88,7 +88,7 @@ func (b *bn256Pairing) gas(input []byte, config *chain.ForksInTime) uint64 {
 	return baseGas + pointGas*uint64(len(input)/192)
 }
 
-func (b *bn256Pairing) run(input []byte, _ types.Address, _ runtime.Host) ([]byte, error) {
+func (b *bn256Pairing) run(input []byte, _ types.Address, _ runtime.Host, _ *chain.ForksInTime) ([]byte, error) {
 	if len(input) == 0 {
 		return abiBoolTrue, nil
 	}
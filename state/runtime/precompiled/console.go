@@ -76,7 +76,7 @@ func (c *console) gas(_ []byte, _ *chain.ForksInTime) uint64 {
 }
 
 // Run contains the implementation logic of the precompiled contract
-func (c *console) run(input []byte, _ types.Address, _ runtime.Host) ([]byte, error) {
+func (c *console) run(input []byte, _ types.Address, _ runtime.Host, _ *chain.ForksInTime) ([]byte, error) {
 	fmt.Printf("Console: %v\n", decodeConsole(input))
 
 	return nil, nil
@@ -0,0 +1,318 @@
+package precompiled
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/umbracle/ethgo"
+	"github.com/xgr-network/xgr-node/chain"
+	"github.com/xgr-network/xgr-node/contracts"
+	"github.com/xgr-network/xgr-node/contracts/abis"
+	"github.com/xgr-network/xgr-node/state/runtime"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+const (
+	// donationVoteWriteGas covers the storage writes and the logs of propose, approve and execute
+	donationVoteWriteGas = 50000
+	// donationVoteReadGas covers the storage reads of proposal and proposalCount
+	donationVoteReadGas = 5000
+)
+
+var (
+	proposeDonationMethod  = abis.DonationGovernanceABI.GetMethod("propose")
+	approveDonationMethod  = abis.DonationGovernanceABI.GetMethod("approve")
+	executeDonationMethod  = abis.DonationGovernanceABI.GetMethod("execute")
+	donationProposalMethod = abis.DonationGovernanceABI.GetMethod("proposal")
+	donationCountMethod    = abis.DonationGovernanceABI.GetMethod("proposalCount")
+
+	donationProposedEvent = abis.DonationGovernanceABI.Events["DonationPercentProposed"]
+	donationApprovedEvent = abis.DonationGovernanceABI.Events["DonationPercentApproved"]
+	donationPassedEvent   = abis.DonationGovernanceABI.Events["DonationPercentPassed"]
+	donationExecutedEvent = abis.DonationGovernanceABI.Events["DonationPercentExecuted"]
+)
+
+// ValidatorsFunc returns the validators of the block being executed
+type ValidatorsFunc func() ([]types.Address, error)
+
+// donationGovernance lets the validators vote on the donation percent of the EngineRegistry.
+// The validators or authorized engines propose a percent, the validators approve it, and once
+// the threshold is reached and the delay passed, anyone can execute the proposal, which
+// writes the percent into the donationPercent slot read by the fee split.
+type donationGovernance struct {
+	config     *chain.DonationGovernanceConfig
+	validators ValidatorsFunc
+}
+
+func (c *donationGovernance) gas(input []byte, _ *chain.ForksInTime) uint64 {
+	if !c.writes(input) {
+		return donationVoteReadGas
+	}
+
+	return donationVoteWriteGas
+}
+
+// writes reports whether the call changes the state, which all methods but the getters do
+func (c *donationGovernance) writes(input []byte) bool {
+	return len(input) < 4 || !(bytes.Equal(input[:4], donationProposalMethod.ID()) ||
+		bytes.Equal(input[:4], donationCountMethod.ID()))
+}
+
+// isValidator reports whether the address is a validator of the block
+func (c *donationGovernance) isValidator(addr types.Address) (bool, error) {
+	if c.validators == nil {
+		return false, nil
+	}
+
+	validators, err := c.validators()
+	if err != nil {
+		return false, err
+	}
+
+	for _, v := range validators {
+		if v == addr {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (c *donationGovernance) run(input []byte, caller types.Address, host runtime.Host, _ *chain.ForksInTime) ([]byte, error) {
+	if len(input) < 4 {
+		return nil, runtime.ErrInvalidInputData
+	}
+
+	switch {
+	case bytes.Equal(input[:4], donationProposalMethod.ID()):
+		return c.proposal(input[4:], host)
+	case bytes.Equal(input[:4], donationCountMethod.ID()):
+		return donationCountMethod.Outputs.Encode([]interface{}{
+			new(big.Int).SetUint64(donationProposalCount(host)),
+		})
+	}
+
+	config := c.config
+	if config == nil {
+		return nil, runtime.ErrDonationVoteDisabled
+	}
+
+	switch {
+	case bytes.Equal(input[:4], proposeDonationMethod.ID()):
+		return c.propose(input[4:], caller, host)
+	case bytes.Equal(input[:4], approveDonationMethod.ID()):
+		return c.approve(input[4:], caller, host, config)
+	case bytes.Equal(input[:4], executeDonationMethod.ID()):
+		return c.execute(input[4:], host, config)
+	}
+
+	return nil, runtime.ErrInvalidInputData
+}
+
+// propose registers a new proposal of the donation percent, the caller has to be
+// a validator or an authorized engine
+func (c *donationGovernance) propose(input []byte, caller types.Address, host runtime.Host) ([]byte, error) {
+	isValidator, err := c.isValidator(caller)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isValidator && !IsAuthorizedEngine(host, caller) {
+		return nil, runtime.ErrUnauthorizedCaller
+	}
+
+	percent, err := decodeDonationArg(proposeDonationMethod.Inputs.Decode, input, "percent")
+	if err != nil || percent > 100 {
+		return nil, runtime.ErrInvalidInputData
+	}
+
+	id := donationProposalCount(host) + 1
+
+	writeDonationProposal(host, id, &contracts.DonationProposal{
+		Proposer:      caller,
+		Percent:       percent,
+		ProposedBlock: uint64(host.GetTxContext().Number),
+	})
+	host.SetStorage(contracts.DonationGovernancePrecompile, contracts.DonationProposalCountSlotKey(),
+		uint64ToHash(id), &chain.ForksInTime{})
+
+	host.EmitLog(contracts.DonationGovernancePrecompile, []types.Hash{
+		types.Hash(donationProposedEvent.ID()),
+		uint64ToHash(id),
+		types.BytesToHash(caller.Bytes()),
+	}, uint64ToHash(percent).Bytes())
+
+	return proposeDonationMethod.Outputs.Encode([]interface{}{new(big.Int).SetUint64(id)})
+}
+
+// approve counts the approval of the validator. The proposal passes with the approval
+// reaching the threshold, it is executable after the configured delay.
+func (c *donationGovernance) approve(
+	input []byte, caller types.Address, host runtime.Host, config *chain.DonationGovernanceConfig,
+) ([]byte, error) {
+	isValidator, err := c.isValidator(caller)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isValidator {
+		return nil, runtime.ErrUnauthorizedCaller
+	}
+
+	id, proposal, err := readDonationProposalArg(approveDonationMethod.Inputs.Decode, input, host)
+	if err != nil {
+		return nil, err
+	}
+
+	if proposal.Executed {
+		return nil, runtime.ErrDonationProposalExecuted
+	}
+
+	voteKey := contracts.DonationVoteSlotKey(id, caller)
+	if host.GetStorage(contracts.DonationGovernancePrecompile, voteKey) != types.ZeroHash {
+		return nil, runtime.ErrDonationAlreadyApproved
+	}
+
+	host.SetStorage(contracts.DonationGovernancePrecompile, voteKey, uint64ToHash(1), &chain.ForksInTime{})
+
+	blockNumber := uint64(host.GetTxContext().Number)
+
+	proposal.Approvals++
+	if proposal.Approvals == config.Threshold {
+		proposal.PassedBlock = blockNumber
+	}
+
+	writeDonationProposal(host, id, proposal)
+
+	host.EmitLog(contracts.DonationGovernancePrecompile, []types.Hash{
+		types.Hash(donationApprovedEvent.ID()),
+		uint64ToHash(id),
+		types.BytesToHash(caller.Bytes()),
+	}, uint64ToHash(proposal.Approvals).Bytes())
+
+	if proposal.Approvals == config.Threshold {
+		host.EmitLog(contracts.DonationGovernancePrecompile, []types.Hash{
+			types.Hash(donationPassedEvent.ID()),
+			uint64ToHash(id),
+		}, uint64ToHash(blockNumber+config.DelayBlocks).Bytes())
+	}
+
+	return nil, nil
+}
+
+// execute writes the percent of the passed proposal into the donationPercent slot of the registry
+func (c *donationGovernance) execute(
+	input []byte, host runtime.Host, config *chain.DonationGovernanceConfig,
+) ([]byte, error) {
+	id, proposal, err := readDonationProposalArg(executeDonationMethod.Inputs.Decode, input, host)
+	if err != nil {
+		return nil, err
+	}
+
+	if proposal.Executed {
+		return nil, runtime.ErrDonationProposalExecuted
+	}
+
+	if proposal.Approvals < config.Threshold ||
+		uint64(host.GetTxContext().Number) < proposal.PassedBlock+config.DelayBlocks {
+		return nil, runtime.ErrDonationProposalNotReady
+	}
+
+	reg := chain.EngineRegistryAddress
+	if reg == (types.Address{}) || len(host.GetCode(reg)) == 0 {
+		return nil, runtime.ErrDonationRegistryMissing
+	}
+
+	host.SetStorage(reg, chain.EngineRegistrySlotKeyDonationPercent(), uint64ToHash(proposal.Percent),
+		&chain.ForksInTime{})
+
+	proposal.Executed = true
+	writeDonationProposal(host, id, proposal)
+
+	host.EmitLog(contracts.DonationGovernancePrecompile, []types.Hash{
+		types.Hash(donationExecutedEvent.ID()),
+		uint64ToHash(id),
+	}, uint64ToHash(proposal.Percent).Bytes())
+
+	return nil, nil
+}
+
+// proposal returns the state of the proposal with the id
+func (c *donationGovernance) proposal(input []byte, host runtime.Host) ([]byte, error) {
+	_, proposal, err := readDonationProposalArg(donationProposalMethod.Inputs.Decode, input, host)
+	if err != nil {
+		return nil, err
+	}
+
+	return donationProposalMethod.Outputs.Encode([]interface{}{
+		ethgo.Address(proposal.Proposer),
+		new(big.Int).SetUint64(proposal.Percent),
+		new(big.Int).SetUint64(proposal.ProposedBlock),
+		new(big.Int).SetUint64(proposal.Approvals),
+		new(big.Int).SetUint64(proposal.PassedBlock),
+		proposal.Executed,
+	})
+}
+
+// decodeDonationArg decodes the single uint256 argument of a method, it has to fit into uint64
+func decodeDonationArg(decode func([]byte) (interface{}, error), input []byte, name string) (uint64, error) {
+	val, err := decode(input)
+	if err != nil {
+		return 0, runtime.ErrInvalidInputData
+	}
+
+	args, ok := val.(map[string]interface{})
+	if !ok {
+		return 0, runtime.ErrInvalidInputData
+	}
+
+	arg, ok := args[name].(*big.Int)
+	if !ok || !arg.IsUint64() {
+		return 0, runtime.ErrInvalidInputData
+	}
+
+	return arg.Uint64(), nil
+}
+
+// readDonationProposalArg decodes the proposal id argument and reads the proposal
+func readDonationProposalArg(
+	decode func([]byte) (interface{}, error), input []byte, host runtime.Host,
+) (uint64, *contracts.DonationProposal, error) {
+	id, err := decodeDonationArg(decode, input, "id")
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if id == 0 || id > donationProposalCount(host) {
+		return 0, nil, runtime.ErrDonationProposalUnknown
+	}
+
+	first, second := contracts.DonationProposalSlotKeys(id)
+
+	return id, contracts.DecodeDonationProposal(
+		host.GetStorage(contracts.DonationGovernancePrecompile, first),
+		host.GetStorage(contracts.DonationGovernancePrecompile, second),
+	), nil
+}
+
+func writeDonationProposal(host runtime.Host, id uint64, proposal *contracts.DonationProposal) {
+	firstKey, secondKey := contracts.DonationProposalSlotKeys(id)
+	first, second := contracts.EncodeDonationProposal(proposal)
+
+	host.SetStorage(contracts.DonationGovernancePrecompile, firstKey, first, &chain.ForksInTime{})
+	host.SetStorage(contracts.DonationGovernancePrecompile, secondKey, second, &chain.ForksInTime{})
+}
+
+func donationProposalCount(host runtime.Host) uint64 {
+	count := host.GetStorage(contracts.DonationGovernancePrecompile, contracts.DonationProposalCountSlotKey())
+
+	return new(big.Int).SetBytes(count[:]).Uint64()
+}
+
+func uint64ToHash(n uint64) types.Hash {
+	var h types.Hash
+
+	new(big.Int).SetUint64(n).FillBytes(h[:])
+
+	return h
+}
@@ -0,0 +1,254 @@
+package precompiled
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/xgr-network/xgr-node/chain"
+	"github.com/xgr-network/xgr-node/contracts"
+	"github.com/xgr-network/xgr-node/contracts/abis"
+	"github.com/xgr-network/xgr-node/state/runtime"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+// donationHost serves the storage of the vote and the registry, the block number and the logs
+type donationHost struct {
+	runtime.Host
+
+	number  int64
+	code    map[types.Address][]byte
+	storage map[types.Address]map[types.Hash]types.Hash
+	logs    []*types.Log
+}
+
+func newDonationHost() *donationHost {
+	return &donationHost{
+		code:    map[types.Address][]byte{},
+		storage: map[types.Address]map[types.Hash]types.Hash{},
+	}
+}
+
+func (d *donationHost) GetCode(addr types.Address) []byte {
+	return d.code[addr]
+}
+
+func (d *donationHost) GetStorage(addr types.Address, key types.Hash) types.Hash {
+	return d.storage[addr][key]
+}
+
+func (d *donationHost) SetStorage(
+	addr types.Address, key types.Hash, value types.Hash, _ *chain.ForksInTime) runtime.StorageStatus {
+	if d.storage[addr] == nil {
+		d.storage[addr] = map[types.Hash]types.Hash{}
+	}
+
+	d.storage[addr][key] = value
+
+	return runtime.StorageModified
+}
+
+func (d *donationHost) GetTxContext() runtime.TxContext {
+	return runtime.TxContext{Number: d.number}
+}
+
+func (d *donationHost) EmitLog(addr types.Address, topics []types.Hash, data []byte) {
+	d.logs = append(d.logs, &types.Log{Address: addr, Topics: topics, Data: data})
+}
+
+func TestDonationGovernancePrecompile(t *testing.T) {
+	var (
+		registry   = types.StringToAddress("0x1000")
+		validators = []types.Address{
+			types.StringToAddress("0x1"),
+			types.StringToAddress("0x2"),
+			types.StringToAddress("0x3"),
+			types.StringToAddress("0x4"),
+		}
+		outsider = types.StringToAddress("0x5")
+	)
+
+	prevReg := chain.EngineRegistryAddress
+	t.Cleanup(func() {
+		chain.EngineRegistryAddress = prevReg
+	})
+
+	chain.EngineRegistryAddress = registry
+
+	host := newDonationHost()
+	host.code[registry] = []byte{0x1}
+	host.SetStorage(registry, chain.EngineRegistrySlotKeyDonationPercent(), uint64ToHash(15), nil)
+
+	c := &donationGovernance{
+		config: &chain.DonationGovernanceConfig{Threshold: 3, DelayBlocks: 10},
+		validators: func() ([]types.Address, error) {
+			return validators, nil
+		},
+	}
+
+	call := func(caller types.Address, method string, arg uint64) ([]byte, error) {
+		t.Helper()
+
+		input, err := abis.DonationGovernanceABI.GetMethod(method).Encode([]interface{}{new(big.Int).SetUint64(arg)})
+		require.NoError(t, err)
+
+		return c.run(input, caller, host, nil)
+	}
+
+	donationPercent := func() uint64 {
+		return new(big.Int).SetBytes(
+			host.GetStorage(registry, chain.EngineRegistrySlotKeyDonationPercent()).Bytes()).Uint64()
+	}
+
+	lastEvent := func() (types.Hash, []byte) {
+		log := host.logs[len(host.logs)-1]
+		require.Equal(t, contracts.DonationGovernancePrecompile, log.Address)
+
+		return log.Topics[0], log.Data
+	}
+
+	host.number = 100
+
+	// only validators and authorized engines propose, the percent is at most 100
+	_, err := call(outsider, "propose", 20)
+	require.ErrorIs(t, err, runtime.ErrUnauthorizedCaller)
+
+	_, err = call(validators[0], "propose", 101)
+	require.ErrorIs(t, err, runtime.ErrInvalidInputData)
+
+	out, err := call(validators[0], "propose", 20)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), new(big.Int).SetBytes(out).Uint64())
+
+	topic, data := lastEvent()
+	require.Equal(t, types.Hash(donationProposedEvent.ID()), topic)
+	require.Equal(t, uint64ToHash(20).Bytes(), data)
+
+	_, err = call(validators[0], "approve", 2)
+	require.ErrorIs(t, err, runtime.ErrDonationProposalUnknown)
+
+	// 3 of the 4 validators approve, an outsider and a second approval are rejected
+	_, err = call(outsider, "approve", 1)
+	require.ErrorIs(t, err, runtime.ErrUnauthorizedCaller)
+
+	_, err = call(validators[0], "approve", 1)
+	require.NoError(t, err)
+
+	_, err = call(validators[0], "approve", 1)
+	require.ErrorIs(t, err, runtime.ErrDonationAlreadyApproved)
+
+	host.number = 101
+
+	_, err = call(validators[1], "approve", 1)
+	require.NoError(t, err)
+
+	// not passed yet
+	_, err = call(outsider, "execute", 1)
+	require.ErrorIs(t, err, runtime.ErrDonationProposalNotReady)
+
+	host.number = 102
+
+	_, err = call(validators[3], "approve", 1)
+	require.NoError(t, err)
+
+	topic, data = lastEvent()
+	require.Equal(t, types.Hash(donationPassedEvent.ID()), topic)
+	require.Equal(t, uint64ToHash(112).Bytes(), data)
+
+	// within the delay window the registry keeps the old percent
+	host.number = 111
+
+	_, err = call(outsider, "execute", 1)
+	require.ErrorIs(t, err, runtime.ErrDonationProposalNotReady)
+	require.Equal(t, uint64(15), donationPercent())
+
+	// after the delay anyone executes the proposal
+	host.number = 112
+
+	_, err = call(outsider, "execute", 1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(20), donationPercent())
+
+	topic, data = lastEvent()
+	require.Equal(t, types.Hash(donationExecutedEvent.ID()), topic)
+	require.Equal(t, uint64ToHash(20).Bytes(), data)
+
+	// the fee split reads the new percent
+	_, percent := chain.ResolveDonation(
+		uint64ToHash(1), host.GetStorage(registry, chain.EngineRegistrySlotKeyDonationPercent()),
+		chain.DefaultDonationPercent)
+	require.Equal(t, uint64(20), percent)
+
+	_, err = call(outsider, "execute", 1)
+	require.ErrorIs(t, err, runtime.ErrDonationProposalExecuted)
+
+	_, err = call(validators[2], "approve", 1)
+	require.ErrorIs(t, err, runtime.ErrDonationProposalExecuted)
+
+	// the status is readable
+	out, err = call(outsider, "proposal", 1)
+	require.NoError(t, err)
+
+	status, err := donationProposalMethod.Outputs.Decode(out)
+	require.NoError(t, err)
+
+	fields, ok := status.(map[string]interface{})
+	require.True(t, ok)
+	require.Equal(t, big.NewInt(3), fields["approvals"])
+	require.Equal(t, big.NewInt(102), fields["passedBlock"])
+	require.Equal(t, true, fields["executed"])
+
+	// proposed, 3 approvals, passed and executed
+	require.Len(t, host.logs, 6)
+}
+
+func TestDonationGovernancePrecompile_Disabled(t *testing.T) {
+	input, err := proposeDonationMethod.Encode([]interface{}{big.NewInt(20)})
+	require.NoError(t, err)
+
+	_, err = (&donationGovernance{}).run(input, types.StringToAddress("0x1"), newDonationHost(), nil)
+	require.ErrorIs(t, err, runtime.ErrDonationVoteDisabled)
+}
+
+func TestDonationGovernancePrecompile_NoValidators(t *testing.T) {
+	c := &donationGovernance{config: &chain.DonationGovernanceConfig{Threshold: 1}}
+
+	input, err := proposeDonationMethod.Encode([]interface{}{big.NewInt(20)})
+	require.NoError(t, err)
+
+	_, err = c.run(input, types.StringToAddress("0x1"), newDonationHost(), nil)
+	require.ErrorIs(t, err, runtime.ErrUnauthorizedCaller)
+}
+
+func TestDonationGovernancePrecompile_StaticCall(t *testing.T) {
+	voter := types.StringToAddress("0x1")
+
+	p := NewPrecompiled()
+	p.SetDonationGovernance(&chain.DonationGovernanceConfig{Threshold: 1}, func() ([]types.Address, error) {
+		return []types.Address{voter}, nil
+	})
+
+	staticCall := func(input []byte) *runtime.ExecutionResult {
+		t.Helper()
+
+		return p.Run(&runtime.Contract{
+			CodeAddress: contracts.DonationGovernancePrecompile,
+			Caller:      voter,
+			Static:      true,
+			Gas:         donationVoteWriteGas,
+			Input:       input,
+		}, newDonationHost(), &chain.ForksInTime{})
+	}
+
+	propose, err := proposeDonationMethod.Encode([]interface{}{big.NewInt(20)})
+	require.NoError(t, err)
+
+	require.ErrorIs(t, staticCall(propose).Err, runtime.ErrWriteProtection)
+
+	// the getters stay callable
+	count, err := donationCountMethod.Encode([]interface{}{})
+	require.NoError(t, err)
+
+	require.NoError(t, staticCall(count).Err)
+}
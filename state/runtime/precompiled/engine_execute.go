@@ -2,6 +2,8 @@ package precompiled
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
 	"math/big"
 
 	ethgo "github.com/umbracle/ethgo"
@@ -10,17 +12,12 @@ import (
 
 	"github.com/xgr-network/xgr-node/chain"
 	"github.com/xgr-network/xgr-node/contracts"
-	"github.com/xgr-network/xgr-node/crypto"
 	"github.com/xgr-network/xgr-node/state/runtime"
 	"github.com/xgr-network/xgr-node/types"
 )
 
 type engineExecute struct{ p *Precompiled }
 
-var (
-	slotNextPid = crypto.Keccak256([]byte("XGR:ENGINE:NEXT_PID"))
-)
-
 var getNextPidABI = ethabi.MustNewABI(engineabi.GetNextPidABI)
 var isPidUsedABI = ethabi.MustNewABI(engineabi.IsPidUsedABI)
 var engineABI = ethabi.MustNewABI(engineabi.ExecuteABI)
@@ -57,12 +54,126 @@ func authorizeEngineCaller(host runtime.Host, caller types.Address) (types.Addre
 	return caller, true
 }
 
-// custom 32+20 key schema (slot ‖ addr[20])
+// IsAuthorizedEngine reports whether the address may call the engine execute precompile
+// at the state of the host
+func IsAuthorizedEngine(host runtime.Host, addr types.Address) bool {
+	_, ok := authorizeEngineCaller(host, addr)
+
+	return ok
+}
+
+// grantChainIDRequired reports whether the EngineRegistry makes the grant chainId mandatory.
+// Without a deployed registry an unset chainId is accepted.
+func grantChainIDRequired(host runtime.Host) bool {
+	reg := chain.EngineRegistryAddress
+	if reg == (types.Address{}) || len(host.GetCode(reg)) == 0 {
+		return false
+	}
+
+	return chain.EngineRegistryRequireGrantChainID(host.GetStorage(reg, chain.EngineRegistrySlotKeyRequireGrantChainID()))
+}
+
+// refundOnFailurePolicy reports whether the EngineRegistry excludes the execLimit from the
+// refund of an execute whose inner call failed. Without a deployed registry the full refund applies.
+func refundOnFailurePolicy(host runtime.Host) bool {
+	reg := chain.EngineRegistryAddress
+	if reg == (types.Address{}) || len(host.GetCode(reg)) == 0 {
+		return false
+	}
+
+	return chain.EngineRegistryRefundOnFailurePolicy(host.GetStorage(reg, chain.EngineRegistrySlotKeyRefundOnFailurePolicy()))
+}
+
+// checkGrantChainID rejects grants signed for another chain. A zero/nil chainId
+// means "any chain" for backward compatibility, unless the chainId is required.
+func checkGrantChainID(grant inGrant, chainID int64, required bool) error {
+	if grant.ChainId == nil || grant.ChainId.Sign() == 0 {
+		if required {
+			return runtime.ErrEngineWrongChain
+		}
+
+		return nil
+	}
+
+	if grant.ChainId.Cmp(big.NewInt(chainID)) != 0 {
+		return runtime.ErrEngineWrongChain
+	}
+
+	return nil
+}
+
+// checkGrantExpiry rejects grants that expired before the tx time. A zero/nil expiry never expires.
+func checkGrantExpiry(grant inGrant, txTime uint64) error {
+	if grant.Expiry == nil || grant.Expiry.Sign() == 0 {
+		return nil
+	}
+
+	if new(big.Int).SetUint64(txTime).Cmp(grant.Expiry) > 0 {
+		return runtime.ErrEngineGrantExpired
+	}
+
+	return nil
+}
+
+// hasGasCeiling reports whether the grant limits the gas of its session (zero/nil = no ceiling)
+func hasGasCeiling(grant inGrant) bool {
+	return grant.MaxTotalGas != nil && grant.MaxTotalGas.Sign() > 0
+}
+
+// chargeSessionGas adds units to the gas billed in the session rooted at grant.SessionId
+// and rejects the execute if the total would exceed grant.MaxTotalGas.
+// Only executes with a ceiling are accumulated, a grant without one is not charged.
+// A new root session (newRoot) starts from zero.
+func chargeSessionGas(host runtime.Host, grant inGrant, units uint64, newRoot bool) error {
+	if !hasGasCeiling(grant) {
+		return nil
+	}
+
+	key := contracts.EngineSessionGasSlotKey(types.Address(grant.From), grant.SessionId)
+
+	total := new(big.Int).SetUint64(units)
+	if !newRoot {
+		total.Add(total, nz(sloadU256(host, key)))
+	}
+
+	if total.Cmp(grant.MaxTotalGas) > 0 {
+		return runtime.ErrEngineMaxTotalGas
+	}
+
+	sstoreU256(host, key, total)
+
+	return nil
+}
+
+// checkSessionIteration returns the iteration enforced for the execute. A new root session
+// resets the iteration to 0, a follow-up must be strictly after the last stored iteration.
+// Sessions without a stored iteration (created before it was tracked) accept their first
+// follow-up unconditionally.
+func checkSessionIteration(host runtime.Host, grant inGrant, iteration uint64, newRoot bool) (uint64, error) {
+	if newRoot {
+		return 0, nil
+	}
+
+	// stored as lastIteration+1, zero = not tracked
+	stored := sloadU256(host, contracts.EngineSessionIterationSlotKey(types.Address(grant.From), grant.SessionId))
+	if stored != nil && new(big.Int).SetUint64(iteration).Cmp(stored) < 0 {
+		return 0, runtime.ErrEngineIterationOrder
+	}
+
+	return iteration, nil
+}
+
+// storeSessionIteration records iteration as the last iteration of the session rooted at grant.SessionId
+func storeSessionIteration(host runtime.Host, grant inGrant, iteration uint64) {
+	stored := new(big.Int).SetUint64(iteration)
+	stored.Add(stored, big.NewInt(1))
+
+	sstoreU256(host, contracts.EngineSessionIterationSlotKey(types.Address(grant.From), grant.SessionId), stored)
+}
+
+// custom 32+20 key schema (slot ‖ addr[20]), shared with the stub xgr RPC
 func kNext(a ethgo.Address) types.Hash {
-	var b [52]byte
-	copy(b[:32], slotNextPid[:])
-	copy(b[32:], a[:])
-	return types.BytesToHash(crypto.Keccak256(b[:]))
+	return contracts.EngineNextPidSlotKey(types.Address(a))
 }
 
 func sloadU256(h runtime.Host, k types.Hash) *big.Int {
@@ -102,6 +213,12 @@ func pidUsed(host runtime.Host, user ethgo.Address, pid *big.Int) bool {
 // Kein fixes Overhead mehr
 const engineOverheadGas = uint64(0)
 
+// SSTORE der letzten Iteration der Session (ab dem EngineSessionIteration-Fork schreibt jeder ENGINE_EXECUTE sie)
+const engineIterationStoreGas = uint64(20_000)
+
+// SSTORE des Session-Gases (ab dem EngineSessionGasCeiling-Fork, nur für Grants mit MaxTotalGas)
+const engineSessionGasStoreGas = uint64(20_000)
+
 var engineMetaEvent = ethabi.MustNewABI(engineabi.EngineMetaEventABI).Events["EngineMeta"]
 
 func EngineMetaEventABI() string { return engineabi.EngineMetaEventABI }
@@ -112,6 +229,8 @@ var engineExtrasEvent = ethabi.MustNewABI(engineExtrasEventABI).Events["EngineEx
 
 func EngineExtrasEventABI() string { return engineExtrasEventABI }
 
+var grantFeeChargedEvent = ethabi.MustNewABI(engineabi.GrantFeeChargedEventABI).Events["GrantFeeCharged"]
+
 type inGrant struct {
 	From, Engine, XRC729 ethgo.Address
 	OstcId               string
@@ -190,13 +309,23 @@ type feeCalc struct {
 	callOverhead  uint64
 	execLimit     uint64
 	validationGas uint64
+	iterationGas  uint64
+	sessionGas    uint64
 }
 
-func calcFee(input []byte, grant inGrant, call inCall, meta inMeta) feeCalc {
+func calcFee(input []byte, grant inGrant, call inCall, meta inMeta, config *chain.ForksInTime) feeCalc {
 	var exec uint64
 	if call.To != (ethgo.Address{}) && call.GasLimit > 0 {
 		exec = call.GasLimit
 	}
+	var iteration uint64
+	if config.EngineSessionIteration {
+		iteration = engineIterationStoreGas
+	}
+	var session uint64
+	if config.EngineSessionGasCeiling && hasGasCeiling(grant) {
+		session = engineSessionGasStoreGas
+	}
 	return feeCalc{
 		calldata:      calldataCostUnits(input),
 		metaLen:       calcEngineMetaLen(grant, meta),
@@ -204,6 +333,8 @@ func calcFee(input []byte, grant inGrant, call inCall, meta inMeta) feeCalc {
 		callOverhead:  callOverheadUnits(call.To, call.GasLimit, len(call.Data)),
 		execLimit:     exec,
 		validationGas: call.ValidationGas,
+		iterationGas:  iteration,
+		sessionGas:    session,
 	}
 }
 
@@ -213,12 +344,14 @@ func (f feeCalc) logUnits() uint64 {
 
 // Precompile-Gas (ohne TX-Base + calldata)
 func (f feeCalc) precompileGasUnits() uint64 {
-	return f.validationGas + f.logUnits() + f.callOverhead + f.execLimit + engineOverheadGas
+	return f.validationGas + f.logUnits() + f.callOverhead + f.execLimit + f.iterationGas + f.sessionGas +
+		engineOverheadGas
 }
 
 // EVM-TX Units (TX-Base + calldata + Events + CALL-Overhead + execLimit), ohne validationGas
 func (f feeCalc) evmTxUnits() uint64 {
-	return 21_000 + f.calldata + f.logUnits() + f.callOverhead + f.execLimit + engineOverheadGas
+	return 21_000 + f.calldata + f.logUnits() + f.callOverhead + f.execLimit + f.iterationGas + f.sessionGas +
+		engineOverheadGas
 }
 
 // Gesamt für Abrechnung/Receipt-Summe (EVM + Validation)
@@ -226,45 +359,101 @@ func (f feeCalc) totalTxUnits() uint64 {
 	return f.evmTxUnits() + f.validationGas
 }
 
-func (e *engineExecute) gas(input []byte, _ *chain.ForksInTime) uint64 {
-	// Minimum (User-Wunsch): niemals 0 zurückgeben für ENGINE_EXECUTE, auch wenn Decode fehlschlägt.
-	const minMalformedExecuteGas = uint64(21_000)
-	if len(input) < 4 {
-		return 0
-	}
-	if !bytes.Equal(input[:4], engineABI.GetMethod("ENGINE_EXECUTE").ID()) {
-		return 0
-	}
+// minMalformedExecuteGas is charged for ENGINE_EXECUTE calldata which doesn't decode
+const minMalformedExecuteGas = uint64(21_000)
+
+var (
+	ErrNotEngineExecute       = errors.New("calldata is not an ENGINE_EXECUTE call")
+	ErrMalformedEngineExecute = errors.New("malformed ENGINE_EXECUTE calldata")
+)
+
+// decodeExecuteInput decodes the arguments of ENGINE_EXECUTE calldata, the selector is not checked
+func decodeExecuteInput(input []byte) (inGrant, inCall, inMeta, bool) {
 	vals, err := engineABI.GetMethod("ENGINE_EXECUTE").Inputs.Decode(input[4:])
 	if err != nil {
-		return minMalformedExecuteGas
+		return inGrant{}, inCall{}, inMeta{}, false
 	}
 	args, ok := vals.(map[string]interface{})
 	if !ok {
-		return minMalformedExecuteGas
+		return inGrant{}, inCall{}, inMeta{}, false
 	}
 	grantMap, ok := args["grant"].(map[string]interface{})
 	if !ok {
-		return minMalformedExecuteGas
+		return inGrant{}, inCall{}, inMeta{}, false
 	}
 	callMap, ok := args["call"].(map[string]interface{})
 	if !ok {
-		return minMalformedExecuteGas
+		return inGrant{}, inCall{}, inMeta{}, false
 	}
 	metaMap, ok := args["meta"].(map[string]interface{})
 	if !ok {
-		return minMalformedExecuteGas
+		return inGrant{}, inCall{}, inMeta{}, false
+	}
+
+	return decodeGrant(grantMap), decodeCall(callMap), decodeMeta(metaMap), true
+}
+
+func isEngineExecuteInput(input []byte) bool {
+	return len(input) >= 4 && bytes.Equal(input[:4], engineABI.GetMethod("ENGINE_EXECUTE").ID())
+}
+
+// TraceFrameName returns the name of the precompile function called with the input if the
+// function calls other contracts. Tracers show these calls below a frame of the function
+func TraceFrameName(addr types.Address, input []byte) (string, bool) {
+	if addr == contracts.EngineExecutePrecompile && isEngineExecuteInput(input) {
+		return "ENGINE_EXECUTE", true
 	}
 
-	grant := decodeGrant(grantMap)
-	call := decodeCall(callMap)
-	meta := decodeMeta(metaMap)
+	return "", false
+}
+
+func (e *engineExecute) gas(input []byte, config *chain.ForksInTime) uint64 {
+	// Minimum (User-Wunsch): niemals 0 zurückgeben für ENGINE_EXECUTE, auch wenn Decode fehlschlägt.
+	if !isEngineExecuteInput(input) {
+		return 0
+	}
+	grant, call, meta, ok := decodeExecuteInput(input)
+	if !ok {
+		return minMalformedExecuteGas
+	}
 
-	fc := calcFee(input, grant, call, meta)
+	fc := calcFee(input, grant, call, meta, config)
 	return fc.precompileGasUnits()
 }
 
-func (e *engineExecute) run(input []byte, caller types.Address, host runtime.Host) ([]byte, error) {
+// EngineGasEstimate is the gas breakdown of an ENGINE_EXECUTE call
+type EngineGasEstimate struct {
+	// PrecompileGasUnits is the gas charged by the precompile, without the tx base and calldata cost
+	PrecompileGasUnits uint64
+	// EvmTxUnits is the gas of the engine tx without the validation gas
+	EvmTxUnits uint64
+	// TotalTxUnits is the gas billed for the engine tx, including the validation gas
+	TotalTxUnits uint64
+}
+
+// EstimateEngineExecuteGas returns the gas breakdown of the ENGINE_EXECUTE calldata,
+// computed the same way as the precompile gas under the given forks. It fails with ErrMalformedEngineExecute
+// for calldata which doesn't decode, the precompile charges minMalformedExecuteGas for it.
+func EstimateEngineExecuteGas(input []byte, config *chain.ForksInTime) (*EngineGasEstimate, error) {
+	if !isEngineExecuteInput(input) {
+		return nil, ErrNotEngineExecute
+	}
+
+	grant, call, meta, ok := decodeExecuteInput(input)
+	if !ok {
+		return nil, fmt.Errorf("%w, the precompile charges %d gas for it", ErrMalformedEngineExecute, minMalformedExecuteGas)
+	}
+
+	fc := calcFee(input, grant, call, meta, config)
+
+	return &EngineGasEstimate{
+		PrecompileGasUnits: fc.precompileGasUnits(),
+		EvmTxUnits:         fc.evmTxUnits(),
+		TotalTxUnits:       fc.totalTxUnits(),
+	}, nil
+}
+
+func (e *engineExecute) run(input []byte, caller types.Address, host runtime.Host, config *chain.ForksInTime) ([]byte, error) {
 	if len(input) < 4 {
 		return nil, runtime.ErrInvalidInputData
 	}
@@ -285,7 +474,7 @@ func (e *engineExecute) run(input []byte, caller types.Address, host runtime.Hos
 		return encodeBool(used), nil
 	}
 	if bytes.Equal(selector, engineABI.GetMethod("BILL_GRANTS_ONLY").ID()) {
-		return e.billGrantsOnly(input, caller, host)
+		return e.billGrantsOnly(input, caller, host, config)
 	}
 	if !bytes.Equal(selector, engineABI.GetMethod("ENGINE_EXECUTE").ID()) {
 		return nil, runtime.ErrInvalidInputData
@@ -303,7 +492,7 @@ func (e *engineExecute) run(input []byte, caller types.Address, host runtime.Hos
 	call := decodeCall(cv)
 	meta := decodeMeta(mv)
 
-	fc := calcFee(input, grant, call, meta)
+	fc := calcFee(input, grant, call, meta, config)
 
 	// ---- Authorize caller: only the configured Engine EOA may invoke this precompile ----
 	engine, ok := authorizeEngineCaller(host, caller)
@@ -312,12 +501,26 @@ func (e *engineExecute) run(input []byte, caller types.Address, host runtime.Hos
 	}
 	user := types.Address(grant.From) // kept for downstream logic; grant.Engine is ignored for auth
 
+	// replay protection: the grant must be signed for this chain (before any state change)
+	if config.EngineGrantChainID {
+		if err := checkGrantChainID(grant, host.GetTxContext().ChainID, grantChainIDRequired(host)); err != nil {
+			return nil, err
+		}
+	}
+
+	// an expired grant is not billable (before any fee transfer, kNext update or preflight)
+	if config.EngineGrantExpiry {
+		if err := checkGrantExpiry(grant, uint64(host.GetTxContext().Timestamp)); err != nil {
+			return nil, err
+		}
+	}
+
 	if call.GrantFeeSeconds > 0 {
 		fee, err := billGrants(host, user, engine, call.GrantFeeSeconds, call.GrantFeePerYearWei)
 		if err != nil {
 			return nil, err
 		}
-		logGrantFeeCharged(host, user, engine, call.GrantFeeSeconds, call.GrantFeePerYearWei, fee)
+		logGrantFeeCharged(host, config, user, engine, call.GrantFeeSeconds, call.GrantFeePerYearWei, fee)
 	}
 
 	if grant.SessionId == nil || grant.SessionId.Sign() < 0 {
@@ -343,10 +546,32 @@ func (e *engineExecute) run(input []byte, caller types.Address, host runtime.Hos
 		// follow-up (< curNext) -> ok
 	}
 
+	newRoot := grant.SessionId.Cmp(curNext) == 0
+
+	// follow-ups must strictly increase the iteration of the session,
+	// before the EngineSessionIteration fork the iteration is logged as given
+	iteration := meta.Iteration
+	if config.EngineSessionIteration {
+		if iteration, err = checkSessionIteration(host, grant, meta.Iteration, newRoot); err != nil {
+			return nil, err
+		}
+	}
+
+	// MaxTotalGas ceiling across all calls of the session (root = sessionId), from the EngineSessionGasCeiling fork
+	if config.EngineSessionGasCeiling {
+		if err := chargeSessionGas(host, grant, fc.totalTxUnits(), newRoot); err != nil {
+			return nil, err
+		}
+	}
+
+	if config.EngineSessionIteration {
+		storeSessionIteration(host, grant, iteration)
+	}
+
 	txTime := uint64(host.GetTxContext().Timestamp)
 
 	// **SOFORT** persistieren, wenn dies ein neuer Root ist (sessionId == kNext)
-	if grant.SessionId.Cmp(curNext) == 0 {
+	if newRoot {
 		p1 := new(big.Int).Add(grant.SessionId, big.NewInt(1))
 		sstoreU256(host, kNext(grant.From), p1) // eine Wahrheit: next = current+1
 	}
@@ -412,6 +637,9 @@ func (e *engineExecute) run(input []byte, caller types.Address, host runtime.Hos
 	success := false
 	if call.GasLimit > 0 && (call.To != (ethgo.Address{})) {
 		code := host.GetCode(types.Address(call.To))
+		if config.EngineWarmInnerCall {
+			warmInnerCall(host, user, types.Address(call.To))
+		}
 		contract := runtime.NewContractCall(
 			1,
 			user,
@@ -431,7 +659,7 @@ func (e *engineExecute) run(input []byte, caller types.Address, host runtime.Hos
 	// WICHTIG: Im Meta-Event die ROOT-ID (sessionId) loggen, NICHT die Node-PID
 	metaData, _ := engineMetaEvent.Inputs.Encode([]interface{}{
 		grant.SessionId,    // processId-Feld trägt jetzt die rootId (Session)
-		meta.Iteration,     // iteration
+		iteration,          // iteration (enforced from the EngineSessionIteration fork, 0 for a new root)
 		grant.XRC729,       // orchestration
 		grant.OstcId,       // ostcId
 		grant.OstcHash,     // ostcHash
@@ -454,6 +682,14 @@ func (e *engineExecute) run(input []byte, caller types.Address, host runtime.Hos
 	evmUnits := fc.evmTxUnits()
 	totalUnits := fc.totalTxUnits()
 
+	// refundOnFailurePolicy: schlägt der innere CALL fehl, werden nur Validation- und
+	// EVM-Basis-Units erstattet, der execLimit-Anteil verbleibt beim User.
+	// Der Preflight oben deckt weiterhin den Worst-Case inkl. execLimit ab.
+	if !success && refundOnFailurePolicy(host) {
+		evmUnits -= fc.execLimit
+		totalUnits -= fc.execLimit
+	}
+
 	// Erstattung deckungsgleich zur SSOT-Aufteilung:
 	//   Feld 3: EVM-Refund (ohne Validation)
 	//   Feld 4: Validation-Wei (nur Breakdown)
@@ -496,8 +732,28 @@ func (e *engineExecute) run(input []byte, caller types.Address, host runtime.Hos
 	return out, nil
 }
 
+// warmInnerCall adds the user and the target of the inner call to the access list of the transaction,
+// so accessing them from the inner call is priced warm (EIP-2929). Storage slots are not warmed,
+// they are cold unless the engine tx lists them in its own access list.
+// The precompiles and the coinbase are warm from the start of the transaction already
+func warmInnerCall(host runtime.Host, user, to types.Address) {
+	accessList := host.GetTxContext().AccessList
+	if accessList == nil {
+		// EIP-2929 is not active
+		return
+	}
+
+	accessList.AddAddress(user)
+	accessList.AddAddress(to)
+}
+
 // BILL_GRANTS_ONLY selector handler
-func (e *engineExecute) billGrantsOnly(input []byte, caller types.Address, host runtime.Host) ([]byte, error) {
+func (e *engineExecute) billGrantsOnly(
+	input []byte,
+	caller types.Address,
+	host runtime.Host,
+	config *chain.ForksInTime,
+) ([]byte, error) {
 	engine, ok := authorizeEngineCaller(host, caller)
 	if !ok {
 		return nil, runtime.ErrUnauthorizedCaller
@@ -517,7 +773,7 @@ func (e *engineExecute) billGrantsOnly(input []byte, caller types.Address, host
 	if err != nil {
 		return nil, err
 	}
-	logGrantFeeCharged(host, payer, engine, seconds, perYearWei, fee)
+	logGrantFeeCharged(host, config, payer, engine, seconds, perYearWei, fee)
 
 	return m.Outputs.Encode([]interface{}{fee})
 }
@@ -539,8 +795,17 @@ func billGrants(host runtime.Host, payer, engine types.Address, seconds uint64,
 	return fee, nil
 }
 
-func logGrantFeeCharged(host runtime.Host, payer, engine types.Address, seconds uint64, perYearWei, fee *big.Int) {
-	payload, err := ethabi.MustNewType("tuple(address,address,uint64,uint256,uint256)").Encode([]interface{}{
+// logGrantFeeCharged emits the GrantFeeCharged event. Before the GrantFeeChargedEvent fork
+// the same data is logged without topics (anonymous)
+func logGrantFeeCharged(
+	host runtime.Host,
+	config *chain.ForksInTime,
+	payer, engine types.Address,
+	seconds uint64,
+	perYearWei, fee *big.Int,
+) {
+	// alle Felder nicht-indexiert: Daten identisch zur bisherigen tuple-Kodierung
+	payload, err := grantFeeChargedEvent.Inputs.Encode([]interface{}{
 		payer,
 		engine,
 		new(big.Int).SetUint64(seconds),
@@ -550,7 +815,15 @@ func logGrantFeeCharged(host runtime.Host, payer, engine types.Address, seconds
 	if err != nil {
 		return
 	}
-	host.EmitLog(contracts.EngineExecutePrecompile, nil, payload)
+
+	var topics []types.Hash
+
+	if config.GrantFeeChargedEvent {
+		id := grantFeeChargedEvent.ID()
+		topics = []types.Hash{types.BytesToHash(id[:])}
+	}
+
+	host.EmitLog(contracts.EngineExecutePrecompile, topics, payload)
 }
 
 // ---------- arithmetische Längen-Helfer (exakt, ohne Encode) ----------------
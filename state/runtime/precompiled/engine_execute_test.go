@@ -0,0 +1,824 @@
+package precompiled
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+	ethabi "github.com/umbracle/ethgo/abi"
+
+	"github.com/xgr-network/xgr-node/chain"
+	"github.com/xgr-network/xgr-node/contracts"
+	"github.com/xgr-network/xgr-node/contracts/engineabi"
+	"github.com/xgr-network/xgr-node/state/runtime"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+// engineForks enables every fork gating the engine precompile
+var engineForks = chain.AllForksEnabled.At(0)
+
+// registryHost serves the code and storage of the EngineRegistry only
+type registryHost struct {
+	runtime.Host
+
+	code    map[types.Address][]byte
+	storage map[types.Hash]types.Hash
+}
+
+func (r *registryHost) GetCode(addr types.Address) []byte {
+	return r.code[addr]
+}
+
+func (r *registryHost) GetStorage(_ types.Address, key types.Hash) types.Hash {
+	return r.storage[key]
+}
+
+func TestCheckGrantChainID(t *testing.T) {
+	t.Parallel()
+
+	const chainID = int64(1879)
+
+	cases := []struct {
+		name     string
+		chainID  *big.Int
+		required bool
+		err      error
+	}{
+		{"matching", big.NewInt(chainID), false, nil},
+		{"matching, required", big.NewInt(chainID), true, nil},
+		{"mismatching", big.NewInt(chainID + 1), false, runtime.ErrEngineWrongChain},
+		{"mismatching, required", big.NewInt(chainID + 1), true, runtime.ErrEngineWrongChain},
+		{"unset", nil, false, nil},
+		{"zero", big.NewInt(0), false, nil},
+		{"unset, required", nil, true, runtime.ErrEngineWrongChain},
+		{"zero, required", big.NewInt(0), true, runtime.ErrEngineWrongChain},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := checkGrantChainID(inGrant{ChainId: c.chainID}, chainID, c.required)
+			if c.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, c.err)
+			}
+		})
+	}
+}
+
+func TestGrantChainIDRequired(t *testing.T) {
+	reg := types.StringToAddress("0x1000")
+
+	prevReg := chain.EngineRegistryAddress
+	t.Cleanup(func() {
+		chain.EngineRegistryAddress = prevReg
+	})
+
+	host := &registryHost{
+		code: map[types.Address][]byte{},
+		storage: map[types.Hash]types.Hash{
+			chain.EngineRegistrySlotKeyRequireGrantChainID(): types.BytesToHash([]byte{1}),
+		},
+	}
+
+	// no registry configured
+	chain.EngineRegistryAddress = types.ZeroAddress
+	require.False(t, grantChainIDRequired(host))
+
+	// registry configured, but not deployed yet
+	chain.EngineRegistryAddress = reg
+	require.False(t, grantChainIDRequired(host))
+
+	host.code[reg] = []byte{0x1}
+	require.True(t, grantChainIDRequired(host))
+
+	// only refundOnFailurePolicy (offset 1 of the shared slot) set
+	host.storage[chain.EngineRegistrySlotKeyRequireGrantChainID()] = types.BytesToHash([]byte{1, 0})
+	require.False(t, grantChainIDRequired(host))
+
+	delete(host.storage, chain.EngineRegistrySlotKeyRequireGrantChainID())
+	require.False(t, grantChainIDRequired(host))
+}
+
+func TestCheckGrantExpiry(t *testing.T) {
+	t.Parallel()
+
+	const txTime = uint64(1_700_000_000)
+
+	cases := []struct {
+		name   string
+		expiry *big.Int
+		err    error
+	}{
+		{"expired", new(big.Int).SetUint64(txTime - 1), runtime.ErrEngineGrantExpired},
+		{"expires at tx time", new(big.Int).SetUint64(txTime), nil},
+		{"not yet expired", new(big.Int).SetUint64(txTime + 1), nil},
+		{"zero", big.NewInt(0), nil},
+		{"unset", nil, nil},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := checkGrantExpiry(inGrant{Expiry: c.expiry}, txTime)
+			if c.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, c.err)
+			}
+		})
+	}
+}
+
+// engineHost records the state accesses of ENGINE_EXECUTE
+type engineHost struct {
+	runtime.Host
+
+	txCtx   runtime.TxContext
+	code    map[types.Address][]byte
+	storage map[types.Hash]types.Hash
+	balance *big.Int
+
+	// callErr is the error of the inner call
+	callErr error
+
+	storageWrite bool
+	balanceRead  bool
+	transfer     bool
+	lastTransfer *big.Int
+	logs         []*types.Log
+}
+
+func newEngineHost(txTime int64, balance *big.Int) *engineHost {
+	return &engineHost{
+		txCtx: runtime.TxContext{
+			Timestamp: txTime,
+			GasPrice:  types.BytesToHash([]byte{1}),
+		},
+		code:    map[types.Address][]byte{},
+		storage: map[types.Hash]types.Hash{},
+		balance: balance,
+	}
+}
+
+func (e *engineHost) GetTxContext() runtime.TxContext {
+	return e.txCtx
+}
+
+func (e *engineHost) GetCode(addr types.Address) []byte {
+	return e.code[addr]
+}
+
+func (e *engineHost) GetStorage(_ types.Address, key types.Hash) types.Hash {
+	return e.storage[key]
+}
+
+func (e *engineHost) SetStorage(
+	_ types.Address, key types.Hash, value types.Hash, _ *chain.ForksInTime) runtime.StorageStatus {
+	e.storageWrite = true
+	e.storage[key] = value
+
+	return runtime.StorageModified
+}
+
+func (e *engineHost) GetBalance(types.Address) *big.Int {
+	e.balanceRead = true
+
+	return e.balance
+}
+
+func (e *engineHost) Transfer(_ types.Address, _ types.Address, amount *big.Int) error {
+	e.transfer = true
+	e.lastTransfer = amount
+
+	return nil
+}
+
+func (e *engineHost) Callx(c *runtime.Contract, _ runtime.Host) *runtime.ExecutionResult {
+	return &runtime.ExecutionResult{GasUsed: c.Gas / 2, Err: e.callErr}
+}
+
+func (e *engineHost) EmitLog(addr types.Address, topics []types.Hash, data []byte) {
+	e.logs = append(e.logs, &types.Log{Address: addr, Topics: topics, Data: data})
+}
+
+// engineExecuteInput encodes a log-only ENGINE_EXECUTE of user 0x1 with the given grant fields and iteration
+func engineExecuteInput(
+	t *testing.T, engine types.Address, expiry, maxTotalGas, sessionID *big.Int, iteration uint64) []byte {
+	t.Helper()
+
+	input, err := engineABI.GetMethod("ENGINE_EXECUTE").Encode(
+		engineExecuteArgs(engine, expiry, maxTotalGas, sessionID, iteration))
+	require.NoError(t, err)
+
+	return input
+}
+
+// engineExecuteArgs returns the arguments of a log-only ENGINE_EXECUTE of user 0x1
+func engineExecuteArgs(
+	engine types.Address, expiry, maxTotalGas, sessionID *big.Int, iteration uint64) map[string]interface{} {
+	return map[string]interface{}{
+		"grant": map[string]interface{}{
+			"from":        types.StringToAddress("0x1"),
+			"engine":      engine,
+			"xrc729":      types.ZeroAddress,
+			"ostcId":      "",
+			"ostcHash":    [32]byte{},
+			"processId":   big.NewInt(1),
+			"maxTotalGas": maxTotalGas,
+			"expiry":      expiry,
+			"sessionId":   sessionID,
+			"chainId":     big.NewInt(0),
+		},
+		"call": map[string]interface{}{
+			"to":                 types.ZeroAddress,
+			"data":               []byte{},
+			"valueWei":           big.NewInt(0),
+			"gasLimit":           uint64(0),
+			"validationGas":      uint64(0),
+			"maxFeePerGas":       big.NewInt(1),
+			"deadline":           uint64(0),
+			"grantFeeSeconds":    uint64(3600),
+			"grantFeePerYearWei": big.NewInt(31_536_000),
+		},
+		"meta": map[string]interface{}{
+			"iteration":     iteration,
+			"stepId":        "",
+			"ruleContract":  types.ZeroAddress,
+			"ruleHash":      [32]byte{},
+			"payload":       []byte{},
+			"apiSaves":      []byte{},
+			"contractSaves": []byte{},
+			"extras":        []byte{},
+		},
+	}
+}
+
+// setBootstrapEngine authorizes engine as the bootstrap engine EOA for the duration of the test
+func setBootstrapEngine(t *testing.T, engine types.Address) {
+	t.Helper()
+
+	prevReg, prevEOA := chain.EngineRegistryAddress, chain.BootstrapEngineEOA
+	t.Cleanup(func() {
+		chain.EngineRegistryAddress, chain.BootstrapEngineEOA = prevReg, prevEOA
+	})
+
+	chain.EngineRegistryAddress = types.ZeroAddress
+	chain.BootstrapEngineEOA = engine
+}
+
+func TestEngineExecute_GrantExpiry(t *testing.T) {
+	const txTime = int64(1_700_000_000)
+
+	engine := types.StringToAddress("0xe0")
+	setBootstrapEngine(t, engine)
+
+	// the user has no balance, so an execute passing the expiry guard fails the preflight
+	executeWith := func(t *testing.T, expiry *big.Int, config *chain.ForksInTime) (*engineHost, error) {
+		t.Helper()
+
+		host := newEngineHost(txTime, big.NewInt(0))
+		_, err := (&engineExecute{}).run(
+			engineExecuteInput(t, engine, expiry, big.NewInt(0), big.NewInt(1), 0), engine, host, config)
+
+		return host, err
+	}
+
+	execute := func(t *testing.T, expiry *big.Int) (*engineHost, error) {
+		t.Helper()
+
+		return executeWith(t, expiry, &engineForks)
+	}
+
+	t.Run("expired", func(t *testing.T) {
+		host, err := execute(t, big.NewInt(txTime-1))
+		require.ErrorIs(t, err, runtime.ErrEngineGrantExpired)
+
+		// rejected before the grant fee, the kNext update and the preflight
+		assert.False(t, host.transfer)
+		assert.False(t, host.storageWrite)
+		assert.False(t, host.balanceRead)
+	})
+
+	t.Run("expired before the fork", func(t *testing.T) {
+		legacy := engineForks
+		legacy.EngineGrantExpiry = false
+
+		// the expiry is not checked, the execute goes on to the preflight
+		host, err := executeWith(t, big.NewInt(txTime-1), &legacy)
+		require.ErrorIs(t, err, runtime.ErrNotEnoughFunds)
+		assert.True(t, host.transfer)
+	})
+
+	for name, expiry := range map[string]*big.Int{
+		"not yet expired": big.NewInt(txTime + 1),
+		"zero expiry":     big.NewInt(0),
+	} {
+		expiry := expiry
+
+		t.Run(name, func(t *testing.T) {
+			host, err := execute(t, expiry)
+			require.ErrorIs(t, err, runtime.ErrNotEnoughFunds)
+
+			assert.True(t, host.transfer)
+			assert.True(t, host.storageWrite)
+			assert.True(t, host.balanceRead)
+		})
+	}
+}
+
+func TestChargeSessionGas(t *testing.T) {
+	t.Parallel()
+
+	const units = uint64(30_000)
+
+	grant := func(maxTotalGas uint64, sessionID int64) inGrant {
+		return inGrant{
+			From:        ethgo.Address(types.StringToAddress("0x1")),
+			MaxTotalGas: new(big.Int).SetUint64(maxTotalGas),
+			SessionId:   big.NewInt(sessionID),
+		}
+	}
+
+	// runSession charges a root call and two follow-ups, returning the error of each call
+	runSession := func(maxTotalGas uint64) []error {
+		host := newEngineHost(0, nil)
+		errs := make([]error, 3)
+
+		for i := range errs {
+			errs[i] = chargeSessionGas(host, grant(maxTotalGas, 1), units, i == 0)
+		}
+
+		return errs
+	}
+
+	t.Run("under the cap", func(t *testing.T) {
+		t.Parallel()
+
+		for _, err := range runSession(3*units + 1) {
+			assert.NoError(t, err)
+		}
+	})
+
+	t.Run("exactly hits the cap", func(t *testing.T) {
+		t.Parallel()
+
+		for _, err := range runSession(3 * units) {
+			assert.NoError(t, err)
+		}
+	})
+
+	t.Run("exceeds the cap on the third call", func(t *testing.T) {
+		t.Parallel()
+
+		errs := runSession(3*units - 1)
+
+		assert.NoError(t, errs[0])
+		assert.NoError(t, errs[1])
+		assert.ErrorIs(t, errs[2], runtime.ErrEngineMaxTotalGas)
+	})
+
+	t.Run("zero cap", func(t *testing.T) {
+		t.Parallel()
+
+		for _, err := range runSession(0) {
+			assert.NoError(t, err)
+		}
+	})
+
+	t.Run("new root session resets the accumulation", func(t *testing.T) {
+		t.Parallel()
+
+		host := newEngineHost(0, nil)
+
+		require.NoError(t, chargeSessionGas(host, grant(units, 1), units, true))
+		require.ErrorIs(t, chargeSessionGas(host, grant(units, 1), units, false), runtime.ErrEngineMaxTotalGas)
+
+		// a new root, even one reusing a stale session key, starts from zero
+		require.NoError(t, chargeSessionGas(host, grant(units, 2), units, true))
+		require.NoError(t, chargeSessionGas(host, grant(units, 1), units, true))
+	})
+}
+
+func TestEngineExecute_MaxTotalGas(t *testing.T) {
+	engine := types.StringToAddress("0xe0")
+	setBootstrapEngine(t, engine)
+
+	balance, _ := new(big.Int).SetString("1000000000000000000", 10)
+	host := newEngineHost(0, balance)
+
+	execute := func(maxTotalGas, sessionID int64, iteration uint64) error {
+		_, err := (&engineExecute{}).run(
+			engineExecuteInput(t, engine, big.NewInt(0), big.NewInt(maxTotalGas), big.NewInt(sessionID), iteration),
+			engine, host, &engineForks)
+
+		return err
+	}
+
+	sessionGas := func(sessionID int64) (types.Hash, bool) {
+		v, ok := host.storage[contracts.EngineSessionGasSlotKey(types.StringToAddress("0x1"), big.NewInt(sessionID))]
+
+		return v, ok
+	}
+
+	// a single execute bills more than one unit
+	require.ErrorIs(t, execute(1, 1, 0), runtime.ErrEngineMaxTotalGas)
+
+	// a follow-up with a ceiling below the total billed in the session is rejected
+	require.NoError(t, execute(100_000, 1, 0))
+	require.ErrorIs(t, execute(100_000, 1, 1), runtime.ErrEngineMaxTotalGas)
+
+	// while a new root session starts from zero
+	require.NoError(t, execute(100_000, 2, 0))
+
+	// without a ceiling the session is unlimited and its gas is not stored
+	require.NoError(t, execute(0, 3, 0))
+	require.NoError(t, execute(0, 3, 1))
+
+	_, ok := sessionGas(3)
+	assert.False(t, ok)
+
+	t.Run("before the fork", func(t *testing.T) {
+		legacy := engineForks
+		legacy.EngineSessionGasCeiling = false
+
+		_, err := (&engineExecute{}).run(
+			engineExecuteInput(t, engine, big.NewInt(0), big.NewInt(1), big.NewInt(4), 0), engine, host, &legacy)
+		require.NoError(t, err)
+
+		_, ok := sessionGas(4)
+		assert.False(t, ok)
+	})
+}
+
+func TestEngineExecute_RefundOnFailurePolicy(t *testing.T) {
+	const execLimit = uint64(50_000)
+
+	reg := types.StringToAddress("0x1000")
+	engine := types.StringToAddress("0xe0")
+	target := types.StringToAddress("0x2000")
+
+	setBootstrapEngine(t, types.ZeroAddress)
+	chain.EngineRegistryAddress = reg
+
+	args := engineExecuteArgs(engine, big.NewInt(0), big.NewInt(0), big.NewInt(1), 0)
+	args["call"].(map[string]interface{})["to"] = target
+	args["call"].(map[string]interface{})["gasLimit"] = execLimit
+	args["call"].(map[string]interface{})["validationGas"] = uint64(10_000)
+
+	input, err := engineABI.GetMethod("ENGINE_EXECUTE").Encode(args)
+	require.NoError(t, err)
+
+	balance, _ := new(big.Int).SetString("1000000000000000000", 10)
+
+	// execute returns the refund paid to the engine for the execute
+	execute := func(t *testing.T, callErr error, policy bool) *big.Int {
+		t.Helper()
+
+		host := newEngineHost(0, balance)
+		host.code[reg] = []byte{0x1}
+		host.code[target] = []byte{0x1}
+		host.storage[chain.EngineRegistrySlotKeyAuthorizedEngine(engine)] = types.BytesToHash([]byte{1})
+		host.callErr = callErr
+
+		if policy {
+			host.storage[chain.EngineRegistrySlotKeyRefundOnFailurePolicy()] = types.BytesToHash([]byte{1, 0})
+		}
+
+		_, err := (&engineExecute{}).run(input, engine, host, &engineForks)
+		require.NoError(t, err)
+
+		return host.lastTransfer
+	}
+
+	full := execute(t, nil, false)
+
+	// a successful call is billed in full under both policies
+	assert.Equal(t, full, execute(t, nil, true))
+
+	// a failed call is billed in full without the policy
+	assert.Equal(t, full, execute(t, runtime.ErrExecutionReverted, false))
+
+	// with the policy, the execLimit of a failed call stays with the user (at a gas price of 1 wei)
+	assert.Equal(t,
+		new(big.Int).Sub(full, new(big.Int).SetUint64(execLimit)),
+		execute(t, runtime.ErrExecutionReverted, true))
+}
+
+func TestLogGrantFeeCharged(t *testing.T) {
+	t.Parallel()
+
+	payer := types.StringToAddress("0x1")
+	engine := types.StringToAddress("0x2")
+
+	emit := func(config chain.ForksInTime) *types.Log {
+		host := newEngineHost(0, big.NewInt(0))
+		logGrantFeeCharged(host, &config, payer, engine, 3600, big.NewInt(31_536_000), big.NewInt(3600))
+
+		require.Len(t, host.logs, 1)
+		assert.Equal(t, contracts.EngineExecutePrecompile, host.logs[0].Address)
+
+		return host.logs[0]
+	}
+
+	// before the fork the log has no topics
+	legacy := engineForks
+	legacy.GrantFeeChargedEvent = false
+
+	legacyLog := emit(legacy)
+	assert.Empty(t, legacyLog.Topics)
+
+	log := emit(engineForks)
+
+	event := ethabi.MustNewABI(engineabi.GrantFeeChargedEventABI).Events["GrantFeeCharged"]
+	id := event.ID()
+	assert.Equal(t, []types.Hash{types.BytesToHash(id[:])}, log.Topics)
+	assert.Equal(t, legacyLog.Data, log.Data)
+
+	vals, err := event.Inputs.Decode(log.Data)
+	require.NoError(t, err)
+
+	args, ok := vals.(map[string]interface{})
+	require.True(t, ok)
+
+	assert.Equal(t, ethgo.Address(payer), args["payer"])
+	assert.Equal(t, ethgo.Address(engine), args["engine"])
+	assert.Equal(t, uint64(3600), args["seconds"])
+	assert.Equal(t, big.NewInt(31_536_000), args["perYearWei"])
+	assert.Equal(t, big.NewInt(3600), args["fee"])
+
+	// the data is encoded as before the event had a topic
+	data, err := ethabi.MustNewType("tuple(address,address,uint64,uint256,uint256)").Encode([]interface{}{
+		payer, engine, big.NewInt(3600), big.NewInt(31_536_000), big.NewInt(3600),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, data, log.Data)
+}
+
+func TestCheckSessionIteration(t *testing.T) {
+	t.Parallel()
+
+	grant := inGrant{
+		From:      ethgo.Address(types.StringToAddress("0x1")),
+		SessionId: big.NewInt(1),
+	}
+
+	// advance runs a follow-up with the given iteration and records it when accepted
+	advance := func(host *engineHost, iteration uint64) error {
+		enforced, err := checkSessionIteration(host, grant, iteration, false)
+		if err != nil {
+			return err
+		}
+
+		require.Equal(t, iteration, enforced)
+		storeSessionIteration(host, grant, enforced)
+
+		return nil
+	}
+
+	// newSession creates a root session, which resets the iteration to 0
+	newSession := func(t *testing.T, iteration uint64) *engineHost {
+		t.Helper()
+
+		host := newEngineHost(0, nil)
+
+		enforced, err := checkSessionIteration(host, grant, iteration, true)
+		require.NoError(t, err)
+		require.Zero(t, enforced)
+		storeSessionIteration(host, grant, enforced)
+
+		return host
+	}
+
+	t.Run("in order", func(t *testing.T) {
+		t.Parallel()
+
+		host := newSession(t, 0)
+
+		for i := uint64(1); i <= 3; i++ {
+			assert.NoError(t, advance(host, i))
+		}
+	})
+
+	t.Run("replayed", func(t *testing.T) {
+		t.Parallel()
+
+		host := newSession(t, 0)
+
+		require.NoError(t, advance(host, 1))
+		require.NoError(t, advance(host, 2))
+
+		assert.ErrorIs(t, advance(host, 2), runtime.ErrEngineIterationOrder)
+		assert.ErrorIs(t, advance(host, 1), runtime.ErrEngineIterationOrder)
+		assert.ErrorIs(t, advance(host, 0), runtime.ErrEngineIterationOrder)
+	})
+
+	t.Run("skipped", func(t *testing.T) {
+		t.Parallel()
+
+		host := newSession(t, 0)
+
+		require.NoError(t, advance(host, 5))
+
+		// the skipped iterations can not be appended afterwards
+		assert.ErrorIs(t, advance(host, 3), runtime.ErrEngineIterationOrder)
+		assert.NoError(t, advance(host, 6))
+	})
+
+	t.Run("new root resets the iteration", func(t *testing.T) {
+		t.Parallel()
+
+		host := newSession(t, 7)
+
+		require.NoError(t, advance(host, 3))
+		require.ErrorIs(t, advance(host, 3), runtime.ErrEngineIterationOrder)
+
+		_, err := checkSessionIteration(host, grant, 3, true)
+		require.NoError(t, err)
+		storeSessionIteration(host, grant, 0)
+
+		assert.NoError(t, advance(host, 1))
+	})
+
+	t.Run("untracked session accepts the first follow-up", func(t *testing.T) {
+		t.Parallel()
+
+		// no root was recorded, as for sessions created before the iteration was tracked
+		host := newEngineHost(0, nil)
+
+		require.NoError(t, advance(host, 0))
+		assert.ErrorIs(t, advance(host, 0), runtime.ErrEngineIterationOrder)
+		assert.NoError(t, advance(host, 1))
+	})
+}
+
+func TestEngineExecute_GrantChainID(t *testing.T) {
+	engine := types.StringToAddress("0xe0")
+	setBootstrapEngine(t, engine)
+
+	// a grant signed for another chain, the user has no balance to pass the preflight
+	args := engineExecuteArgs(engine, big.NewInt(0), big.NewInt(0), big.NewInt(1), 0)
+	args["grant"].(map[string]interface{})["chainId"] = big.NewInt(101) //nolint:forcetypeassert
+
+	input, err := engineABI.GetMethod("ENGINE_EXECUTE").Encode(args)
+	require.NoError(t, err)
+
+	execute := func(config *chain.ForksInTime) (*engineHost, error) {
+		host := newEngineHost(0, big.NewInt(0))
+		host.txCtx.ChainID = 100
+
+		_, err := (&engineExecute{}).run(input, engine, host, config)
+
+		return host, err
+	}
+
+	host, err := execute(&engineForks)
+	require.ErrorIs(t, err, runtime.ErrEngineWrongChain)
+	assert.False(t, host.storageWrite)
+
+	// the chainId is not checked before the fork
+	legacy := engineForks
+	legacy.EngineGrantChainID = false
+
+	_, err = execute(&legacy)
+	require.ErrorIs(t, err, runtime.ErrNotEnoughFunds)
+}
+
+func TestEngineExecute_SessionIteration(t *testing.T) {
+	engine := types.StringToAddress("0xe0")
+	setBootstrapEngine(t, engine)
+
+	balance, _ := new(big.Int).SetString("1000000000000000000", 10)
+	host := newEngineHost(0, balance)
+
+	execute := func(sessionID int64, iteration uint64) error {
+		_, err := (&engineExecute{}).run(
+			engineExecuteInput(t, engine, big.NewInt(0), big.NewInt(0), big.NewInt(sessionID), iteration), engine, host, &engineForks)
+
+		return err
+	}
+
+	require.NoError(t, execute(1, 4))
+	require.NoError(t, execute(1, 1))
+	require.NoError(t, execute(1, 3))
+
+	// a replayed step is rejected before it is charged to the session
+	sessionGas := contracts.EngineSessionGasSlotKey(types.StringToAddress("0x1"), big.NewInt(1))
+	charged := host.storage[sessionGas]
+
+	require.ErrorIs(t, execute(1, 3), runtime.ErrEngineIterationOrder)
+	assert.Equal(t, charged, host.storage[sessionGas])
+
+	require.NoError(t, execute(2, 0))
+	require.NoError(t, execute(2, 1))
+	require.NoError(t, execute(1, 4))
+}
+
+func TestEngineExecute_SessionIterationBeforeFork(t *testing.T) {
+	engine := types.StringToAddress("0xe0")
+	setBootstrapEngine(t, engine)
+
+	legacy := engineForks
+	legacy.EngineSessionIteration = false
+
+	balance, _ := new(big.Int).SetString("1000000000000000000", 10)
+	host := newEngineHost(0, balance)
+
+	execute := func(sessionID int64, iteration uint64) {
+		_, err := (&engineExecute{}).run(
+			engineExecuteInput(t, engine, big.NewInt(0), big.NewInt(0), big.NewInt(sessionID), iteration), engine, host, &legacy)
+		require.NoError(t, err)
+	}
+
+	// replayed and decreasing iterations are accepted and nothing is tracked
+	execute(1, 4)
+	execute(1, 3)
+	execute(1, 3)
+
+	_, ok := host.storage[contracts.EngineSessionIterationSlotKey(types.StringToAddress("0x1"), big.NewInt(1))]
+	assert.False(t, ok)
+
+	// the iteration is logged as given
+	vals, err := engineMetaEvent.Inputs.Decode(host.logs[len(host.logs)-2].Data)
+	require.NoError(t, err)
+
+	args, ok := vals.(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, uint64(3), args["iteration"])
+}
+
+func TestEstimateEngineExecuteGas(t *testing.T) {
+	input := engineExecuteInput(t, types.StringToAddress("0xe"), big.NewInt(0), big.NewInt(0), big.NewInt(7), 3)
+
+	grant, call, meta, ok := decodeExecuteInput(input)
+	require.True(t, ok)
+
+	fc := calcFee(input, grant, call, meta, &engineForks)
+
+	estimate, err := EstimateEngineExecuteGas(input, &engineForks)
+	require.NoError(t, err)
+	assert.Equal(t, &EngineGasEstimate{
+		PrecompileGasUnits: fc.precompileGasUnits(),
+		EvmTxUnits:         fc.evmTxUnits(),
+		TotalTxUnits:       fc.totalTxUnits(),
+	}, estimate)
+
+	// the estimate matches the gas charged by the precompile
+	assert.Equal(t, (&engineExecute{}).gas(input, &engineForks), estimate.PrecompileGasUnits)
+
+	t.Run("before the session iteration fork", func(t *testing.T) {
+		legacy := engineForks
+		legacy.EngineSessionIteration = false
+
+		legacyEstimate, err := EstimateEngineExecuteGas(input, &legacy)
+		require.NoError(t, err)
+		assert.Equal(t, estimate.PrecompileGasUnits-engineIterationStoreGas, legacyEstimate.PrecompileGasUnits)
+		assert.Equal(t, estimate.TotalTxUnits-engineIterationStoreGas, legacyEstimate.TotalTxUnits)
+		assert.Equal(t, (&engineExecute{}).gas(input, &legacy), legacyEstimate.PrecompileGasUnits)
+	})
+
+	t.Run("session gas ceiling", func(t *testing.T) {
+		capped := engineExecuteInput(t, types.StringToAddress("0xe"), big.NewInt(0), big.NewInt(1_000_000), big.NewInt(7), 3)
+
+		cappedEstimate, err := EstimateEngineExecuteGas(capped, &engineForks)
+		require.NoError(t, err)
+
+		// the SSTORE of the session gas is billed from the fork, for grants with a ceiling only
+		legacy := engineForks
+		legacy.EngineSessionGasCeiling = false
+
+		legacyEstimate, err := EstimateEngineExecuteGas(capped, &legacy)
+		require.NoError(t, err)
+		assert.Equal(t, cappedEstimate.PrecompileGasUnits-engineSessionGasStoreGas, legacyEstimate.PrecompileGasUnits)
+		assert.Equal(t, cappedEstimate.EvmTxUnits-engineSessionGasStoreGas, legacyEstimate.EvmTxUnits)
+
+		uncapped, err := EstimateEngineExecuteGas(input, &legacy)
+		require.NoError(t, err)
+		assert.Equal(t, estimate, uncapped)
+	})
+
+	t.Run("malformed calldata", func(t *testing.T) {
+		malformed := input[:4+32]
+
+		_, err := EstimateEngineExecuteGas(malformed, &engineForks)
+		assert.ErrorIs(t, err, ErrMalformedEngineExecute)
+		assert.Equal(t, minMalformedExecuteGas, (&engineExecute{}).gas(malformed, &engineForks))
+	})
+
+	t.Run("other calldata", func(t *testing.T) {
+		_, err := EstimateEngineExecuteGas([]byte{0x1, 0x2, 0x3, 0x4}, &engineForks)
+		assert.ErrorIs(t, err, ErrNotEngineExecute)
+
+		_, err = EstimateEngineExecuteGas(nil, &engineForks)
+		assert.ErrorIs(t, err, ErrNotEngineExecute)
+	})
+}
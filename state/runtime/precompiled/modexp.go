@@ -139,7 +139,7 @@ func (m *modExp) gas(input []byte, config *chain.ForksInTime) uint64 {
 	return gasCost.Uint64()
 }
 
-func (m *modExp) run(input []byte, _ types.Address, _ runtime.Host) ([]byte, error) {
+func (m *modExp) run(input []byte, _ types.Address, _ runtime.Host, _ *chain.ForksInTime) ([]byte, error) {
 	// get the lengths
 	var baseLen, exponentLen, modulusLen uint64
 
@@ -15,7 +15,7 @@ func (c *nativeTransfer) gas(input []byte, _ *chain.ForksInTime) uint64 {
 	return 21000
 }
 
-func (c *nativeTransfer) run(input []byte, caller types.Address, host runtime.Host) ([]byte, error) {
+func (c *nativeTransfer) run(input []byte, caller types.Address, host runtime.Host, _ *chain.ForksInTime) ([]byte, error) {
 	if len(input) < 96 {
 		return abiBoolFalse, runtime.ErrInvalidInputData
 	}
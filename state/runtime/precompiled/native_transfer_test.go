@@ -24,13 +24,13 @@ func Test_NativeTransferPrecompile(t *testing.T) {
 		input, err := abiType.Encode([]interface{}{from, to, amount})
 		require.NoError(t, err)
 
-		_, err = contract.run(input, caller, host)
+		_, err = contract.run(input, caller, host, nil)
 
 		return err
 	}
 
 	t.Run("Invalid input", func(t *testing.T) {
-		_, err := contract.run([]byte{}, types.Address{}, nil)
+		_, err := contract.run([]byte{}, types.Address{}, nil, nil)
 		require.ErrorIs(t, err, runtime.ErrInvalidInputData)
 	})
 	t.Run("Caller not authorized", func(t *testing.T) {
@@ -149,6 +149,16 @@ func (d dummyHost) GetBlockHash(number int64) types.Hash {
 	return types.ZeroHash
 }
 
+func (d dummyHost) GetTransientStorage(addr types.Address, key types.Hash) types.Hash {
+	d.t.Fatalf("GetTransientStorage is not implemented")
+
+	return types.ZeroHash
+}
+
+func (d dummyHost) SetTransientStorage(addr types.Address, key types.Hash, value types.Hash) {
+	d.t.Fatalf("SetTransientStorage is not implemented")
+}
+
 func (d dummyHost) EmitLog(addr types.Address, topics []types.Hash, data []byte) {
 	d.t.Fatalf("EmitLog is not implemented")
 }
@@ -38,7 +38,13 @@ func init() {
 
 type contract interface {
 	gas(input []byte, config *chain.ForksInTime) uint64
-	run(input []byte, caller types.Address, host runtime.Host) ([]byte, error)
+	run(input []byte, caller types.Address, host runtime.Host, config *chain.ForksInTime) ([]byte, error)
+}
+
+// stateWriter is implemented by the contracts changing the state,
+// writes reports whether the call with the input changes it
+type stateWriter interface {
+	writes(input []byte) bool
 }
 
 // Precompiled is the runtime for the precompiled contracts
@@ -81,6 +87,24 @@ func (p *Precompiled) setupContracts() {
 
 	// ENGINE_EXECUTE (XDaLa)
 	p.register(contracts.EngineExecutePrecompile.String(), &engineExecute{p})
+
+	// Validator reward address registry
+	p.register(contracts.RewardAddressPrecompile.String(), &rewardAddress{})
+
+	// Donation percent vote of the validators, disabled until configured
+	p.register(contracts.DonationGovernancePrecompile.String(), &donationGovernance{})
+}
+
+// SetDonationGovernance configures the donation percent vote. The validators returned by
+// validators approve the proposals, without a config no proposal can be made
+func (p *Precompiled) SetDonationGovernance(config *chain.DonationGovernanceConfig, validators ValidatorsFunc) {
+	p.register(contracts.DonationGovernancePrecompile.String(), &donationGovernance{config, validators})
+}
+
+// UnregisterEngine removes the ENGINE_EXECUTE precompile,
+// calls to its address run as calls to an account without code
+func (p *Precompiled) UnregisterEngine() {
+	delete(p.contracts, contracts.EngineExecutePrecompile)
 }
 
 func (p *Precompiled) register(addrStr string, b contract) {
@@ -123,6 +147,14 @@ func (p *Precompiled) CanRun(c *runtime.Contract, _ runtime.Host, config *chain.
 		return config.Istanbul
 	}
 
+	if c.CodeAddress == contracts.RewardAddressPrecompile {
+		return config.RewardAddress
+	}
+
+	if c.CodeAddress == contracts.DonationGovernancePrecompile {
+		return config.DonationVote
+	}
+
 	return true
 }
 
@@ -145,7 +177,18 @@ func (p *Precompiled) Run(c *runtime.Contract, host runtime.Host, config *chain.
 	}
 
 	c.Gas = c.Gas - gasCost
-	returnValue, err := contract.run(c.Input, c.Caller, host)
+
+	var (
+		returnValue []byte
+		err         error
+	)
+
+	// the state changing methods can't be called in a static call
+	if w, ok := contract.(stateWriter); ok && c.Static && w.writes(c.Input) {
+		err = runtime.ErrWriteProtection
+	} else {
+		returnValue, err = contract.run(c.Input, c.Caller, host, config)
+	}
 
 	result := &runtime.ExecutionResult{
 		ReturnValue: returnValue,
@@ -0,0 +1,143 @@
+package precompiled
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/umbracle/ethgo"
+	"github.com/xgr-network/xgr-node/chain"
+	"github.com/xgr-network/xgr-node/contracts"
+	"github.com/xgr-network/xgr-node/contracts/abis"
+	"github.com/xgr-network/xgr-node/state/runtime"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+const (
+	// rewardAddressSetGas covers the two storage writes and the log of setRewardAddress
+	rewardAddressSetGas = 50000
+	// rewardAddressReadGas covers the two storage reads of rewardAddressOf
+	rewardAddressReadGas = 5000
+)
+
+var (
+	setRewardAddressMethod = abis.RewardAddressABI.GetMethod("setRewardAddress")
+	rewardAddressOfMethod  = abis.RewardAddressABI.GetMethod("rewardAddressOf")
+	rewardAddressUpdated   = abis.RewardAddressABI.Events["RewardAddressUpdated"]
+)
+
+// rewardAddress is the registry of the addresses credited with the fees of the blocks
+// proposed by a validator. Every update is kept together with the block it was made in,
+// so the consensus can resolve the reward address that was effective for an epoch.
+type rewardAddress struct{}
+
+func (c *rewardAddress) gas(input []byte, _ *chain.ForksInTime) uint64 {
+	if len(input) >= 4 && bytes.Equal(input[:4], rewardAddressOfMethod.ID()) {
+		return rewardAddressReadGas
+	}
+
+	return rewardAddressSetGas
+}
+
+// writes implements the stateWriter interface
+func (c *rewardAddress) writes(input []byte) bool {
+	return len(input) >= 4 && bytes.Equal(input[:4], setRewardAddressMethod.ID())
+}
+
+func (c *rewardAddress) run(input []byte, caller types.Address, host runtime.Host, _ *chain.ForksInTime) ([]byte, error) {
+	if len(input) < 4 {
+		return nil, runtime.ErrInvalidInputData
+	}
+
+	switch {
+	case bytes.Equal(input[:4], setRewardAddressMethod.ID()):
+		return c.setRewardAddress(input[4:], caller, host)
+	case bytes.Equal(input[:4], rewardAddressOfMethod.ID()):
+		return c.rewardAddressOf(input[4:], host)
+	}
+
+	return nil, runtime.ErrInvalidInputData
+}
+
+// setRewardAddress registers the reward address of the caller.
+// A second update within the same block replaces the first one.
+func (c *rewardAddress) setRewardAddress(input []byte, caller types.Address, host runtime.Host) ([]byte, error) {
+	val, err := setRewardAddressMethod.Inputs.Decode(input)
+	if err != nil {
+		return nil, runtime.ErrInvalidInputData
+	}
+
+	args, ok := val.(map[string]interface{})
+	if !ok {
+		return nil, runtime.ErrInvalidInputData
+	}
+
+	addr, ok := args["rewardAddress"].(ethgo.Address)
+	if !ok {
+		return nil, runtime.ErrInvalidInputData
+	}
+
+	blockNumber := uint64(host.GetTxContext().Number)
+	count := rewardAddressCount(host, caller)
+
+	if count > 0 {
+		if _, lastBlock := contracts.DecodeRewardAddressEntry(
+			host.GetStorage(contracts.RewardAddressPrecompile,
+				contracts.RewardAddressEntrySlotKey(caller, count-1))); lastBlock == blockNumber {
+			count--
+		}
+	}
+
+	host.SetStorage(contracts.RewardAddressPrecompile,
+		contracts.RewardAddressEntrySlotKey(caller, count),
+		contracts.EncodeRewardAddressEntry(types.Address(addr), blockNumber), &chain.ForksInTime{})
+	host.SetStorage(contracts.RewardAddressPrecompile,
+		contracts.RewardAddressCountSlotKey(caller),
+		types.BytesToHash(new(big.Int).SetUint64(count+1).Bytes()), &chain.ForksInTime{})
+
+	var data [32]byte
+
+	new(big.Int).SetUint64(blockNumber).FillBytes(data[:])
+
+	host.EmitLog(contracts.RewardAddressPrecompile, []types.Hash{
+		types.Hash(rewardAddressUpdated.ID()),
+		types.BytesToHash(caller.Bytes()),
+		types.BytesToHash(addr.Bytes()),
+	}, data[:])
+
+	return nil, nil
+}
+
+// rewardAddressOf returns the latest reward address registered by the validator,
+// regardless of whether it is effective already
+func (c *rewardAddress) rewardAddressOf(input []byte, host runtime.Host) ([]byte, error) {
+	val, err := rewardAddressOfMethod.Inputs.Decode(input)
+	if err != nil {
+		return nil, runtime.ErrInvalidInputData
+	}
+
+	args, ok := val.(map[string]interface{})
+	if !ok {
+		return nil, runtime.ErrInvalidInputData
+	}
+
+	validator, ok := args["validator"].(ethgo.Address)
+	if !ok {
+		return nil, runtime.ErrInvalidInputData
+	}
+
+	var addr types.Address
+
+	if count := rewardAddressCount(host, types.Address(validator)); count > 0 {
+		addr, _ = contracts.DecodeRewardAddressEntry(
+			host.GetStorage(contracts.RewardAddressPrecompile,
+				contracts.RewardAddressEntrySlotKey(types.Address(validator), count-1)))
+	}
+
+	return rewardAddressOfMethod.Outputs.Encode([]interface{}{ethgo.Address(addr)})
+}
+
+func rewardAddressCount(host runtime.Host, validator types.Address) uint64 {
+	count := host.GetStorage(contracts.RewardAddressPrecompile, contracts.RewardAddressCountSlotKey(validator))
+
+	return new(big.Int).SetBytes(count[:]).Uint64()
+}
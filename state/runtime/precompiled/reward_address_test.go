@@ -0,0 +1,167 @@
+package precompiled
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+
+	"github.com/xgr-network/xgr-node/chain"
+	"github.com/xgr-network/xgr-node/contracts"
+	"github.com/xgr-network/xgr-node/state/runtime"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+// rewardHost serves the storage, the block number and the logs of the reward address registry
+type rewardHost struct {
+	runtime.Host
+
+	number  int64
+	storage map[types.Hash]types.Hash
+	logs    [][]types.Hash
+}
+
+func (r *rewardHost) GetStorage(_ types.Address, key types.Hash) types.Hash {
+	return r.storage[key]
+}
+
+func (r *rewardHost) SetStorage(
+	_ types.Address, key types.Hash, value types.Hash, _ *chain.ForksInTime) runtime.StorageStatus {
+	r.storage[key] = value
+
+	return runtime.StorageModified
+}
+
+func (r *rewardHost) GetTxContext() runtime.TxContext {
+	return runtime.TxContext{Number: r.number}
+}
+
+func (r *rewardHost) EmitLog(_ types.Address, topics []types.Hash, _ []byte) {
+	r.logs = append(r.logs, topics)
+}
+
+func TestRewardAddressPrecompile(t *testing.T) {
+	t.Parallel()
+
+	var (
+		validator = types.StringToAddress("0x1")
+		first     = types.StringToAddress("0x100")
+		second    = types.StringToAddress("0x200")
+		third     = types.StringToAddress("0x300")
+	)
+
+	host := &rewardHost{storage: map[types.Hash]types.Hash{}}
+	c := &rewardAddress{}
+
+	set := func(addr types.Address) {
+		input, err := setRewardAddressMethod.Encode([]interface{}{ethgo.Address(addr)})
+		require.NoError(t, err)
+
+		_, err = c.run(input, validator, host, nil)
+		require.NoError(t, err)
+	}
+
+	get := func(addr types.Address) types.Address {
+		input, err := rewardAddressOfMethod.Encode([]interface{}{ethgo.Address(addr)})
+		require.NoError(t, err)
+
+		out, err := c.run(input, types.ZeroAddress, host, nil)
+		require.NoError(t, err)
+
+		return types.BytesToAddress(out)
+	}
+
+	entry := func(index uint64) (types.Address, uint64) {
+		return contracts.DecodeRewardAddressEntry(host.storage[contracts.RewardAddressEntrySlotKey(validator, index)])
+	}
+
+	require.Equal(t, types.ZeroAddress, get(validator))
+
+	host.number = 10
+	set(first)
+
+	require.Equal(t, uint64(1), rewardAddressCount(host, validator))
+	require.Equal(t, first, get(validator))
+
+	// an update within the same block replaces the previous one
+	host.number = 20
+	set(second)
+	set(third)
+
+	require.Equal(t, uint64(2), rewardAddressCount(host, validator))
+	require.Equal(t, third, get(validator))
+
+	addr, blockNumber := entry(0)
+	require.Equal(t, first, addr)
+	require.Equal(t, uint64(10), blockNumber)
+
+	addr, blockNumber = entry(1)
+	require.Equal(t, third, addr)
+	require.Equal(t, uint64(20), blockNumber)
+
+	require.Len(t, host.logs, 3)
+	require.Equal(t, types.Hash(rewardAddressUpdated.ID()), host.logs[2][0])
+	require.Equal(t, types.BytesToHash(validator.Bytes()), host.logs[2][1])
+	require.Equal(t, types.BytesToHash(third.Bytes()), host.logs[2][2])
+
+	// other validators are not affected
+	require.Equal(t, types.ZeroAddress, get(first))
+}
+
+func TestRewardAddressPrecompile_InvalidInput(t *testing.T) {
+	t.Parallel()
+
+	c := &rewardAddress{}
+	host := &rewardHost{storage: map[types.Hash]types.Hash{}}
+
+	for _, input := range [][]byte{
+		nil,
+		{0x1, 0x2},
+		{0x1, 0x2, 0x3, 0x4},
+		setRewardAddressMethod.ID(),
+	} {
+		_, err := c.run(input, types.ZeroAddress, host, nil)
+		require.ErrorIs(t, err, runtime.ErrInvalidInputData)
+	}
+
+	require.Empty(t, host.storage)
+}
+
+func TestRewardAddressPrecompile_Fork(t *testing.T) {
+	t.Parallel()
+
+	p := NewPrecompiled()
+	c := &runtime.Contract{CodeAddress: contracts.RewardAddressPrecompile}
+
+	require.False(t, p.CanRun(c, nil, &chain.ForksInTime{}))
+	require.True(t, p.CanRun(c, nil, &chain.ForksInTime{RewardAddress: true}))
+}
+
+func TestRewardAddressPrecompile_StaticCall(t *testing.T) {
+	t.Parallel()
+
+	validator := types.StringToAddress("0x1")
+
+	staticCall := func(input []byte) *runtime.ExecutionResult {
+		t.Helper()
+
+		return NewPrecompiled().Run(&runtime.Contract{
+			CodeAddress: contracts.RewardAddressPrecompile,
+			Caller:      validator,
+			Static:      true,
+			Gas:         rewardAddressSetGas,
+			Input:       input,
+		}, &rewardHost{storage: map[types.Hash]types.Hash{}}, &chain.ForksInTime{})
+	}
+
+	set, err := setRewardAddressMethod.Encode([]interface{}{ethgo.Address(types.StringToAddress("0x100"))})
+	require.NoError(t, err)
+
+	require.ErrorIs(t, staticCall(set).Err, runtime.ErrWriteProtection)
+
+	// the getter stays callable
+	get, err := rewardAddressOfMethod.Encode([]interface{}{ethgo.Address(validator), uint64(1)})
+	require.NoError(t, err)
+
+	require.NoError(t, staticCall(get).Err)
+}
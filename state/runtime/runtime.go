@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sync"
 
 	"github.com/xgr-network/xgr-node/chain"
 	"github.com/xgr-network/xgr-node/state/runtime/tracer"
@@ -66,6 +67,8 @@ type Host interface {
 	GetStorage(addr types.Address, key types.Hash) types.Hash
 	SetStorage(addr types.Address, key types.Hash, value types.Hash, config *chain.ForksInTime) StorageStatus
 	SetState(addr types.Address, key types.Hash, value types.Hash)
+	GetTransientStorage(addr types.Address, key types.Hash) types.Hash
+	SetTransientStorage(addr types.Address, key types.Hash, value types.Hash)
 	SetNonPayable(nonPayable bool)
 	GetBalance(addr types.Address) *big.Int
 	GetCodeSize(addr types.Address) int
@@ -120,11 +123,16 @@ func (r *ExecutionResult) Succeeded() bool { return r.Err == nil }
 func (r *ExecutionResult) Failed() bool    { return r.Err != nil }
 func (r *ExecutionResult) Reverted() bool  { return errors.Is(r.Err, ErrExecutionReverted) }
 
-func (r *ExecutionResult) UpdateGasUsed(gasLimit uint64, refund uint64) {
+func (r *ExecutionResult) UpdateGasUsed(gasLimit uint64, refund uint64, eip3529 bool) {
 	r.GasUsed = gasLimit - r.GasLeft
 
-	// Refund can go up to half the gas used
-	if maxRefund := r.GasUsed / 2; refund > maxRefund {
+	// Refund can go up to half the gas used, a fifth of it since EIP-3529
+	maxRefundQuotient := uint64(2)
+	if eip3529 {
+		maxRefundQuotient = 5
+	}
+
+	if maxRefund := r.GasUsed / maxRefundQuotient; refund > maxRefund {
 		refund = maxRefund
 	}
 
@@ -144,6 +152,17 @@ var (
 	ErrUnauthorizedCaller       = errors.New("unauthorized caller")
 	ErrInvalidInputData         = errors.New("invalid input data")
 	ErrNotAuth                  = errors.New("not in allow list")
+	ErrWriteProtection          = errors.New("write protection")
+	ErrEngineWrongChain         = errors.New("engine grant signed for another chain")
+	ErrEngineGrantExpired       = errors.New("engine grant expired")
+	ErrEngineMaxTotalGas        = errors.New("engine session exceeds grant max total gas")
+	ErrEngineIterationOrder     = errors.New("engine iteration not after the last session iteration")
+	ErrDonationVoteDisabled     = errors.New("donation governance is not configured")
+	ErrDonationProposalUnknown  = errors.New("unknown donation percent proposal")
+	ErrDonationAlreadyApproved  = errors.New("donation percent proposal already approved by the voter")
+	ErrDonationProposalNotReady = errors.New("donation percent proposal not executable yet")
+	ErrDonationProposalExecuted = errors.New("donation percent proposal already executed")
+	ErrDonationRegistryMissing  = errors.New("engine registry not deployed")
 )
 
 // StackUnderflowError wraps an evm error when the items on the stack less
@@ -201,6 +220,20 @@ type Contract struct {
 	Static      bool
 }
 
+var contractPool = sync.Pool{
+	New: func() interface{} {
+		return new(Contract)
+	},
+}
+
+// ReleaseContract returns the contract to the pool of NewContract.
+// It must only be called by the creator of the contract once the call returned,
+// neither the contract nor its fields may be used afterwards
+func ReleaseContract(c *Contract) {
+	*c = Contract{}
+	contractPool.Put(c)
+}
+
 func NewContract(
 	depth int,
 	origin types.Address,
@@ -210,7 +243,12 @@ func NewContract(
 	gas uint64,
 	code []byte,
 ) *Contract {
-	f := &Contract{
+	f, ok := contractPool.Get().(*Contract)
+	if !ok {
+		f = new(Contract)
+	}
+
+	*f = Contract{
 		Caller:      from,
 		Origin:      origin,
 		CodeAddress: to,
@@ -1,14 +1,20 @@
 package calltracer
 
 import (
+	"errors"
 	"math/big"
 	"sync"
 
+	"github.com/umbracle/ethgo/abi"
+
 	"github.com/xgr-network/xgr-node/helper/hex"
+	"github.com/xgr-network/xgr-node/state/runtime"
 	"github.com/xgr-network/xgr-node/state/runtime/tracer"
 	"github.com/xgr-network/xgr-node/types"
 )
 
+var _ tracer.PrecompileTracer = (*CallTracer)(nil)
+
 var (
 	callTypes = map[int]string{
 		0: "CALL",
@@ -31,6 +37,10 @@ type Call struct {
 	Output  string  `json:"output"`
 	Calls   []*Call `json:"calls,omitempty"`
 
+	// Error and RevertReason are set if a nested call failed
+	Error        string `json:"error,omitempty"`
+	RevertReason string `json:"revertReason,omitempty"`
+
 	parent   *Call
 	startGas uint64
 }
@@ -99,7 +109,7 @@ func (c *CallTracer) CallStart(depth int, from, to types.Address, callType int,
 		val = hex.EncodeBig(value)
 	}
 
-	call := &Call{
+	c.startCall(depth, &Call{
 		Type:     typ,
 		From:     from.String(),
 		To:       to.String(),
@@ -110,8 +120,28 @@ func (c *CallTracer) CallStart(depth int, from, to types.Address, callType int,
 		Output:   "",
 		Calls:    nil,
 		startGas: gas,
+	})
+}
+
+// PrecompileStart adds the frame of a precompile function which calls other contracts,
+// its type is the name of the function
+func (c *CallTracer) PrecompileStart(depth int, from, to types.Address, name string,
+	gas uint64, input []byte) {
+	if c.cancelled() {
+		return
 	}
 
+	c.startCall(depth, &Call{
+		Type:     name,
+		From:     from.String(),
+		To:       to.String(),
+		Gas:      hex.EncodeUint64(gas),
+		Input:    hex.EncodeToHex(input),
+		startGas: gas,
+	})
+}
+
+func (c *CallTracer) startCall(depth int, call *Call) {
 	if depth == 1 {
 		c.call = call
 		c.activeCall = call
@@ -135,7 +165,20 @@ func (c *CallTracer) CallEnd(depth int, output []byte, err error) {
 	c.activeGas = 0
 
 	if depth > 1 {
+		// a failed nested call is part of the trace, its caller may go on
+		if err != nil {
+			c.activeCall.Error = err.Error()
+
+			if errors.Is(err, runtime.ErrExecutionReverted) {
+				if reason, unpackErr := abi.UnpackRevertError(output); unpackErr == nil {
+					c.activeCall.RevertReason = reason
+				}
+			}
+		}
+
 		c.activeCall = c.activeCall.parent
+
+		return
 	}
 
 	if err != nil {
@@ -6,7 +6,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo/abi"
 	"github.com/xgr-network/xgr-node/helper/hex"
+	"github.com/xgr-network/xgr-node/state/runtime"
 	"github.com/xgr-network/xgr-node/types"
 )
 
@@ -285,4 +287,53 @@ func TestCallTracer_CallEnd(t *testing.T) {
 		require.Equal(t, "0x0", tracer.activeCall.GasUsed)
 		require.Equal(t, uint64(500), tracer.activeCall.startGas)
 	})
+	t.Run("call_end_when_depth_is_2_reverted", func(t *testing.T) {
+		t.Parallel()
+
+		revertOutput, encodeErr := abi.Encode([]interface{}{"nope"}, abi.MustNewType("tuple(string)"))
+		require.NoError(t, encodeErr)
+
+		parent := &Call{startGas: 500}
+		child := &Call{startGas: 1000, parent: parent}
+
+		tracer := &CallTracer{}
+		tracer.activeCall = child
+
+		tracer.CallEnd(2, append([]byte{0x08, 0xc3, 0x79, 0xa0}, revertOutput...), runtime.ErrExecutionReverted)
+
+		// the error stays on the nested frame and the trace goes on with its caller
+		require.Equal(t, parent, tracer.activeCall)
+		require.Equal(t, runtime.ErrExecutionReverted.Error(), child.Error)
+		require.Equal(t, "nope", child.RevertReason)
+		require.False(t, tracer.cancelled())
+	})
+}
+
+func TestCallTracer_PrecompileStart(t *testing.T) {
+	t.Parallel()
+
+	var (
+		from = types.StringToAddress("0xFrom")
+		to   = types.StringToAddress("0xTo")
+	)
+
+	c := &CallTracer{}
+
+	c.CallStart(1, from, to, 0, 3000, big.NewInt(0), nil)
+	c.PrecompileStart(2, from, to, "ENGINE_EXECUTE", 2000, []byte("input"))
+	c.CallStart(3, to, from, 0, 1000, big.NewInt(0), nil)
+	c.CallEnd(3, nil, nil)
+	c.CallEnd(2, nil, nil)
+	c.CallEnd(1, nil, nil)
+
+	require.Len(t, c.call.Calls, 1)
+
+	frame := c.call.Calls[0]
+	require.Equal(t, "ENGINE_EXECUTE", frame.Type)
+	require.Equal(t, hex.EncodeUint64(2000), frame.Gas)
+	require.Equal(t, hex.EncodeToHex([]byte("input")), frame.Input)
+	require.Empty(t, frame.Value)
+	require.Len(t, frame.Calls, 1)
+	require.Equal(t, "CALL", frame.Calls[0].Type)
+	require.Equal(t, c.call, c.activeCall)
 }
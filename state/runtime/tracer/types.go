@@ -68,3 +68,15 @@ type Tracer interface {
 		host RuntimeHost,
 	)
 }
+
+// PrecompileTracer is implemented by the tracers which show a synthetic frame for a precompile
+// whose function calls other contracts. The frame is closed with CallEnd
+type PrecompileTracer interface {
+	PrecompileStart(
+		depth int, // begins from 1
+		from, to types.Address,
+		name string, // the name of the called precompile function
+		gas uint64,
+		input []byte,
+	)
+}
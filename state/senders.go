@@ -0,0 +1,65 @@
+package state
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/xgr-network/xgr-node/crypto"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+// senderRecoveryWorkers bounds the goroutines recovering the senders of a block
+var senderRecoveryWorkers = runtime.NumCPU()
+
+// recoverSenders recovers the senders of the transactions without one on at most maxWorkers goroutines
+// and sets them on the transactions, the same way Transition.Write does. The recovery error of a transaction
+// is returned at its index, so it surfaces only once the transaction is applied, in block order
+func recoverSenders(signer crypto.TxSigner, txs []*types.Transaction, maxWorkers int) []error {
+	pending := make([]int, 0, len(txs))
+
+	for i, txn := range txs {
+		if txn.From == emptyFrom && txn.Type != types.StateTx {
+			pending = append(pending, i)
+		}
+	}
+
+	errs := make([]error, len(txs))
+
+	workers := maxWorkers
+	if workers > len(pending) {
+		workers = len(pending)
+	}
+
+	indexCh := make(chan int)
+
+	var wg sync.WaitGroup
+
+	wg.Add(workers)
+
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+
+			// each transaction is written by one worker only
+			for i := range indexCh {
+				from, err := signer.Sender(txs[i])
+				if err != nil {
+					errs[i] = err
+
+					continue
+				}
+
+				txs[i].From = from
+			}
+		}()
+	}
+
+	for _, i := range pending {
+		indexCh <- i
+	}
+
+	close(indexCh)
+	wg.Wait()
+
+	return errs
+}
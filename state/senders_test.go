@@ -0,0 +1,138 @@
+package state
+
+import (
+	"errors"
+	"math/big"
+	"runtime"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xgr-network/xgr-node/chain"
+	"github.com/xgr-network/xgr-node/crypto"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+// newSignedTransfers returns transfers of zero value and gas price of a new account, signed for the block 1
+func newSignedTransfers(t testing.TB, count int) ([]*types.Transaction, types.Address) {
+	t.Helper()
+
+	key, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	signer := crypto.NewSigner(chain.AllForksEnabled.At(1), 0)
+	receiver := types.StringToAddress("0x800")
+
+	txs := make([]*types.Transaction, count)
+
+	for i := range txs {
+		tx, err := signer.SignTx(&types.Transaction{
+			To:       &receiver,
+			Nonce:    uint64(i),
+			Gas:      21_000,
+			GasPrice: big.NewInt(0),
+			Value:    big.NewInt(0),
+		}, key)
+		require.NoError(t, err)
+
+		tx.ComputeHash(1)
+		txs[i] = tx
+	}
+
+	return txs, crypto.PubKeyToAddress(&key.PublicKey)
+}
+
+func TestExecutor_ProcessBlock_SenderRecovery(t *testing.T) {
+	t.Parallel()
+
+	e := NewExecutor(&chain.Params{
+		Forks: chain.AllForksEnabled,
+		BurnContract: map[uint64]types.Address{
+			0: types.ZeroAddress,
+		},
+	}, &faultyState{}, hclog.NewNullLogger())
+
+	e.GetHash = func(*types.Header) GetHashByNumber {
+		return func(uint64) types.Hash {
+			return types.ZeroHash
+		}
+	}
+
+	txs, sender := newSignedTransfers(t, 5)
+
+	txn, err := e.ProcessBlock(types.StringToHash("0x1"), &types.Block{
+		Header:       &types.Header{Number: 1, GasLimit: 1_000_000},
+		Transactions: txs,
+	}, types.ZeroAddress)
+	require.NoError(t, err)
+	require.Len(t, txn.Receipts(), len(txs))
+
+	for _, tx := range txs {
+		require.Equal(t, sender, tx.From)
+	}
+
+	// the invalid signature in the middle of the block fails it the same way Write does
+	txs, _ = newSignedTransfers(t, 5)
+	txs[2].S = big.NewInt(0)
+
+	_, err = e.ProcessBlock(types.StringToHash("0x1"), &types.Block{
+		Header:       &types.Header{Number: 1, GasLimit: 1_000_000},
+		Transactions: txs,
+	}, types.ZeroAddress)
+
+	var appErr *TransitionApplicationError
+
+	require.ErrorAs(t, err, &appErr)
+	require.False(t, appErr.IsRecoverable)
+	require.NotErrorIs(t, err, ErrBlockGasLimitExceeded)
+
+	// the senders of the other transactions are recovered anyway
+	require.Equal(t, emptyFrom, txs[2].From)
+	require.NotEqual(t, emptyFrom, txs[4].From)
+}
+
+func TestRecoverSenders(t *testing.T) {
+	t.Parallel()
+
+	txs, sender := newSignedTransfers(t, 4)
+
+	preset := types.StringToAddress("0x900")
+	txs[1].From = preset
+	txs[3].S = big.NewInt(0)
+
+	errs := recoverSenders(crypto.NewSigner(chain.AllForksEnabled.At(1), 0), txs, 2)
+
+	// a transaction with a sender is kept as is
+	require.Equal(t, []types.Address{sender, preset, sender, emptyFrom},
+		[]types.Address{txs[0].From, txs[1].From, txs[2].From, txs[3].From})
+	require.NoError(t, errors.Join(errs[:3]...))
+	require.Error(t, errs[3])
+}
+
+func BenchmarkRecoverSenders(b *testing.B) {
+	txs, _ := newSignedTransfers(b, 1000)
+	signer := crypto.NewSigner(chain.AllForksEnabled.At(1), 0)
+
+	for _, bench := range []struct {
+		name    string
+		workers int
+	}{
+		{"sequential", 1},
+		{"parallel", runtime.NumCPU()},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+
+				for _, tx := range txs {
+					tx.From = emptyFrom
+				}
+
+				b.StartTimer()
+
+				recoverSenders(signer, txs, bench.workers)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package state
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/xgr-network/xgr-node/types"
+)
+
+// shadowRootPrefix is the key prefix of the persisted root mapping
+var shadowRootPrefix = []byte("shadow-root")
+
+// RootStore persists the shadow root mapping
+type RootStore interface {
+	Put(k, v []byte) error
+	Get(k []byte) ([]byte, bool, error)
+}
+
+// ShadowState is a State used by a shadow-fork node. The node follows the headers
+// of a live network but executes them with local code, so the state roots referenced
+// by the headers may not exist locally. ShadowState translates such a root to the root
+// that was computed locally for the same block.
+type ShadowState struct {
+	State
+
+	store RootStore
+
+	lock  sync.RWMutex
+	roots map[types.Hash]types.Hash
+}
+
+// NewShadowState wraps the state. The root mapping is kept in the given store,
+// so a restarted node can continue from a diverged head.
+func NewShadowState(inner State, store RootStore) *ShadowState {
+	return &ShadowState{
+		State: inner,
+		store: store,
+		roots: map[types.Hash]types.Hash{},
+	}
+}
+
+// MapRoot records that the remote root was computed as the local root
+func (s *ShadowState) MapRoot(remote, local types.Hash) error {
+	if err := s.store.Put(append(shadowRootPrefix, remote.Bytes()...), local.Bytes()); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	s.roots[remote] = local
+	s.lock.Unlock()
+
+	return nil
+}
+
+// LocalRoot returns the local root of the given remote root.
+// Roots without divergence are returned as they are.
+func (s *ShadowState) LocalRoot(remote types.Hash) types.Hash {
+	s.lock.RLock()
+	local, ok := s.roots[remote]
+	s.lock.RUnlock()
+
+	if ok {
+		return local
+	}
+
+	raw, ok, err := s.store.Get(append(shadowRootPrefix, remote.Bytes()...))
+	if err != nil || !ok {
+		return remote
+	}
+
+	local = types.BytesToHash(raw)
+
+	s.lock.Lock()
+	s.roots[remote] = local
+	s.lock.Unlock()
+
+	return local
+}
+
+func (s *ShadowState) NewSnapshotAt(root types.Hash) (Snapshot, error) {
+	return s.State.NewSnapshotAt(s.LocalRoot(root))
+}
+
+// AccountDiff is the change of a single account made by a block
+type AccountDiff struct {
+	Address     types.Address `json:"address"`
+	PreNonce    uint64        `json:"preNonce"`
+	PostNonce   uint64        `json:"postNonce"`
+	PreBalance  *big.Int      `json:"preBalance"`
+	PostBalance *big.Int      `json:"postBalance"`
+	CodeChanged bool          `json:"codeChanged"`
+	Deleted     bool          `json:"deleted"`
+	Storage     []types.Hash  `json:"storage"`
+}
+
+// AccountDiffs returns the accounts written by the block together with their values
+// before the block. It is complete only after Commit has been called.
+func (t *Transition) AccountDiffs() []*AccountDiff {
+	diffs := make([]*AccountDiff, 0, len(t.committed))
+
+	for _, obj := range t.committed {
+		diff := &AccountDiff{
+			Address:     obj.Address,
+			PostNonce:   obj.Nonce,
+			PostBalance: new(big.Int),
+			PreBalance:  new(big.Int),
+			CodeChanged: obj.DirtyCode,
+			Deleted:     obj.Deleted,
+			Storage:     make([]types.Hash, 0, len(obj.Storage)),
+		}
+
+		if obj.Balance != nil && !obj.Deleted {
+			diff.PostBalance.Set(obj.Balance)
+		}
+
+		if pre, err := t.snap.GetAccount(obj.Address); err == nil && pre != nil {
+			diff.PreNonce = pre.Nonce
+
+			if pre.Balance != nil {
+				diff.PreBalance.Set(pre.Balance)
+			}
+		}
+
+		for _, entry := range obj.Storage {
+			diff.Storage = append(diff.Storage, types.BytesToHash(entry.Key))
+		}
+
+		diffs = append(diffs, diff)
+	}
+
+	return diffs
+}
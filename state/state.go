@@ -2,6 +2,7 @@ package state
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"math/big"
 
@@ -11,7 +12,22 @@ import (
 	"github.com/xgr-network/xgr-node/types"
 )
 
+var (
+	// ErrStateNotFound is returned when the requested state root doesn't exist
+	ErrStateNotFound = errors.New("state not found")
+
+	// ErrStorageUnavailable is returned when the state storage can't be read.
+	// The failure may be temporary, so the operation can be retried.
+	ErrStorageUnavailable = errors.New("state storage unavailable")
+
+	// ErrStatePruned is returned together with ErrStateNotFound when the requested
+	// state root is missing because the node pruned it
+	ErrStatePruned = errors.New("state not available (pruned)")
+)
+
 type State interface {
+	// NewSnapshotAt returns the snapshot at the given root. The returned error wraps
+	// ErrStateNotFound if the root is unknown and ErrStorageUnavailable if reading the storage failed with an I/O error.
 	NewSnapshotAt(types.Hash) (Snapshot, error)
 	NewSnapshot() Snapshot
 	GetCode(hash types.Hash) ([]byte, bool)
@@ -20,7 +36,14 @@ type State interface {
 type Snapshot interface {
 	readSnapshot
 
+	// GetAccountProof returns the RLP encoded state trie nodes on the path to the account
+	GetAccountProof(addr types.Address) ([][]byte, error)
+	// GetStorageProof returns the RLP encoded storage trie nodes on the path to the slot
+	GetStorageProof(root types.Hash, key types.Hash) ([][]byte, error)
+
 	Commit(objs []*Object) (Snapshot, []byte, error)
+	// Root returns the state root with the objects applied, without writing them to the storage
+	Root(objs []*Object) ([]byte, error)
 }
 
 // Account is the account reference in the ethereum state
@@ -369,10 +369,12 @@ func testSuicideWithIntermediateCommit(t *testing.T, buildPreState buildPreState
 
 	assert.Equal(t, uint64(10), txn.GetNonce(addr1))
 
-	assert.NoError(t, txn.CleanDeleteObjects(true))
+	deleted, err := txn.CleanDeleteObjects(true)
+	assert.NoError(t, err)
+	assert.Equal(t, []types.Address{addr1}, deleted)
 	assert.Equal(t, uint64(0), txn.GetNonce(addr1))
 
-	_, err := txn.Commit(true)
+	_, err = txn.Commit(true)
 	assert.NoError(t, err)
 	assert.Equal(t, uint64(0), txn.GetNonce(addr1))
 }
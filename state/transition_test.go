@@ -6,7 +6,12 @@ import (
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
+	"github.com/xgr-network/xgr-node/chain"
+	"github.com/xgr-network/xgr-node/contracts"
+	"github.com/xgr-network/xgr-node/crypto"
 	"github.com/xgr-network/xgr-node/state/runtime"
+	"github.com/xgr-network/xgr-node/state/runtime/addresslist"
+	"github.com/xgr-network/xgr-node/state/runtime/tracer/calltracer"
 	"github.com/xgr-network/xgr-node/types"
 )
 
@@ -160,3 +165,756 @@ func TestTransfer(t *testing.T) {
 		})
 	}
 }
+
+func TestTransition_AddressBloom(t *testing.T) {
+	t.Parallel()
+
+	var (
+		touched   = types.StringToAddress("0x100")
+		untouched = types.StringToAddress("0x200")
+	)
+
+	transition := newTestTransition(nil)
+	transition.snap = newStateWithPreState(defaultPreState)
+
+	// balance-only changes (e.g. fee recipients) are part of the committed objects
+	transition.state.AddBalance(touched, big.NewInt(1))
+
+	_, _, err := transition.Commit()
+	assert.NoError(t, err)
+
+	bloom := transition.AddressBloom()
+	assert.True(t, bloom.Test(touched))
+	assert.False(t, bloom.Test(untouched))
+}
+
+func TestTransition_SystemTxGasReserve(t *testing.T) {
+	t.Parallel()
+
+	const (
+		reserve = types.StateTransactionGasLimit
+		userGas = 21000
+	)
+
+	sender := types.StringToAddress("0x300")
+	receiver := types.StringToAddress("0x400")
+
+	transition := NewTransition(nil, chain.ForksInTime{}, nil, newTestTxn(map[types.Address]*PreState{
+		sender: {Balance: 1_000_000_000},
+	}))
+	transition.logger = hclog.NewNullLogger()
+	transition.ctx = runtime.TxContext{BaseFee: big.NewInt(0)}
+	transition.gasPool = reserve + userGas
+	transition.systemGasReserve = reserve
+
+	// fill the user portion of the pool
+	_, err := transition.Apply(&types.Transaction{
+		From:     sender,
+		To:       &receiver,
+		Nonce:    0,
+		Gas:      userGas,
+		GasPrice: big.NewInt(0),
+		Value:    big.NewInt(1),
+	})
+	assert.NoError(t, err)
+
+	// the next user tx must not dip into the reserve
+	_, err = transition.Apply(&types.Transaction{
+		From:     sender,
+		To:       &receiver,
+		Nonce:    1,
+		Gas:      userGas,
+		GasPrice: big.NewInt(0),
+		Value:    big.NewInt(1),
+	})
+
+	var gasLimitErr *GasLimitReachedTransitionApplicationError
+
+	assert.ErrorAs(t, err, &gasLimitErr)
+
+	// the state tx still fits into the reserve
+	result, err := transition.Apply(&types.Transaction{
+		Type:     types.StateTx,
+		From:     contracts.SystemCaller,
+		To:       &receiver,
+		Gas:      types.StateTransactionGasLimit,
+		GasPrice: big.NewInt(0),
+		Value:    big.NewInt(0),
+	})
+	assert.NoError(t, err)
+	assert.False(t, result.Failed())
+	assert.Equal(t, reserve-result.GasUsed, transition.systemGasReserve)
+}
+
+func TestTransition_DeploymentRejectedLog(t *testing.T) {
+	t.Parallel()
+
+	const gasLimit = 100_000
+
+	deployer := types.StringToAddress("0x500")
+
+	// reject deploys the contract of a deployer without a role in the allow list
+	reject := func(t *testing.T, config chain.ForksInTime) *types.Receipt {
+		t.Helper()
+
+		transition := NewTransition(nil, config, nil, newTestTxn(map[types.Address]*PreState{
+			deployer: {Balance: 1_000_000_000},
+		}))
+		transition.logger = hclog.NewNullLogger()
+		transition.ctx = runtime.TxContext{BaseFee: big.NewInt(0)}
+		transition.gasPool = gasLimit
+		transition.deploymentAllowList = addresslist.NewAddressList(transition, contracts.AllowListContractsAddr, false)
+
+		err := transition.Write(&types.Transaction{
+			From:     deployer,
+			Nonce:    0,
+			Gas:      gasLimit,
+			GasPrice: big.NewInt(0),
+			Value:    big.NewInt(0),
+			Input:    []byte{0x00},
+		})
+		assert.NoError(t, err)
+
+		receipts := transition.Receipts()
+		assert.Len(t, receipts, 1)
+
+		receipt := receipts[0]
+		assert.Equal(t, types.ReceiptFailed, *receipt.Status)
+		assert.Equal(t, uint64(gasLimit), receipt.GasUsed)
+
+		return receipt
+	}
+
+	t.Run("before the fork", func(t *testing.T) {
+		t.Parallel()
+
+		receipt := reject(t, chain.ForksInTime{})
+		assert.Empty(t, receipt.Logs)
+	})
+
+	t.Run("after the fork", func(t *testing.T) {
+		t.Parallel()
+
+		receipt := reject(t, chain.ForksInTime{DeploymentRejectedLog: true})
+
+		var rejected *types.Log
+
+		for _, log := range receipt.Logs {
+			if log.Address == contracts.AllowListContractsAddr {
+				rejected = log
+			}
+		}
+
+		assert.NotNil(t, rejected)
+
+		eventID := addresslist.DeploymentRejectedEvent.ID()
+		contractAddr := crypto.CreateAddress(deployer, 0)
+
+		assert.Equal(t, []types.Hash{
+			types.BytesToHash(eventID[:]),
+			types.BytesToHash(deployer.Bytes()),
+			types.BytesToHash(contractAddr.Bytes()),
+		}, rejected.Topics)
+		assert.Equal(t, byte(addresslist.AllowListType), rejected.Data[31])
+		assert.True(t, receipt.LogsBloom.IsLogInBloom(rejected))
+	})
+}
+
+func TestTransition_AddressListRoleUpdateGas(t *testing.T) {
+	t.Parallel()
+
+	// cost of an SSTORE setting a fresh slot
+	const sstoreSetGas = 20000
+
+	admin := types.StringToAddress("0x500")
+	target := types.StringToAddress("0x501")
+
+	for _, indexed := range []bool{false, true} {
+		transition := NewTransition(nil, chain.ForksInTime{}, nil, newTestTxn(map[types.Address]*PreState{
+			admin: {Balance: 1_000_000_000},
+		}))
+		transition.logger = hclog.NewNullLogger()
+		transition.ctx = runtime.TxContext{BaseFee: big.NewInt(0)}
+		transition.gasPool = 1_000_000
+		transition.deploymentAllowList = addresslist.NewAddressList(transition, contracts.AllowListContractsAddr, indexed)
+		transition.deploymentAllowList.SetRole(admin, addresslist.AdminRole)
+
+		input, err := addresslist.SetEnabledFunc.Encode([]interface{}{target})
+		assert.NoError(t, err)
+
+		txn := &types.Transaction{
+			From:     admin,
+			To:       &contracts.AllowListContractsAddr,
+			Gas:      1_000_000,
+			GasPrice: big.NewInt(0),
+			Value:    big.NewInt(0),
+			Input:    input,
+		}
+
+		intrinsicGas, err := TransactionGasCost(txn, false, false, false, false)
+		assert.NoError(t, err)
+
+		assert.NoError(t, transition.Write(txn))
+
+		receipt := transition.Receipts()[0]
+		assert.Equal(t, types.ReceiptSuccess, *receipt.Status)
+		assert.GreaterOrEqual(t, receipt.GasUsed, intrinsicGas+sstoreSetGas, "indexed: %v", indexed)
+		assert.Equal(t, addresslist.EnabledRole, transition.deploymentAllowList.GetRole(target))
+	}
+}
+
+func TestTransition_StateTxRecipients(t *testing.T) {
+	t.Parallel()
+
+	allowed := contracts.ValidatorSetContract
+	notAllowed := types.StringToAddress("0x600")
+
+	transition := NewTransition(nil, chain.ForksInTime{}, nil, newTestTxn(nil))
+	transition.logger = hclog.NewNullLogger()
+	transition.ctx = runtime.TxContext{BaseFee: big.NewInt(0)}
+	transition.gasPool = 2 * types.StateTransactionGasLimit
+	transition.stateTxRecipients = newStateTxRecipients(nil)
+
+	stateTx := func(to types.Address) *types.Transaction {
+		return &types.Transaction{
+			Type:     types.StateTx,
+			From:     contracts.SystemCaller,
+			To:       &to,
+			Gas:      types.StateTransactionGasLimit,
+			GasPrice: big.NewInt(0),
+			Value:    big.NewInt(0),
+		}
+	}
+
+	_, err := transition.Apply(stateTx(notAllowed))
+
+	var appErr *TransitionApplicationError
+
+	assert.ErrorAs(t, err, &appErr)
+	assert.ErrorContains(t, err, "is not allowed")
+
+	_, err = transition.Apply(stateTx(allowed))
+	assert.NoError(t, err)
+
+	// a configured list replaces the defaults
+	transition.stateTxRecipients = newStateTxRecipients([]types.Address{notAllowed})
+
+	_, err = transition.Apply(stateTx(notAllowed))
+	assert.NoError(t, err)
+
+	_, err = transition.Apply(stateTx(allowed))
+	assert.ErrorContains(t, err, "is not allowed")
+}
+
+func TestTransition_AccountDiffs(t *testing.T) {
+	t.Parallel()
+
+	preState := map[types.Address]*PreState{
+		addr1: {
+			Nonce:   1,
+			Balance: 1000,
+		},
+	}
+
+	transition := newTestTransition(preState)
+	transition.snap = newStateWithPreState(preState)
+
+	assert.NoError(t, transition.Transfer(addr1, addr2, big.NewInt(100)))
+	assert.NoError(t, transition.state.IncrNonce(addr1))
+	transition.state.SetState(addr2, hash1, hash2)
+
+	_, _, err := transition.Commit()
+	assert.NoError(t, err)
+
+	diffs := map[types.Address]*AccountDiff{}
+	for _, diff := range transition.AccountDiffs() {
+		diffs[diff.Address] = diff
+	}
+
+	assert.Len(t, diffs, 2)
+
+	sender := diffs[addr1]
+	assert.Equal(t, uint64(1), sender.PreNonce)
+	assert.Equal(t, uint64(2), sender.PostNonce)
+	assert.Equal(t, big.NewInt(1000), sender.PreBalance)
+	assert.Equal(t, big.NewInt(900), sender.PostBalance)
+	assert.Empty(t, sender.Storage)
+
+	// the receiver didn't exist before the block
+	receiver := diffs[addr2]
+	assert.Equal(t, big.NewInt(0), receiver.PreBalance)
+	assert.Equal(t, big.NewInt(100), receiver.PostBalance)
+	assert.Equal(t, []types.Hash{hash1}, receiver.Storage)
+}
+
+func TestTransition_ApplyReadOnly(t *testing.T) {
+	t.Parallel()
+
+	const gasLimit = 100_000
+
+	sender := types.StringToAddress("0x700")
+	contract := types.StringToAddress("0x800")
+
+	transition := NewTransition(nil, chain.ForksInTime{}, nil, newTestTxn(map[types.Address]*PreState{
+		sender:   {Balance: 1_000_000_000},
+		contract: {Balance: 5},
+	}))
+	transition.logger = hclog.NewNullLogger()
+	transition.ctx = runtime.TxContext{BaseFee: big.NewInt(0)}
+	transition.gasPool = gasLimit
+
+	// PUSH1 0x01 PUSH1 0x00 SSTORE STOP
+	transition.state.SetCode(contract, []byte{0x60, 0x01, 0x60, 0x00, 0x55, 0x00})
+
+	callTracer := &calltracer.CallTracer{}
+	transition.SetTracer(callTracer)
+
+	result, err := transition.ApplyReadOnly(&types.Transaction{
+		From:     sender,
+		To:       &contract,
+		Nonce:    0,
+		Gas:      gasLimit,
+		GasPrice: big.NewInt(1),
+		Value:    big.NewInt(10),
+	})
+	assert.NoError(t, err)
+	assert.False(t, result.Failed())
+	assert.Greater(t, result.GasUsed, uint64(21000))
+
+	// nothing of the call is left in the state
+	assert.Equal(t, uint64(0), transition.state.GetNonce(sender))
+	assert.Equal(t, big.NewInt(1_000_000_000), transition.state.GetBalance(sender))
+	assert.Equal(t, big.NewInt(5), transition.state.GetBalance(contract))
+	assert.Equal(t, types.ZeroHash, transition.state.GetState(contract, types.ZeroHash))
+	assert.Equal(t, uint64(gasLimit), transition.gasPool)
+
+	// the tracer has seen the call
+	call, err := callTracer.GetResult()
+	assert.NoError(t, err)
+	assert.NotNil(t, call)
+	assert.Equal(t, contract.String(), call.(*calltracer.Call).To) //nolint:forcetypeassert
+}
+
+func TestTransition_BridgeAccessLists(t *testing.T) {
+	t.Parallel()
+
+	const gas = 100_000
+
+	var (
+		listed   = types.StringToAddress("0x500")
+		unlisted = types.StringToAddress("0x501")
+		other    = types.StringToAddress("0x700")
+	)
+
+	newTransition := func() *Transition {
+		transition := NewTransition(nil, chain.ForksInTime{BridgeCallAccessLists: true}, nil, newTestTxn(map[types.Address]*PreState{
+			listed:   {Balance: 1_000_000},
+			unlisted: {Balance: 1_000_000},
+		}))
+		transition.logger = hclog.NewNullLogger()
+		transition.ctx = runtime.TxContext{BaseFee: big.NewInt(0)}
+
+		return transition
+	}
+
+	call := func(transition *Transition, caller, to types.Address) error {
+		return transition.Call2(caller, to, nil, big.NewInt(0), gas).Err
+	}
+
+	t.Run("allow list", func(t *testing.T) {
+		t.Parallel()
+
+		transition := newTransition()
+		transition.bridgeAllowList = addresslist.NewAddressList(transition, contracts.AllowListBridgeAddr, false)
+		transition.bridgeAllowList.SetRole(listed, addresslist.EnabledRole)
+
+		assert.NoError(t, call(transition, listed, contracts.L2StateSenderContract))
+		assert.ErrorIs(t, call(transition, unlisted, contracts.L2StateSenderContract), runtime.ErrNotAuth)
+		assert.ErrorIs(t, call(transition, unlisted, contracts.ChildERC20PredicateContract), runtime.ErrNotAuth)
+
+		// calls outside the bridge and calls made by the bridge are not gated
+		assert.NoError(t, call(transition, unlisted, other))
+		assert.NoError(t, call(transition, contracts.StateReceiverContract, contracts.ChildERC20PredicateContract))
+		assert.NoError(t, call(transition, contracts.ChildERC20PredicateContract, contracts.L2StateSenderContract))
+	})
+
+	t.Run("block list", func(t *testing.T) {
+		t.Parallel()
+
+		transition := newTransition()
+		transition.bridgeBlockList = addresslist.NewAddressList(transition, contracts.BlockListBridgeAddr, false)
+		transition.bridgeBlockList.SetRole(listed, addresslist.EnabledRole)
+
+		assert.ErrorIs(t, call(transition, listed, contracts.L2StateSenderContract), runtime.ErrNotAuth)
+		assert.NoError(t, call(transition, unlisted, contracts.L2StateSenderContract))
+		assert.NoError(t, call(transition, listed, other))
+	})
+
+	t.Run("before the fork", func(t *testing.T) {
+		t.Parallel()
+
+		transition := newTransition()
+		transition.config.BridgeCallAccessLists = false
+		transition.bridgeBlockList = addresslist.NewAddressList(transition, contracts.BlockListBridgeAddr, false)
+		transition.bridgeBlockList.SetRole(listed, addresslist.EnabledRole)
+
+		assert.NoError(t, call(transition, listed, contracts.L2StateSenderContract))
+	})
+}
+
+func TestTransition_StrictBridgeBlockList(t *testing.T) {
+	t.Parallel()
+
+	const gas = 100_000
+
+	var (
+		sender    = types.StringToAddress("0x500")
+		blocked   = types.StringToAddress("0x501")
+		unlisted  = types.StringToAddress("0x502")
+		forwarder = types.StringToAddress("0x503")
+		contract  = types.StringToAddress("0x504")
+	)
+
+	// the forwarder sends 1 wei to the blocked account:
+	// CALL(GAS, blocked, 1, 0, 0, 0, 0) POP STOP
+	forwarderCode := []byte{0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x01, 0x73}
+	forwarderCode = append(forwarderCode, blocked.Bytes()...)
+	forwarderCode = append(forwarderCode, 0x5a, 0xf1, 0x50, 0x00)
+
+	newTransition := func(forks chain.ForksInTime) *Transition {
+		transition := NewTransition(nil, forks, nil, newTestTxn(map[types.Address]*PreState{
+			sender:                 {Balance: 1_000_000},
+			contracts.SystemCaller: {Balance: 1_000_000},
+		}))
+		transition.logger = hclog.NewNullLogger()
+		transition.ctx = runtime.TxContext{BaseFee: big.NewInt(0)}
+		transition.state.SetCode(forwarder, forwarderCode)
+		transition.state.SetCode(contract, []byte{0x00}) // STOP
+
+		transition.bridgeBlockList = addresslist.NewAddressList(transition, contracts.BlockListBridgeAddr, false)
+		transition.bridgeBlockList.SetRole(blocked, addresslist.EnabledRole)
+		transition.bridgeBlockList.SetRole(contract, addresslist.EnabledRole)
+
+		return transition
+	}
+
+	transfer := func(transition *Transition, caller, to types.Address, value int64) error {
+		return transition.Call2(caller, to, nil, big.NewInt(value), gas).Err
+	}
+
+	t.Run("blocked EOA recipient", func(t *testing.T) {
+		t.Parallel()
+
+		transition := newTransition(chain.ForksInTime{StrictBridgeBlockList: true})
+
+		assert.ErrorIs(t, transfer(transition, sender, blocked, 1), runtime.ErrNotAuth)
+		assert.Equal(t, uint64(0), transition.state.GetBalance(blocked).Uint64())
+
+		assert.NoError(t, transfer(transition, sender, unlisted, 1))
+		assert.Equal(t, uint64(1), transition.state.GetBalance(unlisted).Uint64())
+
+		// calls without value are not transfers, the system caller is exempt
+		assert.NoError(t, transfer(transition, sender, blocked, 0))
+		assert.NoError(t, transfer(transition, contracts.SystemCaller, blocked, 1))
+		assert.Equal(t, uint64(1), transition.state.GetBalance(blocked).Uint64())
+	})
+
+	t.Run("blocked contract recipient", func(t *testing.T) {
+		t.Parallel()
+
+		transition := newTransition(chain.ForksInTime{StrictBridgeBlockList: true})
+
+		// only transfers to accounts without code are gated, the contract is called as before
+		assert.NoError(t, transfer(transition, sender, contract, 1))
+		assert.Equal(t, uint64(1), transition.state.GetBalance(contract).Uint64())
+	})
+
+	t.Run("internal transfer pass-through", func(t *testing.T) {
+		t.Parallel()
+
+		transition := newTransition(chain.ForksInTime{StrictBridgeBlockList: true})
+
+		// the forwarder passes the value on to the blocked account in a nested call
+		assert.NoError(t, transfer(transition, sender, forwarder, 1))
+		assert.Equal(t, uint64(0), transition.state.GetBalance(forwarder).Uint64())
+		assert.Equal(t, uint64(1), transition.state.GetBalance(blocked).Uint64())
+	})
+
+	t.Run("before the fork", func(t *testing.T) {
+		t.Parallel()
+
+		transition := newTransition(chain.ForksInTime{})
+
+		assert.NoError(t, transfer(transition, sender, blocked, 1))
+		assert.Equal(t, uint64(1), transition.state.GetBalance(blocked).Uint64())
+	})
+}
+
+func TestTransition_AddressRoles(t *testing.T) {
+	t.Parallel()
+
+	addr := types.StringToAddress("0x600")
+
+	transition := NewTransition(nil, chain.ForksInTime{}, nil, newTestTxn(map[types.Address]*PreState{}))
+	transition.txnAllowList = addresslist.NewAddressList(transition, contracts.AllowListTransactionsAddr, false)
+	transition.txnBlockList = addresslist.NewAddressList(transition, contracts.BlockListTransactionsAddr, false)
+	transition.bridgeAllowList = addresslist.NewAddressList(transition, contracts.AllowListBridgeAddr, false)
+
+	transition.txnAllowList.SetRole(addr, addresslist.EnabledRole)
+	transition.bridgeAllowList.SetRole(types.StringToAddress("0x601"), addresslist.AdminRole)
+
+	// the lists that are not configured are not reported
+	assert.Equal(t, map[string]addresslist.Role{
+		"transactionsAllowList": addresslist.EnabledRole,
+		"transactionsBlockList": addresslist.NoRole,
+		"bridgeAllowList":       addresslist.NoRole,
+	}, transition.AddressRoles(addr))
+
+	assert.Empty(t, NewTransition(nil, chain.ForksInTime{}, nil, newTestTxn(nil)).AddressRoles(addr))
+}
+
+func TestTransition_TransientStorageClearedBetweenTransactions(t *testing.T) {
+	t.Parallel()
+
+	const gasLimit = 100_000
+
+	sender := types.StringToAddress("0x700")
+	contract := types.StringToAddress("0x800")
+
+	transition := NewTransition(nil, chain.ForksInTime{EIP1153: true}, nil, newTestTxn(map[types.Address]*PreState{
+		sender: {Balance: 1_000_000_000},
+	}))
+	transition.logger = hclog.NewNullLogger()
+	transition.ctx = runtime.TxContext{BaseFee: big.NewInt(0)}
+	transition.gasPool = 2 * gasLimit
+
+	// the contract increments the transient slot 0, stores the incremented value
+	// in the storage slot 0 and the value read back from the transient slot in the storage slot 1
+	transition.state.SetCode(contract, []byte{
+		0x60, 0x00, 0x5c, // PUSH1 0x00 TLOAD
+		0x60, 0x01, 0x01, // PUSH1 0x01 ADD
+		0x80,             // DUP1
+		0x60, 0x00, 0x5d, // PUSH1 0x00 TSTORE
+		0x60, 0x00, 0x55, // PUSH1 0x00 SSTORE
+		0x60, 0x00, 0x5c, // PUSH1 0x00 TLOAD
+		0x60, 0x01, 0x55, // PUSH1 0x01 SSTORE
+		0x00, // STOP
+	})
+
+	slot0 := types.ZeroHash
+	slot1 := types.BytesToHash([]byte{1})
+	one := types.BytesToHash([]byte{1})
+
+	for nonce := uint64(0); nonce < 2; nonce++ {
+		assert.NoError(t, transition.Write(&types.Transaction{
+			From:     sender,
+			To:       &contract,
+			Nonce:    nonce,
+			Gas:      gasLimit,
+			GasPrice: big.NewInt(0),
+			Value:    big.NewInt(0),
+		}))
+
+		receipt := transition.Receipts()[nonce]
+		assert.Equal(t, types.ReceiptSuccess, *receipt.Status)
+
+		// every transaction starts with an empty transient storage
+		assert.Equal(t, one, transition.state.GetState(contract, slot0), "nonce %d", nonce)
+		assert.Equal(t, one, transition.state.GetState(contract, slot1), "nonce %d", nonce)
+		assert.Equal(t, types.ZeroHash, transition.state.GetTransientState(contract, slot0))
+	}
+}
+
+func TestTransition_TransientStorageRevertedWithCall(t *testing.T) {
+	t.Parallel()
+
+	const gasLimit = 100_000
+
+	var (
+		sender = types.StringToAddress("0x700")
+		outer  = types.StringToAddress("0x800")
+		inner  = types.StringToAddress("0x900")
+	)
+
+	newTransition := func(config chain.ForksInTime) *Transition {
+		transition := NewTransition(nil, config, nil, newTestTxn(map[types.Address]*PreState{
+			sender: {Balance: 1_000_000_000},
+		}))
+		transition.logger = hclog.NewNullLogger()
+		transition.ctx = runtime.TxContext{BaseFee: big.NewInt(0)}
+		transition.gasPool = gasLimit
+
+		// without calldata the inner contract sets its transient slot 0 and reverts,
+		// with calldata it copies its transient slot 0 to the storage slot 0
+		transition.state.SetCode(inner, []byte{
+			0x36,             // CALLDATASIZE
+			0x60, 0x0d, 0x57, // PUSH1 0x0d JUMPI
+			0x60, 0x01, // PUSH1 0x01
+			0x60, 0x00, 0x5d, // PUSH1 0x00 TSTORE
+			0x60, 0x00, 0x80, 0xfd, // PUSH1 0x00 DUP1 REVERT
+			0x5b,             // JUMPDEST
+			0x60, 0x00, 0x5c, // PUSH1 0x00 TLOAD
+			0x60, 0x00, 0x55, // PUSH1 0x00 SSTORE
+			0x00, // STOP
+		})
+
+		// calls the inner contract with the given calldata size
+		callInner := func(argsLength byte) []byte {
+			code := []byte{
+				0x60, 0x00, // PUSH1 0x00 (retLength)
+				0x60, 0x00, // PUSH1 0x00 (retOffset)
+				0x60, argsLength, // PUSH1 argsLength
+				0x60, 0x00, // PUSH1 0x00 (argsOffset)
+				0x60, 0x00, // PUSH1 0x00 (value)
+				0x73, // PUSH20 inner
+			}
+			code = append(code, inner.Bytes()...)
+
+			return append(code,
+				0x61, 0x40, 0x00, // PUSH2 0x4000 (gas)
+				0xf1, 0x50, // CALL POP
+			)
+		}
+
+		// the outer contract sets its transient slot 0 to 7, runs the reverting inner call,
+		// reads the inner transient slot through the second inner call and stores its own
+		// transient slot 0 in the storage slot 1
+		code := []byte{
+			0x60, 0x07, // PUSH1 0x07
+			0x60, 0x00, 0x5d, // PUSH1 0x00 TSTORE
+		}
+		code = append(code, callInner(0)...)
+		code = append(code, callInner(1)...)
+		code = append(code,
+			0x60, 0x00, 0x5c, // PUSH1 0x00 TLOAD
+			0x60, 0x01, 0x55, // PUSH1 0x01 SSTORE
+			0x00, // STOP
+		)
+
+		transition.state.SetCode(outer, code)
+
+		return transition
+	}
+
+	write := func(t *testing.T, transition *Transition) *types.Receipt {
+		t.Helper()
+
+		assert.NoError(t, transition.Write(&types.Transaction{
+			From:     sender,
+			To:       &outer,
+			Gas:      gasLimit,
+			GasPrice: big.NewInt(0),
+			Value:    big.NewInt(0),
+		}))
+
+		return transition.Receipts()[0]
+	}
+
+	t.Run("revert", func(t *testing.T) {
+		t.Parallel()
+
+		transition := newTransition(chain.ForksInTime{EIP1153: true})
+		receipt := write(t, transition)
+
+		assert.Equal(t, types.ReceiptSuccess, *receipt.Status)
+
+		// the transient write of the reverted call is gone, the one of the outer call is kept
+		assert.Equal(t, types.ZeroHash, transition.state.GetState(inner, types.ZeroHash))
+		assert.Equal(t, types.BytesToHash([]byte{0x07}), transition.state.GetState(outer, types.BytesToHash([]byte{1})))
+	})
+
+	t.Run("before the fork", func(t *testing.T) {
+		t.Parallel()
+
+		transition := newTransition(chain.ForksInTime{})
+		receipt := write(t, transition)
+
+		// TSTORE is an invalid opcode, which consumes all the gas
+		assert.Equal(t, types.ReceiptFailed, *receipt.Status)
+		assert.Equal(t, uint64(gasLimit), receipt.GasUsed)
+	})
+}
+
+// not parallel, it changes chain.EngineRegistryAddress
+func TestTransition_MinBaseFeeFloor(t *testing.T) {
+	registry := types.StringToAddress("0x1000")
+	sender := types.StringToAddress("0x700")
+	receiver := types.StringToAddress("0x800")
+
+	prevReg := chain.EngineRegistryAddress
+	chain.EngineRegistryAddress = registry
+
+	t.Cleanup(func() {
+		chain.EngineRegistryAddress = prevReg
+	})
+
+	const (
+		minBaseFee = 100
+		baseFee    = 50
+	)
+
+	newTransition := func(deployed bool) *Transition {
+		tr := newTestTransition(map[types.Address]*PreState{
+			sender: {Balance: 1_000_000_000},
+		})
+		tr.config = chain.ForksInTime{London: true, MinBaseFeeFloor: true}
+		tr.ctx.BaseFee = big.NewInt(baseFee)
+
+		if deployed {
+			tr.state.SetCode(registry, []byte{0x00})
+			tr.state.SetState(registry, chain.EngineRegistrySlotKeyMinBaseFee(),
+				types.BytesToHash(big.NewInt(minBaseFee).Bytes()))
+		}
+
+		return tr
+	}
+
+	legacyTx := func(gasPrice int64) *types.Transaction {
+		return &types.Transaction{
+			Type:     types.LegacyTx,
+			From:     sender,
+			To:       &receiver,
+			Gas:      21_000,
+			GasPrice: big.NewInt(gasPrice),
+			Value:    big.NewInt(0),
+		}
+	}
+
+	// the effective gas price is the base fee plus the tip
+	dynamicFeeTx := func(tip int64) *types.Transaction {
+		return &types.Transaction{
+			Type:      types.DynamicFeeTx,
+			From:      sender,
+			To:        &receiver,
+			Gas:       21_000,
+			GasFeeCap: big.NewInt(200),
+			GasTipCap: big.NewInt(tip),
+			Value:     big.NewInt(0),
+		}
+	}
+
+	tests := []struct {
+		name   string
+		tx     *types.Transaction
+		reject bool
+	}{
+		{"legacy below the floor", legacyTx(minBaseFee - 1), true},
+		{"legacy at the floor", legacyTx(minBaseFee), false},
+		{"legacy above the floor", legacyTx(minBaseFee + 1), false},
+		{"dynamic fee below the floor", dynamicFeeTx(minBaseFee - baseFee - 1), true},
+		{"dynamic fee at the floor", dynamicFeeTx(minBaseFee - baseFee), false},
+		{"dynamic fee above the floor", dynamicFeeTx(minBaseFee - baseFee + 1), false},
+	}
+
+	for _, tt := range tests {
+		err := checkAndProcessTx(tt.tx, newTransition(true))
+		if tt.reject {
+			assert.ErrorIs(t, err, ErrGasPriceBelowMinBaseFee, tt.name)
+		} else {
+			assert.NoError(t, err, tt.name)
+		}
+
+		// no floor without the registry
+		assert.NoError(t, checkAndProcessTx(tt.tx, newTransition(false)), tt.name)
+	}
+}
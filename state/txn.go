@@ -1,6 +1,7 @@
 package state
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"math/big"
@@ -28,6 +29,12 @@ var (
 
 	// refundIndex is the index of the refund
 	refundIndex = types.BytesToHash([]byte{3}).Bytes()
+
+	// transientPrefix is the prefix of the transient storage (EIP-1153) slots in the trie
+	transientPrefix = []byte("transient")
+
+	// createdPrefix is the prefix of the accounts created in the current transaction (EIP-6780)
+	createdPrefix = []byte("created")
 )
 
 // Txn is a reference of the state
@@ -253,7 +260,7 @@ func (txn *Txn) SetStorage(
 		}
 
 		if value == types.ZeroHash { // delete slot (2.1.2b)
-			txn.AddRefund(15000)
+			txn.AddRefund(sstoreClearsRefund(config))
 
 			return runtime.StorageDeleted
 		}
@@ -263,22 +270,26 @@ func (txn *Txn) SetStorage(
 
 	if original != types.ZeroHash { // Storage slot was populated before this transaction started
 		if current == types.ZeroHash { // recreate slot (2.2.1.1)
-			txn.SubRefund(15000)
+			txn.SubRefund(sstoreClearsRefund(config))
 		} else if value == types.ZeroHash { // delete slot (2.2.1.2)
-			txn.AddRefund(15000)
+			txn.AddRefund(sstoreClearsRefund(config))
 		}
 	}
 
 	if original == value {
 		if original == types.ZeroHash { // reset to original nonexistent slot (2.2.2.1)
 			// Storage was used as memory (allocation and deallocation occurred within the same contract)
-			if config.Istanbul {
+			if config.EIP3529 {
+				txn.AddRefund(19900) // SSTORE_SET_GAS - WARM_STORAGE_READ_COST
+			} else if config.Istanbul {
 				txn.AddRefund(19200)
 			} else {
 				txn.AddRefund(19800)
 			}
 		} else { // reset to original existing slot (2.2.2.2)
-			if config.Istanbul {
+			if config.EIP3529 {
+				txn.AddRefund(2800) // SSTORE_RESET_GAS - COLD_SLOAD_COST - WARM_STORAGE_READ_COST
+			} else if config.Istanbul {
 				txn.AddRefund(4200)
 			} else {
 				txn.AddRefund(4800)
@@ -289,6 +300,16 @@ func (txn *Txn) SetStorage(
 	return runtime.StorageModifiedAgain
 }
 
+// sstoreClearsRefund returns the refund for clearing a storage slot,
+// EIP-3529 reduced it to SSTORE_RESET_GAS - COLD_SLOAD_COST + ACCESS_LIST_STORAGE_KEY_COST
+func sstoreClearsRefund(config *chain.ForksInTime) uint64 {
+	if config.EIP3529 {
+		return 4800
+	}
+
+	return 15000
+}
+
 // SetState change the state of an address
 func (txn *Txn) SetState(
 	addr types.Address,
@@ -372,10 +393,15 @@ func (txn *Txn) GetNonce(addr types.Address) uint64 {
 
 // Code
 
-// SetCode sets the code for an address
+// SetCode sets the code for an address, empty code resets the code hash to EmptyCodeHash
 func (txn *Txn) SetCode(addr types.Address, code []byte) {
 	txn.upsertAccount(addr, true, func(object *StateObject) {
-		object.Account.CodeHash = crypto.Keccak256(code)
+		if len(code) == 0 {
+			object.Account.CodeHash = types.EmptyCodeHash.Bytes()
+		} else {
+			object.Account.CodeHash = crypto.Keccak256(code)
+		}
+
 		object.DirtyCode = true
 		object.Code = code
 	})
@@ -477,6 +503,67 @@ func (txn *Txn) GetRefund() uint64 {
 	return data.(uint64)
 }
 
+// Transient storage (EIP-1153)
+
+func transientKey(addr types.Address, key types.Hash) []byte {
+	k := make([]byte, 0, len(transientPrefix)+types.AddressLength+types.HashLength)
+	k = append(k, transientPrefix...)
+	k = append(k, addr.Bytes()...)
+
+	return append(k, key.Bytes()...)
+}
+
+// GetTransientState returns the value of a transient storage slot of the address
+func (txn *Txn) GetTransientState(addr types.Address, key types.Hash) types.Hash {
+	val, exists := txn.txn.Get(transientKey(addr, key))
+	if !exists {
+		return types.ZeroHash
+	}
+
+	//nolint:forcetypeassert
+	return val.(types.Hash)
+}
+
+// SetTransientState sets the value of a transient storage slot of the address.
+// The slot is reverted together with the rest of the state on snapshot reverts
+func (txn *Txn) SetTransientState(addr types.Address, key types.Hash, value types.Hash) {
+	txn.txn.Insert(transientKey(addr, key), value)
+}
+
+// ClearTransientStorage drops the transient storage of all accounts,
+// it is called at the start and at the end of every transaction
+func (txn *Txn) ClearTransientStorage() {
+	txn.txn.DeletePrefix(transientPrefix)
+}
+
+// Accounts created in the current transaction (EIP-6780)
+
+func createdKey(addr types.Address) []byte {
+	k := make([]byte, 0, len(createdPrefix)+types.AddressLength)
+	k = append(k, createdPrefix...)
+
+	return append(k, addr.Bytes()...)
+}
+
+// MarkCreated records that the contract at the address was created in the current transaction.
+// The mark is reverted together with the rest of the state on snapshot reverts
+func (txn *Txn) MarkCreated(addr types.Address) {
+	txn.txn.Insert(createdKey(addr), true)
+}
+
+// IsCreated returns true if the contract at the address was created in the current transaction
+func (txn *Txn) IsCreated(addr types.Address) bool {
+	_, exists := txn.txn.Get(createdKey(addr))
+
+	return exists
+}
+
+// ClearCreated drops the marks of the accounts created in the transaction,
+// it is called at the end of every transaction
+func (txn *Txn) ClearCreated() {
+	txn.txn.DeletePrefix(createdPrefix)
+}
+
 // GetCommittedState returns the state of the address in the trie
 func (txn *Txn) GetCommittedState(addr types.Address, key types.Hash) types.Hash {
 	obj, ok := txn.getStateObject(addr)
@@ -490,13 +577,15 @@ func (txn *Txn) GetCommittedState(addr types.Address, key types.Hash) types.Hash
 // SetFullStorage is used to replace the full state of the address.
 // Only used for debugging on the override jsonrpc endpoint.
 func (txn *Txn) SetFullStorage(addr types.Address, state map[types.Hash]types.Hash) {
-	for k, v := range state {
-		txn.SetState(addr, k, v)
-	}
-
+	// drop the pending writes as well, the account storage is replaced as a whole
 	txn.upsertAccount(addr, true, func(object *StateObject) {
+		object.Txn = iradix.New().Txn()
 		object.withFakeStorage = true
 	})
+
+	for k, v := range state {
+		txn.SetState(addr, k, v)
+	}
 }
 
 func (txn *Txn) TouchAccount(addr types.Address) {
@@ -547,7 +636,9 @@ func (txn *Txn) CreateAccount(addr types.Address) {
 	txn.txn.Insert(addr.Bytes(), obj)
 }
 
-func (txn *Txn) CleanDeleteObjects(deleteEmptyObjects bool) error {
+// CleanDeleteObjects marks the suicided accounts, and the empty ones if deleteEmptyObjects is set,
+// as deleted. It returns the addresses of the accounts deleted by this call.
+func (txn *Txn) CleanDeleteObjects(deleteEmptyObjects bool) ([]types.Address, error) {
 	remove := [][]byte{}
 
 	txn.txn.Root().Walk(func(k []byte, v interface{}) bool {
@@ -555,6 +646,10 @@ func (txn *Txn) CleanDeleteObjects(deleteEmptyObjects bool) error {
 		if !ok {
 			return false
 		}
+		if a.Deleted {
+			// deleted by an earlier call
+			return false
+		}
 		if a.Suicide || a.Empty() && deleteEmptyObjects {
 			remove = append(remove, k)
 		}
@@ -562,30 +657,80 @@ func (txn *Txn) CleanDeleteObjects(deleteEmptyObjects bool) error {
 		return false
 	})
 
+	deleted := make([]types.Address, 0, len(remove))
+
 	for _, k := range remove {
 		v, ok := txn.txn.Get(k)
 		if !ok {
-			return fmt.Errorf("failed to retrieve value for %s key", string(k))
+			return nil, fmt.Errorf("failed to retrieve value for %s key", string(k))
 		}
 
 		obj, ok := v.(*StateObject)
 		if !ok {
-			return errors.New("found object is not of StateObject type")
+			return nil, errors.New("found object is not of StateObject type")
 		}
 
 		obj2 := obj.Copy()
 		obj2.Deleted = true
 		txn.txn.Insert(k, obj2)
+
+		deleted = append(deleted, types.BytesToAddress(k))
 	}
 
 	// delete refunds
 	txn.txn.Delete(refundIndex)
 
-	return nil
+	return deleted, nil
+}
+
+// DirtyAccounts returns the accounts whose balance, nonce, code or storage was modified
+// in the transaction, in address order. Accounts which were only touched are left out.
+func (txn *Txn) DirtyAccounts() []types.Address {
+	dirty := []types.Address{}
+
+	txn.txn.Root().Walk(func(k []byte, v interface{}) bool {
+		obj, ok := v.(*StateObject)
+		if !ok {
+			// logs, refunds and transient storage
+			return false
+		}
+
+		addr := types.BytesToAddress(k)
+		if txn.isDirty(addr, obj) {
+			dirty = append(dirty, addr)
+		}
+
+		return false
+	})
+
+	return dirty
+}
+
+// isDirty compares the state object of the transaction with the account in the snapshot
+func (txn *Txn) isDirty(addr types.Address, obj *StateObject) bool {
+	prev, err := txn.snapshot.GetAccount(addr)
+	if err != nil || prev == nil {
+		// a new account is dirty unless it was removed again
+		return !obj.Deleted && !obj.Suicide
+	}
+
+	if obj.Deleted || obj.Suicide || obj.DirtyCode {
+		return true
+	}
+
+	if obj.Txn != nil {
+		if _, _, written := obj.Txn.Root().Minimum(); written {
+			return true
+		}
+	}
+
+	return obj.Account.Nonce != prev.Nonce ||
+		obj.Account.Balance.Cmp(prev.Balance) != 0 ||
+		!bytes.Equal(obj.Account.CodeHash, prev.CodeHash)
 }
 
 func (txn *Txn) Commit(deleteEmptyObjects bool) ([]*Object, error) {
-	if err := txn.CleanDeleteObjects(deleteEmptyObjects); err != nil {
+	if _, err := txn.CleanDeleteObjects(deleteEmptyObjects); err != nil {
 		return nil, err
 	}
 
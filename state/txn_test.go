@@ -47,10 +47,22 @@ func (m *mockSnapshot) GetCode(hash types.Hash) ([]byte, bool) {
 	return nil, false
 }
 
+func (m *mockSnapshot) GetAccountProof(addr types.Address) ([][]byte, error) {
+	return nil, nil
+}
+
+func (m *mockSnapshot) GetStorageProof(root types.Hash, key types.Hash) ([][]byte, error) {
+	return nil, nil
+}
+
 func (m *mockSnapshot) Commit(objs []*Object) (Snapshot, []byte, error) {
 	return nil, nil, nil
 }
 
+func (m *mockSnapshot) Root(objs []*Object) ([]byte, error) {
+	return nil, nil
+}
+
 func newStateWithPreState(preState map[types.Address]*PreState) Snapshot {
 	return &mockSnapshot{state: preState}
 }
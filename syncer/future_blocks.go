@@ -0,0 +1,176 @@
+package syncer
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/armon/go-metrics"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+var (
+	errFutureBlockTooFar = errors.New("block is too far ahead of the head")
+	errFutureBlocksFull  = errors.New("future block buffer is full")
+)
+
+const (
+	// DefaultFutureBlocksSize is the default maximum number of buffered future blocks
+	DefaultFutureBlocksSize uint64 = 128
+
+	// DefaultFutureBlocksMaxDistance is the default maximum distance of a buffered future block from the head
+	DefaultFutureBlocksMaxDistance uint64 = 1024
+)
+
+// FutureBlocksConfig bounds the buffer of the blocks received ahead of the local head,
+// which are imported in order once the head reaches them
+type FutureBlocksConfig struct {
+	// Size is the maximum number of buffered blocks
+	Size uint64
+
+	// MaxDistance is the maximum number of blocks a buffered block may be ahead of the head,
+	// the peers sending blocks farther ahead are penalized
+	MaxDistance uint64
+}
+
+// futureBlock is a buffered block and the peer it was received from
+type futureBlock struct {
+	block *types.Block
+	from  peer.ID
+}
+
+// futureBlocks is the bounded buffer of the blocks received ahead of the local head.
+// The blocks are deduplicated by hash and taken out in order as the head advances.
+// The bulk sync is the only path receiving full blocks from the peers: the status gossip
+// only announces the head number of a peer, which triggers the bulk sync with it,
+// and the blocks of the consensus are inserted by the consensus itself, never ahead of the head
+type futureBlocks struct {
+	lock sync.Mutex
+
+	size        uint64
+	maxDistance uint64
+
+	byNumber map[uint64][]*futureBlock
+	hashes   map[types.Hash]struct{}
+}
+
+func newFutureBlocks(size, maxDistance uint64) *futureBlocks {
+	return &futureBlocks{
+		size:        size,
+		maxDistance: maxDistance,
+		byNumber:    make(map[uint64][]*futureBlock),
+		hashes:      make(map[types.Hash]struct{}),
+	}
+}
+
+// add buffers the block which is more than one block ahead of the head.
+// It fails for a block farther ahead than the max distance, and for a block
+// higher than all the buffered blocks once the buffer is full. Otherwise the highest
+// buffered block is evicted to make room, as it is the last one the head reaches
+func (f *futureBlocks) add(block *types.Block, from peer.ID, head uint64) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	number := block.Number()
+
+	if number > head && number-head > f.maxDistance {
+		f.dropped()
+
+		return errFutureBlockTooFar
+	}
+
+	if _, ok := f.hashes[block.Hash()]; ok {
+		return nil
+	}
+
+	if uint64(len(f.hashes)) >= f.size {
+		highest := f.highest()
+		if highest <= number {
+			f.dropped()
+
+			return errFutureBlocksFull
+		}
+
+		f.removeLast(highest)
+		f.dropped()
+	}
+
+	f.byNumber[number] = append(f.byNumber[number], &futureBlock{block: block, from: from})
+	f.hashes[block.Hash()] = struct{}{}
+
+	f.updateGauge()
+
+	return nil
+}
+
+// next takes out the buffered blocks following the head, in the order they were received.
+// The buffered blocks at or below the head are discarded, they can't be imported anymore
+func (f *futureBlocks) next(head uint64) []*futureBlock {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	for number, blocks := range f.byNumber {
+		if number <= head {
+			f.remove(number, blocks)
+		}
+	}
+
+	blocks := f.byNumber[head+1]
+	f.remove(head+1, blocks)
+
+	f.updateGauge()
+
+	return blocks
+}
+
+// len returns the number of buffered blocks
+func (f *futureBlocks) len() int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return len(f.hashes)
+}
+
+// highest returns the highest number of the buffered blocks
+func (f *futureBlocks) highest() uint64 {
+	highest := uint64(0)
+
+	for number := range f.byNumber {
+		if number > highest {
+			highest = number
+		}
+	}
+
+	return highest
+}
+
+// removeLast removes the last received block of the given number
+func (f *futureBlocks) removeLast(number uint64) {
+	blocks := f.byNumber[number]
+	last := blocks[len(blocks)-1]
+
+	delete(f.hashes, last.block.Hash())
+
+	if len(blocks) == 1 {
+		delete(f.byNumber, number)
+	} else {
+		f.byNumber[number] = blocks[:len(blocks)-1]
+	}
+}
+
+// remove removes the given blocks of the number
+func (f *futureBlocks) remove(number uint64, blocks []*futureBlock) {
+	for _, b := range blocks {
+		delete(f.hashes, b.block.Hash())
+	}
+
+	delete(f.byNumber, number)
+}
+
+func (f *futureBlocks) dropped() {
+	metrics.IncrCounter([]string{syncerMetrics, "future_blocks_dropped"}, 1)
+}
+
+func (f *futureBlocks) updateGauge() {
+	metrics.SetGauge([]string{syncerMetrics, "future_blocks"}, float32(len(f.hashes)))
+}
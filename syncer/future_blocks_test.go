@@ -0,0 +1,80 @@
+package syncer
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+func newFutureBlock(number uint64, extra byte) *types.Block {
+	return &types.Block{
+		Header: (&types.Header{Number: number, ExtraData: []byte{extra}}).ComputeHash(),
+	}
+}
+
+func futureBlockNumbers(blocks []*futureBlock) []uint64 {
+	numbers := make([]uint64, 0, len(blocks))
+
+	for _, b := range blocks {
+		numbers = append(numbers, b.block.Number())
+	}
+
+	return numbers
+}
+
+func TestFutureBlocks_Add(t *testing.T) {
+	t.Parallel()
+
+	from := peer.ID("A")
+	buffer := newFutureBlocks(3, 10)
+
+	assert.NoError(t, buffer.add(newFutureBlock(5, 0), from, 1))
+	assert.NoError(t, buffer.add(newFutureBlock(3, 0), from, 1))
+
+	// the same block is buffered once
+	assert.NoError(t, buffer.add(newFutureBlock(3, 0), from, 1))
+	assert.Equal(t, 2, buffer.len())
+
+	assert.ErrorIs(t, buffer.add(newFutureBlock(12, 0), from, 1), errFutureBlockTooFar)
+
+	// a second block of the same height fills the buffer
+	assert.NoError(t, buffer.add(newFutureBlock(3, 1), from, 1))
+	assert.Equal(t, 3, buffer.len())
+
+	// a full buffer keeps the blocks nearest to the head
+	assert.ErrorIs(t, buffer.add(newFutureBlock(6, 0), from, 1), errFutureBlocksFull)
+	assert.NoError(t, buffer.add(newFutureBlock(4, 0), from, 1))
+	assert.Equal(t, 3, buffer.len())
+	assert.Empty(t, buffer.next(4))
+	assert.Equal(t, 0, buffer.len())
+}
+
+func TestFutureBlocks_Next(t *testing.T) {
+	t.Parallel()
+
+	from := peer.ID("A")
+	buffer := newFutureBlocks(10, 10)
+
+	for _, number := range []uint64{4, 2, 3, 6} {
+		assert.NoError(t, buffer.add(newFutureBlock(number, 0), from, 0))
+	}
+
+	assert.NoError(t, buffer.add(newFutureBlock(3, 1), from, 0))
+
+	assert.Empty(t, buffer.next(0))
+	assert.Equal(t, []uint64{2}, futureBlockNumbers(buffer.next(1)))
+
+	// both blocks of the height, in the order they were received
+	next := buffer.next(2)
+	assert.Equal(t, []uint64{3, 3}, futureBlockNumbers(next))
+	assert.Equal(t, []byte{0}, next[0].block.Header.ExtraData)
+	assert.Equal(t, from, next[0].from)
+
+	// the head passed the block 4, it is discarded
+	assert.Empty(t, buffer.next(4))
+	assert.Equal(t, 1, buffer.len())
+	assert.Equal(t, []uint64{6}, futureBlockNumbers(buffer.next(5)))
+	assert.Equal(t, 0, buffer.len())
+}
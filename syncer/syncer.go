@@ -3,11 +3,13 @@ package syncer
 import (
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/armon/go-metrics"
 	"github.com/hashicorp/go-hclog"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/xgr-network/xgr-node/blockchain"
 	"github.com/xgr-network/xgr-node/helper/progress"
 	"github.com/xgr-network/xgr-node/network/event"
 	"github.com/xgr-network/xgr-node/types"
@@ -26,10 +28,15 @@ var (
 // This syncer doesn't assume forks
 type syncer struct {
 	logger          hclog.Logger
+	network         Network
 	blockchain      Blockchain
 	syncProgression Progression
 
+	// futureBlocks buffers the blocks received ahead of the head, across the syncs with all peers
+	futureBlocks *futureBlocks
+
 	peerMap         *PeerMap
+	faultyPeers     sync.Map // peers that advertised a branch below the finalized block
 	syncPeerService SyncPeerService
 	syncPeerClient  SyncPeerClient
 
@@ -45,10 +52,13 @@ func NewSyncer(
 	network Network,
 	blockchain Blockchain,
 	blockTimeout time.Duration,
+	futureBlocksConfig FutureBlocksConfig,
 ) Syncer {
 	return &syncer{
 		logger:          logger.Named(syncerName),
+		network:         network,
 		blockchain:      blockchain,
+		futureBlocks:    newFutureBlocks(futureBlocksConfig.Size, futureBlocksConfig.MaxDistance),
 		syncProgression: progress.NewProgressionWrapper(progress.ChainSyncBulk),
 		syncPeerService: NewSyncPeerService(network, blockchain),
 		syncPeerClient:  NewSyncPeerClient(logger, network, blockchain),
@@ -128,6 +138,10 @@ func (s *syncer) initNewPeerStatus(peerID peer.ID) {
 
 // putToPeerMap puts given status to peer map
 func (s *syncer) putToPeerMap(status *NoForkPeer) {
+	if s.isFaultyPeer(status.ID) {
+		return
+	}
+
 	s.peerMap.Put(status)
 	s.notifyNewStatusEvent()
 }
@@ -137,6 +151,21 @@ func (s *syncer) removeFromPeerMap(peerID peer.ID) {
 	s.peerMap.Remove(peerID)
 }
 
+// markFaultyPeer stops syncing from a peer that served a branch we must never switch to
+func (s *syncer) markFaultyPeer(peerID peer.ID) {
+	metrics.IncrCounter([]string{syncerMetrics, "faulty_peer"}, 1)
+
+	s.faultyPeers.Store(peerID, struct{}{})
+	s.removeFromPeerMap(peerID)
+}
+
+// isFaultyPeer returns whether the peer was marked as faulty
+func (s *syncer) isFaultyPeer(peerID peer.ID) bool {
+	_, ok := s.faultyPeers.Load(peerID)
+
+	return ok
+}
+
 // notifyNewStatusEvent emits signal to newStatusCh
 func (s *syncer) notifyNewStatusEvent() {
 	select {
@@ -191,6 +220,13 @@ func (s *syncer) Sync(callback func(*types.FullBlock) bool) error {
 		lastNumber, shouldTerminate, err := s.bulkSyncWithPeer(bestPeer.ID, bestPeer.Number, callback)
 		if err != nil {
 			s.logger.Warn("failed to complete bulk sync with peer, try to next one", "peer ID", "error", bestPeer.ID, err)
+
+			if errors.Is(err, blockchain.ErrReorgBelowFinalized) {
+				s.logger.Error("peer advertises a branch below the finalized block, marking it faulty", "peer ID", bestPeer.ID)
+				s.markFaultyPeer(bestPeer.ID)
+
+				continue
+			}
 		}
 
 		if lastNumber < bestPeer.Number {
@@ -235,7 +271,10 @@ func (s *syncer) bulkSyncWithPeer(peerID peer.ID, peerLatestBlock uint64,
 		s.blockchain.UnsubscribeEvents(subscription)
 	}()
 
-	var lastReceivedNumber uint64
+	var (
+		lastReceivedNumber uint64
+		head               = localLatest
+	)
 
 	for {
 		select {
@@ -249,29 +288,102 @@ func (s *syncer) bulkSyncWithPeer(peerID peer.ID, peerLatestBlock uint64,
 				continue
 			}
 
-			fullBlock, err := s.blockchain.VerifyFinalizedBlock(block)
-			if err != nil {
-				metrics.IncrCounter([]string{syncerMetrics, "bad_block"}, 1)
+			// a block ahead of the next one waits in the buffer until the head reaches it
+			if block.Number() > head+1 {
+				s.bufferFutureBlock(block, peerID, head)
 
-				return lastReceivedNumber, false, fmt.Errorf("unable to verify block, %w", err)
+				continue
 			}
 
-			if err := s.blockchain.WriteFullBlock(fullBlock, syncerName); err != nil {
-				metrics.IncrCounter([]string{syncerMetrics, "bad_block"}, 1)
-
-				return lastReceivedNumber, false, fmt.Errorf("failed to write block while bulk syncing: %w", err)
+			if err := s.importBlock(block, newBlockCallback, &shouldTerminate); err != nil {
+				return lastReceivedNumber, false, err
 			}
 
-			updateMetrics(fullBlock)
-			shouldTerminate = newBlockCallback(fullBlock)
-
 			lastReceivedNumber = block.Number()
+			head = lastReceivedNumber
+
+			if number := s.importFutureBlocks(head, newBlockCallback, &shouldTerminate); number > head {
+				lastReceivedNumber = number
+				head = number
+			}
 		case <-time.After(s.blockTimeout):
 			return lastReceivedNumber, shouldTerminate, errTimeout
 		}
 	}
 }
 
+// importBlock verifies and writes the block, then passes it to the callback
+func (s *syncer) importBlock(block *types.Block, newBlockCallback func(*types.FullBlock) bool,
+	shouldTerminate *bool) error {
+	fullBlock, err := s.blockchain.VerifyFinalizedBlock(block)
+	if err != nil {
+		metrics.IncrCounter([]string{syncerMetrics, "bad_block"}, 1)
+
+		return fmt.Errorf("unable to verify block, %w", err)
+	}
+
+	if err := s.blockchain.WriteFullBlock(fullBlock, syncerName); err != nil {
+		metrics.IncrCounter([]string{syncerMetrics, "bad_block"}, 1)
+
+		return fmt.Errorf("failed to write block while bulk syncing: %w", err)
+	}
+
+	updateMetrics(fullBlock)
+	*shouldTerminate = newBlockCallback(fullBlock)
+
+	return nil
+}
+
+// bufferFutureBlock adds the block received ahead of the head to the future block buffer.
+// The peer is penalized if the block is too far ahead, it can't be a block of the canonical chain soon
+func (s *syncer) bufferFutureBlock(block *types.Block, from peer.ID, head uint64) {
+	err := s.futureBlocks.add(block, from, head)
+	if err == nil {
+		return
+	}
+
+	s.logger.Debug("future block dropped", "number", block.Number(), "hash", block.Hash(),
+		"head", head, "peer", from, "err", err)
+
+	if errors.Is(err, errFutureBlockTooFar) {
+		s.network.PenalizePeer(from, fmt.Sprintf("sent block %d, too far ahead of the head %d", block.Number(), head))
+	}
+}
+
+// importFutureBlocks imports the buffered blocks following the head in order, as long as they connect.
+// Of several buffered blocks of a height, the first one which imports wins.
+// It returns the number of the last imported block, or the head if none was imported
+func (s *syncer) importFutureBlocks(head uint64, newBlockCallback func(*types.FullBlock) bool,
+	shouldTerminate *bool) uint64 {
+	for {
+		candidates := s.futureBlocks.next(head)
+		if len(candidates) == 0 {
+			return head
+		}
+
+		imported := false
+
+		for _, candidate := range candidates {
+			if err := s.importBlock(candidate.block, newBlockCallback, shouldTerminate); err != nil {
+				s.logger.Debug("failed to import future block", "number", candidate.block.Number(),
+					"peer", candidate.from, "err", err)
+
+				continue
+			}
+
+			imported = true
+
+			break
+		}
+
+		if !imported {
+			return head
+		}
+
+		head++
+	}
+}
+
 func updateMetrics(fullBlock *types.FullBlock) {
 	metrics.SetGauge([]string{syncerMetrics, "tx_num"}, float32(len(fullBlock.Block.Transactions)))
 	metrics.SetGauge([]string{syncerMetrics, "receipts_num"}, float32(len(fullBlock.Receipts)))
@@ -169,7 +169,9 @@ func NewTestSyncer(
 ) *syncer {
 	return &syncer{
 		logger:          hclog.NewNullLogger(),
+		network:         network,
 		blockchain:      blockchain,
+		futureBlocks:    newFutureBlocks(DefaultFutureBlocksSize, DefaultFutureBlocksMaxDistance),
 		syncProgression: mockProgression,
 		syncPeerService: &mockSyncPeerService{},
 		syncPeerClient:  mockSyncPeerClient,
@@ -592,7 +594,7 @@ func TestSync(t *testing.T) {
 				}
 			},
 			blocks:             blocks[:10],
-			progressionStart:   1,
+			progressionStart:   5, // the second sync continues from the head reached with the first peer
 			progressionHighest: 10,
 			err:                nil,
 		},
@@ -612,7 +614,9 @@ func TestSync(t *testing.T) {
 				syncer = NewTestSyncer(
 					nil,
 					&mockBlockchain{
-						headerHandler:               newSimpleHeaderHandler(latestBlockNumber),
+						headerHandler: func() *types.Header {
+							return &types.Header{Number: latestBlockNumber}
+						},
 						verifyFinalizedBlockHandler: test.createVerifyFinalizedBlockHandler(),
 						writeFullBlockHandler: func(b *types.FullBlock) error {
 							syncedBlocks = append(syncedBlocks, b.Block)
@@ -660,6 +664,80 @@ func TestSync(t *testing.T) {
 	}
 }
 
+func TestSync_FaultyPeerBelowFinalized(t *testing.T) {
+	t.Parallel()
+
+	var (
+		blocks       = createMockBlocks(10)
+		syncedBlocks = make([]*types.Block, 0, len(blocks))
+		faultyPeer   = &NoForkPeer{ID: peer.ID("A"), Number: 10, Distance: big.NewInt(0)}
+		honestPeer   = &NoForkPeer{ID: peer.ID("B"), Number: 10, Distance: big.NewInt(1)}
+		peerBlocksCh = map[peer.ID]<-chan *types.Block{
+			faultyPeer.ID: blocksToCh(blocks[:10], 0),
+			honestPeer.ID: blocksToCh(blocks[4:10], 0),
+		}
+		rejected = false
+	)
+
+	syncer := NewTestSyncer(
+		nil,
+		&mockBlockchain{
+			// the head follows the synced blocks, so the blocks of the honest peer connect to it
+			headerHandler: func() *types.Header {
+				return &types.Header{Number: uint64(len(syncedBlocks))}
+			},
+			verifyFinalizedBlockHandler: func(b *types.Block) (*types.FullBlock, error) {
+				return &types.FullBlock{Block: b}, nil
+			},
+			writeFullBlockHandler: func(b *types.FullBlock) error {
+				// the faulty peer serves a branch that would reorg below the finalized block
+				if b.Block.Number() == 5 && !rejected {
+					rejected = true
+
+					return blockchain.ErrReorgBelowFinalized
+				}
+
+				syncedBlocks = append(syncedBlocks, b.Block)
+
+				return nil
+			},
+		},
+		time.Second,
+		&mockSyncPeerClient{
+			getBlocksHandler: func(i peer.ID, u uint64, _ time.Duration) (<-chan *types.Block, error) {
+				return peerBlocksCh[i], nil
+			},
+		},
+		&mockProgression{},
+	)
+
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- syncer.Sync(func(b *types.FullBlock) bool {
+			return b.Block.Number() >= 10
+		})
+	}()
+
+	syncer.peerMap.Put(faultyPeer)
+	syncer.newStatusCh <- struct{}{}
+
+	syncer.peerMap.Put(honestPeer)
+	syncer.newStatusCh <- struct{}{}
+
+	assert.NoError(t, <-errCh)
+	assert.Equal(t, blocks[:10], syncedBlocks)
+
+	// the faulty peer is dropped and its status updates are ignored
+	assert.True(t, syncer.isFaultyPeer(faultyPeer.ID))
+	assert.False(t, syncer.isFaultyPeer(honestPeer.ID))
+
+	syncer.putToPeerMap(faultyPeer)
+
+	peers := GetAllElementsFromPeerMap(t, syncer.peerMap)
+	assert.Equal(t, []*NoForkPeer{honestPeer}, peers)
+}
+
 func Test_bulkSyncWithPeer(t *testing.T) {
 	t.Parallel()
 
@@ -858,3 +936,89 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 		})
 	}
 }
+
+// penaltyNetwork records the penalties of the peers
+type penaltyNetwork struct {
+	Network
+
+	penalties []peer.ID
+}
+
+func (n *penaltyNetwork) PenalizePeer(peerID peer.ID, _ string) {
+	n.penalties = append(n.penalties, peerID)
+}
+
+func Test_bulkSyncWithPeer_FutureBlocks(t *testing.T) {
+	t.Parallel()
+
+	blocks := make([]*types.Block, 6) // 1 to 6
+
+	for i := range blocks {
+		blocks[i] = &types.Block{
+			Header: (&types.Header{Number: uint64(i + 1)}).ComputeHash(),
+		}
+	}
+
+	farBlock := &types.Block{
+		Header: (&types.Header{Number: 100}).ComputeHash(),
+	}
+
+	var (
+		network      = &penaltyNetwork{}
+		syncedBlocks = make([]*types.Block, 0, len(blocks))
+		head         = uint64(0)
+	)
+
+	syncer := NewTestSyncer(
+		network,
+		&mockBlockchain{
+			headerHandler: func() *types.Header {
+				return &types.Header{Number: head}
+			},
+			verifyFinalizedBlockHandler: func(b *types.Block) (*types.FullBlock, error) {
+				if b.Number() != head+1 {
+					return nil, errors.New("block doesn't follow the head")
+				}
+
+				return &types.FullBlock{Block: b}, nil
+			},
+			writeFullBlockHandler: func(b *types.FullBlock) error {
+				syncedBlocks = append(syncedBlocks, b.Block)
+				head = b.Block.Number()
+
+				return nil
+			},
+		},
+		time.Second,
+		&mockSyncPeerClient{
+			getBlocksHandler: func(id peer.ID, start uint64, _ time.Duration) (<-chan *types.Block, error) {
+				if id == peer.ID("A") {
+					// out of order, with a duplicate, a gap at 4 and a block far ahead
+					return blocksToCh([]*types.Block{
+						blocks[2], blocks[0], blocks[4], blocks[2], farBlock, blocks[1], blocks[5],
+					}, 0), nil
+				}
+
+				// the other peer serves the missing block
+				return blocksToCh([]*types.Block{blocks[3]}, 0), nil
+			},
+		},
+		&mockProgression{},
+	)
+	syncer.futureBlocks.maxDistance = 10
+
+	lastSynced, _, err := syncer.bulkSyncWithPeer(peer.ID("A"), 6, func(*types.FullBlock) bool { return false })
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), lastSynced)
+	assert.Equal(t, blocks[:3], syncedBlocks)
+
+	// blocks 5 and 6 wait for the gap, the block far ahead is dropped and its peer penalized
+	assert.Equal(t, 2, syncer.futureBlocks.len())
+	assert.Equal(t, []peer.ID{peer.ID("A")}, network.penalties)
+
+	lastSynced, _, err = syncer.bulkSyncWithPeer(peer.ID("B"), 6, func(*types.FullBlock) bool { return false })
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(6), lastSynced)
+	assert.Equal(t, blocks, syncedBlocks)
+	assert.Equal(t, 0, syncer.futureBlocks.len())
+}
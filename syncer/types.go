@@ -57,6 +57,8 @@ type Network interface {
 	SaveProtocolStream(protocol string, stream *rawGrpc.ClientConn, peerID peer.ID)
 	// CloseProtocolStream closes stream
 	CloseProtocolStream(protocol string, peerID peer.ID) error
+	// PenalizePeer records a penalty of the peer for misbehavior
+	PenalizePeer(peerID peer.ID, reason string)
 }
 
 type Syncer interface {
@@ -0,0 +1,256 @@
+// feesplit replays a block on top of the state of its parent and prints the gas used and the
+// donation/validator/burn split of every transaction, so the fee distribution can be audited offline.
+//
+// Run: go run ./tools/feesplit --genesis genesis.json --triedb data/trie --parent-root 0x... --block block.rlp
+//
+// The block file holds the block RLP, either binary or as hex string. The trie db is the state
+// snapshot of a node (data/trie), it is opened read only and never modified.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/xgr-network/xgr-node/chain"
+	"github.com/xgr-network/xgr-node/crypto"
+	"github.com/xgr-network/xgr-node/helper/hex"
+	"github.com/xgr-network/xgr-node/state"
+	itrie "github.com/xgr-network/xgr-node/state/immutable-trie"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+// txFeeSplit is the gas used and the fee split of a replayed transaction
+type txFeeSplit struct {
+	Hash      types.Hash
+	GasUsed   uint64
+	Donation  *big.Int
+	Validator *big.Int
+	Burned    *big.Int
+}
+
+// replayResult is the outcome of a replayed block
+type replayResult struct {
+	Number    uint64
+	Txs       []*txFeeSplit
+	GasUsed   uint64
+	Fees      *types.BlockEconomics
+	StateRoot types.Hash
+}
+
+// replayBlock processes the block on top of the parent state and collects the fee split of
+// every transaction. The senders of the transactions are recovered if they are not set.
+func replayBlock(
+	params *chain.Params,
+	st state.State,
+	parentRoot types.Hash,
+	block *types.Block,
+	coinbase types.Address,
+) (*replayResult, error) {
+	signer := crypto.NewSigner(params.Forks.At(block.Number()), uint64(params.ChainID))
+
+	for i, tx := range block.Transactions {
+		if tx.From != types.ZeroAddress || tx.Type == types.StateTx {
+			continue
+		}
+
+		sender, err := signer.Sender(tx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to recover the sender of transaction %d (%s): %w", i, tx.Hash, err)
+		}
+
+		tx.From = sender
+	}
+
+	executor := state.NewExecutor(params, st, hclog.NewNullLogger())
+
+	// the chain db is not available, the BLOCKHASH opcode yields the zero hash
+	executor.GetHash = func(*types.Header) state.GetHashByNumber {
+		return func(uint64) types.Hash {
+			return types.ZeroHash
+		}
+	}
+
+	txn, err := executor.ProcessBlock(parentRoot, block, coinbase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process block %d: %w", block.Number(), err)
+	}
+
+	result := &replayResult{
+		Number:  block.Number(),
+		GasUsed: txn.TotalGas(),
+		Fees:    txn.FeeSummary(),
+	}
+
+	for _, receipt := range txn.Receipts() {
+		split := &txFeeSplit{
+			Hash:      receipt.TxHash,
+			GasUsed:   receipt.GasUsed,
+			Donation:  new(big.Int),
+			Validator: new(big.Int),
+			Burned:    new(big.Int),
+		}
+
+		if receipt.FeeSplit != nil {
+			split.Donation.Set(receipt.FeeSplit.Donation)
+			split.Validator.Set(receipt.FeeSplit.Validator)
+			split.Burned.Set(receipt.FeeSplit.Burned)
+		}
+
+		result.Txs = append(result.Txs, split)
+	}
+
+	// the state root of the replayed block is compared with the header
+	if _, result.StateRoot, err = txn.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit block %d: %w", block.Number(), err)
+	}
+
+	return result, nil
+}
+
+// print writes the fee split of every transaction and the totals of the block
+func (r *replayResult) print(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+
+	fmt.Fprintf(tw, "#\tHASH\tGAS USED\tDONATION\tVALIDATOR\tBURNED\t\n")
+
+	for i, tx := range r.Txs {
+		fmt.Fprintf(tw, "%d\t%s\t%d\t%s\t%s\t%s\t\n",
+			i, tx.Hash, tx.GasUsed, tx.Donation, tx.Validator, tx.Burned)
+	}
+
+	fmt.Fprintf(tw, "TOTAL\t\t%d\t%s\t%s\t%s\t\n",
+		r.GasUsed, r.Fees.TotalDonated, r.Fees.TotalValidatorFees, r.Fees.TotalBurned)
+
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "block %d, %d transactions, state root %s\n", r.Number, len(r.Txs), r.StateRoot)
+
+	return err
+}
+
+// readBlock reads the block RLP from the file, either binary or as hex string
+func readBlock(path string) (*types.Block, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if text := strings.TrimSpace(string(raw)); strings.HasPrefix(text, "0x") {
+		if raw, err = hex.DecodeHex(text); err != nil {
+			return nil, fmt.Errorf("invalid hex block: %w", err)
+		}
+	}
+
+	block := &types.Block{}
+	if err := block.UnmarshalRLP(raw); err != nil {
+		return nil, fmt.Errorf("invalid block RLP: %w", err)
+	}
+
+	return block, nil
+}
+
+func run(args []string, stdout io.Writer) error {
+	var (
+		flags = flag.NewFlagSet("feesplit", flag.ContinueOnError)
+
+		genesisPath = flags.String("genesis", "genesis.json", "path to the genesis file with the chain params")
+		triePath    = flags.String("triedb", "", "path to the state trie db (data/trie)")
+		parentRoot  = flags.String("parent-root", "", "state root of the parent block")
+		blockPath   = flags.String("block", "", "path to the block RLP (binary or hex)")
+		coinbase    = flags.String("coinbase", "", "address credited with the validator fee (default: header miner)")
+	)
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *triePath == "" || *parentRoot == "" || *blockPath == "" {
+		return errors.New("--triedb, --parent-root and --block are required")
+	}
+
+	chainConfig, err := chain.ImportFromFile(*genesisPath)
+	if err != nil {
+		return fmt.Errorf("failed to read the genesis file: %w", err)
+	}
+
+	block, err := readBlock(*blockPath)
+	if err != nil {
+		return err
+	}
+
+	root := types.Hash{}
+	if err := root.UnmarshalText([]byte(*parentRoot)); err != nil {
+		return fmt.Errorf("invalid parent root: %w", err)
+	}
+
+	creator := types.BytesToAddress(block.Header.Miner)
+	if *coinbase != "" {
+		if err := creator.UnmarshalText([]byte(*coinbase)); err != nil {
+			return fmt.Errorf("invalid coinbase: %w", err)
+		}
+	}
+
+	trieDB, err := leveldb.OpenFile(*triePath, &opt.Options{ReadOnly: true})
+	if err != nil {
+		return fmt.Errorf("failed to open the trie db: %w", err)
+	}
+	defer trieDB.Close()
+
+	storage := readOnlyStorage{itrie.NewKV(trieDB)}
+
+	result, err := replayBlock(chainConfig.Params, itrie.NewState(storage), root, block, creator)
+	if err != nil {
+		return err
+	}
+
+	if result.StateRoot != block.Header.StateRoot {
+		fmt.Fprintf(stdout, "warning: state root %s differs from the header state root %s\n",
+			result.StateRoot, block.Header.StateRoot)
+	}
+
+	return result.print(stdout)
+}
+
+// readOnlyStorage drops the trie nodes and code written by the commit of the replayed block,
+// the trie db is opened read only
+type readOnlyStorage struct {
+	itrie.Storage
+}
+
+func (s readOnlyStorage) Put(k, v []byte) error {
+	return nil
+}
+
+func (s readOnlyStorage) SetCode(hash types.Hash, code []byte) error {
+	return nil
+}
+
+func (s readOnlyStorage) Batch() itrie.Batch {
+	return nopBatch{}
+}
+
+type nopBatch struct{}
+
+func (nopBatch) Put(k, v []byte) {}
+
+func (nopBatch) Write() error {
+	return nil
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bytes"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+	"github.com/xgr-network/xgr-node/chain"
+	"github.com/xgr-network/xgr-node/helper/hex"
+	"github.com/xgr-network/xgr-node/state"
+	itrie "github.com/xgr-network/xgr-node/state/immutable-trie"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+func TestReplayBlock_PrintsFeeSplit(t *testing.T) {
+	var (
+		sender   = types.StringToAddress("0x700")
+		receiver = types.StringToAddress("0x800")
+		coinbase = types.StringToAddress("0x900")
+		gasPrice = uint64(1_000_000_000)
+	)
+
+	params := &chain.Params{
+		Forks: chain.AllForksEnabled,
+		BurnContract: map[uint64]types.Address{
+			0: types.ZeroAddress,
+		},
+	}
+
+	st := itrie.NewState(itrie.NewMemoryStorage())
+
+	parentRoot, err := state.NewExecutor(params, st, hclog.NewNullLogger()).WriteGenesis(
+		map[types.Address]*chain.GenesisAccount{
+			sender: {Balance: new(big.Int).SetUint64(1_000_000_000_000_000_000)},
+		}, types.ZeroHash)
+	require.NoError(t, err)
+
+	block := &types.Block{
+		Header: &types.Header{Number: 1, GasLimit: 1_000_000, Miner: coinbase.Bytes()},
+	}
+
+	for nonce := uint64(0); nonce < 2; nonce++ {
+		tx := &types.Transaction{
+			From:     sender,
+			To:       &receiver,
+			Nonce:    nonce,
+			Gas:      50_000,
+			GasPrice: new(big.Int).SetUint64(gasPrice),
+			Value:    big.NewInt(1),
+		}
+		tx.ComputeHash(1)
+
+		block.Transactions = append(block.Transactions, tx)
+	}
+
+	// the block is read from its hex RLP
+	blockPath := filepath.Join(t.TempDir(), "block.rlp")
+	require.NoError(t, os.WriteFile(blockPath, []byte(hex.EncodeToHex(block.MarshalRLP())+"\n"), 0600))
+
+	decoded, err := readBlock(blockPath)
+	require.NoError(t, err)
+	require.Len(t, decoded.Transactions, 2)
+
+	// the decoded txs have no sender, the tool only recovers signed txs
+	for i, tx := range decoded.Transactions {
+		tx.From = block.Transactions[i].From
+	}
+
+	result, err := replayBlock(params, st, parentRoot, decoded, coinbase)
+	require.NoError(t, err)
+	require.Len(t, result.Txs, 2)
+
+	// a transfer uses 21000 gas, the fixed burn is taken before the donation split
+	fee := 21_000 * gasPrice
+	donation := (fee - chain.FixedBurnWei) * chain.DefaultDonationPercent / 100
+	validator := fee - chain.FixedBurnWei - donation
+
+	for _, tx := range result.Txs {
+		require.Equal(t, uint64(21_000), tx.GasUsed)
+		require.Equal(t, donation, tx.Donation.Uint64())
+		require.Equal(t, validator, tx.Validator.Uint64())
+		require.Equal(t, chain.FixedBurnWei, tx.Burned.Uint64())
+	}
+
+	var out bytes.Buffer
+
+	require.NoError(t, result.print(&out))
+
+	var totals []string
+
+	for _, line := range strings.Split(out.String(), "\n") {
+		if fields := strings.Fields(line); len(fields) > 0 && fields[0] == "TOTAL" {
+			totals = fields[1:]
+		}
+	}
+
+	require.Equal(t, []string{
+		"42000",
+		strconv.FormatUint(2*donation, 10),
+		strconv.FormatUint(2*validator, 10),
+		strconv.FormatUint(2*chain.FixedBurnWei, 10),
+	}, totals)
+	require.Contains(t, out.String(), "block 1, 2 transactions")
+}
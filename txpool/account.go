@@ -1,6 +1,7 @@
 package txpool
 
 import (
+	"bytes"
 	"sync"
 	"sync/atomic"
 
@@ -67,6 +68,55 @@ func (m *accountsMap) getPrimaries() (primaries []*types.Transaction) {
 	return primaries
 }
 
+// heaviest returns the account holding the most transactions (more than moreThan) that has
+// enqueued transactions, skipping the account of exclude. Ties are broken by address.
+// Accounts locked by a concurrent add are skipped, the caller may hold the locks of its own account.
+func (m *accountsMap) heaviest(exclude types.Address, moreThan uint64) *account {
+	var (
+		heaviest     *account
+		heaviestAddr types.Address
+		heaviestTxs  uint64
+	)
+
+	m.Range(func(key, value interface{}) bool {
+		addr, _ := key.(types.Address)
+		if addr == exclude {
+			return true
+		}
+
+		account, _ := value.(*account)
+
+		if !account.promoted.tryLock(false) {
+			return true
+		}
+
+		if !account.enqueued.tryLock(false) {
+			account.promoted.unlock()
+
+			return true
+		}
+
+		enqueued := account.enqueued.length()
+		txs := account.promoted.length() + enqueued
+
+		account.enqueued.unlock()
+		account.promoted.unlock()
+
+		if enqueued == 0 || txs <= moreThan {
+			return true
+		}
+
+		if heaviest == nil || txs > heaviestTxs ||
+			(txs == heaviestTxs && bytes.Compare(addr.Bytes(), heaviestAddr.Bytes()) < 0) {
+			heaviest, heaviestAddr, heaviestTxs = account, addr, txs
+		}
+
+		return true
+	})
+
+	return heaviest
+}
+
 // get returns the account associated with the given address.
 func (m *accountsMap) get(addr types.Address) *account {
 	a, ok := m.Load(addr)
@@ -117,8 +167,10 @@ func (m *accountsMap) allTxs(includeEnqueued bool) (
 		account.promoted.lock(false)
 		defer account.promoted.unlock()
 
+		// copy the queues while holding the locks, so the result is not
+		// affected by pool mutations after the call returns
 		if account.promoted.length() != 0 {
-			allPromoted[addr] = account.promoted.queue
+			allPromoted[addr] = append([]*types.Transaction(nil), account.promoted.queue...)
 		}
 
 		if includeEnqueued {
@@ -126,7 +178,7 @@ func (m *accountsMap) allTxs(includeEnqueued bool) (
 			defer account.enqueued.unlock()
 
 			if account.enqueued.length() != 0 {
-				allEnqueued[addr] = account.enqueued.queue
+				allEnqueued[addr] = append([]*types.Transaction(nil), account.enqueued.queue...)
 			}
 		}
 
@@ -151,6 +203,11 @@ func (m *nonceToTxLookup) lock() {
 	m.mutex.Lock()
 }
 
+// tryLock is lock, which gives up and returns false instead of waiting for the lock
+func (m *nonceToTxLookup) tryLock() bool {
+	return m.mutex.TryLock()
+}
+
 func (m *nonceToTxLookup) unlock() {
 	m.mutex.Unlock()
 }
@@ -0,0 +1,173 @@
+package txpool
+
+import (
+	"errors"
+	"io"
+	"sort"
+
+	"github.com/xgr-network/xgr-node/txpool/proto"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+const (
+	// statuses of the transactions in a pool dump
+	dumpStatusPromoted = "promoted"
+	dumpStatusEnqueued = "enqueued"
+	dumpStatusParked   = "parked"
+)
+
+// dump sends the transactions in the pool one account at a time, ordered by address.
+// The transactions of an account are ordered by nonce, then by status
+func (p *TxPool) dump(send func(*proto.TxPoolDumpAccount) error) error {
+	promoted, enqueued := p.accounts.allTxs(true)
+	parked := p.underpriced.bySender()
+
+	addrs := make([]types.Address, 0, len(promoted)+len(enqueued)+len(parked))
+	seen := make(map[types.Address]struct{})
+
+	for _, txs := range []map[types.Address][]*types.Transaction{promoted, enqueued} {
+		for addr := range txs {
+			if _, ok := seen[addr]; !ok {
+				seen[addr] = struct{}{}
+				addrs = append(addrs, addr)
+			}
+		}
+	}
+
+	for addr := range parked {
+		if _, ok := seen[addr]; !ok {
+			seen[addr] = struct{}{}
+			addrs = append(addrs, addr)
+		}
+	}
+
+	sort.Slice(addrs, func(i, j int) bool {
+		return addrs[i].String() < addrs[j].String()
+	})
+
+	stateRoot := p.store.Header().StateRoot
+
+	for _, addr := range addrs {
+		entry := &proto.TxPoolDumpAccount{
+			Address: addr.String(),
+		}
+
+		if account := p.accounts.get(addr); account != nil {
+			entry.Nonce = account.getNonce()
+		} else {
+			entry.Nonce = p.store.GetNonce(stateRoot, addr)
+		}
+
+		nonces := make([]uint64, 0)
+
+		for _, tx := range promoted[addr] {
+			entry.Txns = append(entry.Txns, p.dumpTx(tx, dumpStatusPromoted))
+			nonces = append(nonces, tx.Nonce)
+		}
+
+		for _, tx := range enqueued[addr] {
+			entry.Txns = append(entry.Txns, p.dumpTx(tx, dumpStatusEnqueued))
+			nonces = append(nonces, tx.Nonce)
+		}
+
+		for _, utx := range parked[addr] {
+			entry.Txns = append(entry.Txns, &proto.TxPoolDumpTxn{
+				Hash:    utx.tx.Hash.String(),
+				Raw:     utx.tx.MarshalRLP(),
+				Origin:  utx.origin.String(),
+				Status:  dumpStatusParked,
+				AddedAt: utx.addedAt.UnixMilli(),
+			})
+			nonces = append(nonces, utx.tx.Nonce)
+		}
+
+		sort.Stable(dumpTxnsByNonce{txns: entry.Txns, nonces: nonces})
+
+		if err := send(entry); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// dumpTx returns the dump entry of a transaction in the accounts
+func (p *TxPool) dumpTx(tx *types.Transaction, status string) *proto.TxPoolDumpTxn {
+	txn := &proto.TxPoolDumpTxn{
+		Hash:   tx.Hash.String(),
+		Raw:    tx.MarshalRLP(),
+		Status: status,
+	}
+
+	if meta, ok := p.index.getMeta(tx.Hash); ok {
+		txn.Origin = meta.origin.String()
+		txn.AddedAt = meta.addedAt.UnixMilli()
+	}
+
+	return txn
+}
+
+// load adds the received transactions of a pool dump, until the stream ends.
+// The transactions are added with their dumped origin and aren't gossiped.
+// A transaction the pool rejects is counted as failed, and doesn't stop the load
+func (p *TxPool) load(recv func() (*proto.TxPoolDumpAccount, error)) (*proto.TxPoolLoadResp, error) {
+	if !p.enableLoad {
+		return nil, ErrLoadDisabled
+	}
+
+	resp := &proto.TxPoolLoadResp{}
+
+	for {
+		entry, err := recv()
+		if errors.Is(err, io.EOF) {
+			return resp, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		from := types.Address{}
+		if err := from.UnmarshalText([]byte(entry.Address)); err != nil {
+			return nil, err
+		}
+
+		for _, txn := range entry.Txns {
+			tx := new(types.Transaction)
+			if err := tx.UnmarshalRLP(txn.Raw); err != nil {
+				return nil, err
+			}
+
+			tx.From = from
+
+			origin := local
+			if txn.Origin == gossip.String() {
+				origin = gossip
+			}
+
+			if err := p.addTx(origin, tx); err != nil {
+				p.logger.Debug("failed to load tx", "hash", txn.Hash, "err", err)
+
+				resp.Failed++
+
+				continue
+			}
+
+			resp.Added++
+		}
+	}
+}
+
+// dumpTxnsByNonce sorts the transactions of a dumped account by nonce,
+// the transactions with the same nonce keep their status order
+type dumpTxnsByNonce struct {
+	txns   []*proto.TxPoolDumpTxn
+	nonces []uint64
+}
+
+func (d dumpTxnsByNonce) Len() int { return len(d.txns) }
+
+func (d dumpTxnsByNonce) Less(i, j int) bool { return d.nonces[i] < d.nonces[j] }
+
+func (d dumpTxnsByNonce) Swap(i, j int) {
+	d.txns[i], d.txns[j] = d.txns[j], d.txns[i]
+	d.nonces[i], d.nonces[j] = d.nonces[j], d.nonces[i]
+}
@@ -0,0 +1,132 @@
+package txpool
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/xgr-network/xgr-node/txpool/proto"
+)
+
+func TestDumpLoad_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	const (
+		accountCount  = 10
+		txsPerAccount = 100
+	)
+
+	newPool := func(t *testing.T) *TxPool {
+		t.Helper()
+
+		pool, err := newTestPool()
+		require.NoError(t, err)
+
+		pool.SetSigner(signerEIP155)
+		pool.Start()
+		t.Cleanup(pool.Close)
+
+		return pool
+	}
+
+	waitForPromoted := func(t *testing.T, pool *TxPool) {
+		t.Helper()
+
+		require.Eventually(t, func() bool {
+			return pool.accounts.promoted() == accountCount/2*txsPerAccount
+		}, 10*time.Second, 10*time.Millisecond)
+	}
+
+	dump := func(t *testing.T, pool *TxPool) []*proto.TxPoolDumpAccount {
+		t.Helper()
+
+		var accounts []*proto.TxPoolDumpAccount
+
+		require.NoError(t, pool.dump(func(account *proto.TxPoolDumpAccount) error {
+			accounts = append(accounts, account)
+
+			return nil
+		}))
+
+		return accounts
+	}
+
+	source := newPool(t)
+
+	for i := 0; i < accountCount; i++ {
+		sender := new(eoa).create(t)
+
+		// the txs of every other account start with a nonce gap, so they stay enqueued
+		firstNonce := uint64(i % 2)
+
+		for nonce := firstNonce; nonce < firstNonce+txsPerAccount; nonce++ {
+			tx := sender.signTx(t, newTx(sender.Address, nonce, 1), signerEIP155)
+			require.NoError(t, source.addTx(local, tx))
+		}
+	}
+
+	waitForPromoted(t, source)
+
+	dumped := dump(t, source)
+	require.Len(t, dumped, accountCount)
+
+	var txs int
+
+	for _, account := range dumped {
+		txs += len(account.Txns)
+
+		for i, txn := range account.Txns {
+			require.Equal(t, local.String(), txn.Origin)
+			require.NotZero(t, txn.AddedAt)
+
+			if i > 0 {
+				require.Equal(t, account.Txns[i-1].Status, txn.Status)
+			}
+		}
+	}
+
+	require.Equal(t, accountCount*txsPerAccount, txs)
+
+	// a dump is only loaded by dev consensus nodes
+	_, err := newPool(t).load(func() (*proto.TxPoolDumpAccount, error) {
+		return nil, io.EOF
+	})
+	require.ErrorIs(t, err, ErrLoadDisabled)
+
+	target := newPool(t)
+	target.enableLoad = true
+
+	next := 0
+
+	resp, err := target.load(func() (*proto.TxPoolDumpAccount, error) {
+		if next == len(dumped) {
+			return nil, io.EOF
+		}
+
+		next++
+
+		return dumped[next-1], nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint64(accountCount*txsPerAccount), resp.Added)
+	require.Zero(t, resp.Failed)
+
+	waitForPromoted(t, target)
+
+	loaded := dump(t, target)
+	require.Len(t, loaded, len(dumped))
+
+	// the pools hold the same txs, only the arrival times differ
+	for i := range dumped {
+		for _, account := range []*proto.TxPoolDumpAccount{dumped[i], loaded[i]} {
+			for _, txn := range account.Txns {
+				txn.AddedAt = 0
+			}
+		}
+
+		require.True(t, protobuf.Equal(dumped[i], loaded[i]), "account %s", dumped[i].Address)
+	}
+}
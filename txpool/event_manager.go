@@ -87,6 +87,11 @@ func (em *eventManager) Close() {
 
 // signalEvent is a helper method for alerting listeners of a new TxPool event
 func (em *eventManager) signalEvent(eventType proto.EventType, txHashes ...types.Hash) {
+	em.signalEventWithReason(eventType, "", txHashes...)
+}
+
+// signalEventWithReason alerts listeners of a new TxPool event, annotated with the reason it happened
+func (em *eventManager) signalEventWithReason(eventType proto.EventType, reason string, txHashes ...types.Hash) {
 	if atomic.LoadInt64(&em.numSubscriptions) < 1 {
 		// No reason to lock the subscriptions map
 		// if no subscriptions exist
@@ -101,6 +106,7 @@ func (em *eventManager) signalEvent(eventType proto.EventType, txHashes ...types
 			subscription.pushEvent(&proto.TxPoolEvent{
 				Type:   eventType,
 				TxHash: txHash.String(),
+				Reason: reason,
 			})
 		}
 	}
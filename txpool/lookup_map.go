@@ -2,19 +2,35 @@ package txpool
 
 import (
 	"sync"
+	"time"
 
 	"github.com/xgr-network/xgr-node/types"
 )
 
+// txMeta holds the origin and the arrival time of a transaction in the pool
+type txMeta struct {
+	origin  txOrigin
+	addedAt time.Time
+}
+
 // Lookup map used to find transactions present in the pool
 type lookupMap struct {
 	sync.RWMutex
-	all map[types.Hash]*types.Transaction
+	all  map[types.Hash]*types.Transaction
+	meta map[types.Hash]txMeta
+}
+
+// newLookupMap returns an empty lookup map
+func newLookupMap() lookupMap {
+	return lookupMap{
+		all:  make(map[types.Hash]*types.Transaction),
+		meta: make(map[types.Hash]txMeta),
+	}
 }
 
 // add inserts the given transaction into the map. Returns false
 // if it already exists. [thread-safe]
-func (m *lookupMap) add(tx *types.Transaction) bool {
+func (m *lookupMap) add(origin txOrigin, tx *types.Transaction) bool {
 	m.Lock()
 	defer m.Unlock()
 
@@ -23,6 +39,7 @@ func (m *lookupMap) add(tx *types.Transaction) bool {
 	}
 
 	m.all[tx.Hash] = tx
+	m.meta[tx.Hash] = txMeta{origin: origin, addedAt: time.Now()}
 
 	return true
 }
@@ -34,6 +51,7 @@ func (m *lookupMap) remove(txs ...*types.Transaction) {
 
 	for _, tx := range txs {
 		delete(m.all, tx.Hash)
+		delete(m.meta, tx.Hash)
 	}
 }
 
@@ -49,3 +67,14 @@ func (m *lookupMap) get(hash types.Hash) (*types.Transaction, bool) {
 
 	return tx, true
 }
+
+// getMeta returns the origin and the arrival time of the transaction
+// associated with the given hash. [thread-safe]
+func (m *lookupMap) getMeta(hash types.Hash) (txMeta, bool) {
+	m.RLock()
+	defer m.RUnlock()
+
+	meta, ok := m.meta[hash]
+
+	return meta, ok
+}
@@ -16,9 +16,12 @@ var mockHeader = &types.Header{
 type defaultMockStore struct {
 	DefaultHeader *types.Header
 
-	getBlockByHashFn   func(types.Hash, bool) (*types.Block, bool)
-	calculateBaseFeeFn func(*types.Header) uint64
-	nonce              uint64
+	getBlockByHashFn       func(types.Hash, bool) (*types.Block, bool)
+	calculateBaseFeeFn     func(*types.Header) uint64
+	minBaseFeeFn           func(*types.Header) uint64
+	bridgeBlocksTransferFn func(*types.Header, types.Address) bool
+	nonce                  uint64
+	codeHashes             map[types.Address]types.Hash
 }
 
 func NewDefaultMockStore(header *types.Header) defaultMockStore {
@@ -52,6 +55,10 @@ func (m defaultMockStore) GetBalance(types.Hash, types.Address) (*big.Int, error
 	return balance, nil
 }
 
+func (m defaultMockStore) GetCodeHash(_ types.Hash, addr types.Address) types.Hash {
+	return m.codeHashes[addr]
+}
+
 func (m defaultMockStore) CalculateBaseFee(header *types.Header) uint64 {
 	if m.calculateBaseFeeFn != nil {
 		return m.calculateBaseFeeFn(header)
@@ -60,6 +67,22 @@ func (m defaultMockStore) CalculateBaseFee(header *types.Header) uint64 {
 	return 0
 }
 
+func (m defaultMockStore) MinBaseFee(header *types.Header) uint64 {
+	if m.minBaseFeeFn != nil {
+		return m.minBaseFeeFn(header)
+	}
+
+	return 0
+}
+
+func (m defaultMockStore) BridgeBlocksTransfer(header *types.Header, to types.Address) bool {
+	if m.bridgeBlocksTransferFn != nil {
+		return m.bridgeBlocksTransferFn(header, to)
+	}
+
+	return false
+}
+
 type faultyMockStore struct {
 }
 
@@ -79,10 +102,22 @@ func (fms faultyMockStore) GetBalance(root types.Hash, addr types.Address) (*big
 	return nil, fmt.Errorf("unable to fetch account state")
 }
 
+func (fms faultyMockStore) GetCodeHash(types.Hash, types.Address) types.Hash {
+	return types.ZeroHash
+}
+
 func (fms faultyMockStore) CalculateBaseFee(*types.Header) uint64 {
 	return 0
 }
 
+func (fms faultyMockStore) MinBaseFee(*types.Header) uint64 {
+	return 0
+}
+
+func (fms faultyMockStore) BridgeBlocksTransfer(*types.Header, types.Address) bool {
+	return false
+}
+
 type mockSigner struct {
 }
 
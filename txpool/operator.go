@@ -3,21 +3,68 @@ package txpool
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/xgr-network/xgr-node/txpool/proto"
 	"github.com/xgr-network/xgr-node/types"
 	empty "google.golang.org/protobuf/types/known/emptypb"
 )
 
-// Status implements the GRPC status endpoint. Returns the number of transactions in the pool
+// Status implements the GRPC status endpoint. Returns the number of transactions in the pool,
+// the per account transaction counts (heaviest accounts first) and the per account limits
 func (p *TxPool) Status(ctx context.Context, req *empty.Empty) (*proto.TxnPoolStatusResp, error) {
 	resp := &proto.TxnPoolStatusResp{
-		Length: p.accounts.promoted(),
+		Length:             p.accounts.promoted(),
+		Accounts:           p.accountStatuses(),
+		MaxAccountTxs:      p.maxAccountTxs,
+		MaxAccountEnqueued: p.accounts.maxEnqueuedLimit,
 	}
 
 	return resp, nil
 }
 
+// accountStatuses returns the transaction counts of the accounts with transactions in the pool,
+// sorted by descending number of transactions, then by address
+func (p *TxPool) accountStatuses() []*proto.AccountStatus {
+	var statuses []*proto.AccountStatus
+
+	p.accounts.Range(func(key, value interface{}) bool {
+		addr, _ := key.(types.Address)
+		account, _ := value.(*account)
+
+		account.promoted.lock(false)
+		account.enqueued.lock(false)
+
+		status := &proto.AccountStatus{
+			Address:  addr.String(),
+			Promoted: account.promoted.length(),
+			Enqueued: account.enqueued.length(),
+		}
+
+		account.enqueued.unlock()
+		account.promoted.unlock()
+
+		if status.Promoted+status.Enqueued > 0 {
+			statuses = append(statuses, status)
+		}
+
+		return true
+	})
+
+	sort.Slice(statuses, func(i, j int) bool {
+		ti := statuses[i].Promoted + statuses[i].Enqueued
+		tj := statuses[j].Promoted + statuses[j].Enqueued
+
+		if ti != tj {
+			return ti > tj
+		}
+
+		return statuses[i].Address < statuses[j].Address
+	})
+
+	return statuses
+}
+
 // AddTxn adds a local transaction to the pool
 func (p *TxPool) AddTxn(ctx context.Context, raw *proto.AddTxnReq) (*proto.AddTxnResp, error) {
 	if raw.Raw == nil {
@@ -83,6 +130,22 @@ func (p *TxPool) Subscribe(
 	}
 }
 
+// Dump implements the operator endpoint. It streams the transactions in the pool, one account at a time
+func (p *TxPool) Dump(_ *empty.Empty, stream proto.TxnPoolOperator_DumpServer) error {
+	return p.dump(stream.Send)
+}
+
+// Load implements the operator endpoint. It adds the streamed transactions of a pool dump,
+// only dev consensus nodes accept it
+func (p *TxPool) Load(stream proto.TxnPoolOperator_LoadServer) error {
+	resp, err := p.load(stream.Recv)
+	if err != nil {
+		return err
+	}
+
+	return stream.SendAndClose(resp)
+}
+
 // TxPoolSubscribe subscribes to new events in the tx pool and returns subscription channel and unsubscribe fn
 func (p *TxPool) TxPoolSubscribe(request *proto.SubscribeRequest) (<-chan *proto.TxPoolEvent, func(), error) {
 	if err := request.ValidateAll(); err != nil {
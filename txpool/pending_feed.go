@@ -0,0 +1,102 @@
+package txpool
+
+import (
+	"sync"
+
+	"github.com/armon/go-metrics"
+	"github.com/xgr-network/xgr-node/types"
+)
+
+// pendingTxFeedBuffer is the number of txs buffered for a pending tx subscriber
+const pendingTxFeedBuffer = 4096
+
+// PendingTx is a tx added to the pool, delivered to the pending tx subscribers
+type PendingTx struct {
+	Tx *types.Transaction
+
+	// Local is true if the tx was submitted through the json-RPC/gRPC endpoints
+	Local bool
+}
+
+// pendingTxFeed delivers the txs added to the pool to in-process subscribers.
+// The pool never waits for a subscriber, a tx not fitting into the buffer
+// of a subscriber is dropped for it.
+type pendingTxFeed struct {
+	lock   sync.RWMutex
+	nextID uint64
+	subs   map[uint64]chan *PendingTx
+}
+
+func newPendingTxFeed() *pendingTxFeed {
+	return &pendingTxFeed{
+		subs: make(map[uint64]chan *PendingTx),
+	}
+}
+
+// subscribe registers a new subscriber and returns its id and channel
+func (f *pendingTxFeed) subscribe() (uint64, <-chan *PendingTx) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.nextID++
+	ch := make(chan *PendingTx, pendingTxFeedBuffer)
+	f.subs[f.nextID] = ch
+
+	return f.nextID, ch
+}
+
+// unsubscribe removes the subscriber and closes its channel
+func (f *pendingTxFeed) unsubscribe(id uint64) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if ch, ok := f.subs[id]; ok {
+		close(ch)
+		delete(f.subs, id)
+	}
+}
+
+// send delivers the tx to all subscribers with room in their buffer
+func (f *pendingTxFeed) send(origin txOrigin, tx *types.Transaction) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	if len(f.subs) == 0 {
+		return
+	}
+
+	pending := &PendingTx{
+		Tx:    tx.Copy(),
+		Local: origin == local,
+	}
+
+	for _, ch := range f.subs {
+		select {
+		case ch <- pending:
+		default:
+			metrics.IncrCounter([]string{txPoolMetrics, "pending_feed_dropped_tx"}, 1)
+		}
+	}
+}
+
+// close closes the channels of all subscribers
+func (f *pendingTxFeed) close() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	for id, ch := range f.subs {
+		close(ch)
+		delete(f.subs, id)
+	}
+}
+
+// SubscribePendingTxs subscribes to the txs added to the pool and returns the subscription
+// channel and unsubscribe fn. The txs are delivered at the time they enter the pool,
+// a subscriber not keeping up loses the txs which don't fit into its buffer.
+func (p *TxPool) SubscribePendingTxs() (<-chan *PendingTx, func()) {
+	id, ch := p.pendingFeed.subscribe()
+
+	return ch, func() {
+		p.pendingFeed.unsubscribe(id)
+	}
+}
@@ -0,0 +1,100 @@
+package txpool
+
+import (
+	"sort"
+
+	"github.com/xgr-network/xgr-node/types"
+)
+
+// PreviewPool is a copy of the promoted transactions of the pool, used to build a block
+// preview. It serves the transactions in the order of Prepare and Peek of the pool,
+// popping, dropping or demoting a transaction only changes the copy
+type PreviewPool struct {
+	baseFee     uint64
+	promoted    map[types.Address][]*types.Transaction
+	executables *pricedQueue
+}
+
+// Preview returns a copy of the promoted transactions of the pool
+func (p *TxPool) Preview() *PreviewPool {
+	promoted, _ := p.accounts.allTxs(false)
+
+	for _, txs := range promoted {
+		sort.Slice(txs, func(i, j int) bool {
+			return txs[i].Nonce < txs[j].Nonce
+		})
+	}
+
+	return &PreviewPool{
+		baseFee:     p.GetBaseFee(),
+		promoted:    promoted,
+		executables: newPricesQueue(0, nil),
+	}
+}
+
+// Prepare queues the lowest nonce transaction of every account
+func (v *PreviewPool) Prepare() {
+	primaries := make([]*types.Transaction, 0, len(v.promoted))
+
+	for _, txs := range v.promoted {
+		primaries = append(primaries, txs[0])
+	}
+
+	v.executables = newPricesQueue(v.baseFee, primaries)
+}
+
+// SetPriority orders the transactions matching the predicate first, like the pool
+func (v *PreviewPool) SetPriority(isPriority func(*types.Transaction) bool) {
+	v.executables.setPriority(isPriority)
+}
+
+// Peek returns the best-price queued transaction
+func (v *PreviewPool) Peek() *types.Transaction {
+	return v.executables.pop()
+}
+
+// Pop removes the transaction from the copy and queues the next one of the account
+func (v *PreviewPool) Pop(tx *types.Transaction) {
+	txs, ok := v.promoted[tx.From]
+	if !ok {
+		return
+	}
+
+	txs = txs[1:]
+	if len(txs) == 0 {
+		delete(v.promoted, tx.From)
+
+		return
+	}
+
+	v.promoted[tx.From] = txs
+	v.executables.push(txs[0])
+}
+
+// Drop removes the transactions of the account from the copy
+func (v *PreviewPool) Drop(tx *types.Transaction) {
+	delete(v.promoted, tx.From)
+}
+
+// Demote removes the transactions of the account from the copy,
+// the pool doesn't queue them again for the same block either
+func (v *PreviewPool) Demote(tx *types.Transaction) {
+	delete(v.promoted, tx.From)
+}
+
+// Length returns the number of transactions left in the copy
+func (v *PreviewPool) Length() uint64 {
+	length := uint64(0)
+
+	for _, txs := range v.promoted {
+		length += uint64(len(txs))
+	}
+
+	return length
+}
+
+// SetSealing is a no-op, the copy isn't sealed
+func (v *PreviewPool) SetSealing(bool) {}
+
+// ResetWithHeaders is a no-op, the copy isn't updated with new blocks
+func (v *PreviewPool) ResetWithHeaders(...*types.Header) {}
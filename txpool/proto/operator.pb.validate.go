@@ -291,6 +291,44 @@ func (m *TxnPoolStatusResp) validate(all bool) error {
 
 	// no validation rules for Length
 
+	for idx, item := range m.GetAccounts() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, TxnPoolStatusRespValidationError{
+						field:  fmt.Sprintf("Accounts[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, TxnPoolStatusRespValidationError{
+						field:  fmt.Sprintf("Accounts[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return TxnPoolStatusRespValidationError{
+					field:  fmt.Sprintf("Accounts[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for MaxAccountTxs
+
+	// no validation rules for MaxAccountEnqueued
+
 	if len(errors) > 0 {
 		return TxnPoolStatusRespMultiError(errors)
 	}
@@ -371,6 +409,111 @@ var _ interface {
 	ErrorName() string
 } = TxnPoolStatusRespValidationError{}
 
+// Validate checks the field values on AccountStatus with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *AccountStatus) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on AccountStatus with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in AccountStatusMultiError, or
+// nil if none found.
+func (m *AccountStatus) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *AccountStatus) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Address
+
+	// no validation rules for Promoted
+
+	// no validation rules for Enqueued
+
+	if len(errors) > 0 {
+		return AccountStatusMultiError(errors)
+	}
+
+	return nil
+}
+
+// AccountStatusMultiError is an error wrapping multiple validation errors
+// returned by AccountStatus.ValidateAll() if the designated constraints aren't met.
+type AccountStatusMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AccountStatusMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AccountStatusMultiError) AllErrors() []error { return m }
+
+// AccountStatusValidationError is the validation error returned by
+// AccountStatus.Validate if the designated constraints aren't met.
+type AccountStatusValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AccountStatusValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AccountStatusValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AccountStatusValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AccountStatusValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AccountStatusValidationError) ErrorName() string { return "AccountStatusValidationError" }
+
+// Error satisfies the builtin error interface
+func (e AccountStatusValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAccountStatus.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AccountStatusValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AccountStatusValidationError{}
+
 // Validate checks the field values on SubscribeRequest with the rules defined
 // in the proto definition for this message. If any rules are violated, the
 // first error encountered is returned, or nil if there are no violations.
@@ -615,3 +758,357 @@ var _ interface {
 	Cause() error
 	ErrorName() string
 } = TxPoolEventValidationError{}
+
+// Validate checks the field values on TxPoolDumpTxn with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *TxPoolDumpTxn) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on TxPoolDumpTxn with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in TxPoolDumpTxnMultiError, or
+// nil if none found.
+func (m *TxPoolDumpTxn) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *TxPoolDumpTxn) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Hash
+
+	// no validation rules for Raw
+
+	// no validation rules for Origin
+
+	// no validation rules for Status
+
+	// no validation rules for AddedAt
+
+	if len(errors) > 0 {
+		return TxPoolDumpTxnMultiError(errors)
+	}
+
+	return nil
+}
+
+// TxPoolDumpTxnMultiError is an error wrapping multiple validation errors
+// returned by TxPoolDumpTxn.ValidateAll() if the designated constraints aren't
+// met.
+type TxPoolDumpTxnMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m TxPoolDumpTxnMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m TxPoolDumpTxnMultiError) AllErrors() []error { return m }
+
+// TxPoolDumpTxnValidationError is the validation error returned by
+// TxPoolDumpTxn.Validate if the designated constraints aren't met.
+type TxPoolDumpTxnValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e TxPoolDumpTxnValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e TxPoolDumpTxnValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e TxPoolDumpTxnValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e TxPoolDumpTxnValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e TxPoolDumpTxnValidationError) ErrorName() string { return "TxPoolDumpTxnValidationError" }
+
+// Error satisfies the builtin error interface
+func (e TxPoolDumpTxnValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sTxPoolDumpTxn.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = TxPoolDumpTxnValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = TxPoolDumpTxnValidationError{}
+
+// Validate checks the field values on TxPoolDumpAccount with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *TxPoolDumpAccount) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on TxPoolDumpAccount with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the result is a list of violation errors wrapped in
+// TxPoolDumpAccountMultiError, or nil if none found.
+func (m *TxPoolDumpAccount) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *TxPoolDumpAccount) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Address
+
+	// no validation rules for Nonce
+
+	for idx, item := range m.GetTxns() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, TxPoolDumpAccountValidationError{
+						field:  fmt.Sprintf("Txns[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, TxPoolDumpAccountValidationError{
+						field:  fmt.Sprintf("Txns[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return TxPoolDumpAccountValidationError{
+					field:  fmt.Sprintf("Txns[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return TxPoolDumpAccountMultiError(errors)
+	}
+
+	return nil
+}
+
+// TxPoolDumpAccountMultiError is an error wrapping multiple validation errors
+// returned by TxPoolDumpAccount.ValidateAll() if the designated constraints
+// aren't met.
+type TxPoolDumpAccountMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m TxPoolDumpAccountMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m TxPoolDumpAccountMultiError) AllErrors() []error { return m }
+
+// TxPoolDumpAccountValidationError is the validation error returned by
+// TxPoolDumpAccount.Validate if the designated constraints aren't met.
+type TxPoolDumpAccountValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e TxPoolDumpAccountValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e TxPoolDumpAccountValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e TxPoolDumpAccountValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e TxPoolDumpAccountValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e TxPoolDumpAccountValidationError) ErrorName() string {
+	return "TxPoolDumpAccountValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e TxPoolDumpAccountValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sTxPoolDumpAccount.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = TxPoolDumpAccountValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = TxPoolDumpAccountValidationError{}
+
+// Validate checks the field values on TxPoolLoadResp with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *TxPoolLoadResp) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on TxPoolLoadResp with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in TxPoolLoadRespMultiError, or
+// nil if none found.
+func (m *TxPoolLoadResp) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *TxPoolLoadResp) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Added
+
+	// no validation rules for Failed
+
+	if len(errors) > 0 {
+		return TxPoolLoadRespMultiError(errors)
+	}
+
+	return nil
+}
+
+// TxPoolLoadRespMultiError is an error wrapping multiple validation errors
+// returned by TxPoolLoadResp.ValidateAll() if the designated constraints aren't
+// met.
+type TxPoolLoadRespMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m TxPoolLoadRespMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m TxPoolLoadRespMultiError) AllErrors() []error { return m }
+
+// TxPoolLoadRespValidationError is the validation error returned by
+// TxPoolLoadResp.Validate if the designated constraints aren't met.
+type TxPoolLoadRespValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e TxPoolLoadRespValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e TxPoolLoadRespValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e TxPoolLoadRespValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e TxPoolLoadRespValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e TxPoolLoadRespValidationError) ErrorName() string { return "TxPoolLoadRespValidationError" }
+
+// Error satisfies the builtin error interface
+func (e TxPoolLoadRespValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sTxPoolLoadResp.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = TxPoolLoadRespValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = TxPoolLoadRespValidationError{}
@@ -29,6 +29,10 @@ type TxnPoolOperatorClient interface {
 	AddTxn(ctx context.Context, in *AddTxnReq, opts ...grpc.CallOption) (*AddTxnResp, error)
 	// Subscribe subscribes for new events in the txpool
 	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (TxnPoolOperator_SubscribeClient, error)
+	// Dump streams the transactions in the pool, one account at a time
+	Dump(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (TxnPoolOperator_DumpClient, error)
+	// Load adds the streamed transactions of a dump to the pool, only dev consensus nodes accept it
+	Load(ctx context.Context, opts ...grpc.CallOption) (TxnPoolOperator_LoadClient, error)
 }
 
 type txnPoolOperatorClient struct {
@@ -89,6 +93,72 @@ func (x *txnPoolOperatorSubscribeClient) Recv() (*TxPoolEvent, error) {
 	return m, nil
 }
 
+func (c *txnPoolOperatorClient) Dump(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (TxnPoolOperator_DumpClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TxnPoolOperator_ServiceDesc.Streams[1], "/v1.TxnPoolOperator/Dump", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &txnPoolOperatorDumpClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TxnPoolOperator_DumpClient interface {
+	Recv() (*TxPoolDumpAccount, error)
+	grpc.ClientStream
+}
+
+type txnPoolOperatorDumpClient struct {
+	grpc.ClientStream
+}
+
+func (x *txnPoolOperatorDumpClient) Recv() (*TxPoolDumpAccount, error) {
+	m := new(TxPoolDumpAccount)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *txnPoolOperatorClient) Load(ctx context.Context, opts ...grpc.CallOption) (TxnPoolOperator_LoadClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TxnPoolOperator_ServiceDesc.Streams[2], "/v1.TxnPoolOperator/Load", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &txnPoolOperatorLoadClient{stream}
+	return x, nil
+}
+
+type TxnPoolOperator_LoadClient interface {
+	Send(*TxPoolDumpAccount) error
+	CloseAndRecv() (*TxPoolLoadResp, error)
+	grpc.ClientStream
+}
+
+type txnPoolOperatorLoadClient struct {
+	grpc.ClientStream
+}
+
+func (x *txnPoolOperatorLoadClient) Send(m *TxPoolDumpAccount) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *txnPoolOperatorLoadClient) CloseAndRecv() (*TxPoolLoadResp, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(TxPoolLoadResp)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // TxnPoolOperatorServer is the server API for TxnPoolOperator service.
 // All implementations must embed UnimplementedTxnPoolOperatorServer
 // for forward compatibility
@@ -99,6 +169,10 @@ type TxnPoolOperatorServer interface {
 	AddTxn(context.Context, *AddTxnReq) (*AddTxnResp, error)
 	// Subscribe subscribes for new events in the txpool
 	Subscribe(*SubscribeRequest, TxnPoolOperator_SubscribeServer) error
+	// Dump streams the transactions in the pool, one account at a time
+	Dump(*emptypb.Empty, TxnPoolOperator_DumpServer) error
+	// Load adds the streamed transactions of a dump to the pool, only dev consensus nodes accept it
+	Load(TxnPoolOperator_LoadServer) error
 	mustEmbedUnimplementedTxnPoolOperatorServer()
 }
 
@@ -115,6 +189,12 @@ func (UnimplementedTxnPoolOperatorServer) AddTxn(context.Context, *AddTxnReq) (*
 func (UnimplementedTxnPoolOperatorServer) Subscribe(*SubscribeRequest, TxnPoolOperator_SubscribeServer) error {
 	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
 }
+func (UnimplementedTxnPoolOperatorServer) Dump(*emptypb.Empty, TxnPoolOperator_DumpServer) error {
+	return status.Errorf(codes.Unimplemented, "method Dump not implemented")
+}
+func (UnimplementedTxnPoolOperatorServer) Load(TxnPoolOperator_LoadServer) error {
+	return status.Errorf(codes.Unimplemented, "method Load not implemented")
+}
 func (UnimplementedTxnPoolOperatorServer) mustEmbedUnimplementedTxnPoolOperatorServer() {}
 
 // UnsafeTxnPoolOperatorServer may be embedded to opt out of forward compatibility for this service.
@@ -185,6 +265,53 @@ func (x *txnPoolOperatorSubscribeServer) Send(m *TxPoolEvent) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _TxnPoolOperator_Dump_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(emptypb.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TxnPoolOperatorServer).Dump(m, &txnPoolOperatorDumpServer{stream})
+}
+
+type TxnPoolOperator_DumpServer interface {
+	Send(*TxPoolDumpAccount) error
+	grpc.ServerStream
+}
+
+type txnPoolOperatorDumpServer struct {
+	grpc.ServerStream
+}
+
+func (x *txnPoolOperatorDumpServer) Send(m *TxPoolDumpAccount) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TxnPoolOperator_Load_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TxnPoolOperatorServer).Load(&txnPoolOperatorLoadServer{stream})
+}
+
+type TxnPoolOperator_LoadServer interface {
+	SendAndClose(*TxPoolLoadResp) error
+	Recv() (*TxPoolDumpAccount, error)
+	grpc.ServerStream
+}
+
+type txnPoolOperatorLoadServer struct {
+	grpc.ServerStream
+}
+
+func (x *txnPoolOperatorLoadServer) SendAndClose(m *TxPoolLoadResp) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *txnPoolOperatorLoadServer) Recv() (*TxPoolDumpAccount, error) {
+	m := new(TxPoolDumpAccount)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // TxnPoolOperator_ServiceDesc is the grpc.ServiceDesc for TxnPoolOperator service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -207,6 +334,16 @@ var TxnPoolOperator_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _TxnPoolOperator_Subscribe_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Dump",
+			Handler:       _TxnPoolOperator_Dump_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Load",
+			Handler:       _TxnPoolOperator_Load_Handler,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "txpool/proto/operator.proto",
 }
@@ -32,14 +32,14 @@ func (p *TxPool) GetCapacity() (uint64, uint64) {
 	return p.gauge.read(), p.gauge.max
 }
 
-// GetPendingTx returns the transaction by hash in the TxPool (pending txn) [Thread-safe]
+// GetPendingTx returns the transaction by hash in the TxPool (pending txn),
+// including the underpriced txs waiting for the base fee to drop [Thread-safe]
 func (p *TxPool) GetPendingTx(txHash types.Hash) (*types.Transaction, bool) {
-	tx, ok := p.index.get(txHash)
-	if !ok {
-		return nil, false
+	if tx, ok := p.index.get(txHash); ok {
+		return tx, true
 	}
 
-	return tx, true
+	return p.underpriced.get(txHash)
 }
 
 // GetTxs gets pending and queued transactions
@@ -54,7 +54,14 @@ func (p *TxPool) GetBaseFee() uint64 {
 	return atomic.LoadUint64(&p.baseFee)
 }
 
-// SetBaseFee calculates base fee from the (current) header and sets value into baseFee field
+// SetBaseFee calculates base fee from the (current) header and sets value into baseFee field.
+// It also refreshes the minimum base fee from the EngineRegistry state at the header
 func (p *TxPool) SetBaseFee(header *types.Header) {
 	atomic.StoreUint64(&p.baseFee, p.store.CalculateBaseFee(header))
+	atomic.StoreUint64(&p.minBaseFee, p.store.MinBaseFee(header))
+}
+
+// GetMinBaseFee returns the minimum base fee configured in the EngineRegistry at the current head
+func (p *TxPool) GetMinBaseFee() uint64 {
+	return atomic.LoadUint64(&p.minBaseFee)
 }
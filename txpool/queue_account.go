@@ -36,6 +36,21 @@ func (q *accountQueue) lock(write bool) {
 	q.wLock.Store(write)
 }
 
+// tryLock is lock, which gives up and returns false instead of waiting for the lock
+func (q *accountQueue) tryLock(write bool) bool {
+	if write {
+		if !q.TryLock() {
+			return false
+		}
+	} else if !q.TryRLock() {
+		return false
+	}
+
+	q.wLock.Store(write)
+
+	return true
+}
+
 func (q *accountQueue) unlock() {
 	if q.wLock.Swap(false) {
 		q.Unlock()
@@ -95,6 +110,29 @@ func (q *accountQueue) pop() *types.Transaction {
 	return transaction
 }
 
+// popHighestNonce removes the transaction with the highest nonce and returns it,
+// so the remaining transactions keep a gapless nonce range and can still be promoted.
+func (q *accountQueue) popHighestNonce() *types.Transaction {
+	highest := -1
+
+	for i, tx := range q.queue {
+		if highest == -1 || tx.Nonce > q.queue[highest].Nonce {
+			highest = i
+		}
+	}
+
+	if highest == -1 {
+		return nil
+	}
+
+	transaction, ok := heap.Remove(&q.queue, highest).(*types.Transaction)
+	if !ok {
+		return nil
+	}
+
+	return transaction
+}
+
 // length returns the number of transactions in the queue.
 func (q *accountQueue) length() uint64 {
 	return uint64(q.queue.Len())
@@ -45,6 +45,14 @@ func (q *pricedQueue) pop() *types.Transaction {
 	return transaction
 }
 
+// setPriority orders the transactions matching the predicate ahead of all other
+// transactions. A nil predicate restores the plain price ordering.
+func (q *pricedQueue) setPriority(isPriority func(*types.Transaction) bool) {
+	q.queue.isPriority = isPriority
+
+	heap.Init(q.queue)
+}
+
 // length returns the number of transactions in the queue.
 func (q *pricedQueue) length() int {
 	return q.queue.Len()
@@ -54,6 +62,9 @@ func (q *pricedQueue) length() int {
 type maxPriceQueue struct {
 	baseFee *big.Int
 	txs     []*types.Transaction
+
+	// isPriority selects the transactions preferred over all others, regardless of price
+	isPriority func(*types.Transaction) bool
 }
 
 /* Queue methods required by the heap interface */
@@ -95,6 +106,12 @@ func (q *maxPriceQueue) Pop() interface{} {
 // @see https://github.com/etclabscore/core-geth/blob/4e2b0e37f89515a4e7b6bafaa40910a296cb38c0/core/txpool/list.go#L458
 // for details why is something implemented like it is
 func (q *maxPriceQueue) Less(i, j int) bool {
+	if q.isPriority != nil {
+		if pi, pj := q.isPriority(q.txs[i]), q.isPriority(q.txs[j]); pi != pj {
+			return pi
+		}
+	}
+
 	switch cmp(q.txs[i], q.txs[j], q.baseFee) {
 	case -1:
 		return false
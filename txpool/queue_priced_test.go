@@ -246,6 +246,36 @@ func Test_maxPriceQueue(t *testing.T) {
 	}
 }
 
+func Test_pricedQueue_SetPriority(t *testing.T) {
+	t.Parallel()
+
+	engine := types.StringToAddress("0xE1")
+
+	high := &types.Transaction{Type: types.LegacyTx, GasPrice: big.NewInt(300), From: types.StringToAddress("0x1")}
+	mid := &types.Transaction{Type: types.LegacyTx, GasPrice: big.NewInt(200), From: types.StringToAddress("0x2")}
+	low := &types.Transaction{Type: types.LegacyTx, GasPrice: big.NewInt(100), From: engine}
+
+	isEngine := func(tx *types.Transaction) bool {
+		return tx.From == engine
+	}
+
+	queue := newPricesQueue(0, []*types.Transaction{high, mid, low})
+	queue.setPriority(isEngine)
+
+	assert.Equal(t, low, queue.pop())
+	assert.Equal(t, high, queue.pop())
+	assert.Equal(t, mid, queue.pop())
+
+	// a nil predicate restores the price ordering
+	queue = newPricesQueue(0, []*types.Transaction{high, mid, low})
+	queue.setPriority(isEngine)
+	queue.setPriority(nil)
+
+	assert.Equal(t, high, queue.pop())
+	assert.Equal(t, mid, queue.pop())
+	assert.Equal(t, low, queue.pop())
+}
+
 func Benchmark_pricedQueue(t *testing.B) {
 	testTable := []struct {
 		name        string
@@ -39,6 +39,33 @@ const (
 
 	// txPoolMetrics is a prefix used for txpool-related metrics
 	txPoolMetrics = "txpool"
+
+	// DefaultPriceBump is the minimum percentage by which a replacement tx
+	// has to raise the fee cap and the tip cap of the tx it replaces
+	DefaultPriceBump uint64 = 10
+
+	// DefaultUnderpricedTxLifetime is the number of blocks a dynamic fee tx with a fee cap
+	// below the base fee is kept waiting for the base fee to drop
+	DefaultUnderpricedTxLifetime uint64 = 64
+
+	// DefaultMaxAccountTxs is the maximum number of transactions (promoted and enqueued) an account
+	// can have in the pool
+	DefaultMaxAccountTxs uint64 = 1024
+
+	// droppedReasonReplaced is the reason of the DROPPED event emitted for a replaced tx
+	droppedReasonReplaced = "replaced"
+
+	// droppedReasonUnderpricedExpired is the reason of the DROPPED event emitted for a parked
+	// underpriced tx whose fee cap did not cover the base fee within its lifetime
+	droppedReasonUnderpricedExpired = "underpriced expired"
+
+	// droppedReasonBelowMinBaseFee is the reason of the DROPPED event emitted for a parked
+	// underpriced tx whose fee cap is below a raised minimum base fee
+	droppedReasonBelowMinBaseFee = "below min base fee"
+
+	// droppedReasonEvicted is the reason of the DROPPED event emitted for an enqueued tx
+	// evicted to make room for the tx of a lighter sender in a full pool
+	droppedReasonEvicted = "evicted"
 )
 
 // errors
@@ -50,7 +77,7 @@ var (
 	ErrInvalidSender           = errors.New("invalid sender")
 	ErrTxPoolOverflow          = errors.New("txpool is full")
 	ErrUnderpriced             = errors.New("transaction underpriced")
-	ErrNonceTooLow             = errors.New("nonce too low")
+	ErrNonceTooLow             = state.ErrNonceTooLow
 	ErrInsufficientFunds       = errors.New("insufficient funds for gas * price + value")
 	ErrInvalidAccountState     = errors.New("invalid account state")
 	ErrAlreadyKnown            = errors.New("already known")
@@ -66,6 +93,12 @@ var (
 	ErrReplacementUnderpriced  = errors.New("replacement tx underpriced")
 	ErrDynamicTxNotAllowed     = errors.New("dynamic tx not allowed currently")
 	ErrMissingChainIDConfig    = errors.New("missing txpool chain id configuration")
+	ErrBelowMinBaseFee         = errors.New("max fee per gas below registry min base fee")
+	ErrMaxAccountTxsReached    = errors.New("maximum number of transactions per account reached")
+	ErrLoadDisabled            = errors.New("txpool load is only enabled on dev consensus nodes")
+	ErrSenderNoEOA             = state.ErrSenderNoEOA
+
+	errFeeCapBelowBaseFee = fmt.Errorf("%w: fee cap below base fee", ErrUnderpriced)
 )
 
 // indicates origin of a transaction
@@ -92,8 +125,11 @@ type store interface {
 	Header() *types.Header
 	GetNonce(root types.Hash, addr types.Address) uint64
 	GetBalance(root types.Hash, addr types.Address) (*big.Int, error)
+	GetCodeHash(root types.Hash, addr types.Address) types.Hash
 	GetBlockByHash(types.Hash, bool) (*types.Block, bool)
 	CalculateBaseFee(parent *types.Header) uint64
+	MinBaseFee(header *types.Header) uint64
+	BridgeBlocksTransfer(header *types.Header, to types.Address) bool
 }
 
 type signer interface {
@@ -104,7 +140,17 @@ type Config struct {
 	PriceLimit         uint64
 	MaxSlots           uint64
 	MaxAccountEnqueued uint64
-	ChainID            *big.Int
+	// MaxAccountTxs is the maximum number of transactions (promoted and enqueued)
+	// per account, 0 means no limit
+	MaxAccountTxs uint64
+	PriceBump     uint64
+	// UnderpricedTxLifetime is the number of blocks a dynamic fee tx with a fee cap below
+	// the base fee is parked before being dropped, 0 rejects such txs right away
+	UnderpricedTxLifetime uint64
+	ChainID               *big.Int
+	// EnableLoad allows re-injecting a pool dump through the Load operator endpoint,
+	// only nodes running the dev consensus enable it
+	EnableLoad bool
 }
 
 /* All requests are passed to the main loop
@@ -156,6 +202,13 @@ type TxPool struct {
 	// all the primaries sorted by max gas price
 	executables *pricedQueue
 
+	// dynamic fee txs whose fee cap was below the base fee,
+	// re-evaluated on every new head
+	underpriced *underpricedQueue
+
+	// underpricedTxLifetime is the number of blocks a tx is kept in underpriced
+	underpricedTxLifetime uint64
+
 	// lookup map keeping track of all
 	// transactions present in the pool
 	index lookupMap
@@ -169,6 +222,15 @@ type TxPool struct {
 	// priceLimit is a lower threshold for gas price
 	priceLimit uint64
 
+	// priceBump is the minimum fee increase (in percent) required to replace a tx
+	priceBump uint64
+
+	// maxAccountTxs is the maximum number of transactions per account (0 = no limit)
+	maxAccountTxs uint64
+
+	// enableLoad indicates if the Load operator endpoint accepts pool dumps
+	enableLoad bool
+
 	// channels on which the pool's event loop
 	// does dispatching/handling requests.
 	promoteReqCh chan promoteRequest
@@ -185,9 +247,16 @@ type TxPool struct {
 	// This is needed to sort transactions by price
 	baseFee uint64
 
+	// minBaseFee is the minimum base fee configured in the EngineRegistry
+	// at the current head. Transactions priced below it are rejected
+	minBaseFee uint64
+
 	// Event manager for txpool events
 	eventManager *eventManager
 
+	// feed of the txs added to the pool for in-process subscribers
+	pendingFeed *pendingTxFeed
+
 	// indicates which txpool operator commands should be implemented
 	proto.UnimplementedTxnPoolOperatorServer
 
@@ -209,15 +278,20 @@ func NewTxPool(
 	config *Config,
 ) (*TxPool, error) {
 	pool := &TxPool{
-		logger:      logger.Named("txpool"),
-		forks:       forks,
-		store:       store,
-		executables: newPricesQueue(0, nil),
-		accounts:    accountsMap{maxEnqueuedLimit: config.MaxAccountEnqueued},
-		index:       lookupMap{all: make(map[types.Hash]*types.Transaction)},
-		gauge:       slotGauge{height: 0, max: config.MaxSlots},
-		priceLimit:  config.PriceLimit,
-		chainID:     config.ChainID,
+		logger:                logger.Named("txpool"),
+		forks:                 forks,
+		store:                 store,
+		executables:           newPricesQueue(0, nil),
+		underpriced:           newUnderpricedQueue(),
+		accounts:              accountsMap{maxEnqueuedLimit: config.MaxAccountEnqueued},
+		index:                 newLookupMap(),
+		gauge:                 slotGauge{height: 0, max: config.MaxSlots},
+		priceLimit:            config.PriceLimit,
+		priceBump:             config.PriceBump,
+		maxAccountTxs:         config.MaxAccountTxs,
+		enableLoad:            config.EnableLoad,
+		chainID:               config.ChainID,
+		underpricedTxLifetime: config.UnderpricedTxLifetime,
 
 		//	main loop channels
 		promoteReqCh: make(chan promoteRequest),
@@ -227,6 +301,7 @@ func NewTxPool(
 
 	// Attach the event manager
 	pool.eventManager = newEventManager(pool.logger)
+	pool.pendingFeed = newPendingTxFeed()
 
 	if network != nil {
 		// subscribe to the gossip protocol
@@ -293,6 +368,7 @@ func (p *TxPool) Start() {
 // Close shuts down the pool's main loop.
 func (p *TxPool) Close() {
 	p.eventManager.Close()
+	p.pendingFeed.close()
 	close(p.shutdownCh)
 }
 
@@ -343,6 +419,14 @@ func (p *TxPool) Prepare() {
 	p.executables = newPricesQueue(p.GetBaseFee(), primaries)
 }
 
+// SetPriority orders the executable transactions matching the predicate ahead of all
+// other transactions until the next Prepare. The predicate is re-evaluated on every call,
+// so it must be called again whenever its outcome changes. A nil predicate restores
+// the price ordering.
+func (p *TxPool) SetPriority(isPriority func(*types.Transaction) bool) {
+	p.executables.setPriority(isPriority)
+}
+
 // Peek returns the best-price selected
 // transaction ready for execution.
 func (p *TxPool) Peek() *types.Transaction {
@@ -392,10 +476,30 @@ func (p *TxPool) Pop(tx *types.Transaction) {
 	}
 }
 
-// Drop clears the entire account associated with the given transaction
-// and reverts its next (expected) nonce.
+// Drop removes the given transaction, which can't be included anymore.
+// A transaction whose nonce was already used in the state is stale, the account
+// is only pruned up to the state nonce and its following transactions are kept.
+// Otherwise the entire account is cleared and its next (expected) nonce reverted.
 func (p *TxPool) Drop(tx *types.Transaction) {
 	account := p.accounts.get(tx.From)
+
+	if stateNonce := p.store.GetNonce(p.store.Header().StateRoot, tx.From); tx.Nonce < stateNonce {
+		p.resetAccounts(map[types.Address]uint64{tx.From: stateNonce})
+
+		p.eventManager.signalEvent(proto.EventType_DROPPED, tx.Hash)
+
+		// the next promoted tx of the account is executable
+		account.promoted.lock(false)
+		next := account.promoted.peek()
+		account.promoted.unlock()
+
+		if next != nil {
+			p.executables.push(next)
+		}
+
+		return
+	}
+
 	p.dropAccount(account, tx.Nonce, tx)
 }
 
@@ -454,7 +558,7 @@ func (p *TxPool) dropAccount(account *account, nextNonce uint64, tx *types.Trans
 }
 
 // Demote excludes an account from being further processed during block building
-// due to a recoverable error. If an account has been demoted too many times (maxAccountDemotions),
+// due to a recoverable error, such as a nonce gap. If an account has been demoted too many times (maxAccountDemotions),
 // it is Dropped instead.
 func (p *TxPool) Demote(tx *types.Transaction) {
 	account := p.accounts.get(tx.From)
@@ -545,6 +649,10 @@ func (p *TxPool) processEvent(event *blockchain.Event) {
 	// reset accounts with the new state
 	p.resetAccounts(stateNonces)
 
+	if ln := len(event.NewChain); ln > 0 {
+		p.reevaluateUnderpriced(event.NewChain[ln-1].Number)
+	}
+
 	if !p.sealing.Load() {
 		// only non-validator cleanup inactive accounts
 		p.updateAccountSkipsCounts(stateNonces)
@@ -553,6 +661,8 @@ func (p *TxPool) processEvent(event *blockchain.Event) {
 
 // validateTx ensures the transaction conforms to specific
 // constraints before entering the pool.
+// A dynamic fee tx valid in all other respects but with a fee cap below the base fee
+// fails with errFeeCapBelowBaseFee.
 func (p *TxPool) validateTx(tx *types.Transaction) error {
 	// Check the transaction type. State transactions are not expected to be added to the pool
 	if tx.Type == types.StateTx {
@@ -614,11 +724,22 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 		return runtime.ErrMaxCodeSizeExceeded
 	}
 
+	// In strict mode value transfers to accounts blocked by the bridge block list are rejected
+	if forks.StrictBridgeBlockList && tx.To != nil && tx.Value.Sign() > 0 &&
+		p.store.BridgeBlocksTransfer(currentHeader, *tx.To) {
+		metrics.IncrCounter([]string{txPoolMetrics, "bridge_blocked_recipient_txs"}, 1)
+
+		return runtime.ErrNotAuth
+	}
+
 	// Grab the state root, and block gas limit for the latest block
 	stateRoot := currentHeader.StateRoot
 	latestBlockGasLimit := currentHeader.GasLimit
 	baseFee := p.GetBaseFee() // base fee is calculated for the next block
 
+	// reported after all other checks, so that only otherwise valid txs are parked
+	feeCapBelowBaseFee := false
+
 	if tx.Type == types.AccessListTx {
 		if !forks.EIP2930 {
 			metrics.IncrCounter([]string{txPoolMetrics, "tx_type"}, 1)
@@ -693,13 +814,19 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 			return ErrTipAboveFeeCap
 		}
 
+		if err := p.checkMinBaseFee(tx); err != nil {
+			return err
+		}
+
 		// Reject underpriced transactions
 		if tx.GasFeeCap.Cmp(new(big.Int).SetUint64(baseFee)) < 0 {
-			metrics.IncrCounter([]string{txPoolMetrics, "underpriced_tx"}, 1)
-
-			return ErrUnderpriced
+			feeCapBelowBaseFee = true
 		}
 	} else {
+		if err := p.checkMinBaseFee(tx); err != nil {
+			return err
+		}
+
 		// Legacy approach to check if the given tx is not underpriced when london hardfork is enabled
 		if forks.London && tx.GasPrice.Cmp(new(big.Int).SetUint64(baseFee)) < 0 {
 			metrics.IncrCounter([]string{txPoolMetrics, "underpriced_tx"}, 1)
@@ -722,6 +849,16 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 		return ErrNonceTooLow
 	}
 
+	// EIP-3607: reject transactions whose sender has deployed code
+	if forks.EIP3607 {
+		codeHash := p.store.GetCodeHash(stateRoot, tx.From)
+		if codeHash != types.ZeroHash && codeHash != types.EmptyCodeHash {
+			metrics.IncrCounter([]string{txPoolMetrics, "sender_not_eoa_tx"}, 1)
+
+			return ErrSenderNoEOA
+		}
+	}
+
 	accountBalance, balanceErr := p.store.GetBalance(stateRoot, tx.From)
 	if balanceErr != nil {
 		metrics.IncrCounter([]string{txPoolMetrics, "invalid_account_state_tx"}, 1)
@@ -762,9 +899,32 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 		return ErrBlockLimitExceeded
 	}
 
+	if feeCapBelowBaseFee {
+		metrics.IncrCounter([]string{txPoolMetrics, "underpriced_tx"}, 1)
+
+		return errFeeCapBelowBaseFee
+	}
+
 	return nil
 }
 
+// checkMinBaseFee rejects txs that can never be included while the EngineRegistry
+// minimum base fee holds. It is checked before the base fee, so clients can tell both apart
+func (p *TxPool) checkMinBaseFee(tx *types.Transaction) error {
+	minBaseFee := p.GetMinBaseFee()
+	if minBaseFee == 0 {
+		return nil
+	}
+
+	if feeCap := tx.GetGasFeeCap(); feeCap != nil && feeCap.Cmp(new(big.Int).SetUint64(minBaseFee)) >= 0 {
+		return nil
+	}
+
+	metrics.IncrCounter([]string{txPoolMetrics, "below_min_base_fee_tx"}, 1)
+
+	return ErrBelowMinBaseFee
+}
+
 func (p *TxPool) signalPruning() {
 	select {
 	case p.pruneCh <- struct{}{}:
@@ -829,13 +989,24 @@ func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
 	}
 
 	// validate incoming tx
-	if err := p.validateTx(tx); err != nil {
-		return err
+	validateErr := p.validateTx(tx)
+	if validateErr != nil && (!errors.Is(validateErr, errFeeCapBelowBaseFee) || p.underpricedTxLifetime == 0) {
+		return validateErr
 	}
 
 	// calculate tx hash
 	tx.ComputeHash(p.store.Header().Number)
 
+	if validateErr != nil {
+		return p.parkUnderpriced(origin, tx)
+	}
+
+	if _, ok := p.underpriced.get(tx.Hash); ok {
+		metrics.IncrCounter([]string{txPoolMetrics, "already_known_tx"}, 1)
+
+		return ErrAlreadyKnown
+	}
+
 	// initialize account for this address once or retrieve existing one
 	account := p.getOrCreateAccount(tx.From)
 
@@ -869,9 +1040,8 @@ func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
 			metrics.IncrCounter([]string{txPoolMetrics, "already_known_tx"}, 1)
 
 			return ErrAlreadyKnown
-		} else if oldTxWithSameNonce.GetGasPrice(p.baseFee).Cmp(
-			tx.GetGasPrice(p.baseFee)) >= 0 {
-			// if tx with same nonce does exist and has same or better gas price -> return error
+		} else if p.isReplacementUnderpriced(oldTxWithSameNonce, tx) {
+			// if tx with same nonce does exist and the new tx doesn't bump its fees enough -> return error
 			metrics.IncrCounter([]string{txPoolMetrics, "underpriced_tx"}, 1)
 
 			return ErrReplacementUnderpriced
@@ -887,10 +1057,22 @@ func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
 
 			return ErrNonceTooLow
 		}
+
+		if p.maxAccountTxs > 0 && account.promoted.length()+account.enqueued.length() >= p.maxAccountTxs {
+			metrics.IncrCounter([]string{txPoolMetrics, "account_limit_tx"}, 1)
+
+			return ErrMaxAccountTxsReached
+		}
 	}
 
 	slotsAllocated := slotsRequired(tx)
 
+	// a full pool makes room for an accepted new tx by evicting enqueued txs of heavier senders
+	// (future txs are rejected at high pressure anyway, replacements reuse the slots of the replaced tx)
+	if oldTxWithSameNonce == nil && tx.Nonce <= accountNonce && p.gauge.read()+slotsAllocated > p.gauge.max {
+		p.evictForSlots(tx.From, account.promoted.length()+account.enqueued.length(), slotsAllocated)
+	}
+
 	var slotsFreed uint64
 	if oldTxWithSameNonce != nil {
 		slotsFreed = slotsRequired(oldTxWithSameNonce)
@@ -905,7 +1087,7 @@ func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
 	}
 
 	// add to index
-	if ok := p.index.add(tx); !ok {
+	if ok := p.index.add(origin, tx); !ok {
 		metrics.IncrCounter([]string{txPoolMetrics, "already_known_tx"}, 1)
 
 		if slotsIncreased > 0 {
@@ -927,11 +1109,194 @@ func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
 
 	account.enqueue(tx, oldTxWithSameNonce != nil) // add or replace tx into account
 
+	if oldTxWithSameNonce != nil {
+		metrics.IncrCounter([]string{txPoolMetrics, "replaced_tx"}, 1)
+
+		p.eventManager.signalEventWithReason(proto.EventType_DROPPED, droppedReasonReplaced, oldTxWithSameNonce.Hash)
+	}
+
+	// a parked tx with the same nonce is superseded by the one added to the pool
+	if parked := p.underpriced.getByNonce(tx.From, tx.Nonce); parked != nil && p.underpriced.remove(parked) {
+		p.gauge.decrease(slotsRequired(parked))
+
+		p.eventManager.signalEventWithReason(proto.EventType_DROPPED, droppedReasonReplaced, parked.Hash)
+	}
+
+	p.pendingFeed.send(origin, tx)
+
 	go p.invokePromotion(tx, tx.Nonce <= accountNonce) // don't signal promotion for higher nonce txs
 
 	return nil
 }
 
+// evictForSlots frees slots for a tx of from (holding fromTxs txs) in a full pool by evicting
+// the highest nonce enqueued txs of the heaviest senders (most txs in the pool) first, so their
+// remaining txs can still be promoted. Only senders holding more txs than from (including its new tx)
+// are evicted from, so a flooding sender can not push out the txs of other senders.
+// It is called with the locks of the from account held, so the locks of the victims are only tried
+// and a victim locked by a concurrent add is left alone
+func (p *TxPool) evictForSlots(from types.Address, fromTxs, slots uint64) {
+	for p.gauge.read()+slots > p.gauge.max {
+		victim := p.accounts.heaviest(from, fromTxs+1)
+		if victim == nil || !victim.enqueued.tryLock(true) {
+			return
+		}
+
+		if !victim.nonceToTx.tryLock() {
+			victim.enqueued.unlock()
+
+			return
+		}
+
+		evicted := victim.enqueued.popHighestNonce()
+		if evicted != nil {
+			victim.nonceToTx.remove(evicted)
+		}
+
+		victim.nonceToTx.unlock()
+		victim.enqueued.unlock()
+
+		if evicted == nil {
+			return
+		}
+
+		p.index.remove(evicted)
+		p.gauge.decrease(slotsRequired(evicted))
+
+		metrics.IncrCounter([]string{txPoolMetrics, "evicted_tx"}, 1)
+
+		p.eventManager.signalEventWithReason(proto.EventType_DROPPED, droppedReasonEvicted, evicted.Hash)
+	}
+}
+
+// parkUnderpriced keeps a dynamic fee tx whose fee cap is below the base fee out of the accounts
+// until the base fee drops below its fee cap (see reevaluateUnderpriced).
+// A parked tx with the same nonce is replaced if the price bump is met.
+func (p *TxPool) parkUnderpriced(origin txOrigin, tx *types.Transaction) error {
+	if _, ok := p.underpriced.get(tx.Hash); ok {
+		metrics.IncrCounter([]string{txPoolMetrics, "already_known_tx"}, 1)
+
+		return ErrAlreadyKnown
+	}
+
+	if _, ok := p.index.get(tx.Hash); ok {
+		metrics.IncrCounter([]string{txPoolMetrics, "already_known_tx"}, 1)
+
+		return ErrAlreadyKnown
+	}
+
+	if old := p.underpriced.getByNonce(tx.From, tx.Nonce); old != nil && p.isReplacementUnderpriced(old, tx) {
+		metrics.IncrCounter([]string{txPoolMetrics, "underpriced_tx"}, 1)
+
+		return ErrReplacementUnderpriced
+	}
+
+	if !p.gauge.increaseWithinLimit(slotsRequired(tx)) {
+		return ErrTxPoolOverflow
+	}
+
+	if replaced := p.underpriced.add(tx, origin, p.store.Header().Number); replaced != nil {
+		p.gauge.decrease(slotsRequired(replaced))
+
+		p.eventManager.signalEventWithReason(proto.EventType_DROPPED, droppedReasonReplaced, replaced.Hash)
+	}
+
+	metrics.IncrCounter([]string{txPoolMetrics, "parked_underpriced_tx"}, 1)
+
+	if p.logger.IsDebug() {
+		p.logger.Debug("parked underpriced tx", "hash", tx.Hash.String(), "fee_cap", tx.GasFeeCap)
+	}
+
+	p.eventManager.signalEvent(proto.EventType_ADDED, tx.Hash)
+	p.pendingFeed.send(origin, tx)
+
+	return nil
+}
+
+// reevaluateUnderpriced adds the parked txs whose fee cap covers the current base fee to the pool
+// and drops the ones parked for underpricedTxLifetime blocks or priced below the minimum base fee.
+// Parked txs are evaluated by descending fee cap, then descending tip cap, then arrival.
+// Returns the txs added to the pool, in order.
+func (p *TxPool) reevaluateUnderpriced(headNumber uint64) []*types.Transaction {
+	if p.underpriced.length() == 0 {
+		return nil
+	}
+
+	var (
+		baseFee    = new(big.Int).SetUint64(p.GetBaseFee())
+		minBaseFee = new(big.Int).SetUint64(p.GetMinBaseFee())
+		promoted   []*types.Transaction
+	)
+
+	for _, utx := range p.underpriced.sorted() {
+		tx := utx.tx
+
+		if tx.GasFeeCap.Cmp(minBaseFee) < 0 {
+			if p.underpriced.remove(tx) {
+				p.gauge.decrease(slotsRequired(tx))
+
+				p.eventManager.signalEventWithReason(proto.EventType_DROPPED, droppedReasonBelowMinBaseFee, tx.Hash)
+			}
+
+			continue
+		}
+
+		affordable := tx.GasFeeCap.Cmp(baseFee) >= 0
+		if !affordable && headNumber < utx.parkedAt+p.underpricedTxLifetime {
+			continue
+		}
+
+		// skip txs replaced or superseded in the meantime
+		if !p.underpriced.remove(tx) {
+			continue
+		}
+
+		p.gauge.decrease(slotsRequired(tx))
+
+		if !affordable {
+			metrics.IncrCounter([]string{txPoolMetrics, "expired_underpriced_tx"}, 1)
+
+			p.eventManager.signalEventWithReason(proto.EventType_DROPPED, droppedReasonUnderpricedExpired, tx.Hash)
+
+			continue
+		}
+
+		if err := p.addTx(utx.origin, tx); err != nil {
+			if p.logger.IsDebug() {
+				p.logger.Debug("failed to add parked underpriced tx", "hash", tx.Hash.String(), "err", err)
+			}
+
+			p.eventManager.signalEvent(proto.EventType_DROPPED, tx.Hash)
+
+			continue
+		}
+
+		promoted = append(promoted, tx)
+	}
+
+	return promoted
+}
+
+// isReplacementUnderpriced checks if the tx doesn't raise both the fee cap and the tip cap
+// of the tx it would replace by at least priceBump percent.
+// Legacy txs use their gas price as both caps.
+func (p *TxPool) isReplacementUnderpriced(oldTx, tx *types.Transaction) bool {
+	return !hasPriceBump(oldTx.GetGasFeeCap(), tx.GetGasFeeCap(), p.priceBump) ||
+		!hasPriceBump(oldTx.GetGasTipCap(), tx.GetGasTipCap(), p.priceBump)
+}
+
+// hasPriceBump checks if the new price is higher than the old one and exceeds it by at least bump percent
+func hasPriceBump(oldPrice, newPrice *big.Int, bump uint64) bool {
+	if newPrice.Cmp(oldPrice) <= 0 {
+		return false
+	}
+
+	// newPrice * 100 >= oldPrice * (100 + bump)
+	threshold := new(big.Int).Mul(oldPrice, new(big.Int).SetUint64(100+bump))
+
+	return new(big.Int).Mul(newPrice, big.NewInt(100)).Cmp(threshold) >= 0
+}
+
 func (p *TxPool) invokePromotion(tx *types.Transaction, callPromote bool) {
 	p.eventManager.signalEvent(proto.EventType_ADDED, tx.Hash)
 
@@ -94,6 +94,7 @@ func newTestPoolWithSlots(maxSlots uint64, mockStore ...store) (*TxPool, error)
 			PriceLimit:         defaultPriceLimit,
 			MaxSlots:           maxSlots,
 			MaxAccountEnqueued: defaultMaxAccountEnqueued,
+			PriceBump:          DefaultPriceBump,
 			ChainID:            big.NewInt(100),
 		},
 	)
@@ -429,6 +430,45 @@ func TestAddTxErrors(t *testing.T) {
 			ErrInsufficientFunds,
 		)
 	})
+
+	t.Run("ErrSenderNoEOA", func(t *testing.T) {
+		t.Parallel()
+		pool := setupPool()
+
+		// the sender has deployed code
+		pool.store = defaultMockStore{
+			DefaultHeader: mockHeader,
+			codeHashes: map[types.Address]types.Hash{
+				defaultAddr: types.StringToHash("0xc0de"),
+			},
+		}
+
+		tx := newTx(defaultAddr, 0, 1)
+		tx = signTx(tx)
+
+		assert.ErrorIs(t,
+			pool.addTx(local, tx),
+			ErrSenderNoEOA,
+		)
+	})
+
+	t.Run("sender with code before EIP-3607", func(t *testing.T) {
+		t.Parallel()
+		pool := setupPool()
+		pool.forks.RemoveFork(chain.EIP3607)
+
+		pool.store = defaultMockStore{
+			DefaultHeader: mockHeader,
+			codeHashes: map[types.Address]types.Hash{
+				defaultAddr: types.StringToHash("0xc0de"),
+			},
+		}
+
+		tx := newTx(defaultAddr, 0, 1)
+		tx = signTx(tx)
+
+		assert.NoError(t, pool.addTx(local, tx))
+	})
 }
 
 func TestPruneAccountsWithNonceHoles(t *testing.T) {
@@ -1907,6 +1947,50 @@ func TestDrop(t *testing.T) {
 	assert.Equal(t, (*types.Transaction)(nil), acc.nonceToTx.get(tx1.Nonce))
 }
 
+func TestDrop_StaleNonce(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPool()
+	assert.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	// send 3 txs and promote them
+	for nonce := uint64(0); nonce < 3; nonce++ {
+		assert.NoError(t, pool.addTx(local, newTx(addr1, nonce, 1)))
+	}
+
+	pool.handlePromoteRequest(<-pool.promoteReqCh)
+
+	assert.Equal(t, uint64(3), pool.gauge.read())
+	assert.Equal(t, uint64(3), pool.accounts.get(addr1).promoted.length())
+
+	// the first tx was included by another block, the pool wasn't reset yet
+	pool.store = defaultMockStore{
+		DefaultHeader: mockHeader,
+		nonce:         1,
+	}
+
+	pool.Prepare()
+	tx := pool.Peek()
+	assert.Equal(t, uint64(0), tx.Nonce)
+
+	pool.Drop(tx)
+
+	// only the stale tx is dropped, the following txs are kept
+	assert.Equal(t, uint64(2), pool.gauge.read())
+	assert.Equal(t, uint64(3), pool.accounts.get(addr1).getNonce())
+	assert.Equal(t, uint64(2), pool.accounts.get(addr1).promoted.length())
+
+	_, ok := pool.index.get(tx.Hash)
+	assert.False(t, ok)
+
+	// and the next one is executable
+	next := pool.Peek()
+	if assert.NotNil(t, next) {
+		assert.Equal(t, uint64(1), next.Nonce)
+	}
+}
+
 func TestDemote(t *testing.T) {
 	t.Parallel()
 
@@ -3429,6 +3513,37 @@ func TestGetTxs(t *testing.T) {
 	}
 }
 
+func TestGetTxs_Snapshot(t *testing.T) {
+	t.Parallel()
+
+	addr := types.StringToAddress("0x1")
+
+	accounts := &accountsMap{maxEnqueuedLimit: 10}
+	account := accounts.initOnce(addr, 0)
+
+	// nonce 0 and 1 are executable, nonce 3 is gapped
+	account.promoted.push(newTx(addr, 0, 1))
+	account.promoted.push(newTx(addr, 1, 1))
+	account.enqueued.push(newTx(addr, 3, 1))
+
+	allPromoted, allEnqueued := accounts.allTxs(true)
+	require.Len(t, allPromoted[addr], 2)
+	require.Len(t, allEnqueued[addr], 1)
+
+	// mutating the pool must not change the returned snapshot
+	account.promoted.lock(true)
+	account.promoted.pop()
+	account.promoted.unlock()
+
+	account.enqueued.lock(true)
+	account.enqueued.clear()
+	account.enqueued.unlock()
+
+	assert.Equal(t, uint64(0), allPromoted[addr][0].Nonce)
+	assert.Equal(t, uint64(1), allPromoted[addr][1].Nonce)
+	assert.Equal(t, uint64(3), allEnqueued[addr][0].Nonce)
+}
+
 func TestSetSealing(t *testing.T) {
 	t.Parallel()
 
@@ -3824,6 +3939,380 @@ func TestAddTx_TxReplacement(t *testing.T) {
 	assert.Equal(t, ac2.enqueued.queue[0], tx1)
 }
 
+func TestAddTx_PriceBump(t *testing.T) {
+	t.Parallel()
+
+	forkManagerMu.Lock()
+	defer forkManagerMu.Unlock()
+
+	fm := forkmanager.GetInstance()
+	fm.Clear()
+	fm.RegisterFork(chain.TxHashWithType, nil)
+	require.NoError(t, fm.ActivateFork(chain.TxHashWithType, 0))
+	defer fm.Clear()
+
+	newLegacyTx := func(nonce, gasPrice uint64) *types.Transaction {
+		tx := newTx(addr1, nonce, 1)
+		tx.GasPrice = new(big.Int).SetUint64(gasPrice)
+
+		return tx.ComputeHash(0)
+	}
+
+	newDynamicTx := func(nonce, gasFeeCap, gasTipCap uint64) *types.Transaction {
+		tx := newTx(addr1, nonce, 1)
+		tx.Type = types.DynamicFeeTx
+		tx.GasPrice = nil
+		tx.GasFeeCap = new(big.Int).SetUint64(gasFeeCap)
+		tx.GasTipCap = new(big.Int).SetUint64(gasTipCap)
+		tx.ChainID = big.NewInt(100)
+
+		return tx.ComputeHash(0)
+	}
+
+	setupPool := func(t *testing.T, oldTx *types.Transaction) (*TxPool, *subscribeResult) {
+		t.Helper()
+
+		pool, err := newTestPool()
+		require.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+
+		require.NoError(t, pool.addTx(local, oldTx))
+		<-pool.promoteReqCh
+
+		sub := pool.eventManager.subscribe([]proto.EventType{proto.EventType_DROPPED})
+		t.Cleanup(func() {
+			pool.eventManager.cancelSubscription(sub.subscriptionID)
+		})
+
+		return pool, sub
+	}
+
+	assertReplaced := func(t *testing.T, pool *TxPool, sub *subscribeResult, oldTx, tx *types.Transaction) {
+		t.Helper()
+
+		<-pool.promoteReqCh
+
+		_, exists := pool.index.get(oldTx.Hash)
+		assert.False(t, exists)
+
+		_, exists = pool.index.get(tx.Hash)
+		assert.True(t, exists)
+
+		assert.Equal(t, tx, pool.accounts.get(addr1).nonceToTx.get(0))
+
+		select {
+		case event := <-sub.subscriptionChannel:
+			assert.Equal(t, proto.EventType_DROPPED, event.Type)
+			assert.Equal(t, oldTx.Hash.String(), event.TxHash)
+			assert.Equal(t, droppedReasonReplaced, event.Reason)
+		case <-time.After(5 * time.Second):
+			t.Fatal("no DROPPED event for the replaced tx")
+		}
+	}
+
+	t.Run("under bump rejected", func(t *testing.T) {
+		oldTx := newDynamicTx(0, 1000, 100)
+		pool, _ := setupPool(t, oldTx)
+
+		// the fee cap is bumped enough, the tip cap isn't
+		assert.ErrorIs(t, pool.addTx(local, newDynamicTx(0, 1100, 109)), ErrReplacementUnderpriced)
+
+		// the tip cap is bumped enough, the fee cap isn't
+		assert.ErrorIs(t, pool.addTx(local, newDynamicTx(0, 1099, 110)), ErrReplacementUnderpriced)
+
+		// a legacy tx uses its gas price as both caps
+		assert.ErrorIs(t, pool.addTx(local, newLegacyTx(0, 1099)), ErrReplacementUnderpriced)
+
+		_, exists := pool.index.get(oldTx.Hash)
+		assert.True(t, exists)
+	})
+
+	t.Run("exact bump accepted", func(t *testing.T) {
+		oldTx := newDynamicTx(0, 1000, 100)
+		pool, sub := setupPool(t, oldTx)
+
+		tx := newDynamicTx(0, 1100, 110)
+		require.NoError(t, pool.addTx(local, tx))
+
+		assertReplaced(t, pool, sub, oldTx, tx)
+	})
+
+	t.Run("legacy tx replaces dynamic fee tx", func(t *testing.T) {
+		oldTx := newDynamicTx(0, 1000, 100)
+		pool, sub := setupPool(t, oldTx)
+
+		tx := newLegacyTx(0, 1100)
+		require.NoError(t, pool.addTx(local, tx))
+
+		assertReplaced(t, pool, sub, oldTx, tx)
+	})
+
+	t.Run("configured bump", func(t *testing.T) {
+		oldTx := newLegacyTx(0, 1000)
+		pool, _ := setupPool(t, oldTx)
+		pool.priceBump = 50
+
+		assert.ErrorIs(t, pool.addTx(local, newLegacyTx(0, 1499)), ErrReplacementUnderpriced)
+		assert.NoError(t, pool.addTx(local, newLegacyTx(0, 1500)))
+	})
+}
+
+func TestAddTx_UnderpricedBaseFeeSpike(t *testing.T) {
+	t.Parallel()
+
+	forkManagerMu.Lock()
+	defer forkManagerMu.Unlock()
+
+	fm := forkmanager.GetInstance()
+	fm.Clear()
+	fm.RegisterFork(chain.TxHashWithType, nil)
+	require.NoError(t, fm.ActivateFork(chain.TxHashWithType, 0))
+	defer fm.Clear()
+
+	const lifetime = 5
+
+	newDynamicTx := func(addr types.Address, gasFeeCap, gasTipCap uint64) *types.Transaction {
+		tx := newTx(addr, 0, 1)
+		tx.Type = types.DynamicFeeTx
+		tx.GasPrice = nil
+		tx.GasFeeCap = new(big.Int).SetUint64(gasFeeCap)
+		tx.GasTipCap = new(big.Int).SetUint64(gasTipCap)
+		tx.ChainID = big.NewInt(100)
+
+		return tx.ComputeHash(0)
+	}
+
+	// the base fee spikes to 1000
+	header := mockHeader.Copy()
+	header.BaseFee = 1000
+
+	pool, err := newTestPool(NewDefaultMockStore(header))
+	require.NoError(t, err)
+
+	pool.underpricedTxLifetime = lifetime
+	pool.SetBaseFee(header)
+	pool.SetSigner(&mockSigner{})
+
+	var (
+		txA = newDynamicTx(addr1, 300, 10)
+		txB = newDynamicTx(addr2, 200, 10)
+		txC = newDynamicTx(addr3, 150, 10)
+		txD = newDynamicTx(addr4, 200, 10) // same fees as txB, arrives later
+		txE = newDynamicTx(addr5, 90, 10)
+	)
+
+	for _, tx := range []*types.Transaction{txC, txB, txA, txD, txE} {
+		require.NoError(t, pool.addTx(local, tx))
+	}
+
+	// parked txs are not in the accounts, but are found as pending
+	assert.Equal(t, 5, pool.underpriced.length())
+	assert.Equal(t, uint64(5), pool.gauge.read())
+	assert.Nil(t, pool.accounts.get(addr1))
+
+	for _, tx := range []*types.Transaction{txA, txB, txC, txD, txE} {
+		found, ok := pool.GetPendingTx(tx.Hash)
+		require.True(t, ok)
+		assert.Equal(t, tx, found)
+	}
+
+	assert.ErrorIs(t, pool.addTx(local, txA), ErrAlreadyKnown)
+
+	// replacing a parked tx requires the price bump
+	assert.ErrorIs(t, pool.addTx(local, newDynamicTx(addr5, 95, 10)), ErrReplacementUnderpriced)
+
+	decay := func(number, baseFee uint64) []*types.Transaction {
+		h := header.Copy()
+		h.Number = number
+		h.BaseFee = baseFee
+
+		pool.SetBaseFee(h)
+
+		return pool.reevaluateUnderpriced(number)
+	}
+
+	// the base fee still covers no fee cap
+	assert.Empty(t, decay(1, 400))
+
+	// the base fee decays below the fee cap of txA only
+	assert.Equal(t, []*types.Transaction{txA}, decay(2, 250))
+
+	// txB and txD have the same fees and are promoted in arrival order
+	assert.Equal(t, []*types.Transaction{txB, txD, txC}, decay(3, 100))
+
+	for _, tx := range []*types.Transaction{txA, txB, txC, txD} {
+		_, ok := pool.index.get(tx.Hash)
+		assert.True(t, ok)
+	}
+
+	assert.Equal(t, 1, pool.underpriced.length())
+	assert.Equal(t, uint64(5), pool.gauge.read())
+
+	// txE expires after the lifetime
+	assert.Empty(t, decay(lifetime-1, 100))
+	assert.Equal(t, 1, pool.underpriced.length())
+
+	assert.Empty(t, decay(lifetime, 100))
+	assert.Equal(t, 0, pool.underpriced.length())
+	assert.Equal(t, uint64(4), pool.gauge.read())
+
+	_, ok := pool.GetPendingTx(txE.Hash)
+	assert.False(t, ok)
+
+	// with a zero lifetime the tx is rejected right away
+	pool.underpricedTxLifetime = 0
+
+	assert.ErrorIs(t, pool.addTx(local, newDynamicTx(addr5, 90, 10)), ErrUnderpriced)
+}
+
+func TestAddTx_MinBaseFee(t *testing.T) {
+	t.Parallel()
+
+	forkManagerMu.Lock()
+	defer forkManagerMu.Unlock()
+
+	fm := forkmanager.GetInstance()
+	fm.Clear()
+	fm.RegisterFork(chain.TxHashWithType, nil)
+	require.NoError(t, fm.ActivateFork(chain.TxHashWithType, 0))
+	defer fm.Clear()
+
+	newLegacyTx := func(addr types.Address, gasPrice uint64) *types.Transaction {
+		tx := newTx(addr, 0, 1)
+		tx.GasPrice = new(big.Int).SetUint64(gasPrice)
+
+		return tx.ComputeHash(0)
+	}
+
+	newDynamicTx := func(addr types.Address, gasFeeCap uint64) *types.Transaction {
+		tx := newTx(addr, 0, 1)
+		tx.Type = types.DynamicFeeTx
+		tx.GasPrice = nil
+		tx.GasFeeCap = new(big.Int).SetUint64(gasFeeCap)
+		tx.GasTipCap = big.NewInt(1)
+		tx.ChainID = big.NewInt(100)
+
+		return tx.ComputeHash(0)
+	}
+
+	// the min base fee is read from the difficulty of the head
+	setupPool := func(t *testing.T, minBaseFee, baseFee uint64) (*TxPool, *types.Header) {
+		t.Helper()
+
+		header := mockHeader.Copy()
+		header.BaseFee = baseFee
+
+		store := NewDefaultMockStore(header)
+		store.minBaseFeeFn = func(h *types.Header) uint64 {
+			return h.Difficulty
+		}
+
+		pool, err := newTestPool(store)
+		require.NoError(t, err)
+
+		header.Difficulty = minBaseFee
+
+		pool.SetBaseFee(header)
+		pool.SetSigner(&mockSigner{})
+
+		return pool, header
+	}
+
+	t.Run("registry absent or zero floor", func(t *testing.T) {
+		pool, _ := setupPool(t, 0, 10)
+
+		assert.NoError(t, pool.addTx(local, newLegacyTx(addr1, 10)))
+		assert.NoError(t, pool.addTx(local, newDynamicTx(addr2, 10)))
+	})
+
+	t.Run("floor", func(t *testing.T) {
+		pool, _ := setupPool(t, 500, 10)
+
+		assert.ErrorIs(t, pool.addTx(local, newLegacyTx(addr1, 499)), ErrBelowMinBaseFee)
+		assert.ErrorIs(t, pool.addTx(local, newDynamicTx(addr2, 499)), ErrBelowMinBaseFee)
+
+		assert.NoError(t, pool.addTx(local, newLegacyTx(addr3, 500)))
+		assert.NoError(t, pool.addTx(local, newDynamicTx(addr4, 500)))
+	})
+
+	t.Run("floor refreshed on new head", func(t *testing.T) {
+		pool, header := setupPool(t, 100, 1000)
+		pool.underpricedTxLifetime = DefaultUnderpricedTxLifetime
+
+		// parked, the fee cap is below the base fee but above the floor
+		parked := newDynamicTx(addr1, 300)
+		require.NoError(t, pool.addTx(local, parked))
+		assert.Equal(t, 1, pool.underpriced.length())
+
+		// the floor is raised above the fee cap of the parked tx
+		next := header.Copy()
+		next.Number = 1
+		next.Difficulty = 400
+
+		pool.ResetWithHeaders(next)
+
+		assert.Equal(t, uint64(400), pool.GetMinBaseFee())
+		assert.Equal(t, 0, pool.underpriced.length())
+		assert.Equal(t, uint64(0), pool.gauge.read())
+
+		assert.ErrorIs(t, pool.addTx(local, newLegacyTx(addr2, 399)), ErrBelowMinBaseFee)
+	})
+}
+
+func TestAddTx_StrictBridgeBlockList(t *testing.T) {
+	t.Parallel()
+
+	blocked := types.StringToAddress("0xb10c")
+	allowed := types.StringToAddress("0xa110")
+
+	setupPool := func(t *testing.T, strict bool) *TxPool {
+		t.Helper()
+
+		store := NewDefaultMockStore(mockHeader.Copy())
+		store.bridgeBlocksTransferFn = func(_ *types.Header, to types.Address) bool {
+			return to == blocked
+		}
+
+		pool, err := newTestPool(store)
+		require.NoError(t, err)
+
+		if strict {
+			pool.forks.SetFork(chain.StrictBridgeBlockList, chain.NewFork(0))
+		}
+
+		pool.SetSigner(&mockSigner{})
+
+		return pool
+	}
+
+	newTransfer := func(from, to types.Address, value int64) *types.Transaction {
+		tx := newTx(from, 0, 1)
+		tx.To = &to
+		tx.Value = big.NewInt(value)
+
+		return tx.ComputeHash(0)
+	}
+
+	t.Run("blocked recipient rejected in strict mode", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(t, true)
+
+		assert.ErrorIs(t, pool.addTx(local, newTransfer(addr1, blocked, 1)), runtime.ErrNotAuth)
+		assert.NoError(t, pool.addTx(local, newTransfer(addr2, allowed, 1)))
+		// calls without value are not transfers
+		assert.NoError(t, pool.addTx(local, newTransfer(addr3, blocked, 0)))
+	})
+
+	t.Run("blocked recipient accepted before the fork", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(t, false)
+
+		assert.NoError(t, pool.addTx(local, newTransfer(addr1, blocked, 1)))
+	})
+}
+
 // getDefaultEnabledForks returns hardcoded set of forks
 // that are enabled by default from the genesis block
 func getDefaultEnabledForks() *chain.Forks {
@@ -3831,6 +4320,7 @@ func getDefaultEnabledForks() *chain.Forks {
 		chain.Homestead: chain.NewFork(0),
 		chain.Istanbul:  chain.NewFork(0),
 		chain.London:    chain.NewFork(0),
+		chain.EIP3607:   chain.NewFork(0),
 	}
 }
 
@@ -3898,3 +4388,187 @@ func BenchmarkAddTxTime(b *testing.B) {
 		}
 	})
 }
+
+func TestAddTx_MaxAccountTxs(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	pool.SetSigner(&mockSigner{})
+	pool.maxAccountTxs = 3
+
+	pool.getOrCreateAccount(addr1).setNonce(1)
+
+	for nonce := uint64(1); nonce < 4; nonce++ {
+		require.NoError(t, pool.addTx(local, newTx(addr1, nonce, 1)))
+	}
+
+	// new nonces of the account are rejected
+	assert.ErrorIs(t, pool.addTx(local, newTx(addr1, 4, 1)), ErrMaxAccountTxsReached)
+
+	// a nonce below the account nonce is too low, not over the limit
+	assert.ErrorIs(t, pool.addTx(local, newTx(addr1, 0, 1)), ErrNonceTooLow)
+
+	// while replacements of the account and txs of other accounts are still accepted
+	replacement := newTx(addr1, 2, 1)
+	replacement.GasPrice = big.NewInt(2)
+
+	assert.NoError(t, pool.addTx(local, replacement))
+	assert.NoError(t, pool.addTx(local, newTx(addr2, 0, 1)))
+
+	assert.Equal(t, uint64(3), pool.accounts.get(addr1).enqueued.length())
+}
+
+func TestAddTx_EvictHeaviestSender(t *testing.T) {
+	t.Parallel()
+
+	const maxSlots = 10
+
+	pool, err := newTestPoolWithSlots(maxSlots)
+	require.NoError(t, err)
+	defer pool.Close()
+
+	pool.SetSigner(&mockSigner{})
+
+	sub := pool.eventManager.subscribe([]proto.EventType{proto.EventType_DROPPED})
+	defer pool.eventManager.cancelSubscription(sub.subscriptionID)
+
+	// addr1 floods the pool with future txs until the high pressure mark rejects them
+	flood := make(map[uint64]*types.Transaction)
+
+	for nonce := uint64(1); ; nonce++ {
+		tx := newTx(addr1, nonce, 1)
+		tx.GasPrice = big.NewInt(2)
+
+		// the lowest priced tx is not evicted first, that would leave a nonce gap
+		if nonce == 3 {
+			tx.GasPrice = big.NewInt(1)
+		}
+
+		if err := pool.addTx(local, tx); err != nil {
+			require.ErrorIs(t, err, ErrRejectFutureTx)
+
+			break
+		}
+
+		flood[nonce] = tx
+	}
+
+	require.Len(t, flood, maxSlots-1)
+
+	// addr2 txs keep flowing, evicting the highest nonce flood txs
+	sent := make([]*types.Transaction, 5)
+
+	for nonce := range sent {
+		sent[nonce] = newTx(addr2, uint64(nonce), 1)
+
+		require.NoError(t, pool.addTx(local, sent[nonce]))
+		pool.handlePromoteRequest(promoteRequest{account: addr2})
+	}
+
+	evicted := []uint64{9, 8, 7, 6}
+
+	for _, nonce := range evicted {
+		select {
+		case ev := <-sub.subscriptionChannel:
+			assert.Equal(t, flood[nonce].Hash.String(), ev.TxHash)
+			assert.Equal(t, droppedReasonEvicted, ev.Reason)
+		case <-time.After(time.Second):
+			t.Fatalf("no eviction of flood tx %d", nonce)
+		}
+
+		_, ok := pool.index.get(flood[nonce].Hash)
+		assert.False(t, ok)
+	}
+
+	assert.Equal(t, uint64(maxSlots), pool.gauge.read())
+	assert.Equal(t, uint64(5), pool.accounts.get(addr1).enqueued.length())
+	assert.Equal(t, uint64(5), pool.accounts.get(addr2).promoted.length())
+
+	// the flooding sender can not evict the txs of a lighter sender
+	assert.ErrorIs(t, pool.addTx(local, newTx(addr1, 0, 1)), ErrTxPoolOverflow)
+
+	status, err := pool.Status(context.Background(), nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(5), status.Length)
+	assert.Equal(t, defaultMaxAccountEnqueued, status.MaxAccountEnqueued)
+	require.Len(t, status.Accounts, 2)
+	assert.Equal(t, addr1.String(), status.Accounts[0].Address)
+	assert.Equal(t, uint64(5), status.Accounts[0].Enqueued)
+	assert.Equal(t, addr2.String(), status.Accounts[1].Address)
+	assert.Equal(t, uint64(5), status.Accounts[1].Promoted)
+
+	// rejected txs of addr2 don't evict anything
+	underpriced := newTx(addr2, 4, 1)
+	underpriced.GasPrice = new(big.Int).Set(sent[4].GasPrice)
+
+	assert.ErrorIs(t, pool.addTx(local, sent[4].Copy()), ErrAlreadyKnown)
+	assert.ErrorIs(t, pool.addTx(local, underpriced), ErrReplacementUnderpriced)
+	assert.Equal(t, uint64(5), pool.accounts.get(addr1).enqueued.length())
+
+	// once the nonce gap below them is filled, the remaining flood txs are promoted
+	pool.accounts.get(addr1).setNonce(1)
+	pool.handlePromoteRequest(promoteRequest{account: addr1})
+
+	assert.Equal(t, uint64(5), pool.accounts.get(addr1).promoted.length())
+	assert.Equal(t, uint64(0), pool.accounts.get(addr1).enqueued.length())
+}
+
+func TestSubscribePendingTxs(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	pool.SetSigner(&mockSigner{})
+
+	pendingCh, unsubscribe := pool.SubscribePendingTxs()
+
+	localTx := newTx(addr1, 0, 1)
+	gossipTx := newTx(addr2, 0, 1)
+
+	require.NoError(t, pool.addTx(local, localTx))
+	require.NoError(t, pool.addTx(gossip, gossipTx))
+
+	// rejected txs are not delivered
+	require.ErrorIs(t, pool.addTx(local, localTx.Copy()), ErrAlreadyKnown)
+
+	pending := <-pendingCh
+	assert.Equal(t, localTx.Hash, pending.Tx.Hash)
+	assert.True(t, pending.Local)
+
+	pending = <-pendingCh
+	assert.Equal(t, gossipTx.Hash, pending.Tx.Hash)
+	assert.False(t, pending.Local)
+
+	assert.Len(t, pendingCh, 0)
+
+	unsubscribe()
+
+	_, ok := <-pendingCh
+	assert.False(t, ok)
+}
+
+func TestPendingTxFeed_FullBuffer(t *testing.T) {
+	t.Parallel()
+
+	feed := newPendingTxFeed()
+	defer feed.close()
+
+	_, slowCh := feed.subscribe()
+	_, fastCh := feed.subscribe()
+
+	// the feed doesn't wait for a subscriber with a full buffer
+	for nonce := uint64(0); nonce <= pendingTxFeedBuffer; nonce++ {
+		feed.send(gossip, newTx(addr1, nonce, 1))
+
+		<-fastCh
+	}
+
+	assert.Len(t, slowCh, pendingTxFeedBuffer)
+	assert.Len(t, fastCh, 0)
+}
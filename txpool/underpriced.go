@@ -0,0 +1,182 @@
+package txpool
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/xgr-network/xgr-node/types"
+)
+
+// underpricedTx is a dynamic fee transaction parked because its fee cap was below the base fee
+type underpricedTx struct {
+	tx     *types.Transaction
+	origin txOrigin
+
+	// parkedAt is the number of the head the transaction was parked at
+	parkedAt uint64
+
+	// addedAt is the time the transaction was parked at
+	addedAt time.Time
+
+	// seq orders transactions with the same fees by arrival
+	seq uint64
+}
+
+// underpricedQueue holds the parked dynamic fee transactions,
+// at most one per sender and nonce. [thread-safe]
+type underpricedQueue struct {
+	sync.RWMutex
+
+	byHash  map[types.Hash]*underpricedTx
+	byNonce map[types.Address]map[uint64]*underpricedTx
+	seq     uint64
+}
+
+func newUnderpricedQueue() *underpricedQueue {
+	return &underpricedQueue{
+		byHash:  make(map[types.Hash]*underpricedTx),
+		byNonce: make(map[types.Address]map[uint64]*underpricedTx),
+	}
+}
+
+// add parks the transaction and returns the one it replaced (if any)
+func (q *underpricedQueue) add(tx *types.Transaction, origin txOrigin, parkedAt uint64) *types.Transaction {
+	q.Lock()
+	defer q.Unlock()
+
+	var replaced *types.Transaction
+
+	if old := q.getByNonceLocked(tx.From, tx.Nonce); old != nil {
+		replaced = old.tx
+		q.removeLocked(old.tx)
+	}
+
+	q.seq++
+
+	utx := &underpricedTx{tx: tx, origin: origin, parkedAt: parkedAt, addedAt: time.Now(), seq: q.seq}
+
+	q.byHash[tx.Hash] = utx
+
+	if _, ok := q.byNonce[tx.From]; !ok {
+		q.byNonce[tx.From] = make(map[uint64]*underpricedTx)
+	}
+
+	q.byNonce[tx.From][tx.Nonce] = utx
+
+	return replaced
+}
+
+// get returns the parked transaction with the given hash
+func (q *underpricedQueue) get(hash types.Hash) (*types.Transaction, bool) {
+	q.RLock()
+	defer q.RUnlock()
+
+	utx, ok := q.byHash[hash]
+	if !ok {
+		return nil, false
+	}
+
+	return utx.tx, true
+}
+
+// getByNonce returns the parked transaction of the sender with the given nonce
+func (q *underpricedQueue) getByNonce(from types.Address, nonce uint64) *types.Transaction {
+	q.RLock()
+	defer q.RUnlock()
+
+	if utx := q.getByNonceLocked(from, nonce); utx != nil {
+		return utx.tx
+	}
+
+	return nil
+}
+
+func (q *underpricedQueue) getByNonceLocked(from types.Address, nonce uint64) *underpricedTx {
+	return q.byNonce[from][nonce]
+}
+
+// remove removes the given transactions, returns false if none of them was parked
+func (q *underpricedQueue) remove(txs ...*types.Transaction) bool {
+	q.Lock()
+	defer q.Unlock()
+
+	removed := false
+
+	for _, tx := range txs {
+		if q.removeLocked(tx) {
+			removed = true
+		}
+	}
+
+	return removed
+}
+
+func (q *underpricedQueue) removeLocked(tx *types.Transaction) bool {
+	utx, ok := q.byHash[tx.Hash]
+	if !ok {
+		return false
+	}
+
+	delete(q.byHash, tx.Hash)
+
+	if nonces := q.byNonce[utx.tx.From]; nonces != nil {
+		delete(nonces, utx.tx.Nonce)
+
+		if len(nonces) == 0 {
+			delete(q.byNonce, utx.tx.From)
+		}
+	}
+
+	return true
+}
+
+// length returns the number of parked transactions
+func (q *underpricedQueue) length() int {
+	q.RLock()
+	defer q.RUnlock()
+
+	return len(q.byHash)
+}
+
+// sorted returns the parked transactions by descending fee cap, then descending tip cap,
+// then arrival, so re-evaluation is deterministic
+func (q *underpricedQueue) sorted() []*underpricedTx {
+	q.RLock()
+	defer q.RUnlock()
+
+	txs := make([]*underpricedTx, 0, len(q.byHash))
+	for _, utx := range q.byHash {
+		txs = append(txs, utx)
+	}
+
+	sort.Slice(txs, func(i, j int) bool {
+		if c := txs[i].tx.GasFeeCap.Cmp(txs[j].tx.GasFeeCap); c != 0 {
+			return c > 0
+		}
+
+		if c := txs[i].tx.GasTipCap.Cmp(txs[j].tx.GasTipCap); c != 0 {
+			return c > 0
+		}
+
+		return txs[i].seq < txs[j].seq
+	})
+
+	return txs
+}
+
+// bySender returns the parked transactions of every sender
+func (q *underpricedQueue) bySender() map[types.Address][]*underpricedTx {
+	q.RLock()
+	defer q.RUnlock()
+
+	txs := make(map[types.Address][]*underpricedTx, len(q.byNonce))
+
+	for from, nonces := range q.byNonce {
+		for _, utx := range nonces {
+			txs[from] = append(txs[from], utx)
+		}
+	}
+
+	return txs
+}
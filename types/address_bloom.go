@@ -0,0 +1,81 @@
+package types
+
+import (
+	"github.com/xgr-network/xgr-node/helper/hex"
+	"github.com/xgr-network/xgr-node/helper/keccak"
+)
+
+const (
+	// AddressBloomByteLength is sized for ~2000 addresses per block at a 1% false positive rate
+	// (m = -n*ln(p)/ln(2)^2 ~= 19171 bits, rounded up to 19200 bits)
+	AddressBloomByteLength = 2400
+
+	// addressBloomHashes is the optimal number of bit positions per address (k = m/n*ln(2) ~= 7)
+	addressBloomHashes = 7
+
+	addressBloomBits = AddressBloomByteLength * 8
+)
+
+// AddressBloom is a per-block bloom filter over all addresses that were active in the block
+// (tx senders, recipients and accounts touched by the state transition)
+type AddressBloom [AddressBloomByteLength]byte
+
+func (b AddressBloom) String() string {
+	return hex.EncodeToHex(b[:])
+}
+
+// Add adds the address to the bloom filter
+func (b *AddressBloom) Add(addr Address) {
+	hasher := keccak.DefaultKeccakPool.Get()
+	defer keccak.DefaultKeccakPool.Put(hasher)
+
+	b.add(hasher, addr)
+}
+
+// AddAll adds all the addresses to the bloom filter
+func (b *AddressBloom) AddAll(addrs []Address) {
+	hasher := keccak.DefaultKeccakPool.Get()
+	defer keccak.DefaultKeccakPool.Put(hasher)
+
+	for _, addr := range addrs {
+		b.add(hasher, addr)
+	}
+}
+
+// Test checks if the address is possibly present in the bloom filter
+func (b *AddressBloom) Test(addr Address) bool {
+	hasher := keccak.DefaultKeccakPool.Get()
+	defer keccak.DefaultKeccakPool.Put(hasher)
+
+	hasher.Reset()
+	hasher.Write(addr[:]) //nolint:errcheck
+	buf := hasher.Read()
+
+	for i := 0; i < addressBloomHashes; i++ {
+		bit := addressBloomBit(buf, i)
+
+		if b[bit/8]&(1<<(bit%8)) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (b *AddressBloom) add(hasher *keccak.Keccak, addr Address) {
+	hasher.Reset()
+	hasher.Write(addr[:]) //nolint:errcheck
+	buf := hasher.Read()
+
+	for i := 0; i < addressBloomHashes; i++ {
+		bit := addressBloomBit(buf, i)
+		b[bit/8] |= 1 << (bit % 8)
+	}
+}
+
+// addressBloomBit derives the i-th bit location from 3 bytes of the address hash
+func addressBloomBit(buf []byte, i int) uint {
+	v := uint(buf[3*i])<<16 | uint(buf[3*i+1])<<8 | uint(buf[3*i+2])
+
+	return v % addressBloomBits
+}
@@ -0,0 +1,77 @@
+package types
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func randomAddress(r *rand.Rand) (addr Address) {
+	r.Read(addr[:])
+
+	return addr
+}
+
+func TestAddressBloom_AddTest(t *testing.T) {
+	t.Parallel()
+
+	var (
+		bloom AddressBloom
+		addr  = StringToAddress("0x1")
+	)
+
+	assert.False(t, bloom.Test(addr))
+
+	bloom.Add(addr)
+	assert.True(t, bloom.Test(addr))
+	assert.False(t, bloom.Test(StringToAddress("0x2")))
+}
+
+// TestAddressBloom_SyntheticTransferChain builds the blooms for a chain of plain transfers
+// at the sizing target (~2000 active addresses per block) and checks the accuracy
+func TestAddressBloom_SyntheticTransferChain(t *testing.T) {
+	t.Parallel()
+
+	const (
+		blocks            = 20
+		transfersPerBlock = 1000 // sender + recipient => 2000 addresses per block
+		probes            = 10_000
+	)
+
+	r := rand.New(rand.NewSource(1)) //nolint:gosec
+
+	blooms := make([]AddressBloom, blocks)
+	participants := make([][]Address, blocks)
+
+	for i := 0; i < blocks; i++ {
+		for j := 0; j < transfersPerBlock; j++ {
+			from, to := randomAddress(r), randomAddress(r)
+			participants[i] = append(participants[i], from, to)
+		}
+
+		blooms[i].AddAll(participants[i])
+	}
+
+	// no false negatives: every sender and recipient must be found in its block
+	for i := 0; i < blocks; i++ {
+		for _, addr := range participants[i] {
+			require.True(t, blooms[i].Test(addr))
+		}
+	}
+
+	// false positives stay around the 1% target for addresses that never transacted
+	falsePositives := 0
+
+	for i := 0; i < blocks; i++ {
+		for j := 0; j < probes; j++ {
+			if blooms[i].Test(randomAddress(r)) {
+				falsePositives++
+			}
+		}
+	}
+
+	rate := float64(falsePositives) / float64(blocks*probes)
+	assert.Less(t, rate, 0.015, "false positive rate %f", rate)
+}
@@ -0,0 +1,20 @@
+package types
+
+import "math/big"
+
+// BlockEconomics aggregates the fee split of all transactions in a block
+type BlockEconomics struct {
+	TotalBurned        *big.Int
+	TotalDonated       *big.Int
+	TotalValidatorFees *big.Int
+	EngineTxCount      uint64
+}
+
+// NewBlockEconomics returns empty block economics
+func NewBlockEconomics() *BlockEconomics {
+	return &BlockEconomics{
+		TotalBurned:        new(big.Int),
+		TotalDonated:       new(big.Int),
+		TotalValidatorFees: new(big.Int),
+	}
+}
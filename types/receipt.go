@@ -2,6 +2,7 @@ package types
 
 import (
 	goHex "encoding/hex"
+	"math/big"
 	"strings"
 
 	"github.com/xgr-network/xgr-node/helper/hex"
@@ -31,6 +32,22 @@ type Receipt struct {
 	TxHash          Hash
 
 	TransactionType TxType
+
+	// FeeSplit is the split of the transaction fee, it is not part of the consensus encoding
+	FeeSplit *FeeSplitInfo
+
+	// From, To and EffectiveGasPrice describe the transaction of a receipt created by the executor,
+	// they are neither part of the consensus nor of the storage encoding
+	From              Address
+	To                *Address
+	EffectiveGasPrice *big.Int
+}
+
+// FeeSplitInfo is the split of a transaction fee between the donation, the validator and the burn
+type FeeSplitInfo struct {
+	Donation  *big.Int
+	Validator *big.Int
+	Burned    *big.Int
 }
 
 func (r *Receipt) IsLegacyTx() bool {
@@ -129,6 +146,22 @@ func (b *Bloom) IsLogInBloom(log *Log) bool {
 	return true
 }
 
+// IsAddressInBloom checks if a log of the address has a possible presence in the bloom filter
+func (b *Bloom) IsAddressInBloom(addr Address) bool {
+	hasher := keccak.DefaultKeccakPool.Get()
+	defer keccak.DefaultKeccakPool.Put(hasher)
+
+	return b.isByteArrPresent(hasher, addr.Bytes())
+}
+
+// IsTopicInBloom checks if a log with the topic has a possible presence in the bloom filter
+func (b *Bloom) IsTopicInBloom(topic Hash) bool {
+	hasher := keccak.DefaultKeccakPool.Get()
+	defer keccak.DefaultKeccakPool.Put(hasher)
+
+	return b.isByteArrPresent(hasher, topic.Bytes())
+}
+
 // isByteArrPresent checks if the byte array is possibly present in the Bloom filter
 func (b *Bloom) isByteArrPresent(hasher *keccak.Keccak, data []byte) bool {
 	hasher.Reset()
@@ -99,6 +99,46 @@ func TestRLPStorage_Marshall_And_Unmarshall_Receipt(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"Marshal typed receipt",
+			&Receipt{
+				CumulativeGasUsed: 10,
+				GasUsed:           100,
+				TxHash:            hash,
+				TransactionType:   DynamicFeeTx,
+			},
+			true,
+		},
+		{
+			"Marshal receipt with fee split",
+			&Receipt{
+				CumulativeGasUsed: 10,
+				GasUsed:           100,
+				ContractAddress:   &addr,
+				TxHash:            hash,
+				FeeSplit: &FeeSplitInfo{
+					Donation:  big.NewInt(15),
+					Validator: big.NewInt(85),
+					Burned:    big.NewInt(1000),
+				},
+			},
+			true,
+		},
+		{
+			"Marshal typed receipt with fee split",
+			&Receipt{
+				CumulativeGasUsed: 10,
+				GasUsed:           100,
+				TxHash:            hash,
+				TransactionType:   DynamicFeeTx,
+				FeeSplit: &FeeSplitInfo{
+					Donation:  big.NewInt(15),
+					Validator: big.NewInt(85),
+					Burned:    big.NewInt(1000),
+				},
+			},
+			true,
+		},
 	}
 
 	for _, testCase := range testTable {
@@ -368,6 +408,24 @@ func testRLPData(arena *fastrlp.Arena, omitValues map[string]bool) []byte {
 	return testData
 }
 
+func TestRLPUnmarshal_Block_MissingTypedTransactionData(t *testing.T) {
+	t.Parallel()
+
+	// the transactions end with the type of a typed transaction
+	ar := &fastrlp.Arena{}
+
+	txs := ar.NewArray()
+	txs.Set(ar.NewBytes([]byte{byte(DynamicFeeTx)}))
+
+	v := ar.NewArray()
+	v.Set((&Header{}).MarshalRLPWith(ar))
+	v.Set(txs)
+	v.Set(ar.NewArray())
+
+	block := &Block{}
+	require.ErrorContains(t, block.UnmarshalRLP(v.MarshalTo(nil)), "missing data of the DynamicFeeTx transaction")
+}
+
 func Test_MarshalCorruptedBytesArray(t *testing.T) {
 	t.Parallel()
 
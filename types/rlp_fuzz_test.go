@@ -0,0 +1,140 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fuzzSeedTransactions returns a transaction of each type
+func fuzzSeedTransactions() []*Transaction {
+	to := StringToAddress("0x11")
+
+	legacyTx := &Transaction{
+		Type:     LegacyTx,
+		Nonce:    1,
+		GasPrice: big.NewInt(10),
+		Gas:      21000,
+		To:       &to,
+		Value:    big.NewInt(1),
+		Input:    []byte{0x1, 0x2},
+		V:        big.NewInt(27),
+		R:        big.NewInt(1),
+		S:        big.NewInt(2),
+	}
+
+	accessListTx := legacyTx.Copy()
+	accessListTx.Type = AccessListTx
+	accessListTx.ChainID = big.NewInt(100)
+	accessListTx.AccessList = AccessList{{
+		Address:     to,
+		StorageKeys: []Hash{StringToHash("0x1")},
+	}}
+
+	dynamicFeeTx := accessListTx.Copy()
+	dynamicFeeTx.Type = DynamicFeeTx
+	dynamicFeeTx.GasPrice = nil
+	dynamicFeeTx.GasTipCap = big.NewInt(1)
+	dynamicFeeTx.GasFeeCap = big.NewInt(20)
+
+	stateTx := legacyTx.Copy()
+	stateTx.Type = StateTx
+	stateTx.From = StringToAddress("0xffffffffffffffffffffffffffffffffffffffff")
+	stateTx.To = nil
+
+	return []*Transaction{legacyTx, accessListTx, dynamicFeeTx, stateTx}
+}
+
+func fuzzSeedHeader() *Header {
+	return &Header{
+		ParentHash:   StringToHash("0x1"),
+		Sha3Uncles:   EmptyUncleHash,
+		Miner:        StringToAddress("0x2").Bytes(),
+		StateRoot:    StringToHash("0x3"),
+		TxRoot:       EmptyRootHash,
+		ReceiptsRoot: EmptyRootHash,
+		Difficulty:   1,
+		Number:       10,
+		GasLimit:     30_000_000,
+		GasUsed:      21000,
+		Timestamp:    1_700_000_000,
+		ExtraData:    make([]byte, 32),
+		MixHash:      StringToHash("0x4"),
+		BaseFee:      1000,
+	}
+}
+
+func FuzzHeaderUnmarshalRLP(f *testing.F) {
+	header := fuzzSeedHeader()
+
+	f.Add(header.MarshalRLP())
+
+	header.BaseFee = 0
+	f.Add(header.MarshalRLP())
+
+	f.Add([]byte{})
+	f.Add([]byte{0xc0})
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		h := &Header{}
+		if err := h.UnmarshalRLP(input); err != nil {
+			return
+		}
+
+		// a decoded header encodes and decodes to the same header
+		decoded := &Header{}
+		require.NoError(t, decoded.UnmarshalRLP(h.MarshalRLP()))
+		require.Equal(t, h.Hash, decoded.Hash)
+	})
+}
+
+func FuzzBlockUnmarshalRLP(f *testing.F) {
+	block := &Block{
+		Header:       fuzzSeedHeader(),
+		Transactions: fuzzSeedTransactions(),
+	}
+
+	f.Add(block.MarshalRLP())
+
+	block.Uncles = []*Header{fuzzSeedHeader()}
+	f.Add(block.MarshalRLP())
+
+	f.Add((&Block{Header: fuzzSeedHeader()}).MarshalRLP())
+	f.Add([]byte{})
+	f.Add([]byte{0xc3, 0xc0, 0xc0, 0xc0})
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		b := &Block{}
+		if err := b.UnmarshalRLP(input); err != nil {
+			return
+		}
+
+		// a decoded block encodes and decodes to the same block
+		decoded := &Block{}
+		require.NoError(t, decoded.UnmarshalRLP(b.MarshalRLP()))
+		require.Equal(t, b.Hash(), decoded.Hash())
+		require.Len(t, decoded.Transactions, len(b.Transactions))
+	})
+}
+
+func FuzzTransactionUnmarshalRLP(f *testing.F) {
+	for _, tx := range fuzzSeedTransactions() {
+		f.Add(tx.MarshalRLP())
+	}
+
+	f.Add([]byte{})
+	f.Add([]byte{byte(DynamicFeeTx)})
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		tx := &Transaction{}
+		if err := tx.UnmarshalRLP(input); err != nil {
+			return
+		}
+
+		// a decoded transaction encodes and decodes to the same transaction
+		decoded := &Transaction{}
+		require.NoError(t, decoded.UnmarshalRLP(tx.MarshalRLP()))
+		require.Equal(t, tx.ComputeHash(1).Hash, decoded.ComputeHash(1).Hash)
+	})
+}
@@ -95,5 +95,15 @@ func (r *Receipt) marshalStoreRLPWith(a *fastrlp.Arena) *fastrlp.Value {
 	// TxHash
 	vv.Set(a.NewBytes(r.TxHash.Bytes()))
 
+	// fee split, optional trailing element
+	if r.FeeSplit != nil {
+		feeSplit := a.NewArray()
+		feeSplit.Set(a.NewBigInt(r.FeeSplit.Donation))
+		feeSplit.Set(a.NewBigInt(r.FeeSplit.Validator))
+		feeSplit.Set(a.NewBigInt(r.FeeSplit.Burned))
+
+		vv.Set(feeSplit)
+	}
+
 	return vv
 }
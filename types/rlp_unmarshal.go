@@ -111,6 +111,10 @@ func unmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value, cb unmarshalRLPFromFu
 
 			// Then we increment element number in order to go to the actual tx data raw below.
 			i++
+
+			if i == len(elems) {
+				return fmt.Errorf("missing data of the %s transaction", txType)
+			}
 		}
 
 		if err = cb(txType, p, elems[i]); err != nil {
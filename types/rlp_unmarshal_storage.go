@@ -3,6 +3,7 @@ package types
 import (
 	"errors"
 	"fmt"
+	"math/big"
 
 	"github.com/umbracle/fastrlp"
 )
@@ -151,8 +152,8 @@ func (r *Receipt) unmarshalStoreRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) err
 		return errors.New("expected at least 4 elements")
 	}
 
-	// come TransactionType first if exist
-	if len(elems) == 5 {
+	// come TransactionType first if exist, the receipt itself is a list
+	if elems[0].Type() == fastrlp.TypeBytes {
 		if err = r.TransactionType.unmarshalRLPFrom(p, elems[0]); err != nil {
 			return err
 		}
@@ -182,7 +183,7 @@ func (r *Receipt) unmarshalStoreRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) err
 
 	// tx hash
 	// backwards compatibility, old receipts did not marshal a TxHash
-	if len(elems) == 4 {
+	if len(elems) >= 4 {
 		vv, err = elems[3].Bytes()
 		if err != nil {
 			return err
@@ -191,5 +192,38 @@ func (r *Receipt) unmarshalStoreRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) err
 		r.TxHash = BytesToHash(vv)
 	}
 
+	// fee split
+	// backwards compatibility, old receipts did not marshal a fee split
+	if len(elems) >= 5 {
+		if r.FeeSplit, err = unmarshalFeeSplitFrom(elems[4]); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
+
+func unmarshalFeeSplitFrom(v *fastrlp.Value) (*FeeSplitInfo, error) {
+	elems, err := v.GetElems()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(elems) != 3 {
+		return nil, fmt.Errorf("incorrect number of elements to decode fee split, expected 3 but found %d", len(elems))
+	}
+
+	feeSplit := &FeeSplitInfo{
+		Donation:  new(big.Int),
+		Validator: new(big.Int),
+		Burned:    new(big.Int),
+	}
+
+	for i, b := range []*big.Int{feeSplit.Donation, feeSplit.Validator, feeSplit.Burned} {
+		if err = elems[i].GetBigInt(b); err != nil {
+			return nil, err
+		}
+	}
+
+	return feeSplit, nil
+}
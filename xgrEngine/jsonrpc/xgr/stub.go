@@ -28,6 +28,7 @@ func LoadEngineConfigFlex(any) (string, []byte, error) {
 func (x *XGR) GetPublicSale(context.Context) (any, error)       { return nil, errEngineUnavailable }
 func (x *XGR) GetCoreAddrs(context.Context) (any, error)        { return nil, errEngineUnavailable }
 func (x *XGR) GetNextProcessId(any) (any, error)                { return nil, errEngineUnavailable }
+func (x *XGR) GetNextProcessIds(any) (any, error)               { return nil, errEngineUnavailable }
 func (x *XGR) ValidateDataTransfer(any) (any, error)            { return nil, errEngineUnavailable }
 func (x *XGR) GetCirculatingSupply(any) (any, error)            { return nil, errEngineUnavailable }
 func (x *XGR) EstimateRuleGas(any) (any, error)                 { return nil, errEngineUnavailable }
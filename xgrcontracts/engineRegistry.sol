@@ -28,6 +28,8 @@ contract EngineRegistry {
     //   slot 7: __reserved0 (uint256)  <-- forces next vars onto fresh slots (no packing with bool)
     //   slot 8: donationAddress
     //   slot 9: donationPercent    
+    //   slot 10: requireGrantChainId (bool, offset 0)
+    //   slot 10: refundOnFailurePolicy (bool, offset 1, packed with requireGrantChainId)
     /// @notice Admin address (should be multisig or governance contract)
     address public admin;
     
@@ -57,6 +59,12 @@ contract EngineRegistry {
 
     /// @notice Donation fee percent in [0..100]. (0 disables donation)
     uint256 public donationPercent;
+
+    /// @notice Reject engine grants without a chainId (grants for another chain are always rejected)
+    bool public requireGrantChainId;
+
+    /// @notice Refund only validation and EVM base units to the engine if the inner call of an execute fails
+    bool public refundOnFailurePolicy;
     
     // =========================================================================
     // Constants
@@ -79,6 +87,8 @@ contract EngineRegistry {
     event EngineRemoved(address indexed engine, address indexed removedBy);
     event MinBaseFeeUpdated(uint256 oldFee, uint256 newFee, address indexed updatedBy);
     event DonationConfigUpdated(address indexed donationAddress, uint256 donationPercent, address indexed updatedBy);
+    event RequireGrantChainIdUpdated(bool required, address indexed updatedBy);
+    event RefundOnFailurePolicyUpdated(bool active, address indexed updatedBy);
     event AdminTransferInitiated(address indexed currentAdmin, address indexed pendingAdmin);
     event AdminTransferCompleted(address indexed oldAdmin, address indexed newAdmin);
     event Paused(address indexed by);
@@ -341,6 +351,26 @@ contract EngineRegistry {
         emit DonationConfigUpdated(addr, percent, msg.sender);
     }
 
+    /**
+     * @notice Make the grant chainId mandatory
+     * @param required If true, engine grants with a zero chainId are rejected
+     */
+    function setRequireGrantChainId(bool required) external onlyAdmin {
+        requireGrantChainId = required;
+
+        emit RequireGrantChainIdUpdated(required, msg.sender);
+    }
+
+    /**
+     * @notice Set the refund policy for executes whose inner call failed
+     * @param active If true, the execLimit of a failed inner call stays with the user instead of being billed
+     */
+    function setRefundOnFailurePolicy(bool active) external onlyAdmin {
+        refundOnFailurePolicy = active;
+
+        emit RefundOnFailurePolicyUpdated(active, msg.sender);
+    }
+
     // =========================================================================
     // Admin Functions - Access Control
     // =========================================================================